@@ -3,6 +3,7 @@ module github.com/rocket-pool/smartnode
 go 1.13
 
 require (
+	github.com/aws/aws-sdk-go v1.34.0
 	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/btcsuite/btcutil v1.0.2
 	github.com/docker/distribution v2.7.1+incompatible // indirect
@@ -16,6 +17,7 @@ require (
 	github.com/imdario/mergo v0.3.9
 	github.com/opencontainers/go-digest v1.0.0 // indirect
 	github.com/opencontainers/image-spec v1.0.1 // indirect
+	github.com/pkg/sftp v1.12.0
 	github.com/prysmaticlabs/ethereumapis v0.0.0-20200729044127-8027cc96e2c0
 	github.com/prysmaticlabs/go-ssz v0.0.0-20200612203617-6d5c9aa213ae
 	github.com/rocket-pool/rocketpool-go v0.0.0-20200813050037-c430afba9ad3
@@ -24,7 +26,7 @@ require (
 	github.com/wealdtech/go-eth2-types/v2 v2.5.0
 	github.com/wealdtech/go-eth2-util v1.5.0
 	github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4 v1.1.0
-	golang.org/x/crypto v0.0.0-20200709230013-948cd5f35899
+	golang.org/x/crypto v0.0.0-20200820211705-5c72a883971a
 	golang.org/x/sync v0.0.0-20190423024810-112230192c58
 	google.golang.org/grpc v1.29.1
 	gopkg.in/yaml.v2 v2.3.0
@@ -0,0 +1,27 @@
+package doctor
+
+import (
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Diagnose common smart node problems, with step-by-step remediation and fix commands which can be run interactively",
+        UsageText: "rocketpool doctor",
+        Action: func(c *cli.Context) error {
+
+            // Validate args
+            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+            // Run
+            return Run(c, true)
+
+        },
+    })
+}
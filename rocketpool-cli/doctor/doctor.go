@@ -0,0 +1,560 @@
+package doctor
+
+import (
+    "fmt"
+    "math"
+    "os"
+    "os/exec"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Settings
+const (
+    MinAvailableDiskSpace = 50 * 1024 * 1024 * 1024
+    WarnAvailableDiskSpace = 200 * 1024 * 1024 * 1024
+    MaxClockOffsetSeconds = 2
+    MaxBlockAgeSeconds = 300
+)
+
+
+// Health check result
+// Results for known failure signatures include remediation steps, and fix commands which can be run interactively
+type doctorResult struct {
+    Name string
+    Status string
+    Message string
+    Fix string
+    Steps []string
+    Commands []fixCommand
+}
+
+
+// Fix command
+type fixCommand struct {
+    Command string
+    Description string
+    Run func() error
+}
+
+
+// Wallet remediation steps
+var walletSteps = []string{
+    "If this is a new node, create a wallet with 'rocketpool wallet init' and record its mnemonic somewhere safe.",
+    "If you are moving an existing node to this machine, restore its wallet with 'rocketpool wallet recover' and its mnemonic instead.",
+    "Never run the same node wallet's validators on two machines at once, or they will be slashed.",
+}
+
+
+// Health check report
+type doctorReport struct {
+    results []doctorResult
+}
+
+
+// Add a result to the report
+func (r *doctorReport) add(name string, status string, message, fix string) {
+    r.results = append(r.results, doctorResult{
+        Name: name,
+        Status: status,
+        Message: message,
+        Fix: fix,
+    })
+}
+
+
+// Add remediation steps & fix commands to the last result in the report
+func (r *doctorReport) remedy(steps []string, commands ...fixCommand) {
+    if len(r.results) == 0 {
+        return
+    }
+    result := &r.results[len(r.results) - 1]
+    result.Steps = steps
+    result.Commands = commands
+}
+
+
+// Print the report
+func (r *doctorReport) print() {
+    counts := make(map[string]int)
+    for _, result := range r.results {
+        cliutils.PrintStatus(result.Status, fmt.Sprintf("%s: %s", result.Name, result.Message))
+        if result.Status != cliutils.StatusOK && result.Fix != "" {
+            fmt.Printf("    Suggested fix: %s\n", result.Fix)
+        }
+        counts[result.Status]++
+    }
+    fmt.Println("")
+    fmt.Printf("%d check(s) passed, %d warning(s), %d failure(s).\n", counts[cliutils.StatusOK], counts[cliutils.StatusWarn], counts[cliutils.StatusFail])
+}
+
+
+// Check the health of the Rocket Pool service & host
+// If guided, print remediation steps for each problem and offer to run its fix commands
+func Run(c *cli.Context, guided bool) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Report
+    report := &doctorReport{}
+
+    // Load config
+    cfg, err := rp.LoadMergedConfig()
+    if err != nil {
+        report.add("Configuration", cliutils.StatusFail, fmt.Sprintf("Could not load the smart node configuration: %s", err), "Run 'rocketpool service install' to install the smart node, or 'rocketpool service config' to configure it.")
+        report.print()
+        return nil
+    }
+
+    // Run host checks
+    fmt.Println("Running health checks...")
+    fmt.Println("")
+    apiRunning := false
+    if cfg.Smartnode.ServiceBackend == rocketpool.BackendSystemd {
+        apiRunning = checkUnits(rp, report)
+    } else if checkDocker(rp, report) {
+        apiRunning = checkContainers(rp, report)
+    }
+    checkDiskSpace(rp, cfg, report)
+    checkPorts(rp, cfg, report)
+    checkClockOffset(rp, report)
+
+    // Run node checks
+    if apiRunning {
+        checkClientSync(rp, report)
+        checkWalletAndRegistration(c, rp, report)
+        checkRemoteSigner(rp, report)
+    } else {
+        report.add("Node", cliutils.StatusWarn, "Client sync, wallet and registration checks were skipped as the smart node API container is not running.", "Start the smart node with 'rocketpool service start'.")
+    }
+
+    // Print report
+    report.print()
+
+    // Guide the user through remediation
+    if guided {
+        troubleshoot(report)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Print remediation steps for each problem and offer to run its fix commands
+func troubleshoot(report *doctorReport) {
+    fixed := false
+    for _, result := range report.results {
+        if result.Status == cliutils.StatusOK || len(result.Steps) == 0 {
+            continue
+        }
+
+        // Print steps
+        fmt.Println("")
+        fmt.Printf("%s: %s\n", result.Name, result.Message)
+        for si, step := range result.Steps {
+            fmt.Printf("    %d. %s\n", si + 1, step)
+        }
+
+        // Offer to run fix commands
+        for _, command := range result.Commands {
+            if !cliutils.Confirm(fmt.Sprintf("Run '%s' to %s?", command.Command, command.Description)) {
+                continue
+            }
+            if err := command.Run(); err != nil {
+                cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("'%s' failed: %s", command.Command, err))
+                continue
+            }
+            fixed = true
+        }
+
+    }
+    if fixed {
+        fmt.Println("")
+        fmt.Println("Run 'rocketpool doctor' again to check whether the problems were resolved.")
+    }
+}
+
+
+// Check that the Docker daemon, or podman, is available
+func checkDocker(rp *rocketpool.Client, report *doctorReport) bool {
+    version, err := rp.GetDockerVersion()
+    if runtime, runtimeErr := rp.GetContainerRuntime(); runtimeErr == nil && runtime.Name == rocketpool.RuntimePodman {
+        if err != nil {
+            socketCmd := "sudo systemctl enable --now podman.socket"
+            if runtime.Rootless {
+                socketCmd = "systemctl --user enable --now podman.socket"
+            }
+            report.add("Podman", cliutils.StatusFail, err.Error(), fmt.Sprintf("Make sure podman and podman-compose are installed, and that the podman API socket is enabled (e.g. '%s').", socketCmd))
+            return false
+        }
+        report.add("Podman", cliutils.StatusOK, fmt.Sprintf("Podman is available (version %s).", version), "")
+        return true
+    }
+    if err != nil {
+        report.add("Docker", cliutils.StatusFail, err.Error(), "Make sure Docker is installed and running (e.g. 'sudo systemctl start docker'), and that your user is in the 'docker' group.")
+        return false
+    }
+    report.add("Docker", cliutils.StatusOK, fmt.Sprintf("The Docker daemon is running (version %s).", version), "")
+    return true
+}
+
+
+// Get the name of the container runtime CLI used by the service, for fix commands
+func getRuntimeName(rp *rocketpool.Client) string {
+    if runtime, err := rp.GetContainerRuntime(); err == nil {
+        return runtime.Name
+    }
+    return rocketpool.RuntimeDocker
+}
+
+
+// Check the Rocket Pool service container states; returns whether the API container is running
+func checkContainers(rp *rocketpool.Client, report *doctorReport) bool {
+    states, err := rp.GetContainerStates()
+    if err != nil {
+        report.add("Containers", cliutils.StatusFail, err.Error(), "")
+        return false
+    }
+    if len(states) == 0 {
+        report.add("Containers", cliutils.StatusFail, "No Rocket Pool service containers were found.", "Start the smart node with 'rocketpool service start'.")
+        return false
+    }
+    names := make([]string, 0, len(states))
+    for name := range states {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    for _, name := range names {
+        state := states[name]
+        if state == "running" {
+            report.add("Containers", cliutils.StatusOK, fmt.Sprintf("%s is running.", name), "")
+        } else {
+            report.add("Containers", cliutils.StatusFail, fmt.Sprintf("%s is %s.", name, state), "Check the container's logs with 'rocketpool service logs', then restart it with 'rocketpool service start'.")
+        }
+    }
+    return (states[rocketpool.APIContainerName] == "running")
+}
+
+
+// Check the Rocket Pool service systemd unit states; returns whether the node daemon unit is running
+// API commands are run by the native daemon binary, which requires the same setup as the node daemon
+func checkUnits(rp *rocketpool.Client, report *doctorReport) bool {
+    states, err := rp.GetUnitStates()
+    if err != nil {
+        report.add("Services", cliutils.StatusFail, err.Error(), "")
+        return false
+    }
+    if len(states) == 0 {
+        report.add("Services", cliutils.StatusFail, "No Rocket Pool systemd units were found.", "Install the smart node with 'rocketpool service install --backend systemd'.")
+        return false
+    }
+    names := make([]string, 0, len(states))
+    for name := range states {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    for _, name := range names {
+        state := states[name]
+        if state == "running" {
+            report.add("Services", cliutils.StatusOK, fmt.Sprintf("%s is running.", name), "")
+        } else {
+            report.add("Services", cliutils.StatusFail, fmt.Sprintf("%s is %s.", name, state), "Check the unit's logs with 'rocketpool service logs', then restart it with 'rocketpool service start'.")
+        }
+    }
+    return (states[rocketpool.SystemdUnitPrefix + "node" + rocketpool.SystemdUnitSuffix] == "running")
+}
+
+
+// Check available disk space
+// Disks are also reported if their usage is above the configured alert percentage
+func checkDiskSpace(rp *rocketpool.Client, cfg config.RocketPoolConfig, report *doctorReport) {
+    spaces, err := rp.GetDiskSpace()
+    if err != nil {
+        report.add("Disk space", cliutils.StatusWarn, err.Error(), "")
+        return
+    }
+    for _, space := range spaces {
+        message := fmt.Sprintf("%s has %.1f GB of %.1f GB available.", space.Path, toGB(space.Available), toGB(space.Total))
+        fix := "Free up disk space, or move Docker's data folder to a larger disk; running out of space will stop your clients."
+        if space.Available < MinAvailableDiskSpace {
+            report.add("Disk space", cliutils.StatusFail, message, fix)
+        } else if space.Available < WarnAvailableDiskSpace || getDiskUsagePercent(space) >= cfg.GetAlertDiskUsagePercent() {
+            report.add("Disk space", cliutils.StatusWarn, message, fix)
+        } else {
+            report.add("Disk space", cliutils.StatusOK, message, "")
+            continue
+        }
+        report.remedy([]string{
+            "Remove Docker images left behind by previous smart node and client versions.",
+            "Check for large files outside the smart node, e.g. with 'sudo du -xh / | sort -h | tail'.",
+            "If space is still low, move Docker's data folder to a larger disk; running out of space will stop your clients.",
+        }, fixCommand{
+            Command: fmt.Sprintf("%s image prune -a -f", getRuntimeName(rp)),
+            Description: "remove Docker images not used by any container",
+            Run: rp.PruneDockerImages,
+        })
+    }
+}
+
+
+// Check that client P2P ports are open on the host
+func checkPorts(rp *rocketpool.Client, cfg config.RocketPoolConfig, report *doctorReport) {
+
+    // Get expected ports
+    type clientPort struct {
+        Name string
+        Service string
+        Port uint64
+    }
+    expected := []clientPort{}
+    if !cfg.Chains.Eth1.External {
+        expected = append(expected, clientPort{Name: "Eth 1.0", Service: "eth1", Port: uint64(cfg.GetEth1P2PPort())})
+    }
+    if !cfg.Chains.Eth2.External {
+        expected = append(expected, clientPort{Name: "Eth 2.0", Service: "eth2", Port: uint64(cfg.GetEth2P2PPort())})
+    }
+    if len(expected) == 0 {
+        return
+    }
+
+    // Check ports
+    ports, err := rp.GetListeningPorts()
+    if err != nil {
+        report.add("Ports", cliutils.StatusWarn, err.Error(), "")
+        return
+    }
+    for _, client := range expected {
+        if ports[client.Port] {
+            report.add("Ports", cliutils.StatusOK, fmt.Sprintf("The %s client is listening for peers on port %d.", client.Name, client.Port), "")
+        } else {
+            report.add("Ports", cliutils.StatusFail, fmt.Sprintf("Nothing is listening on the %s client's P2P port %d.", client.Name, client.Port), fmt.Sprintf("Make sure the %s client is running, and that port %d (TCP & UDP) is allowed through your firewall and forwarded by your router.", client.Name, client.Port))
+            report.remedy([]string{
+                fmt.Sprintf("Check the %s client is running with 'rocketpool service status', and review its logs with 'rocketpool service logs %s'.", client.Name, client.Service),
+                fmt.Sprintf("Restart the %s client so it binds to port %d again.", client.Name, client.Port),
+                fmt.Sprintf("Allow port %d through the host firewall, e.g. 'sudo ufw allow %d'.", client.Port, client.Port),
+                fmt.Sprintf("Forward port %d (TCP & UDP) to this machine in your router's settings.", client.Port),
+            }, restartCommand(rp, client.Service, client.Name))
+        }
+    }
+
+}
+
+
+// Check the remote signer is reachable and holds the node's validator keys, if configured
+func checkRemoteSigner(rp *rocketpool.Client, report *doctorReport) {
+    status, err := rp.SignerStatus()
+    if err != nil {
+        report.add("Remote signer", cliutils.StatusWarn, err.Error(), "")
+        return
+    }
+    if !status.Configured {
+        return
+    }
+    if !status.Reachable {
+        report.add("Remote signer", cliutils.StatusFail, status.SignerError, fmt.Sprintf("Make sure the Web3Signer instance at %s is running and reachable from the smart node containers.", status.URL))
+        return
+    }
+    if status.SignerError != "" {
+        report.add("Remote signer", cliutils.StatusWarn, status.SignerError, "")
+        return
+    }
+    if len(status.MissingKeys) > 0 {
+        report.add("Remote signer", cliutils.StatusFail, fmt.Sprintf("The remote signer is missing %d of the node's validator keys; those validators cannot attest.", len(status.MissingKeys)), "Import the missing keys with 'rocketpool service sync-signer'.")
+        report.remedy([]string{
+            "Import the node's validator keys which the remote signer does not hold.",
+            "If the signer loads keys from its own key store, make sure the imported keys are kept across signer restarts.",
+        }, fixCommand{
+            Command: "rocketpool service sync-signer",
+            Description: "import the missing validator keys into the remote signer",
+            Run: func() error {
+                _, err := rp.SyncSigner()
+                return err
+            },
+        })
+        return
+    }
+    report.add("Remote signer", cliutils.StatusOK, fmt.Sprintf("The remote signer at %s is up and holds %d validator key(s).", status.URL, status.SignerKeyCount), "")
+}
+
+
+// Check the host clock offset
+func checkClockOffset(rp *rocketpool.Client, report *doctorReport) {
+    offset, err := rp.GetClockOffset()
+    if err != nil {
+        report.add("Time", cliutils.StatusWarn, err.Error(), "")
+        return
+    }
+    seconds := math.Abs(offset.Seconds())
+    if seconds >= MaxClockOffsetSeconds {
+        report.add("Time", cliutils.StatusFail, fmt.Sprintf("The host clock is %s off.", offset.Round(time.Second).String()), "Enable time synchronization on the host (e.g. 'sudo timedatectl set-ntp on'); validators with inaccurate clocks miss attestations.")
+    } else {
+        report.add("Time", cliutils.StatusOK, "The host clock is accurate.", "")
+    }
+}
+
+
+// Check client sync status
+func checkClientSync(rp *rocketpool.Client, report *doctorReport) {
+    status, err := rp.ServiceClientStatus()
+    if err != nil {
+        report.add("Clients", cliutils.StatusFail, fmt.Sprintf("Could not check client status: %s", err), "")
+        return
+    }
+    checkClientStatus(rp, "Eth 1.0", "eth1", status.Eth1, report)
+    checkClientStatus(rp, "Eth 2.0", "eth2", status.Eth2, report)
+}
+
+
+// Check a client's status
+func checkClientStatus(rp *rocketpool.Client, clientName, service string, status api.ClientStatus, report *doctorReport) {
+
+    // Get fix commands; external clients are not managed by the smart node
+    commands := []fixCommand{}
+    if !status.External {
+        commands = append(commands, restartCommand(rp, service, clientName))
+    }
+
+    // Check status
+    if !status.Reachable {
+        report.add("Clients", cliutils.StatusFail, fmt.Sprintf("The %s client is not reachable: %s", clientName, status.Error), fmt.Sprintf("Check the %s client is running with 'rocketpool service status' and review its logs with 'rocketpool service logs'.", clientName))
+        report.remedy([]string{
+            fmt.Sprintf("Check the %s client is running with 'rocketpool service status'.", clientName),
+            fmt.Sprintf("Review its logs for errors with 'rocketpool service logs %s'.", service),
+            "If it is running, check the client provider setting with 'rocketpool service config'.",
+            "Restart the client.",
+        }, commands...)
+    } else if !status.Synced {
+        message := fmt.Sprintf("The %s client is still syncing.", clientName)
+        if status.SyncProgress > 0 {
+            message = fmt.Sprintf("The %s client is still syncing (%.2f%% complete).", clientName, status.SyncProgress * 100)
+        }
+        report.add("Clients", cliutils.StatusWarn, message, "Wait for the client to finish syncing; if progress stalls, check its peer count with 'rocketpool service peers'.")
+        report.remedy([]string{
+            "Wait for the client to finish syncing; an initial sync can take several days.",
+            "Check the client's peer count with 'rocketpool service peers'; a client with few peers syncs slowly or not at all, which usually means its P2P port is blocked.",
+            fmt.Sprintf("Review its logs for errors with 'rocketpool service logs %s'.", service),
+            "If progress has stalled, restart the client.",
+        }, commands...)
+    } else if status.LatestBlockTime > 0 && time.Since(time.Unix(int64(status.LatestBlockTime), 0)) > MaxBlockAgeSeconds * time.Second {
+        age := time.Since(time.Unix(int64(status.LatestBlockTime), 0)).Round(time.Second)
+        report.add("Clients", cliutils.StatusFail, fmt.Sprintf("The %s client reports that it is synced, but its latest block is %s old.", clientName, age.String()), "The client has stopped following the chain; check its peers and restart it.")
+        report.remedy([]string{
+            "Check that the host clock is accurate (see the Time check above).",
+            "Check the client's peer count with 'rocketpool service peers'; a client with no peers cannot receive new blocks.",
+            fmt.Sprintf("Review its logs for errors with 'rocketpool service logs %s'.", service),
+            "Restart the client to reconnect it to the network.",
+        }, commands...)
+    } else {
+        report.add("Clients", cliutils.StatusOK, fmt.Sprintf("The %s client is synced.", clientName), "")
+    }
+
+}
+
+
+// Check the node wallet & registration status
+func checkWalletAndRegistration(c *cli.Context, rp *rocketpool.Client, report *doctorReport) {
+
+    // Check wallet
+    wallet, err := rp.WalletStatus()
+    if err != nil {
+        report.add("Wallet", cliutils.StatusFail, fmt.Sprintf("Could not check wallet status: %s", err), "")
+        return
+    }
+    if wallet.WalletOffline {
+        report.add("Wallet", cliutils.StatusOK, fmt.Sprintf("The node wallet is kept offline, with account %s.", wallet.AccountAddress.Hex()), "")
+    } else if !wallet.PasswordSet {
+        report.add("Wallet", cliutils.StatusFail, "The node password has not been set.", "Run 'rocketpool wallet init' to set a password and create the node wallet.")
+        report.remedy(walletSteps, cliCommand(c, "create a new node wallet", "wallet", "init"), cliCommand(c, "restore an existing node wallet from its mnemonic", "wallet", "recover"))
+        return
+    } else if !wallet.WalletInitialized {
+        report.add("Wallet", cliutils.StatusFail, "The node wallet has not been initialized.", "Run 'rocketpool wallet init', or 'rocketpool wallet recover' to restore an existing wallet.")
+        report.remedy(walletSteps, cliCommand(c, "create a new node wallet", "wallet", "init"), cliCommand(c, "restore an existing node wallet from its mnemonic", "wallet", "recover"))
+        return
+    } else {
+        report.add("Wallet", cliutils.StatusOK, fmt.Sprintf("The node wallet is initialized with account %s.", wallet.AccountAddress.Hex()), "")
+    }
+
+    // Check registration
+    node, err := rp.NodeStatus()
+    if err != nil {
+        report.add("Registration", cliutils.StatusWarn, fmt.Sprintf("Could not check node registration: %s", err), "Registration can only be checked once the Eth 1.0 client is synced.")
+        return
+    }
+    if !node.Registered {
+        report.add("Registration", cliutils.StatusFail, "The node is not registered with Rocket Pool.", "Run 'rocketpool node register' once the Eth 1.0 client is synced.")
+        return
+    }
+    report.add("Registration", cliutils.StatusOK, "The node is registered with Rocket Pool.", "")
+
+}
+
+
+// Get a fix command which restarts a service container
+func restartCommand(rp *rocketpool.Client, service, clientName string) fixCommand {
+    return fixCommand{
+        Command: fmt.Sprintf("%s restart %s_%s", getRuntimeName(rp), rocketpool.ComposeProjectName, service),
+        Description: fmt.Sprintf("restart the %s client", clientName),
+        Run: func() error { return rp.RestartServiceContainer(service) },
+    }
+}
+
+
+// Get a fix command which runs a rocketpool CLI command interactively, with the same global options as the current command
+func cliCommand(c *cli.Context, description string, args ...string) fixCommand {
+    globalArgs := getGlobalArgs(c)
+    return fixCommand{
+        Command: "rocketpool " + strings.Join(args, " "),
+        Description: description,
+        Run: func() error {
+            cmd := exec.Command(os.Args[0], append(append([]string{}, globalArgs...), args...)...)
+            cmd.Stdin = os.Stdin
+            cmd.Stdout = os.Stdout
+            cmd.Stderr = os.Stderr
+            return cmd.Run()
+        },
+    }
+}
+
+
+// Get the global options passed to the CLI before the current command
+func getGlobalArgs(c *cli.Context) []string {
+    names := map[string]bool{c.Command.Name: true}
+    for _, alias := range c.Command.Aliases {
+        names[alias] = true
+    }
+    for ai, arg := range os.Args[1:] {
+        if names[arg] {
+            return os.Args[1:ai + 1]
+        }
+    }
+    return []string{}
+}
+
+
+// Convert a byte count to gigabytes
+func toGB(bytes uint64) float64 {
+    return float64(bytes) / (1024 * 1024 * 1024)
+}
+
+
+// Get the percentage of a disk's space in use
+func getDiskUsagePercent(space rocketpool.DiskSpace) float64 {
+    if space.Total == 0 {
+        return 0
+    }
+    return 100 * float64(space.Total - space.Available) / float64(space.Total)
+}
@@ -0,0 +1,83 @@
+package fleet
+
+import (
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    fileFlag := cli.StringFlag{
+        Name:  "file, p",
+        Usage: "The fleet profile `file` listing the nodes to manage (defaults to ~/" + DefaultFleetFile + ")",
+    }
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Manage a fleet of Rocket Pool smart nodes",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "list",
+                Aliases:   []string{"l"},
+                Usage:     "List the nodes in the fleet profile",
+                UsageText: "rocketpool fleet list [options]",
+                Flags: []cli.Flag{
+                    fileFlag,
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return listFleet(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "upgrade",
+                Aliases:   []string{"u"},
+                Usage:     "Upgrade the smart node on every node in the fleet, preserving node settings",
+                UsageText: "rocketpool fleet upgrade [options]",
+                Flags: []cli.Flag{
+                    fileFlag,
+                    cli.BoolFlag{
+                        Name:  "rolling, r",
+                        Usage: "Wait for each node to return to healthy attestations before upgrading the next, and abort the rollout if it does not",
+                    },
+                    cli.StringFlag{
+                        Name:  "health-timeout, t",
+                        Usage: "How long to wait for each node to become healthy in a rolling upgrade (e.g. 30m)",
+                        Value: "30m",
+                    },
+                    cli.StringFlag{
+                        Name:  "network, n",
+                        Usage: "The Eth 2.0 network to run Rocket Pool on",
+                        Value: "medalla",
+                    },
+                    cli.StringFlag{
+                        Name:  "version, v",
+                        Usage: "The smart node package version to install",
+                        Value: "latest",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    healthTimeout, err := cliutils.ValidateDuration("health timeout", c.String("health-timeout"))
+                    if err != nil { return err }
+
+                    // Run
+                    return upgradeFleet(c, c.Bool("rolling"), healthTimeout)
+
+                },
+            },
+
+        },
+    })
+}
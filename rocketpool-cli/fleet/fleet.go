@@ -0,0 +1,110 @@
+package fleet
+
+import (
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/urfave/cli"
+    "gopkg.in/yaml.v2"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Config
+const DefaultFleetFile = ".rocketpool/fleet.yml"
+
+
+// Fleet profile
+type Fleet struct {
+    Nodes []Node            `yaml:"nodes"`
+}
+
+
+// Fleet node; nodes without a host are managed locally
+type Node struct {
+    Name string             `yaml:"name"`
+    Host string             `yaml:"host,omitempty"`
+    User string             `yaml:"user,omitempty"`
+    Key string              `yaml:"key,omitempty"`
+}
+
+
+// Load the fleet profile from the --file option or the default location
+func loadFleet(c *cli.Context) (Fleet, error) {
+
+    // Get home folder
+    homeDir, err := os.UserHomeDir()
+    if err != nil {
+        return Fleet{}, fmt.Errorf("Could not get home folder: %w", err)
+    }
+
+    // Get fleet profile path
+    path := c.String("file")
+    if path == "" {
+        path = filepath.Join(homeDir, DefaultFleetFile)
+    }
+
+    // Read & parse fleet profile
+    fleetBytes, err := ioutil.ReadFile(path)
+    if os.IsNotExist(err) {
+        return Fleet{}, fmt.Errorf("The fleet profile %s was not found; create it with the hosts of your nodes.", path)
+    }
+    if err != nil {
+        return Fleet{}, fmt.Errorf("Could not read fleet profile: %w", err)
+    }
+    var fleet Fleet
+    if err := yaml.Unmarshal(fleetBytes, &fleet); err != nil {
+        return Fleet{}, fmt.Errorf("Could not parse fleet profile: %w", err)
+    }
+
+    // Check & normalize nodes
+    if len(fleet.Nodes) == 0 {
+        return Fleet{}, errors.New("The fleet profile does not contain any nodes.")
+    }
+    names := make(map[string]bool)
+    for ni := range fleet.Nodes {
+        node := &fleet.Nodes[ni]
+        if node.Name == "" {
+            node.Name = node.Host
+        }
+        if node.Name == "" {
+            node.Name = "local"
+        }
+        if names[node.Name] {
+            return Fleet{}, fmt.Errorf("The fleet profile contains more than one node named %s.", node.Name)
+        }
+        names[node.Name] = true
+        if strings.HasPrefix(node.Key, "~/") {
+            node.Key = filepath.Join(homeDir, node.Key[2:])
+        }
+    }
+
+    // Return
+    return fleet, nil
+
+}
+
+
+// Create a Rocket Pool client for a fleet node
+func (node Node) newClient(c *cli.Context) (*rocketpool.Client, error) {
+    rp, err := rocketpool.NewClient(node.Host, node.User, node.Key)
+    if err != nil {
+        return nil, fmt.Errorf("Could not connect to node %s: %w", node.Name, err)
+    }
+    rp.SetAccessible(c.GlobalBool("accessible"))
+    return rp, nil
+}
+
+
+// Get a description of a fleet node's location
+func (node Node) location() string {
+    if node.Host == "" {
+        return "local"
+    }
+    return fmt.Sprintf("%s@%s", node.User, node.Host)
+}
@@ -0,0 +1,25 @@
+package fleet
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+)
+
+
+func listFleet(c *cli.Context) error {
+
+    // Load fleet profile
+    fleet, err := loadFleet(c)
+    if err != nil {
+        return err
+    }
+
+    // Print nodes
+    fmt.Printf("The fleet has %d node(s):\n", len(fleet.Nodes))
+    for _, node := range fleet.Nodes {
+        fmt.Printf("- %s (%s)\n", node.Name, node.location())
+    }
+    return nil
+
+}
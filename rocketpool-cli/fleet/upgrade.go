@@ -0,0 +1,173 @@
+package fleet
+
+import (
+    "fmt"
+    "math/big"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Config
+var healthCheckInterval, _ = time.ParseDuration("1m")
+
+
+func upgradeFleet(c *cli.Context, rolling bool, healthTimeout time.Duration) error {
+
+    // Load fleet profile
+    fleet, err := loadFleet(c)
+    if err != nil {
+        return err
+    }
+
+    // Prompt for confirmation
+    mode := "one after another"
+    if rolling {
+        mode = fmt.Sprintf("one at a time, waiting up to %s for each to return to healthy attestations before continuing", healthTimeout)
+    }
+    fmt.Printf("The following nodes will be upgraded to smart node version %s on the %s network, %s:\n", c.String("version"), c.String("network"), mode)
+    for _, node := range fleet.Nodes {
+        fmt.Printf("- %s (%s)\n", node.Name, node.location())
+    }
+    fmt.Println("")
+    if !cliutils.Confirm("Node settings are preserved, but each node's validators will be offline while it is upgraded. Are you sure you want to continue?") {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Upgrade nodes
+    for ni, node := range fleet.Nodes {
+        fmt.Println("")
+        fmt.Printf("Upgrading node %s (%d of %d)...\n", node.Name, ni + 1, len(fleet.Nodes))
+        if err := upgradeNode(c, node, rolling, healthTimeout); err != nil {
+            remaining := len(fleet.Nodes) - ni - 1
+            return fmt.Errorf("The rollout was aborted at node %s with %d node(s) not upgraded: %w", node.Name, remaining, err)
+        }
+        cliutils.PrintStatus(cliutils.StatusOK, fmt.Sprintf("Node %s was upgraded successfully.", node.Name))
+    }
+
+    // Print success message & return
+    fmt.Println("")
+    fmt.Printf("All %d node(s) were upgraded successfully.\n", len(fleet.Nodes))
+    return nil
+
+}
+
+
+// Upgrade a fleet node, preserving its user settings
+// If rolling, wait for the node to return to healthy attestations
+func upgradeNode(c *cli.Context, node Node, rolling bool, healthTimeout time.Duration) error {
+
+    // Get RP client
+    rp, err := node.newClient(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Save user settings; the installer overwrites the node's configuration
+    userConfig, err := rp.LoadUserConfig()
+    if err != nil { return err }
+
+    // Install new version with the node's service backend & architecture
+    if err := rp.InstallService(false, true, c.String("network"), c.String("version"), userConfig.Smartnode.ServiceBackend, userConfig.Smartnode.Architecture); err != nil {
+        return fmt.Errorf("Could not install the new version: %w", err)
+    }
+
+    // Restore user settings & restart service
+    if err := rp.SaveUserConfig(userConfig, fmt.Sprintf("Restore settings after upgrade to %s", c.String("version"))); err != nil {
+        return fmt.Errorf("Could not restore node settings: %w", err)
+    }
+    if err := rp.StartService(); err != nil {
+        return fmt.Errorf("Could not start the service: %w", err)
+    }
+
+    // Wait for node health
+    if !rolling {
+        return nil
+    }
+    return waitNodeHealthy(rp, healthTimeout)
+
+}
+
+
+// Wait for a node's clients to sync and its active validators to attest successfully
+// Attestations are healthy once the node's active validator balances increase, as they are rewarded each epoch
+func waitNodeHealthy(rp *rocketpool.Client, timeout time.Duration) error {
+
+    // Check health until timeout
+    var baseline *big.Int
+    var lastProblem string
+    deadline := time.Now().Add(timeout)
+    for {
+
+        // Check health
+        healthy, problem, err := checkNodeHealth(rp, &baseline)
+        if err != nil {
+            problem = err.Error()
+        }
+        if healthy {
+            return nil
+        }
+        if problem != lastProblem {
+            cliutils.PrintStatus(cliutils.StatusWarn, problem)
+            lastProblem = problem
+        }
+
+        // Check timeout
+        if time.Now().Add(healthCheckInterval).After(deadline) {
+            return fmt.Errorf("The node did not become healthy within %s: %s", timeout, problem)
+        }
+        time.Sleep(healthCheckInterval)
+
+    }
+
+}
+
+
+// Check a node's health, recording its active validator balance as a baseline on the first successful check
+func checkNodeHealth(rp *rocketpool.Client, baseline **big.Int) (bool, string, error) {
+
+    // Check clients
+    clientStatus, err := rp.ServiceClientStatus()
+    if err != nil {
+        return false, "", err
+    }
+    if !clientStatus.Eth1.Synced || !clientStatus.Eth2.Synced {
+        return false, "Waiting for the Eth 1.0 and Eth 2.0 clients to sync...", nil
+    }
+
+    // Get active validator balance
+    minipoolStatus, err := rp.MinipoolStatus()
+    if err != nil {
+        return false, "", err
+    }
+    balance := big.NewInt(0)
+    activeValidators := 0
+    for _, mp := range minipoolStatus.Minipools {
+        if mp.Validator.Exists && mp.Validator.Active && !mp.Validator.Exiting && mp.Validator.Balance != nil {
+            balance.Add(balance, mp.Validator.Balance)
+            activeValidators++
+        }
+    }
+    if activeValidators == 0 {
+        return true, "", nil
+    }
+
+    // Check balance against baseline
+    if *baseline == nil {
+        *baseline = balance
+        return false, fmt.Sprintf("Waiting for attestations from %d active validator(s)...", activeValidators), nil
+    }
+    if balance.Cmp(*baseline) > 0 {
+        return true, "", nil
+    }
+    if balance.Cmp(*baseline) < 0 {
+        *baseline = balance
+        return false, "Validator balances are decreasing; attestations are being missed.", nil
+    }
+    return false, fmt.Sprintf("Waiting for attestations from %d active validator(s)...", activeValidators), nil
+
+}
@@ -0,0 +1,41 @@
+package history
+
+import (
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, historyName string, historyAliases []string, lastName string, lastAliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      historyName,
+        Aliases:   historyAliases,
+        Usage:     "Display recently run commands and their outcomes",
+        UsageText: "rocketpool history",
+        Action: func(c *cli.Context) error {
+
+            // Validate args
+            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+            // Run
+            return showHistory(c)
+
+        },
+    }, cli.Command{
+        Name:      lastName,
+        Aliases:   lastAliases,
+        Usage:     "Display the full output of the previous command",
+        UsageText: "rocketpool last",
+        Action: func(c *cli.Context) error {
+
+            // Validate args
+            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+            // Run
+            return showLast(c)
+
+        },
+    })
+}
@@ -0,0 +1,107 @@
+package history
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+
+// Config
+const (
+    HistoryFile = ".rocketpool/cli-history.json"
+    MaxEntries = 50
+    MaxOutputLength = 1024 * 1024
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Command history entry
+type Entry struct {
+    Time time.Time          `json:"time"`
+    Command string          `json:"command"`
+    Success bool            `json:"success"`
+    Error string            `json:"error,omitempty"`
+    Output string           `json:"output"`
+    OutputOmitted bool      `json:"outputOmitted,omitempty"`
+    OutputTruncated bool    `json:"outputTruncated,omitempty"`
+}
+
+
+// Get the command history file path
+func getHistoryPath() (string, error) {
+    homeDir, err := os.UserHomeDir()
+    if err != nil {
+        return "", fmt.Errorf("Could not get home folder: %w", err)
+    }
+    return filepath.Join(homeDir, HistoryFile), nil
+}
+
+
+// Load command history, oldest first
+func Load() ([]Entry, error) {
+
+    // Get history path
+    path, err := getHistoryPath()
+    if err != nil {
+        return []Entry{}, err
+    }
+
+    // Read history; no entries if not found
+    historyBytes, err := ioutil.ReadFile(path)
+    if os.IsNotExist(err) {
+        return []Entry{}, nil
+    }
+    if err != nil {
+        return []Entry{}, fmt.Errorf("Could not read command history: %w", err)
+    }
+
+    // Decode history
+    var entries []Entry
+    if err := json.Unmarshal(historyBytes, &entries); err != nil {
+        return []Entry{}, fmt.Errorf("Could not decode command history: %w", err)
+    }
+    return entries, nil
+
+}
+
+
+// Add an entry to the command history, discarding the oldest entries over the limit
+func Add(entry Entry) error {
+
+    // Get history path
+    path, err := getHistoryPath()
+    if err != nil {
+        return err
+    }
+
+    // Load history & add entry
+    entries, err := Load()
+    if err != nil {
+        entries = []Entry{}
+    }
+    entries = append(entries, entry)
+    if len(entries) > MaxEntries {
+        entries = entries[len(entries) - MaxEntries:]
+    }
+
+    // Encode history
+    historyBytes, err := json.Marshal(entries)
+    if err != nil {
+        return fmt.Errorf("Could not encode command history: %w", err)
+    }
+
+    // Write history
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create command history folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path, historyBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write command history: %w", err)
+    }
+    return nil
+
+}
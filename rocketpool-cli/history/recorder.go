@@ -0,0 +1,115 @@
+package history
+
+import (
+    "bytes"
+    "io"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/urfave/cli"
+)
+
+
+// Commands which are not recorded
+var unrecordedCommands = []string{"history", "last", "help", "h"}
+
+// Commands whose output is not recorded as it may contain secrets (e.g. wallet mnemonics & support tokens)
+var sensitiveCommands = []string{"wallet", "w", "support", "u"}
+
+
+// Command recorder; copies stdout to the terminal and to a buffer while a command runs
+type Recorder struct {
+    entry Entry
+    stdout *os.File
+    writer *os.File
+    output bytes.Buffer
+    done chan struct{}
+}
+
+
+// Start recording a command's output to the command history
+// Returns nil if the command should not be recorded
+func Start(c *cli.Context) *Recorder {
+
+    // Check command
+    command := c.Args().First()
+    if command == "" || hasCommand(unrecordedCommands, command) {
+        return nil
+    }
+
+    // Initialize recorder
+    r := &Recorder{
+        entry: Entry{
+            Time: time.Now(),
+            Command: strings.Join(os.Args[1:], " "),
+            OutputOmitted: hasCommand(sensitiveCommands, command),
+        },
+        stdout: os.Stdout,
+        done: make(chan struct{}),
+    }
+    if r.entry.OutputOmitted {
+        return r
+    }
+
+    // Redirect stdout through a pipe
+    reader, writer, err := os.Pipe()
+    if err != nil {
+        return r
+    }
+    r.writer = writer
+    os.Stdout = writer
+    go (func() {
+        io.Copy(io.MultiWriter(r.stdout, &r.output), reader)
+        reader.Close()
+        close(r.done)
+    })()
+
+    // Return
+    return r
+
+}
+
+
+// Finish recording and add the command to the command history
+func (r *Recorder) Finish(err error) {
+    if r == nil {
+        return
+    }
+
+    // Restore stdout & wait for output to be copied
+    if r.writer != nil {
+        os.Stdout = r.stdout
+        r.writer.Close()
+        <-r.done
+    }
+
+    // Record outcome & output
+    r.entry.Success = (err == nil)
+    if err != nil {
+        r.entry.Error = err.Error()
+    }
+    if !r.entry.OutputOmitted {
+        output := r.output.String()
+        if len(output) > MaxOutputLength {
+            output = output[len(output) - MaxOutputLength:]
+            r.entry.OutputTruncated = true
+        }
+        r.entry.Output = output
+    }
+
+    // Add entry to history; failures are not reported as the command itself has completed
+    Add(r.entry)
+
+}
+
+
+// Check whether a command name is in a list
+func hasCommand(commands []string, command string) bool {
+    for _, name := range commands {
+        if name == command {
+            return true
+        }
+    }
+    return false
+}
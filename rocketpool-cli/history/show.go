@@ -0,0 +1,80 @@
+package history
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Settings
+const TimeFormat = "2006-01-02 15:04:05"
+
+
+// Display recently run commands
+func showHistory(c *cli.Context) error {
+
+    // Load history
+    entries, err := Load()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(entries) == 0 {
+        fmt.Println("No commands have been recorded yet.")
+        return nil
+    }
+    fmt.Printf("%d recent command(s), oldest first:\n", len(entries))
+    for _, entry := range entries {
+        if entry.Success {
+            cliutils.PrintStatus(cliutils.StatusOK, fmt.Sprintf("%s rocketpool %s", entry.Time.Format(TimeFormat), entry.Command))
+        } else {
+            cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("%s rocketpool %s (%s)", entry.Time.Format(TimeFormat), entry.Command, entry.Error))
+        }
+    }
+    return nil
+
+}
+
+
+// Display the output of the previous command
+func showLast(c *cli.Context) error {
+
+    // Load history
+    entries, err := Load()
+    if err != nil {
+        return err
+    }
+    if len(entries) == 0 {
+        fmt.Println("No commands have been recorded yet.")
+        return nil
+    }
+    entry := entries[len(entries) - 1]
+
+    // Print command details
+    fmt.Printf("Command: rocketpool %s\n", entry.Command)
+    fmt.Printf("Run at:  %s\n", entry.Time.Format(TimeFormat))
+    if entry.Success {
+        fmt.Println("Result:  succeeded")
+    } else {
+        fmt.Printf("Result:  failed (%s)\n", entry.Error)
+    }
+    fmt.Println("")
+
+    // Print output & return
+    if entry.OutputOmitted {
+        fmt.Println("The output of this command was not recorded as it may contain secrets.")
+        return nil
+    }
+    if entry.OutputTruncated {
+        fmt.Printf("The output of this command was truncated to its last %d bytes.\n", MaxOutputLength)
+    }
+    cliutils.PrintSeparator("-----------------")
+    fmt.Print(entry.Output)
+    cliutils.PrintSeparator("-----------------")
+    return nil
+
+}
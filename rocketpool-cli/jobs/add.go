@@ -0,0 +1,52 @@
+package jobs
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+func addJob(c *cli.Context, jobType string, minipool string, maxGasPrice float64, notBefore time.Time) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Resolve minipool address
+    minipoolAddress, _, err := rp.ResolveAddress(minipool)
+    if err != nil {
+        return err
+    }
+
+    // Add job
+    response, err := rp.AddJob(jobType, minipoolAddress, maxGasPrice, notBefore)
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Scheduled %s%s.\n", response.Job.Description(), formatConditions(response.Job))
+    fmt.Println("The node daemon will run the job once the minipool is ready and its conditions are met.")
+    return nil
+
+}
+
+
+// Parse a job time in local time or RFC 3339 format; an empty value means no time
+func parseJobTime(value string) (time.Time, error) {
+    if value == "" {
+        return time.Time{}, nil
+    }
+    if t, err := time.ParseInLocation(TimeFormat, value, time.Local); err == nil {
+        return t, nil
+    }
+    if t, err := time.Parse(time.RFC3339, value); err == nil {
+        return t, nil
+    }
+    return time.Time{}, fmt.Errorf("Invalid time '%s' - must be in YYYY-MM-DD HH:MM or RFC 3339 format", value)
+}
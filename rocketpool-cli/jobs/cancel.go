@@ -0,0 +1,29 @@
+package jobs
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+func cancelJob(c *cli.Context, id uint64) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Cancel job
+    response, err := rp.CancelJob(id)
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Cancelled %s.\n", response.Job.Description())
+    return nil
+
+}
@@ -0,0 +1,105 @@
+package jobs
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/jobs"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Manage minipool actions scheduled to be run by the node daemon",
+        UsageText: "rocketpool jobs [command]",
+        Action: func(c *cli.Context) error {
+
+            // Validate args
+            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+            // Run
+            return listJobs(c)
+
+        },
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "list",
+                Aliases:   []string{"l"},
+                Usage:     "List the node's scheduled jobs",
+                UsageText: "rocketpool jobs list",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return listJobs(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "add",
+                Aliases:   []string{"a"},
+                Usage:     fmt.Sprintf("Schedule a minipool action (%s) to be run once its conditions are met", strings.Join(jobs.Types, ", ")),
+                UsageText: "rocketpool jobs add type minipool-address [options]",
+                Flags: []cli.Flag{
+                    cli.Float64Flag{
+                        Name:  "max-gas-price, g",
+                        Usage: "Only run the job while the gas price is at or below this `gwei` value",
+                    },
+                    cli.StringFlag{
+                        Name:  "at, t",
+                        Usage: "Do not run the job before this local `time` (YYYY-MM-DD HH:MM or RFC 3339)",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    jobType := c.Args().Get(0)
+                    if !jobs.ValidType(jobType) {
+                        return fmt.Errorf("Invalid job type '%s' - valid types are: %s", jobType, strings.Join(jobs.Types, ", "))
+                    }
+                    minipoolAddress, err := cliutils.ValidateAddressOrENSName("minipool address", c.Args().Get(1))
+                    if err != nil { return err }
+                    if c.Float64("max-gas-price") < 0 {
+                        return fmt.Errorf("Invalid max gas price '%f' - must not be negative", c.Float64("max-gas-price"))
+                    }
+                    notBefore, err := parseJobTime(c.String("at"))
+                    if err != nil { return err }
+
+                    // Run
+                    return addJob(c, jobType, minipoolAddress, c.Float64("max-gas-price"), notBefore)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "cancel",
+                Aliases:   []string{"c"},
+                Usage:     "Cancel a pending job",
+                UsageText: "rocketpool jobs cancel id",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    id, err := cliutils.ValidateUint("job ID", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    return cancelJob(c, id)
+
+                },
+            },
+
+        },
+    })
+}
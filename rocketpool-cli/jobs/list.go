@@ -0,0 +1,68 @@
+package jobs
+
+import (
+    "fmt"
+
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/jobs"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Settings
+const TimeFormat = "2006-01-02 15:04"
+
+
+func listJobs(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get jobs
+    response, err := rp.Jobs()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(response.Jobs) == 0 {
+        fmt.Println("The node has no scheduled jobs.")
+        return nil
+    }
+    fmt.Printf("The node has %d job(s):\n", len(response.Jobs))
+    for _, job := range response.Jobs {
+        switch job.Status {
+            case jobs.StatusDone: cliutils.PrintStatus(cliutils.StatusOK, fmt.Sprintf("%s - done in transaction %s", job.Description(), job.TxHash.Hex()))
+            case jobs.StatusFailed: cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("%s - failed after %d attempt(s): %s", job.Description(), job.Attempts, job.Error))
+            case jobs.StatusCancelled: cliutils.PrintStatus(cliutils.StatusWarn, fmt.Sprintf("%s - cancelled", job.Description()))
+            case jobs.StatusRunning: cliutils.PrintStatus(cliutils.StatusWarn, fmt.Sprintf("%s - running, waiting for transaction %s", job.Description(), job.TxHash.Hex()))
+            default:
+                status := fmt.Sprintf("%s - pending%s", job.Description(), formatConditions(job))
+                if job.Error != "" {
+                    status += fmt.Sprintf(" (last attempt failed: %s)", job.Error)
+                }
+                cliutils.PrintStatus(cliutils.StatusWarn, status)
+        }
+    }
+    return nil
+
+}
+
+
+// Format a job's run conditions
+func formatConditions(job jobs.Job) string {
+    conditions := ""
+    if !job.NotBefore.IsZero() {
+        conditions += fmt.Sprintf(", not before %s", job.NotBefore.Local().Format(TimeFormat))
+    }
+    if job.MaxGasPrice > 0 {
+        conditions += fmt.Sprintf(", at a gas price of at most %s", units.FormatGwei(eth.GweiToWei(job.MaxGasPrice)))
+    }
+    return conditions
+}
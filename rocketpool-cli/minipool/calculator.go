@@ -0,0 +1,69 @@
+package minipool
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Settings
+const (
+    DefaultCalculatorAPR = 5
+    DefaultCalculatorYears = 1
+)
+
+
+func runCalculator(c *cli.Context, apr float64, years uint64) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get projections
+    response, err := rp.MinipoolCalculator(apr, years)
+    if err != nil {
+        return err
+    }
+
+    // Print projections
+    fmt.Printf("Projected node returns over %d year(s) at a %.2f%% validator APR and the current %.2f%% node commission rate:\n", response.Years, response.APR * 100, response.NodeFee * 100)
+    fmt.Println("")
+    bestReturn := -1.0
+    bestType := ""
+    for _, projection := range response.DepositTypes {
+        fmt.Printf("%s deposit: %s from the node, %s from users\n", projection.DepositType, units.FormatEth(projection.NodeAmount), units.FormatEth(projection.UserAmount))
+        if !projection.Available {
+            fmt.Println("    Only available to trusted nodes.")
+            fmt.Println("")
+            continue
+        }
+        fmt.Printf("    Rewards:  %s\n", units.FormatEth(projection.Rewards))
+        if projection.GasEstimated {
+            fmt.Printf("    Gas cost: %s to deposit at %s\n", units.FormatGasCost(projection.GasCost, projection.GasInfo.GasToken), units.FormatGwei(projection.GasInfo.GasPrice))
+        } else {
+            fmt.Println("    Gas cost: unknown; deposit gas can only be estimated for registered nodes with enough ETH to deposit")
+        }
+        fmt.Printf("    Net:      %s\n", units.FormatEth(projection.NetRewards))
+        if projection.NodeAmount.Sign() > 0 {
+            fmt.Printf("    Return:   %.2f%% per year on node ETH\n", projection.AnnualReturn * 100)
+            if projection.AnnualReturn > bestReturn {
+                bestReturn = projection.AnnualReturn
+                bestType = projection.DepositType
+            }
+        }
+        fmt.Println("")
+    }
+
+    // Print recommendation & return
+    if bestType != "" {
+        fmt.Printf("The %s deposit type makes the most capital-efficient use of node ETH.\n", bestType)
+    }
+    fmt.Println("Projections are estimates only; actual rewards depend on validator performance and network conditions.")
+    return nil
+
+}
@@ -0,0 +1,161 @@
+package minipool
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "strings"
+
+    "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/rocketpool-cli/tx"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Close dissolved & withdrawable minipools one at a time, simulating each close and checking its validator first
+func closeMinipoolsWizard(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get minipool statuses
+    status, err := rp.MinipoolStatus()
+    if err != nil {
+        return err
+    }
+
+    // Get dissolved & withdrawable minipools
+    closableMinipools := []api.MinipoolDetails{}
+    for _, minipool := range status.Minipools {
+        if minipool.Status.Status == types.Dissolved || minipool.Status.Status == types.Withdrawable {
+            closableMinipools = append(closableMinipools, minipool)
+        }
+    }
+    if len(closableMinipools) == 0 {
+        fmt.Println("The node has no dissolved or withdrawable minipools to close.")
+        return nil
+    }
+    fmt.Printf("The node has %d dissolved or withdrawable minipool(s). Each will be simulated and checked on the beacon chain before you confirm closing it.\n", len(closableMinipools))
+    fmt.Println("")
+
+    // Close minipools
+    closed := 0
+    for mi, minipool := range closableMinipools {
+        cliutils.PrintSeparator("-----------------")
+        fmt.Printf("(%d/%d) ", mi + 1, len(closableMinipools))
+        ok, err := closeMinipoolGuided(rp, minipool)
+        if tx.HandleOfflineBatchTransaction(err, len(closableMinipools) - mi - 1) {
+            return nil
+        }
+        if ok {
+            closed++
+        }
+        fmt.Println("")
+    }
+
+    // Log & return
+    fmt.Printf("Closed %d of %d minipool(s).\n", closed, len(closableMinipools))
+    return nil
+
+}
+
+
+// Simulate, review & close a minipool; returns whether it was closed
+// In offline mode, the *api.OfflineTransactionError for the prepared transaction is returned
+func closeMinipoolGuided(rp *rocketpool.Client, minipool api.MinipoolDetails) (bool, error) {
+
+    // Simulate close
+    fmt.Printf("Minipool %s (%s)\n", minipool.Address.Hex(), minipool.Status.Status.String())
+    simulation, err := rp.SimulateCloseMinipool(minipool.Address)
+    if err != nil {
+        fmt.Printf("Could not simulate closing the minipool: %s.\n", err)
+        return false, nil
+    }
+    if simulation.InvalidStatus {
+        fmt.Println("The minipool is not dissolved or withdrawable, skipping.")
+        return false, nil
+    }
+    if simulation.WithdrawalDelayActive {
+        fmt.Println("The minipool withdrawal delay has not passed yet, skipping.")
+        return false, nil
+    }
+
+    // Print balances
+    fmt.Println("")
+    fmt.Printf("Returned to the node account: %s", units.FormatEth(simulation.NodeETHAmount))
+    if simulation.NodeNETHAmount != nil && simulation.NodeNETHAmount.Sign() > 0 {
+        fmt.Printf(" and %s", units.FormatToken(simulation.NodeNETHAmount, units.TokenDecimals, "nETH"))
+    }
+    fmt.Println("")
+    if simulation.MinipoolStatus == types.Dissolved {
+        fmt.Printf("User deposit: %s, already returned to the deposit pool when the minipool was dissolved\n", units.FormatEth(simulation.UserAmount))
+    } else {
+        fmt.Printf("Paid to rETH holders from the validator's withdrawal: %s\n", units.FormatEth(simulation.UserAmount))
+    }
+
+    // Print validator status
+    switch {
+        case !simulation.ValidatorExists:
+            fmt.Println("Validator: not found on the beacon chain")
+        case simulation.ValidatorWithdrawn:
+            fmt.Printf("Validator %d: exited at epoch %d and withdrawable since epoch %d, with a balance of %s\n", simulation.ValidatorIndex, simulation.ExitEpoch, simulation.WithdrawableEpoch, units.FormatEth(simulation.ValidatorBalance))
+        case simulation.ValidatorExited:
+            fmt.Printf("Validator %d: exited at epoch %d but not withdrawable until epoch %d (currently %d), with a balance of %s\n", simulation.ValidatorIndex, simulation.ExitEpoch, simulation.WithdrawableEpoch, simulation.CurrentEpoch, units.FormatEth(simulation.ValidatorBalance))
+        default:
+            fmt.Printf("Validator %d: has NOT exited the beacon chain, with a balance of %s\n", simulation.ValidatorIndex, units.FormatEth(simulation.ValidatorBalance))
+    }
+
+    // Check simulation
+    if simulation.SimulationError != "" {
+        fmt.Println("")
+        fmt.Printf("The %s transaction would fail: %s\n", simulation.Method, simulation.SimulationError)
+        fmt.Println("Skipping the minipool.")
+        return false, nil
+    }
+    fmt.Println("")
+    fmt.Printf("The %s transaction was simulated successfully.\n", simulation.Method)
+    cliutils.PrintGasInfo(simulation.GasInfo)
+
+    // Require typed confirmation if funds would be forfeited
+    if simulation.ForfeitAmount != nil && simulation.ForfeitAmount.Cmp(big.NewInt(0)) > 0 {
+        fmt.Println("")
+        fmt.Printf("WARNING: closing this minipool now forfeits the validator's beacon chain balance of %s. This cannot be undone.\n", units.FormatEth(simulation.ForfeitAmount))
+        confirmation := cliutils.Prompt("To forfeit these funds and close the minipool, type the minipool address; enter anything else to skip it:", "^.*$", "")
+        if !strings.EqualFold(strings.TrimSpace(confirmation), minipool.Address.Hex()) {
+            fmt.Println("The address did not match, skipping the minipool.")
+            return false, nil
+        }
+    } else if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to close minipool %s?", minipool.Address.Hex())) {
+        fmt.Println("Skipped.")
+        return false, nil
+    }
+
+    // Close minipool
+    var closeErr error
+    if simulation.Method == "withdraw" {
+        _, closeErr = rp.WithdrawMinipool(minipool.Address)
+    } else {
+        _, closeErr = rp.CloseMinipool(minipool.Address)
+    }
+    if closeErr != nil {
+        var offlineErr *api.OfflineTransactionError
+        if errors.As(closeErr, &offlineErr) {
+            return false, closeErr
+        }
+        if tx.HandleDryRun(closeErr) {
+            return false, nil
+        }
+        fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), closeErr)
+        return false, nil
+    }
+    fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
+    return true, nil
+
+}
@@ -2,13 +2,15 @@ package minipool
 
 import (
     "fmt"
+    "math/big"
 
-    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/rocketpool-cli/tx"
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     "github.com/rocket-pool/smartnode/shared/types/api"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -43,7 +45,7 @@ func closeMinipools(c *cli.Context) error {
     options := make([]string, len(closableMinipools) + 1)
     options[0] = "All available minipools"
     for mi, minipool := range closableMinipools {
-        options[mi + 1] = fmt.Sprintf("%s (%.2f ETH to claim)", minipool.Address.Hex(), eth.WeiToEth(minipool.Node.DepositBalance))
+        options[mi + 1] = fmt.Sprintf("%s (%s to claim)", minipool.Address.Hex(), units.FormatEth(minipool.Node.DepositBalance))
     }
     selected, _ := cliutils.Select("Please select a minipool to close:", options)
 
@@ -55,9 +57,51 @@ func closeMinipools(c *cli.Context) error {
         selectedMinipools = []api.MinipoolDetails{closableMinipools[selected - 1]}
     }
 
-    // Close minipools
+    // Check minipools can be closed
+    closeMinipools := []api.MinipoolDetails{}
+    gasInfos := []api.GasInfo{}
+    totalBalance := big.NewInt(0)
     for _, minipool := range selectedMinipools {
+        canClose, err := rp.CanCloseMinipool(minipool.Address)
+        if err != nil {
+            fmt.Printf("Could not check whether minipool %s can be closed: %s.\n", minipool.Address.Hex(), err)
+            continue
+        }
+        if !canClose.CanClose {
+            fmt.Printf("Minipool %s cannot be closed:\n", minipool.Address.Hex())
+            if canClose.InvalidStatus {
+                fmt.Println("The minipool has not been dissolved.")
+            }
+            continue
+        }
+        closeMinipools = append(closeMinipools, minipool)
+        gasInfos = append(gasInfos, canClose.GasInfo)
+        totalBalance.Add(totalBalance, canClose.NodeDepositBalance)
+    }
+    if len(closeMinipools) == 0 {
+        return nil
+    }
+
+    // Print balance preview & gas estimate
+    fmt.Printf("%s will be returned to the node account from %d minipool(s).\n", units.FormatEth(totalBalance), len(closeMinipools))
+    cliutils.PrintTotalGasInfo(gasInfos)
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to close %d minipool(s)?", len(closeMinipools))) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Close minipools
+    for mi, minipool := range closeMinipools {
         if _, err := rp.CloseMinipool(minipool.Address); err != nil {
+            if tx.HandleOfflineBatchTransaction(err, len(closeMinipools) - mi - 1) {
+                return nil
+            }
+            if tx.HandleDryRun(err) {
+                fmt.Println("")
+                continue
+            }
             fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
             fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
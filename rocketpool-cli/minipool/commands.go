@@ -1,6 +1,9 @@
 package minipool
 
 import (
+    "errors"
+    "fmt"
+
     "github.com/urfave/cli"
 
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -31,6 +34,22 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "performance",
+                Aliases:   []string{"p"},
+                Usage:     "Show the attestation & proposal performance of the node's minipool validators",
+                UsageText: "rocketpool minipool performance",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getPerformance(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "refund",
                 Aliases:   []string{"r"},
@@ -113,6 +132,72 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "close-wizard",
+                Aliases:   []string{"cw"},
+                Usage:     "Close dissolved and withdrawable minipools one at a time, reviewing a simulation and the validator's beacon chain status for each",
+                UsageText: "rocketpool minipool close-wizard",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return closeMinipoolsWizard(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "verify-credentials",
+                Aliases:   []string{"v"},
+                Usage:     "Verify that the beacon chain withdrawal credentials of the node's minipool validators match the network",
+                UsageText: "rocketpool minipool verify-credentials",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return verifyCredentials(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "calculator",
+                Aliases:   []string{"x"},
+                Usage:     "Compare the node's expected returns across deposit types, including gas costs, before depositing",
+                UsageText: "rocketpool minipool calculator [options]",
+                Flags: []cli.Flag{
+                    cli.Float64Flag{
+                        Name:  "apr, a",
+                        Usage: "The expected validator reward rate, as an annual `percentage`",
+                        Value: DefaultCalculatorAPR,
+                    },
+                    cli.Uint64Flag{
+                        Name:  "years, y",
+                        Usage: "The number of `years` to project returns over",
+                        Value: DefaultCalculatorYears,
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    if c.Float64("apr") <= 0 || c.Float64("apr") > 100 {
+                        return fmt.Errorf("Invalid APR '%f' - must be a percentage greater than 0", c.Float64("apr"))
+                    }
+                    if c.Uint64("years") == 0 {
+                        return errors.New("Invalid years '0' - must be greater than 0")
+                    }
+
+                    // Run
+                    return runCalculator(c, c.Float64("apr") / 100, c.Uint64("years"))
+
+                },
+            },
+
         },
     })
 }
@@ -2,14 +2,16 @@ package minipool
 
 import (
     "fmt"
+    "math/big"
 
     "github.com/rocket-pool/rocketpool-go/types"
-    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/rocketpool-cli/tx"
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     "github.com/rocket-pool/smartnode/shared/types/api"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -26,51 +28,100 @@ func dissolveMinipools(c *cli.Context) error {
         return err
     }
 
-    // Get initialized minipools
-    initializedMinipools := []api.MinipoolDetails{}
+    // Get initialized & prelaunch minipools
+    dissolvableMinipools := []api.MinipoolDetails{}
     for _, minipool := range status.Minipools {
-        if minipool.Status.Status == types.Initialized {
-            initializedMinipools = append(initializedMinipools, minipool)
+        if minipool.Status.Status == types.Initialized || minipool.Status.Status == types.Prelaunch {
+            dissolvableMinipools = append(dissolvableMinipools, minipool)
         }
     }
 
-    // Check for initialized minipools
-    if len(initializedMinipools) == 0 {
+    // Check for dissolvable minipools
+    if len(dissolvableMinipools) == 0 {
         fmt.Println("No minipools can be dissolved.")
         return nil
     }
 
     // Prompt for minipool selection
-    options := make([]string, len(initializedMinipools) + 1)
+    options := make([]string, len(dissolvableMinipools) + 1)
     options[0] = "All available minipools"
-    for mi, minipool := range initializedMinipools {
-        options[mi + 1] = fmt.Sprintf("%s (%.2f ETH deposited)", minipool.Address.Hex(), eth.WeiToEth(minipool.Node.DepositBalance))
+    for mi, minipool := range dissolvableMinipools {
+        options[mi + 1] = fmt.Sprintf("%s (%s deposited)", minipool.Address.Hex(), units.FormatEth(minipool.Node.DepositBalance))
     }
     selected, _ := cliutils.Select("Please select a minipool to dissolve:", options)
 
     // Get selected minipools
     var selectedMinipools []api.MinipoolDetails
     if selected == 0 {
-        selectedMinipools = initializedMinipools
+        selectedMinipools = dissolvableMinipools
     } else {
-        selectedMinipools = []api.MinipoolDetails{initializedMinipools[selected - 1]}
+        selectedMinipools = []api.MinipoolDetails{dissolvableMinipools[selected - 1]}
     }
 
+    // Check minipools can be dissolved
+    dissolveMinipools := []api.MinipoolDetails{}
+    gasInfos := []api.GasInfo{}
+    totalBalance := big.NewInt(0)
+    for _, minipool := range selectedMinipools {
+        canDissolve, err := rp.CanDissolveMinipool(minipool.Address)
+        if err != nil {
+            fmt.Printf("Could not check whether minipool %s can be dissolved: %s.\n", minipool.Address.Hex(), err)
+            continue
+        }
+        if !canDissolve.CanDissolve {
+            fmt.Printf("Minipool %s cannot be dissolved:\n", minipool.Address.Hex())
+            if canDissolve.InvalidStatus {
+                fmt.Println("The minipool is not initialized or in prelaunch.")
+            }
+            if canDissolve.LaunchTimeoutActive {
+                fmt.Println("The minipool is in prelaunch and its launch timeout has not passed yet.")
+            }
+            continue
+        }
+        dissolveMinipools = append(dissolveMinipools, minipool)
+        gasInfos = append(gasInfos, canDissolve.GasInfo)
+        totalBalance.Add(totalBalance, canDissolve.NodeDepositBalance)
+    }
+    if len(dissolveMinipools) == 0 {
+        return nil
+    }
+
+    // Print balance preview & gas estimate; minipools are closed after dissolving, which requires an additional transaction each
+    fmt.Printf("%s will be recovered to the node account from %d minipool(s).\n", units.FormatEth(totalBalance), len(dissolveMinipools))
+    cliutils.PrintTotalGasInfo(gasInfos)
+    fmt.Println("Each minipool will also be closed after it is dissolved, which requires an additional transaction.")
+
     // Prompt for confirmation
-    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to dissolve %d minipool(s)? This action cannot be undone!", len(selectedMinipools))) {
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to dissolve %d minipool(s)? This action cannot be undone!", len(dissolveMinipools))) {
         fmt.Println("Cancelled.")
         return nil
     }
 
     // Dissolve and close minipools
-    for _, minipool := range selectedMinipools {
+    // Each minipool takes two transactions, so the remaining count includes the close of the current minipool
+    for mi, minipool := range dissolveMinipools {
         if _, err := rp.DissolveMinipool(minipool.Address); err != nil {
+            if tx.HandleOfflineBatchTransaction(err, (len(dissolveMinipools) - mi) * 2 - 1) {
+                return nil
+            }
+            if tx.HandleDryRun(err) {
+                fmt.Println("The close transaction cannot be simulated until the minipool has been dissolved.")
+                fmt.Println("")
+                continue
+            }
             fmt.Printf("Could not dissolve minipool %s: %s.\n", minipool.Address.Hex(), err)
             continue
         } else {
             fmt.Printf("Successfully dissolved minipool %s.\n", minipool.Address.Hex())
         }
         if _, err := rp.CloseMinipool(minipool.Address); err != nil {
+            if tx.HandleOfflineBatchTransaction(err, (len(dissolveMinipools) - mi - 1) * 2) {
+                return nil
+            }
+            if tx.HandleDryRun(err) {
+                fmt.Println("")
+                continue
+            }
             fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
             fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
@@ -9,6 +9,7 @@ import (
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     "github.com/rocket-pool/smartnode/shared/types/api"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
 
@@ -55,22 +56,55 @@ func exitMinipools(c *cli.Context) error {
         selectedMinipools = []api.MinipoolDetails{stakingMinipools[selected - 1]}
     }
 
+    // Check minipools can be exited
+    exitableMinipools := []api.MinipoolDetails{}
+    for _, minipool := range selectedMinipools {
+        canExit, err := rp.CanExitMinipool(minipool.Address)
+        if err != nil {
+            fmt.Printf("Could not check whether minipool %s can be exited: %s.\n", minipool.Address.Hex(), err)
+            continue
+        }
+        if !canExit.CanExit {
+            fmt.Printf("Minipool %s cannot be exited:\n", minipool.Address.Hex())
+            if canExit.InvalidStatus {
+                fmt.Println("The minipool is not staking.")
+            }
+            if canExit.ValidatorNotActive {
+                fmt.Println("The minipool's validator is not active on the beacon chain yet.")
+            }
+            if canExit.ValidatorExiting {
+                fmt.Println("The minipool's validator is already exiting the beacon chain.")
+            }
+            if canExit.ActivationTooRecent {
+                fmt.Printf("The minipool's validator must be active for %d epochs before it can exit; it can exit from epoch %d (currently epoch %d).\n", validator.ShardCommitteePeriod, canExit.EarliestExitEpoch, canExit.CurrentEpoch)
+            }
+            continue
+        }
+        exitableMinipools = append(exitableMinipools, minipool)
+    }
+    if len(exitableMinipools) == 0 {
+        return nil
+    }
+
     // Prompt for confirmation
-    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to exit %d minipool(s)? This action cannot be undone!", len(selectedMinipools))) {
+    fmt.Println("Exiting a minipool's validator permanently stops it from validating; it will stop earning rewards once the exit is processed, and its balance cannot be withdrawn until withdrawals are enabled on the beacon chain.")
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to exit %d minipool(s)? This action cannot be undone!", len(exitableMinipools))) {
         fmt.Println("Cancelled.")
         return nil
     }
 
     // Exit minipools
-    for _, minipool := range selectedMinipools {
-        if _, err := rp.ExitMinipool(minipool.Address); err != nil {
+    for _, minipool := range exitableMinipools {
+        if response, err := rp.ExitMinipool(minipool.Address); err != nil {
             fmt.Printf("Could not exit minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
-            fmt.Printf("Successfully exited minipool %s.\n", minipool.Address.Hex())
+            fmt.Printf("Successfully submitted a voluntary exit for minipool %s (validator %d) at epoch %d.\n", minipool.Address.Hex(), response.ValidatorIndex, response.Epoch)
         }
     }
 
     // Return
+    fmt.Println("")
+    fmt.Println("It may take several epochs for the exit to be processed, depending on the beacon chain exit queue. Run 'rocketpool minipool status' to track the validator's exit.")
     return nil
 
 }
@@ -0,0 +1,68 @@
+package minipool
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+func getPerformance(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get minipool performance
+    response, err := rp.MinipoolPerformance()
+    if err != nil {
+        return err
+    }
+
+    // Check performance has been recorded
+    if !response.Tracked {
+        fmt.Println("Validator performance has not been recorded yet. The node daemon records it for each epoch once the clients have synced.")
+        return nil
+    }
+    if len(response.Minipools) == 0 {
+        fmt.Printf("The node does not have any validators yet (last checked epoch %d).\n", response.LastEpoch)
+        return nil
+    }
+
+    // Print & return
+    fmt.Printf("Validator performance up to epoch %d:\n", response.LastEpoch)
+    fmt.Println("")
+    for _, minipool := range response.Minipools {
+        cliutils.PrintSeparator("-----------------")
+        fmt.Printf("\n")
+        fmt.Printf("Address:              %s\n", minipool.Address.Hex())
+        if minipool.ActiveEpochs == 0 {
+        fmt.Printf("Validator:            not active yet\n")
+        fmt.Printf("\n")
+        continue
+        }
+        fmt.Printf("Validator index:      %d\n", minipool.ValidatorIndex)
+        fmt.Printf("Tracked since epoch:  %d (%d active epochs)\n", minipool.FirstEpoch, minipool.ActiveEpochs)
+        fmt.Printf("Attestations:         %d included, %d missed\n", minipool.Attestations, minipool.MissedAttestations)
+        fmt.Printf("Correct target/head:  %d / %d\n", minipool.CorrectTargets, minipool.CorrectHeads)
+        fmt.Printf("Effectiveness:        %.2f%%\n", minipool.Effectiveness)
+        if minipool.AverageInclusionDistance > 0 {
+        fmt.Printf("Inclusion distance:   %.2f slots on average\n", minipool.AverageInclusionDistance)
+        }
+        fmt.Printf("Block proposals:      %d proposed, %d missed\n", minipool.Proposals, minipool.MissedProposals)
+        if minipool.MissedAttestations > 0 {
+        fmt.Printf("Last missed epoch:    %d\n", minipool.LastMissedAttestationEpoch)
+        }
+        if minipool.MissedProposals > 0 {
+        fmt.Printf("Last missed slot:     %d\n", minipool.LastMissedProposalSlot)
+        }
+        fmt.Printf("\n")
+    }
+    return nil
+
+}
+
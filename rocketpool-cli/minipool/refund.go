@@ -2,13 +2,15 @@ package minipool
 
 import (
     "fmt"
+    "math/big"
 
-    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/rocketpool-cli/tx"
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     "github.com/rocket-pool/smartnode/shared/types/api"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -43,7 +45,7 @@ func refundMinipools(c *cli.Context) error {
     options := make([]string, len(refundableMinipools) + 1)
     options[0] = "All available minipools"
     for mi, minipool := range refundableMinipools {
-        options[mi + 1] = fmt.Sprintf("%s (%.2f ETH to claim)", minipool.Address.Hex(), eth.WeiToEth(minipool.Node.RefundBalance))
+        options[mi + 1] = fmt.Sprintf("%s (%s to claim)", minipool.Address.Hex(), units.FormatEth(minipool.Node.RefundBalance))
     }
     selected, _ := cliutils.Select("Please select a minipool to refund ETH from:", options)
 
@@ -55,9 +57,51 @@ func refundMinipools(c *cli.Context) error {
         selectedMinipools = []api.MinipoolDetails{refundableMinipools[selected - 1]}
     }
 
-    // Refund minipools
+    // Check minipools can be refunded
+    refundMinipools := []api.MinipoolDetails{}
+    gasInfos := []api.GasInfo{}
+    totalRefund := big.NewInt(0)
     for _, minipool := range selectedMinipools {
+        canRefund, err := rp.CanRefundMinipool(minipool.Address)
+        if err != nil {
+            fmt.Printf("Could not check whether minipool %s can be refunded: %s.\n", minipool.Address.Hex(), err)
+            continue
+        }
+        if !canRefund.CanRefund {
+            fmt.Printf("Minipool %s cannot be refunded:\n", minipool.Address.Hex())
+            if canRefund.InsufficientRefundBalance {
+                fmt.Println("The minipool has no ETH available to refund.")
+            }
+            continue
+        }
+        refundMinipools = append(refundMinipools, minipool)
+        gasInfos = append(gasInfos, canRefund.GasInfo)
+        totalRefund.Add(totalRefund, canRefund.RefundBalance)
+    }
+    if len(refundMinipools) == 0 {
+        return nil
+    }
+
+    // Print refund preview & gas estimate
+    fmt.Printf("%s will be refunded to the node account from %d minipool(s).\n", units.FormatEth(totalRefund), len(refundMinipools))
+    cliutils.PrintTotalGasInfo(gasInfos)
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to refund ETH from %d minipool(s)?", len(refundMinipools))) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Refund minipools
+    for mi, minipool := range refundMinipools {
         if _, err := rp.RefundMinipool(minipool.Address); err != nil {
+            if tx.HandleOfflineBatchTransaction(err, len(refundMinipools) - mi - 1) {
+                return nil
+            }
+            if tx.HandleDryRun(err) {
+                fmt.Println("")
+                continue
+            }
             fmt.Printf("Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
             fmt.Printf("Successfully refunded ETH from minipool %s.\n", minipool.Address.Hex())
@@ -4,12 +4,13 @@ import (
     "fmt"
 
     "github.com/rocket-pool/rocketpool-go/types"
-    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
     "github.com/rocket-pool/smartnode/shared/utils/hex"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -63,16 +64,16 @@ func getStatus(c *cli.Context) error {
         fmt.Printf("%d %s minipool(s):\n", len(minipools), statusName)
         fmt.Println("")
         for _, minipool := range minipools {
-            fmt.Printf("-----------------\n")
+            cliutils.PrintSeparator("-----------------")
             fmt.Printf("\n")
             fmt.Printf("Address:           %s\n", minipool.Address.Hex())
             fmt.Printf("Status updated:    %s\n", minipool.Status.StatusTime.Format(TimeFormat))
             fmt.Printf("Node fee:          %f%%\n", minipool.Node.Fee * 100)
-            fmt.Printf("Node deposit:      %.2f ETH\n", eth.WeiToEth(minipool.Node.DepositBalance))
+            fmt.Printf("Node deposit:      %s\n", units.FormatEth(minipool.Node.DepositBalance))
             if minipool.Status.Status == types.Prelaunch || minipool.Status.Status == types.Staking {
             if minipool.User.DepositAssigned {
             fmt.Printf("RP ETH assigned:   %s\n", minipool.User.DepositAssignedTime.Format(TimeFormat))
-            fmt.Printf("RP deposit:        %.2f ETH\n", eth.WeiToEth(minipool.User.DepositBalance))
+            fmt.Printf("RP deposit:        %s\n", units.FormatEth(minipool.User.DepositBalance))
             } else {
             fmt.Printf("RP ETH assigned:   no\n")
             }
@@ -85,14 +86,18 @@ func getStatus(c *cli.Context) error {
             } else {
             fmt.Printf("Validator active:  no\n")
             }
-            fmt.Printf("Validator balance: %.2f ETH\n", eth.WeiToEth(minipool.Validator.Balance))
-            fmt.Printf("Expected rewards:  %.2f ETH\n", eth.WeiToEth(minipool.Validator.NodeBalance))
+            fmt.Printf("Validator balance: %s\n", units.FormatEth(minipool.Validator.Balance))
+            fmt.Printf("Expected rewards:  %s\n", units.FormatEth(minipool.Validator.NodeBalance))
+            if minipool.Validator.Exiting {
+            fmt.Printf("Validator exit:    epoch %d\n", minipool.Validator.ExitEpoch)
+            fmt.Printf("Withdrawable:      epoch %d\n", minipool.Validator.WithdrawableEpoch)
+            }
             } else {
             fmt.Printf("Validator seen:    no\n")
             }
             }
             if minipool.Status.Status == types.Withdrawable {
-            fmt.Printf("Final balance:     %.2f ETH\n", eth.WeiToEth(minipool.Staking.EndBalance))
+            fmt.Printf("Final balance:     %s\n", units.FormatEth(minipool.Staking.EndBalance))
             }
             fmt.Printf("\n")
         }
@@ -101,21 +106,21 @@ func getStatus(c *cli.Context) error {
     if len(refundableMinipools) > 0 {
         fmt.Printf("%d minipools have refunds available:\n", len(refundableMinipools))
         for _, minipool := range refundableMinipools {
-            fmt.Printf("- %s (%.2f ETH to claim)\n", minipool.Address.Hex(), eth.WeiToEth(minipool.Node.RefundBalance))
+            fmt.Printf("- %s (%s to claim)\n", minipool.Address.Hex(), units.FormatEth(minipool.Node.RefundBalance))
         }
         fmt.Println("")
     }
     if len(withdrawableMinipools) > 0 {
         fmt.Printf("%d minipools are ready for withdrawal:\n", len(withdrawableMinipools))
         for _, minipool := range withdrawableMinipools {
-            fmt.Printf("- %s (%.2f nETH to claim)\n", minipool.Address.Hex(), eth.WeiToEth(minipool.Balances.NETH))
+            fmt.Printf("- %s (%s to claim)\n", minipool.Address.Hex(), units.FormatToken(minipool.Balances.NETH, units.TokenDecimals, "nETH"))
         }
         fmt.Println("")
     }
     if len(closeableMinipools) > 0 {
         fmt.Printf("%d dissolved minipools can be closed:\n", len(closeableMinipools))
         for _, minipool := range closeableMinipools {
-            fmt.Printf("- %s (%.2f ETH to claim)\n", minipool.Address.Hex(), eth.WeiToEth(minipool.Node.DepositBalance))
+            fmt.Printf("- %s (%s to claim)\n", minipool.Address.Hex(), units.FormatEth(minipool.Node.DepositBalance))
         }
         fmt.Println("")
     }
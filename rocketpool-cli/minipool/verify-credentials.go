@@ -0,0 +1,51 @@
+package minipool
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+
+func verifyCredentials(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Verify minipool withdrawal credentials
+    response, err := rp.VerifyMinipoolCredentials()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(response.Minipools) == 0 {
+        fmt.Println("The node does not have any minipool validators yet.")
+        return nil
+    }
+    fmt.Printf("Expected withdrawal credentials: %s\n", response.ExpectedCredentials.Hex())
+    fmt.Println("")
+    for _, minipool := range response.Minipools {
+        if !minipool.ValidatorExists {
+            cliutils.PrintStatus(cliutils.StatusWarn, fmt.Sprintf("%s: validator %s has not been seen on the beacon chain yet", minipool.Address.Hex(), hex.AddPrefix(minipool.ValidatorPubkey.Hex())))
+        } else if minipool.Match {
+            cliutils.PrintStatus(cliutils.StatusOK, minipool.Address.Hex())
+        } else {
+            cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("%s: mismatch - validator %s has withdrawal credentials %s", minipool.Address.Hex(), hex.AddPrefix(minipool.ValidatorPubkey.Hex()), minipool.WithdrawalCredentials.Hex()))
+        }
+    }
+    fmt.Println("")
+    if response.MismatchCount > 0 {
+        fmt.Printf("%d minipool validator(s) have withdrawal credentials which do not match the network. Do not make further deposits until this has been investigated.\n", response.MismatchCount)
+    } else {
+        fmt.Println("All minipool validators seen on the beacon chain have the expected withdrawal credentials.")
+    }
+    return nil
+
+}
@@ -2,13 +2,15 @@ package minipool
 
 import (
     "fmt"
+    "math/big"
 
-    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/rocketpool-cli/tx"
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     "github.com/rocket-pool/smartnode/shared/types/api"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -43,7 +45,7 @@ func withdrawMinipools(c *cli.Context) error {
     options := make([]string, len(withdrawableMinipools) + 1)
     options[0] = "All available minipools"
     for mi, minipool := range withdrawableMinipools {
-        options[mi + 1] = fmt.Sprintf("%s (%.2f nETH to claim)", minipool.Address.Hex(), eth.WeiToEth(minipool.Balances.NETH))
+        options[mi + 1] = fmt.Sprintf("%s (%s to claim)", minipool.Address.Hex(), units.FormatToken(minipool.Balances.NETH, units.TokenDecimals, "nETH"))
     }
     selected, _ := cliutils.Select("Please select a minipool to withdraw from:", options)
 
@@ -55,9 +57,60 @@ func withdrawMinipools(c *cli.Context) error {
         selectedMinipools = []api.MinipoolDetails{withdrawableMinipools[selected - 1]}
     }
 
-    // Withdraw minipools
+    // Simulate minipool claims
+    withdrawMinipools := []api.MinipoolDetails{}
+    gasInfos := []api.GasInfo{}
+    totalNETH := big.NewInt(0)
+    totalETH := big.NewInt(0)
     for _, minipool := range selectedMinipools {
-        if _, err := rp.CloseMinipool(minipool.Address); err != nil {
+        canWithdraw, err := rp.CanWithdrawMinipool(minipool.Address)
+        if err != nil {
+            fmt.Printf("Could not check whether minipool %s can be withdrawn from: %s.\n", minipool.Address.Hex(), err)
+            continue
+        }
+        if !canWithdraw.CanWithdraw {
+            fmt.Printf("Minipool %s cannot be withdrawn from:\n", minipool.Address.Hex())
+            if canWithdraw.InvalidStatus {
+                fmt.Println("The minipool is not withdrawable.")
+            }
+            if canWithdraw.WithdrawalDelayActive {
+                fmt.Println("The minipool withdrawal delay has not passed.")
+            }
+            continue
+        }
+        fmt.Printf("Minipool %s has %s and %s to claim.\n", minipool.Address.Hex(), units.FormatToken(canWithdraw.NETHAmount, units.TokenDecimals, "nETH"), units.FormatEth(canWithdraw.ETHAmount))
+        if canWithdraw.BelowMinClaimGasRatio {
+            cliutils.PrintStatus(cliutils.StatusWarn, fmt.Sprintf("The value of the minipool %s claim is less than %.2f times its estimated gas cost.", minipool.Address.Hex(), canWithdraw.MinClaimGasRatio))
+        }
+        withdrawMinipools = append(withdrawMinipools, minipool)
+        gasInfos = append(gasInfos, canWithdraw.GasInfo)
+        totalNETH.Add(totalNETH, canWithdraw.NETHAmount)
+        totalETH.Add(totalETH, canWithdraw.ETHAmount)
+    }
+    if len(withdrawMinipools) == 0 {
+        return nil
+    }
+
+    // Print claim preview & gas estimate
+    fmt.Printf("%s and %s will be claimed from %d minipool(s).\n", units.FormatToken(totalNETH, units.TokenDecimals, "nETH"), units.FormatEth(totalETH), len(withdrawMinipools))
+    cliutils.PrintTotalGasInfo(gasInfos)
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to withdraw from %d minipool(s)?", len(withdrawMinipools))) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Withdraw minipools
+    for mi, minipool := range withdrawMinipools {
+        if _, err := rp.WithdrawMinipool(minipool.Address); err != nil {
+            if tx.HandleOfflineBatchTransaction(err, len(withdrawMinipools) - mi - 1) {
+                return nil
+            }
+            if tx.HandleDryRun(err) {
+                fmt.Println("")
+                continue
+            }
             fmt.Printf("Could not withdraw from minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
             fmt.Printf("Successfully withdrew from minipool %s.\n", minipool.Address.Hex())
@@ -1,6 +1,9 @@
 package network
 
 import (
+    "errors"
+    "time"
+
     "github.com/urfave/cli"
 
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -31,6 +34,79 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "stats",
+                Aliases:   []string{"s"},
+                Usage:     "Get network-wide Rocket Pool statistics, such as total ETH staked, the rETH exchange rate and the minipool queue",
+                UsageText: "rocketpool network stats [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "at-block",
+                        Usage: "Read the network stats at a past block `number`; requires an archive node",
+                    },
+                    cli.StringFlag{
+                        Name:  "at-date",
+                        Usage: "Read the network stats at the last block before a `date` (YYYY-MM-DD, optionally with HH:MM); requires an archive node",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    var atDate time.Time
+                    if c.String("at-date") != "" {
+                        if c.IsSet("at-block") {
+                            return errors.New("Only one of --at-block and --at-date may be set.")
+                        }
+                        var err error
+                        atDate, err = cliutils.ValidateDate("date", c.String("at-date"))
+                        if err != nil { return err }
+                    }
+
+                    // Run
+                    return getStats(c, c.Uint64("at-block"), atDate)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "settings",
+                Aliases:   []string{"t"},
+                Usage:     "Get the network's on-chain protocol settings, such as deposit limits and the node commission rate range",
+                UsageText: "rocketpool network settings [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "json, j",
+                        Usage: "Print the settings as JSON",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getSettings(c, c.Bool("json"))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "queue",
+                Aliases:   []string{"q"},
+                Usage:     "Get the deposit pool and minipool queue status, the node's minipools' queue positions and estimated times to assignment",
+                UsageText: "rocketpool network queue",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getQueue(c)
+
+                },
+            },
+
         },
     })
 }
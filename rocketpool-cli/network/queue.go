@@ -0,0 +1,59 @@
+package network
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+func getQueue(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get queue status
+    queue, err := rp.NetworkQueue()
+    if err != nil {
+        return err
+    }
+
+    // Print queue status
+    fmt.Printf("The deposit pool has a balance of %s.\n", units.FormatEth(queue.DepositPoolBalance))
+    fmt.Printf("There are %d available minipools with a total capacity of %s:\n", queue.MinipoolQueueLength, units.FormatEth(queue.MinipoolQueueCapacity))
+    fmt.Printf("  Half deposit queue:  %d (assigned first)\n", queue.HalfQueueLength)
+    fmt.Printf("  Full deposit queue:  %d\n", queue.FullQueueLength)
+    fmt.Printf("  Empty deposit queue: %d (assigned last)\n", queue.EmptyQueueLength)
+    fmt.Println("")
+
+    // Print assignment rate
+    if queue.AssignmentRate > 0 {
+        fmt.Printf("%d minipools were assigned deposits in the last week, an average of %.1f per day.\n", queue.RecentAssignments, queue.AssignmentRate)
+    } else {
+        fmt.Println("No minipools were assigned deposits in the last week, so times to assignment cannot be estimated.")
+    }
+
+    // Print node minipools
+    if len(queue.NodeMinipools) == 0 {
+        fmt.Println("The node has no minipools waiting in the queue.")
+        return nil
+    }
+    fmt.Println("")
+    fmt.Printf("The node has %d minipool(s) waiting in the queue:\n", len(queue.NodeMinipools))
+    for _, mp := range queue.NodeMinipools {
+        if queue.AssignmentRate > 0 {
+            fmt.Printf("  %s (%s deposit): position %d, estimated assignment in %.1f days\n", mp.Address.Hex(), mp.DepositType.String(), mp.Position, float64(mp.Position) / queue.AssignmentRate)
+        } else {
+            fmt.Printf("  %s (%s deposit): position %d\n", mp.Address.Hex(), mp.DepositType.String(), mp.Position)
+        }
+    }
+    fmt.Println("")
+    fmt.Println("Estimates assume deposits continue at last week's rate; minipools joining the half deposit queue are assigned ahead of full and empty deposit minipools.")
+    return nil
+
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+    "encoding/json"
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+func getSettings(c *cli.Context, asJSON bool) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get network settings
+    settings, err := rp.NetworkSettings()
+    if err != nil {
+        return err
+    }
+
+    // Print JSON & return
+    if asJSON {
+        settingsBytes, err := json.MarshalIndent(struct {
+            Deposit interface{}     `json:"deposit"`
+            Minipool interface{}    `json:"minipool"`
+            Network interface{}     `json:"network"`
+            Node interface{}        `json:"node"`
+        }{settings.Deposit, settings.Minipool, settings.Network, settings.Node}, "", "    ")
+        if err != nil {
+            return fmt.Errorf("Could not encode network settings: %w", err)
+        }
+        fmt.Println(string(settingsBytes))
+        return nil
+    }
+
+    // Print & return
+    fmt.Println("Deposit settings:")
+    fmt.Printf("  Deposit assignment enabled:        %s\n", formatEnabled(settings.Deposit.AssignDepositsEnabled))
+    fmt.Printf("  Maximum assignments per deposit:   %d\n", settings.Deposit.MaximumDepositAssignments)
+    fmt.Println("")
+    fmt.Println("Minipool settings:")
+    fmt.Printf("  Full deposit node amount:          %s\n", units.FormatEth(settings.Minipool.FullDepositNodeAmount))
+    fmt.Printf("  Half deposit node amount:          %s\n", units.FormatEth(settings.Minipool.HalfDepositNodeAmount))
+    fmt.Printf("  Empty deposit node amount:         %s\n", units.FormatEth(settings.Minipool.EmptyDepositNodeAmount))
+    fmt.Printf("  Withdrawable submissions:          %s\n", formatEnabled(settings.Minipool.SubmitWithdrawableEnabled))
+    fmt.Printf("  Launch timeout:                    %d blocks\n", settings.Minipool.LaunchTimeout)
+    fmt.Printf("  Withdrawal delay:                  %d blocks\n", settings.Minipool.WithdrawalDelay)
+    fmt.Println("")
+    fmt.Println("Network settings:")
+    fmt.Printf("  Balance submissions:               %s\n", formatEnabled(settings.Network.SubmitBalancesEnabled))
+    fmt.Printf("  Balance submission frequency:      %d blocks\n", settings.Network.SubmitBalancesFrequency)
+    fmt.Printf("  Withdrawal processing:             %s\n", formatEnabled(settings.Network.ProcessWithdrawalsEnabled))
+    fmt.Printf("  Node commission rate range:        %f%% - %f%% (target %f%%)\n", settings.Network.MinimumNodeFee * 100, settings.Network.MaximumNodeFee * 100, settings.Network.TargetNodeFee * 100)
+    fmt.Println("")
+    fmt.Println("Node settings:")
+    fmt.Printf("  Node registration:                 %s\n", formatEnabled(settings.Node.RegistrationEnabled))
+    fmt.Printf("  Node deposits:                     %s\n", formatEnabled(settings.Node.DepositEnabled))
+    return nil
+
+}
+
+
+// Format an enabled setting for display
+func formatEnabled(enabled bool) string {
+    if enabled {
+        return "enabled"
+    }
+    return "disabled"
+}
+
@@ -0,0 +1,56 @@
+package network
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+func getStats(c *cli.Context, atBlock uint64, atDate time.Time) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get the block to read at
+    if !atDate.IsZero() {
+        block, err := rp.GetBlockAtTime(atDate)
+        if err != nil { return err }
+        atBlock = block.Block
+    }
+
+    // Get network stats
+    var stats api.NetworkStatsResponse
+    if atBlock > 0 {
+        stats, err = rp.NetworkStatsAt(atBlock)
+    } else {
+        stats, err = rp.NetworkStats()
+    }
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if stats.Block > 0 {
+        fmt.Printf("Showing network stats at block %d (%s).\n\n", stats.Block, stats.BlockTime.Format(time.RFC1123))
+    }
+    fmt.Printf("Network balances (as of block %d):\n", stats.BalancesBlock)
+    fmt.Printf("  Total ETH:          %s\n", units.FormatEth(stats.TotalETHBalance))
+    fmt.Printf("  Staking ETH:        %s\n", units.FormatEth(stats.StakingETHBalance))
+    fmt.Printf("  rETH supply:        %s\n", units.FormatEth(stats.RETHSupply))
+    fmt.Printf("  rETH exchange rate: %.6f ETH per rETH\n", stats.RETHExchangeRate)
+    fmt.Println("")
+    fmt.Printf("There are %d registered nodes running %d minipools.\n", stats.NodeCount, stats.MinipoolCount)
+    fmt.Printf("The deposit pool has a balance of %s.\n", units.FormatEth(stats.DepositPoolBalance))
+    fmt.Printf("There are %d available minipools with a total capacity of %s.\n", stats.MinipoolQueueLength, units.FormatEth(stats.MinipoolQueueCapacity))
+    fmt.Printf("The current network node commission rate is %f%%.\n", stats.NodeFee * 100)
+    return nil
+
+}
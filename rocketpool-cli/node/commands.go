@@ -1,8 +1,12 @@
 package node
 
 import (
+    "errors"
+    "time"
+
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/shared/utils/attestation"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -19,14 +23,33 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 Name:      "status",
                 Aliases:   []string{"s"},
                 Usage:     "Get the node's status",
-                UsageText: "rocketpool node status",
+                UsageText: "rocketpool node status [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "at-block",
+                        Usage: "Read the node status at a past block `number`; requires an archive node",
+                    },
+                    cli.StringFlag{
+                        Name:  "at-date",
+                        Usage: "Read the node status at the last block before a `date` (YYYY-MM-DD, optionally with HH:MM); requires an archive node",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    var atDate time.Time
+                    if c.String("at-date") != "" {
+                        if c.IsSet("at-block") {
+                            return errors.New("Only one of --at-block and --at-date may be set.")
+                        }
+                        var err error
+                        atDate, err = cliutils.ValidateDate("date", c.String("at-date"))
+                        if err != nil { return err }
+                    }
 
                     // Run
-                    return getStatus(c)
+                    return getStatus(c, c.Uint64("at-block"), atDate)
 
                 },
             },
@@ -79,10 +102,88 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "rewards",
+                Aliases:   []string{"w"},
+                Usage:     "Display the node's rewards claim history",
+                UsageText: "rocketpool node rewards",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getRewards(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "tx-queue",
+                Aliases:   []string{"q"},
+                Usage:     "Display the node's pending transactions",
+                UsageText: "rocketpool node tx-queue",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getTxQueue(c)
+
+                },
+                Subcommands: []cli.Command{
+
+                    cli.Command{
+                        Name:      "cancel",
+                        Aliases:   []string{"c"},
+                        Usage:     "Cancel a pending node transaction",
+                        UsageText: "rocketpool node tx-queue cancel nonce",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                            nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+                            if err != nil { return err }
+
+                            // Run
+                            return cancelTransaction(c, nonce)
+
+                        },
+                    },
+
+                },
+            },
+
+            cli.Command{
+                Name:      "diff",
+                Aliases:   []string{"f"},
+                Usage:     "Show what has changed in the node's balances, collateral, minipools & settings since a recorded snapshot",
+                UsageText: "rocketpool node diff [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "since, s",
+                        Usage: "How long ago to compare against, as a `duration` such as 12h, 7d or 2w",
+                        Value: "7d",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    since, err := cliutils.ValidateAge("since", c.String("since"))
+                    if err != nil { return err }
+
+                    // Run
+                    return getDiff(c, since)
+
+                },
+            },
+
             cli.Command{
                 Name:      "send",
                 Aliases:   []string{"n"},
-                Usage:     "Send ETH or tokens from the node account to an address",
+                Usage:     "Send ETH, nETH or rETH from the node account to an address or ENS name",
                 UsageText: "rocketpool node send amount token to",
                 Action: func(c *cli.Context) error {
 
@@ -92,11 +193,68 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                     if err != nil { return err }
                     token, err := cliutils.ValidateTokenType("token type", c.Args().Get(1))
                     if err != nil { return err }
-                    toAddress, err := cliutils.ValidateAddress("to address", c.Args().Get(2))
+                    to, err := cliutils.ValidateAddressOrENSName("to address", c.Args().Get(2))
                     if err != nil { return err }
 
                     // Run
-                    return nodeSend(c, amount, token, toAddress)
+                    return nodeSend(c, amount, token, to)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "prove-ownership",
+                Aliases:   []string{"p"},
+                Usage:     "Sign an attestation that you control the node account, in response to a challenge from a third-party service",
+                UsageText: "rocketpool node prove-ownership [options] challenge",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "output, o",
+                        Usage: "Save the attestation to a file at `path` instead of printing it",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    challenge := c.Args().Get(0)
+                    if err := attestation.ValidateChallenge(challenge); err != nil { return err }
+
+                    // Run
+                    return proveOwnership(c, challenge)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "verify-ownership",
+                Aliases:   []string{"v"},
+                Usage:     "Verify a node ownership attestation, from a file, inline JSON or '-' for stdin",
+                UsageText: "rocketpool node verify-ownership [options] attestation",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "challenge, c",
+                        Usage: "Require the attestation to be signed for this challenge",
+                    },
+                    cli.StringFlag{
+                        Name:  "address, a",
+                        Usage: "Require the attestation to be signed by this node address or ENS name",
+                    },
+                    cli.DurationFlag{
+                        Name:  "max-age, m",
+                        Usage: "Require the attestation to be signed within this duration (e.g. 10m)",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    if c.String("address") != "" {
+                        if _, err := cliutils.ValidateAddressOrENSName("address", c.String("address")); err != nil { return err }
+                    }
+
+                    // Run
+                    return verifyOwnership(c, c.Args().Get(0))
 
                 },
             },
@@ -8,6 +8,7 @@ import (
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -81,14 +82,27 @@ func nodeDeposit(c *cli.Context) error {
     // Prompt for minimum node fee
     minNodeFee := promptMinNodeFee(nodeFees.NodeFee, suggestedMinNodeFee)
 
+    // Display collateral requirement; the current protocol version does not require RPL to be staked against minipools
+    fmt.Println("No RPL collateral is required to create a minipool.")
+
+    // Display gas estimate
+    cliutils.PrintGasInfo(canDeposit.GasInfo)
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to deposit %s to create a minipool with a minimum possible commission rate of %f%%?", units.FormatEth(amountWei), minNodeFee * 100)) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
     // Make deposit
+    fmt.Println("Making node deposit and waiting for the minipool to be created...")
     response, err := rp.NodeDeposit(amountWei, minNodeFee)
     if err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("The node deposit of %.2f ETH was made successfully.\n", eth.WeiToEth(amountWei))
+    fmt.Printf("The node deposit of %s was made successfully.\n", units.FormatEth(amountWei))
     fmt.Printf("A new minipool was created at %s.\n", response.MinipoolAddress.Hex())
     return nil
 
@@ -0,0 +1,79 @@
+package node
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Category titles
+var diffCategories = []struct{ Name, Title string }{
+    {"node", "Node"},
+    {"balances", "Balances & collateral"},
+    {"minipools", "Minipools"},
+    {"config", "Settings"},
+}
+
+
+func getDiff(c *cli.Context, since time.Duration) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get node diff
+    response, err := rp.NodeDiff(since.String())
+    if err != nil {
+        return err
+    }
+
+    // Check snapshot
+    if !response.HasSnapshot {
+        fmt.Println("No node snapshots have been recorded yet. The node daemon records a snapshot every 6 hours.")
+        return nil
+    }
+
+    // Print header
+    if response.SnapshotTime.After(response.CurrentTime.Add(-since)) {
+        fmt.Println("No snapshot was recorded that long ago; showing changes since the oldest snapshot.")
+    }
+    fmt.Printf("Changes since the snapshot at %s (block %d), to now (block %d):\n", response.SnapshotTime.Local().Format("2006-01-02 15:04"), response.SnapshotBlock, response.CurrentBlock)
+    if len(response.Changes) == 0 {
+        fmt.Println("Nothing has changed.")
+        return nil
+    }
+
+    // Print changes by category
+    for _, category := range diffCategories {
+        printed := false
+        for _, change := range response.Changes {
+            if change.Category != category.Name {
+                continue
+            }
+            if !printed {
+                fmt.Println("")
+                fmt.Printf("%s:\n", category.Title)
+                printed = true
+            }
+            switch {
+                case change.Old == "" && change.Category == "minipools":
+                    fmt.Printf("  %s: %s\n", change.Item, change.New)
+                case change.Old == "":
+                    fmt.Printf("  %s: (unset) -> %s\n", change.Item, change.New)
+                case change.New == "":
+                    fmt.Printf("  %s: %s -> (unset)\n", change.Item, change.Old)
+                default:
+                    fmt.Printf("  %s: %s -> %s\n", change.Item, change.Old, change.New)
+            }
+        }
+    }
+
+    // Return
+    return nil
+
+}
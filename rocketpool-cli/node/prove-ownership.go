@@ -0,0 +1,132 @@
+package node
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/attestation"
+)
+
+
+// Config
+const AttestationFileMode = 0644
+
+
+func proveOwnership(c *cli.Context, challenge string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Sign attestation
+    response, err := rp.ProveNodeOwnership(challenge)
+    if err != nil {
+        return err
+    }
+    attestationBytes, err := json.MarshalIndent(response.Attestation, "", "    ")
+    if err != nil {
+        return fmt.Errorf("Could not encode attestation: %w", err)
+    }
+
+    // Print attestation
+    path := c.String("output")
+    if path == "" {
+        fmt.Println(string(attestationBytes))
+        return nil
+    }
+
+    // Write attestation
+    if err := ioutil.WriteFile(path, append(attestationBytes, '\n'), AttestationFileMode); err != nil {
+        return fmt.Errorf("Could not write attestation file: %w", err)
+    }
+
+    // Log & return
+    fmt.Printf("Node %s signed an ownership attestation for challenge '%s', saved to %s.\n", response.Attestation.Address.Hex(), challenge, path)
+    fmt.Println("Send the file to the service which issued the challenge; it can be checked with 'rocketpool node verify-ownership <file>'.")
+    return nil
+
+}
+
+
+func verifyOwnership(c *cli.Context, source string) error {
+
+    // Read attestation from file, stdin or argument
+    var attestationBytes []byte
+    var err error
+    switch {
+        case source == "-":
+            attestationBytes, err = ioutil.ReadAll(os.Stdin)
+        case strings.HasPrefix(strings.TrimSpace(source), "{"):
+            attestationBytes = []byte(source)
+        default:
+            attestationBytes, err = ioutil.ReadFile(source)
+    }
+    if err != nil {
+        return fmt.Errorf("Could not read attestation: %w", err)
+    }
+    var a attestation.Attestation
+    if err := json.Unmarshal(attestationBytes, &a); err != nil {
+        return fmt.Errorf("Could not decode attestation: %w", err)
+    }
+
+    // Verify signature
+    if err := a.Verify(); err != nil {
+        return fmt.Errorf("The attestation is NOT valid: %w", err)
+    }
+
+    // Check expected details
+    if challenge := c.String("challenge"); challenge != "" && a.Challenge != challenge {
+        return fmt.Errorf("The attestation is NOT valid: it was signed for challenge '%s', not '%s'", a.Challenge, challenge)
+    }
+    if address := c.String("address"); address != "" {
+        expectedAddress, expectedDescription, err := resolveExpectedAddress(c, address)
+        if err != nil {
+            return err
+        }
+        if a.Address != expectedAddress {
+            return fmt.Errorf("The attestation is NOT valid: it was signed by node %s, not %s", a.Address.Hex(), expectedDescription)
+        }
+    }
+    if maxAge := c.Duration("max-age"); maxAge > 0 && time.Since(a.Timestamp) > maxAge {
+        return fmt.Errorf("The attestation is NOT valid: it was signed at %s, more than %s ago", a.Timestamp.Format(time.RFC3339), maxAge)
+    }
+
+    // Log & return
+    fmt.Println("The attestation is valid.")
+    fmt.Printf("Node:              %s\n", a.Address.Hex())
+    fmt.Printf("Challenge:         %s\n", a.Challenge)
+    fmt.Printf("Signed at:         %s\n", a.Timestamp.Format(time.RFC3339))
+    fmt.Printf("Smartnode version: %s\n", a.SmartnodeVersion)
+    return nil
+
+}
+
+// Get the expected attestation address and its description for display
+// Addresses are checked offline; ENS names are resolved by the smart node
+func resolveExpectedAddress(c *cli.Context, value string) (common.Address, string, error) {
+
+    // Check address
+    if common.IsHexAddress(value) {
+        address := common.HexToAddress(value)
+        return address, address.Hex(), nil
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return common.Address{}, "", err }
+    defer rp.Close()
+
+    // Resolve name
+    return rp.ResolveAddress(value)
+
+}
+
@@ -0,0 +1,54 @@
+package node
+
+import (
+    "fmt"
+
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+func getRewards(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get node rewards
+    response, err := rp.NodeRewards()
+    if err != nil {
+        return err
+    }
+
+    // Print auto-claim settings
+    if response.AutoWithdrawEnabled {
+        if response.AutoWithdrawMaxGasPrice > 0 {
+            fmt.Printf("Rewards are claimed automatically when the gas price is at or below %s.\n", units.FormatGwei(eth.GweiToWei(response.AutoWithdrawMaxGasPrice)))
+        } else {
+            fmt.Println("Rewards are claimed automatically.")
+        }
+    } else {
+        fmt.Println("Automatic rewards claims are disabled.")
+    }
+    fmt.Println("")
+
+    // Print claims & return
+    if len(response.Claims) == 0 {
+        fmt.Println("The node has not claimed any rewards yet.")
+        return nil
+    }
+    fmt.Printf("The node has claimed a total of %s in %d claim(s):\n", units.FormatToken(response.TotalAmount, units.TokenDecimals, "nETH"), len(response.Claims))
+    for _, claim := range response.Claims {
+        method := "manual"
+        if claim.Automatic {
+            method = "automatic"
+        }
+        fmt.Printf("- %s: %s from minipool %s (%s, tx %s)\n", claim.Time.Format("2006-01-02 15:04"), units.FormatToken(claim.Amount, units.TokenDecimals, "nETH"), claim.MinipoolAddress.Hex(), method, claim.TxHash.Hex())
+    }
+    return nil
+
+}
@@ -3,16 +3,16 @@ package node
 import (
     "fmt"
 
-    "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
-func nodeSend(c *cli.Context, amount float64, token string, toAddress common.Address) error {
+func nodeSend(c *cli.Context, amount float64, token string, to string) error {
 
     // Get RP client
     rp, err := rocketpool.NewClientFromCtx(c)
@@ -22,21 +22,30 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddress common.Add
     // Get amount in wei
     amountWei := eth.EthToWei(amount)
 
+    // Resolve recipient
+    toAddress, toDescription, err := rp.ResolveAddress(to)
+    if err != nil {
+        return err
+    }
+
     // Check tokens can be sent
-    canSend, err := rp.CanNodeSend(amountWei, token)
+    canSend, err := rp.CanNodeSend(amountWei, token, toAddress)
     if err != nil {
         return err
     }
     if !canSend.CanSend {
         fmt.Println("Cannot send tokens:")
         if canSend.InsufficientBalance {
-            fmt.Printf("The node's %s balance is insufficient.\n", token)
+            fmt.Printf("The node's %s balance is insufficient.\n", units.TokenSymbol(token))
         }
         return nil
     }
 
+    // Display gas estimate
+    cliutils.PrintGasInfo(canSend.GasInfo)
+
     // Prompt for confirmation
-    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to send %.2f %s to %s? This action cannot be undone!", eth.WeiToEth(amountWei), token, toAddress.Hex())) {
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to send %s to %s? This action cannot be undone!", units.FormatToken(amountWei, units.TokenDecimals, units.TokenSymbol(token)), toDescription)) {
         fmt.Println("Cancelled.")
         return nil
     }
@@ -47,7 +56,7 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddress common.Add
     }
 
     // Log & return
-    fmt.Printf("Successfully sent %.2f %s to %s.\n", eth.WeiToEth(amountWei), token, toAddress.Hex())
+    fmt.Printf("Successfully sent %s to %s.\n", units.FormatToken(amountWei, units.TokenDecimals, units.TokenSymbol(token)), toDescription)
     return nil
 
 }
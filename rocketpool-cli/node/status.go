@@ -2,29 +2,71 @@ package node
 
 import (
     "fmt"
+    "time"
 
-    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
-func getStatus(c *cli.Context) error {
+// Action item priority labels
+var actionPriorityLabels = map[int]string{
+    api.ActionPriorityUrgent: "URGENT",
+    api.ActionPriorityHigh:   "HIGH",
+    api.ActionPriorityNormal: "NORMAL",
+    api.ActionPriorityLow:    "LOW",
+}
+
+
+func getStatus(c *cli.Context, atBlock uint64, atDate time.Time) error {
 
     // Get RP client
     rp, err := rocketpool.NewClientFromCtx(c)
     if err != nil { return err }
     defer rp.Close()
 
+    // Get the block to read at
+    if !atDate.IsZero() {
+        block, err := rp.GetBlockAtTime(atDate)
+        if err != nil { return err }
+        atBlock = block.Block
+    }
+
     // Get node status
-    status, err := rp.NodeStatus()
+    var status api.NodeStatusResponse
+    if atBlock > 0 {
+        status, err = rp.NodeStatusAt(atBlock)
+    } else {
+        status, err = rp.NodeStatus()
+    }
     if err != nil {
         return err
     }
+    if status.Block > 0 {
+        fmt.Printf("Showing the node status at block %d (%s).\n\n", status.Block, status.BlockTime.Format(time.RFC1123))
+    }
+
+    // Print sync status
+    if status.Sync.Eth1Error != "" {
+        fmt.Printf("The Eth 1.0 client sync status could not be checked: %s\n", status.Sync.Eth1Error)
+    } else if !status.Sync.Eth1Synced {
+        fmt.Println("The Eth 1.0 client is still syncing; the node status may be out of date.")
+    }
+    if status.Sync.Eth2Error != "" {
+        fmt.Printf("The Eth 2.0 client sync status could not be checked: %s\n", status.Sync.Eth2Error)
+    } else if !status.Sync.Eth2Synced {
+        fmt.Println("The Eth 2.0 client is still syncing; validator balances may be out of date.")
+    }
 
     // Print & return
-    fmt.Printf("The node %s has a balance of %.2f ETH and %.2f nETH.\n", status.AccountAddress.Hex(), eth.WeiToEth(status.Balances.ETH), eth.WeiToEth(status.Balances.NETH))
+    accountAddress := status.AccountAddress.Hex()
+    if status.AccountAddressName != "" {
+        accountAddress = fmt.Sprintf("%s (%s)", status.AccountAddressName, accountAddress)
+    }
+    fmt.Printf("The node %s has a balance of %s and %s.\n", accountAddress, units.FormatEth(status.Balances.ETH), units.FormatToken(status.Balances.NETH, units.TokenDecimals, "nETH"))
     if status.Registered {
         fmt.Printf("The node is registered with Rocket Pool with a timezone location of %s.\n", status.TimezoneLocation)
         if status.Trusted {
@@ -59,9 +101,42 @@ func getStatus(c *cli.Context) error {
         if status.MinipoolCounts.CloseAvailable > 0 {
             fmt.Printf("* %d dissolved minipools can be closed!\n", status.MinipoolCounts.CloseAvailable)
         }
+        if status.Block > 0 {
+            fmt.Println("Validator balances are only shown for the current state.")
+        } else if status.Validators.Error != "" {
+            fmt.Printf("Validator balances could not be loaded from the beacon chain: %s\n", status.Validators.Error)
+        } else if status.Validators.Active > 0 {
+            fmt.Printf("The node has %d active validator(s) with a total balance of %s on the beacon chain.\n", status.Validators.Active, units.FormatEth(status.Validators.Balance))
+        }
     } else {
         fmt.Println("The node is not registered with Rocket Pool.")
     }
+
+    // Print disk space
+    if status.Block == 0 && status.DiskSpace.Total > 0 {
+        fmt.Printf("The node's disk has %.1f GB available of %.1f GB", float64(status.DiskSpace.Available) / 1e9, float64(status.DiskSpace.Total) / 1e9)
+        switch {
+            case !status.DiskSpace.GrowthKnown:
+                fmt.Println("; its growth rate will be known after a day of monitoring.")
+            case status.DiskSpace.DaysUntilFull < 0:
+                fmt.Println(" and is not filling up.")
+            default:
+                fmt.Printf(", and is filling by %.1f GB per day; it will be full in about %.0f days.\n", status.DiskSpace.GrowthPerDay / 1e9, status.DiskSpace.DaysUntilFull)
+        }
+    }
+
+    // Print action items
+    if len(status.Actions) > 0 {
+        fmt.Println("")
+        fmt.Printf("Actions required (%d):\n", len(status.Actions))
+        for _, action := range status.Actions {
+            fmt.Printf("[%s] %s\n", actionPriorityLabels[action.Priority], action.Title)
+            fmt.Printf("    %s\n", action.Detail)
+            if action.Command != "" {
+                fmt.Printf("    Run: %s\n", action.Command)
+            }
+        }
+    }
     return nil
 
 }
@@ -0,0 +1,73 @@
+package node
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+func getTxQueue(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get pending transactions
+    response, err := rp.NodeTxQueue()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(response.Transactions) == 0 {
+        fmt.Println("The node has no pending transactions.")
+        return nil
+    }
+    fmt.Printf("The node has %d pending transaction(s):\n", len(response.Transactions))
+    for _, tx := range response.Transactions {
+        description := tx.Description
+        if tx.Cancelled {
+            description += " (cancelling)"
+        }
+        fmt.Printf("- Nonce %d: %s\n", tx.Nonce, description)
+        fmt.Printf("  Tx %s at %s, sent %s", tx.TxHash.Hex(), units.FormatGwei(tx.GasPrice), tx.Created.Format("2006-01-02 15:04"))
+        if tx.Replacements > 0 {
+            fmt.Printf(", replaced %d time(s)", tx.Replacements)
+        }
+        fmt.Println("")
+    }
+    return nil
+
+}
+
+
+func cancelTransaction(c *cli.Context, nonce uint64) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to cancel the pending transaction with nonce %d? It will be replaced with an empty transfer at a higher gas price.", nonce)) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Cancel transaction
+    response, err := rp.CancelNodeTransaction(nonce)
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("The cancellation transaction was submitted with hash %s.\n", response.TxHash.Hex())
+    return nil
+
+}
@@ -3,10 +3,10 @@ package queue
 import (
     "fmt"
 
-    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -24,8 +24,8 @@ func getStatus(c *cli.Context) error {
     }
 
     // Print & return
-    fmt.Printf("The deposit pool has a balance of %.2f ETH.\n", eth.WeiToEth(status.DepositPoolBalance))
-    fmt.Printf("There are %d available minipools with a total capacity of %.2f ETH.\n", status.MinipoolQueueLength, eth.WeiToEth(status.MinipoolQueueCapacity))
+    fmt.Printf("The deposit pool has a balance of %s.\n", units.FormatEth(status.DepositPoolBalance))
+    fmt.Printf("There are %d available minipools with a total capacity of %s.\n", status.MinipoolQueueLength, units.FormatEth(status.MinipoolQueueCapacity))
     return nil
 
 }
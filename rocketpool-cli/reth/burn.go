@@ -0,0 +1,63 @@
+package reth
+
+import (
+    "fmt"
+
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+func burn(c *cli.Context, amount float64) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get amount in wei
+    amountWei := eth.EthToWei(amount)
+
+    // Check rETH can be burned
+    canBurn, err := rp.CanRETHBurn(amountWei)
+    if err != nil {
+        return err
+    }
+    if !canBurn.CanBurn {
+        fmt.Println("Cannot burn rETH:")
+        if canBurn.InsufficientBalance {
+            fmt.Println("The node's rETH balance is insufficient.")
+        }
+        if canBurn.InsufficientCollateral {
+            fmt.Printf("The rETH contract only holds %s for exchange, and %s would be needed. Please try a smaller amount or try again later.\n", units.FormatEth(canBurn.Collateral), units.FormatEth(canBurn.ExpectedETH))
+        }
+        return nil
+    }
+
+    // Print burn details
+    fmt.Printf("The current exchange rate is %.6f ETH per rETH, so burning %s will return approximately %s.\n", canBurn.ExchangeRate, units.FormatToken(amountWei, units.TokenDecimals, "rETH"), units.FormatEth(canBurn.ExpectedETH))
+
+    // Display gas estimate
+    cliutils.PrintGasInfo(canBurn.GasInfo)
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to burn %s for ETH?", units.FormatToken(amountWei, units.TokenDecimals, "rETH"))) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Burn rETH
+    if _, err := rp.RETHBurn(amountWei); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Successfully burned %s for ETH.\n", units.FormatToken(amountWei, units.TokenDecimals, "rETH"))
+    return nil
+
+}
+
@@ -0,0 +1,57 @@
+package reth
+
+import (
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Mint and burn rETH",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "deposit",
+                Aliases:   []string{"d"},
+                Usage:     "Deposit ETH from the node account into the deposit pool to mint rETH",
+                UsageText: "rocketpool reth deposit amount",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    amount, err := cliutils.ValidatePositiveEthAmount("deposit amount", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    return deposit(c, amount)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "burn",
+                Aliases:   []string{"b"},
+                Usage:     "Burn rETH held by the node account for ETH",
+                UsageText: "rocketpool reth burn amount",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    amount, err := cliutils.ValidatePositiveEthAmount("burn amount", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    return burn(c, amount)
+
+                },
+            },
+
+        },
+    })
+}
+
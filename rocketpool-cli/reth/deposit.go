@@ -0,0 +1,67 @@
+package reth
+
+import (
+    "fmt"
+
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+func deposit(c *cli.Context, amount float64) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get amount in wei
+    amountWei := eth.EthToWei(amount)
+
+    // Check deposit can be made
+    canDeposit, err := rp.CanRETHDeposit(amountWei)
+    if err != nil {
+        return err
+    }
+    if !canDeposit.CanDeposit {
+        fmt.Println("Cannot deposit ETH for rETH:")
+        if canDeposit.InsufficientBalance {
+            fmt.Println("The node's ETH balance is insufficient.")
+        }
+        if canDeposit.BelowMinimum {
+            fmt.Printf("The minimum deposit is %s.\n", units.FormatEth(canDeposit.MinimumDeposit))
+        }
+        if canDeposit.DepositDisabled {
+            fmt.Println("Deposits into the deposit pool are currently disabled.")
+        }
+        return nil
+    }
+
+    // Print deposit details
+    fmt.Printf("The current exchange rate is %.6f ETH per rETH, so depositing %s will mint approximately %s.\n", canDeposit.ExchangeRate, units.FormatEth(amountWei), units.FormatToken(canDeposit.ExpectedRETH, units.TokenDecimals, "rETH"))
+    fmt.Printf("The deposit pool has a balance of %s; deposited ETH is assigned to minipools in the queue.\n", units.FormatEth(canDeposit.DepositPoolBalance))
+
+    // Display gas estimate
+    cliutils.PrintGasInfo(canDeposit.GasInfo)
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to deposit %s for rETH?", units.FormatEth(amountWei))) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Deposit
+    if _, err := rp.RETHDeposit(amountWei); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Successfully deposited %s for rETH.\n", units.FormatEth(amountWei))
+    return nil
+
+}
+
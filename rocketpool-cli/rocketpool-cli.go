@@ -3,24 +3,33 @@ package main
 import (
     "fmt"
     "os"
+    "strings"
 
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/rocketpool-cli/doctor"
     "github.com/rocket-pool/smartnode/rocketpool-cli/faucet"
+    "github.com/rocket-pool/smartnode/rocketpool-cli/fleet"
+    "github.com/rocket-pool/smartnode/rocketpool-cli/history"
+    "github.com/rocket-pool/smartnode/rocketpool-cli/jobs"
     "github.com/rocket-pool/smartnode/rocketpool-cli/minipool"
     "github.com/rocket-pool/smartnode/rocketpool-cli/network"
     "github.com/rocket-pool/smartnode/rocketpool-cli/node"
     "github.com/rocket-pool/smartnode/rocketpool-cli/queue"
+    "github.com/rocket-pool/smartnode/rocketpool-cli/reth"
     "github.com/rocket-pool/smartnode/rocketpool-cli/service"
+    "github.com/rocket-pool/smartnode/rocketpool-cli/support"
+    "github.com/rocket-pool/smartnode/rocketpool-cli/tx"
     "github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+    "github.com/rocket-pool/smartnode/shared"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
 
-// Run
-func main() {
-
-    // Add logo to application help template
-    cli.AppHelpTemplate = fmt.Sprintf(`
+// Help logo
+const Logo = `
 ______           _        _    ______           _ 
 | ___ \         | |      | |   | ___ \         | |
 | |_/ /___   ___| | _____| |_  | |_/ /__   ___ | |
@@ -28,7 +37,16 @@ ______           _        _    ______           _
 | |\ \ (_) | (__|   <  __/ |_  | | | (_) | (_) | |
 \_| \_\___/ \___|_|\_\___|\__| \_|  \___/ \___/|_|
 
-%s`, cli.AppHelpTemplate)
+`
+
+
+// Run
+func main() {
+
+    // Add logo to application help template, unless in accessible mode
+    if !hasAccessibleFlag(os.Args) {
+        cli.AppHelpTemplate = fmt.Sprintf("%s%s", Logo, cli.AppHelpTemplate)
+    }
 
     // Initialise application
     app := cli.NewApp()
@@ -36,7 +54,7 @@ ______           _        _    ______           _
     // Set application info
     app.Name = "rocketpool"
     app.Usage = "Rocket Pool CLI"
-    app.Version = "0.0.1"
+    app.Version = shared.RocketPoolVersion
     app.Authors = []cli.Author{
         cli.Author{
             Name:  "David Rugendyke",
@@ -63,23 +81,96 @@ ______           _        _    ______           _
             Name:  "key, k",
             Usage: "Smart node SSH key `file`",
         },
+        cli.Float64Flag{
+            Name:  "max-fee, f",
+            Usage: "Maximum gas price for transactions in `gwei`; overrides the smart node setting",
+        },
+        cli.Float64Flag{
+            Name:  "priority-fee, i",
+            Usage: "Priority fee for transactions in `gwei`; overrides the smart node setting",
+        },
+        cli.DurationFlag{
+            Name:  "api-timeout",
+            Usage: "How long to wait for an API call, including any transaction it waits for, before cancelling it (0 to wait indefinitely)",
+            Value: rocketpool.DefaultAPITimeout,
+        },
+        cli.DurationFlag{
+            Name:  "command-timeout",
+            Usage: "How long to wait for short commands on the smart node host, such as reading the config, before cancelling them (0 to wait indefinitely)",
+            Value: rocketpool.DefaultCommandTimeout,
+        },
+        cli.BoolFlag{
+            Name:  "offline",
+            Usage: "Prepare transactions for signing on an offline machine with 'rocketpool wallet sign-tx', instead of signing and sending them; the unsigned transaction is written to a file",
+        },
+        cli.BoolFlag{
+            Name:  "dry-run",
+            Usage: "Simulate transactions against the latest block and show their gas estimate, events and state changes, instead of sending them",
+        },
+        cli.BoolFlag{
+            Name:  "accessible, a",
+            Usage: "Accessible output for screen readers and simple terminals; disables colors, animations and decorative formatting",
+        },
+        cli.StringFlag{
+            Name:  "trace",
+            Usage: "Trace the command across the CLI, SSH, API and Eth 1.0 client, and write the trace to `file` for viewing in chrome://tracing or https://ui.perfetto.dev",
+        },
+    }
+
+    // Apply output settings & start recording command history & trace
+    var recorder *history.Recorder
+    var tracePath string
+    app.Before = func(c *cli.Context) error {
+        cliutils.SetAccessible(c.GlobalBool("accessible"))
+        recorder = history.Start(c)
+        if tracePath = c.GlobalString("trace"); tracePath != "" {
+            tracing.Start(tracing.NewTraceID(), "cli", "rocketpool " + strings.Join(c.Args(), " "))
+        }
+        return nil
     }
 
     // Register commands
+      doctor.RegisterCommands(app, "doctor",   []string{"d"})
       faucet.RegisterCommands(app, "faucet",   []string{"f"})
+       fleet.RegisterCommands(app, "fleet",    []string{"t"})
+     history.RegisterCommands(app, "history",  []string{"y"}, "last", []string{"l"})
+        jobs.RegisterCommands(app, "jobs",     []string{"j"})
     minipool.RegisterCommands(app, "minipool", []string{"m"})
      network.RegisterCommands(app, "network",  []string{"e"})
         node.RegisterCommands(app, "node",     []string{"n"})
        queue.RegisterCommands(app, "queue",    []string{"q"})
+        reth.RegisterCommands(app, "reth",     []string{"r"})
      service.RegisterCommands(app, "service",  []string{"s"})
+     support.RegisterCommands(app, "support",  []string{"u"})
+          tx.RegisterCommands(app, "tx",       []string{"x"})
       wallet.RegisterCommands(app, "wallet",   []string{"w"})
 
     // Run application
     fmt.Println("")
-    if err := app.Run(os.Args); err != nil {
+    err := app.Run(os.Args)
+    if err != nil && !tx.HandleOfflineTransaction(err) && !tx.HandleDryRun(err) {
         fmt.Println(err)
     }
+    if spans := tracing.Finish(err); spans != nil {
+        if err := tracing.Export(tracePath, spans); err != nil {
+            fmt.Println(err)
+        } else {
+            fmt.Printf("Trace written to %s.\n", tracePath)
+        }
+    }
     fmt.Println("")
+    recorder.Finish(err)
 
 }
 
+
+
+// Check whether the accessible output flag was passed; used before flags are parsed
+func hasAccessibleFlag(args []string) bool {
+    for _, arg := range args[1:] {
+        if arg == "--accessible" || arg == "-accessible" || arg == "-a" {
+            return true
+        }
+    }
+    return false
+}
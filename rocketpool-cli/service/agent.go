@@ -0,0 +1,89 @@
+package service
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/containers"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Settings
+var agentPollInterval, _ = time.ParseDuration("10s")
+
+
+// Run container requests queued by the node daemon when the docker socket is disabled
+func runAgent(c *cli.Context, once bool) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Check docker socket mode
+    cfg, err := rp.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+    if !cfg.UseServiceAgent() {
+        fmt.Println("The docker socket is enabled, so the node daemon controls containers directly and the service agent is not required.")
+        return nil
+    }
+
+    // Apply requests
+    if !once {
+        fmt.Println("Waiting for service requests from the node daemon...")
+    }
+    for {
+        if err := applyServiceRequests(rp); err != nil {
+            if once {
+                return err
+            }
+            fmt.Println(err)
+        }
+        if once {
+            return nil
+        }
+        time.Sleep(agentPollInterval)
+    }
+
+}
+
+
+// Apply unapplied service requests in order
+// A failed request stops processing, so that it is retried before any later requests; invalid requests are skipped
+func applyServiceRequests(rp *rocketpool.Client) error {
+
+    // Load requests & agent state
+    requests, state, err := rp.LoadServiceRequests()
+    if err != nil {
+        return err
+    }
+
+    // Apply requests
+    for _, request := range requests {
+        if request.ID <= state.LastAppliedID {
+            continue
+        }
+        if err := containers.ValidateRequest(request); err != nil {
+            fmt.Printf("%s: skipping invalid request %d: %s\n", time.Now().Format(time.RFC3339), request.ID, err.Error())
+        } else {
+            fmt.Printf("%s: %s %s (%q, requested at %s)\n", time.Now().Format(time.RFC3339), request.Action, request.Service, request.Reason, request.Created.Format(time.RFC3339))
+            if err := rp.ApplyServiceRequest(request); err != nil {
+                return fmt.Errorf("Could not %s %s: %w", request.Action, request.Service, err)
+            }
+        }
+        state.LastAppliedID = request.ID
+        state.Updated = time.Now()
+        if err := rp.SaveServiceAgentState(state); err != nil {
+            return err
+        }
+    }
+
+    // Return
+    return nil
+
+}
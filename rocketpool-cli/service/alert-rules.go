@@ -0,0 +1,182 @@
+package service
+
+import (
+    "fmt"
+    "io/ioutil"
+
+    "github.com/urfave/cli"
+    "gopkg.in/yaml.v2"
+
+    "github.com/rocket-pool/smartnode/rocketpool-cli/doctor"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/metrics"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Config
+const (
+    AlertRuleGroup = "rocketpool"
+    AlertRulesFileMode = 0644
+    diskFilesystemSelector = `fstype!~"tmpfs|overlay|squashfs"`
+)
+
+
+// Prometheus alerting rules file
+type alertRulesFile struct {
+    Groups []alertRuleGroup             `yaml:"groups"`
+}
+type alertRuleGroup struct {
+    Name string                         `yaml:"name"`
+    Rules []alertRule                   `yaml:"rules"`
+}
+type alertRule struct {
+    Alert string                        `yaml:"alert"`
+    Expr string                         `yaml:"expr"`
+    For string                          `yaml:"for,omitempty"`
+    Labels map[string]string            `yaml:"labels,omitempty"`
+    Annotations map[string]string       `yaml:"annotations,omitempty"`
+}
+
+
+// Export Prometheus alerting rules matching the smart node's alert thresholds
+func exportAlertRules(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Load config
+    cfg, err := rp.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+
+    // Get rules
+    rulesBytes, err := yaml.Marshal(alertRulesFile{
+        Groups: []alertRuleGroup{alertRuleGroup{
+            Name: AlertRuleGroup,
+            Rules: getAlertRules(cfg),
+        }},
+    })
+    if err != nil {
+        return fmt.Errorf("Could not encode alerting rules: %w", err)
+    }
+
+    // Print rules
+    path := c.String("output")
+    if path == "" {
+        fmt.Print(string(rulesBytes))
+        return nil
+    }
+
+    // Write rules
+    if err := ioutil.WriteFile(path, rulesBytes, AlertRulesFileMode); err != nil {
+        return fmt.Errorf("Could not write alerting rules file: %w", err)
+    }
+
+    // Log & return
+    fmt.Printf("Alerting rules were saved to %s.\n", path)
+    fmt.Println("Add the file to 'rule_files' in your Prometheus config, and export the rules again after changing alert settings.")
+    return nil
+
+}
+
+
+// Get the alerting rules for a config
+// Disk rules apply to node_exporter metrics; node & watchtower rules are only included if the daemons serve metrics
+func getAlertRules(cfg config.RocketPoolConfig) []alertRule {
+
+    // Disk rules
+    rules := []alertRule{
+        alertRule{
+            Alert: "RocketPoolDiskUsageHigh",
+            Expr: fmt.Sprintf("100 * (1 - node_filesystem_avail_bytes{%s} / node_filesystem_size_bytes{%s}) > %g", diskFilesystemSelector, diskFilesystemSelector, cfg.GetAlertDiskUsagePercent()),
+            For: "10m",
+            Labels: map[string]string{"severity": "warning"},
+            Annotations: map[string]string{
+                "summary": fmt.Sprintf("Disk usage is above %g%%", cfg.GetAlertDiskUsagePercent()),
+                "description": "{{ $labels.mountpoint }} on {{ $labels.instance }} is {{ $value | printf \"%.1f\" }}% full; running out of space will stop the Rocket Pool clients.",
+            },
+        },
+        alertRule{
+            Alert: "RocketPoolDiskSpaceLow",
+            Expr: fmt.Sprintf("node_filesystem_avail_bytes{%s} < %d", diskFilesystemSelector, uint64(doctor.MinAvailableDiskSpace)),
+            For: "10m",
+            Labels: map[string]string{"severity": "critical"},
+            Annotations: map[string]string{
+                "summary": fmt.Sprintf("Less than %d GB of disk space is available", uint64(doctor.MinAvailableDiskSpace) / (1024 * 1024 * 1024)),
+                "description": "{{ $labels.mountpoint }} on {{ $labels.instance }} has {{ $value | humanize1024 }}B available; running out of space will stop the Rocket Pool clients.",
+            },
+        },
+    }
+
+    // Node rules
+    if cfg.Smartnode.MetricsAddress != "" {
+        rules = append(rules, alertRule{
+            Alert: "RocketPoolDiskFullSoon",
+            Expr: fmt.Sprintf("%s_disk_days_until_full >= 0 and %s_disk_days_until_full < %g", metrics.NodeNamespace, metrics.NodeNamespace, cfg.GetAlertDiskFullDays()),
+            For: "1h",
+            Labels: map[string]string{"severity": "warning"},
+            Annotations: map[string]string{
+                "summary": fmt.Sprintf("The node's disk will be full within %g days", cfg.GetAlertDiskFullDays()),
+                "description": "The disk holding the smart node data on {{ $labels.instance }} is predicted to be full in {{ $value | printf \"%.0f\" }} days at its current growth rate.",
+            },
+        })
+    }
+    if cfg.Watchtower.MetricsAddress == "" {
+        return rules
+    }
+
+    // Watchtower rules
+    metric := func(name string) string {
+        return fmt.Sprintf("%s_%s", metrics.WatchtowerNamespace, name)
+    }
+    rules = append(rules,
+        alertRule{
+            Alert: "RocketPoolWatchtowerBalanceLow",
+            Expr: fmt.Sprintf("%s < %g and on(instance) %s == 1", metric("node_balance_eth"), cfg.GetWatchtowerLowBalanceAlert(), metric("node_trusted")),
+            For: "5m",
+            Labels: map[string]string{"severity": "warning"},
+            Annotations: map[string]string{
+                "summary": fmt.Sprintf("The watchtower node balance is below %g ETH", cfg.GetWatchtowerLowBalanceAlert()),
+                "description": "The node account on {{ $labels.instance }} has {{ $value }} ETH; top it up so that the watchtower can continue to pay for oracle submissions.",
+            },
+        },
+        alertRule{
+            Alert: "RocketPoolWatchtowerSubmissionFailed",
+            Expr: fmt.Sprintf("increase(%s[1h]) > 0", metric("submission_failures_total")),
+            Labels: map[string]string{"severity": "warning"},
+            Annotations: map[string]string{
+                "summary": "A watchtower oracle submission failed",
+                "description": "The {{ $labels.type }} submission on {{ $labels.instance }} failed in the last hour; check the watchtower logs.",
+            },
+        },
+        alertRule{
+            Alert: "RocketPoolWatchtowerTaskErrors",
+            Expr: fmt.Sprintf("increase(%s[30m]) > 0", metric("task_errors_total")),
+            For: "30m",
+            Labels: map[string]string{"severity": "warning"},
+            Annotations: map[string]string{
+                "summary": "A watchtower task is failing",
+                "description": "The {{ $labels.task }} task on {{ $labels.instance }} has been returning errors for 30 minutes; check the watchtower logs.",
+            },
+        },
+        alertRule{
+            Alert: "RocketPoolRPCConnectionUnhealthy",
+            Expr: fmt.Sprintf("%s == 0", metric("rpc_connection_healthy")),
+            For: "5m",
+            Labels: map[string]string{"severity": "warning"},
+            Annotations: map[string]string{
+                "summary": "An Eth 1.0 RPC connection is unhealthy",
+                "description": "Pooled connection {{ $labels.connection }} on {{ $labels.instance }} cannot reach the Eth 1.0 provider.",
+            },
+        },
+    )
+
+    // Return
+    return rules
+
+}
+
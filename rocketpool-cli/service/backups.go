@@ -0,0 +1,207 @@
+package service
+
+import (
+    "fmt"
+    "io/ioutil"
+    "strings"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/backup"
+    "github.com/rocket-pool/smartnode/shared/services/passwords"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// List the backups at the backup destination
+func listBackups(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get backups
+    response, err := rp.ServiceBackups()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(response.Backups) == 0 {
+        fmt.Printf("There are no backups at the %s backup destination yet.\n", response.Destination)
+        return nil
+    }
+    fmt.Printf("%d backup(s) at the %s backup destination:\n", len(response.Backups), response.Destination)
+    for _, name := range response.Backups {
+        if backupTime, ok := backup.GetBackupTime(name); ok {
+            fmt.Printf("- %s (created %s)\n", name, backupTime.Format("2006-01-02 15:04:05 UTC"))
+        }
+    }
+    return nil
+
+}
+
+
+// Restore a backup from the backup destination
+func restoreBackup(c *cli.Context, name string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Restoring backup %s will overwrite the node's current wallet, validator keys and settings. Are you sure you want to continue?", name)) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Prompt for passphrase
+    passphrase := cliutils.Prompt("Please enter the backup passphrase, or leave blank to use the node password:", "^.*$", "")
+
+    // Restore backup
+    if _, err := rp.RestoreBackup(name, passphrase); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Successfully restored backup %s. Please restart the Rocket Pool service to apply the restored wallet & settings.\n", name)
+    return nil
+
+}
+
+
+// Verify the most recent backup at the backup destination
+func verifyBackup(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Prompt for passphrase
+    passphrase := cliutils.Prompt("Please enter the backup passphrase, or leave blank to use the node password:", "^.*$", "")
+
+    // Verify backup
+    fmt.Println("Restoring the most recent backup to a temporary folder...")
+    response, err := rp.VerifyBackup(passphrase)
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Backup %s was verified successfully:\n", response.Name)
+    fmt.Printf("- Config files loaded: %s\n", strings.Join(response.ConfigFiles, ", "))
+    fmt.Printf("- Wallet decrypted with node account %s\n", response.NodeAddress.Hex())
+    fmt.Printf("- Validator keystores parsed: %d\n", response.KeystoreCount)
+    return nil
+
+}
+
+
+// Create an encrypted backup of the node's wallet, validator keys & settings, saved to a local file
+func createBackup(c *cli.Context, outputPath string, upload bool) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Prompt for passphrase
+    passphrase := promptBackupPassphrase()
+
+    // Archive node folder
+    fmt.Println("Archiving the node's wallet, validator keys, slashing protection data and settings (excluding chain data)...")
+    archive, err := rp.ArchiveNodeFolder(backup.DefaultExcludes)
+    if err != nil {
+        return err
+    }
+
+    // Encrypt & save backup
+    data, err := backup.Encrypt(archive, passphrase)
+    if err != nil {
+        return err
+    }
+    name := backup.GetBackupName(time.Now())
+    if outputPath == "" {
+        outputPath = name
+    }
+    if err := ioutil.WriteFile(outputPath, data, 0600); err != nil {
+        return fmt.Errorf("Could not write backup file %s: %w", outputPath, err)
+    }
+    fmt.Printf("Saved the encrypted backup to %s.\n", outputPath)
+
+    // Upload backup
+    if upload {
+        response, err := rp.UploadBackup(name, data)
+        if err != nil {
+            return err
+        }
+        fmt.Printf("Uploaded backup %s to the %s backup destination.\n", response.Name, response.Destination)
+    }
+
+    // Return
+    fmt.Println("Store the backup and its passphrase somewhere safe; anyone with both can control your node wallet.")
+    return nil
+
+}
+
+
+// Restore the node's wallet, validator keys & settings from a local backup file
+func restoreBackupFile(c *cli.Context, path string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Read backup
+    data, err := ioutil.ReadFile(path)
+    if err != nil {
+        return fmt.Errorf("Could not read backup file %s: %w", path, err)
+    }
+
+    // Decrypt backup
+    passphrase := cliutils.Prompt("Please enter the backup passphrase:", "^.+$", "Please enter the backup passphrase")
+    archive, err := backup.Decrypt(data, passphrase)
+    if err != nil {
+        return err
+    }
+
+    // Prompt for confirmation
+    if !cliutils.Confirm("Restoring this backup will overwrite the node's current wallet, validator keys and settings. Make sure its validators are not running on any other machine, or they will be slashed. Are you sure you want to continue?") {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Restore backup
+    if err := rp.ExtractNodeFolder(archive); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Successfully restored %s. Please restart the Rocket Pool service to apply the restored wallet & settings; chain data will resync.\n", path)
+    return nil
+
+}
+
+
+// Prompt for a backup passphrase
+func promptBackupPassphrase() string {
+    for {
+        passphrase := cliutils.Prompt(
+            "Please enter a passphrase to encrypt the backup with:",
+            fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+            fmt.Sprintf("Your passphrase must be at least %d characters long", passwords.MinPasswordLength),
+        )
+        confirmation := cliutils.Prompt("Please confirm your passphrase:", "^.*$", "")
+        if passphrase == confirmation {
+            return passphrase
+        }
+        fmt.Println("Passphrase confirmation does not match.")
+        fmt.Println("")
+    }
+}
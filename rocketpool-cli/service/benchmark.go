@@ -0,0 +1,143 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Settings
+const (
+    BenchmarkHistoryCount = 5
+)
+
+
+// Client hardware requirements
+type benchmarkRequirement struct {
+    WriteIOPS float64
+    ReadIOPS float64
+    DownloadSpeed float64
+}
+var defaultRequirement = benchmarkRequirement{
+    WriteIOPS: 500,
+    ReadIOPS: 2000,
+    DownloadSpeed: 10,
+}
+var clientRequirements = map[string]benchmarkRequirement{
+    "geth": benchmarkRequirement{
+        WriteIOPS: 1000,
+        ReadIOPS: 5000,
+        DownloadSpeed: 25,
+    },
+    "infura": benchmarkRequirement{},
+    "lighthouse": benchmarkRequirement{
+        WriteIOPS: 500,
+        ReadIOPS: 2000,
+        DownloadSpeed: 10,
+    },
+    "prysm": benchmarkRequirement{
+        WriteIOPS: 500,
+        ReadIOPS: 2000,
+        DownloadSpeed: 10,
+    },
+}
+
+
+// Run host disk & network benchmarks
+func runBenchmark(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get requirements for selected clients
+    requirement := benchmarkRequirement{}
+    if cfg, err := rp.LoadMergedConfig(); err == nil {
+        for _, client := range []string{cfg.Chains.Eth1.Client.Selected, cfg.Chains.Eth2.Client.Selected} {
+            requirement = combineRequirements(requirement, getClientRequirement(client))
+        }
+    } else {
+        requirement = defaultRequirement
+    }
+
+    // Run benchmark
+    fmt.Println("Running benchmark, this may take a minute...")
+    fmt.Println("")
+    result, err := rp.RunBenchmark(c.Bool("skip-network"))
+    if err != nil { return err }
+
+    // Get previous results & save new result
+    history, err := rp.GetBenchmarkHistory()
+    if err != nil { return err }
+    if err := rp.SaveBenchmarkResult(result); err != nil { return err }
+
+    // Print results
+    fmt.Println("Benchmark results:")
+    printBenchmarkScore("Disk write", result.WriteIOPS, requirement.WriteIOPS, "IOPS", fmt.Sprintf(" (%.2f ms latency)", result.WriteLatency))
+    printBenchmarkScore("Disk read", result.ReadIOPS, requirement.ReadIOPS, "IOPS", fmt.Sprintf(" (%.2f ms latency)", result.ReadLatency))
+    if result.NetworkTested {
+        printBenchmarkScore("Download", result.DownloadSpeed, requirement.DownloadSpeed, "Mbps", "")
+    }
+
+    // Print history
+    if len(history) > 0 {
+        if len(history) > BenchmarkHistoryCount {
+            history = history[len(history) - BenchmarkHistoryCount:]
+        }
+        fmt.Println("")
+        fmt.Println("Previous results:")
+        for _, previous := range history {
+            fmt.Printf("%s - write %.0f IOPS, read %.0f IOPS", previous.Time.Format("2006-01-02 15:04"), previous.WriteIOPS, previous.ReadIOPS)
+            if previous.NetworkTested {
+                fmt.Printf(", download %.1f Mbps", previous.DownloadSpeed)
+            }
+            fmt.Println("")
+        }
+        last := history[len(history) - 1]
+        if result.WriteIOPS < last.WriteIOPS / 2 || result.ReadIOPS < last.ReadIOPS / 2 {
+            fmt.Println("")
+            fmt.Println("Disk performance has degraded significantly since the last benchmark.")
+        }
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get the hardware requirements for a client
+func getClientRequirement(clientId string) benchmarkRequirement {
+    if requirement, ok := clientRequirements[clientId]; ok {
+        return requirement
+    }
+    return defaultRequirement
+}
+
+
+// Combine hardware requirements for clients running on the same host
+func combineRequirements(a, b benchmarkRequirement) benchmarkRequirement {
+    return benchmarkRequirement{
+        WriteIOPS: a.WriteIOPS + b.WriteIOPS,
+        ReadIOPS: a.ReadIOPS + b.ReadIOPS,
+        DownloadSpeed: a.DownloadSpeed + b.DownloadSpeed,
+    }
+}
+
+
+// Print a benchmark value scored against its requirement
+func printBenchmarkScore(name string, value, required float64, unit, detail string) {
+    var verdict string
+    if required == 0 || value >= required * 2 {
+        verdict = "good"
+    } else if value >= required {
+        verdict = "sufficient"
+    } else {
+        verdict = "insufficient"
+    }
+    fmt.Printf("- %s: %.1f %s%s - %s (%.0f %s required)\n", name, value, unit, detail, verdict, required, unit)
+}
@@ -3,6 +3,7 @@ package service
 import (
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/rocketpool-cli/doctor"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -39,14 +40,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Usage: "The smart node package version to install",
                         Value: "latest",
                     },
+                    cli.StringFlag{
+                        Name:  "backend, b",
+                        Usage: "The service backend to run the clients & smart node with: compose (containers) or systemd (native units)",
+                        Value: "compose",
+                    },
+                    cli.StringFlag{
+                        Name:  "architecture, a",
+                        Usage: "The host architecture to install client images & settings for: amd64 or arm64 (detected from the host if not set)",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    backend, err := cliutils.ValidateServiceBackend("service backend", c.String("backend"))
+                    if err != nil { return err }
+                    architecture := ""
+                    if c.String("architecture") != "" {
+                        if architecture, err = cliutils.ValidateArchitecture("architecture", c.String("architecture")); err != nil { return err }
+                    }
 
                     // Run command
-                    return installService(c)
+                    return installService(c, backend, architecture)
 
                 },
             },
@@ -55,7 +71,17 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 Name:      "config",
                 Aliases:   []string{"c"},
                 Usage:     "Configure the Rocket Pool service",
-                UsageText: "rocketpool service config",
+                UsageText: "rocketpool service config [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "preset, p",
+                        Usage: "The `name` or file of a preset to start from",
+                    },
+                    cli.BoolFlag{
+                        Name:  "classic",
+                        Usage: "Use the step-by-step prompts instead of the full-screen editor",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
@@ -65,6 +91,115 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                     return configureService(c)
 
                 },
+                Subcommands: []cli.Command{
+
+                    cli.Command{
+                        Name:      "describe",
+                        Aliases:   []string{"d"},
+                        Usage:     "Describe a Rocket Pool service setting",
+                        UsageText: "rocketpool service config describe setting",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                            // Run command
+                            return describeSetting(c, c.Args().Get(0))
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "validate",
+                        Aliases:   []string{"v"},
+                        Usage:     "Check the Rocket Pool service config and settings files for invalid settings",
+                        UsageText: "rocketpool service config validate",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                            // Run command
+                            return validateConfig(c)
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "history",
+                        Aliases:   []string{"h"},
+                        Usage:     "List the recorded changes to the Rocket Pool service settings",
+                        UsageText: "rocketpool service config history",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                            // Run command
+                            return printConfigHistory(c)
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "revert",
+                        Aliases:   []string{"r"},
+                        Usage:     "Revert the Rocket Pool service settings to a previous revision",
+                        UsageText: "rocketpool service config revert revision",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                            // Run command
+                            return revertConfig(c, c.Args().Get(0))
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "presets",
+                        Aliases:   []string{"p"},
+                        Usage:     "List the built-in and saved config presets",
+                        UsageText: "rocketpool service config presets",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                            // Run command
+                            return listPresets(c)
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "save-preset",
+                        Aliases:   []string{"s"},
+                        Usage:     "Save the current client choices and settings as a preset which can be shared",
+                        UsageText: "rocketpool service config save-preset [options] name",
+                        Flags: []cli.Flag{
+                            cli.StringFlag{
+                                Name:  "description, d",
+                                Usage: "A description of the setup the preset is for",
+                            },
+                            cli.StringFlag{
+                                Name:  "output, o",
+                                Usage: "The `file` to save the preset to (defaults to ~/" + PresetsFolder + "/<name>" + PresetFileExtension + ")",
+                            },
+                        },
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                            // Run command
+                            return savePreset(c, c.Args().Get(0))
+
+                        },
+                    },
+
+                },
             },
 
             cli.Command{
@@ -135,13 +270,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 Name:      "logs",
                 Aliases:   []string{"l"},
                 Usage:     "View the Rocket Pool service logs",
-                UsageText: "rocketpool service logs [services...]",
+                UsageText: "rocketpool service logs [options] [services...]",
                 Flags: []cli.Flag{
                     cli.StringFlag{
                         Name:  "tail, t",
-                        Usage: "The number of lines to show from the end of the logs (number or \"all\")",
+                        Usage: "The number of lines to show from the end of each service's logs (number or \"all\"); all lines by default if --since is set",
                         Value: "100",
                     },
+                    cli.StringFlag{
+                        Name:  "since, s",
+                        Usage: "Show logs since a duration ago (e.g. 30m) or a local time (e.g. \"2020-11-01 12:00\")",
+                    },
+                    cli.StringFlag{
+                        Name:  "until, u",
+                        Usage: "Show logs until a duration ago or a local time, without following",
+                    },
+                    cli.StringFlag{
+                        Name:  "grep, g",
+                        Usage: "Only show lines matching a regular expression (e.g. \"(?i)error\")",
+                    },
+                    cli.BoolFlag{
+                        Name:  "no-follow, n",
+                        Usage: "Print the logs and exit instead of following them",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
@@ -167,6 +318,392 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "import-chaindata",
+                Aliases:   []string{"d"},
+                Usage:     "Import an Eth 1.0 chain data snapshot from a URL or file on the host",
+                UsageText: "rocketpool service import-chaindata [options] source",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "checksum, c",
+                        Usage: "The expected SHA-256 checksum of the snapshot file",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Run command
+                    return importChainData(c, c.Args().Get(0))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "migrate-chaindata",
+                Aliases:   []string{"m"},
+                Usage:     "Move a client's chain data from its docker volume to a path on the host, e.g. on a separate disk",
+                UsageText: "rocketpool service migrate-chaindata eth1|eth2 path",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+
+                    // Run command
+                    return migrateChainData(c, c.Args().Get(0), c.Args().Get(1))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "prune-eth1",
+                Usage:     "Stop the Rocket Pool service, prune the Eth 1.0 client's chain data offline to free up disk space, and restart it",
+                UsageText: "rocketpool service prune-eth1",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return pruneEth1(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "peers",
+                Aliases:   []string{"e"},
+                Usage:     "View the Rocket Pool service client peers",
+                UsageText: "rocketpool service peers [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "locations, l",
+                        Usage: "Look up peer locations (sends peer IP addresses to freegeoip.app)",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return servicePeers(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "sync",
+                Aliases:   []string{"y"},
+                Usage:     "View the Eth 1.0 and Eth 2.0 client sync progress",
+                UsageText: "rocketpool service sync [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "follow, f",
+                        Usage: "Keep polling the sync progress until both clients are synced",
+                    },
+                    cli.DurationFlag{
+                        Name:  "interval, i",
+                        Usage: "The polling interval when following (e.g. 30s)",
+                        Value: DefaultSyncInterval,
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return serviceSync(c, c.Bool("follow"), c.Duration("interval"))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "net-diag",
+                Aliases:   []string{"n"},
+                Usage:     "Check the Eth 1.0 and Eth 2.0 client p2p ports and peers",
+                UsageText: "rocketpool service net-diag",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return serviceNetDiag(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "benchmark",
+                Aliases:   []string{"b"},
+                Usage:     "Benchmark the host's disk and network performance",
+                UsageText: "rocketpool service benchmark [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "skip-network, n",
+                        Usage: "Do not run the network throughput test",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return runBenchmark(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "backups",
+                Usage:     "List the backups available at the configured backup destination",
+                UsageText: "rocketpool service backups",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return listBackups(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "backup",
+                Usage:     "Save an encrypted backup of the node's wallet, validator keys, slashing protection data & settings to a local file",
+                UsageText: "rocketpool service backup [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "output, o",
+                        Usage: "The backup file `path` (defaults to a timestamped file in the current folder)",
+                    },
+                    cli.BoolFlag{
+                        Name:  "upload, u",
+                        Usage: "Also upload a backup to the configured backup destination",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return createBackup(c, c.String("output"), c.Bool("upload"))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "restore",
+                Usage:     "Restore the node's wallet, validator keys & settings from a local backup file, e.g. onto a fresh machine",
+                UsageText: "rocketpool service restore [file]",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Run command
+                    return restoreBackupFile(c, c.Args().Get(0))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "restore-backup",
+                Usage:     "Restore the node's wallet, keys & settings from a backup at the configured backup destination",
+                UsageText: "rocketpool service restore-backup [name]",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Run command
+                    return restoreBackup(c, c.Args().Get(0))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "verify-backup",
+                Usage:     "Check that the most recent backup at the configured backup destination can be restored",
+                UsageText: "rocketpool service verify-backup",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return verifyBackup(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "verify-install",
+                Usage:     "Check the installed compose files, chain configs & scripts against the smartnode-install release, and offer to restore changed files",
+                UsageText: "rocketpool service verify-install [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "network, n",
+                        Usage: "The Eth 2.0 network the service was installed for",
+                        Value: "medalla",
+                    },
+                    cli.StringFlag{
+                        Name:  "version, v",
+                        Usage: "The installed smart node package version",
+                        Value: "latest",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return verifyInstall(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "doctor",
+                Aliases:   []string{"dr"},
+                Usage:     "Check the health of the Rocket Pool service and host, with suggested fixes for any problems",
+                UsageText: "rocketpool service doctor",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return doctor.Run(c, false)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "security-review",
+                Usage:     "Review the node host's SSH, firewall & automatic update settings and confirm the wallet backup",
+                UsageText: "rocketpool service security-review",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return securityReview(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "export-alert-rules",
+                Usage:     "Export Prometheus alerting rules matching the smart node's alert thresholds",
+                UsageText: "rocketpool service export-alert-rules [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "output, o",
+                        Usage: "The rules file `path` to write (defaults to printing the rules)",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return exportAlertRules(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "signer-status",
+                Usage:     "Check the remote signer health and whether it holds the node's validator keys",
+                UsageText: "rocketpool service signer-status",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return signerStatus(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "sync-signer",
+                Usage:     "Import the node's validator keys missing from the remote signer",
+                UsageText: "rocketpool service sync-signer",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return syncSigner(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "tasks",
+                Aliases:   []string{"t"},
+                Usage:     "List the node & watchtower daemon tasks and their status",
+                UsageText: "rocketpool service tasks",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return listTasks(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "trigger-task",
+                Usage:     "Run a daemon task immediately",
+                UsageText: "rocketpool service trigger-task name",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Run command
+                    return triggerTask(c, c.Args().Get(0))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "agent",
+                Usage:     "Run container restarts & stops requested by the node daemon while the docker socket is disabled",
+                UsageText: "rocketpool service agent [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "once, o",
+                        Usage: "Apply pending requests and exit, e.g. from a cron job, instead of waiting for new requests",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return runAgent(c, c.Bool("once"))
+
+                },
+            },
+
         },
     })
 }
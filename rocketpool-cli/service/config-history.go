@@ -0,0 +1,125 @@
+package service
+
+import (
+    "fmt"
+    "strconv"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Print the config change history
+func printConfigHistory(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get config history
+    history, err := rp.LoadConfigHistory()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(history) == 0 {
+        fmt.Println("No config changes have been recorded yet.")
+        return nil
+    }
+    for _, revision := range history {
+        fmt.Printf("Revision %d - %s by %s: %s\n", revision.Number, revision.Time.Format("2006-01-02 15:04:05 MST"), revision.User, revision.Description)
+        printSettingChanges(revision.Changes)
+        fmt.Println("")
+    }
+    return nil
+
+}
+
+
+// Revert the user config to a previous revision
+func revertConfig(c *cli.Context, revisionArg string) error {
+
+    // Parse revision
+    number, err := strconv.Atoi(revisionArg)
+    if err != nil {
+        return fmt.Errorf("Invalid revision '%s'", revisionArg)
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get revision & current user config
+    history, err := rp.LoadConfigHistory()
+    if err != nil {
+        return err
+    }
+    var revision *config.Revision
+    for ri := range history {
+        if history[ri].Number == number {
+            revision = &history[ri]
+            break
+        }
+    }
+    if revision == nil {
+        return fmt.Errorf("Config revision %d was not found; run 'rocketpool service config history' to list revisions", number)
+    }
+    currentConfig, err := rp.LoadUserConfig()
+    if err != nil {
+        return err
+    }
+    revisionConfig, err := config.Parse([]byte(revision.Settings))
+    if err != nil {
+        return err
+    }
+
+    // Print changes
+    changes, err := config.Diff(&currentConfig, &revisionConfig)
+    if err != nil {
+        return err
+    }
+    if len(changes) == 0 {
+        fmt.Printf("The current settings are the same as revision %d.\n", number)
+        return nil
+    }
+    fmt.Printf("Reverting to revision %d will make the following changes:\n", number)
+    printSettingChanges(changes)
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to revert to revision %d?", number)) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Revert config
+    if err := rp.RevertUserConfig(number); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Reverted to revision %d. Run 'rocketpool service start' to apply the settings.\n", number)
+    return nil
+
+}
+
+
+// Print setting changes
+func printSettingChanges(changes []config.SettingChange) {
+    for _, change := range changes {
+        oldValue := change.Old
+        if oldValue == "" {
+            oldValue = "(unset)"
+        }
+        newValue := change.New
+        if newValue == "" {
+            newValue = "(unset)"
+        }
+        fmt.Printf("    %s: %s -> %s\n", change.Setting, oldValue, newValue)
+    }
+}
@@ -0,0 +1,566 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "net"
+    "regexp"
+    "strconv"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/portmap"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/tui"
+)
+
+
+// Config
+const defaultValueLabel = "(default)"
+var webhookURLRegex = regexp.MustCompile("^(https?://\\S+)?$")
+
+
+// Full-screen service config editor
+type configEditor struct {
+    screen *tui.Screen
+    rp *rocketpool.Client
+    globalConfig config.RocketPoolConfig
+    userConfig config.RocketPoolConfig
+    changed bool
+}
+
+
+// A chain being edited
+type editorChain struct {
+    name string
+    global *config.Chain
+    user *config.Chain
+    fallbackP2PPort int
+}
+
+
+// Edit the user config in a full-screen UI, starting from the current settings
+// Returns whether the config was saved
+func editConfig(rp *rocketpool.Client, globalConfig, userConfig config.RocketPoolConfig) (bool, error) {
+
+    // Open screen
+    screen, err := tui.Open()
+    if err != nil {
+        return false, err
+    }
+    defer screen.Close()
+
+    // Initialize editor; the user's client selections are applied to the global config so selected client params are available
+    e := &configEditor{
+        screen: screen,
+        rp: rp,
+        globalConfig: globalConfig,
+        userConfig: userConfig,
+    }
+    if userConfig.Chains.Eth1.Client.Selected != "" {
+        e.globalConfig.Chains.Eth1.Client.Selected = userConfig.Chains.Eth1.Client.Selected
+    }
+    if userConfig.Chains.Eth2.Client.Selected != "" {
+        e.globalConfig.Chains.Eth2.Client.Selected = userConfig.Chains.Eth2.Client.Selected
+    }
+    eth1 := editorChain{"Eth 1.0", &(e.globalConfig.Chains.Eth1), &(e.userConfig.Chains.Eth1), config.DefaultEth1P2PPort}
+    eth2 := editorChain{"Eth 2.0", &(e.globalConfig.Chains.Eth2), &(e.userConfig.Chains.Eth2), config.DefaultEth2P2PPort}
+
+    // Main menu
+    selected := 0
+    for {
+        var ok bool
+        selected, ok = screen.Menu("Rocket Pool service configuration", []tui.Item{
+            tui.Item{Label: "Eth 1.0 client", Value: e.getClientLabel(eth1), Description: "The Eth 1.0 client to run, or an external Eth 1.0 client for Rocket Pool to use."},
+            tui.Item{Label: "Eth 1.0 client settings", Value: e.getSettingsLabel(eth1), Description: "Settings for the selected Eth 1.0 client, and its static peers and bootnodes."},
+            tui.Item{Label: "Eth 2.0 client", Value: e.getClientLabel(eth2), Description: "The Eth 2.0 beacon & validator client to run, or an external beacon node for Rocket Pool to use."},
+            tui.Item{Label: "Eth 2.0 client settings", Value: e.getSettingsLabel(eth2), Description: "Settings for the selected Eth 2.0 client, and its static peers and bootnodes."},
+            tui.Item{Label: "Smart node settings", Description: "Doppelganger protection, automatic rewards claims, transaction fees, alerts and the container runtime."},
+            tui.Item{Label: "Preview environment", Description: "Show the environment variables the Rocket Pool containers will be started with."},
+            tui.Item{Label: "Save and exit", Description: "Save the settings; run 'rocketpool service start' afterwards to apply them."},
+            tui.Item{Label: "Exit without saving"},
+        }, selected)
+        if !ok {
+            selected = 7
+        }
+        switch selected {
+            case 0: e.editClient(eth1)
+            case 1: e.editClientSettings(eth1)
+            case 2: e.editClient(eth2)
+            case 3: e.editClientSettings(eth2)
+            case 4: e.editSmartnodeSettings()
+            case 5: e.previewEnv()
+            case 6:
+                if saved, err := e.save(); saved || err != nil {
+                    return saved, err
+                }
+            case 7:
+                if !e.changed || e.confirm("Discard your changes?") {
+                    return false, nil
+                }
+        }
+    }
+
+}
+
+
+// Select a chain's client, and whether it is managed outside of Rocket Pool
+func (e *configEditor) editClient(chain editorChain) {
+
+    // Get client options
+    items := []tui.Item{}
+    for _, option := range chain.global.Client.Options {
+        value := ""
+        if option.ID == chain.global.Client.Selected {
+            value = "(selected)"
+        }
+        image := option.Image
+        if image == "" {
+            image = option.BeaconImage
+        }
+        items = append(items, tui.Item{Label: option.Name, Value: value, Description: fmt.Sprintf("Runs the %s image.", image)})
+    }
+    items = append(items, tui.Item{
+        Label: "External client",
+        Value: formatBool(chain.user.External),
+        Description: fmt.Sprintf("Use an %s client you already run instead of running one in Rocket Pool's containers.", chain.name),
+    })
+
+    // Select client or toggle external client
+    selected, ok := e.screen.Menu(fmt.Sprintf("%s client", chain.name), items, 0)
+    if !ok {
+        return
+    }
+    if selected == len(chain.global.Client.Options) {
+        external, ok := e.selectBool(fmt.Sprintf("External %s client", chain.name), chain.user.External)
+        if !ok {
+            return
+        }
+        chain.user.External = external
+        if external {
+            provider, ok := e.screen.Edit(fmt.Sprintf("%s provider", chain.name), fmt.Sprintf("The address of your %s client's API, reachable from the Rocket Pool containers (e.g. http://192.168.1.10:8545).", chain.name), chain.user.Provider, func(value string) error {
+                if value == "" {
+                    return errors.New("A provider address is required")
+                }
+                return nil
+            })
+            if ok {
+                chain.user.Provider = provider
+            }
+        } else {
+            chain.user.Provider = ""
+        }
+        e.changed = true
+        return
+    }
+
+    // Set selected client; params are kept so that they are restored if the client is selected again
+    chain.global.Client.Selected = chain.global.Client.Options[selected].ID
+    chain.user.Client.Selected = chain.global.Client.Options[selected].ID
+    e.changed = true
+
+}
+
+
+// Edit a chain's client params, static peers, bootnodes and p2p port
+func (e *configEditor) editClientSettings(chain editorChain) {
+    client := chain.global.GetSelectedClient()
+    if client == nil {
+        e.screen.View(fmt.Sprintf("%s client settings", chain.name), []string{fmt.Sprintf("Please select an %s client first.", chain.name)})
+        return
+    }
+    selected := 0
+    for {
+
+        // Get settings
+        items := []tui.Item{}
+        for _, param := range client.Params {
+            value := getUserParam(chain.user, param.Env)
+            if value == "" {
+                value = defaultValueLabel
+                if param.Default != "" {
+                    value = fmt.Sprintf("(default: %s)", param.Default)
+                }
+            }
+            items = append(items, tui.Item{Label: param.Name, Value: value, Description: param.Description})
+        }
+        defaultP2PPort := config.GetDefaultP2PPort(client.ID, chain.fallbackP2PPort)
+        items = append(items,
+            tui.Item{Label: "Static peers", Value: strings.Join(chain.user.StaticPeers, ","), Description: fmt.Sprintf("Peers the %s client should always stay connected to, separated by commas.", chain.name)},
+            tui.Item{Label: "Bootnodes", Value: strings.Join(chain.user.Bootnodes, ","), Description: fmt.Sprintf("Nodes the %s client uses to discover peers, separated by commas, replacing the client defaults.", chain.name)},
+            tui.Item{Label: "P2P port", Value: formatP2PPort(chain.user.P2PPort, defaultP2PPort), Description: fmt.Sprintf("The port the %s client listens for p2p connections on; forward it to the node to allow inbound peers.", chain.name)},
+        )
+
+        // Select setting
+        var ok bool
+        selected, ok = e.screen.Menu(fmt.Sprintf("%s client settings (%s)", chain.name, client.Name), items, selected)
+        if !ok {
+            return
+        }
+
+        // Edit setting
+        switch {
+            case selected < len(client.Params):
+                param := client.Params[selected]
+                if value, ok := e.editParam(param, getUserParam(chain.user, param.Env)); ok {
+                    setUserParam(chain.user, param.Env, value)
+                    e.changed = true
+                }
+            case selected == len(client.Params):
+                if value, ok := e.screen.Edit("Static peers", items[selected].Description, strings.Join(chain.user.StaticPeers, ","), nil); ok {
+                    chain.user.StaticPeers = splitList(value)
+                    e.changed = true
+                }
+            case selected == len(client.Params) + 1:
+                if value, ok := e.screen.Edit("Bootnodes", items[selected].Description, strings.Join(chain.user.Bootnodes, ","), nil); ok {
+                    chain.user.Bootnodes = splitList(value)
+                    e.changed = true
+                }
+            default:
+                value := ""
+                if chain.user.P2PPort > 0 {
+                    value = strconv.Itoa(chain.user.P2PPort)
+                }
+                description := fmt.Sprintf("%s\nLeave blank for the default of '%d'.", items[selected].Description, defaultP2PPort)
+                if value, ok := e.screen.Edit("P2P port", description, value, func(value string) error {
+                    if port, err := strconv.Atoi(value); value != "" && (err != nil || port < 1 || port > 65535) {
+                        return errors.New("Please enter a port from 1 to 65535")
+                    }
+                    return nil
+                }); ok {
+                    chain.user.P2PPort, _ = strconv.Atoi(value)
+                    e.changed = true
+                }
+        }
+
+    }
+}
+
+
+// Edit a client param value; bool & enum params are selected from their options
+func (e *configEditor) editParam(param config.ClientParam, value string) (string, bool) {
+
+    // Get description
+    description := param.Description
+    if format := param.GetFormatDescription(); format != "" {
+        description += fmt.Sprintf("\nExpected format: %s.", format)
+    }
+    if param.Default != "" {
+        description += fmt.Sprintf("\nLeave blank for the default of '%s'.", param.Default)
+    }
+
+    // Select option
+    var options []string
+    switch param.GetType() {
+        case config.ParamTypeBool: options = []string{"true", "false"}
+        case config.ParamTypeEnum: options = param.Options
+    }
+    if len(options) > 0 {
+        items := []tui.Item{}
+        selected := 0
+        for oi, option := range options {
+            items = append(items, tui.Item{Label: option, Description: description})
+            if option == value {
+                selected = oi
+            }
+        }
+        if !param.Required || param.Default != "" {
+            items = append(items, tui.Item{Label: defaultValueLabel, Description: description})
+        }
+        selected, ok := e.screen.Menu(param.Name, items, selected)
+        if !ok {
+            return "", false
+        }
+        if selected == len(options) {
+            return "", true
+        }
+        return options[selected], true
+    }
+
+    // Edit value
+    return e.screen.Edit(param.Name, description, value, func(value string) error {
+        if value == "" && param.Default != "" {
+            return nil
+        }
+        return param.Validate(value)
+    })
+
+}
+
+
+// Edit smart node settings
+func (e *configEditor) editSmartnodeSettings() {
+    sn := &(e.userConfig.Smartnode)
+    pm := &(e.userConfig.PortMapping)
+    selected := 0
+    for {
+
+        // Select setting
+        var ok bool
+        selected, ok = e.screen.Menu("Smart node settings", []tui.Item{
+            tui.Item{Label: "Doppelganger protection", Value: formatBool(sn.DoppelgangerProtection), Description: "Wait several epochs before starting the validator after importing or recovering validator keys, to avoid being slashed if they are active elsewhere."},
+            tui.Item{Label: "Automatic rewards claims", Value: formatBool(!sn.AutoWithdrawDisabled), Description: "Automatically claim rewards from withdrawable minipools."},
+            tui.Item{Label: "Rewards claim max gas price", Value: formatGwei(sn.AutoWithdrawMaxGasPrice), Description: "The maximum gas price in gwei to claim rewards at; blank for no limit."},
+            tui.Item{Label: "Max fee", Value: formatGwei(sn.MaxFee), Description: "The maximum gas price in gwei to send transactions at; blank for no limit."},
+            tui.Item{Label: "Priority fee", Value: formatGwei(sn.PriorityFee), Description: "The priority fee in gwei to add to transactions; blank to use the recent median."},
+            tui.Item{Label: "Alert webhook URL", Value: sn.AlertWebhookURL, Description: "A webhook URL (e.g. Slack or Discord) which node alerts are posted to; blank for none."},
+            tui.Item{Label: "Docker socket disabled", Value: formatBool(sn.DockerSocketDisabled), Description: "Run the node and api containers without the docker socket; validator restarts & stops are then queued for 'rocketpool service agent' on the host."},
+            tui.Item{Label: "Container runtime", Value: sn.ContainerRuntime, Description: "docker or podman; detected from the commands installed on the host if blank."},
+            tui.Item{Label: "Service backend", Value: sn.ServiceBackend, Description: "compose (containers) or systemd (native units); must match how the service was installed."},
+            tui.Item{Label: "Architecture", Value: sn.Architecture, Description: "amd64 or arm64; detected from the host if blank."},
+            tui.Item{Label: "Port mapping", Value: pm.Method, Description: "Forward the p2p ports from the router with upnp, natpmp, or auto to try both; blank to disable."},
+            tui.Item{Label: "Port mapping host", Value: pm.Host, Description: "The LAN IPv4 address of the node host to forward UPnP mappings to; blank for the node daemon's own address."},
+        }, selected)
+        if !ok {
+            return
+        }
+
+        // Edit setting
+        switch selected {
+            case 0:
+                if value, ok := e.selectBool("Doppelganger protection", sn.DoppelgangerProtection); ok {
+                    sn.DoppelgangerProtection = value
+                    e.changed = true
+                }
+            case 1:
+                if value, ok := e.selectBool("Automatic rewards claims", !sn.AutoWithdrawDisabled); ok {
+                    sn.AutoWithdrawDisabled = !value
+                    e.changed = true
+                }
+            case 2: e.editGwei("Rewards claim max gas price", &(sn.AutoWithdrawMaxGasPrice))
+            case 3: e.editGwei("Max fee", &(sn.MaxFee))
+            case 4: e.editGwei("Priority fee", &(sn.PriorityFee))
+            case 5:
+                if value, ok := e.screen.Edit("Alert webhook URL", "A webhook URL (e.g. Slack or Discord) which node alerts are posted to; blank for none.", sn.AlertWebhookURL, func(value string) error {
+                    if !webhookURLRegex.MatchString(value) {
+                        return errors.New("Please enter a valid http(s) URL")
+                    }
+                    return nil
+                }); ok {
+                    sn.AlertWebhookURL = value
+                    e.changed = true
+                }
+            case 6:
+                if value, ok := e.selectBool("Docker socket disabled", sn.DockerSocketDisabled); ok {
+                    sn.DockerSocketDisabled = value
+                    e.changed = true
+                }
+            case 7:
+                options := []string{"", rocketpool.RuntimeDocker, rocketpool.RuntimePodman}
+                selected, ok := e.screen.Menu("Container runtime", []tui.Item{
+                    tui.Item{Label: "Detect automatically"},
+                    tui.Item{Label: rocketpool.RuntimeDocker},
+                    tui.Item{Label: rocketpool.RuntimePodman},
+                }, 0)
+                if ok {
+                    sn.ContainerRuntime = options[selected]
+                    e.changed = true
+                }
+            case 8:
+                options := []string{"", rocketpool.BackendSystemd}
+                selected, ok := e.screen.Menu("Service backend", []tui.Item{
+                    tui.Item{Label: rocketpool.BackendCompose},
+                    tui.Item{Label: rocketpool.BackendSystemd},
+                }, 0)
+                if ok {
+                    sn.ServiceBackend = options[selected]
+                    e.changed = true
+                }
+            case 9:
+                options := []string{"", config.ArchitectureAmd64, config.ArchitectureArm64}
+                selected, ok := e.screen.Menu("Architecture", []tui.Item{
+                    tui.Item{Label: "Detect automatically"},
+                    tui.Item{Label: config.ArchitectureAmd64},
+                    tui.Item{Label: config.ArchitectureArm64},
+                }, 0)
+                if ok {
+                    sn.Architecture = options[selected]
+                    e.changed = true
+                }
+            case 10:
+                options := []string{"", portmap.MethodUPnP, portmap.MethodNATPMP, portmap.MethodAuto}
+                selected, ok := e.screen.Menu("Port mapping", []tui.Item{
+                    tui.Item{Label: "Disabled"},
+                    tui.Item{Label: "UPnP"},
+                    tui.Item{Label: "NAT-PMP"},
+                    tui.Item{Label: "Automatic (UPnP, then NAT-PMP)"},
+                }, 0)
+                if ok {
+                    pm.Method = options[selected]
+                    e.changed = true
+                }
+            case 11:
+                if value, ok := e.screen.Edit("Port mapping host", "The LAN IPv4 address of the node host to forward UPnP mappings to; blank for the node daemon's own address.", pm.Host, func(value string) error {
+                    if value != "" && net.ParseIP(value).To4() == nil {
+                        return errors.New("Please enter an IPv4 address")
+                    }
+                    return nil
+                }); ok {
+                    pm.Host = value
+                    e.changed = true
+                }
+        }
+
+    }
+}
+
+
+// Show the environment variables the containers will be started with
+func (e *configEditor) previewEnv() {
+    merged := config.Merge(&(e.globalConfig), &(e.userConfig))
+    lines := []string{}
+    if err := merged.Validate(); err != nil {
+        lines = append(lines, fmt.Sprintf("The settings are invalid: %s", err.Error()))
+    } else if env, err := e.rp.GetComposeEnv(merged); err != nil {
+        lines = append(lines, fmt.Sprintf("The settings are invalid: %s", err.Error()))
+    } else {
+        lines = env
+    }
+    e.screen.View("Container environment", lines)
+}
+
+
+// Validate and save the user config
+func (e *configEditor) save() (bool, error) {
+    merged := config.Merge(&(e.globalConfig), &(e.userConfig))
+    if err := merged.Validate(); err != nil {
+        e.screen.View("Settings not saved", []string{err.Error()})
+        return false, nil
+    }
+    if _, err := e.rp.GetComposeEnv(merged); err != nil {
+        e.screen.View("Settings not saved", []string{err.Error()})
+        return false, nil
+    }
+    if err := e.rp.SaveUserConfig(e.userConfig, "Configured with 'rocketpool service config'"); err != nil {
+        return false, err
+    }
+    return true, nil
+}
+
+
+// Select a boolean value
+func (e *configEditor) selectBool(title string, value bool) (bool, bool) {
+    selected := 1
+    if value {
+        selected = 0
+    }
+    selected, ok := e.screen.Menu(title, []tui.Item{tui.Item{Label: "Yes"}, tui.Item{Label: "No"}}, selected)
+    return (selected == 0), ok
+}
+
+
+// Prompt for confirmation
+func (e *configEditor) confirm(title string) bool {
+    confirmed, ok := e.selectBool(title, false)
+    return ok && confirmed
+}
+
+
+// Edit a gwei amount; 0 (blank) means the setting is unset
+func (e *configEditor) editGwei(title string, value *float64) {
+    text, ok := e.screen.Edit(title, "Enter an amount in gwei, or leave blank to unset.", formatGwei(*value), func(text string) error {
+        if text == "" {
+            return nil
+        }
+        if amount, err := strconv.ParseFloat(text, 64); err != nil || amount < 0 {
+            return errors.New("Please enter a valid gwei amount")
+        }
+        return nil
+    })
+    if !ok {
+        return
+    }
+    *value = 0
+    if text != "" {
+        *value, _ = strconv.ParseFloat(text, 64)
+    }
+    e.changed = true
+}
+
+
+// Get the label for a chain's selected client
+func (e *configEditor) getClientLabel(chain editorChain) string {
+    client := chain.global.GetSelectedClient()
+    if client == nil {
+        return "(none selected)"
+    }
+    if chain.user.External {
+        return fmt.Sprintf("%s (external, %s)", client.Name, chain.user.Provider)
+    }
+    return client.Name
+}
+
+
+// Get the label for a chain's client settings
+func (e *configEditor) getSettingsLabel(chain editorChain) string {
+    client := chain.global.GetSelectedClient()
+    if client == nil {
+        return ""
+    }
+    set := 0
+    for _, param := range client.Params {
+        if getUserParam(chain.user, param.Env) != "" {
+            set++
+        }
+    }
+    return fmt.Sprintf("%d of %d set", set, len(client.Params))
+}
+
+
+// Get a user param value
+func getUserParam(chain *config.Chain, env string) string {
+    for _, param := range chain.Client.Params {
+        if param.Env == env {
+            return param.Value
+        }
+    }
+    return ""
+}
+
+
+// Set a user param value, removing the param if the value is empty
+func setUserParam(chain *config.Chain, env, value string) {
+    params := []config.UserParam{}
+    for _, param := range chain.Client.Params {
+        if param.Env != env {
+            params = append(params, param)
+        }
+    }
+    if value != "" {
+        params = append(params, config.UserParam{Env: env, Value: value})
+    }
+    chain.Client.Params = params
+}
+
+
+// Split a comma-separated list
+func splitList(value string) []string {
+    values := []string{}
+    for _, item := range strings.Split(value, ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            values = append(values, item)
+        }
+    }
+    return values
+}
+
+
+// Format values for display
+func formatBool(value bool) string {
+    if value {
+        return "Yes"
+    }
+    return "No"
+}
+func formatGwei(value float64) string {
+    if value == 0 {
+        return ""
+    }
+    return strconv.FormatFloat(value, 'f', -1, 64)
+}
+func formatP2PPort(value, defaultValue int) string {
+    if value == 0 {
+        return fmt.Sprintf("(default: %d)", defaultValue)
+    }
+    return strconv.Itoa(value)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Validate the global config & user settings against the config schema
+func validateConfig(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Load & validate config; schema errors are reported with their file & line numbers
+    rpConfig, err := rp.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+    if err := rpConfig.Validate(); err != nil {
+        return err
+    }
+    if _, err := rp.GetComposeEnv(rpConfig); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Println("The Rocket Pool service config is valid.")
+    return nil
+
+}
@@ -3,6 +3,8 @@ package service
 import (
     "fmt"
     "math/rand"
+    "strconv"
+    "strings"
     "time"
 
     "github.com/urfave/cli"
@@ -10,6 +12,7 @@ import (
     "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/tui"
 )
 
 
@@ -27,19 +30,80 @@ func configureService(c *cli.Context) error {
         return err
     }
 
+    // Edit the current user config in the full-screen editor if available
+    if c.String("preset") == "" && !c.Bool("classic") && !cliutils.IsAccessible() && tui.IsAvailable() {
+        userConfig, err := rp.LoadUserConfig()
+        if err != nil {
+            return err
+        }
+        saved, err := editConfig(rp, globalConfig, userConfig)
+        if err != nil {
+            return err
+        }
+        if saved {
+            fmt.Println("Done! Run 'rocketpool service start' to apply new configuration settings.")
+        } else {
+            fmt.Println("Configuration was not changed.")
+        }
+        return nil
+    }
+
     // Initialize user config
     userConfig := config.RocketPoolConfig{}
 
+    // Get preset
+    var preset *config.Preset
+    if presetName := c.String("preset"); presetName != "" {
+        selectedPreset, err := getPreset(presetName)
+        if err != nil {
+            return err
+        }
+        preset = &selectedPreset
+        fmt.Printf("Using preset %s.\n", preset.Name)
+        fmt.Println("")
+    } else if preset, err = selectPreset(); err != nil {
+        return err
+    }
+    eth1Preset, eth2Preset := &config.PresetChain{}, &config.PresetChain{}
+    if preset != nil {
+        eth1Preset, eth2Preset = &preset.Eth1, &preset.Eth2
+    }
+
     // Configure chains
-    if err := configureChain(&(globalConfig.Chains.Eth1), &(userConfig.Chains.Eth1), "Eth 1.0", false); err != nil {
+    if err := configureChain(&(globalConfig.Chains.Eth1), &(userConfig.Chains.Eth1), "Eth 1.0", false, eth1Preset); err != nil {
         return err
     }
-    if err := configureChain(&(globalConfig.Chains.Eth2), &(userConfig.Chains.Eth2), "Eth 2.0", true); err != nil {
+    if err := configureChain(&(globalConfig.Chains.Eth2), &(userConfig.Chains.Eth2), "Eth 2.0", true, eth2Preset); err != nil {
         return err
     }
 
+    // Configure doppelganger protection
+    userConfig.Smartnode.DoppelgangerProtection = cliutils.Confirm("Would you like to enable doppelganger protection? Your validator will not start until several epochs have passed after importing or recovering validator keys, to avoid being slashed if they are active elsewhere.")
+
+    // Configure automatic rewards claims
+    userConfig.Smartnode.AutoWithdrawDisabled = !cliutils.Confirm("Would you like the node to automatically claim rewards from withdrawable minipools?")
+    if !userConfig.Smartnode.AutoWithdrawDisabled {
+        maxGasPrice := cliutils.Prompt("Please enter the maximum gas price in gwei to claim rewards at, or leave blank for no limit:", "^(\\d+(\\.\\d+)?)?$", "Please enter a valid gas price")
+        if maxGasPrice != "" {
+            userConfig.Smartnode.AutoWithdrawMaxGasPrice, _ = strconv.ParseFloat(maxGasPrice, 64)
+        }
+    }
+
+    // Configure transaction gas limits
+    maxFee := cliutils.Prompt("Please enter the maximum gas price in gwei to send transactions at, or leave blank for no limit:", "^(\\d+(\\.\\d+)?)?$", "Please enter a valid gas price")
+    if maxFee != "" {
+        userConfig.Smartnode.MaxFee, _ = strconv.ParseFloat(maxFee, 64)
+    }
+    priorityFee := cliutils.Prompt("Please enter the priority fee in gwei to add to transactions, or leave blank to use the recent median:", "^(\\d+(\\.\\d+)?)?$", "Please enter a valid priority fee")
+    if priorityFee != "" {
+        userConfig.Smartnode.PriorityFee, _ = strconv.ParseFloat(priorityFee, 64)
+    }
+
+    // Configure alert webhook
+    userConfig.Smartnode.AlertWebhookURL = cliutils.Prompt("Please enter a webhook URL to send node alerts to (e.g. a Slack or Discord webhook), or leave blank for none:", "^(https?://\\S+)?$", "Please enter a valid http(s) URL")
+
     // Save user config
-    if err := rp.SaveUserConfig(userConfig); err != nil {
+    if err := rp.SaveUserConfig(userConfig, "Configured with 'rocketpool service config'"); err != nil {
         return err
     }
 
@@ -50,23 +114,37 @@ func configureService(c *cli.Context) error {
 }
 
 
-// Configure a chain
-func configureChain(globalChain, userChain *config.Chain, chainName string, defaultRandomClient bool) error {
+// Configure a chain, using the preset's client & settings where set
+func configureChain(globalChain, userChain *config.Chain, chainName string, defaultRandomClient bool, preset *config.PresetChain) error {
 
     // Check client options
     if len(globalChain.Client.Options) == 0 {
         return fmt.Errorf("There are no available %s client options", chainName)
     }
 
+    // Prompt for external client
+    if cliutils.Confirm(fmt.Sprintf("Do you already run your own %s client which you would like Rocket Pool to use?", chainName)) {
+        return configureExternalChain(globalChain, userChain, chainName, preset)
+    }
+
+    // Get preset client
+    selected := -1
+    for oi, option := range globalChain.Client.Options {
+        if preset.Client != "" && option.ID == preset.Client {
+            selected = oi
+        }
+    }
+
     // Prompt for random client selection
     var randomClient bool
-    if defaultRandomClient {
+    if defaultRandomClient && selected == -1 {
         randomClient = cliutils.Confirm(fmt.Sprintf("Would you like to run a random %s client (recommended)?", chainName))
     }
 
     // Select client
-    var selected int
-    if randomClient {
+    if selected != -1 {
+        fmt.Printf("Using the %s client from the preset.\n", globalChain.Client.Options[selected].Name)
+    } else if randomClient {
         rand.Seed(time.Now().UnixNano())
         selected = rand.Intn(len(globalChain.Client.Options))
     } else {
@@ -86,39 +164,114 @@ func configureChain(globalChain, userChain *config.Chain, chainName string, defa
     fmt.Println("")
 
     // Prompt for params
-    params := []config.UserParam{}
-    for _, param := range globalChain.GetSelectedClient().Params {
-
-        // Get expected param format
-        var expectedFormat string
-        if param.Regex != "" {
-            expectedFormat = param.Regex
-        } else if param.Required {
-            expectedFormat = "^.+$"
-        } else {
-            expectedFormat = "^.*$"
-        }
+    userChain.Client.Params = promptClientParams(globalChain.GetSelectedClient(), preset)
+
+    // Prompt for static peers & bootnodes
+    if cliutils.Confirm(fmt.Sprintf("Would you like to configure static peers or bootnodes for the %s client?", chainName)) {
+        userChain.StaticPeers = promptList("Please enter the static peers to connect to, separated by commas (leave blank for none)")
+        userChain.Bootnodes = promptList("Please enter the bootnodes to use, separated by commas (leave blank for the client defaults)")
+    }
+
+    // Return
+    return nil
+
+}
 
-        // Optional field text
-        optionalLabel := ""
-        if !param.Required {
-            optionalLabel = " (leave blank for none)"
-        }
 
-        // Prompt for value
-        value := cliutils.Prompt(fmt.Sprintf("Please enter the %s%s", param.Name, optionalLabel), expectedFormat, fmt.Sprintf("Invalid %s", param.Name))
+// Configure an externally managed chain client
+func configureExternalChain(globalChain, userChain *config.Chain, chainName string, preset *config.PresetChain) error {
 
-        // Add param
+    // Select client type
+    clientOptions := make([]string, len(globalChain.Client.Options))
+    for oi, option := range globalChain.Client.Options {
+        clientOptions[oi] = option.Name
+    }
+    selected, _ := cliutils.Select(fmt.Sprintf("Which %s client does your node run?", chainName), clientOptions)
+
+    // Set selected client
+    globalChain.Client.Selected = globalChain.Client.Options[selected].ID
+    userChain.Client.Selected = globalChain.Client.Options[selected].ID
+    userChain.External = true
+
+    // Prompt for provider
+    userChain.Provider = cliutils.Prompt(fmt.Sprintf("Please enter the address of your %s client's API, reachable from the Rocket Pool containers (e.g. http://192.168.1.10:8545)", chainName), "^.+$", "Invalid provider address")
+
+    // Prompt for params
+    fmt.Println("")
+    userChain.Client.Params = promptClientParams(globalChain.GetSelectedClient(), preset)
+
+    // Log & return
+    fmt.Printf("External %s %s client selected - Rocket Pool will not run a %s container.\n", globalChain.GetSelectedClient().Name, chainName, chainName)
+    fmt.Println("")
+    return nil
+
+}
+
+
+// Prompt for a client's parameter values
+// Values set by the preset are used without prompting, and preset settings the client does not define are passed through
+func promptClientParams(client *config.ClientOption, preset *config.PresetChain) []config.UserParam {
+    params := []config.UserParam{}
+    defined := make(map[string]bool)
+    for _, param := range client.Params {
+        defined[param.Env] = true
+        if value, ok := preset.GetParam(param.Env); ok && param.Validate(value) == nil {
+            fmt.Printf("Using %s '%s' from the preset.\n", param.Name, value)
+            params = append(params, config.UserParam{Env: param.Env, Value: value})
+            continue
+        }
         params = append(params, config.UserParam{
             Env: param.Env,
-            Value: value,
+            Value: promptParam(param),
         })
+    }
+    for _, param := range preset.Params {
+        if !defined[param.Env] {
+            params = append(params, param)
+        }
+    }
+    return params
+}
 
+
+// Prompt for a client parameter value until a valid value is entered
+func promptParam(param config.ClientParam) string {
+
+    // Build prompt
+    prompt := fmt.Sprintf("Please enter the %s", param.Name)
+    if format := param.GetFormatDescription(); format != "" {
+        prompt += fmt.Sprintf(" (%s)", format)
+    }
+    if param.Description != "" {
+        prompt = fmt.Sprintf("%s\n%s", param.Description, prompt)
+    }
+    if param.Default != "" {
+        prompt += fmt.Sprintf(" (leave blank for the default of '%s')", param.Default)
+    } else if !param.Required {
+        prompt += " (leave blank for none)"
     }
-    userChain.Client.Params = params
 
-    // Return
-    return nil
+    // Prompt for value
+    for {
+        value := cliutils.Prompt(prompt, "^.*$", "")
+        if err := param.Validate(value); err != nil && !(value == "" && param.Default != "") {
+            fmt.Println(err)
+            fmt.Println("")
+            continue
+        }
+        return value
+    }
 
 }
 
+
+// Prompt for a comma-separated list of values
+func promptList(initialPrompt string) []string {
+    values := []string{}
+    for _, value := range strings.Split(cliutils.Prompt(initialPrompt, "^.*$", ""), ",") {
+        if value = strings.TrimSpace(value); value != "" {
+            values = append(values, value)
+        }
+    }
+    return values
+}
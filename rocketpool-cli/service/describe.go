@@ -0,0 +1,425 @@
+package service
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Setting description
+type settingDescription struct {
+    Name string
+    Key string
+    Description string
+    Type string
+    Default string
+    Value string
+    Client string
+    Containers []string
+}
+
+
+// Describe a Rocket Pool service setting
+func describeSetting(c *cli.Context, setting string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Load config
+    cfg, err := rp.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+
+    // Get settings
+    settings := getSettingDescriptions(cfg)
+
+    // Find exact matches
+    matches := []settingDescription{}
+    for _, s := range settings {
+        if strings.EqualFold(s.Key, setting) || strings.EqualFold(s.Name, setting) {
+            matches = append(matches, s)
+        }
+    }
+
+    // Find partial matches if no exact matches found
+    if len(matches) == 0 {
+        for _, s := range settings {
+            if strings.Contains(strings.ToLower(s.Key), strings.ToLower(setting)) || strings.Contains(strings.ToLower(s.Name), strings.ToLower(setting)) || strings.Contains(strings.ToLower(s.Description), strings.ToLower(setting)) {
+                matches = append(matches, s)
+            }
+        }
+        if len(matches) > 1 {
+            fmt.Printf("Multiple settings match '%s':\n", setting)
+            for _, s := range matches {
+                fmt.Printf("- %s (%s)\n", s.Key, s.Name)
+            }
+            return nil
+        }
+    }
+
+    // Check matches
+    if len(matches) == 0 {
+        fmt.Printf("No settings match '%s'.\n", setting)
+        return nil
+    }
+
+    // Print & return
+    for mi, s := range matches {
+        if mi > 0 {
+            fmt.Println("")
+        }
+        printSettingDescription(s)
+    }
+    return nil
+
+}
+
+
+// Get descriptions of all available settings
+func getSettingDescriptions(cfg config.RocketPoolConfig) []settingDescription {
+
+    // Core chain settings
+    settings := []settingDescription{}
+    for _, chain := range []struct{
+        id string
+        name string
+        chain *config.Chain
+        containers []string
+        p2pPort int
+        defaultP2PPort int
+    }{
+        {"eth1", "Eth 1.0", &(cfg.Chains.Eth1), []string{rocketpool.Eth1ServiceName}, cfg.GetEth1P2PPort(), config.GetDefaultP2PPort(cfg.Chains.Eth1.Client.Selected, config.DefaultEth1P2PPort)},
+        {"eth2", "Eth 2.0", &(cfg.Chains.Eth2), []string{rocketpool.Eth2ServiceName, "validator"}, cfg.GetEth2P2PPort(), config.GetDefaultP2PPort(cfg.Chains.Eth2.Client.Selected, config.DefaultEth2P2PPort)},
+    } {
+        settings = append(settings,
+            settingDescription{
+                Name: fmt.Sprintf("%s provider", chain.name),
+                Key: fmt.Sprintf("chains.%s.provider", chain.id),
+                Description: fmt.Sprintf("The address of the %s client API used by the Rocket Pool services.", chain.name),
+                Type: config.ParamTypeURL,
+                Value: chain.chain.Provider,
+                Containers: []string{fmt.Sprintf("all (%s_PROVIDER)", strings.ToUpper(chain.id))},
+            },
+            settingDescription{
+                Name: fmt.Sprintf("External %s client", chain.name),
+                Key: fmt.Sprintf("chains.%s.external", chain.id),
+                Description: fmt.Sprintf("Whether the %s client is managed outside of Rocket Pool; if set, the %s container is not run.", chain.name, chain.id),
+                Type: config.ParamTypeBool,
+                Default: "false",
+                Value: fmt.Sprintf("%t", chain.chain.External),
+            },
+            settingDescription{
+                Name: fmt.Sprintf("%s chain data path", chain.name),
+                Key: fmt.Sprintf("chains.%s.dataPath", chain.id),
+                Description: fmt.Sprintf("An absolute host path to store the %s chain data in instead of a docker volume, e.g. on a separate disk. Use `rocketpool service migrate-chaindata` to move existing data.", chain.name),
+                Type: config.ParamTypePath,
+                Value: chain.chain.DataPath,
+                Containers: []string{fmt.Sprintf("%s (%s_DATA_VOLUME)", chain.containers[0], strings.ToUpper(chain.id))},
+            },
+            settingDescription{
+                Name: fmt.Sprintf("%s p2p port", chain.name),
+                Key: fmt.Sprintf("chains.%s.p2pPort", chain.id),
+                Description: fmt.Sprintf("The port the %s client listens for p2p connections on; forward it to the node to allow inbound peers.", chain.name),
+                Type: config.ParamTypeInt,
+                Default: fmt.Sprintf("%d", chain.defaultP2PPort),
+                Value: fmt.Sprintf("%d", chain.p2pPort),
+                Containers: []string{fmt.Sprintf("%s (%s_P2P_PORT)", chain.containers[0], strings.ToUpper(chain.id))},
+            },
+            settingDescription{
+                Name: fmt.Sprintf("%s static peers", chain.name),
+                Key: fmt.Sprintf("chains.%s.staticPeers", chain.id),
+                Description: fmt.Sprintf("Peers the %s client should always stay connected to.", chain.name),
+                Type: "list",
+                Value: strings.Join(chain.chain.StaticPeers, ","),
+                Containers: []string{fmt.Sprintf("%s (%s_STATIC_PEERS)", chain.containers[0], strings.ToUpper(chain.id))},
+            },
+            settingDescription{
+                Name: fmt.Sprintf("%s bootnodes", chain.name),
+                Key: fmt.Sprintf("chains.%s.bootnodes", chain.id),
+                Description: fmt.Sprintf("Nodes the %s client uses to discover peers, replacing the client defaults.", chain.name),
+                Type: "list",
+                Value: strings.Join(chain.chain.Bootnodes, ","),
+                Containers: []string{fmt.Sprintf("%s (%s_BOOTNODES)", chain.containers[0], strings.ToUpper(chain.id))},
+            },
+        )
+
+        // Client params
+        userValues := map[string]string{}
+        for _, param := range chain.chain.Client.Params {
+            userValues[param.Env] = param.Value
+        }
+        for _, option := range chain.chain.Client.Options {
+            for _, param := range option.Params {
+                containers := make([]string, len(chain.containers))
+                for ci, container := range chain.containers {
+                    containers[ci] = fmt.Sprintf("%s (%s)", container, param.Env)
+                }
+                value := ""
+                if option.ID == chain.chain.Client.Selected {
+                    value = userValues[param.Env]
+                }
+                settings = append(settings, settingDescription{
+                    Name: param.Name,
+                    Key: param.Env,
+                    Description: param.Description,
+                    Type: param.GetType(),
+                    Default: param.Default,
+                    Value: value,
+                    Client: fmt.Sprintf("%s (%s)", option.Name, chain.name),
+                    Containers: containers,
+                })
+            }
+        }
+
+    }
+    settings = append(settings, settingDescription{
+        Name: "Eth 1.0 analytics provider",
+        Key: "chains.eth1.analyticsProvider",
+        Description: "The address of a secondary Eth 1.0 client API used for heavy historical queries, keeping the Eth 1.0 provider free for duties. The Eth 1.0 provider is used if blank or unreachable.",
+        Type: config.ParamTypeURL,
+        Value: cfg.Chains.Eth1.AnalyticsProvider,
+        Containers: []string{"api", "node", "watchtower"},
+    })
+
+    // Smart node settings
+    settings = append(settings,
+        settingDescription{
+            Name: "Doppelganger protection",
+            Key: "smartnode.doppelgangerProtection",
+            Description: "Whether to delay validator startup after importing or recovering validator keys, to avoid being slashed if they are active elsewhere.",
+            Type: config.ParamTypeBool,
+            Default: "false",
+            Value: fmt.Sprintf("%t", cfg.Smartnode.DoppelgangerProtection),
+            Containers: []string{"node", "validator (DOPPELGANGER_DETECTION)"},
+        },
+        settingDescription{
+            Name: "Doppelganger protection delay",
+            Key: "smartnode.doppelgangerDelayEpochs",
+            Description: "The number of epochs to keep the validator stopped for after importing or recovering validator keys.",
+            Type: config.ParamTypeInt,
+            Default: fmt.Sprintf("%d", config.DefaultDoppelgangerDelayEpochs),
+            Value: fmt.Sprintf("%d", cfg.GetDoppelgangerDelayEpochs()),
+            Containers: []string{"node"},
+        },
+        settingDescription{
+            Name: "Automatic rewards claims disabled",
+            Key: "smartnode.autoWithdrawDisabled",
+            Description: "Whether to disable automatically claiming rewards from withdrawable minipools.",
+            Type: config.ParamTypeBool,
+            Default: "false",
+            Value: fmt.Sprintf("%t", cfg.Smartnode.AutoWithdrawDisabled),
+            Containers: []string{"node"},
+        },
+        settingDescription{
+            Name: "Automatic rewards claim gas price limit",
+            Key: "smartnode.autoWithdrawMaxGasPrice",
+            Description: "The maximum gas price in gwei to automatically claim rewards at; claims are deferred while gas is above it. 0 means no limit.",
+            Type: "number",
+            Default: "0",
+            Value: fmt.Sprintf("%g", cfg.Smartnode.AutoWithdrawMaxGasPrice),
+            Containers: []string{"node"},
+        },
+        settingDescription{
+            Name: "Maximum transaction fee",
+            Key: "smartnode.maxFee",
+            Description: "The maximum gas price in gwei to send transactions at; transactions are refused or deferred while gas is above it. 0 means no limit.",
+            Type: "number",
+            Default: "0",
+            Value: fmt.Sprintf("%g", cfg.Smartnode.MaxFee),
+            Containers: []string{"api", "node", "watchtower"},
+        },
+        settingDescription{
+            Name: "Transaction priority fee",
+            Key: "smartnode.priorityFee",
+            Description: "The priority fee in gwei to add to the base fee for transactions. 0 uses the median priority fee of recent blocks.",
+            Type: "number",
+            Default: "0",
+            Value: fmt.Sprintf("%g", cfg.Smartnode.PriorityFee),
+            Containers: []string{"api", "node", "watchtower"},
+        },
+        settingDescription{
+            Name: "Alert webhook URL",
+            Key: "smartnode.alertWebhookUrl",
+            Description: "A webhook URL (e.g. Slack or Discord) which node alerts are posted to.",
+            Type: config.ParamTypeURL,
+            Value: cfg.Smartnode.AlertWebhookURL,
+            Containers: []string{"node", "watchtower"},
+        },
+        settingDescription{
+            Name: "Docker socket disabled",
+            Key: "smartnode.dockerSocketDisabled",
+            Description: "Whether to run the node and api containers without the docker socket. Validator restarts & stops are then queued for `rocketpool service agent` on the host.",
+            Type: config.ParamTypeBool,
+            Default: "false",
+            Value: fmt.Sprintf("%t", cfg.Smartnode.DockerSocketDisabled),
+            Containers: []string{"api (DOCKER_SOCKET)", "node (DOCKER_SOCKET)"},
+        },
+        settingDescription{
+            Name: "Container runtime",
+            Key: "smartnode.containerRuntime",
+            Description: "The container runtime used to run the Rocket Pool service: docker (with docker-compose) or podman (with podman-compose). Detected from the commands installed on the host if blank, preferring docker.",
+            Type: config.ParamTypeEnum,
+            Value: cfg.Smartnode.ContainerRuntime,
+            Containers: []string{"api (DOCKER_SOCKET)", "node (DOCKER_SOCKET)"},
+        },
+        settingDescription{
+            Name: "Service backend",
+            Key: "smartnode.serviceBackend",
+            Description: "How the clients & smart node are run: compose (as containers, with docker-compose or podman-compose) or systemd (natively, as rocketpool-<service> systemd units). Selected by `rocketpool service install --backend`.",
+            Type: config.ParamTypeEnum,
+            Default: "compose",
+            Value: cfg.Smartnode.ServiceBackend,
+            Containers: []string{"all"},
+        },
+        settingDescription{
+            Name: "Architecture",
+            Key: "smartnode.architecture",
+            Description: "The host architecture to select client images & settings for: amd64 or arm64 (e.g. a Raspberry Pi 4 or Rock64). On arm64, client settings default to the low-power preset's values unless the client defines its own. Detected from the host if blank.",
+            Type: config.ParamTypeEnum,
+            Value: cfg.Smartnode.Architecture,
+            Containers: []string{"all (ARCHITECTURE)"},
+        },
+        settingDescription{
+            Name: "Node metrics address",
+            Key: "smartnode.metricsAddress",
+            Description: "The host:port the node daemon serves Prometheus metrics on, such as disk space & its predicted days until full. Leave blank to disable.",
+            Type: config.ParamTypeString,
+            Value: cfg.Smartnode.MetricsAddress,
+            Containers: []string{"node"},
+        },
+        settingDescription{
+            Name: "Offline node address",
+            Key: "smartnode.nodeAddress",
+            Description: "The node account address, if the node wallet is kept on an offline machine instead of the node. Transactions must then be prepared with `--offline`, signed with `rocketpool wallet sign-tx` on the offline machine, and sent with `rocketpool tx broadcast`. Ignored if the node wallet is initialized.",
+            Type: config.ParamTypeString,
+            Value: cfg.Smartnode.NodeAddress,
+            Containers: []string{"api"},
+        },
+    )
+
+    // Alert settings
+    settings = append(settings,
+        settingDescription{
+            Name: "Disk usage alert",
+            Key: "alerts.diskUsagePercent",
+            Description: "The percentage of a disk's space in use above which `rocketpool service doctor` and exported Prometheus alerting rules report it.",
+            Type: "number",
+            Default: fmt.Sprintf("%d", config.DefaultAlertDiskUsagePercent),
+            Value: fmt.Sprintf("%g", cfg.GetAlertDiskUsagePercent()),
+        },
+        settingDescription{
+            Name: "Disk full alert",
+            Key: "alerts.diskFullDays",
+            Description: "Alert when the node's disk is predicted to be full within this many days at its growth rate over the last week, as monitored by the node daemon's monitor-disk-space task.",
+            Type: "number",
+            Default: fmt.Sprintf("%d", config.DefaultAlertDiskFullDays),
+            Value: fmt.Sprintf("%g", cfg.GetAlertDiskFullDays()),
+            Containers: []string{"node"},
+        },
+    )
+
+    // Port mapping settings
+    settings = append(settings,
+        settingDescription{
+            Name: "Port mapping",
+            Key: "portMapping.method",
+            Description: "Have the node daemon forward the Eth 1.0 and Eth 2.0 p2p ports from the router: upnp, natpmp, or auto to try UPnP then NAT-PMP. The mappings are renewed while the daemon runs; check them with `rocketpool service net-diag`. Leave blank to disable.",
+            Type: config.ParamTypeEnum,
+            Value: cfg.PortMapping.Method,
+            Containers: []string{"node"},
+        },
+        settingDescription{
+            Name: "Port mapping host",
+            Key: "portMapping.host",
+            Description: "The LAN IPv4 address of the node host to forward UPnP mappings to. Leave blank to use the address the node daemon reaches the router from, which is only the host's address if the daemon shares the host's network. NAT-PMP always forwards to the daemon's address.",
+            Type: config.ParamTypeString,
+            Value: cfg.PortMapping.Host,
+            Containers: []string{"node"},
+        },
+    )
+
+    // Backup settings
+    settings = append(settings,
+        settingDescription{
+            Name: "Backup destination",
+            Key: "backup.destination",
+            Description: "Where to store automatic encrypted backups of the node's wallet, validator keys & settings: local, s3, sftp or rsync. Leave blank to disable backups.",
+            Type: config.ParamTypeEnum,
+            Value: cfg.Backup.Destination,
+            Containers: []string{"node", "api"},
+        },
+        settingDescription{
+            Name: "Backup path",
+            Key: "backup.path",
+            Description: "The folder to store backups in for local & sftp destinations, or the object key prefix for s3 destinations.",
+            Type: config.ParamTypePath,
+            Value: cfg.Backup.Path,
+            Containers: []string{"node", "api"},
+        },
+        settingDescription{
+            Name: "Backup interval",
+            Key: "backup.interval",
+            Description: "How often to create backups, as a duration (e.g. 12h).",
+            Type: config.ParamTypeString,
+            Default: config.DefaultBackupInterval,
+            Value: cfg.Backup.Interval,
+            Containers: []string{"node"},
+        },
+        settingDescription{
+            Name: "Backup retention",
+            Key: "backup.retention",
+            Description: "The number of backups to keep at the destination; older backups are deleted.",
+            Type: config.ParamTypeInt,
+            Default: fmt.Sprintf("%d", config.DefaultBackupRetention),
+            Value: fmt.Sprintf("%d", cfg.GetBackupRetention()),
+            Containers: []string{"node"},
+        },
+        settingDescription{
+            Name: "Backup passphrase path",
+            Key: "backup.passphrasePath",
+            Description: "A file containing the passphrase backups are encrypted with. Defaults to the node password.",
+            Type: config.ParamTypePath,
+            Value: cfg.Backup.PassphrasePath,
+            Containers: []string{"node", "api"},
+        },
+    )
+
+    // Return
+    return settings
+
+}
+
+
+// Print a setting description
+func printSettingDescription(s settingDescription) {
+    fmt.Printf("%s (%s)\n", s.Name, s.Key)
+    if s.Description != "" {
+        fmt.Println(s.Description)
+    }
+    fmt.Printf("Type:          %s\n", s.Type)
+    fmt.Printf("Default:       %s\n", valueOrNone(s.Default))
+    fmt.Printf("Current value: %s\n", valueOrNone(s.Value))
+    if s.Client != "" {
+        fmt.Printf("Client:        %s\n", s.Client)
+    }
+    if len(s.Containers) > 0 {
+        fmt.Printf("Applies to:    %s\n", strings.Join(s.Containers, ", "))
+    }
+}
+
+
+// Get a value or a placeholder if empty
+func valueOrNone(value string) string {
+    if value == "" {
+        return "(none)"
+    }
+    return value
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Import an Eth 1.0 chain data snapshot
+func importChainData(c *cli.Context, source string) error {
+
+    // Print warnings & prompt for confirmation
+    fmt.Println("Chain data snapshots are not verified by the Eth 1.0 client - only import snapshots from a source you trust.")
+    if c.String("checksum") == "" {
+        fmt.Println("No checksum was provided, so the snapshot's integrity will not be verified.")
+    }
+    if !cliutils.Confirm("The Eth 1.0 client will be stopped and its existing chain data replaced. Are you sure you want to continue?") {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Import chain data
+    if err := rp.ImportChainData(source, c.String("checksum")); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Println("")
+    fmt.Println("The chain data snapshot was successfully imported. Run 'rocketpool service start' to restart the Eth 1.0 client.")
+    return nil
+
+}
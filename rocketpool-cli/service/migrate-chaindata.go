@@ -0,0 +1,49 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Move a client's chain data to a host path
+func migrateChainData(c *cli.Context, chainID, targetPath string) error {
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("The %s client will be stopped while its chain data is copied to %s. Are you sure you want to continue?", chainID, targetPath)) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Migrate chain data
+    previousMount, err := rp.MigrateChainData(chainID, targetPath)
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Println("")
+    fmt.Printf("The %s chain data was successfully copied to %s and the config updated.\n", chainID, targetPath)
+    fmt.Println("Run 'rocketpool service start' to restart the client using the new location.")
+    fmt.Printf("The original chain data at %s has been kept; once the client is running normally, you can remove it with:\n", previousMount)
+    if previousMount == rocketpool.Eth1VolumeName || previousMount == rocketpool.Eth2VolumeName {
+        runtimeName := rocketpool.RuntimeDocker
+        if runtime, err := rp.GetContainerRuntime(); err == nil {
+            runtimeName = runtime.Name
+        }
+        fmt.Printf("    %s volume rm %s\n", runtimeName, previousMount)
+    } else {
+        fmt.Printf("    sudo rm -rf '%s'\n", previousMount)
+    }
+    return nil
+
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Check the Rocket Pool service client p2p networking
+func serviceNetDiag(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get network diagnostics
+    diag, err := rp.ServiceNetDiag()
+    if err != nil {
+        return err
+    }
+
+    // Print public IP address
+    if diag.PublicIPError != "" {
+        fmt.Printf("Could not determine the node's public IP address (%s); the p2p ports were not checked.\n\n", diag.PublicIPError)
+    } else {
+        fmt.Printf("Public IP address: %s\n\n", diag.PublicIP)
+    }
+
+    // Print port mapping status
+    if diag.PortMapping.Enabled {
+        fmt.Printf("Port mapping: %s", diag.PortMapping.Method)
+        if diag.PortMapping.Updated > 0 {
+            fmt.Printf(", last renewed at %s", time.Unix(diag.PortMapping.Updated, 0).Format("2006-01-02 15:04:05"))
+        }
+        if diag.PortMapping.ExternalIP != "" && diag.PublicIP != "" && diag.PortMapping.ExternalIP != diag.PublicIP {
+            fmt.Printf("\nThe router's external IP address %s differs from the public IP address; the router may be behind another NAT, which port mapping cannot forward through.", diag.PortMapping.ExternalIP)
+        }
+        fmt.Print("\n\n")
+    }
+
+    // Print client diagnostics
+    eth1Open := printClientNetDiag("Eth 1.0", diag.Eth1, diag.PortMapping.Enabled)
+    eth2Open := printClientNetDiag("Eth 2.0", diag.Eth2, diag.PortMapping.Enabled)
+
+    // Print suggestions
+    if eth1Open && eth2Open {
+        return nil
+    }
+    fmt.Println("To allow inbound peer connections:")
+    if diag.PortMapping.Enabled {
+        fmt.Println("- Check that UPnP or NAT-PMP is enabled in your router's settings, or forward the closed ports (both TCP and UDP) to this machine manually.")
+    } else {
+        fmt.Println("- Forward the closed ports (both TCP and UDP) from your router to this machine, or have the node map them automatically with the portMapping.method setting in 'rocketpool service config'.")
+    }
+    fmt.Println("- Allow the closed ports through the machine's firewall, e.g.:")
+    for _, client := range []api.ClientNetDiag{diag.Eth1, diag.Eth2} {
+        if client.External || (client.PortChecked && client.PortOpen) {
+            continue
+        }
+        fmt.Printf("    sudo ufw allow %d/tcp\n", client.P2PPort)
+        fmt.Printf("    sudo ufw allow %d/udp\n", client.P2PPort)
+    }
+    fmt.Println("- If the ports are already in use, set different ports with the chains.eth1.p2pPort and chains.eth2.p2pPort settings in 'rocketpool service config'.")
+    fmt.Println("Note that some routers do not support connections to their own public address, so a port may be reported as closed when it is reachable from other networks.")
+    return nil
+
+}
+
+
+// Print a client's p2p networking diagnostics; returns false if the client's port was found to be closed
+func printClientNetDiag(clientName string, diag api.ClientNetDiag, portMappingEnabled bool) bool {
+
+    // External clients are not managed by the smartnode
+    if diag.External {
+        fmt.Printf("%s: using an external client; check its p2p networking separately.\n\n", clientName)
+        return true
+    }
+
+    // Peers
+    fmt.Printf("%s (p2p port %d):\n", clientName, diag.P2PPort)
+    if diag.Error != "" {
+        fmt.Printf("- Peers: unavailable (%s)\n", diag.Error)
+    } else if diag.DirectionsKnown {
+        fmt.Printf("- Peers: %d (%d inbound)\n", diag.PeerCount, diag.InboundPeers)
+    } else {
+        fmt.Printf("- Peers: %d\n", diag.PeerCount)
+    }
+
+    // Port mapping
+    if portMappingEnabled {
+        if diag.PortMapped {
+            fmt.Println("- Port mapping: active")
+        } else {
+            fmt.Printf("- Port mapping: failed (%s)\n", diag.PortMappingError)
+        }
+    }
+
+    // Port
+    open := true
+    if !diag.PortChecked {
+        fmt.Println("- Port: not checked")
+    } else if diag.PortOpen {
+        fmt.Println("- Port: open")
+    } else if diag.DirectionsKnown && diag.InboundPeers > 0 {
+        fmt.Println("- Port: not reachable at the public address, but the client has inbound peers")
+    } else {
+        fmt.Println("- Port: closed")
+        open = false
+    }
+    fmt.Println("")
+    return open
+
+}
+
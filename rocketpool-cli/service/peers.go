@@ -0,0 +1,82 @@
+package service
+
+import (
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Settings
+const (
+    MinHealthyPeerCount = 10
+)
+
+
+// View the Rocket Pool service client peers
+func servicePeers(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get client peers
+    peers, err := rp.ServicePeers(c.Bool("locations"))
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    printClientPeers("Eth 1.0", peers.Eth1, peers.SnapshotTime)
+    fmt.Println("")
+    printClientPeers("Eth 2.0", peers.Eth2, peers.SnapshotTime)
+    if !c.Bool("locations") && (peers.Eth1.AdminAPIAvailable || peers.Eth2.AdminAPIAvailable) {
+        fmt.Println("")
+        fmt.Println("Run 'rocketpool service peers --locations' to look up peer locations; peer IP addresses will be sent to freegeoip.app.")
+    }
+    return nil
+
+}
+
+
+// Print a client's peer summary
+func printClientPeers(clientName string, peers api.ClientPeers, snapshotTime int64) {
+
+    // Peer count
+    fmt.Printf("The %s client has %d peer(s).\n", clientName, peers.PeerCount)
+    if peers.PeerCount < MinHealthyPeerCount {
+        cliutils.PrintStatus(cliutils.StatusWarn, fmt.Sprintf("The %s client has a low peer count - check your firewall and port forwarding settings, or configure static peers with 'rocketpool service config'.", clientName))
+    }
+    if !peers.AdminAPIAvailable {
+        fmt.Printf("The %s client admin API is not available, so peer details cannot be shown.\n", clientName)
+        return
+    }
+
+    // Peer locations
+    if len(peers.Countries) > 0 {
+        countries := make([]string, 0, len(peers.Countries))
+        for country := range peers.Countries {
+            countries = append(countries, country)
+        }
+        sort.Slice(countries, func(i, j int) bool {
+            return peers.Countries[countries[i]] > peers.Countries[countries[j]]
+        })
+        fmt.Println("Peer locations:")
+        for _, country := range countries {
+            fmt.Printf("- %s: %d\n", country, peers.Countries[country])
+        }
+    }
+
+    // Peer churn
+    if snapshotTime > 0 {
+        fmt.Printf("Since %s: %d new peer(s), %d disconnected peer(s).\n", time.Unix(snapshotTime, 0).Format("2006-01-02 15:04:05"), peers.NewPeers, peers.LostPeers)
+    }
+
+}
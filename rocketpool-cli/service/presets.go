@@ -0,0 +1,213 @@
+package service
+
+import (
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Config
+const (
+    PresetsFolder = ".rocketpool/presets"
+    PresetFileExtension = ".yml"
+    PresetDirMode = 0700
+    PresetFileMode = 0600
+)
+
+
+// Get the folder user presets are saved to
+func getPresetsPath() (string, error) {
+    homeDir, err := os.UserHomeDir()
+    if err != nil {
+        return "", fmt.Errorf("Could not get home folder: %w", err)
+    }
+    return filepath.Join(homeDir, PresetsFolder), nil
+}
+
+
+// Load the presets saved by the user
+func loadUserPresets() ([]config.Preset, error) {
+
+    // Get preset files
+    presetsPath, err := getPresetsPath()
+    if err != nil {
+        return []config.Preset{}, err
+    }
+    files, err := ioutil.ReadDir(presetsPath)
+    if os.IsNotExist(err) {
+        return []config.Preset{}, nil
+    }
+    if err != nil {
+        return []config.Preset{}, fmt.Errorf("Could not read presets folder: %w", err)
+    }
+
+    // Load presets
+    presets := []config.Preset{}
+    for _, file := range files {
+        if file.IsDir() || filepath.Ext(file.Name()) != PresetFileExtension {
+            continue
+        }
+        preset, err := loadPresetFile(filepath.Join(presetsPath, file.Name()))
+        if err != nil {
+            return []config.Preset{}, err
+        }
+        presets = append(presets, preset)
+    }
+    return presets, nil
+
+}
+
+
+// Load a preset from a file
+func loadPresetFile(path string) (config.Preset, error) {
+    presetBytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return config.Preset{}, fmt.Errorf("Could not read preset file %s: %w", path, err)
+    }
+    preset, err := config.ParsePreset(presetBytes)
+    if err != nil {
+        return config.Preset{}, fmt.Errorf("%s: %w", path, err)
+    }
+    return preset, nil
+}
+
+
+// Get a preset by name or file path; saved user presets take precedence over built-in presets
+func getPreset(nameOrPath string) (config.Preset, error) {
+
+    // Load from file
+    if strings.Contains(nameOrPath, string(filepath.Separator)) || filepath.Ext(nameOrPath) == PresetFileExtension {
+        return loadPresetFile(nameOrPath)
+    }
+
+    // Get by name
+    userPresets, err := loadUserPresets()
+    if err != nil {
+        return config.Preset{}, err
+    }
+    for _, preset := range userPresets {
+        if preset.Name == nameOrPath {
+            return preset, nil
+        }
+    }
+    if preset, ok := config.GetPreset(nameOrPath); ok {
+        return preset, nil
+    }
+    return config.Preset{}, fmt.Errorf("Preset '%s' was not found; run 'rocketpool service config presets' to list the available presets", nameOrPath)
+
+}
+
+
+// Get all available presets, built-in first
+func getAllPresets() ([]config.Preset, error) {
+    userPresets, err := loadUserPresets()
+    if err != nil {
+        return []config.Preset{}, err
+    }
+    return append(append([]config.Preset{}, config.Presets...), userPresets...), nil
+}
+
+
+// Prompt for a preset to start the config wizard from; returns nil if none is selected
+func selectPreset() (*config.Preset, error) {
+
+    // Get presets
+    presets, err := getAllPresets()
+    if err != nil {
+        return nil, err
+    }
+
+    // Select preset
+    options := []string{"None - choose every setting yourself"}
+    for _, preset := range presets {
+        options = append(options, fmt.Sprintf("%s - %s", preset.Name, preset.Description))
+    }
+    selected, _ := cliutils.Select("Which preset would you like to start from? Presets choose clients and set cache sizes and peer counts for common setups.", options)
+    if selected == 0 {
+        return nil, nil
+    }
+    preset := presets[selected - 1]
+    fmt.Printf("Preset %s selected.\n", preset.Name)
+    fmt.Println("")
+    return &preset, nil
+
+}
+
+
+// List the available presets
+func listPresets(c *cli.Context) error {
+
+    // Get presets
+    presets, err := getAllPresets()
+    if err != nil {
+        return err
+    }
+
+    // Print presets
+    for pi, preset := range presets {
+        if pi == len(config.Presets) {
+            fmt.Println("")
+            fmt.Println("Saved presets:")
+        }
+        fmt.Printf("- %s: %s\n", preset.Name, preset.Description)
+    }
+    return nil
+
+}
+
+
+// Save the current client choices & settings as a preset
+func savePreset(c *cli.Context, name string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Load user config
+    userConfig, err := rp.LoadUserConfig()
+    if err != nil {
+        return err
+    }
+    if userConfig.Chains.Eth1.Client.Selected == "" && userConfig.Chains.Eth2.Client.Selected == "" {
+        return fmt.Errorf("The Rocket Pool service has not been configured yet; run 'rocketpool service config' first")
+    }
+
+    // Get output path
+    path := c.String("output")
+    if path == "" {
+        presetsPath, err := getPresetsPath()
+        if err != nil {
+            return err
+        }
+        if err := os.MkdirAll(presetsPath, PresetDirMode); err != nil {
+            return fmt.Errorf("Could not create presets folder: %w", err)
+        }
+        path = filepath.Join(presetsPath, name + PresetFileExtension)
+    }
+
+    // Write preset
+    preset := config.NewPreset(name, c.String("description"), userConfig)
+    presetBytes, err := preset.Serialize()
+    if err != nil {
+        return err
+    }
+    if err := ioutil.WriteFile(path, presetBytes, PresetFileMode); err != nil {
+        return fmt.Errorf("Could not write preset file: %w", err)
+    }
+
+    // Log & return
+    fmt.Printf("Preset %s was saved to %s.\n", name, path)
+    fmt.Println("Share the file with other operators, who can use it with 'rocketpool service config --preset <file>'.")
+    return nil
+
+}
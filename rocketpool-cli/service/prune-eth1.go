@@ -0,0 +1,40 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Prune the Eth 1.0 client's chain data offline
+func pruneEth1(c *cli.Context) error {
+
+    // Print warnings & prompt for confirmation
+    fmt.Println("Pruning removes old state from the Eth 1.0 chain data to free up disk space. It can take several hours, during which the Rocket Pool service is stopped and your validators will miss attestations.")
+    fmt.Println("Do not interrupt pruning once it has started; if it is interrupted, run this command again to finish it.")
+    if !cliutils.Confirm("Are you sure you want to stop the Rocket Pool service and prune the Eth 1.0 chain data?") {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Prune chain data
+    if err := rp.PruneEth1(); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Println("")
+    fmt.Println("The Eth 1.0 chain data was successfully pruned and the Rocket Pool service restarted.")
+    return nil
+
+}
+
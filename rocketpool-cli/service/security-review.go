@@ -0,0 +1,160 @@
+package service
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/services/security"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Run the interactive security review of the node host, and record its completion
+func securityReview(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Check for a completed review
+    review, err := rp.LoadSecurityReview()
+    if err != nil { return err }
+    if review != nil && !cliutils.Confirm(fmt.Sprintf("The security review was completed on %s with %d warning(s). Would you like to run it again?", review.Completed.Format(time.RFC1123), review.Warnings())) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Run review
+    return runSecurityReview(rp)
+
+}
+
+
+// Walk through the security review checks, and record the results
+func runSecurityReview(rp *rocketpool.Client) error {
+
+    // Run the host checks, checking again after fixes until all pass or the operator continues
+    fmt.Println("This review checks the node host's security settings; it does not change them.")
+    fmt.Println("")
+    var checks []security.Check
+    for {
+        host, err := rp.GetHostSecurity()
+        if err != nil { return err }
+        checks = getHostSecurityChecks(host)
+        warnings := 0
+        for _, check := range checks {
+            printSecurityCheck(check)
+            if check.Status == security.CheckWarning {
+                warnings++
+            }
+        }
+        fmt.Println("")
+        if warnings == 0 || !cliutils.Confirm("Would you like to fix the warnings above in another terminal and check again?") {
+            break
+        }
+        fmt.Println("")
+    }
+
+    // Confirm wallet backup
+    fmt.Println("Your node wallet can only be recovered from its mnemonic; anyone with the mnemonic controls the node's funds.")
+    walletCheck := security.Check{Name: "Wallet backup"}
+    switch option, _ := cliutils.Select("Have you recorded your wallet mnemonic offline, somewhere safe?", []string{
+        "Yes, the mnemonic is recorded offline",
+        "No",
+        "The node wallet has not been created yet",
+    }); option {
+        case 0:
+            walletCheck.Status = security.CheckPassed
+        case 1:
+            walletCheck.Status = security.CheckWarning
+            walletCheck.Detail = "The mnemonic is only shown when the wallet is created; keep an encrypted backup of the node's wallet & keys with 'rocketpool service backup' instead."
+        default:
+            walletCheck.Status = security.CheckSkipped
+            walletCheck.Detail = "Record the mnemonic when the wallet is created with 'rocketpool wallet init', then run this review again."
+    }
+    printSecurityCheck(walletCheck)
+    checks = append(checks, walletCheck)
+
+    // Record review
+    review := &security.Review{
+        Completed: time.Now(),
+        Checks: checks,
+    }
+    if err := rp.SaveSecurityReview(*review); err != nil { return err }
+
+    // Log & return
+    fmt.Println("")
+    if review.Warnings() > 0 {
+        fmt.Printf("The security review was completed with %d warning(s). Run 'rocketpool service security-review' again after fixing them.\n", review.Warnings())
+    } else {
+        fmt.Println("The security review was completed with no warnings.")
+    }
+    return nil
+
+}
+
+
+// Get the security review checks for the host settings
+func getHostSecurityChecks(host rocketpool.HostSecurity) []security.Check {
+
+    // SSH checks
+    var checks []security.Check
+    if !host.SSHConfigFound {
+        checks = append(checks, security.Check{
+            Name: "SSH server",
+            Status: security.CheckSkipped,
+            Detail: "No SSH server config was found.",
+        })
+    } else {
+        passwordCheck := security.Check{Name: "SSH password login", Status: security.CheckPassed}
+        if host.SSHPasswordAuthentication != "no" {
+            passwordCheck.Status = security.CheckWarning
+            passwordCheck.Detail = "Password logins are enabled. Log in with an SSH key, then set 'PasswordAuthentication no' in /etc/ssh/sshd_config and restart sshd."
+        }
+        rootCheck := security.Check{Name: "SSH root login", Status: security.CheckPassed}
+        switch host.SSHPermitRootLogin {
+            case "", "no", "prohibit-password", "without-password", "forced-commands-only":
+            default:
+                rootCheck.Status = security.CheckWarning
+                rootCheck.Detail = "Root can log in with a password. Set 'PermitRootLogin no' in /etc/ssh/sshd_config and restart sshd."
+        }
+        checks = append(checks, passwordCheck, rootCheck)
+    }
+
+    // Firewall check
+    firewallCheck := security.Check{Name: "Firewall", Status: security.CheckPassed, Detail: host.Firewall}
+    if host.Firewall == "" {
+        firewallCheck.Status = security.CheckWarning
+        firewallCheck.Detail = "No firewall is enabled. Enable ufw or firewalld, allowing only SSH and the Eth 1.0 & Eth 2.0 client P2P ports."
+    }
+
+    // Automatic update check
+    updatesCheck := security.Check{Name: "Automatic OS updates", Status: security.CheckPassed, Detail: host.AutoUpdates}
+    if host.AutoUpdates == "" {
+        updatesCheck.Status = security.CheckWarning
+        updatesCheck.Detail = "Automatic security updates are not enabled. Install and enable unattended-upgrades (Debian & Ubuntu) or dnf-automatic (Fedora & RHEL)."
+    }
+
+    // Return
+    return append(checks, firewallCheck, updatesCheck)
+
+}
+
+
+// Print a security review check result
+func printSecurityCheck(check security.Check) {
+    message := check.Name
+    if check.Detail != "" {
+        message = fmt.Sprintf("%s: %s", check.Name, check.Detail)
+    }
+    switch check.Status {
+        case security.CheckPassed:  cliutils.PrintStatus(cliutils.StatusOK, message)
+        case security.CheckSkipped: cliutils.PrintStatus("SKIP", message)
+        default:                    cliutils.PrintStatus(cliutils.StatusWarn, message)
+    }
+}
+
@@ -2,16 +2,19 @@ package service
 
 import (
     "fmt"
+    "regexp"
+    "time"
 
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
 
 // Install the Rocket Pool service
-func installService(c *cli.Context) error {
+func installService(c *cli.Context, backend, architecture string) error {
 
     // Get install location
     var location string
@@ -23,8 +26,8 @@ func installService(c *cli.Context) error {
 
     // Prompt for confirmation
     if !cliutils.Confirm(fmt.Sprintf(
-        "The Rocket Pool service will be installed %s --\nNetwork: %s\nVersion: %s\n\nAny existing configuration will be overwritten.\nAre you sure you want to continue?",
-        location, c.String("network"), c.String("version"),
+        "The Rocket Pool service will be installed %s --\nNetwork: %s\nVersion: %s\nBackend: %s\nArchitecture: %s\n\nAny existing configuration will be overwritten.\nAre you sure you want to continue?",
+        location, c.String("network"), c.String("version"), backend, getArchitectureLabel(architecture),
     )) {
         fmt.Println("Cancelled.")
         return nil
@@ -36,9 +39,20 @@ func installService(c *cli.Context) error {
     defer rp.Close()
 
     // Install service
-    err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"))
+    err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), backend, architecture)
     if err != nil { return err }
 
+    // Select the systemd service backend & architecture override
+    if backend == rocketpool.BackendSystemd || architecture != "" {
+        cfg, err := rp.LoadUserConfig()
+        if err != nil { return err }
+        if backend == rocketpool.BackendSystemd {
+            cfg.Smartnode.ServiceBackend = backend
+        }
+        cfg.Smartnode.Architecture = architecture
+        if err := rp.SaveUserConfig(cfg, fmt.Sprintf("Install with the %s service backend for %s hosts", backend, getArchitectureLabel(architecture))); err != nil { return err }
+    }
+
     // Print success message & return
     fmt.Println("")
     fmt.Printf("The Rocket Pool service was successfully installed %s!\n", location)
@@ -46,11 +60,32 @@ func installService(c *cli.Context) error {
         fmt.Println("Please restart your shell session to apply updated user permissions.")
     }
     fmt.Println("Run 'rocketpool service config' to configure the service before starting it.")
+
+    // Offer the security review if not completed
+    review, err := rp.LoadSecurityReview()
+    if err != nil { return err }
+    if review == nil {
+        fmt.Println("")
+        if cliutils.Confirm("Would you like to run the one-time security review of the node host now?") {
+            fmt.Println("")
+            return runSecurityReview(rp)
+        }
+        fmt.Println("You can run it later with 'rocketpool service security-review'.")
+    }
     return nil
 
 }
 
 
+// Get a description of an install architecture
+func getArchitectureLabel(architecture string) string {
+    if architecture == "" {
+        return "detected from the host"
+    }
+    return architecture
+}
+
+
 // View the Rocket Pool service status
 func serviceStatus(c *cli.Context) error {
 
@@ -60,11 +95,48 @@ func serviceStatus(c *cli.Context) error {
     defer rp.Close()
 
     // Print service status
-    return rp.PrintServiceStatus()
+    if err := rp.PrintServiceStatus(); err != nil {
+        return err
+    }
+
+    // Check external clients
+    cfg, err := rp.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+    if !cfg.Chains.Eth1.External && !cfg.Chains.Eth2.External {
+        return nil
+    }
+    clientStatus, err := rp.ServiceClientStatus()
+    if err != nil {
+        return err
+    }
+
+    // Print external client status & return
+    fmt.Println("")
+    if clientStatus.Eth1.External {
+        printExternalClientStatus("Eth 1.0", clientStatus.Eth1)
+    }
+    if clientStatus.Eth2.External {
+        printExternalClientStatus("Eth 2.0", clientStatus.Eth2)
+    }
+    return nil
 
 }
 
 
+// Print the status of an externally managed client
+func printExternalClientStatus(clientName string, status api.ClientStatus) {
+    if !status.Reachable {
+        cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("The external %s client at %s is not reachable: %s", clientName, status.Provider, status.Error))
+    } else if !status.Synced {
+        cliutils.PrintStatus(cliutils.StatusWarn, fmt.Sprintf("The external %s client at %s is reachable but still syncing.", clientName, status.Provider))
+    } else {
+        cliutils.PrintStatus(cliutils.StatusOK, fmt.Sprintf("The external %s client at %s is reachable and synced.", clientName, status.Provider))
+    }
+}
+
+
 // Start the Rocket Pool service
 func startService(c *cli.Context) error {
 
@@ -120,16 +192,57 @@ func stopService(c *cli.Context) error {
 
 
 // View the Rocket Pool service logs
+// Logs are followed unless an end time is set; all lines since the start time are shown unless a tail is set
 func serviceLogs(c *cli.Context, serviceNames ...string) error {
 
+    // Get log options
+    options := rocketpool.LogOptions{
+        Tail: c.String("tail"),
+        Follow: !c.Bool("no-follow") && c.String("until") == "",
+    }
+    if c.String("since") != "" {
+        since, err := parseLogTime(c.String("since"))
+        if err != nil { return err }
+        options.Since = since
+        if !c.IsSet("tail") {
+            options.Tail = "all"
+        }
+    }
+    if c.String("until") != "" {
+        until, err := parseLogTime(c.String("until"))
+        if err != nil { return err }
+        options.Until = until
+    }
+    if c.String("grep") != "" {
+        pattern, err := regexp.Compile(c.String("grep"))
+        if err != nil {
+            return fmt.Errorf("Invalid grep pattern '%s': %w", c.String("grep"), err)
+        }
+        options.Grep = pattern
+    }
+
     // Get RP client
     rp, err := rocketpool.NewClientFromCtx(c)
     if err != nil { return err }
     defer rp.Close()
 
     // Print service logs
-    return rp.PrintServiceLogs(c.String("tail"), serviceNames...)
+    return rp.PrintServiceLogs(options, serviceNames...)
+
+}
+
 
+// Parse a log time, either relative to now (e.g. 30m) or absolute in local time (e.g. "2020-11-01 12:00")
+func parseLogTime(value string) (time.Time, error) {
+    if duration, err := time.ParseDuration(value); err == nil {
+        return time.Now().Add(-duration), nil
+    }
+    for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+        if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+            return t, nil
+        }
+    }
+    return time.Time{}, fmt.Errorf("Invalid log time '%s'; use a duration ago (e.g. 30m) or a local time (e.g. \"2020-11-01 12:00\")", value)
 }
 
 
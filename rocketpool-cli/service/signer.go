@@ -0,0 +1,78 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Print the remote signer status
+func signerStatus(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get remote signer status
+    status, err := rp.SignerStatus()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if !status.Configured {
+        fmt.Println("No remote signer is configured; validator keys are stored on the node.")
+        return nil
+    }
+    if !status.Reachable {
+        cliutils.PrintStatus(cliutils.StatusFail, status.SignerError)
+        return nil
+    }
+    cliutils.PrintStatus(cliutils.StatusOK, fmt.Sprintf("The remote signer at %s is up and holds %d validator key(s).", status.URL, status.SignerKeyCount))
+    if status.SignerError != "" {
+        cliutils.PrintStatus(cliutils.StatusWarn, status.SignerError)
+        return nil
+    }
+    if len(status.MissingKeys) > 0 {
+        cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("The remote signer is missing %d of the node's validator keys:", len(status.MissingKeys)))
+        for _, pubkey := range status.MissingKeys {
+            fmt.Printf("    %s\n", pubkey.Hex())
+        }
+        fmt.Println("Run 'rocketpool service sync-signer' to import them.")
+    }
+    return nil
+
+}
+
+
+// Import the validator keys missing from the remote signer
+func syncSigner(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Import missing keys
+    response, err := rp.SyncSigner()
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    if len(response.ImportedKeys) == 0 {
+        fmt.Println("The remote signer already holds all of the node's validator keys.")
+        return nil
+    }
+    for _, pubkey := range response.ImportedKeys {
+        fmt.Printf("Imported validator key %s.\n", pubkey.Hex())
+    }
+    fmt.Printf("%d validator key(s) were imported into the remote signer.\n", len(response.ImportedKeys))
+    return nil
+
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Settings
+var DefaultSyncInterval, _ = time.ParseDuration("15s")
+
+
+// View the Rocket Pool service client sync progress, optionally polling until both clients are synced
+func serviceSync(c *cli.Context, follow bool, interval time.Duration) error {
+
+    // Check polling interval
+    if interval <= 0 {
+        return errors.New("The polling interval must be greater than zero.")
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Print sync progress
+    for {
+
+        // Get sync progress
+        sync, err := rp.ServiceSync()
+        if err != nil {
+            return err
+        }
+
+        // Print
+        fmt.Printf("Sync progress at %s:\n", time.Now().Format("2006-01-02 15:04:05"))
+        printClientSync("Eth 1.0", "block", sync.Eth1)
+        printClientSync("Eth 2.0", "slot", sync.Eth2)
+
+        // Check whether to keep polling
+        if !follow {
+            if sync.SnapshotTime == 0 && !(sync.Eth1.Synced && sync.Eth2.Synced) {
+                fmt.Println("Run the command again, or use --follow, to estimate the time remaining.")
+            }
+            return nil
+        }
+        if sync.Eth1.Synced && sync.Eth2.Synced {
+            fmt.Println("Both clients are synced.")
+            return nil
+        }
+        fmt.Println("")
+        time.Sleep(interval)
+
+    }
+
+}
+
+
+// Print a client's sync progress
+func printClientSync(clientName, heightName string, sync api.ClientSync) {
+
+    // Client status
+    if !sync.Reachable {
+        fmt.Printf("- %s: unreachable (%s)\n", clientName, sync.Error)
+        return
+    }
+    if sync.Error != "" {
+        fmt.Printf("- %s: %d peer(s), sync progress unavailable (%s)\n", clientName, sync.PeerCount, sync.Error)
+        return
+    }
+    if sync.Synced {
+        fmt.Printf("- %s: synced at %s %d, %d peer(s)\n", clientName, heightName, sync.CurrentHeight, sync.PeerCount)
+        return
+    }
+
+    // Sync progress
+    progress := 0.0
+    var behind uint64
+    if sync.NetworkHeight > sync.CurrentHeight {
+        progress = float64(sync.CurrentHeight) / float64(sync.NetworkHeight) * 100
+        behind = sync.NetworkHeight - sync.CurrentHeight
+    }
+    fmt.Printf("- %s: syncing, %s %d of %d (%.2f%%, %d behind), %d peer(s)\n", clientName, heightName, sync.CurrentHeight, sync.NetworkHeight, progress, behind, sync.PeerCount)
+
+    // Estimated time remaining
+    if sync.HeightsPerSecond > 0 || sync.SecondsRemaining >= 0 {
+        remaining := "unknown, the client is not catching up"
+        if sync.SecondsRemaining >= 0 {
+            remaining = "about " + (time.Duration(sync.SecondsRemaining) * time.Second).String()
+        }
+        fmt.Printf("  %.1f %ss per second, time remaining %s\n", sync.HeightsPerSecond, heightName, remaining)
+    }
+
+}
+
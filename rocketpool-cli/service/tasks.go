@@ -0,0 +1,74 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// List the daemon tasks and their status
+func listTasks(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get tasks
+    response, err := rp.ServiceTasks()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(response.Tasks) == 0 {
+        fmt.Println("No daemon tasks have been registered yet; please check that the Rocket Pool service is running.")
+        return nil
+    }
+    for _, task := range response.Tasks {
+        status := "enabled"
+        if !task.Enabled {
+            status = "disabled"
+        }
+        if task.Rehearsal {
+            status += " (rehearsal)"
+        }
+        lastRun := "never"
+        if !task.LastRun.IsZero() {
+            lastRun = task.LastRun.Format("2006-01-02 15:04:05 MST")
+        }
+        interval := fmt.Sprintf("every %s", task.Interval)
+        if task.ActiveInterval != task.IdleInterval {
+            interval += fmt.Sprintf(" (adaptive, %s - %s)", task.ActiveInterval, task.IdleInterval)
+        }
+        fmt.Printf("%s/%s: %s, %s, last run %s\n", task.Daemon, task.Name, status, interval, lastRun)
+        if task.LastError != "" {
+            fmt.Printf("    Last error: %s\n", task.LastError)
+        }
+    }
+    return nil
+
+}
+
+
+// Trigger a daemon task to run immediately
+func triggerTask(c *cli.Context, name string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Trigger task
+    if _, err := rp.TriggerTask(name); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Task %s will run within a few seconds. Its output can be viewed with 'rocketpool service logs'.\n", name)
+    return nil
+
+}
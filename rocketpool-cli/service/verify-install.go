@@ -0,0 +1,78 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Compare the installed Rocket Pool files against the release manifest, and offer to restore changed files
+func verifyInstall(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Verify installed files
+    network := c.String("network")
+    version := c.String("version")
+    files, err := rp.VerifyInstall(network, version)
+    if err != nil { return err }
+
+    // Print results
+    changed := printInstallFiles(files)
+    fmt.Println("")
+    if len(changed) == 0 {
+        fmt.Printf("All %d installed files match the %s release for the %s network.\n", len(files), version, network)
+        return nil
+    }
+    fmt.Printf("%d of %d installed files do not match the %s release for the %s network.\n", len(changed), len(files), version, network)
+    fmt.Println("Changes may be intentional manual edits, a different installed version, or tampering - check any you did not make.")
+
+    // Prompt for restoration
+    if !cliutils.Confirm("Would you like to restore the changed files from the release? Modified files will be kept with a .bak suffix.") {
+        return nil
+    }
+
+    // Restore files
+    if err := rp.RestoreInstallFiles(network, version, changed); err != nil { return err }
+
+    // Verify restored files
+    files, err = rp.VerifyInstall(network, version)
+    if err != nil { return err }
+    fmt.Println("")
+    if changed = printInstallFiles(files); len(changed) > 0 {
+        return fmt.Errorf("%d installed files still do not match the release after restoring them.", len(changed))
+    }
+
+    // Log & return
+    fmt.Println("")
+    fmt.Println("The changed files were successfully restored. Run 'rocketpool service start' to apply them.")
+    return nil
+
+}
+
+
+// Print installed file results, and return the files which do not match the release
+func printInstallFiles(files []rocketpool.InstallFile) []rocketpool.InstallFile {
+    changed := []rocketpool.InstallFile{}
+    for _, file := range files {
+        switch file.Status {
+            case rocketpool.InstallFileOK:
+                cliutils.PrintStatus(cliutils.StatusOK, file.Path)
+            case rocketpool.InstallFileModified:
+                cliutils.PrintStatus(cliutils.StatusWarn, fmt.Sprintf("%s has been modified", file.Path))
+                changed = append(changed, file)
+            default:
+                cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("%s is missing", file.Path))
+                changed = append(changed, file)
+        }
+    }
+    return changed
+}
+
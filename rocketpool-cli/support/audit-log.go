@@ -0,0 +1,43 @@
+package support
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+func printAuditLog(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get audit log
+    response, err := rp.SupportAuditLog()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(response.Entries) == 0 {
+        fmt.Println("The audit log is empty.")
+        return nil
+    }
+    for _, entry := range response.Entries {
+        result := "allowed"
+        if !entry.Allowed {
+            result = "denied"
+        }
+        line := fmt.Sprintf("%s  %-16s %s (%s)", entry.Time.Format("2006-01-02 15:04:05 MST"), entry.Actor, entry.Action, result)
+        if entry.Detail != "" {
+            line += fmt.Sprintf(" - %s", entry.Detail)
+        }
+        fmt.Println(line)
+    }
+    return nil
+
+}
@@ -0,0 +1,86 @@
+package support
+
+import (
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Grant a trusted helper temporary, read-only access to the smart node",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "grant",
+                Aliases:   []string{"g"},
+                Usage:     "Create a time-limited, read-only support access token, optionally opening a reverse SSH tunnel to the helper",
+                UsageText: "rocketpool support grant [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "duration, d",
+                        Usage: "How long support access lasts (e.g. 2h)",
+                        Value: "2h",
+                    },
+                    cli.StringFlag{
+                        Name:  "tunnel, t",
+                        Usage: "Open a reverse SSH tunnel to the helper's `user@host`",
+                    },
+                    cli.UintFlag{
+                        Name:  "tunnel-port, p",
+                        Usage: "The `port` on the helper's host to forward to the support socket",
+                        Value: DefaultTunnelPort,
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    duration, err := cliutils.ValidateDuration("duration", c.String("duration"))
+                    if err != nil { return err }
+
+                    // Run
+                    return grantSupport(c, duration)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "revoke",
+                Aliases:   []string{"r"},
+                Usage:     "Revoke all support access tokens immediately",
+                UsageText: "rocketpool support revoke",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return revokeSupport(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "audit-log",
+                Aliases:   []string{"a"},
+                Usage:     "Show support access grants and every action taken with them",
+                UsageText: "rocketpool support audit-log",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return printAuditLog(c)
+
+                },
+            },
+
+        },
+    })
+}
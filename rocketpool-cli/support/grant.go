@@ -0,0 +1,60 @@
+package support
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Config
+const DefaultTunnelPort = 8484
+
+
+func grantSupport(c *cli.Context, duration time.Duration) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Prompt for confirmation
+    if !cliutils.Confirm(fmt.Sprintf("This will allow anyone with the support token to view the node's status and service logs for %s. Only share it with someone you trust. Are you sure you want to continue?", duration)) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Grant support access
+    response, err := rp.SupportGrant(duration)
+    if err != nil {
+        return err
+    }
+
+    // Print token & usage
+    port := c.Uint("tunnel-port")
+    fmt.Printf("Support access %s granted until %s.\n", response.ID, response.Expires.Format("2006-01-02 15:04:05 MST"))
+    fmt.Println("")
+    fmt.Printf("Support token: %s\n", response.Token)
+    fmt.Println("")
+    fmt.Println("The helper can inspect the node through the tunnel with requests such as:")
+    fmt.Printf("    curl -H 'Authorization: Bearer <token>' -d '{\"args\":[\"node\",\"status\"]}' http://localhost:%d/api\n", port)
+    fmt.Printf("    curl -H 'Authorization: Bearer <token>' 'http://localhost:%d/logs?service=node&tail=200'\n", port)
+    fmt.Println("Every request is recorded in the audit log; run 'rocketpool support revoke' to end access early.")
+    fmt.Println("")
+
+    // Open tunnel
+    if helper := c.String("tunnel"); helper != "" {
+        fmt.Printf("Opening a reverse SSH tunnel to port %d on %s; press Ctrl+C to close it...\n", port, helper)
+        if err := rp.OpenSupportTunnel(helper, port, time.Until(response.Expires)); err != nil {
+            return fmt.Errorf("The support tunnel closed: %w", err)
+        }
+    }
+
+    // Return
+    return nil
+
+}
@@ -0,0 +1,29 @@
+package support
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+func revokeSupport(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Revoke support access
+    response, err := rp.SupportRevoke()
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("Revoked %d active support token(s).\n", response.RevokedCount)
+    return nil
+
+}
@@ -0,0 +1,56 @@
+package tx
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+func broadcastTransaction(c *cli.Context, path string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Read signed transaction
+    transactionBytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return fmt.Errorf("Could not read signed transaction file %s: %w", path, err)
+    }
+    var signedTx api.SignedTransaction
+    if err := json.Unmarshal(transactionBytes, &signedTx); err != nil {
+        return fmt.Errorf("Could not decode signed transaction file %s: %w", path, err)
+    }
+
+    // Prompt for confirmation
+    fmt.Printf("Transaction:  %s\n", signedTx.Description)
+    fmt.Printf("From:         %s\n", signedTx.From.Hex())
+    fmt.Printf("Nonce:        %d\n", signedTx.Nonce)
+    fmt.Printf("Hash:         %s\n", signedTx.Hash.Hex())
+    fmt.Println("")
+    if !cliutils.Confirm("Are you sure you want to broadcast this transaction?") {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Broadcast transaction
+    fmt.Println("Broadcasting transaction and waiting for it to be mined...")
+    response, err := rp.BroadcastTransaction(signedTx)
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("The transaction '%s' was successfully mined with hash %s.\n", signedTx.Description, response.TxHash.Hex())
+    return nil
+
+}
+
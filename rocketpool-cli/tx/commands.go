@@ -0,0 +1,37 @@
+package tx
+
+import (
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Manage transactions signed offline",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "broadcast",
+                Aliases:   []string{"b"},
+                Usage:     "Broadcast a transaction signed with 'rocketpool wallet sign-tx' and wait for it to be mined",
+                UsageText: "rocketpool tx broadcast signed-tx-file",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Run
+                    return broadcastTransaction(c, c.Args().Get(0))
+
+                },
+            },
+
+        },
+    })
+}
+
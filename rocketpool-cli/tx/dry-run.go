@@ -0,0 +1,109 @@
+package tx
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Print the simulation from a command run with --dry-run
+// Returns false if the error is not an *api.DryRunError
+func HandleDryRun(err error) bool {
+
+    // Get simulation
+    var dryRunErr *api.DryRunError
+    if !errors.As(err, &dryRunErr) {
+        return false
+    }
+    simulation := dryRunErr.Simulation
+
+    // Print transaction
+    to := "contract creation"
+    if simulation.To != nil {
+        to = simulation.To.Hex()
+    }
+    fmt.Printf("Dry run of '%s' against the latest block; the transaction has not been sent.\n", simulation.Description)
+    fmt.Println("")
+    fmt.Printf("From:         %s\n", simulation.From.Hex())
+    fmt.Printf("To:           %s\n", to)
+    if simulation.Value != nil {
+        fmt.Printf("Value:        %s\n", units.FormatEth(simulation.Value))
+    }
+    fmt.Printf("Nonce:        %d\n", simulation.Nonce)
+    cliutils.PrintGasInfo(api.GasInfo{
+        EstGasLimit: simulation.GasLimit,
+        GasPrice: simulation.GasPrice,
+        GasToken: simulation.GasToken,
+    })
+    fmt.Println("")
+
+    // Print result
+    if simulation.Reverted {
+        cliutils.PrintStatus(cliutils.StatusFail, fmt.Sprintf("The transaction would revert: %s", simulation.RevertReason))
+        return true
+    }
+    cliutils.PrintStatus(cliutils.StatusOK, "The transaction would succeed.")
+
+    // Print events
+    if simulation.EventsAvailable {
+        fmt.Println("")
+        fmt.Printf("Events (%d):\n", len(simulation.Events))
+        for _, event := range simulation.Events {
+            args := make([]string, len(event.Args))
+            for ai, arg := range event.Args {
+                args[ai] = fmt.Sprintf("%s=%s", arg.Name, arg.Value)
+            }
+            fmt.Printf("- %s %s(%s)\n", getAccountLabel(event.Address, event.Contract), event.Name, strings.Join(args, ", "))
+        }
+    }
+
+    // Print state changes
+    if simulation.StateChangesAvailable {
+        fmt.Println("")
+        fmt.Printf("State changes (%d accounts):\n", len(simulation.StateChanges))
+        for _, change := range simulation.StateChanges {
+            details := []string{}
+            if change.Created {
+                details = append(details, "created")
+            }
+            if change.BalanceAfter != nil {
+                balanceChange := new(big.Int).Sub(change.BalanceAfter, change.BalanceBefore)
+                sign := "+"
+                if balanceChange.Sign() < 0 {
+                    sign = "-"
+                }
+                details = append(details, fmt.Sprintf("balance %s -> %s (%s%s)", units.FormatEth(change.BalanceBefore), units.FormatEth(change.BalanceAfter), sign, units.FormatEth(new(big.Int).Abs(balanceChange))))
+            }
+            if change.StorageSlots > 0 {
+                details = append(details, fmt.Sprintf("%d storage slot(s)", change.StorageSlots))
+            }
+            fmt.Printf("- %s: %s\n", getAccountLabel(change.Address, change.Contract), strings.Join(details, ", "))
+        }
+    }
+
+    // Print trace error
+    if simulation.TraceError != "" {
+        fmt.Println("")
+        cliutils.PrintStatus(cliutils.StatusWarn, simulation.TraceError)
+    }
+    return true
+
+}
+
+
+// Get a display label for an account, with its contract name if known
+func getAccountLabel(address common.Address, contract string) string {
+    if contract == "" {
+        return address.Hex()
+    }
+    return fmt.Sprintf("%s (%s)", address.Hex(), contract)
+}
+
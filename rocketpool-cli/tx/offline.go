@@ -0,0 +1,63 @@
+package tx
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Config
+const UnsignedTransactionFileMode = 0644
+
+
+// Save the unsigned transaction from a command run with --offline, and print instructions for signing it
+// Returns false if the error is not an *api.OfflineTransactionError
+func HandleOfflineTransaction(err error) bool {
+
+    // Get unsigned transaction
+    var offlineErr *api.OfflineTransactionError
+    if !errors.As(err, &offlineErr) {
+        return false
+    }
+    unsignedTx := offlineErr.Transaction
+
+    // Write unsigned transaction
+    path := fmt.Sprintf("rocketpool-tx-%d.json", unsignedTx.Nonce)
+    transactionBytes, err := json.MarshalIndent(unsignedTx, "", "  ")
+    if err == nil {
+        err = ioutil.WriteFile(path, transactionBytes, UnsignedTransactionFileMode)
+    }
+    if err != nil {
+        fmt.Printf("Could not write unsigned transaction file %s: %s\n", path, err.Error())
+        return true
+    }
+
+    // Print instructions
+    cliutils.PrintUnsignedTransaction(unsignedTx)
+    fmt.Println("")
+    fmt.Printf("The unsigned transaction was written to %s and has not been sent.\n", path)
+    fmt.Printf("Copy it to the machine holding the node wallet and run 'rocketpool wallet sign-tx %s', then copy the signed file back and run 'rocketpool tx broadcast' on the node.\n", path)
+    fmt.Println("Sign and broadcast it before preparing another transaction, as later transactions depend on its nonce.")
+    return true
+
+}
+
+
+// Save the unsigned transaction from a command which sends a batch of transactions, as HandleOfflineTransaction
+// Only one transaction can be prepared at a time, so the command must stop after it; remaining is the number of
+// transactions in the batch which have not been prepared
+func HandleOfflineBatchTransaction(err error, remaining int) bool {
+    if !HandleOfflineTransaction(err) {
+        return false
+    }
+    if remaining > 0 {
+        fmt.Printf("%d remaining transaction(s) have not been prepared; run the command again once it has been broadcast to prepare the next one.\n", remaining)
+    }
+    return true
+}
+
@@ -79,6 +79,67 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "export-keys",
+                Aliases:   []string{"x"},
+                Usage:     "Export the node's validator keys as EIP-2335 keystores",
+                UsageText: "rocketpool wallet export-keys [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "output-dir, d",
+                        Usage: "The directory to write keystore files to",
+                        Value: "validator_keys",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return exportValidatorKeys(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "import-keys",
+                Aliases:   []string{"m"},
+                Usage:     "Import validator keys from EIP-2335 keystore files",
+                UsageText: "rocketpool wallet import-keys keystore-files...",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateMinArgCount(c, 1); err != nil { return err }
+
+                    // Run
+                    return importValidatorKeys(c, c.Args()...)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "sign-tx",
+                Aliases:   []string{"t"},
+                Usage:     "Sign a transaction prepared with --offline, on the machine holding the node wallet",
+                UsageText: "rocketpool wallet sign-tx [options] unsigned-tx-file",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "output, o",
+                        Usage: "The file to write the signed transaction to; defaults to the unsigned transaction file with a .signed.json extension",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Run
+                    return signTransaction(c, c.Args().Get(0))
+
+                },
+            },
+
         },
     })
 }
@@ -0,0 +1,94 @@
+package wallet
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Config
+const (
+    KeystoreDirMode = 0700
+    KeystoreFileMode = 0600
+)
+
+
+func exportValidatorKeys(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get & check wallet status
+    status, err := rp.WalletStatus()
+    if err != nil {
+        return err
+    }
+    if !status.WalletInitialized {
+        fmt.Println("The node wallet is not initialized.")
+        return nil
+    }
+    if len(status.ValidatorKeys) == 0 {
+        fmt.Println("The node wallet does not have any validator keys.")
+        return nil
+    }
+
+    // Print slashing protection warning & prompt for confirmation
+    fmt.Println("WARNING: running the same validator key in more than one validator client at a time will result in your validator being slashed.")
+    fmt.Println("If you load these keys into another validator client, you must stop the Rocket Pool service first and ensure it is never run with these keys again.")
+    fmt.Println("")
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to export %d validator key(s)?", len(status.ValidatorKeys))) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Prompt for keystore password
+    password := promptKeystorePassword("Please enter a password to encrypt the exported keystores with:", true)
+
+    // Export validator keys
+    response, err := rp.ExportValidatorKeys(password)
+    if err != nil {
+        return err
+    }
+
+    // Create output directory
+    outputDir := c.String("output-dir")
+    if err := os.MkdirAll(outputDir, KeystoreDirMode); err != nil {
+        return fmt.Errorf("Could not create keystore directory %s: %w", outputDir, err)
+    }
+
+    // Write keystore files
+    for _, keystoreJson := range response.Keystores {
+
+        // Decode keystore
+        var keystore wallet.ValidatorKeystore
+        if err := json.Unmarshal([]byte(keystoreJson), &keystore); err != nil {
+            return fmt.Errorf("Could not decode validator keystore: %w", err)
+        }
+
+        // Write keystore file
+        keystorePath := filepath.Join(outputDir, fmt.Sprintf("keystore-%s.json", strings.Replace(keystore.Path, "/", "_", -1)))
+        if err := ioutil.WriteFile(keystorePath, []byte(keystoreJson), KeystoreFileMode); err != nil {
+            return fmt.Errorf("Could not write keystore file %s: %w", keystorePath, err)
+        }
+
+        // Log
+        fmt.Printf("Exported validator %s to %s\n", keystore.Pubkey.Hex(), keystorePath)
+
+    }
+
+    // Return
+    return nil
+
+}
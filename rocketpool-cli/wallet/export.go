@@ -6,6 +6,7 @@ import (
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
 
@@ -42,11 +43,11 @@ func exportWallet(c *cli.Context) error {
     fmt.Println(export.Password)
     fmt.Println("")
     fmt.Println("Wallet file:")
-    fmt.Println("============")
+    cliutils.PrintSeparator("============")
     fmt.Println("")
     fmt.Println(export.Wallet)
     fmt.Println("")
-    fmt.Println("============")
+    cliutils.PrintSeparator("============")
     return nil
 
 }
@@ -0,0 +1,68 @@
+package wallet
+
+import (
+    "fmt"
+    "io/ioutil"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+func importValidatorKeys(c *cli.Context, keystorePaths ...string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get & check wallet status
+    status, err := rp.WalletStatus()
+    if err != nil {
+        return err
+    }
+    if !status.WalletInitialized {
+        fmt.Println("The node wallet is not initialized.")
+        return nil
+    }
+
+    // Read keystore files
+    keystores := make([][]byte, len(keystorePaths))
+    for ki, keystorePath := range keystorePaths {
+        keystore, err := ioutil.ReadFile(keystorePath)
+        if err != nil {
+            return fmt.Errorf("Could not read keystore file %s: %w", keystorePath, err)
+        }
+        keystores[ki] = keystore
+    }
+
+    // Print slashing protection warning & prompt for confirmation
+    fmt.Println("WARNING: running the same validator key in more than one validator client at a time will result in your validator being slashed.")
+    fmt.Println("Before importing, make sure these keys are no longer loaded in any other validator client, and that at least two epochs have passed since it was stopped.")
+    fmt.Println("Imported keys are not derived from your node wallet and cannot be recovered from its mnemonic - keep a backup of the original keystores.")
+    fmt.Println("")
+    if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to import %d validator key(s)?", len(keystores))) {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Prompt for keystore password
+    password := promptKeystorePassword("Please enter the password the keystores are encrypted with:", false)
+
+    // Import keys
+    for ki, keystore := range keystores {
+        response, err := rp.ImportValidatorKey(keystore, password)
+        if err != nil {
+            return fmt.Errorf("Could not import keystore file %s: %w", keystorePaths[ki], err)
+        }
+        fmt.Printf("Imported validator %s from %s\n", response.ValidatorKey.Hex(), keystorePaths[ki])
+    }
+
+    // Log & return
+    fmt.Println("")
+    fmt.Println("Run 'rocketpool service start' to restart the validator client with the imported keys.")
+    return nil
+
+}
@@ -0,0 +1,71 @@
+package wallet
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "strings"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Config
+const SignedTransactionFileMode = 0644
+
+
+func signTransaction(c *cli.Context, path string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Read unsigned transaction
+    transactionBytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return fmt.Errorf("Could not read unsigned transaction file %s: %w", path, err)
+    }
+    var unsignedTx api.UnsignedTransaction
+    if err := json.Unmarshal(transactionBytes, &unsignedTx); err != nil {
+        return fmt.Errorf("Could not decode unsigned transaction file %s: %w", path, err)
+    }
+
+    // Print transaction & prompt for confirmation
+    cliutils.PrintUnsignedTransaction(unsignedTx)
+    fmt.Println("")
+    if !cliutils.Confirm("Are you sure you want to sign this transaction?") {
+        fmt.Println("Cancelled.")
+        return nil
+    }
+
+    // Sign transaction
+    response, err := rp.SignTransaction(unsignedTx)
+    if err != nil {
+        return err
+    }
+
+    // Write signed transaction
+    outputPath := c.String("output")
+    if outputPath == "" {
+        outputPath = strings.TrimSuffix(path, ".json") + ".signed.json"
+    }
+    signedBytes, err := json.MarshalIndent(response.Transaction, "", "  ")
+    if err != nil {
+        return fmt.Errorf("Could not encode signed transaction: %w", err)
+    }
+    if err := ioutil.WriteFile(outputPath, signedBytes, SignedTransactionFileMode); err != nil {
+        return fmt.Errorf("Could not write signed transaction file %s: %w", outputPath, err)
+    }
+
+    // Log & return
+    fmt.Printf("Signed transaction %s and wrote it to %s.\n", response.Transaction.Hash.Hex(), outputPath)
+    fmt.Println("Copy the file to the node and send it with 'rocketpool tx broadcast'.")
+    return nil
+
+}
+
@@ -32,6 +32,9 @@ func getStatus(c *cli.Context) error {
                 fmt.Println(key.Hex())
             }
         }
+    } else if status.WalletOffline {
+        fmt.Println("The node wallet is kept offline; transactions must be prepared with --offline and signed with 'rocketpool wallet sign-tx' on the offline machine.")
+        fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
     } else {
         fmt.Println("The node wallet has not been initialized.")
     }
@@ -29,6 +29,29 @@ func promptPassword() string {
 }
 
 
+// Prompt for a validator keystore password
+func promptKeystorePassword(initialPrompt string, confirm bool) string {
+    for {
+        password := cliutils.Prompt(initialPrompt, "^.+$", "Please enter a password")
+        if !confirm {
+            return password
+        }
+        if len(password) < passwords.MinPasswordLength {
+            fmt.Printf("Your password must be at least %d characters long\n", passwords.MinPasswordLength)
+            fmt.Println("")
+            continue
+        }
+        confirmation := cliutils.Prompt("Please confirm your password:", "^.*$", "")
+        if password == confirmation {
+            return password
+        } else {
+            fmt.Println("Password confirmation does not match.")
+            fmt.Println("")
+        }
+    }
+}
+
+
 // Prompt for a recovery mnemonic phrase
 func promptMnemonic() string {
     for {
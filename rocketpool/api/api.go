@@ -1,13 +1,23 @@
+// API handlers are exported from each subpackage and can be called directly from Go code,
+// passing a config.Options value in place of a CLI context, e.g.:
+//     status, err := node.GetStatus(config.Options{ConfigPath: "/.rocketpool/config.yml"})
+// Services are loaded once per process from the options passed to the first handler called; options passed to later calls are ignored,
+// and if a service failed to load, later calls return the same error. Run a separate process to use a different config.
 package api
 
 import (
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/rocketpool/api/faucet"
+    "github.com/rocket-pool/smartnode/rocketpool/api/jobs"
     "github.com/rocket-pool/smartnode/rocketpool/api/minipool"
     "github.com/rocket-pool/smartnode/rocketpool/api/network"
     "github.com/rocket-pool/smartnode/rocketpool/api/node"
     "github.com/rocket-pool/smartnode/rocketpool/api/queue"
+    "github.com/rocket-pool/smartnode/rocketpool/api/reth"
+    "github.com/rocket-pool/smartnode/rocketpool/api/service"
+    "github.com/rocket-pool/smartnode/rocketpool/api/support"
+    "github.com/rocket-pool/smartnode/rocketpool/api/tx"
     "github.com/rocket-pool/smartnode/rocketpool/api/wallet"
 )
 
@@ -25,10 +35,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
     // Register subcommands
       faucet.RegisterSubcommands(&command, "faucet",   []string{"f"})
+        jobs.RegisterSubcommands(&command, "jobs",     []string{"j"})
     minipool.RegisterSubcommands(&command, "minipool", []string{"m"})
      network.RegisterSubcommands(&command, "network",  []string{"e"})
         node.RegisterSubcommands(&command, "node",     []string{"n"})
        queue.RegisterSubcommands(&command, "queue",    []string{"q"})
+        reth.RegisterSubcommands(&command, "reth",     []string{"r"})
+     service.RegisterSubcommands(&command, "service",  []string{"s"})
+     support.RegisterSubcommands(&command, "support",  []string{"u"})
+          tx.RegisterSubcommands(&command, "tx",       []string{"x"})
       wallet.RegisterSubcommands(&command, "wallet",   []string{"w"})
 
     // Register CLI command
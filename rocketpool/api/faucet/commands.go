@@ -29,7 +29,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(faucetWithdraw(c, token))
+                    api.PrintResponse(FaucetWithdraw(c, token))
                     return nil
 
                 },
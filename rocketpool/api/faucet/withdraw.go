@@ -1,14 +1,13 @@
 package faucet
 
 import (
-    "github.com/urfave/cli"
-
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func faucetWithdraw(c *cli.Context, token string) (*api.FaucetWithdrawResponse, error) {
+func FaucetWithdraw(c config.Context, token string) (*api.FaucetWithdrawResponse, error) {
 
     // Get services
     w, err := services.GetWallet(c)
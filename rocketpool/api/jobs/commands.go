@@ -0,0 +1,87 @@
+package jobs
+
+import (
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/utils/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+    command.Subcommands = append(command.Subcommands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Manage the node's scheduled jobs",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "list",
+                Aliases:   []string{"l"},
+                Usage:     "Get the node's scheduled jobs",
+                UsageText: "rocketpool api jobs list",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetJobs(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "add",
+                Aliases:   []string{"a"},
+                Usage:     "Schedule a minipool action to be run by the node daemon",
+                UsageText: "rocketpool api jobs add type minipool-address max-gas-price not-before",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 4); err != nil { return err }
+                    jobType := c.Args().Get(0)
+                    minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(1))
+                    if err != nil { return err }
+                    maxGasPrice, err := cliutils.ValidateEthAmount("max gas price", c.Args().Get(2))
+                    if err != nil { return err }
+                    notBefore, err := cliutils.ValidateUint("not before timestamp", c.Args().Get(3))
+                    if err != nil { return err }
+
+                    // Run
+                    var notBeforeTime time.Time
+                    if notBefore > 0 {
+                        notBeforeTime = time.Unix(int64(notBefore), 0)
+                    }
+                    api.PrintResponse(AddJob(c, jobType, minipoolAddress, maxGasPrice, notBeforeTime))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "cancel",
+                Aliases:   []string{"c"},
+                Usage:     "Cancel a pending job",
+                UsageText: "rocketpool api jobs cancel id",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    id, err := cliutils.ValidateUint("job ID", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(CancelJob(c, id))
+                    return nil
+
+                },
+            },
+
+        },
+    })
+}
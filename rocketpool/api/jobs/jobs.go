@@ -0,0 +1,113 @@
+package jobs
+
+import (
+    "bytes"
+    "fmt"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/jobs"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetJobs(c config.Context) (*api.JobsResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.JobsResponse{}
+
+    // Get jobs
+    nodeJobs, err := jobs.Load(cfg.GetDataPath())
+    if err != nil {
+        return nil, err
+    }
+    response.Jobs = nodeJobs
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func AddJob(c config.Context, jobType string, minipoolAddress common.Address, maxGasPrice float64, notBefore time.Time) (*api.AddJobResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.AddJobResponse{}
+
+    // Validate job type
+    if !jobs.ValidType(jobType) {
+        return nil, fmt.Errorf("Invalid job type '%s'", jobType)
+    }
+
+    // Validate minipool owner
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+    mp, err := minipool.NewMinipool(rp, minipoolAddress)
+    if err != nil {
+        return nil, err
+    }
+    owner, err := mp.GetNodeAddress(nil)
+    if err != nil {
+        return nil, err
+    }
+    if !bytes.Equal(owner.Bytes(), nodeAccount.Address.Bytes()) {
+        return nil, fmt.Errorf("Minipool %s does not belong to the node", minipoolAddress.Hex())
+    }
+
+    // Add job
+    job, err := jobs.Add(cfg.GetDataPath(), jobs.Job{
+        Type: jobType,
+        Minipool: minipoolAddress,
+        MaxGasPrice: maxGasPrice,
+        NotBefore: notBefore,
+    })
+    if err != nil {
+        return nil, err
+    }
+    response.Job = job
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func CancelJob(c config.Context, id uint64) (*api.CancelJobResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.CancelJobResponse{}
+
+    // Cancel job
+    job, err := jobs.Cancel(cfg.GetDataPath(), id)
+    if err != nil {
+        return nil, err
+    }
+    response.Job = job
+
+    // Return response
+    return &response, nil
+
+}
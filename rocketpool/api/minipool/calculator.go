@@ -0,0 +1,140 @@
+package minipool
+
+import (
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/network"
+    "github.com/rocket-pool/rocketpool-go/node"
+    "github.com/rocket-pool/rocketpool-go/settings"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+
+// Project the node's returns for each deposit type over a number of years at a validator APR
+// Node operators earn rewards on their own ETH, plus the network node fee on rewards earned by user-deposited ETH
+func GetCalculator(c config.Context, apr float64, years uint64) (*api.MinipoolCalculatorResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.MinipoolCalculatorResponse{
+        APR: apr,
+        Years: years,
+    }
+
+    // Get node account; gas is only estimated & trusted status only checked if the node wallet is initialized
+    var nodeAddress common.Address
+    hasAccount := false
+    if nodeAccount, err := w.GetNodeAccount(); err == nil {
+        nodeAddress = nodeAccount.Address
+        hasAccount = true
+    }
+
+    // Data
+    var wg errgroup.Group
+    var fullAmount, halfAmount, emptyAmount *big.Int
+    var trusted bool
+    var gasPrice *big.Int
+
+    // Get network details
+    wg.Go(func() error {
+        var err error
+        response.NodeFee, err = network.GetNodeFee(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        fullAmount, err = settings.GetMinipoolFullDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        halfAmount, err = settings.GetMinipoolHalfDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        emptyAmount, err = settings.GetMinipoolEmptyDepositNodeAmount(rp, nil)
+        return err
+    })
+
+    // Get node trusted status
+    if hasAccount {
+        wg.Go(func() error {
+            var err error
+            trusted, err = node.GetNodeTrusted(rp, nodeAddress, nil)
+            return err
+        })
+    }
+
+    // Get gas price
+    wg.Go(func() error {
+        var err error
+        gasPrice, err = services.GetGasPrice(c)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Project returns; the full deposit node amount is the total validator balance
+    for _, depositType := range []struct {
+        Type rptypes.MinipoolDeposit
+        NodeAmount *big.Int
+    }{
+        {rptypes.Full, fullAmount},
+        {rptypes.Half, halfAmount},
+        {rptypes.Empty, emptyAmount},
+    } {
+        projection := api.DepositTypeProjection{
+            DepositType: depositType.Type.String(),
+            NodeAmount: depositType.NodeAmount,
+            UserAmount: new(big.Int).Sub(fullAmount, depositType.NodeAmount),
+            Available: (depositType.NodeAmount.Cmp(big.NewInt(0)) > 0 || trusted),
+            GasCost: big.NewInt(0),
+        }
+
+        // Estimate deposit gas; estimates fail if the node is not registered or cannot afford the deposit
+        if hasAccount {
+            if gasInfo, err := eth1.EstimateContractGas(rp, gasPrice, "rocketNodeDeposit", nodeAddress, depositType.NodeAmount, "deposit", big.NewInt(0)); err == nil {
+                gasInfo.GasToken = cfg.GetGasToken()
+                projection.GasEstimated = true
+                projection.GasInfo = gasInfo
+                projection.GasCost = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasInfo.EstGasLimit))
+            }
+        }
+
+        // Calculate rewards
+        nodeAmount := eth.WeiToEth(projection.NodeAmount)
+        userAmount := eth.WeiToEth(projection.UserAmount)
+        rewards := (nodeAmount + userAmount * response.NodeFee) * apr * float64(years)
+        projection.Rewards = eth.EthToWei(rewards)
+        projection.NetRewards = new(big.Int).Sub(projection.Rewards, projection.GasCost)
+        if nodeAmount > 0 && years > 0 {
+            projection.AnnualReturn = eth.WeiToEth(projection.NetRewards) / nodeAmount / float64(years)
+        }
+
+        response.DepositTypes = append(response.DepositTypes, projection)
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -1,17 +1,29 @@
 package minipool
 
 import (
+    "context"
+    "errors"
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/settings"
+    "github.com/rocket-pool/rocketpool-go/tokens"
     "github.com/rocket-pool/rocketpool-go/types"
-    "github.com/urfave/cli"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
 )
 
 
-func canCloseMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanCloseMinipoolResponse, error) {
+func CanCloseMinipool(c config.Context, minipoolAddress common.Address) (*api.CanCloseMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
@@ -38,28 +50,242 @@ func canCloseMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanC
         return nil, err
     }
 
+    // Data
+    var wg errgroup.Group
+    var status types.MinipoolStatus
+
     // Check minipool status
-    status, err := mp.GetStatus(nil)
-    if err != nil {
+    wg.Go(func() error {
+        var err error
+        status, err = mp.GetStatus(nil)
+        return err
+    })
+
+    // Get node deposit balance to be returned
+    wg.Go(func() error {
+        nodeDepositBalance, err := mp.GetNodeDepositBalance(nil)
+        if err == nil { response.NodeDepositBalance = nodeDepositBalance }
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
         return nil, err
     }
-    response.InvalidStatus = (status != types.Dissolved)
 
-    // Update & return response
+    // Update response
+    response.InvalidStatus = (status != types.Dissolved)
     response.CanClose = !response.InvalidStatus
+
+    // Estimate gas
+    if response.CanClose {
+        gasInfo, err := estimateMinipoolGas(c, rp, nodeAccount.Address, minipoolAddress, "close")
+        if err != nil {
+            return nil, err
+        }
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
     return &response, nil
 
 }
 
 
-func closeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CloseMinipoolResponse, error) {
+func SimulateCloseMinipool(c config.Context, minipoolAddress common.Address) (*api.SimulateCloseMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    if err := services.RequireBeaconClientSynced(c); err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.SimulateCloseMinipoolResponse{
+        ForfeitAmount: big.NewInt(0),
+    }
+
+    // Create minipool
+    mp, err := minipool.NewMinipool(rp, minipoolAddress)
+    if err != nil {
+        return nil, err
+    }
+
+    // Validate minipool owner
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+    if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+        return nil, err
+    }
+
+    // Data
+    var wg errgroup.Group
+    var status minipool.StatusDetails
+    var currentBlock uint64
+    var withdrawalDelay uint64
+    var userDepositBalance *big.Int
+    var withdrawalTotalBalance *big.Int
+    var withdrawalNodeBalance *big.Int
+    var pubkey types.ValidatorPubkey
+    var head beacon.BeaconHead
+
+    // Get minipool status
+    wg.Go(func() error {
+        var err error
+        status, err = mp.GetStatusDetails(nil)
+        return err
+    })
+
+    // Get current block
+    wg.Go(func() error {
+        header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+        if err == nil {
+            currentBlock = header.Number.Uint64()
+        }
+        return err
+    })
+
+    // Get withdrawal delay
+    wg.Go(func() error {
+        var err error
+        withdrawalDelay, err = settings.GetMinipoolWithdrawalDelay(rp, nil)
+        return err
+    })
+
+    // Get balances returned to the node; the minipool's nETH & ETH balances are sent to the node when it is destroyed
+    wg.Go(func() error {
+        var err error
+        response.NodeNETHAmount, err = tokens.GetNETHBalance(rp, minipoolAddress, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.NodeETHAmount, err = rp.Client.BalanceAt(context.Background(), minipoolAddress, nil)
+        return err
+    })
+
+    // Get user balances
+    wg.Go(func() error {
+        var err error
+        userDepositBalance, err = mp.GetUserDepositBalance(nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        withdrawalTotalBalance, err = minipool.GetMinipoolWithdrawalTotalBalance(rp, minipoolAddress, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        withdrawalNodeBalance, err = minipool.GetMinipoolWithdrawalNodeBalance(rp, minipoolAddress, nil)
+        return err
+    })
+
+    // Get validator pubkey
+    wg.Go(func() error {
+        var err error
+        pubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+        return err
+    })
+
+    // Get beacon head
+    wg.Go(func() error {
+        var err error
+        head, err = bc.GetBeaconHead()
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+    response.MinipoolStatus = status.Status
+    response.CurrentEpoch = head.Epoch
+
+    // Get close method & user balance
+    // Dissolved minipools returned their user deposit to the deposit pool when they were dissolved
+    // Withdrawable minipools pay the user share of the validator's withdrawal to rETH holders
+    switch status.Status {
+        case types.Dissolved:
+            response.Method = "close"
+            response.UserAmount = userDepositBalance
+        case types.Withdrawable:
+            response.Method = "withdraw"
+            response.WithdrawalDelayActive = ((currentBlock - status.StatusBlock) < withdrawalDelay)
+            response.UserAmount, err = safemath.Sub(withdrawalTotalBalance, withdrawalNodeBalance)
+            if errors.Is(err, safemath.ErrNegativeResult) {
+                response.UserAmount, err = big.NewInt(0), nil
+            }
+            if err != nil {
+                return nil, fmt.Errorf("Could not calculate minipool user share: %w", err)
+            }
+        default:
+            response.InvalidStatus = true
+            return &response, nil
+    }
+
+    // Check validator status; an empty pubkey means the minipool never staked
+    if pubkey != (types.ValidatorPubkey{}) {
+        validator, err := bc.GetValidatorStatus(pubkey, nil)
+        if err != nil {
+            return nil, err
+        }
+        if validator.Exists {
+            response.ValidatorExists = true
+            response.ValidatorIndex = validator.Index
+            response.ValidatorBalance = eth.GweiToWei(float64(validator.Balance))
+            response.ExitEpoch = validator.ExitEpoch
+            response.WithdrawableEpoch = validator.WithdrawableEpoch
+            response.ValidatorExited = (validator.ExitEpoch != beacon.FarFutureEpoch && validator.ExitEpoch <= head.Epoch)
+            response.ValidatorWithdrawn = (validator.WithdrawableEpoch != beacon.FarFutureEpoch && validator.WithdrawableEpoch <= head.Epoch)
+        }
+    }
+
+    // Get the validator balance forfeited by closing now
+    // Dissolved minipools do not account for any validator balance; withdrawable minipools only account for it once the validator is withdrawn
+    if response.ValidatorExists && (status.Status == types.Dissolved || !response.ValidatorWithdrawn) {
+        response.ForfeitAmount = response.ValidatorBalance
+    }
+
+    // Simulate the close against the latest block
+    if !response.WithdrawalDelayActive {
+        if err := simulateMinipoolCall(rp, nodeAccount.Address, minipoolAddress, response.Method); err != nil {
+            response.SimulationError = err.Error()
+        }
+    }
+
+    // Update response
+    response.CanClose = !(response.WithdrawalDelayActive || response.SimulationError != "")
+
+    // Estimate gas
+    if response.CanClose {
+        gasInfo, err := estimateMinipoolGas(c, rp, nodeAccount.Address, minipoolAddress, response.Method)
+        if err != nil {
+            return nil, err
+        }
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func CloseMinipool(c config.Context, minipoolAddress common.Address) (*api.CloseMinipoolResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.CloseMinipoolResponse{}
@@ -71,13 +297,16 @@ func closeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CloseMi
     }
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
 
     // Close
-    txReceipt, err := mp.Close(opts)
+    txReceipt, err := txm.Transact(opts, fmt.Sprintf("Close minipool %s", minipoolAddress.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := mp.Close(opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
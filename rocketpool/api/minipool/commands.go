@@ -27,7 +27,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(getStatus(c))
+                    api.PrintResponse(GetStatus(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "performance",
+                Aliases:   []string{"p"},
+                Usage:     "Get the recorded performance of the node's minipool validators",
+                UsageText: "rocketpool api minipool performance",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetPerformance(c))
                     return nil
 
                 },
@@ -45,7 +62,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canRefundMinipool(c, minipoolAddress))
+                    api.PrintResponse(CanRefundMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -63,7 +80,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(refundMinipool(c, minipoolAddress))
+                    api.PrintResponse(RefundMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -81,7 +98,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canDissolveMinipool(c, minipoolAddress))
+                    api.PrintResponse(CanDissolveMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -99,7 +116,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(dissolveMinipool(c, minipoolAddress))
+                    api.PrintResponse(DissolveMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -117,7 +134,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canExitMinipool(c, minipoolAddress))
+                    api.PrintResponse(CanExitMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -135,7 +152,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(exitMinipool(c, minipoolAddress))
+                    api.PrintResponse(ExitMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -153,7 +170,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canWithdrawMinipool(c, minipoolAddress))
+                    api.PrintResponse(CanWithdrawMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -171,7 +188,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(withdrawMinipool(c, minipoolAddress))
+                    api.PrintResponse(WithdrawMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -189,7 +206,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canCloseMinipool(c, minipoolAddress))
+                    api.PrintResponse(CanCloseMinipool(c, minipoolAddress))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "simulate-close",
+                Usage:     "Simulate closing a dissolved or withdrawable minipool, and check its validator on the beacon chain",
+                UsageText: "rocketpool api minipool simulate-close minipool-address",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(SimulateCloseMinipool(c, minipoolAddress))
                     return nil
 
                 },
@@ -207,7 +241,45 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(closeMinipool(c, minipoolAddress))
+                    api.PrintResponse(CloseMinipool(c, minipoolAddress))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "verify-credentials",
+                Aliases:   []string{"v"},
+                Usage:     "Verify the withdrawal credentials of the node's minipool validators on the beacon chain",
+                UsageText: "rocketpool api minipool verify-credentials",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(VerifyMinipoolCredentials(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "calculator",
+                Aliases:   []string{"x"},
+                Usage:     "Project the node's returns for each deposit type at a validator APR",
+                UsageText: "rocketpool api minipool calculator apr years",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    apr, err := cliutils.ValidateFraction("APR", c.Args().Get(0))
+                    if err != nil { return err }
+                    years, err := cliutils.ValidateUint("years", c.Args().Get(1))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetCalculator(c, apr, years))
                     return nil
 
                 },
@@ -1,17 +1,23 @@
 package minipool
 
 import (
+    "context"
+    "fmt"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/settings"
     "github.com/rocket-pool/rocketpool-go/types"
-    "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func canDissolveMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanDissolveMinipoolResponse, error) {
+func CanDissolveMinipool(c config.Context, minipoolAddress common.Address) (*api.CanDissolveMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
@@ -38,28 +44,75 @@ func canDissolveMinipool(c *cli.Context, minipoolAddress common.Address) (*api.C
         return nil, err
     }
 
-    // Check minipool status
-    status, err := mp.GetStatus(nil)
-    if err != nil {
+    // Data
+    var wg errgroup.Group
+    var status minipool.StatusDetails
+    var currentBlock uint64
+    var launchTimeout uint64
+
+    // Get minipool status
+    wg.Go(func() error {
+        var err error
+        status, err = mp.GetStatusDetails(nil)
+        return err
+    })
+
+    // Get node deposit balance to be recovered
+    wg.Go(func() error {
+        nodeDepositBalance, err := mp.GetNodeDepositBalance(nil)
+        if err == nil { response.NodeDepositBalance = nodeDepositBalance }
+        return err
+    })
+
+    // Get current block
+    wg.Go(func() error {
+        header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+        if err == nil { currentBlock = header.Number.Uint64() }
+        return err
+    })
+
+    // Get launch timeout
+    wg.Go(func() error {
+        var err error
+        launchTimeout, err = settings.GetMinipoolLaunchTimeout(rp, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
         return nil, err
     }
-    response.InvalidStatus = !(status == types.Initialized || status == types.Prelaunch)
 
-    // Update & return response
-    response.CanDissolve = !response.InvalidStatus
+    // Check minipool status; prelaunch minipools may only be dissolved once the launch timeout has passed
+    response.InvalidStatus = !(status.Status == types.Initialized || status.Status == types.Prelaunch)
+    response.LaunchTimeoutActive = (status.Status == types.Prelaunch && currentBlock < status.StatusBlock + launchTimeout)
+
+    // Update response
+    response.CanDissolve = !(response.InvalidStatus || response.LaunchTimeoutActive)
+
+    // Estimate gas
+    if response.CanDissolve {
+        gasInfo, err := estimateMinipoolGas(c, rp, nodeAccount.Address, minipoolAddress, "dissolve")
+        if err != nil {
+            return nil, err
+        }
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
     return &response, nil
 
 }
 
 
-func dissolveMinipool(c *cli.Context, minipoolAddress common.Address) (*api.DissolveMinipoolResponse, error) {
+func DissolveMinipool(c config.Context, minipoolAddress common.Address) (*api.DissolveMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.DissolveMinipoolResponse{}
@@ -71,13 +124,16 @@ func dissolveMinipool(c *cli.Context, minipoolAddress common.Address) (*api.Diss
     }
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
 
     // Dissolve
-    txReceipt, err := mp.Dissolve(opts)
+    txReceipt, err := txm.Transact(opts, fmt.Sprintf("Dissolve minipool %s", minipoolAddress.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := mp.Dissolve(opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
@@ -4,21 +4,28 @@ import (
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
     "github.com/rocket-pool/rocketpool-go/types"
-    "github.com/urfave/cli"
+    eth2types "github.com/wealdtech/go-eth2-types/v2"
+    "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
 
-func canExitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanExitMinipoolResponse, error) {
+func CanExitMinipool(c config.Context, minipoolAddress common.Address) (*api.CanExitMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    if err := services.RequireBeaconClientSynced(c); err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.CanExitMinipoolResponse{}
@@ -38,30 +45,132 @@ func canExitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanEx
         return nil, err
     }
 
-    // Check minipool status
-    status, err := mp.GetStatus(nil)
-    if err != nil {
+    // Data
+    var wg errgroup.Group
+    var status types.MinipoolStatus
+    var pubkey types.ValidatorPubkey
+    var head beacon.BeaconHead
+
+    // Get minipool status
+    wg.Go(func() error {
+        var err error
+        status, err = mp.GetStatus(nil)
+        return err
+    })
+
+    // Get minipool validator pubkey
+    wg.Go(func() error {
+        var err error
+        pubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+        return err
+    })
+
+    // Get beacon head
+    wg.Go(func() error {
+        var err error
+        head, err = bc.GetBeaconHead()
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
         return nil, err
     }
     response.InvalidStatus = (status != types.Staking)
+    response.CurrentEpoch = head.Epoch
+
+    // Check validator status
+    if !response.InvalidStatus {
+        validatorStatus, err := bc.GetValidatorStatus(pubkey, nil)
+        if err != nil {
+            return nil, err
+        }
+        response.ValidatorNotActive = (!validatorStatus.Exists || validatorStatus.ActivationEpoch > head.Epoch)
+        response.ValidatorExiting = (validatorStatus.Exists && validatorStatus.ExitEpoch != beacon.FarFutureEpoch)
+        if !response.ValidatorNotActive {
+            response.EarliestExitEpoch = validator.GetEarliestExitEpoch(validatorStatus.ActivationEpoch)
+            response.ActivationTooRecent = (head.Epoch < response.EarliestExitEpoch)
+        }
+    }
 
     // Update & return response
-    response.CanExit = !response.InvalidStatus
+    response.CanExit = !(response.InvalidStatus || response.ValidatorNotActive || response.ValidatorExiting || response.ActivationTooRecent)
     return &response, nil
 
 }
 
 
-func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMinipoolResponse, error) {
+func ExitMinipool(c config.Context, minipoolAddress common.Address) (*api.ExitMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    if err := services.RequireBeaconClientSynced(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
 
-    // Exit
-    // TODO: implement
+    // Response
+    response := api.ExitMinipoolResponse{}
+
+    // Get minipool validator pubkey
+    pubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    // Get validator private key
+    validatorKey, err := w.GetValidatorKeyByPubkey(pubkey)
+    if err != nil {
+        return nil, err
+    }
+
+    // Data
+    var wg errgroup.Group
+    var validatorStatus beacon.ValidatorStatus
+    var head beacon.BeaconHead
+
+    // Get validator status
+    wg.Go(func() error {
+        var err error
+        validatorStatus, err = bc.GetValidatorStatus(pubkey, nil)
+        return err
+    })
+
+    // Get beacon head
+    wg.Go(func() error {
+        var err error
+        head, err = bc.GetBeaconHead()
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Get voluntary exit signature domain; exits are valid from the current epoch
+    signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], head.Epoch)
+    if err != nil {
+        return nil, err
+    }
+
+    // Sign voluntary exit message
+    signature, err := validator.GetSignedExitMessage(validatorKey, validatorStatus.Index, head.Epoch, signatureDomain)
+    if err != nil {
+        return nil, err
+    }
+
+    // Broadcast voluntary exit message
+    if err := bc.ExitValidator(validatorStatus.Index, head.Epoch, signature); err != nil {
+        return nil, err
+    }
+    response.ValidatorIndex = validatorStatus.Index
+    response.Epoch = head.Epoch
 
     // Return response
-    return &api.ExitMinipoolResponse{}, nil
+    return &response, nil
 
 }
-
@@ -0,0 +1,61 @@
+package minipool
+
+import (
+    "bytes"
+    "sort"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/performance"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetPerformance(c config.Context) (*api.MinipoolPerformanceResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.MinipoolPerformanceResponse{
+        Minipools: []api.MinipoolPerformance{},
+    }
+
+    // Load recorded performance
+    record, tracked, err := performance.Load(cfg.GetDataPath())
+    if err != nil {
+        return nil, err
+    }
+    response.Tracked = tracked
+    response.LastEpoch = record.LastEpoch
+
+    // Get minipool performance
+    for _, validator := range record.Validators {
+        response.Minipools = append(response.Minipools, api.MinipoolPerformance{
+            Address: validator.MinipoolAddress,
+            ValidatorPubkey: validator.Pubkey,
+            ValidatorIndex: validator.Index,
+            FirstEpoch: validator.FirstEpoch,
+            ActiveEpochs: validator.ActiveEpochs,
+            Attestations: validator.Attestations,
+            MissedAttestations: validator.MissedAttestations,
+            CorrectTargets: validator.CorrectTargets,
+            CorrectHeads: validator.CorrectHeads,
+            Effectiveness: validator.Effectiveness(),
+            AverageInclusionDistance: validator.AverageInclusionDistance(),
+            Proposals: validator.Proposals,
+            MissedProposals: validator.MissedProposals,
+            LastMissedAttestationEpoch: validator.LastMissedAttestationEpoch,
+            LastMissedProposalSlot: validator.LastMissedProposalSlot,
+        })
+    }
+    sort.Slice(response.Minipools, func(i, j int) bool {
+        return bytes.Compare(response.Minipools[i].Address.Bytes(), response.Minipools[j].Address.Bytes()) < 0
+    })
+
+    // Return response
+    return &response, nil
+
+}
+
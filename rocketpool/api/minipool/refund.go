@@ -1,18 +1,20 @@
 package minipool
 
 import (
+    "fmt"
     "math/big"
 
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
-    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func canRefundMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanRefundMinipoolResponse, error) {
+func CanRefundMinipool(c config.Context, minipoolAddress common.Address) (*api.CanRefundMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
@@ -44,23 +46,35 @@ func canRefundMinipool(c *cli.Context, minipoolAddress common.Address) (*api.Can
     if err != nil {
         return nil, err
     }
+    response.RefundBalance = refundBalance
     response.InsufficientRefundBalance = (refundBalance.Cmp(big.NewInt(0)) == 0)
 
-    // Update & return response
+    // Update response
     response.CanRefund = !response.InsufficientRefundBalance
+
+    // Estimate gas
+    if response.CanRefund {
+        gasInfo, err := estimateMinipoolGas(c, rp, nodeAccount.Address, minipoolAddress, "refund")
+        if err != nil {
+            return nil, err
+        }
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
     return &response, nil
 
 }
 
 
-func refundMinipool(c *cli.Context, minipoolAddress common.Address) (*api.RefundMinipoolResponse, error) {
+func RefundMinipool(c config.Context, minipoolAddress common.Address) (*api.RefundMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.RefundMinipoolResponse{}
@@ -72,13 +86,16 @@ func refundMinipool(c *cli.Context, minipoolAddress common.Address) (*api.Refund
     }
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
 
     // Refund
-    txReceipt, err := mp.Refund(opts)
+    txReceipt, err := txm.Transact(opts, fmt.Sprintf("Refund minipool %s", minipoolAddress.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := mp.Refund(opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
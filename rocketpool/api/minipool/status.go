@@ -1,21 +1,20 @@
 package minipool
 
 import (
-    "github.com/urfave/cli"
-
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
+func GetStatus(c config.Context) (*api.MinipoolStatusResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
     if err := services.RequireBeaconClientSynced(c); err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
-    rp, err := services.GetRocketPool(c)
+    rp, err := services.GetAnalyticsRocketPool(c)
     if err != nil { return nil, err }
     bc, err := services.GetBeaconClient(c)
     if err != nil { return nil, err }
@@ -6,6 +6,7 @@ import (
     "fmt"
     "math/big"
 
+    "github.com/ethereum/go-ethereum"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
     "github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -15,9 +16,13 @@ import (
     "github.com/rocket-pool/rocketpool-go/utils/eth"
     "golang.org/x/sync/errgroup"
 
+    "github.com/rocket-pool/smartnode/shared/services"
     "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
     "github.com/rocket-pool/smartnode/shared/utils/eth2"
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
 )
 
 
@@ -34,6 +39,61 @@ func validateMinipoolOwner(mp *minipool.Minipool, nodeAddress common.Address) er
 }
 
 
+// Estimate the gas usage of a node transaction on a minipool contract
+func estimateMinipoolGas(c config.Context, rp *rocketpool.RocketPool, nodeAddress, minipoolAddress common.Address, method string) (api.GasInfo, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return api.GasInfo{}, err }
+
+    // Get gas price & minipool ABI
+    gasPrice, err := services.GetGasPrice(c)
+    if err != nil {
+        return api.GasInfo{}, err
+    }
+    minipoolAbi, err := rp.GetABI("rocketMinipool")
+    if err != nil {
+        return api.GasInfo{}, err
+    }
+
+    // Estimate gas
+    gasInfo, err := eth1.EstimateCallGas(rp, gasPrice, minipoolAddress, minipoolAbi, "rocketMinipool", nodeAddress, nil, method)
+    if err != nil {
+        return api.GasInfo{}, err
+    }
+    if cfg.Smartnode.MaxFee > 0 {
+        gasInfo.MaxGasPrice = eth.GweiToWei(cfg.Smartnode.MaxFee)
+    }
+    gasInfo.GasToken = cfg.GetGasToken()
+
+    // Return
+    return gasInfo, nil
+
+}
+
+
+// Execute a minipool method call by the node against the latest block without sending a transaction
+// Returns the revert error if the call would fail
+func simulateMinipoolCall(rp *rocketpool.RocketPool, nodeAddress, minipoolAddress common.Address, method string) error {
+    minipoolAbi, err := rp.GetABI("rocketMinipool")
+    if err != nil {
+        return err
+    }
+    data, err := minipoolAbi.Pack(method)
+    if err != nil {
+        return fmt.Errorf("Could not encode rocketMinipool.%s call data: %w", method, err)
+    }
+    if _, err := rp.Client.CallContract(context.Background(), ethereum.CallMsg{
+        From: nodeAddress,
+        To: &minipoolAddress,
+        Data: data,
+    }, nil); err != nil {
+        return fmt.Errorf("rocketMinipool.%s would fail: %w", method, err)
+    }
+    return nil
+}
+
+
 // Get all node minipool details
 func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address) ([]api.MinipoolDetails, error) {
 
@@ -203,12 +263,22 @@ func getMinipoolValidatorDetails(rp *rocketpool.RocketPool, bc beacon.Client, mi
     if validator.Exists {
         details.Exists = true
         details.Active = (validator.ActivationEpoch <= currentEpoch)
+        details.Exiting = (validator.ExitEpoch != beacon.FarFutureEpoch)
+        if details.Exiting {
+            details.ExitEpoch = validator.ExitEpoch
+            details.WithdrawableEpoch = validator.WithdrawableEpoch
+        }
+    }
+
+    // Get validator activation balance
+    activationBalance, err := safemath.Add(minipoolDetails.Node.DepositBalance, minipoolDetails.User.DepositBalance)
+    if err != nil {
+        return api.ValidatorDetails{}, fmt.Errorf("Could not calculate minipool %s activation balance: %w", minipoolDetails.Address.Hex(), err)
     }
 
     // use deposit balances if validator not active
     if !details.Active {
-        details.Balance = new(big.Int)
-        details.Balance.Add(minipoolDetails.Node.DepositBalance, minipoolDetails.User.DepositBalance)
+        details.Balance = activationBalance
         details.NodeBalance = new(big.Int)
         details.NodeBalance.Set(minipoolDetails.Node.DepositBalance)
         return details, nil
@@ -225,13 +295,14 @@ func getMinipoolValidatorDetails(rp *rocketpool.RocketPool, bc beacon.Client, mi
         startEpoch = currentEpoch
     }
 
-    // Get validator activation balance
-    activationBalanceWei := new(big.Int)
-    activationBalanceWei.Add(minipoolDetails.Node.DepositBalance, minipoolDetails.User.DepositBalance)
-    activationBalance := eth.WeiToGwei(activationBalanceWei)
-
     // Calculate approximate validator balance at start epoch
-    startBalance := eth.GweiToWei(activationBalance + (float64(validator.Balance) - activationBalance) * float64(startEpoch - validator.ActivationEpoch) / float64(currentEpoch - validator.ActivationEpoch))
+    startBalance := activationBalance
+    if currentEpoch > validator.ActivationEpoch {
+        startBalance, err = safemath.Interpolate(activationBalance, details.Balance, startEpoch - validator.ActivationEpoch, currentEpoch - validator.ActivationEpoch)
+        if err != nil {
+            return api.ValidatorDetails{}, fmt.Errorf("Could not calculate minipool %s start balance: %w", minipoolDetails.Address.Hex(), err)
+        }
+    }
 
     // Get expected node balance
     nodeBalance, err := minipool.GetMinipoolNodeRewardAmount(rp, minipoolDetails.Node.Fee, minipoolDetails.User.DepositBalance, startBalance, details.Balance, nil)
@@ -0,0 +1,48 @@
+package minipool
+
+import (
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+
+func VerifyMinipoolCredentials(c config.Context) (*api.VerifyMinipoolCredentialsResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    if err := services.RequireBeaconClientSynced(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.VerifyMinipoolCredentialsResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Verify minipool withdrawal credentials
+    expected, credentials, err := validator.VerifyWithdrawalCredentials(rp, bc, nodeAccount.Address)
+    if err != nil {
+        return nil, err
+    }
+    response.ExpectedCredentials = expected
+    response.Minipools = credentials
+    for _, mpCredentials := range credentials {
+        if mpCredentials.ValidatorExists && !mpCredentials.Match {
+            response.MismatchCount++
+        }
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -2,23 +2,31 @@ package minipool
 
 import (
     "context"
+    "fmt"
+    "time"
 
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
     "github.com/rocket-pool/rocketpool-go/settings"
+    "github.com/rocket-pool/rocketpool-go/tokens"
     "github.com/rocket-pool/rocketpool-go/types"
-    "github.com/urfave/cli"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/rewards"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func canWithdrawMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanWithdrawMinipoolResponse, error) {
+func CanWithdrawMinipool(c config.Context, minipoolAddress common.Address) (*api.CanWithdrawMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
     ec, err := services.GetEthClient(c)
@@ -90,21 +98,50 @@ func canWithdrawMinipool(c *cli.Context, minipoolAddress common.Address) (*api.C
     // Check minipool withdrawal delay
     response.WithdrawalDelayActive = ((currentBlock - statusBlock) < withdrawalDelay)
 
-    // Update & return response
+    // Update response
     response.CanWithdraw = !(response.InvalidStatus || response.WithdrawalDelayActive)
+
+    // Simulate claim
+    if response.CanWithdraw {
+        gasPrice, err := services.GetGasPrice(c)
+        if err != nil {
+            return nil, err
+        }
+        simulation, err := rewards.SimulateClaim(rp, minipoolAddress, nodeAccount.Address, gasPrice)
+        if err != nil {
+            return nil, err
+        }
+        meetsMinRatio, err := simulation.MeetsMinRatio(cfg.GetMinClaimGasRatio())
+        if err != nil {
+            return nil, err
+        }
+        response.NETHAmount = simulation.NETHAmount
+        response.ETHAmount = simulation.ETHAmount
+        response.GasInfo = simulation.GasInfo
+        if cfg.Smartnode.MaxFee > 0 {
+            response.GasInfo.MaxGasPrice = eth.GweiToWei(cfg.Smartnode.MaxFee)
+        }
+        response.GasInfo.GasToken = cfg.GetGasToken()
+        response.BelowMinClaimGasRatio = !meetsMinRatio
+        response.MinClaimGasRatio = cfg.GetMinClaimGasRatio()
+    }
+
+    // Return response
     return &response, nil
 
 }
 
 
-func withdrawMinipool(c *cli.Context, minipoolAddress common.Address) (*api.WithdrawMinipoolResponse, error) {
+func WithdrawMinipool(c config.Context, minipoolAddress common.Address) (*api.WithdrawMinipoolResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
+    cfg, err := services.GetConfig(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.WithdrawMinipoolResponse{}
@@ -115,19 +152,37 @@ func withdrawMinipool(c *cli.Context, minipoolAddress common.Address) (*api.With
         return nil, err
     }
 
+    // Get claimable nETH balance
+    amount, err := tokens.GetNETHBalance(rp, minipoolAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
 
     // Withdraw
-    txReceipt, err := mp.Withdraw(opts)
+    txReceipt, err := txm.Transact(opts, fmt.Sprintf("Withdraw minipool %s", minipoolAddress.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := mp.Withdraw(opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
     response.TxHash = txReceipt.TxHash
 
+    // Record rewards claim; the withdrawal has already been made, so errors are not returned
+    rewards.RecordClaim(cfg.GetDataPath(), rewards.Claim{
+        Time: time.Now(),
+        MinipoolAddress: minipoolAddress,
+        Amount: amount,
+        TxHash: txReceipt.TxHash,
+        GasPrice: opts.GasPrice,
+    })
+
     // Return response
     return &response, nil
 
@@ -0,0 +1,40 @@
+package network
+
+import (
+    "context"
+    "math/big"
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+
+func GetBlockAtTime(c config.Context, t time.Time) (*api.NetworkBlockAtTimeResponse, error) {
+
+    // Get services
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NetworkBlockAtTimeResponse{}
+
+    // Get block
+    blockNumber, err := eth1.GetBlockAtTime(ec, t)
+    if err != nil {
+        return nil, err
+    }
+    header, err := ec.HeaderByNumber(context.Background(), new(big.Int).SetUint64(blockNumber))
+    if err != nil {
+        return nil, err
+    }
+    response.Block = blockNumber
+    response.BlockTime = time.Unix(int64(header.Time), 0)
+
+    // Return response
+    return &response, nil
+
+}
+
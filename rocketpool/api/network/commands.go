@@ -1,6 +1,8 @@
 package network
 
 import (
+    "time"
+
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/utils/api"
@@ -27,7 +29,117 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(getNodeFee(c))
+                    api.PrintResponse(GetNodeFee(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "stats",
+                Aliases:   []string{"s"},
+                Usage:     "Get network-wide Rocket Pool statistics, optionally at a past block",
+                UsageText: "rocketpool api network stats [block number]",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateMaxArgCount(c, 1); err != nil { return err }
+                    var blockNumber uint64
+                    if c.NArg() == 1 {
+                        var err error
+                        blockNumber, err = cliutils.ValidateUint("block number", c.Args().Get(0))
+                        if err != nil { return err }
+                    }
+
+                    // Run
+                    api.PrintResponse(GetStats(c, blockNumber))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "settings",
+                Usage:     "Get the network's on-chain protocol settings",
+                UsageText: "rocketpool api network settings",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetSettings(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "block-at-time",
+                Usage:     "Get the latest block mined at or before a time",
+                UsageText: "rocketpool api network block-at-time timestamp",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    timestamp, err := cliutils.ValidateUint("timestamp", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetBlockAtTime(c, time.Unix(int64(timestamp), 0)))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "resolve-name",
+                Usage:     "Resolve an ENS name to an address",
+                UsageText: "rocketpool api network resolve-name name",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    name, err := cliutils.ValidateENSName("name", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(ResolveName(c, name))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "lookup-address",
+                Usage:     "Look up the primary ENS name of an address",
+                UsageText: "rocketpool api network lookup-address address",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(LookupAddress(c, address))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "queue",
+                Aliases:   []string{"q"},
+                Usage:     "Get the deposit pool and minipool queue status, including the node's queued minipools",
+                UsageText: "rocketpool api network queue",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetQueue(c))
                     return nil
 
                 },
@@ -0,0 +1,57 @@
+package network
+
+import (
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/ens"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func ResolveName(c config.Context, name string) (*api.NetworkResolveNameResponse, error) {
+
+    // Get services
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NetworkResolveNameResponse{}
+
+    // Resolve name
+    address, err := ens.ResolveName(ec, name)
+    if err != nil {
+        return nil, err
+    }
+    response.Name = ens.Normalize(name)
+    response.Address = address
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func LookupAddress(c config.Context, address common.Address) (*api.NetworkLookupAddressResponse, error) {
+
+    // Get services
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NetworkLookupAddressResponse{}
+
+    // Look up address
+    name, err := ens.LookupAddress(ec, address)
+    if err != nil {
+        return nil, err
+    }
+    response.Address = address
+    response.Name = name
+
+    // Return response
+    return &response, nil
+
+}
+
@@ -3,15 +3,15 @@ package network
 import (
     "github.com/rocket-pool/rocketpool-go/network"
     "github.com/rocket-pool/rocketpool-go/settings"
-    "github.com/urfave/cli"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func getNodeFee(c *cli.Context) (*api.NodeFeeResponse, error) {
+func GetNodeFee(c config.Context) (*api.NodeFeeResponse, error) {
 
     // Get services
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
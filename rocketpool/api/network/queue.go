@@ -0,0 +1,250 @@
+package network
+
+import (
+    "context"
+    "errors"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/crypto"
+    "github.com/rocket-pool/rocketpool-go/deposit"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/types"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Settings
+const assignmentRateBlocks = 40320 // ~1 week
+
+
+// Minipool queues in the order they are assigned deposits from
+var queueOrder = []types.MinipoolDeposit{types.Half, types.Full, types.Empty}
+var queueKeys = map[types.MinipoolDeposit]string{
+    types.Half: "minipools.available.half",
+    types.Full: "minipools.available.full",
+    types.Empty: "minipools.available.empty",
+}
+
+
+func GetQueue(c config.Context) (*api.NetworkQueueResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetAnalyticsRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NetworkQueueResponse{
+        NodeMinipools: []api.QueuedMinipool{},
+    }
+
+    // Data
+    var wg errgroup.Group
+    lengths := make([]uint64, len(queueOrder))
+    var nodeMinipools []api.QueuedMinipool
+
+    // Get deposit pool balance
+    wg.Go(func() error {
+        var err error
+        response.DepositPoolBalance, err = deposit.GetBalance(rp, nil)
+        return err
+    })
+
+    // Get minipool queue capacity
+    wg.Go(func() error {
+        var err error
+        response.MinipoolQueueCapacity, err = minipool.GetQueueTotalCapacity(rp, nil)
+        return err
+    })
+
+    // Get minipool queue lengths
+    for qi, depositType := range queueOrder {
+        qi, depositType := qi, depositType
+        wg.Go(func() error {
+            length, err := callBig(rp, nil, "rocketMinipoolQueue", "getLength", uint8(depositType))
+            if err == nil {
+                lengths[qi] = length.Uint64()
+            }
+            return err
+        })
+    }
+
+    // Get node minipools in the queue; skipped if the node wallet is not initialized
+    if nodeAccount, err := w.GetNodeAccount(); err == nil {
+        wg.Go(func() error {
+            var err error
+            nodeMinipools, err = getNodeQueuedMinipools(rp, nodeAccount.Address)
+            return err
+        })
+    }
+
+    // Get recent assignments
+    wg.Go(func() error {
+        var err error
+        response.RecentAssignments, response.AssignmentRate, err = getAssignmentRate(rp)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Set queue lengths
+    queueLengths := make(map[types.MinipoolDeposit]uint64)
+    for qi, depositType := range queueOrder {
+        queueLengths[depositType] = lengths[qi]
+        response.MinipoolQueueLength += lengths[qi]
+    }
+    response.HalfQueueLength = queueLengths[types.Half]
+    response.FullQueueLength = queueLengths[types.Full]
+    response.EmptyQueueLength = queueLengths[types.Empty]
+
+    // Get node minipool positions in the overall queue; nodeMinipools positions are within their own queue
+    for _, mp := range nodeMinipools {
+        for _, depositType := range queueOrder {
+            if depositType == mp.DepositType {
+                break
+            }
+            mp.Position += queueLengths[depositType]
+        }
+        response.NodeMinipools = append(response.NodeMinipools, mp)
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Get a node's minipools which are waiting in the queue, with their 1-based positions in their deposit type's queue
+func getNodeQueuedMinipools(rp *rocketpool.RocketPool, nodeAddress common.Address) ([]api.QueuedMinipool, error) {
+
+    // Get minipool addresses
+    addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+    if err != nil {
+        return []api.QueuedMinipool{}, err
+    }
+
+    // Data
+    var wg errgroup.Group
+    queued := make([]*api.QueuedMinipool, len(addresses))
+
+    // Load queue details
+    for mi, address := range addresses {
+        mi, address := mi, address
+        wg.Go(func() error {
+
+            // Check minipool is waiting for assignment
+            mp, err := minipool.NewMinipool(rp, address)
+            if err != nil {
+                return err
+            }
+            status, err := mp.GetStatus(nil)
+            if err != nil || status != types.Initialized {
+                return err
+            }
+            depositType, err := mp.GetDepositType(nil)
+            if err != nil {
+                return err
+            }
+            key, ok := queueKeys[depositType]
+            if !ok {
+                return nil
+            }
+
+            // Get queue index; -1 if not in the queue
+            index, err := callBig(rp, nil, "addressQueueStorage", "getIndexOf", crypto.Keccak256Hash([]byte(key)), address)
+            if err != nil {
+                return err
+            }
+            if index.Sign() < 0 {
+                return nil
+            }
+            queued[mi] = &api.QueuedMinipool{
+                Address: address,
+                DepositType: depositType,
+                Position: index.Uint64() + 1,
+            }
+            return nil
+
+        })
+    }
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return []api.QueuedMinipool{}, err
+    }
+
+    // Return
+    minipools := []api.QueuedMinipool{}
+    for _, mp := range queued {
+        if mp != nil {
+            minipools = append(minipools, *mp)
+        }
+    }
+    return minipools, nil
+
+}
+
+
+// Get the number of deposit assignments to minipools over the recent assignment rate window, and the rate per day
+func getAssignmentRate(rp *rocketpool.RocketPool) (uint64, float64, error) {
+
+    // Get deposit pool contract details
+    depositPoolAddress, err := rp.GetAddress("rocketDepositPool")
+    if err != nil {
+        return 0, 0, err
+    }
+    depositPoolAbi, err := rp.GetABI("rocketDepositPool")
+    if err != nil {
+        return 0, 0, err
+    }
+    assignedEvent, ok := depositPoolAbi.Events["DepositAssigned"]
+    if !ok {
+        return 0, 0, errors.New("Could not find the deposit pool DepositAssigned event")
+    }
+
+    // Get block range
+    latestHeader, err := rp.Client.HeaderByNumber(context.Background(), nil)
+    if err != nil {
+        return 0, 0, err
+    }
+    fromBlock := big.NewInt(0)
+    if latestHeader.Number.Uint64() > assignmentRateBlocks {
+        fromBlock.SetUint64(latestHeader.Number.Uint64() - assignmentRateBlocks)
+    }
+    fromHeader, err := rp.Client.HeaderByNumber(context.Background(), fromBlock)
+    if err != nil {
+        return 0, 0, err
+    }
+
+    // Get assignment events
+    logs, err := rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+        FromBlock: fromBlock,
+        ToBlock: latestHeader.Number,
+        Addresses: []common.Address{*depositPoolAddress},
+        Topics: [][]common.Hash{[]common.Hash{assignedEvent.ID}},
+    })
+    if err != nil {
+        return 0, 0, err
+    }
+
+    // Get rate
+    count := uint64(len(logs))
+    days := float64(latestHeader.Time - fromHeader.Time) / 86400
+    if days <= 0 {
+        return count, 0, nil
+    }
+    return count, float64(count) / days, nil
+
+}
@@ -0,0 +1,123 @@
+package network
+
+import (
+    "github.com/rocket-pool/rocketpool-go/settings"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetSettings(c config.Context) (*api.NetworkSettingsResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NetworkSettingsResponse{}
+
+    // Sync
+    var wg errgroup.Group
+
+    // Get deposit settings
+    wg.Go(func() error {
+        var err error
+        response.Deposit.AssignDepositsEnabled, err = settings.GetAssignDepositsEnabled(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Deposit.MaximumDepositAssignments, err = settings.GetMaximumDepositAssignments(rp, nil)
+        return err
+    })
+
+    // Get minipool settings
+    wg.Go(func() error {
+        var err error
+        response.Minipool.FullDepositNodeAmount, err = settings.GetMinipoolFullDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Minipool.HalfDepositNodeAmount, err = settings.GetMinipoolHalfDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Minipool.EmptyDepositNodeAmount, err = settings.GetMinipoolEmptyDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Minipool.SubmitWithdrawableEnabled, err = settings.GetMinipoolSubmitWithdrawableEnabled(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Minipool.LaunchTimeout, err = settings.GetMinipoolLaunchTimeout(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Minipool.WithdrawalDelay, err = settings.GetMinipoolWithdrawalDelay(rp, nil)
+        return err
+    })
+
+    // Get network settings
+    wg.Go(func() error {
+        var err error
+        response.Network.SubmitBalancesEnabled, err = settings.GetSubmitBalancesEnabled(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Network.SubmitBalancesFrequency, err = settings.GetSubmitBalancesFrequency(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Network.ProcessWithdrawalsEnabled, err = settings.GetProcessWithdrawalsEnabled(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Network.MinimumNodeFee, err = settings.GetMinimumNodeFee(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Network.TargetNodeFee, err = settings.GetTargetNodeFee(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Network.MaximumNodeFee, err = settings.GetMaximumNodeFee(rp, nil)
+        return err
+    })
+
+    // Get node settings
+    wg.Go(func() error {
+        var err error
+        response.Node.RegistrationEnabled, err = settings.GetNodeRegistrationEnabled(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.Node.DepositEnabled, err = settings.GetNodeDepositEnabled(rp, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
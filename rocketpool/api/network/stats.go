@@ -0,0 +1,123 @@
+package network
+
+import (
+    "context"
+    "math/big"
+    "time"
+
+    "github.com/rocket-pool/rocketpool-go/deposit"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/network"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+
+func GetStats(c config.Context, blockNumber uint64) (*api.NetworkStatsResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetAnalyticsRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NetworkStatsResponse{}
+
+    // Get call options
+    opts, err := eth1.GetHistoricalCallOpts(rp.Client, blockNumber)
+    if err != nil {
+        return nil, err
+    }
+    if opts != nil {
+        header, err := rp.Client.HeaderByNumber(context.Background(), opts.BlockNumber)
+        if err != nil {
+            return nil, err
+        }
+        response.Block = blockNumber
+        response.BlockTime = time.Unix(int64(header.Time), 0)
+    }
+
+    // Sync
+    var wg errgroup.Group
+
+    // Get network balances
+    wg.Go(func() error {
+        var err error
+        response.TotalETHBalance, err = callBig(rp, opts, "rocketNetworkBalances", "getTotalETHBalance")
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.StakingETHBalance, err = callBig(rp, opts, "rocketNetworkBalances", "getStakingETHBalance")
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.RETHSupply, err = callBig(rp, opts, "rocketNetworkBalances", "getTotalRETHSupply")
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.BalancesBlock, err = network.GetBalancesBlock(rp, opts)
+        return err
+    })
+
+    // Get node & minipool counts
+    wg.Go(func() error {
+        nodeCount, err := callBig(rp, opts, "rocketNodeManager", "getNodeCount")
+        if err == nil {
+            response.NodeCount = nodeCount.Uint64()
+        }
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MinipoolCount, err = minipool.GetMinipoolCount(rp, opts)
+        return err
+    })
+
+    // Get deposit pool & minipool queue status
+    wg.Go(func() error {
+        var err error
+        response.DepositPoolBalance, err = deposit.GetBalance(rp, opts)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MinipoolQueueLength, err = minipool.GetQueueTotalLength(rp, opts)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MinipoolQueueCapacity, err = minipool.GetQueueTotalCapacity(rp, opts)
+        return err
+    })
+
+    // Get node fee
+    wg.Go(func() error {
+        var err error
+        response.NodeFee, err = network.GetNodeFee(rp, opts)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Get rETH exchange rate, as ETH per rETH at the last balances submission
+    response.RETHExchangeRate = 1
+    if response.RETHSupply.Cmp(big.NewInt(0)) > 0 {
+        response.RETHExchangeRate = eth.WeiToEth(response.TotalETHBalance) / eth.WeiToEth(response.RETHSupply)
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
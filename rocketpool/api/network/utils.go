@@ -0,0 +1,24 @@
+package network
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+
+// Call a network contract method returning a uint256
+func callBig(rp *rocketpool.RocketPool, opts *bind.CallOpts, contractName, method string, args ...interface{}) (*big.Int, error) {
+    contract, err := rp.GetContract(contractName)
+    if err != nil {
+        return nil, err
+    }
+    value := new(*big.Int)
+    if err := contract.Call(opts, value, method, args...); err != nil {
+        return nil, fmt.Errorf("Could not get %s.%s: %w", contractName, method, err)
+    }
+    return *value, nil
+}
+
@@ -4,16 +4,17 @@ import (
     "context"
     "math/big"
 
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/rocket-pool/rocketpool-go/tokens"
-    "github.com/urfave/cli"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func canNodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.CanNodeBurnResponse, error) {
+func CanNodeBurn(c config.Context, amountWei *big.Int, token string) (*api.CanNodeBurnResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
@@ -83,21 +84,21 @@ func canNodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 }
 
 
-func nodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.NodeBurnResponse, error) {
+func NodeBurn(c config.Context, amountWei *big.Int, token string) (*api.NodeBurnResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.NodeBurnResponse{}
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
@@ -107,7 +108,10 @@ func nodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.NodeBurnRe
         case "neth":
 
             // Burn nETH
-            txReceipt, err := tokens.BurnNETH(rp, amountWei, opts)
+            txReceipt, err := txm.Transact(opts, "Burn nETH", func(opts *bind.TransactOpts) error {
+                _, err := tokens.BurnNETH(rp, amountWei, opts)
+                return err
+            })
             if err != nil {
                 return nil, err
             }
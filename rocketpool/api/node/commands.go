@@ -4,6 +4,7 @@ import (
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/utils/api"
+    "github.com/rocket-pool/smartnode/shared/utils/attestation"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -19,15 +20,21 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
             cli.Command{
                 Name:      "status",
                 Aliases:   []string{"s"},
-                Usage:     "Get the node's status",
-                UsageText: "rocketpool api node status",
+                Usage:     "Get the node's status, optionally at a past block",
+                UsageText: "rocketpool api node status [block number]",
                 Action: func(c *cli.Context) error {
 
                     // Validate args
-                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+                    if err := cliutils.ValidateMaxArgCount(c, 1); err != nil { return err }
+                    var blockNumber uint64
+                    if c.NArg() == 1 {
+                        var err error
+                        blockNumber, err = cliutils.ValidateUint("block number", c.Args().Get(0))
+                        if err != nil { return err }
+                    }
 
                     // Run
-                    api.PrintResponse(getStatus(c))
+                    api.PrintResponse(GetStatus(c, blockNumber))
                     return nil
 
                 },
@@ -43,7 +50,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canRegisterNode(c))
+                    api.PrintResponse(CanRegisterNode(c))
                     return nil
 
                 },
@@ -61,7 +68,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(registerNode(c, timezoneLocation))
+                    api.PrintResponse(RegisterNode(c, timezoneLocation))
                     return nil
 
                 },
@@ -80,7 +87,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(setTimezoneLocation(c, timezoneLocation))
+                    api.PrintResponse(SetTimezoneLocation(c, timezoneLocation))
                     return nil
 
                 },
@@ -98,7 +105,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canNodeDeposit(c, amountWei))
+                    api.PrintResponse(CanNodeDeposit(c, amountWei))
                     return nil
 
                 },
@@ -118,7 +125,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(nodeDeposit(c, amountWei, minNodeFee))
+                    api.PrintResponse(NodeDeposit(c, amountWei, minNodeFee))
                     return nil
 
                 },
@@ -127,18 +134,20 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
             cli.Command{
                 Name:      "can-send",
                 Usage:     "Check whether the node can send ETH or tokens to an address",
-                UsageText: "rocketpool api node can-send amount token",
+                UsageText: "rocketpool api node can-send amount token to",
                 Action: func(c *cli.Context) error {
 
                     // Validate args
-                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    if err := cliutils.ValidateArgCount(c, 3); err != nil { return err }
                     amountWei, err := cliutils.ValidatePositiveWeiAmount("send amount", c.Args().Get(0))
                     if err != nil { return err }
                     token, err := cliutils.ValidateTokenType("token type", c.Args().Get(1))
                     if err != nil { return err }
+                    toAddress, err := cliutils.ValidateAddress("to address", c.Args().Get(2))
+                    if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canNodeSend(c, amountWei, token))
+                    api.PrintResponse(CanNodeSend(c, amountWei, token, toAddress))
                     return nil
 
                 },
@@ -160,7 +169,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(nodeSend(c, amountWei, token, toAddress))
+                    api.PrintResponse(NodeSend(c, amountWei, token, toAddress))
                     return nil
 
                 },
@@ -180,7 +189,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canNodeBurn(c, amountWei, token))
+                    api.PrintResponse(CanNodeBurn(c, amountWei, token))
                     return nil
 
                 },
@@ -200,7 +209,94 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(nodeBurn(c, amountWei, token))
+                    api.PrintResponse(NodeBurn(c, amountWei, token))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "rewards",
+                Aliases:   []string{"w"},
+                Usage:     "Get the node's rewards claim history",
+                UsageText: "rocketpool api node rewards",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetRewards(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "tx-queue",
+                Aliases:   []string{"q"},
+                Usage:     "Get the node's pending transactions",
+                UsageText: "rocketpool api node tx-queue",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetTxQueue(c))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "cancel-tx",
+                Usage:     "Cancel a pending node transaction",
+                UsageText: "rocketpool api node cancel-tx nonce",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(CancelTransaction(c, nonce))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "prove-ownership",
+                Aliases:   []string{"p"},
+                Usage:     "Sign an attestation that the node controls its account, for a third-party challenge",
+                UsageText: "rocketpool api node prove-ownership challenge",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    if err := attestation.ValidateChallenge(c.Args().Get(0)); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(ProveOwnership(c, c.Args().Get(0)))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "diff",
+                Usage:     "Get the changes to the node's state since a recorded snapshot",
+                UsageText: "rocketpool api node diff since",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    since, err := cliutils.ValidateAge("since", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetDiff(c, since))
                     return nil
 
                 },
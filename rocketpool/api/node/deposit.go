@@ -5,15 +5,18 @@ import (
     "errors"
     "math/big"
 
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/node"
     "github.com/rocket-pool/rocketpool-go/settings"
     "github.com/rocket-pool/rocketpool-go/utils/contract"
-    "github.com/urfave/cli"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
 
 
@@ -24,10 +27,12 @@ type minipoolCreated struct {
 }
 
 
-func canNodeDeposit(c *cli.Context, amountWei *big.Int) (*api.CanNodeDepositResponse, error) {
+func CanNodeDeposit(c config.Context, amountWei *big.Int) (*api.CanNodeDepositResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
     ec, err := services.GetEthClient(c)
@@ -82,36 +87,58 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int) (*api.CanNodeDepositResp
         return nil, err
     }
 
-    // Update & return response
+    // Update response
     response.CanDeposit = !(response.InsufficientBalance || response.InvalidAmount || response.DepositDisabled)
+
+    // Estimate gas; the minimum node fee does not affect gas usage, so estimate with no minimum
+    if response.CanDeposit {
+        gasPrice, err := services.GetGasPrice(c)
+        if err != nil {
+            return nil, err
+        }
+        gasInfo, err := eth1.EstimateContractGas(rp, gasPrice, "rocketNodeDeposit", nodeAccount.Address, amountWei, "deposit", big.NewInt(0))
+        if err != nil {
+            return nil, err
+        }
+        if cfg.Smartnode.MaxFee > 0 {
+            gasInfo.MaxGasPrice = eth.GweiToWei(cfg.Smartnode.MaxFee)
+        }
+        gasInfo.GasToken = cfg.GetGasToken()
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
     return &response, nil
 
 }
 
 
-func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64) (*api.NodeDepositResponse, error) {
+func NodeDeposit(c config.Context, amountWei *big.Int, minNodeFee float64) (*api.NodeDepositResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     ec, err := services.GetEthClient(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.NodeDepositResponse{}
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
     opts.Value = amountWei
 
     // Deposit
-    txReceipt, err := node.Deposit(rp, minNodeFee, opts)
+    txReceipt, err := txm.Transact(opts, "Make node deposit", func(opts *bind.TransactOpts) error {
+        _, err := node.Deposit(rp, minNodeFee, opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
@@ -0,0 +1,65 @@
+package node
+
+import (
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/snapshots"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetDiff(c config.Context, since time.Duration) (*api.NodeDiffResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    mc, err := services.GetMulticall(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodeDiffResponse{
+        Changes: []snapshots.Change{},
+    }
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Get recorded snapshot; the oldest snapshot is used if none were recorded before the requested time
+    recorded, err := snapshots.Get(cfg.GetDataPath())
+    if err != nil {
+        return nil, err
+    }
+    snapshot, ok := snapshots.GetAt(recorded, time.Now().Add(-since))
+    if !ok {
+        return &response, nil
+    }
+    response.HasSnapshot = true
+    response.SnapshotTime = snapshot.Time
+    response.SnapshotBlock = snapshot.Block
+
+    // Collect current snapshot
+    current, err := snapshots.Collect(rp, mc, nodeAccount.Address, &cfg)
+    if err != nil {
+        return nil, err
+    }
+    response.CurrentTime = current.Time
+    response.CurrentBlock = current.Block
+
+    // Get changes
+    response.Changes = snapshots.Diff(&snapshot, &current)
+
+    // Return response
+    return &response, nil
+
+}
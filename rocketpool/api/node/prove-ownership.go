@@ -0,0 +1,43 @@
+package node
+
+import (
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared"
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/attestation"
+)
+
+
+func ProveOwnership(c config.Context, challenge string) (*api.ProveNodeOwnershipResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ProveNodeOwnershipResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Create & sign attestation
+    response.Attestation, err = attestation.New(nodeAccount.Address, challenge, shared.RocketPoolVersion, time.Now())
+    if err != nil {
+        return nil, err
+    }
+    response.Attestation.Signature, err = w.SignNodeMessage([]byte(response.Attestation.Message))
+    if err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -1,17 +1,18 @@
 package node
 
 import (
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/rocket-pool/rocketpool-go/node"
     "github.com/rocket-pool/rocketpool-go/settings"
-    "github.com/urfave/cli"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func canRegisterNode(c *cli.Context) (*api.CanRegisterNodeResponse, error) {
+func CanRegisterNode(c config.Context) (*api.CanRegisterNodeResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
@@ -62,27 +63,30 @@ func canRegisterNode(c *cli.Context) (*api.CanRegisterNodeResponse, error) {
 }
 
 
-func registerNode(c *cli.Context, timezoneLocation string) (*api.RegisterNodeResponse, error) {
+func RegisterNode(c config.Context, timezoneLocation string) (*api.RegisterNodeResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.RegisterNodeResponse{}
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
 
     // Register node
-    txReceipt, err := node.RegisterNode(rp, timezoneLocation, opts)
+    txReceipt, err := txm.Transact(opts, "Register node", func(opts *bind.TransactOpts) error {
+        _, err := node.RegisterNode(rp, timezoneLocation, opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
@@ -0,0 +1,55 @@
+package node
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/rewards"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
+)
+
+
+func GetRewards(c config.Context) (*api.NodeRewardsResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodeRewardsResponse{
+        AutoWithdrawEnabled: !cfg.Smartnode.AutoWithdrawDisabled,
+        AutoWithdrawMaxGasPrice: cfg.Smartnode.AutoWithdrawMaxGasPrice,
+    }
+
+    // Get rewards claims
+    claims, err := rewards.GetClaims(cfg.GetDataPath())
+    if err != nil {
+        return nil, err
+    }
+
+    // Add claims to response
+    response.Claims = make([]api.RewardsClaim, len(claims))
+    response.TotalAmount = big.NewInt(0)
+    for ci, claim := range claims {
+        response.Claims[ci] = api.RewardsClaim{
+            Time: claim.Time,
+            MinipoolAddress: claim.MinipoolAddress,
+            Amount: claim.Amount,
+            TxHash: claim.TxHash,
+            Automatic: claim.Automatic,
+        }
+        if claim.Amount != nil {
+            response.TotalAmount, err = safemath.Add(response.TotalAmount, claim.Amount)
+            if err != nil {
+                return nil, fmt.Errorf("Could not calculate total rewards claimed: %w", err)
+            }
+        }
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -2,23 +2,30 @@ package node
 
 import (
     "context"
+    "fmt"
     "math/big"
 
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
     "github.com/rocket-pool/rocketpool-go/tokens"
     "github.com/rocket-pool/rocketpool-go/utils/eth"
-    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
 
 
-func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNodeSendResponse, error) {
+func CanNodeSend(c config.Context, amountWei *big.Int, token string, to common.Address) (*api.CanNodeSendResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
     ec, err := services.GetEthClient(c)
@@ -55,32 +62,76 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
             }
             response.InsufficientBalance = (amountWei.Cmp(nethBalanceWei) > 0)
 
+        case "reth":
+
+            // Check node rETH balance
+            rethBalanceWei, err := getRETHBalance(rp, nodeAccount.Address)
+            if err != nil {
+                return nil, err
+            }
+            response.InsufficientBalance = (amountWei.Cmp(rethBalanceWei) > 0)
+
     }
 
-    // Update & return response
+    // Update response
     response.CanSend = !response.InsufficientBalance
+
+    // Estimate gas
+    if response.CanSend {
+        gasPrice, err := services.GetGasPrice(c)
+        if err != nil {
+            return nil, err
+        }
+        var gasInfo api.GasInfo
+        switch token {
+            case "eth":
+                gasLimit, err := ec.EstimateGas(context.Background(), ethereum.CallMsg{
+                    From: nodeAccount.Address,
+                    To: &to,
+                    Value: amountWei,
+                })
+                if err != nil {
+                    return nil, fmt.Errorf("Could not estimate gas for ETH transfer: %w", err)
+                }
+                gasInfo = api.GasInfo{EstGasLimit: gasLimit, GasPrice: gasPrice}
+            case "neth":
+                gasInfo, err = eth1.EstimateContractGas(rp, gasPrice, "rocketNodeETHToken", nodeAccount.Address, nil, "transfer", to, amountWei)
+            case "reth":
+                gasInfo, err = eth1.EstimateContractGas(rp, gasPrice, "rocketETHToken", nodeAccount.Address, nil, "transfer", to, amountWei)
+        }
+        if err != nil {
+            return nil, err
+        }
+        if cfg.Smartnode.MaxFee > 0 {
+            gasInfo.MaxGasPrice = eth.GweiToWei(cfg.Smartnode.MaxFee)
+        }
+        gasInfo.GasToken = cfg.GetGasToken()
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
     return &response, nil
 
 }
 
 
-func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Address) (*api.NodeSendResponse, error) {
+func NodeSend(c config.Context, amountWei *big.Int, token string, to common.Address) (*api.NodeSendResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     ec, err := services.GetEthClient(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.NodeSendResponse{}
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
@@ -91,7 +142,10 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 
             // Transfer ETH
             opts.Value = amountWei
-            txReceipt, err := eth.SendTransaction(ec, to, opts)
+            txReceipt, err := txm.Transact(opts, fmt.Sprintf("Send ETH to %s", to.Hex()), func(opts *bind.TransactOpts) error {
+                _, err := eth.SendTransaction(ec, to, opts)
+                return err
+            })
             if err != nil {
                 return nil, err
             }
@@ -100,10 +154,29 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
         case "neth":
 
             // Transfer nETH
-            txReceipt, err := tokens.TransferNETH(rp, to, amountWei, opts)
+            txReceipt, err := txm.Transact(opts, fmt.Sprintf("Send nETH to %s", to.Hex()), func(opts *bind.TransactOpts) error {
+                _, err := tokens.TransferNETH(rp, to, amountWei, opts)
+                return err
+            })
+            if err != nil {
+                return nil, err
+            }
+            response.TxHash = txReceipt.TxHash
+
+        case "reth":
+
+            // Transfer rETH
+            rethToken, err := rp.GetContract("rocketETHToken")
             if err != nil {
                 return nil, err
             }
+            txReceipt, err := txm.Transact(opts, fmt.Sprintf("Send rETH to %s", to.Hex()), func(opts *bind.TransactOpts) error {
+                _, err := rethToken.Transact(opts, "transfer", to, amountWei)
+                return err
+            })
+            if err != nil {
+                return nil, fmt.Errorf("Could not transfer rETH to %s: %w", to.Hex(), err)
+            }
             response.TxHash = txReceipt.TxHash
 
     }
@@ -113,3 +186,17 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 
 }
 
+
+// Get an address's rETH balance
+func getRETHBalance(rp *rocketpool.RocketPool, address common.Address) (*big.Int, error) {
+    rethToken, err := rp.GetContract("rocketETHToken")
+    if err != nil {
+        return nil, err
+    }
+    balance := new(*big.Int)
+    if err := rethToken.Call(nil, balance, "balanceOf", address); err != nil {
+        return nil, fmt.Errorf("Could not get rETH balance of %s: %w", address.Hex(), err)
+    }
+    return *balance, nil
+}
+
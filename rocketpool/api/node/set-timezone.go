@@ -1,34 +1,38 @@
 package node
 
 import (
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/rocket-pool/rocketpool-go/node"
-    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func setTimezoneLocation(c *cli.Context, timezoneLocation string) (*api.SetNodeTimezoneResponse, error) {
+func SetTimezoneLocation(c config.Context, timezoneLocation string) (*api.SetNodeTimezoneResponse, error) {
 
     // Get services
     if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.SetNodeTimezoneResponse{}
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
 
     // Set timezone location
-    txReceipt, err := node.SetTimezoneLocation(rp, timezoneLocation, opts)
+    txReceipt, err := txm.Transact(opts, "Set node timezone location", func(opts *bind.TransactOpts) error {
+        _, err := node.SetTimezoneLocation(rp, timezoneLocation, opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
@@ -1,18 +1,30 @@
 package node
 
 import (
+    "context"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/node"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
     "github.com/rocket-pool/rocketpool-go/tokens"
     "github.com/rocket-pool/rocketpool-go/types"
-    "github.com/urfave/cli"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/actions"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/diskspace"
+    "github.com/rocket-pool/smartnode/shared/services/ens"
+    "github.com/rocket-pool/smartnode/shared/services/rewards"
+    "github.com/rocket-pool/smartnode/shared/services/security"
     "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
 
 
-func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
+func GetStatus(c config.Context, blockNumber uint64) (*api.NodeStatusResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
@@ -21,6 +33,8 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    mc, err := services.GetMulticall(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.NodeStatusResponse{}
@@ -32,12 +46,27 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
     }
     response.AccountAddress = nodeAccount.Address
 
+    // Get call options
+    opts, err := eth1.GetHistoricalCallOpts(rp.Client, blockNumber)
+    if err != nil {
+        return nil, err
+    }
+    if opts != nil {
+        header, err := rp.Client.HeaderByNumber(context.Background(), opts.BlockNumber)
+        if err != nil {
+            return nil, err
+        }
+        response.Block = blockNumber
+        response.BlockTime = time.Unix(int64(header.Time), 0)
+    }
+
     // Sync
     var wg errgroup.Group
+    var minipoolDetails []minipoolCountDetails
 
     // Get node details
     wg.Go(func() error {
-        details, err := node.GetNodeDetails(rp, nodeAccount.Address, nil)
+        details, err := node.GetNodeDetails(rp, nodeAccount.Address, opts)
         if err == nil {
             response.Registered = details.Exists
             response.Trusted = details.Trusted
@@ -46,17 +75,26 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
         return err
     })
 
+    // Get node account ENS name; ENS being unavailable does not prevent the status from loading
+    wg.Go(func() error {
+        if name, err := ens.LookupAddress(rp.Client, nodeAccount.Address); err == nil {
+            response.AccountAddressName = name
+        }
+        return nil
+    })
+
     // Get node balances
     wg.Go(func() error {
         var err error
-        response.Balances, err = tokens.GetBalances(rp, nodeAccount.Address, nil)
+        response.Balances, err = tokens.GetBalances(rp, nodeAccount.Address, opts)
         return err
     })
 
     // Get node minipool counts
     wg.Go(func() error {
-        details, err := getNodeMinipoolCountDetails(rp, nodeAccount.Address)
+        details, err := getNodeMinipoolCountDetails(rp, mc, nodeAccount.Address, opts)
         if err == nil {
+            minipoolDetails = details
             response.MinipoolCounts.Total = len(details)
             for _, mpDetails := range details {
                 switch mpDetails.Status {
@@ -80,13 +118,152 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
         return err
     })
 
+    // Get active validator balances; the beacon chain being unavailable does not prevent the status from loading
+    // Beacon chain balances are only loaded for the current state
+    wg.Go(func() error {
+        if opts != nil {
+            response.Validators.Error = "Validator balances are not available for past blocks"
+            return nil
+        }
+        bc, err := services.GetBeaconClient(c)
+        if err == nil {
+            response.Validators.Active, response.Validators.Balance, err = getNodeValidatorBalances(rp, bc, nodeAccount.Address)
+        }
+        if err != nil {
+            response.Validators.Error = err.Error()
+        }
+        return nil
+    })
+
+    // Get Eth 1.0 sync status
+    wg.Go(func() error {
+        progress, err := rp.Client.SyncProgress(context.Background())
+        if err != nil {
+            response.Sync.Eth1Error = err.Error()
+        } else {
+            response.Sync.Eth1Synced = (progress == nil)
+        }
+        return nil
+    })
+
+    // Get Eth 2.0 sync status
+    wg.Go(func() error {
+        bc, err := services.GetBeaconClient(c)
+        if err != nil {
+            response.Sync.Eth2Error = err.Error()
+            return nil
+        }
+        syncStatus, err := bc.GetSyncStatus()
+        if err != nil {
+            response.Sync.Eth2Error = err.Error()
+        } else {
+            response.Sync.Eth2Synced = !syncStatus.Syncing
+        }
+        return nil
+    })
+
     // Wait for data
     if err := wg.Wait(); err != nil {
         return nil, err
     }
 
+    // Get action items & disk space prediction for the current state
+    if opts == nil {
+        if err := getDiskSpace(c, &response); err != nil {
+            return nil, err
+        }
+        response.Actions, err = getActionItems(c, rp, nodeAccount.Address, &response, minipoolDetails)
+        if err != nil {
+            return nil, err
+        }
+    }
+
     // Return response
     return &response, nil
 
 }
 
+
+// Get the node's action items from its status
+// Sync status errors are reported by the status itself, so clients are only treated as unsynced when their status was checked
+func getActionItems(c config.Context, rp *rocketpool.RocketPool, nodeAddress common.Address, status *api.NodeStatusResponse, minipoolDetails []minipoolCountDetails) ([]api.NodeActionItem, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Build node state
+    state := actions.State{
+        Registered: status.Registered,
+        Balances: status.Balances,
+        Minipools: make([]actions.MinipoolState, len(minipoolDetails)),
+        Eth1Synced: (status.Sync.Eth1Synced || status.Sync.Eth1Error != ""),
+        Eth2Synced: (status.Sync.Eth2Synced || status.Sync.Eth2Error != ""),
+        AutoWithdrawEnabled: !cfg.Smartnode.AutoWithdrawDisabled,
+        DiskDaysUntilFull: -1,
+        DiskFullDaysAlert: cfg.GetAlertDiskFullDays(),
+    }
+    if status.DiskSpace.GrowthKnown {
+        state.DiskDaysUntilFull = status.DiskSpace.DaysUntilFull
+    }
+    review, err := security.LoadReview(cfg.GetDataPath())
+    if err != nil { return nil, err }
+    if review != nil {
+        state.SecurityReviewed = true
+        state.SecurityWarnings = review.Warnings()
+    }
+    for mi, details := range minipoolDetails {
+        state.Minipools[mi] = actions.MinipoolState{
+            Address: details.Address,
+            Status: details.Status,
+            RefundAvailable: details.RefundAvailable,
+            WithdrawalAvailable: details.WithdrawalAvailable,
+            CloseAvailable: details.CloseAvailable,
+        }
+    }
+
+    // Check whether available withdrawals are worth their gas cost; withdrawals which cannot be simulated are assumed to be
+    var gasPrice *big.Int
+    for mi := range state.Minipools {
+        mp := &state.Minipools[mi]
+        if !mp.WithdrawalAvailable {
+            continue
+        }
+        if gasPrice == nil {
+            if gasPrice, err = services.GetGasPrice(c); err != nil {
+                break
+            }
+        }
+        simulation, err := rewards.SimulateClaim(rp, mp.Address, nodeAddress, gasPrice)
+        if err != nil {
+            continue
+        }
+        if meetsMinRatio, err := simulation.MeetsMinRatio(cfg.GetMinClaimGasRatio()); err == nil {
+            mp.BelowMinClaimGasRatio = !meetsMinRatio
+        }
+    }
+
+    // Return
+    return actions.Get(&state), nil
+
+}
+
+
+// Get the node's disk space prediction, recorded by the node daemon
+func getDiskSpace(c config.Context, status *api.NodeStatusResponse) error {
+    cfg, err := services.GetConfig(c)
+    if err != nil { return err }
+    history, err := diskspace.LoadHistory(cfg.GetDataPath())
+    if err != nil { return err }
+    prediction, ok := history.Predict()
+    if !ok {
+        return nil
+    }
+    status.DiskSpace.Available = prediction.Available
+    status.DiskSpace.Total = prediction.Total
+    status.DiskSpace.GrowthKnown = prediction.GrowthKnown
+    status.DiskSpace.GrowthPerDay = prediction.GrowthPerDay
+    status.DiskSpace.DaysUntilFull = prediction.DaysUntilFull
+    return nil
+}
+
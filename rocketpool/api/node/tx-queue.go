@@ -0,0 +1,86 @@
+package node
+
+import (
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetTxQueue(c config.Context) (*api.NodeTxQueueResponse, error) {
+
+    // Get services
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodeTxQueueResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Get pending transactions
+    pending, err := txm.GetPendingTransactions()
+    if err != nil {
+        return nil, err
+    }
+
+    // Add node account transactions to response
+    response.Transactions = []api.PendingTransaction{}
+    for _, pt := range pending {
+        if pt.From != nodeAccount.Address {
+            continue
+        }
+        response.Transactions = append(response.Transactions, api.PendingTransaction{
+            Description: pt.Description,
+            Nonce: pt.Nonce,
+            TxHash: pt.LatestHash(),
+            To: pt.To,
+            Value: pt.Value,
+            GasPrice: pt.GasPrice,
+            Created: pt.Created,
+            LastBroadcast: pt.LastBroadcast,
+            Replacements: len(pt.Hashes) - 1,
+            Cancelled: pt.Cancelled,
+        })
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func CancelTransaction(c config.Context, nonce uint64) (*api.CancelNodeTransactionResponse, error) {
+
+    // Get services
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.CancelNodeTransactionResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Cancel transaction
+    txHash, err := txm.CancelTransaction(nodeAccount.Address, nonce)
+    if err != nil {
+        return nil, err
+    }
+    response.TxHash = txHash
+
+    // Return response
+    return &response, nil
+
+}
@@ -2,19 +2,27 @@ package node
 
 import (
     "context"
+    "fmt"
     "math/big"
 
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
     "github.com/rocket-pool/rocketpool-go/rocketpool"
     "github.com/rocket-pool/rocketpool-go/settings"
     "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
     "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/multicall"
 )
 
 
 // Minipool count details
 type minipoolCountDetails struct {
+    Address common.Address
     Status types.MinipoolStatus
     RefundAvailable bool
     WithdrawalAvailable bool
@@ -23,24 +31,30 @@ type minipoolCountDetails struct {
 
 
 // Get all node minipool count details
-func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.Address) ([]minipoolCountDetails, error) {
+// Minipool details are loaded in a single batch of calls, at the call options block if set
+func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, mc *multicall.Client, nodeAddress common.Address, opts *bind.CallOpts) ([]minipoolCountDetails, error) {
 
     // Data
-    var wg1 errgroup.Group
+    var wg errgroup.Group
     var addresses []common.Address
     var currentBlock uint64
     var withdrawalDelay uint64
+    var minipoolABI *abi.ABI
 
     // Get minipool addresses
-    wg1.Go(func() error {
+    wg.Go(func() error {
         var err error
-        addresses, err = minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+        addresses, err = minipool.GetNodeMinipoolAddresses(rp, nodeAddress, opts)
         return err
     })
 
     // Get current block
-    wg1.Go(func() error {
-        header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+    var blockNumber *big.Int
+    if opts != nil {
+        blockNumber = opts.BlockNumber
+    }
+    wg.Go(func() error {
+        header, err := rp.Client.HeaderByNumber(context.Background(), blockNumber)
         if err == nil {
             currentBlock = header.Number.Uint64()
         }
@@ -48,34 +62,55 @@ func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.A
     })
 
     // Get withdrawal delay
-    wg1.Go(func() error {
+    wg.Go(func() error {
         var err error
-        withdrawalDelay, err = settings.GetMinipoolWithdrawalDelay(rp, nil)
+        withdrawalDelay, err = settings.GetMinipoolWithdrawalDelay(rp, opts)
+        return err
+    })
+
+    // Get minipool ABI
+    wg.Go(func() error {
+        var err error
+        minipoolABI, err = rp.GetABI("rocketMinipool")
         return err
     })
 
     // Wait for data
-    if err := wg1.Wait(); err != nil {
+    if err := wg.Wait(); err != nil {
         return []minipoolCountDetails{}, err
     }
 
-    // Data
-    var wg2 errgroup.Group
-    details := make([]minipoolCountDetails, len(addresses))
-
-    // Load details
+    // Load minipool details
+    statuses := make([]uint8, len(addresses))
+    statusBlocks := make([]*big.Int, len(addresses))
+    refundBalances := make([]*big.Int, len(addresses))
+    batch := mc.NewBatchAt(blockNumber)
     for mi, address := range addresses {
-        mi, address := mi, address
-        wg2.Go(func() error {
-            mpDetails, err := getMinipoolCountDetails(rp, address, currentBlock, withdrawalDelay)
-            if err == nil { details[mi] = mpDetails }
-            return err
-        })
+        if err := batch.AddCall(address, minipoolABI, &statuses[mi], "getStatus"); err != nil {
+            return []minipoolCountDetails{}, err
+        }
+        if err := batch.AddCall(address, minipoolABI, &statusBlocks[mi], "getStatusBlock"); err != nil {
+            return []minipoolCountDetails{}, err
+        }
+        if err := batch.AddCall(address, minipoolABI, &refundBalances[mi], "getNodeRefundBalance"); err != nil {
+            return []minipoolCountDetails{}, err
+        }
+    }
+    if err := batch.Execute(); err != nil {
+        return []minipoolCountDetails{}, fmt.Errorf("Could not get minipool details: %w", err)
     }
 
-    // Wait for data
-    if err := wg2.Wait(); err != nil {
-        return []minipoolCountDetails{}, err
+    // Get count details
+    details := make([]minipoolCountDetails, len(addresses))
+    for mi := range addresses {
+        status := types.MinipoolStatus(statuses[mi])
+        details[mi] = minipoolCountDetails{
+            Address: addresses[mi],
+            Status: status,
+            RefundAvailable: (refundBalances[mi].Cmp(big.NewInt(0)) > 0),
+            WithdrawalAvailable: (status == types.Withdrawable && (currentBlock - statusBlocks[mi].Uint64()) >= withdrawalDelay),
+            CloseAvailable: (status == types.Dissolved),
+        }
     }
 
     // Return
@@ -84,50 +119,70 @@ func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.A
 }
 
 
-// Get a minipool's count details
-func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, currentBlock, withdrawalDelay uint64) (minipoolCountDetails, error) {
-
-    // Create minipool
-    mp, err := minipool.NewMinipool(rp, minipoolAddress)
-    if err != nil {
-        return minipoolCountDetails{}, err
-    }
+// Get the number of active validators for a node's minipools, and their total balance on the beacon chain
+func getNodeValidatorBalances(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address) (int, *big.Int, error) {
 
     // Data
-    var wg errgroup.Group
-    var status types.MinipoolStatus
-    var statusBlock uint64
-    var refundBalance *big.Int
+    var wg1 errgroup.Group
+    var addresses []common.Address
+    var currentEpoch uint64
 
-    // Load data
-    wg.Go(func() error {
-        var err error
-        status, err = mp.GetStatus(nil)
-        return err
-    })
-    wg.Go(func() error {
+    // Get minipool addresses
+    wg1.Go(func() error {
         var err error
-        statusBlock, err = mp.GetStatusBlock(nil)
+        addresses, err = minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
         return err
     })
-    wg.Go(func() error {
-        var err error
-        refundBalance, err = mp.GetNodeRefundBalance(nil)
+
+    // Get current epoch
+    wg1.Go(func() error {
+        head, err := bc.GetBeaconHead()
+        if err == nil {
+            currentEpoch = head.Epoch
+        }
         return err
     })
 
     // Wait for data
-    if err := wg.Wait(); err != nil {
-        return minipoolCountDetails{}, err
+    if err := wg1.Wait(); err != nil {
+        return 0, nil, err
+    }
+
+    // Data
+    var wg2 errgroup.Group
+    validators := make([]beacon.ValidatorStatus, len(addresses))
+
+    // Load validator statuses
+    for mi, address := range addresses {
+        mi, address := mi, address
+        wg2.Go(func() error {
+            pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+            if err != nil {
+                return err
+            }
+            validator, err := bc.GetValidatorStatus(pubkey, nil)
+            if err == nil { validators[mi] = validator }
+            return err
+        })
+    }
+
+    // Wait for data
+    if err := wg2.Wait(); err != nil {
+        return 0, nil, err
+    }
+
+    // Get active validator balances
+    active := 0
+    balance := big.NewInt(0)
+    for _, validator := range validators {
+        if !validator.Exists || validator.ActivationEpoch > currentEpoch || validator.ExitEpoch <= currentEpoch {
+            continue
+        }
+        active++
+        balance.Add(balance, eth.GweiToWei(float64(validator.Balance)))
     }
 
     // Return
-    return minipoolCountDetails{
-        Status: status,
-        RefundAvailable: (refundBalance.Cmp(big.NewInt(0)) > 0),
-        WithdrawalAvailable: (status == types.Withdrawable && (currentBlock - statusBlock) >= withdrawalDelay),
-        CloseAvailable: (status == types.Dissolved),
-    }, nil
+    return active, balance, nil
 
 }
-
@@ -27,7 +27,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(getStatus(c))
+                    api.PrintResponse(GetStatus(c))
                     return nil
 
                 },
@@ -43,7 +43,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(canProcessQueue(c))
+                    api.PrintResponse(CanProcessQueue(c))
                     return nil
 
                 },
@@ -59,7 +59,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(processQueue(c))
+                    api.PrintResponse(ProcessQueue(c))
                     return nil
 
                 },
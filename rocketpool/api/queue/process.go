@@ -3,18 +3,19 @@ package queue
 import (
     "math/big"
 
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/rocket-pool/rocketpool-go/deposit"
     "github.com/rocket-pool/rocketpool-go/minipool"
     "github.com/rocket-pool/rocketpool-go/settings"
-    "github.com/urfave/cli"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func canProcessQueue(c *cli.Context) (*api.CanProcessQueueResponse, error) {
+func CanProcessQueue(c config.Context) (*api.CanProcessQueueResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
@@ -69,27 +70,30 @@ func canProcessQueue(c *cli.Context) (*api.CanProcessQueueResponse, error) {
 }
 
 
-func processQueue(c *cli.Context) (*api.ProcessQueueResponse, error) {
+func ProcessQueue(c config.Context) (*api.ProcessQueueResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
 
     // Response
     response := api.ProcessQueueResponse{}
 
     // Get transactor
-    opts, err := w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(c)
     if err != nil {
         return nil, err
     }
 
     // Process queue
-    txReceipt, err := deposit.AssignDeposits(rp, opts)
+    txReceipt, err := txm.Transact(opts, "Process deposit pool", func(opts *bind.TransactOpts) error {
+        _, err := deposit.AssignDeposits(rp, opts)
+        return err
+    })
     if err != nil {
         return nil, err
     }
@@ -3,15 +3,15 @@ package queue
 import (
     "github.com/rocket-pool/rocketpool-go/deposit"
     "github.com/rocket-pool/rocketpool-go/minipool"
-    "github.com/urfave/cli"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func getStatus(c *cli.Context) (*api.QueueStatusResponse, error) {
+func GetStatus(c config.Context) (*api.QueueStatusResponse, error) {
 
     // Get services
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
@@ -0,0 +1,147 @@
+package reth
+
+import (
+    "context"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+
+func CanBurn(c config.Context, amountWei *big.Int) (*api.CanRETHBurnResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.CanRETHBurnResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Sync
+    var wg errgroup.Group
+    var rethBalanceWei *big.Int
+    var rate exchangeRate
+
+    // Get node rETH balance
+    wg.Go(func() error {
+        var err error
+        rethBalanceWei, err = getRETHBalance(rp, nodeAccount.Address)
+        return err
+    })
+
+    // Get rETH contract collateral
+    wg.Go(func() error {
+        rethContractAddress, err := rp.GetAddress("rocketETHToken")
+        if err != nil {
+            return err
+        }
+        response.Collateral, err = ec.BalanceAt(context.Background(), *rethContractAddress, nil)
+        return err
+    })
+
+    // Get exchange rate
+    wg.Go(func() error {
+        var err error
+        rate, err = getExchangeRate(rp)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Update response
+    response.ExchangeRate = rate.ETHPerRETH()
+    response.ExpectedETH, err = rate.ETHValue(amountWei)
+    if err != nil {
+        return nil, err
+    }
+    response.InsufficientBalance = (amountWei.Cmp(rethBalanceWei) > 0)
+    response.InsufficientCollateral = (response.ExpectedETH.Cmp(response.Collateral) > 0)
+    response.CanBurn = !(response.InsufficientBalance || response.InsufficientCollateral)
+
+    // Estimate gas
+    if response.CanBurn {
+        gasPrice, err := services.GetGasPrice(c)
+        if err != nil {
+            return nil, err
+        }
+        gasInfo, err := eth1.EstimateContractGas(rp, gasPrice, "rocketETHToken", nodeAccount.Address, nil, "burn", amountWei)
+        if err != nil {
+            return nil, err
+        }
+        if cfg.Smartnode.MaxFee > 0 {
+            gasInfo.MaxGasPrice = eth.GweiToWei(cfg.Smartnode.MaxFee)
+        }
+        gasInfo.GasToken = cfg.GetGasToken()
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func Burn(c config.Context, amountWei *big.Int) (*api.RETHBurnResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.RETHBurnResponse{}
+
+    // Get rETH contract
+    rethToken, err := rp.GetContract("rocketETHToken")
+    if err != nil {
+        return nil, err
+    }
+
+    // Get transactor
+    opts, err := services.GetNodeAccountTransactor(c)
+    if err != nil {
+        return nil, err
+    }
+
+    // Burn rETH
+    txReceipt, err := txm.Transact(opts, "Burn rETH", func(opts *bind.TransactOpts) error {
+        _, err := rethToken.Transact(opts, "burn", amountWei)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+    response.TxHash = txReceipt.TxHash
+
+    // Return response
+    return &response, nil
+
+}
+
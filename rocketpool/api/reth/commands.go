@@ -0,0 +1,94 @@
+package reth
+
+import (
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/utils/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+    command.Subcommands = append(command.Subcommands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Mint and burn rETH",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "can-deposit",
+                Usage:     "Check whether the node can deposit ETH into the deposit pool for rETH",
+                UsageText: "rocketpool api reth can-deposit amount",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    amountWei, err := cliutils.ValidatePositiveWeiAmount("deposit amount", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(CanDeposit(c, amountWei))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "deposit",
+                Aliases:   []string{"d"},
+                Usage:     "Deposit ETH into the deposit pool for rETH",
+                UsageText: "rocketpool api reth deposit amount",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    amountWei, err := cliutils.ValidatePositiveWeiAmount("deposit amount", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(Deposit(c, amountWei))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "can-burn",
+                Usage:     "Check whether the node can burn rETH for ETH",
+                UsageText: "rocketpool api reth can-burn amount",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    amountWei, err := cliutils.ValidatePositiveWeiAmount("burn amount", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(CanBurn(c, amountWei))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "burn",
+                Aliases:   []string{"b"},
+                Usage:     "Burn rETH for ETH",
+                UsageText: "rocketpool api reth burn amount",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    amountWei, err := cliutils.ValidatePositiveWeiAmount("burn amount", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(Burn(c, amountWei))
+                    return nil
+
+                },
+            },
+
+        },
+    })
+}
+
@@ -0,0 +1,160 @@
+package reth
+
+import (
+    "context"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/rocket-pool/rocketpool-go/deposit"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+
+func CanDeposit(c config.Context, amountWei *big.Int) (*api.CanRETHDepositResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.CanRETHDepositResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Sync
+    var wg errgroup.Group
+    var rate exchangeRate
+
+    // Check node balance
+    wg.Go(func() error {
+        ethBalanceWei, err := ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
+        if err == nil {
+            response.InsufficientBalance = (amountWei.Cmp(ethBalanceWei) > 0)
+        }
+        return err
+    })
+
+    // Check deposit settings
+    wg.Go(func() error {
+        depositEnabled, err := callBool(rp, "rocketDepositSettings", "getDepositEnabled")
+        if err == nil {
+            response.DepositDisabled = !depositEnabled
+        }
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MinimumDeposit, err = callBig(rp, "rocketDepositSettings", "getMinimumDeposit")
+        return err
+    })
+
+    // Get deposit pool balance
+    wg.Go(func() error {
+        var err error
+        response.DepositPoolBalance, err = deposit.GetBalance(rp, nil)
+        return err
+    })
+
+    // Get exchange rate
+    wg.Go(func() error {
+        var err error
+        rate, err = getExchangeRate(rp)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Update response
+    response.BelowMinimum = (amountWei.Cmp(response.MinimumDeposit) < 0)
+    response.ExchangeRate = rate.ETHPerRETH()
+    response.ExpectedRETH, err = rate.RETHValue(amountWei)
+    if err != nil {
+        return nil, err
+    }
+    response.CanDeposit = !(response.InsufficientBalance || response.BelowMinimum || response.DepositDisabled)
+
+    // Estimate gas
+    if response.CanDeposit {
+        gasPrice, err := services.GetGasPrice(c)
+        if err != nil {
+            return nil, err
+        }
+        gasInfo, err := eth1.EstimateContractGas(rp, gasPrice, "rocketDepositPool", nodeAccount.Address, amountWei, "deposit")
+        if err != nil {
+            return nil, err
+        }
+        if cfg.Smartnode.MaxFee > 0 {
+            gasInfo.MaxGasPrice = eth.GweiToWei(cfg.Smartnode.MaxFee)
+        }
+        gasInfo.GasToken = cfg.GetGasToken()
+        response.GasInfo = gasInfo
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func Deposit(c config.Context, amountWei *big.Int) (*api.RETHDepositResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.RETHDepositResponse{}
+
+    // Get deposit pool contract
+    rocketDepositPool, err := rp.GetContract("rocketDepositPool")
+    if err != nil {
+        return nil, err
+    }
+
+    // Get transactor
+    opts, err := services.GetNodeAccountTransactor(c)
+    if err != nil {
+        return nil, err
+    }
+    opts.Value = amountWei
+
+    // Deposit
+    txReceipt, err := txm.Transact(opts, "Deposit ETH for rETH", func(opts *bind.TransactOpts) error {
+        _, err := rocketDepositPool.Transact(opts, "deposit")
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+    response.TxHash = txReceipt.TxHash
+
+    // Return response
+    return &response, nil
+
+}
+
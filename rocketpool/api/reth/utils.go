@@ -0,0 +1,120 @@
+package reth
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
+)
+
+
+// Network balances used to calculate the rETH exchange rate
+type exchangeRate struct {
+    TotalETH *big.Int
+    RETHSupply *big.Int
+}
+
+
+// Get the rETH exchange rate at the last network balances submission
+func getExchangeRate(rp *rocketpool.RocketPool) (exchangeRate, error) {
+
+    // Data
+    var wg errgroup.Group
+    var rate exchangeRate
+
+    // Load data
+    wg.Go(func() error {
+        var err error
+        rate.TotalETH, err = callBig(rp, "rocketNetworkBalances", "getTotalETHBalance")
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        rate.RETHSupply, err = callBig(rp, "rocketNetworkBalances", "getTotalRETHSupply")
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return exchangeRate{}, err
+    }
+
+    // Return
+    return rate, nil
+
+}
+
+
+// Get the exchange rate in ETH per rETH; rETH is minted 1:1 until network balances are submitted
+func (r exchangeRate) ETHPerRETH() float64 {
+    if r.RETHSupply.Cmp(big.NewInt(0)) == 0 || r.TotalETH.Cmp(big.NewInt(0)) == 0 {
+        return 1
+    }
+    return eth.WeiToEth(r.TotalETH) / eth.WeiToEth(r.RETHSupply)
+}
+
+
+// Get the rETH value of an ETH amount
+func (r exchangeRate) RETHValue(ethAmount *big.Int) (*big.Int, error) {
+    if r.RETHSupply.Cmp(big.NewInt(0)) == 0 || r.TotalETH.Cmp(big.NewInt(0)) == 0 {
+        return new(big.Int).Set(ethAmount), nil
+    }
+    value, err := safemath.MulDiv(ethAmount, r.RETHSupply, r.TotalETH)
+    if err != nil {
+        return nil, fmt.Errorf("Could not calculate rETH value: %w", err)
+    }
+    return value, nil
+}
+
+
+// Get the ETH value of an rETH amount
+func (r exchangeRate) ETHValue(rethAmount *big.Int) (*big.Int, error) {
+    if r.RETHSupply.Cmp(big.NewInt(0)) == 0 || r.TotalETH.Cmp(big.NewInt(0)) == 0 {
+        return new(big.Int).Set(rethAmount), nil
+    }
+    value, err := safemath.MulDiv(rethAmount, r.TotalETH, r.RETHSupply)
+    if err != nil {
+        return nil, fmt.Errorf("Could not calculate rETH ETH value: %w", err)
+    }
+    return value, nil
+}
+
+
+// Get an address's rETH balance
+func getRETHBalance(rp *rocketpool.RocketPool, address common.Address) (*big.Int, error) {
+    return callBig(rp, "rocketETHToken", "balanceOf", address)
+}
+
+
+// Call a network contract method returning a uint256
+func callBig(rp *rocketpool.RocketPool, contractName, method string, args ...interface{}) (*big.Int, error) {
+    contract, err := rp.GetContract(contractName)
+    if err != nil {
+        return nil, err
+    }
+    value := new(*big.Int)
+    if err := contract.Call(nil, value, method, args...); err != nil {
+        return nil, fmt.Errorf("Could not get %s.%s: %w", contractName, method, err)
+    }
+    return *value, nil
+}
+
+
+// Call a network contract method returning a bool
+func callBool(rp *rocketpool.RocketPool, contractName, method string, args ...interface{}) (bool, error) {
+    contract, err := rp.GetContract(contractName)
+    if err != nil {
+        return false, err
+    }
+    value := new(bool)
+    if err := contract.Call(nil, value, method, args...); err != nil {
+        return false, fmt.Errorf("Could not get %s.%s: %w", contractName, method, err)
+    }
+    return *value, nil
+}
+
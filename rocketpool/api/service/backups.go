@@ -0,0 +1,148 @@
+package service
+
+import (
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/backup"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetBackups(c config.Context) (*api.ServiceBackupsResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    destination, err := services.GetBackupDestination(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceBackupsResponse{}
+    response.Destination = cfg.Backup.Destination
+
+    // Get backups
+    backups, err := backup.List(destination)
+    if err != nil {
+        return nil, err
+    }
+    response.Backups = backups
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func CreateBackup(c config.Context, passphrase string) (*api.ServiceCreateBackupResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    destination, err := services.GetBackupDestination(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceCreateBackupResponse{}
+    response.Destination = cfg.Backup.Destination
+
+    // Get passphrase
+    if passphrase == "" {
+        passphrase, err = services.GetBackupPassphrase(c)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    // Create backup
+    name, err := backup.Create(destination, services.GetBackupRootPath(c), backup.DefaultExcludes, passphrase, cfg.GetBackupRetention())
+    if err != nil {
+        return nil, err
+    }
+    response.Name = name
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Upload an encrypted backup staged in the backup upload folder to the backup destination, then remove the staged file
+func UploadBackup(c config.Context, name string) (*api.ServiceCreateBackupResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    destination, err := services.GetBackupDestination(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceCreateBackupResponse{}
+    response.Destination = cfg.Backup.Destination
+    response.Name = name
+
+    // Read staged backup
+    path := filepath.Join(services.GetBackupRootPath(c), backup.UploadFolder, name)
+    data, err := ioutil.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("Could not read staged backup %s: %w", name, err)
+    }
+    defer os.Remove(path)
+
+    // Upload backup
+    if err := backup.Upload(destination, name, data, cfg.GetBackupRetention()); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func RestoreBackup(c config.Context, name, passphrase string) (*api.ServiceRestoreBackupResponse, error) {
+
+    // Get services
+    destination, err := services.GetBackupDestination(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceRestoreBackupResponse{}
+
+    // Check backup exists
+    backups, err := backup.List(destination)
+    if err != nil {
+        return nil, err
+    }
+    found := false
+    for _, backupName := range backups {
+        if backupName == name {
+            found = true
+            break
+        }
+    }
+    if !found {
+        return nil, fmt.Errorf("Backup %s was not found", name)
+    }
+
+    // Get passphrase
+    if passphrase == "" {
+        passphrase, err = services.GetBackupPassphrase(c)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    // Restore backup
+    if err := backup.Restore(destination, name, services.GetBackupRootPath(c), passphrase); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
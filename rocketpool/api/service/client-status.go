@@ -0,0 +1,83 @@
+package service
+
+import (
+    "context"
+
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetClientStatus(c config.Context) (*api.ServiceClientStatusResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceClientStatusResponse{}
+    response.Eth1.External = cfg.Chains.Eth1.External
+    response.Eth1.Provider = cfg.Chains.Eth1.Provider
+    response.Eth2.External = cfg.Chains.Eth2.External
+    response.Eth2.Provider = cfg.Chains.Eth2.Provider
+
+    // Data
+    var wg errgroup.Group
+
+    // Check Eth 1.0 client
+    wg.Go(func() error {
+        ec, err := services.GetEthClient(c)
+        if err != nil {
+            response.Eth1.Error = err.Error()
+            return nil
+        }
+        progress, err := ec.SyncProgress(context.Background())
+        if err != nil {
+            response.Eth1.Error = err.Error()
+            return nil
+        }
+        response.Eth1.Reachable = true
+        response.Eth1.Synced = (progress == nil)
+        if progress == nil {
+            response.Eth1.SyncProgress = 1
+        } else if progress.HighestBlock > 0 {
+            response.Eth1.SyncProgress = float64(progress.CurrentBlock) / float64(progress.HighestBlock)
+        }
+        if header, err := ec.HeaderByNumber(context.Background(), nil); err == nil {
+            response.Eth1.LatestBlockTime = header.Time
+        }
+        return nil
+    })
+
+    // Check Eth 2.0 client
+    wg.Go(func() error {
+        bc, err := services.GetBeaconClient(c)
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        syncStatus, err := bc.GetSyncStatus()
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        response.Eth2.Reachable = true
+        response.Eth2.Synced = !syncStatus.Syncing
+        if response.Eth2.Synced {
+            response.Eth2.SyncProgress = 1
+        }
+        return nil
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
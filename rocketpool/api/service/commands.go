@@ -0,0 +1,245 @@
+package service
+
+import (
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/utils/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+    command.Subcommands = append(command.Subcommands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Inspect the Rocket Pool service clients and manage backups",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "peers",
+                Aliases:   []string{"p"},
+                Usage:     "Get the Eth 1.0 and Eth 2.0 client peers",
+                UsageText: "rocketpool api service peers [lookup-locations]",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateMaxArgCount(c, 1); err != nil { return err }
+                    lookupLocations := false
+                    if c.NArg() == 1 {
+                        var err error
+                        lookupLocations, err = cliutils.ValidateBool("lookup locations", c.Args().Get(0))
+                        if err != nil { return err }
+                    }
+
+                    // Run
+                    api.PrintResponse(GetPeers(c, lookupLocations))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "sync",
+                Aliases:   []string{"n"},
+                Usage:     "Get the Eth 1.0 and Eth 2.0 client sync progress",
+                UsageText: "rocketpool api service sync",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetSync(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "net-diag",
+                Aliases:   []string{"g"},
+                Usage:     "Check the Eth 1.0 and Eth 2.0 client p2p networking",
+                UsageText: "rocketpool api service net-diag",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetNetDiag(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "client-status",
+                Aliases:   []string{"c"},
+                Usage:     "Get the Eth 1.0 and Eth 2.0 client connection & sync status",
+                UsageText: "rocketpool api service client-status",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetClientStatus(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "signer-status",
+                Aliases:   []string{"s"},
+                Usage:     "Get the remote signer health and the validator keys it is missing",
+                UsageText: "rocketpool api service signer-status",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetSignerStatus(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "sync-signer",
+                Aliases:   []string{"y"},
+                Usage:     "Import the validator keys missing from the remote signer",
+                UsageText: "rocketpool api service sync-signer",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(SyncSigner(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "backups",
+                Aliases:   []string{"b"},
+                Usage:     "Get the backups available at the backup destination",
+                UsageText: "rocketpool api service backups",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetBackups(c))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "create-backup",
+                Usage:     "Create a backup at the backup destination; uses the node password if passphrase is blank",
+                UsageText: "rocketpool api service create-backup passphrase",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    passphrase := c.Args().Get(0)
+
+                    // Run
+                    api.PrintResponse(CreateBackup(c, passphrase))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "upload-backup",
+                Usage:     "Upload an encrypted backup staged in the backup upload folder to the backup destination",
+                UsageText: "rocketpool api service upload-backup name",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    name, err := cliutils.ValidateBackupName("backup name", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(UploadBackup(c, name))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "restore-backup",
+                Usage:     "Restore a backup from the backup destination; uses the node password if passphrase is blank",
+                UsageText: "rocketpool api service restore-backup name passphrase",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    name := c.Args().Get(0)
+                    passphrase := c.Args().Get(1)
+
+                    // Run
+                    api.PrintResponse(RestoreBackup(c, name, passphrase))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "verify-backup",
+                Usage:     "Restore the most recent backup to a temporary folder and check its contents; uses the node password if passphrase is blank",
+                UsageText: "rocketpool api service verify-backup passphrase",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    passphrase := c.Args().Get(0)
+
+                    // Run
+                    api.PrintResponse(VerifyBackup(c, passphrase))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "tasks",
+                Aliases:   []string{"t"},
+                Usage:     "Get the status of the node & watchtower daemon tasks",
+                UsageText: "rocketpool api service tasks",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetTasks(c))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "trigger-task",
+                Usage:     "Trigger a daemon task to run immediately",
+                UsageText: "rocketpool api service trigger-task name",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    name := c.Args().Get(0)
+
+                    // Run
+                    api.PrintResponse(TriggerTask(c, name))
+                    return nil
+
+                },
+            },
+
+        },
+    })
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+    "time"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/portmap"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+
+// Config
+var portCheckTimeout, _ = time.ParseDuration("5s")
+
+
+func GetNetDiag(c config.Context) (*api.ServiceNetDiagResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceNetDiagResponse{}
+    response.Eth1.External = cfg.Chains.Eth1.External
+    response.Eth1.P2PPort = cfg.GetEth1P2PPort()
+    response.Eth2.External = cfg.Chains.Eth2.External
+    response.Eth2.P2PPort = cfg.GetEth2P2PPort()
+
+    // Get port mapping status, as maintained by the node daemon
+    if cfg.PortMapping.Method != "" {
+        response.PortMapping.Enabled = true
+        response.PortMapping.Method = cfg.PortMapping.Method
+        status, found, err := portmap.LoadStatus(cfg.GetDataPath())
+        if err != nil {
+            return nil, err
+        }
+        for _, diag := range []*api.ClientNetDiag{&response.Eth1, &response.Eth2} {
+            if diag.External {
+                continue
+            }
+            if !found {
+                diag.PortMappingError = "The node daemon has not mapped the port yet"
+                continue
+            }
+            diag.PortMapped, diag.PortMappingError = status.IsMapped(diag.P2PPort)
+        }
+        if found {
+            if status.Method != "" {
+                response.PortMapping.Method = status.Method
+            }
+            response.PortMapping.ExternalIP = status.ExternalIP
+            response.PortMapping.Updated = status.Updated.Unix()
+        }
+    }
+
+    // Data
+    var wg errgroup.Group
+
+    // Get Eth 1.0 peers; connection directions are only available via the admin API
+    wg.Go(func() error {
+        rpcClient, err := services.GetEthRPCClient(c)
+        if err != nil {
+            response.Eth1.Error = err.Error()
+            return nil
+        }
+        var peerInfos []adminPeerInfo
+        if err := rpcClient.Call(&peerInfos, "admin_peers"); err == nil {
+            response.Eth1.PeerCount = len(peerInfos)
+            response.Eth1.DirectionsKnown = true
+            for _, peerInfo := range peerInfos {
+                if peerInfo.Network.Inbound {
+                    response.Eth1.InboundPeers++
+                }
+            }
+            return nil
+        }
+        var peerCount hexutil.Uint64
+        if err := rpcClient.Call(&peerCount, "net_peerCount"); err != nil {
+            response.Eth1.Error = err.Error()
+            return nil
+        }
+        response.Eth1.PeerCount = int(peerCount)
+        return nil
+    })
+
+    // Get Eth 2.0 peers
+    wg.Go(func() error {
+        bc, err := services.GetBeaconClient(c)
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        peers, err := bc.GetPeers()
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        response.Eth2.PeerCount = len(peers)
+        for _, peer := range peers {
+            if peer.Direction != beacon.PeerDirectionUnknown {
+                response.Eth2.DirectionsKnown = true
+            }
+            if peer.Direction == beacon.PeerDirectionInbound {
+                response.Eth2.InboundPeers++
+            }
+        }
+        return nil
+    })
+
+    // Check p2p ports at the public IP address
+    // Routers without NAT loopback support may refuse connections to their own public address, so closed ports are not conclusive
+    wg.Go(func() error {
+        publicIP, err := netutils.GetPublicIP()
+        if err != nil {
+            response.PublicIPError = err.Error()
+            return nil
+        }
+        response.PublicIP = publicIP
+        var portWg errgroup.Group
+        for _, diag := range []*api.ClientNetDiag{&response.Eth1, &response.Eth2} {
+            diag := diag
+            portWg.Go(func() error {
+                diag.PortOpen = netutils.CheckTCPPort(publicIP, diag.P2PPort, portCheckTimeout)
+                diag.PortChecked = true
+                return nil
+            })
+        }
+        return portWg.Wait()
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
@@ -0,0 +1,252 @@
+package service
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+
+// Config
+const (
+    PeersSnapshotFile = "peers.json"
+    MaxPeerLocationLookups = 32
+    UnknownPeerLocation = "unknown"
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Client peer
+type peer struct {
+    ID string
+    Host string
+}
+
+
+// Geth admin API peer info
+type adminPeerInfo struct {
+    ID string                   `json:"id"`
+    Network struct {
+        RemoteAddress string    `json:"remoteAddress"`
+        Inbound bool            `json:"inbound"`
+    }                           `json:"network"`
+}
+
+
+// Peer snapshot
+type peersSnapshot struct {
+    Time int64                  `json:"time"`
+    Eth1 []string               `json:"eth1"`
+    Eth2 []string               `json:"eth2"`
+}
+
+
+// Peer locations are only looked up if requested, as peer addresses are sent to a third-party geolocation service
+func GetPeers(c config.Context, lookupLocations bool) (*api.ServicePeersResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    rpcClient, err := services.GetEthRPCClient(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServicePeersResponse{}
+
+    // Data
+    var wg errgroup.Group
+    var eth1Peers []peer
+    var eth2Peers []peer
+
+    // Get Eth 1.0 peers; fall back to peer count if admin API is unavailable
+    wg.Go(func() error {
+        var peerInfos []adminPeerInfo
+        if err := rpcClient.Call(&peerInfos, "admin_peers"); err == nil {
+            response.Eth1.AdminAPIAvailable = true
+            eth1Peers = make([]peer, len(peerInfos))
+            for pi, peerInfo := range peerInfos {
+                host, _, _ := net.SplitHostPort(peerInfo.Network.RemoteAddress)
+                eth1Peers[pi] = peer{ID: peerInfo.ID, Host: host}
+            }
+            response.Eth1.PeerCount = len(eth1Peers)
+            return nil
+        }
+        var peerCount hexutil.Uint64
+        if err := rpcClient.Call(&peerCount, "net_peerCount"); err != nil {
+            return fmt.Errorf("Could not get Eth 1.0 peer count: %w", err)
+        }
+        response.Eth1.PeerCount = int(peerCount)
+        return nil
+    })
+
+    // Get Eth 2.0 peers; fall back to peer count if peer list is unavailable
+    wg.Go(func() error {
+        beaconPeers, err := bc.GetPeers()
+        if err != nil {
+            peerCount, err := bc.GetPeerCount()
+            if err != nil {
+                return fmt.Errorf("Could not get Eth 2.0 peer count: %w", err)
+            }
+            response.Eth2.PeerCount = int(peerCount)
+            return nil
+        }
+        response.Eth2.AdminAPIAvailable = true
+        eth2Peers = make([]peer, len(beaconPeers))
+        for pi, beaconPeer := range beaconPeers {
+            eth2Peers[pi] = peer{ID: beaconPeer.ID, Host: beaconPeer.Address}
+        }
+        response.Eth2.PeerCount = len(eth2Peers)
+        return nil
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Get peer locations
+    if lookupLocations {
+        response.Eth1.Countries = getPeerCountries(eth1Peers)
+        response.Eth2.Countries = getPeerCountries(eth2Peers)
+    }
+
+    // Get peer churn since the previous snapshot
+    snapshotPath := filepath.Join(cfg.GetDataPath(), PeersSnapshotFile)
+    if previous, err := loadPeersSnapshot(snapshotPath); err == nil {
+        response.Eth1.NewPeers, response.Eth1.LostPeers = getPeerChurn(previous.Eth1, eth1Peers)
+        response.Eth2.NewPeers, response.Eth2.LostPeers = getPeerChurn(previous.Eth2, eth2Peers)
+        response.SnapshotTime = previous.Time
+    }
+
+    // Save current snapshot
+    if err := savePeersSnapshot(snapshotPath, eth1Peers, eth2Peers); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Get the number of peers per country
+func getPeerCountries(peers []peer) map[string]int {
+
+    // Get unique hosts to look up
+    hosts := []string{}
+    hostCounts := map[string]int{}
+    unknown := 0
+    for _, p := range peers {
+        if p.Host == "" {
+            unknown++
+            continue
+        }
+        if _, ok := hostCounts[p.Host]; !ok {
+            if len(hosts) >= MaxPeerLocationLookups {
+                unknown++
+                continue
+            }
+            hosts = append(hosts, p.Host)
+        }
+        hostCounts[p.Host]++
+    }
+
+    // Look up host locations
+    var lock sync.Mutex
+    var wg errgroup.Group
+    countries := map[string]int{}
+    for _, host := range hosts {
+        host := host
+        wg.Go(func() error {
+            country, err := netutils.GetCountryCode(host)
+            if err != nil || country == "" {
+                country = UnknownPeerLocation
+            }
+            lock.Lock()
+            countries[country] += hostCounts[host]
+            lock.Unlock()
+            return nil
+        })
+    }
+    wg.Wait()
+
+    // Return
+    if unknown > 0 {
+        countries[UnknownPeerLocation] += unknown
+    }
+    return countries
+
+}
+
+
+// Get the number of new and lost peers since a previous peer ID set
+func getPeerChurn(previousIds []string, peers []peer) (int, int) {
+    previous := map[string]bool{}
+    for _, id := range previousIds {
+        previous[id] = true
+    }
+    newPeers := 0
+    for _, p := range peers {
+        if previous[p.ID] {
+            delete(previous, p.ID)
+        } else {
+            newPeers++
+        }
+    }
+    return newPeers, len(previous)
+}
+
+
+// Load a peer snapshot from disk
+func loadPeersSnapshot(path string) (peersSnapshot, error) {
+    var snapshot peersSnapshot
+    snapshotBytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return snapshot, err
+    }
+    err = json.Unmarshal(snapshotBytes, &snapshot)
+    return snapshot, err
+}
+
+
+// Save a peer snapshot to disk
+func savePeersSnapshot(path string, eth1Peers, eth2Peers []peer) error {
+    snapshot := peersSnapshot{
+        Time: time.Now().Unix(),
+        Eth1: make([]string, len(eth1Peers)),
+        Eth2: make([]string, len(eth2Peers)),
+    }
+    for pi, p := range eth1Peers {
+        snapshot.Eth1[pi] = p.ID
+    }
+    for pi, p := range eth2Peers {
+        snapshot.Eth2[pi] = p.ID
+    }
+    snapshotBytes, err := json.Marshal(snapshot)
+    if err != nil {
+        return fmt.Errorf("Could not encode peer snapshot: %w", err)
+    }
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create peer snapshot folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path, snapshotBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write peer snapshot to disk: %w", err)
+    }
+    return nil
+}
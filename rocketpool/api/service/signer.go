@@ -0,0 +1,154 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/services/web3signer"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+
+func GetSignerStatus(c config.Context) (*api.ServiceSignerStatusResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    signer, err := services.GetRemoteSigner(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceSignerStatusResponse{
+        MissingKeys: []rptypes.ValidatorPubkey{},
+    }
+
+    // Check remote signer is configured
+    if signer == nil {
+        return &response, nil
+    }
+    response.Configured = true
+    response.URL = cfg.RemoteSigner.URL
+
+    // Check remote signer health
+    if err := signer.Upcheck(); err != nil {
+        response.SignerError = err.Error()
+        return &response, nil
+    }
+    response.Reachable = true
+
+    // Get missing validator keys
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    signerKeyCount, missingKeys, err := getMissingSignerKeys(w, signer)
+    if err != nil {
+        response.SignerError = err.Error()
+        return &response, nil
+    }
+    response.SignerKeyCount = signerKeyCount
+    for _, key := range missingKeys {
+        response.MissingKeys = append(response.MissingKeys, key.pubkey)
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func SyncSigner(c config.Context) (*api.ServiceSyncSignerResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    pm, err := services.GetPasswordManager(c)
+    if err != nil { return nil, err }
+    signer, err := services.GetRemoteSigner(c)
+    if err != nil { return nil, err }
+    if signer == nil {
+        return nil, apiutils.NotReadyError(errors.New("No remote signer is configured"))
+    }
+
+    // Response
+    response := api.ServiceSyncSignerResponse{
+        ImportedKeys: []rptypes.ValidatorPubkey{},
+    }
+
+    // Get missing validator keys
+    _, missingKeys, err := getMissingSignerKeys(w, signer)
+    if err != nil {
+        return nil, err
+    }
+
+    // Import missing keys
+    password, err := pm.GetPassword()
+    if err != nil {
+        return nil, err
+    }
+    for _, key := range missingKeys {
+        keystore, err := w.ExportValidatorKey(key.index, password)
+        if err != nil {
+            return nil, err
+        }
+        if err := signer.ImportKeystore(keystore, password); err != nil {
+            return nil, fmt.Errorf("Could not import validator key %s: %w", key.pubkey.Hex(), err)
+        }
+        response.ImportedKeys = append(response.ImportedKeys, key.pubkey)
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Wallet validator key missing from the remote signer
+type missingSignerKey struct {
+    index uint
+    pubkey rptypes.ValidatorPubkey
+}
+
+
+// Get the number of keys held by the remote signer, and the wallet validator keys it does not hold
+func getMissingSignerKeys(w *wallet.Wallet, signer *web3signer.Client) (int, []missingSignerKey, error) {
+
+    // Get signer pubkeys
+    signerPubkeys, err := signer.GetPublicKeys()
+    if err != nil {
+        return 0, []missingSignerKey{}, err
+    }
+    held := make(map[rptypes.ValidatorPubkey]bool)
+    for _, pubkey := range signerPubkeys {
+        held[pubkey] = true
+    }
+
+    // Get missing wallet validator keys
+    if !w.IsInitialized() {
+        return len(signerPubkeys), []missingSignerKey{}, nil
+    }
+    keyCount, err := w.GetValidatorKeyCount()
+    if err != nil {
+        return 0, []missingSignerKey{}, err
+    }
+    missingKeys := []missingSignerKey{}
+    for index := uint(0); index < keyCount; index++ {
+        key, err := w.GetValidatorKeyAt(index)
+        if err != nil {
+            return 0, []missingSignerKey{}, err
+        }
+        pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+        if !held[pubkey] {
+            missingKeys = append(missingKeys, missingSignerKey{index: index, pubkey: pubkey})
+        }
+    }
+
+    // Return
+    return len(signerPubkeys), missingKeys, nil
+
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Config
+const SyncSnapshotFile = "sync.json"
+var maxSyncSnapshotAge, _ = time.ParseDuration("1h")
+
+
+// Sync snapshot
+type syncSnapshot struct {
+    Time int64                  `json:"time"`
+    Eth1 syncHeights            `json:"eth1"`
+    Eth2 syncHeights            `json:"eth2"`
+}
+type syncHeights struct {
+    Current uint64              `json:"current"`
+    Network uint64              `json:"network"`
+}
+
+
+func GetSync(c config.Context) (*api.ServiceSyncResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceSyncResponse{}
+    response.Eth1.SecondsRemaining = -1
+    response.Eth2.SecondsRemaining = -1
+
+    // Data
+    var wg errgroup.Group
+
+    // Get Eth 1.0 sync progress; the network height is the highest block known to the client
+    wg.Go(func() error {
+        ec, err := services.GetEthClient(c)
+        if err != nil {
+            response.Eth1.Error = err.Error()
+            return nil
+        }
+        progress, err := ec.SyncProgress(context.Background())
+        if err != nil {
+            response.Eth1.Error = err.Error()
+            return nil
+        }
+        response.Eth1.Reachable = true
+        if progress != nil {
+            response.Eth1.CurrentHeight = progress.CurrentBlock
+            response.Eth1.NetworkHeight = progress.HighestBlock
+        } else if header, err := ec.HeaderByNumber(context.Background(), nil); err == nil {
+            response.Eth1.Synced = true
+            response.Eth1.CurrentHeight = header.Number.Uint64()
+            response.Eth1.NetworkHeight = response.Eth1.CurrentHeight
+        } else {
+            response.Eth1.Error = err.Error()
+        }
+        if rpcClient, err := services.GetEthRPCClient(c); err == nil {
+            var peerCount hexutil.Uint64
+            if err := rpcClient.Call(&peerCount, "net_peerCount"); err == nil {
+                response.Eth1.PeerCount = int(peerCount)
+            }
+        }
+        return nil
+    })
+
+    // Get Eth 2.0 sync progress; the network height is the current slot by wall clock time
+    wg.Go(func() error {
+        bc, err := services.GetBeaconClient(c)
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        syncStatus, err := bc.GetSyncStatus()
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        response.Eth2.Reachable = true
+        response.Eth2.Synced = !syncStatus.Syncing
+        head, err := bc.GetBeaconHead()
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        eth2Config, err := bc.GetEth2Config()
+        if err != nil {
+            response.Eth2.Error = err.Error()
+            return nil
+        }
+        response.Eth2.CurrentHeight = head.Slot
+        response.Eth2.NetworkHeight = head.Slot
+        now := uint64(time.Now().Unix())
+        if eth2Config.SecondsPerSlot > 0 && now > eth2Config.GenesisTime {
+            if networkSlot := (now - eth2Config.GenesisTime) / eth2Config.SecondsPerSlot; networkSlot > head.Slot {
+                response.Eth2.NetworkHeight = networkSlot
+            }
+        }
+        if peers, err := bc.GetPeers(); err == nil {
+            response.Eth2.PeerCount = len(peers)
+        }
+        return nil
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Get sync rates since the previous snapshot
+    snapshot := syncSnapshot{
+        Time: time.Now().Unix(),
+        Eth1: syncHeights{Current: response.Eth1.CurrentHeight, Network: response.Eth1.NetworkHeight},
+        Eth2: syncHeights{Current: response.Eth2.CurrentHeight, Network: response.Eth2.NetworkHeight},
+    }
+    snapshotPath := filepath.Join(cfg.GetDataPath(), SyncSnapshotFile)
+    if previous, err := loadSyncSnapshot(snapshotPath); err == nil && snapshot.Time > previous.Time && time.Duration(snapshot.Time - previous.Time) * time.Second <= maxSyncSnapshotAge {
+        elapsed := float64(snapshot.Time - previous.Time)
+        setSyncRate(&response.Eth1, previous.Eth1, elapsed)
+        setSyncRate(&response.Eth2, previous.Eth2, elapsed)
+        response.SnapshotTime = previous.Time
+    }
+    if response.Eth1.Synced {
+        response.Eth1.SecondsRemaining = 0
+    }
+    if response.Eth2.Synced {
+        response.Eth2.SecondsRemaining = 0
+    }
+
+    // Save current snapshot
+    if err := saveSyncSnapshot(snapshotPath, snapshot); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Set a client's sync rate and estimated time remaining from a previous snapshot
+// The estimate uses the rate at which the client is closing the gap to the network height, as the network height also moves
+func setSyncRate(sync *api.ClientSync, previous syncHeights, elapsed float64) {
+    if !sync.Reachable || sync.CurrentHeight < previous.Current || previous.Network < previous.Current || sync.NetworkHeight < sync.CurrentHeight {
+        return
+    }
+    sync.HeightsPerSecond = float64(sync.CurrentHeight - previous.Current) / elapsed
+    previousGap := float64(previous.Network - previous.Current)
+    gap := float64(sync.NetworkHeight - sync.CurrentHeight)
+    if gap == 0 {
+        sync.SecondsRemaining = 0
+    } else if closingRate := (previousGap - gap) / elapsed; closingRate > 0 {
+        sync.SecondsRemaining = int64(gap / closingRate)
+    }
+}
+
+
+// Load a sync snapshot from disk
+func loadSyncSnapshot(path string) (syncSnapshot, error) {
+    var snapshot syncSnapshot
+    snapshotBytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return snapshot, err
+    }
+    err = json.Unmarshal(snapshotBytes, &snapshot)
+    return snapshot, err
+}
+
+
+// Save a sync snapshot to disk
+func saveSyncSnapshot(path string, snapshot syncSnapshot) error {
+    snapshotBytes, err := json.Marshal(snapshot)
+    if err != nil {
+        return fmt.Errorf("Could not encode sync snapshot: %w", err)
+    }
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create sync snapshot folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path, snapshotBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write sync snapshot to disk: %w", err)
+    }
+    return nil
+}
+
@@ -0,0 +1,63 @@
+package service
+
+import (
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/scheduler"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func GetTasks(c config.Context) (*api.ServiceTasksResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceTasksResponse{}
+
+    // Get task statuses
+    statuses, err := scheduler.GetTaskStatuses(cfg.GetDataPath())
+    if err != nil {
+        return nil, err
+    }
+    response.Tasks = make([]api.DaemonTask, len(statuses))
+    for si, status := range statuses {
+        response.Tasks[si] = api.DaemonTask{
+            Name: status.Name,
+            Daemon: status.Daemon,
+            Interval: status.Interval,
+            ActiveInterval: status.ActiveInterval,
+            IdleInterval: status.IdleInterval,
+            Enabled: status.Enabled,
+            Rehearsal: status.Rehearsal,
+            LastRun: status.LastRun,
+            LastError: status.LastError,
+        }
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func TriggerTask(c config.Context, name string) (*api.ServiceTriggerTaskResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceTriggerTaskResponse{}
+
+    // Trigger task
+    if err := scheduler.Trigger(cfg.GetDataPath(), name); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/backup"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/passwords"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+    "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Encrypted keystore file fields required to decrypt a key
+type keystoreFile struct {
+    Crypto map[string]interface{}   `json:"crypto"`
+}
+
+
+func VerifyBackup(c config.Context, passphrase string) (*api.ServiceVerifyBackupResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    destination, err := services.GetBackupDestination(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ServiceVerifyBackupResponse{}
+
+    // Get most recent backup
+    backups, err := backup.List(destination)
+    if err != nil {
+        return nil, err
+    }
+    if len(backups) == 0 {
+        return nil, errors.New("There are no backups at the backup destination to verify")
+    }
+    response.Name = backups[len(backups) - 1]
+
+    // Get passphrase
+    if passphrase == "" {
+        passphrase, err = services.GetBackupPassphrase(c)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    // Restore backup to a temporary folder
+    tempPath, err := ioutil.TempDir("", "rocketpool-verify-backup-")
+    if err != nil {
+        return nil, fmt.Errorf("Could not create temporary folder: %w", err)
+    }
+    defer os.RemoveAll(tempPath)
+    if err := backup.Restore(destination, response.Name, tempPath, passphrase); err != nil {
+        return nil, err
+    }
+
+    // Get a path within the restored backup
+    rootPath := services.GetBackupRootPath(c)
+    restoredPath := func(path string) (string, error) {
+        relPath, err := filepath.Rel(rootPath, path)
+        if err != nil || strings.HasPrefix(relPath, "..") {
+            return "", fmt.Errorf("%s is outside of the backed up folder %s", path, rootPath)
+        }
+        return filepath.Join(tempPath, relPath), nil
+    }
+
+    // Load configs
+    for _, configPath := range []string{c.GlobalString("config"), c.GlobalString("settings")} {
+        path, err := restoredPath(configPath)
+        if err != nil {
+            return nil, err
+        }
+        configBytes, err := ioutil.ReadFile(path)
+        if os.IsNotExist(err) {
+            continue
+        }
+        if err != nil {
+            return nil, fmt.Errorf("Could not read restored config file %s: %w", filepath.Base(path), err)
+        }
+        if _, err := config.Parse(configBytes); err != nil {
+            return nil, fmt.Errorf("Could not load restored config file %s: %w", filepath.Base(path), err)
+        }
+        response.ConfigFiles = append(response.ConfigFiles, filepath.Base(path))
+    }
+    if len(response.ConfigFiles) == 0 {
+        return nil, errors.New("The backup does not contain any config files")
+    }
+
+    // Get restored password manager; passwords from external sources are not part of the backup
+    pm, err := services.GetPasswordManager(c)
+    if err != nil {
+        return nil, err
+    }
+    if cfg.Smartnode.PasswordSource.Type == "" {
+        passwordPath, err := restoredPath(cfg.Smartnode.PasswordPath)
+        if err != nil {
+            return nil, err
+        }
+        pm = passwords.NewPasswordManager(passwordPath)
+    }
+
+    // Decrypt wallet
+    walletPath, err := restoredPath(cfg.Smartnode.WalletPath)
+    if err != nil {
+        return nil, err
+    }
+    if _, err := os.Stat(walletPath); os.IsNotExist(err) {
+        return nil, errors.New("The backup does not contain a wallet")
+    }
+    w, err := wallet.NewWallet(walletPath, pm)
+    if err != nil {
+        return nil, fmt.Errorf("Could not decrypt the restored wallet: %w", err)
+    }
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, fmt.Errorf("Could not decrypt the restored wallet: %w", err)
+    }
+    response.NodeAddress = nodeAccount.Address
+
+    // Parse keystores
+    keychainPath, err := restoredPath(cfg.Smartnode.ValidatorKeychainPath)
+    if err != nil {
+        return nil, err
+    }
+    response.KeystoreCount, err = verifyKeystores(keychainPath)
+    if err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Parse the encrypted keystore files in a validator keychain folder and return the number found
+func verifyKeystores(keychainPath string) (int, error) {
+    count := 0
+    err := filepath.Walk(keychainPath, func(path string, info os.FileInfo, err error) error {
+        if os.IsNotExist(err) && path == keychainPath {
+            return filepath.SkipDir
+        }
+        if err != nil {
+            return err
+        }
+        if info.IsDir() || (info.Name() != lighthouse.KeyFileName && info.Name() != prysm.WalletFileName) {
+            return nil
+        }
+        keystoreBytes, err := ioutil.ReadFile(path)
+        if err != nil {
+            return err
+        }
+        var keystore keystoreFile
+        if err := json.Unmarshal(keystoreBytes, &keystore); err != nil || len(keystore.Crypto) == 0 {
+            return fmt.Errorf("Could not parse restored keystore %s", strings.TrimPrefix(path, keychainPath))
+        }
+        count++
+        return nil
+    })
+    if err != nil {
+        return 0, fmt.Errorf("Could not verify restored validator keystores: %w", err)
+    }
+    return count, nil
+}
@@ -0,0 +1,74 @@
+package support
+
+import (
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/utils/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+    command.Subcommands = append(command.Subcommands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Manage remote support access",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "grant",
+                Aliases:   []string{"g"},
+                Usage:     "Create a time-limited, read-only support access token",
+                UsageText: "rocketpool api support grant duration",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    duration, err := cliutils.ValidateDuration("duration", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(Grant(c, duration))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "revoke",
+                Aliases:   []string{"r"},
+                Usage:     "Revoke all support access tokens",
+                UsageText: "rocketpool api support revoke",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(Revoke(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "audit-log",
+                Aliases:   []string{"a"},
+                Usage:     "Get the support access audit log",
+                UsageText: "rocketpool api support audit-log",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(GetAuditLog(c))
+                    return nil
+
+                },
+            },
+
+        },
+    })
+}
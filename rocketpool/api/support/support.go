@@ -0,0 +1,109 @@
+package support
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/audit"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/support"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func Grant(c config.Context, duration time.Duration) (*api.SupportGrantResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.SupportGrantResponse{}
+
+    // Create grant
+    grant, token, err := support.CreateGrant(cfg.GetDataPath(), duration)
+    if err != nil {
+        return nil, err
+    }
+    response.ID = grant.ID
+    response.Token = token
+    response.Expires = grant.Expires
+
+    // Record grant
+    if err := audit.Record(cfg.GetDataPath(), audit.Entry{
+        Actor: "operator",
+        Action: "support grant",
+        Allowed: true,
+        Detail: fmt.Sprintf("Granted support access %s until %s", grant.ID, grant.Expires.Format(time.RFC3339)),
+    }); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func Revoke(c config.Context) (*api.SupportRevokeResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.SupportRevokeResponse{}
+
+    // Revoke grants
+    revokedCount, err := support.RevokeGrants(cfg.GetDataPath())
+    if err != nil {
+        return nil, err
+    }
+    response.RevokedCount = revokedCount
+
+    // Record revocation
+    if err := audit.Record(cfg.GetDataPath(), audit.Entry{
+        Actor: "operator",
+        Action: "support revoke",
+        Allowed: true,
+        Detail: fmt.Sprintf("Revoked %d active support grant(s)", revokedCount),
+    }); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func GetAuditLog(c config.Context) (*api.SupportAuditLogResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.SupportAuditLogResponse{}
+
+    // Get audit log entries
+    entries, err := audit.GetEntries(cfg.GetDataPath())
+    if err != nil {
+        return nil, err
+    }
+    response.Entries = make([]api.AuditLogEntry, len(entries))
+    for ei, entry := range entries {
+        response.Entries[ei] = api.AuditLogEntry{
+            Time: entry.Time,
+            Actor: entry.Actor,
+            Action: entry.Action,
+            Allowed: entry.Allowed,
+            Detail: entry.Detail,
+        }
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -0,0 +1,41 @@
+package tx
+
+import (
+    "encoding/json"
+    "fmt"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+
+func BroadcastTransaction(c config.Context, transaction []byte) (*api.BroadcastTransactionResponse, error) {
+
+    // Get services
+    if err := services.RequireEthClientSynced(c); err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.BroadcastTransactionResponse{}
+
+    // Decode signed transaction
+    var signedTx api.SignedTransaction
+    if err := json.Unmarshal(transaction, &signedTx); err != nil {
+        return nil, apiutils.InputError(fmt.Errorf("Could not decode signed transaction: %w", err))
+    }
+
+    // Broadcast transaction
+    txReceipt, err := txm.BroadcastSignedTransaction(signedTx)
+    if err != nil {
+        return nil, err
+    }
+    response.TxHash = txReceipt.TxHash
+
+    // Return response
+    return &response, nil
+
+}
+
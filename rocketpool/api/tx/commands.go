@@ -0,0 +1,41 @@
+package tx
+
+import (
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/utils/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+    command.Subcommands = append(command.Subcommands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Manage transactions signed offline",
+        Subcommands: []cli.Command{
+
+            cli.Command{
+                Name:      "broadcast",
+                Aliases:   []string{"b"},
+                Usage:     "Broadcast a transaction signed offline and wait for it to be mined",
+                UsageText: "rocketpool api tx broadcast transaction-hex",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    transaction, err := cliutils.ValidateHexData("transaction", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(BroadcastTransaction(c, transaction))
+                    return nil
+
+                },
+            },
+
+        },
+    })
+}
+
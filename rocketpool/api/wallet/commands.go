@@ -27,7 +27,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(getStatus(c))
+                    api.PrintResponse(GetStatus(c))
                     return nil
 
                 },
@@ -46,7 +46,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(setPassword(c, password))
+                    api.PrintResponse(SetPassword(c, password))
                     return nil
 
                 },
@@ -63,7 +63,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(initWallet(c))
+                    api.PrintResponse(InitWallet(c))
                     return nil
 
                 },
@@ -82,7 +82,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err != nil { return err }
 
                     // Run
-                    api.PrintResponse(recoverWallet(c, mnemonic))
+                    api.PrintResponse(RecoverWallet(c, mnemonic))
                     return nil
 
                 },
@@ -99,7 +99,65 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(exportWallet(c))
+                    api.PrintResponse(ExportWallet(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "export-keys",
+                Aliases:   []string{"x"},
+                Usage:     "Export the node's validator keys as EIP-2335 keystores",
+                UsageText: "rocketpool api wallet export-keys password",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    password, err := cliutils.ValidateNodePassword("keystore password", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(ExportValidatorKeys(c, password))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "import-key",
+                Aliases:   []string{"m"},
+                Usage:     "Import a validator key from an EIP-2335 keystore",
+                UsageText: "rocketpool api wallet import-key keystore-hex password",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    keystore, err := cliutils.ValidateHexData("keystore", c.Args().Get(0))
+                    if err != nil { return err }
+                    password := c.Args().Get(1)
+
+                    // Run
+                    api.PrintResponse(ImportValidatorKey(c, keystore, password))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "sign-tx",
+                Aliases:   []string{"t"},
+                Usage:     "Sign a transaction prepared for offline signing with the node account",
+                UsageText: "rocketpool api wallet sign-tx transaction-hex",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    transaction, err := cliutils.ValidateHexData("transaction", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(SignTransaction(c, transaction))
                     return nil
 
                 },
@@ -0,0 +1,39 @@
+package wallet
+
+import (
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func ExportValidatorKeys(c config.Context, password string) (*api.ExportValidatorKeysResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ExportValidatorKeysResponse{}
+
+    // Get validator key count
+    validatorCount, err := w.GetValidatorKeyCount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Export validator keys
+    response.Keystores = make([]string, validatorCount)
+    for vi := uint(0); vi < validatorCount; vi++ {
+        keystore, err := w.ExportValidatorKey(vi, password)
+        if err != nil {
+            return nil, err
+        }
+        response.Keystores[vi] = string(keystore)
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -3,14 +3,14 @@ package wallet
 import (
     "encoding/hex"
 
-    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func exportWallet(c *cli.Context) (*api.ExportWalletResponse, error) {
+func ExportWallet(c config.Context) (*api.ExportWalletResponse, error) {
 
     // Get services
     if err := services.RequireNodeWallet(c); err != nil { return nil, err }
@@ -0,0 +1,40 @@
+package wallet
+
+import (
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/doppelganger"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func ImportValidatorKey(c config.Context, keystore []byte, password string) (*api.ImportValidatorKeyResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.ImportValidatorKeyResponse{}
+
+    // Import validator key
+    pubkey, err := w.ImportValidatorKey(keystore, password)
+    if err != nil {
+        return nil, err
+    }
+    response.ValidatorKey = pubkey
+
+    // Record key change for doppelganger protection
+    if cfg.Smartnode.DoppelgangerProtection {
+        if err := doppelganger.RecordKeyChange(cfg.GetDataPath()); err != nil {
+            return nil, err
+        }
+    }
+
+    // Return response
+    return &response, nil
+
+}
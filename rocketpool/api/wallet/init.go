@@ -3,14 +3,14 @@ package wallet
 import (
     "errors"
 
-    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func initWallet(c *cli.Context) (*api.InitWalletResponse, error) {
+func InitWallet(c config.Context) (*api.InitWalletResponse, error) {
 
     // Get services
     if err := services.RequireNodePassword(c); err != nil { return nil, err }
@@ -4,18 +4,21 @@ import (
     "errors"
 
     "github.com/rocket-pool/rocketpool-go/minipool"
-    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/doppelganger"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse, error) {
+func RecoverWallet(c config.Context, mnemonic string) (*api.RecoverWalletResponse, error) {
 
     // Get services
     if err := services.RequireNodePassword(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
@@ -60,6 +63,13 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
         return nil, err
     }
 
+    // Record key change for doppelganger protection
+    if cfg.Smartnode.DoppelgangerProtection && len(pubkeys) > 0 {
+        if err := doppelganger.RecordKeyChange(cfg.GetDataPath()); err != nil {
+            return nil, err
+        }
+    }
+
     // Return response
     return &response, nil
 
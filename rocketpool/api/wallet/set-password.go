@@ -3,14 +3,14 @@ package wallet
 import (
     "errors"
 
-    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func setPassword(c *cli.Context, password string) (*api.SetPasswordResponse, error) {
+func SetPassword(c config.Context, password string) (*api.SetPasswordResponse, error) {
 
     // Get services
     pm, err := services.GetPasswordManager(c)
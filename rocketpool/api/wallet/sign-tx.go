@@ -0,0 +1,42 @@
+package wallet
+
+import (
+    "encoding/json"
+    "fmt"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+
+func SignTransaction(c config.Context, transaction []byte) (*api.SignTransactionResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.SignTransactionResponse{}
+
+    // Decode unsigned transaction
+    var unsignedTx api.UnsignedTransaction
+    if err := json.Unmarshal(transaction, &unsignedTx); err != nil {
+        return nil, apiutils.InputError(fmt.Errorf("Could not decode unsigned transaction: %w", err))
+    }
+
+    // Sign transaction
+    signedTx, err := transactions.SignOfflineTransaction(w, unsignedTx)
+    if err != nil {
+        return nil, err
+    }
+    response.Transaction = signedTx
+
+    // Return response
+    return &response, nil
+
+}
+
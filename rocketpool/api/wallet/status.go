@@ -1,14 +1,13 @@
 package wallet
 
 import (
-    "github.com/urfave/cli"
-
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
+func GetStatus(c config.Context) (*api.WalletStatusResponse, error) {
 
     // Get services
     pm, err := services.GetPasswordManager(c)
@@ -22,6 +21,7 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
     // Get wallet status
     response.PasswordSet = pm.IsPasswordSet()
     response.WalletInitialized = w.IsInitialized()
+    response.WalletOffline = w.IsOffline()
 
     // Get accounts if initialized
     if response.WalletInitialized {
@@ -40,6 +40,15 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
         }
         response.ValidatorKeys = validatorKeys
 
+    } else if response.WalletOffline {
+
+        // Get offline node account
+        nodeAccount, err := w.GetNodeAccount()
+        if err != nil {
+            return nil, err
+        }
+        response.AccountAddress = nodeAccount.Address
+
     }
 
     // Return response
@@ -0,0 +1,212 @@
+package apiserver
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "os"
+    "os/exec"
+    "strings"
+
+    "github.com/docker/docker/client"
+    "github.com/fatih/color"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+    netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+
+// Config
+const (
+    DefaultSocketPath = "/.rocketpool/api.sock"
+    DefaultSupportSocketPath = "/.rocketpool/support.sock"
+    RequestPath = "/api"
+    LogsPath = "/logs"
+    MaxRequestSize = 1024 * 1024
+    APIServerColor = color.FgHiGreen
+)
+
+
+// Global flags which API requests may set
+var allowedGlobalFlags = map[string]bool{
+    "--maxFee": true,
+    "--priorityFee": true,
+    "--traceId": true,
+    "--offline": true,
+    "--dryRun": true,
+}
+
+
+// API server
+type apiServer struct {
+    log log.ColorLogger
+    globalArgs []string
+    dataPath string
+    d *client.Client // nil if the docker socket is disabled
+}
+
+
+// Register API server command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Run the Rocket Pool API server on a unix socket",
+        Flags: []cli.Flag{
+            cli.StringFlag{
+                Name:  "socket",
+                Usage: "API server unix socket `path`",
+                Value: DefaultSocketPath,
+            },
+            cli.StringFlag{
+                Name:  "support-socket",
+                Usage: "Read-only remote support unix socket `path`",
+                Value: DefaultSupportSocketPath,
+            },
+        },
+        Action: func(c *cli.Context) error {
+            return run(c, name, aliases)
+        },
+    })
+}
+
+
+// Run API server
+// Requests are run by the API command in a separate process, so that settings changes and global flags apply to each request
+func run(c *cli.Context, name string, aliases []string) error {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return err }
+    d, err := services.GetDocker(c)
+    if err != nil { return err }
+
+    // Get the global args the server was started with, to pass on to API commands
+    globalArgs := []string{}
+    for _, arg := range os.Args[1:] {
+        if arg == name || contains(aliases, arg) {
+            break
+        }
+        globalArgs = append(globalArgs, arg)
+    }
+    server := &apiServer{
+        log: log.NewColorLogger("api-server", APIServerColor),
+        globalArgs: globalArgs,
+        dataPath: cfg.GetDataPath(),
+        d: d,
+    }
+
+    // Listen on API & support sockets
+    apiListener, err := netutils.ListenUnix(c.String("socket"))
+    if err != nil {
+        return err
+    }
+    defer apiListener.Close()
+    supportListener, err := netutils.ListenUnix(c.String("support-socket"))
+    if err != nil {
+        return err
+    }
+    defer supportListener.Close()
+
+    // Serve support requests
+    supportMux := http.NewServeMux()
+    supportMux.HandleFunc(RequestPath, server.handleSupportRequest)
+    supportMux.HandleFunc(LogsPath, server.handleSupportLogs)
+    go (func() {
+        server.log.Error(http.Serve(supportListener, supportMux))
+    })()
+
+    // Serve API requests
+    server.log.Printlnf("Listening on %s...", c.String("socket"))
+    mux := http.NewServeMux()
+    mux.HandleFunc(RequestPath, server.handleRequest)
+    return http.Serve(apiListener, mux)
+
+}
+
+
+// Handle an API request
+func (s *apiServer) handleRequest(w http.ResponseWriter, r *http.Request) {
+
+    // Check method
+    if r.Method != http.MethodPost {
+        http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+
+    // Decode request
+    var request api.ServerRequest
+    if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestSize)).Decode(&request); err != nil {
+        http.Error(w, fmt.Sprintf("Could not decode API request: %s", err), http.StatusBadRequest)
+        return
+    }
+    if err := validateGlobalArgs(request.GlobalArgs); err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    // Run API command & write response
+    s.runCommand(r.Context(), w, request)
+
+}
+
+
+// Run an API command and write its output as the response
+// The command is killed if the context is cancelled, e.g. when the client disconnects
+func (s *apiServer) runCommand(ctx context.Context, w http.ResponseWriter, request api.ServerRequest) {
+
+    // Run API command
+    args := append([]string{}, s.globalArgs...)
+    args = append(args, request.GlobalArgs...)
+    args = append(args, "api")
+    args = append(args, request.Args...)
+    var stdout, stderr bytes.Buffer
+    cmd := exec.CommandContext(ctx, os.Args[0], args...)
+    cmd.Stdout = &stdout
+    cmd.Stderr = &stderr
+    if err := cmd.Run(); err != nil {
+        if ctx.Err() != nil {
+            s.log.Printlnf("API command '%s' was cancelled by the client.", strings.Join(request.Args, " "))
+            return
+        }
+        s.log.Error(fmt.Errorf("Could not run API command: %w: %s", err, stderr.String()))
+        http.Error(w, fmt.Sprintf("Could not run API command: %s", err), http.StatusInternalServerError)
+        return
+    }
+
+    // Write response
+    w.Header().Set("Content-Type", "application/json")
+    w.Write(stdout.Bytes())
+
+}
+
+
+// Check that request global args only set permitted flags
+func validateGlobalArgs(args []string) error {
+    if len(args) % 2 != 0 {
+        return errors.New("Invalid API request global arguments")
+    }
+    for ai := 0; ai < len(args); ai += 2 {
+        if !allowedGlobalFlags[args[ai]] {
+            return fmt.Errorf("Global flag %s is not permitted in API requests", args[ai])
+        }
+    }
+    return nil
+}
+
+
+// Check whether a string slice contains a value
+func contains(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,174 @@
+package apiserver
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+
+    "github.com/docker/docker/api/types"
+    "github.com/docker/docker/pkg/stdcopy"
+
+    "github.com/rocket-pool/smartnode/shared/services/audit"
+    "github.com/rocket-pool/smartnode/shared/services/support"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Config
+const (
+    ContainerPrefix = "rocketpool_"
+    DefaultLogLines = 200
+    MaxLogLines = 5000
+)
+
+
+// Read-only API commands available to support sessions
+var supportCommands = map[string]bool{
+    "node status": true,
+    "node rewards": true,
+    "node tx-queue": true,
+    "minipool status": true,
+    "minipool performance": true,
+    "network node-fee": true,
+    "queue status": true,
+    "wallet status": true,
+    "service client-status": true,
+    "service peers": true,
+    "service sync": true,
+    "service net-diag": true,
+    "service tasks": true,
+}
+
+
+// Services whose logs are available to support sessions
+var supportLogServices = map[string]bool{
+    "eth1": true,
+    "eth2": true,
+    "validator": true,
+    "node": true,
+    "watchtower": true,
+    "api": true,
+}
+
+
+// Handle a support API request
+func (s *apiServer) handleSupportRequest(w http.ResponseWriter, r *http.Request) {
+
+    // Authorize request
+    actor, ok := s.authorizeSupport(w, r)
+    if !ok {
+        return
+    }
+
+    // Check method
+    if r.Method != http.MethodPost {
+        http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+
+    // Decode request
+    var request api.ServerRequest
+    if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestSize)).Decode(&request); err != nil {
+        http.Error(w, fmt.Sprintf("Could not decode API request: %s", err), http.StatusBadRequest)
+        return
+    }
+
+    // Check command is read-only
+    command := strings.Join(request.Args, " ")
+    allowed := (len(request.GlobalArgs) == 0 && len(request.Args) == 2 && supportCommands[command])
+    s.recordAudit(audit.Entry{
+        Actor: actor,
+        Action: fmt.Sprintf("api %s", command),
+        Allowed: allowed,
+    })
+    if !allowed {
+        http.Error(w, fmt.Sprintf("Command '%s' is not available to support sessions", command), http.StatusForbidden)
+        return
+    }
+
+    // Run API command & write response
+    s.runCommand(r.Context(), w, request)
+
+}
+
+
+// Handle a support service logs request
+func (s *apiServer) handleSupportLogs(w http.ResponseWriter, r *http.Request) {
+
+    // Authorize request
+    actor, ok := s.authorizeSupport(w, r)
+    if !ok {
+        return
+    }
+
+    // Get & check parameters
+    service := r.URL.Query().Get("service")
+    tail := DefaultLogLines
+    if tailParam := r.URL.Query().Get("tail"); tailParam != "" {
+        var err error
+        if tail, err = strconv.Atoi(tailParam); err != nil || tail <= 0 || tail > MaxLogLines {
+            http.Error(w, fmt.Sprintf("Invalid tail value; must be between 1 and %d", MaxLogLines), http.StatusBadRequest)
+            return
+        }
+    }
+    allowed := supportLogServices[service]
+    s.recordAudit(audit.Entry{
+        Actor: actor,
+        Action: fmt.Sprintf("logs %s", service),
+        Allowed: allowed,
+        Detail: fmt.Sprintf("%d lines", tail),
+    })
+    if !allowed {
+        http.Error(w, fmt.Sprintf("Logs for service '%s' are not available to support sessions", service), http.StatusForbidden)
+        return
+    }
+
+    // Get & write logs
+    if s.d == nil {
+        http.Error(w, "Container logs are not available as the docker socket is disabled on this node", http.StatusServiceUnavailable)
+        return
+    }
+    logs, err := s.d.ContainerLogs(context.Background(), ContainerPrefix + service, types.ContainerLogsOptions{
+        ShowStdout: true,
+        ShowStderr: true,
+        Timestamps: true,
+        Tail: strconv.Itoa(tail),
+    })
+    if err != nil {
+        http.Error(w, fmt.Sprintf("Could not get %s logs: %s", service, err), http.StatusInternalServerError)
+        return
+    }
+    defer logs.Close()
+    w.Header().Set("Content-Type", "text/plain")
+    stdcopy.StdCopy(w, w, logs)
+
+}
+
+
+// Authorize a support request by its bearer token, recording failed attempts
+func (s *apiServer) authorizeSupport(w http.ResponseWriter, r *http.Request) (string, bool) {
+    token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+    grant, err := support.Authorize(s.dataPath, token)
+    if err != nil {
+        s.recordAudit(audit.Entry{
+            Actor: "support",
+            Action: fmt.Sprintf("%s %s", r.Method, r.URL.Path),
+            Allowed: false,
+            Detail: err.Error(),
+        })
+        http.Error(w, err.Error(), http.StatusUnauthorized)
+        return "", false
+    }
+    return fmt.Sprintf("support:%s", grant.ID), true
+}
+
+
+// Record an audit log entry
+func (s *apiServer) recordAudit(entry audit.Entry) {
+    if err := audit.Record(s.dataPath, entry); err != nil {
+        s.log.Error(err)
+    }
+}
@@ -0,0 +1,119 @@
+package node
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/backup"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+var backupNodeInterval, _ = time.ParseDuration("1h")
+
+
+// Backup node task
+type backupNode struct {
+    c *cli.Context
+    log log.ColorLogger
+    cfg config.RocketPoolConfig
+    alerter *alerts.Alerter
+}
+
+
+// Create backup node task
+func newBackupNode(c *cli.Context, logger log.ColorLogger) (*backupNode, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &backupNode{
+        c: c,
+        log: logger,
+        cfg: cfg,
+        alerter: alerter,
+    }, nil
+
+}
+
+
+// Back up the node, alerting on failure
+func (t *backupNode) run() error {
+    err := t.backup()
+    if err != nil {
+        if err := t.alerter.Send("Backup failed", err.Error()); err != nil {
+            t.log.Error(err)
+        }
+    }
+    return err
+}
+
+
+// Back up the node's wallet, keys & settings to the configured destination when the latest backup is due
+func (t *backupNode) backup() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
+    // Check if backups are enabled
+    if t.cfg.Backup.Destination == "" {
+        return nil
+    }
+
+    // Get backup interval
+    interval, err := t.cfg.GetBackupInterval()
+    if err != nil {
+        return err
+    }
+
+    // Get backup destination
+    destination, err := services.GetBackupDestination(t.c)
+    if err != nil {
+        return err
+    }
+
+    // Check latest backup time
+    backups, err := backup.List(destination)
+    if err != nil {
+        return err
+    }
+    if len(backups) > 0 {
+        if latest, ok := backup.GetBackupTime(backups[len(backups) - 1]); ok && time.Since(latest) < interval {
+            return nil
+        }
+    }
+
+    // Log
+    t.log.Printlnf("Backing up node to %s destination...", t.cfg.Backup.Destination)
+
+    // Get passphrase
+    passphrase, err := services.GetBackupPassphrase(t.c)
+    if err != nil {
+        return err
+    }
+
+    // Create backup
+    name, err := backup.Create(destination, services.GetBackupRootPath(t.c), backup.DefaultExcludes, passphrase, t.cfg.GetBackupRetention())
+    if err != nil {
+        return fmt.Errorf("Could not back up node: %w", err)
+    }
+
+    // Log & return
+    t.log.Printlnf("Successfully created backup %s.", name)
+    return nil
+
+}
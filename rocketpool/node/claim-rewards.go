@@ -0,0 +1,309 @@
+package node
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/settings"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/gas"
+    "github.com/rocket-pool/smartnode/shared/services/rewards"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Settings
+var claimRewardsActiveInterval, _ = time.ParseDuration("15m")
+var claimRewardsIdleInterval, _ = time.ParseDuration("1h")
+
+
+// Claim rewards task
+type claimRewards struct {
+    c *cli.Context
+    log log.ColorLogger
+    cfg config.RocketPoolConfig
+    w *wallet.Wallet
+    txm *transactions.Manager
+    ec *ethclient.Client
+    rp *rocketpool.RocketPool
+    cache *contracts.Cache
+    alerter *alerts.Alerter
+    pending bool
+}
+
+
+// Create claim rewards task
+func newClaimRewards(c *cli.Context, logger log.ColorLogger) (*claimRewards, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &claimRewards{
+        c: c,
+        log: logger,
+        cfg: cfg,
+        w: w,
+        txm: txm,
+        ec: ec,
+        rp: rp,
+        cache: cache,
+        alerter: alerter,
+    }, nil
+
+}
+
+
+// Withdraw node balances & rewards from withdrawable minipools
+func (t *claimRewards) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
+    // Check if automatic claims are enabled
+    if t.cfg.Smartnode.AutoWithdrawDisabled {
+        return nil
+    }
+
+    // Wait for eth client to sync
+    if err := services.WaitEthClientSynced(t.c, true); err != nil {
+        return err
+    }
+
+    // Log
+    t.log.Println("Checking for minipool rewards to claim...")
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Get claimable minipools
+    minipools, err := t.getClaimableMinipools(nodeAccount.Address)
+    if err != nil {
+        return err
+    }
+    t.pending = (len(minipools) > 0)
+    if len(minipools) == 0 {
+        return nil
+    }
+
+    // Get & check gas price
+    gasPrice, err := services.GetGasPrice(t.c)
+    if err != nil {
+        return err
+    }
+    if err := gas.CheckMaxFee(gasPrice, t.cfg.Smartnode.MaxFee); err != nil {
+        t.log.Warnf("%d minipools have rewards to claim, but %s Claims are deferred.", len(minipools), err.Error())
+        return nil
+    }
+    if t.cfg.Smartnode.AutoWithdrawMaxGasPrice > 0 && gasPrice.Cmp(eth.GweiToWei(t.cfg.Smartnode.AutoWithdrawMaxGasPrice)) > 0 {
+        t.log.Warnf("%d minipools have rewards to claim, but the current gas price of %s is above the %s limit; claims are deferred.", len(minipools), units.FormatGwei(gasPrice), units.FormatGwei(eth.GweiToWei(t.cfg.Smartnode.AutoWithdrawMaxGasPrice)))
+        return nil
+    }
+
+    // Log
+    t.log.Printlnf("%d minipools have rewards to claim...", len(minipools))
+
+    // Claim rewards
+    for _, mp := range minipools {
+        if err := t.claimMinipoolRewards(mp, gasPrice); err != nil {
+            t.log.Error(fmt.Errorf("Could not claim minipool %s rewards: %w", mp.Address.Hex(), err))
+        }
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Check whether the node has minipool rewards waiting to be claimed
+func (t *claimRewards) isActive() bool {
+    return t.pending
+}
+
+
+// Get withdrawable minipools which have passed the withdrawal delay
+func (t *claimRewards) getClaimableMinipools(nodeAddress common.Address) ([]*minipool.Minipool, error) {
+
+    // Data
+    var wg1 errgroup.Group
+    var addresses []common.Address
+    var currentBlock uint64
+    var withdrawalDelay uint64
+
+    // Get node minipool addresses
+    wg1.Go(func() error {
+        var err error
+        addresses, err = minipool.GetNodeMinipoolAddresses(t.rp, nodeAddress, nil)
+        return err
+    })
+
+    // Get current block
+    wg1.Go(func() error {
+        header, err := t.ec.HeaderByNumber(context.Background(), nil)
+        if err == nil {
+            currentBlock = header.Number.Uint64()
+        }
+        return err
+    })
+
+    // Get withdrawal delay
+    wg1.Go(func() error {
+        var err error
+        withdrawalDelay, err = t.cache.GetUint64("settings.minipoolWithdrawalDelay", func() (uint64, error) { return settings.GetMinipoolWithdrawalDelay(t.rp, nil) })
+        return err
+    })
+
+    // Wait for data
+    if err := wg1.Wait(); err != nil {
+        return []*minipool.Minipool{}, err
+    }
+
+    // Create minipool contracts
+    minipools := make([]*minipool.Minipool, len(addresses))
+    for mi, address := range addresses {
+        mp, err := minipool.NewMinipool(t.rp, address)
+        if err != nil {
+            return []*minipool.Minipool{}, err
+        }
+        minipools[mi] = mp
+    }
+
+    // Data
+    var wg2 errgroup.Group
+    statuses := make([]minipool.StatusDetails, len(minipools))
+
+    // Load minipool statuses
+    for mi, mp := range minipools {
+        mi, mp := mi, mp
+        wg2.Go(func() error {
+            status, err := mp.GetStatusDetails(nil)
+            if err == nil { statuses[mi] = status }
+            return err
+        })
+    }
+
+    // Wait for data
+    if err := wg2.Wait(); err != nil {
+        return []*minipool.Minipool{}, err
+    }
+
+    // Filter minipools by status & withdrawal delay
+    claimableMinipools := []*minipool.Minipool{}
+    for mi, mp := range minipools {
+        if statuses[mi].Status == rptypes.Withdrawable && (currentBlock - statuses[mi].StatusBlock) >= withdrawalDelay {
+            claimableMinipools = append(claimableMinipools, mp)
+        }
+    }
+
+    // Return
+    return claimableMinipools, nil
+
+}
+
+
+// Claim a minipool's rewards
+func (t *claimRewards) claimMinipoolRewards(mp *minipool.Minipool, gasPrice *big.Int) error {
+
+    // Get transactor
+    opts, err := t.w.GetNodeAccountTransactor()
+    if err != nil {
+        return err
+    }
+    opts.GasPrice = gasPrice
+    opts, err = services.GetTaskTransactor(t.c, "claim-rewards", opts, t.log)
+    if err != nil {
+        return err
+    }
+
+    // Simulate claim & check its value against the gas cost
+    simulation, err := rewards.SimulateClaim(t.rp, mp.Address, opts.From, gasPrice)
+    if err != nil {
+        return err
+    }
+    minRatio := t.cfg.GetMinClaimGasRatio()
+    meetsMinRatio, err := simulation.MeetsMinRatio(minRatio)
+    if err != nil {
+        return err
+    }
+    if !meetsMinRatio {
+        t.log.Warnf("Minipool %s has %s and %s to claim, but the estimated gas cost of %s is above the minimum value to gas ratio of %.2f; the claim is deferred.", mp.Address.Hex(), units.FormatToken(simulation.NETHAmount, units.TokenDecimals, "nETH"), units.FormatEth(simulation.ETHAmount), units.FormatGasCost(simulation.GasCost, t.cfg.GetGasToken()), minRatio)
+        return nil
+    }
+    amount := simulation.NETHAmount
+
+    // Log
+    t.log.Printlnf("Claiming minipool %s rewards...", mp.Address.Hex())
+
+    // Withdraw
+    txReceipt, err := t.txm.Transact(opts, fmt.Sprintf("Claim minipool %s rewards", mp.Address.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := mp.Withdraw(opts)
+        return err
+    })
+    if transactions.IsRehearsed(err) {
+        return nil
+    } else if err != nil {
+        return err
+    }
+
+    // Record claim
+    if err := rewards.RecordClaim(t.cfg.GetDataPath(), rewards.Claim{
+        Time: time.Now(),
+        MinipoolAddress: mp.Address,
+        Amount: amount,
+        TxHash: txReceipt.TxHash,
+        GasPrice: gasPrice,
+        Automatic: true,
+    }); err != nil {
+        t.log.Error(err)
+    }
+
+    // Log & alert
+    message := fmt.Sprintf("Successfully claimed %s from minipool %s.", units.FormatToken(amount, units.TokenDecimals, "nETH"), mp.Address.Hex())
+    t.log.Println(message)
+    if err := t.alerter.Send("Rewards claimed", message); err != nil {
+        t.log.Error(err)
+    }
+
+    // Return
+    return nil
+
+}
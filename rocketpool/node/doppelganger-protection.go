@@ -0,0 +1,112 @@
+package node
+
+import (
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/containers"
+    "github.com/rocket-pool/smartnode/shared/services/doppelganger"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+var doppelgangerProtectionInterval, _ = time.ParseDuration("1m")
+
+
+// Doppelganger protection task
+type doppelgangerProtection struct {
+    c *cli.Context
+    log log.ColorLogger
+    cfg config.RocketPoolConfig
+    bc beacon.Client
+    containers *containers.Controller
+}
+
+
+// Create doppelganger protection task
+func newDoppelgangerProtection(c *cli.Context, logger log.ColorLogger) (*doppelgangerProtection, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+    cc, err := services.GetContainerController(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &doppelgangerProtection{
+        c: c,
+        log: logger,
+        cfg: cfg,
+        bc: bc,
+        containers: cc,
+    }, nil
+
+}
+
+
+// Keep the validator stopped until the configured number of epochs has passed since a key import or recovery
+func (t *doppelgangerProtection) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
+    // Check if doppelganger protection is enabled
+    if !t.cfg.Smartnode.DoppelgangerProtection {
+        return nil
+    }
+
+    // Get eth2 config
+    eth2Config, err := t.bc.GetEth2Config()
+    if err != nil {
+        return err
+    }
+
+    // Get delay end time
+    delayEnd, pending, err := doppelganger.GetDelayEnd(t.cfg.GetDataPath(), t.cfg.GetDoppelgangerDelayEpochs(), eth2Config.SecondsPerEpoch)
+    if err != nil {
+        return err
+    }
+    if !pending {
+        return nil
+    }
+
+    // Keep validator stopped while delay is active
+    if time.Now().Before(delayEnd) {
+        if err := t.containers.Stop(containers.ValidatorService, "Doppelganger protection after validator keys were imported or recovered"); err != nil {
+            return err
+        }
+        t.log.Printlnf("Validator keys were recently imported or recovered, the validator container will be started at %s.", delayEnd.Format(time.RFC1123))
+        return nil
+    }
+
+    // Start validator container
+    t.log.Println("Doppelganger protection delay has elapsed, starting validator container...")
+    if err := t.containers.Restart(containers.ValidatorService, "Doppelganger protection delay elapsed"); err != nil {
+        return err
+    }
+
+    // Clear key change
+    if err := doppelganger.ClearKeyChange(t.cfg.GetDataPath()); err != nil {
+        return err
+    }
+
+    // Log & return
+    if t.containers.Direct() {
+        t.log.Println("Successfully started validator container.")
+    } else {
+        t.log.Println("Requested a validator container start from the service agent.")
+    }
+    return nil
+
+}
@@ -0,0 +1,172 @@
+package node
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/portmap"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+var (
+    maintainPortMappingsInterval, _ = time.ParseDuration("10m")
+    portMappingLease, _ = time.ParseDuration("1h")
+)
+
+
+// Maintain port mappings task
+type maintainPortMappings struct {
+    c *cli.Context
+    log log.ColorLogger
+    alerter *alerts.Alerter
+}
+
+
+// A p2p port to map
+type mappedPort struct {
+    Chain string
+    Port int
+}
+
+
+// Create maintain port mappings task
+func newMaintainPortMappings(c *cli.Context, logger log.ColorLogger) (*maintainPortMappings, error) {
+
+    // Get services
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &maintainPortMappings{
+        c: c,
+        log: logger,
+        alerter: alerter,
+    }, nil
+
+}
+
+
+// Map the managed clients' p2p ports on the router and renew the mappings before their leases expire
+// Mappings are removed when port mapping is disabled or a port changes
+func (t *maintainPortMappings) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+
+    // Load previous status
+    previous, found, err := portmap.LoadStatus(cfg.GetDataPath())
+    if err != nil {
+        return err
+    }
+
+    // Get ports to map
+    ports := []mappedPort{}
+    if cfg.PortMapping.Method != "" {
+        if !cfg.Chains.Eth1.External {
+            ports = append(ports, mappedPort{Chain: "eth1", Port: cfg.GetEth1P2PPort()})
+        }
+        if !cfg.Chains.Eth2.External {
+            ports = append(ports, mappedPort{Chain: "eth2", Port: cfg.GetEth2P2PPort()})
+        }
+    }
+
+    // Remove previous mappings which are no longer required
+    if found {
+        t.removeMappings(cfg, previous, ports)
+    }
+    if len(ports) == 0 {
+        return portmap.DeleteStatus(cfg.GetDataPath())
+    }
+
+    // Map ports
+    status := portmap.Status{Updated: time.Now(), Alerted: previous.Alerted}
+    if gateway, err := portmap.Discover(cfg.PortMapping.Method, cfg.PortMapping.Host); err != nil {
+        status.Error = err.Error()
+    } else {
+        status.Method = gateway.Method()
+        if externalIP, err := gateway.GetExternalIP(); err == nil {
+            status.ExternalIP = externalIP
+        }
+        for _, port := range ports {
+            for _, protocol := range []string{portmap.ProtocolTCP, portmap.ProtocolUDP} {
+                mapping := portmap.Mapping{Chain: port.Chain, Protocol: protocol, Port: port.Port}
+                if lease, err := gateway.AddMapping(protocol, port.Port, fmt.Sprintf("Rocket Pool %s p2p", port.Chain), portMappingLease); err != nil {
+                    mapping.Error = err.Error()
+                } else {
+                    mapping.Expires = time.Now().Add(lease)
+                }
+                status.Mappings = append(status.Mappings, mapping)
+            }
+        }
+    }
+
+    // Alert once when mapping fails, and again when it recovers
+    if err := status.Err(); err != nil {
+        t.log.Println(fmt.Errorf("Could not map p2p ports: %w", err))
+        if !status.Alerted {
+            t.notify("Port mapping failed", fmt.Sprintf("The node could not map its p2p ports on the router, so the clients may not accept inbound peers once existing mappings expire: %s. Check that UPnP or NAT-PMP is enabled on the router, or forward the ports manually and disable port mapping.", err.Error()))
+            status.Alerted = true
+        }
+    } else {
+        for _, port := range ports {
+            t.log.Printlnf("Mapped %s p2p port %d via %s.", port.Chain, port.Port, status.Method)
+        }
+        if status.Alerted {
+            t.notify("Port mapping restored", "The node has mapped its p2p ports on the router again.")
+            status.Alerted = false
+        }
+    }
+
+    // Save status
+    return portmap.SaveStatus(cfg.GetDataPath(), status)
+
+}
+
+
+// Remove mappings from a previous status which are not in the current ports
+func (t *maintainPortMappings) removeMappings(cfg config.RocketPoolConfig, previous portmap.Status, ports []mappedPort) {
+    var gateway portmap.Gateway
+    for _, mapping := range previous.Mappings {
+        required := false
+        for _, port := range ports {
+            if port.Port == mapping.Port {
+                required = true
+            }
+        }
+        if required || mapping.Error != "" || time.Now().After(mapping.Expires) {
+            continue
+        }
+        if gateway == nil {
+            var err error
+            if gateway, err = portmap.Discover(previous.Method, cfg.PortMapping.Host); err != nil {
+                t.log.Println(fmt.Errorf("Could not remove previous port mappings: %w", err))
+                return
+            }
+        }
+        if err := gateway.DeleteMapping(mapping.Protocol, mapping.Port); err != nil {
+            t.log.Println(fmt.Errorf("Could not remove %s port mapping %d: %w", mapping.Protocol, mapping.Port, err))
+        } else {
+            t.log.Printlnf("Removed %s port mapping %d.", mapping.Protocol, mapping.Port)
+        }
+    }
+}
+
+
+// Send a notification
+func (t *maintainPortMappings) notify(title, message string) {
+    t.log.Println(message)
+    if err := t.alerter.Send(title, message); err != nil {
+        t.log.Error(err)
+    }
+}
+
@@ -0,0 +1,322 @@
+package node
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+const MinipoolNotificationsFile = "minipool-notifications.json"
+var minipoolNotificationsActiveInterval, _ = time.ParseDuration("1m")
+var minipoolNotificationsIdleInterval, _ = time.ParseDuration("10m")
+
+
+// Minipool notifications task
+type minipoolNotifications struct {
+    c *cli.Context
+    log log.ColorLogger
+    cfg config.RocketPoolConfig
+    w *wallet.Wallet
+    rp *rocketpool.RocketPool
+    bc beacon.Client
+    alerter *alerts.Alerter
+    pending bool
+}
+
+
+// Notified minipool events
+type minipoolNotificationState struct {
+    Assigned bool   `json:"assigned"`
+    Activated bool  `json:"activated"`
+}
+
+
+// Minipool notification details
+type minipoolNotificationDetails struct {
+    Address common.Address
+    Assigned bool
+    Activated bool
+    ActivationEpoch uint64
+    Pending bool
+}
+
+
+// Create minipool notifications task
+func newMinipoolNotifications(c *cli.Context, logger log.ColorLogger) (*minipoolNotifications, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &minipoolNotifications{
+        c: c,
+        log: logger,
+        cfg: cfg,
+        w: w,
+        rp: rp,
+        bc: bc,
+        alerter: alerter,
+    }, nil
+
+}
+
+
+// Notify the operator when node minipools are assigned user ETH or their validators are activated
+func (t *minipoolNotifications) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
+    // Wait for eth clients to sync
+    if err := services.WaitEthClientSynced(t.c, false); err != nil {
+        return err
+    }
+    if err := services.WaitBeaconClientSynced(t.c, false); err != nil {
+        return err
+    }
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Load notification state; existing minipools are recorded without notifying on first run
+    state, initialized, err := t.loadState()
+    if err != nil {
+        return err
+    }
+
+    // Get minipool details
+    minipools, err := t.getMinipoolNotificationDetails(nodeAccount.Address)
+    if err != nil {
+        return err
+    }
+
+    // Check minipools for new events
+    t.pending = false
+    for _, details := range minipools {
+        if details.Pending {
+            t.pending = true
+        }
+        address := details.Address.Hex()
+        mpState := state[address]
+
+        // User ETH assigned
+        if details.Assigned && !mpState.Assigned {
+            if initialized {
+                t.notify("Minipool assigned", fmt.Sprintf("Minipool %s has been assigned user ETH from the deposit pool and will begin staking shortly.", address))
+            }
+            mpState.Assigned = true
+        }
+
+        // Validator activated
+        if details.Activated && !mpState.Activated {
+            if initialized {
+                t.notify("Validator activated", fmt.Sprintf("The validator for minipool %s was activated on the beacon chain at epoch %d.", address, details.ActivationEpoch))
+            }
+            mpState.Activated = true
+        }
+
+        state[address] = mpState
+    }
+
+    // Save notification state
+    return t.saveState(state)
+
+}
+
+
+// Check whether the node has minipools waiting to be assigned or activated
+func (t *minipoolNotifications) isActive() bool {
+    return t.pending
+}
+
+
+// Get node minipool notification details
+func (t *minipoolNotifications) getMinipoolNotificationDetails(nodeAddress common.Address) ([]minipoolNotificationDetails, error) {
+
+    // Data
+    var wg1 errgroup.Group
+    var addresses []common.Address
+    var beaconHead beacon.BeaconHead
+
+    // Get node minipool addresses
+    wg1.Go(func() error {
+        var err error
+        addresses, err = minipool.GetNodeMinipoolAddresses(t.rp, nodeAddress, nil)
+        return err
+    })
+
+    // Get beacon head
+    wg1.Go(func() error {
+        var err error
+        beaconHead, err = t.bc.GetBeaconHead()
+        return err
+    })
+
+    // Wait for data
+    if err := wg1.Wait(); err != nil {
+        return []minipoolNotificationDetails{}, err
+    }
+
+    // Data
+    var wg2 errgroup.Group
+    minipools := make([]minipoolNotificationDetails, len(addresses))
+
+    // Load details
+    for mi, address := range addresses {
+        mi, address := mi, address
+        wg2.Go(func() error {
+            details, err := t.getMinipoolDetails(address, beaconHead)
+            if err == nil { minipools[mi] = details }
+            return err
+        })
+    }
+
+    // Wait for data
+    if err := wg2.Wait(); err != nil {
+        return []minipoolNotificationDetails{}, err
+    }
+
+    // Return
+    return minipools, nil
+
+}
+
+
+// Get minipool notification details
+func (t *minipoolNotifications) getMinipoolDetails(minipoolAddress common.Address, beaconHead beacon.BeaconHead) (minipoolNotificationDetails, error) {
+
+    // Create minipool
+    mp, err := minipool.NewMinipool(t.rp, minipoolAddress)
+    if err != nil {
+        return minipoolNotificationDetails{}, err
+    }
+
+    // Data
+    var wg errgroup.Group
+    var status rptypes.MinipoolStatus
+    var userDepositAssigned bool
+    var pubkey rptypes.ValidatorPubkey
+
+    // Load data
+    wg.Go(func() error {
+        var err error
+        status, err = mp.GetStatus(nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        userDepositAssigned, err = mp.GetUserDepositAssigned(nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        pubkey, err = minipool.GetMinipoolPubkey(t.rp, minipoolAddress, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return minipoolNotificationDetails{}, err
+    }
+
+    // Details
+    details := minipoolNotificationDetails{
+        Address: minipoolAddress,
+        Assigned: userDepositAssigned,
+    }
+
+    // Check validator activation
+    if status == rptypes.Staking {
+        validator, err := t.bc.GetValidatorStatus(pubkey, nil)
+        if err != nil {
+            return minipoolNotificationDetails{}, err
+        }
+        details.Activated = validator.Exists && validator.ActivationEpoch <= beaconHead.Epoch
+        details.ActivationEpoch = validator.ActivationEpoch
+    }
+
+    // Check whether the minipool has events still to come
+    details.Pending = (status == rptypes.Initialized || status == rptypes.Prelaunch || (status == rptypes.Staking && !details.Activated))
+
+    // Return
+    return details, nil
+
+}
+
+
+// Send a notification
+func (t *minipoolNotifications) notify(title, message string) {
+    t.log.Println(message)
+    if err := t.alerter.Send(title, message); err != nil {
+        t.log.Error(err)
+    }
+}
+
+
+// Load minipool notification state from disk
+func (t *minipoolNotifications) loadState() (map[string]minipoolNotificationState, bool, error) {
+    state := make(map[string]minipoolNotificationState)
+    stateBytes, err := ioutil.ReadFile(filepath.Join(t.cfg.GetDataPath(), MinipoolNotificationsFile))
+    if os.IsNotExist(err) {
+        return state, false, nil
+    }
+    if err != nil {
+        return nil, false, fmt.Errorf("Could not read minipool notification state: %w", err)
+    }
+    if err := json.Unmarshal(stateBytes, &state); err != nil {
+        return nil, false, fmt.Errorf("Could not decode minipool notification state: %w", err)
+    }
+    return state, true, nil
+}
+
+
+// Save minipool notification state to disk
+func (t *minipoolNotifications) saveState(state map[string]minipoolNotificationState) error {
+    stateBytes, err := json.Marshal(state)
+    if err != nil {
+        return fmt.Errorf("Could not encode minipool notification state: %w", err)
+    }
+    path := filepath.Join(t.cfg.GetDataPath(), MinipoolNotificationsFile)
+    if err := os.MkdirAll(filepath.Dir(path), alerts.DirMode); err != nil {
+        return fmt.Errorf("Could not create minipool notification state folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path, stateBytes, alerts.FileMode); err != nil {
+        return fmt.Errorf("Could not write minipool notification state: %w", err)
+    }
+    return nil
+}
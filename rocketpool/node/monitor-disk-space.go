@@ -0,0 +1,102 @@
+package node
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/diskspace"
+    "github.com/rocket-pool/smartnode/shared/services/metrics"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+var (
+    monitorDiskSpaceInterval, _ = time.ParseDuration("1h")
+    diskSpaceAlertInterval, _ = time.ParseDuration("24h")
+)
+
+
+// Monitor disk space task
+type monitorDiskSpace struct {
+    c *cli.Context
+    log log.ColorLogger
+    alerter *alerts.Alerter
+    metrics *metrics.Registry
+}
+
+
+// Create monitor disk space task
+func newMonitorDiskSpace(c *cli.Context, logger log.ColorLogger, registry *metrics.Registry) (*monitorDiskSpace, error) {
+
+    // Get services
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Define metrics
+    registry.Gauge("disk_available_bytes", "Available space on the disk holding the smart node data folder.")
+    registry.Gauge("disk_total_bytes", "Total size of the disk holding the smart node data folder.")
+    registry.Gauge("disk_growth_bytes_per_day", "Growth in used disk space per day over the last week.")
+    registry.Gauge("disk_days_until_full", "Predicted days until the disk is full at the current growth rate, or -1 if it is not filling up.")
+
+    // Return task
+    return &monitorDiskSpace{
+        c: c,
+        log: logger,
+        alerter: alerter,
+        metrics: registry,
+    }, nil
+
+}
+
+
+// Record the disk space of the data folder, predict when it will be full, and alert if that is sooner than the threshold
+// Chain data shares this disk unless it has been moved elsewhere with a chain data path
+func (t *monitorDiskSpace) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+
+    // Sample disk space
+    sample, err := diskspace.GetSample(cfg.GetDataPath())
+    if err != nil {
+        return err
+    }
+    history, err := diskspace.LoadHistory(cfg.GetDataPath())
+    if err != nil {
+        return err
+    }
+    history.Add(sample)
+
+    // Get prediction & record metrics
+    prediction, _ := history.Predict()
+    t.metrics.Set("disk_available_bytes", float64(prediction.Available))
+    t.metrics.Set("disk_total_bytes", float64(prediction.Total))
+    if prediction.GrowthKnown {
+        t.metrics.Set("disk_growth_bytes_per_day", prediction.GrowthPerDay)
+        t.metrics.Set("disk_days_until_full", prediction.DaysUntilFull)
+    }
+
+    // Alert at most daily while the disk is predicted to be full within the threshold
+    threshold := cfg.GetAlertDiskFullDays()
+    if prediction.GrowthKnown && prediction.DaysUntilFull >= 0 && prediction.DaysUntilFull < threshold && time.Since(history.LastAlert) >= diskSpaceAlertInterval {
+        message := fmt.Sprintf("The node's disk has %.1f GB available and is filling by %.1f GB per day; it will be full in about %.0f days. Free up space, e.g. with 'rocketpool service prune-eth1', or move chain data to a larger disk.", float64(prediction.Available) / 1e9, prediction.GrowthPerDay / 1e9, prediction.DaysUntilFull)
+        t.log.Println(message)
+        if err := t.alerter.Send("Disk filling up", message); err != nil {
+            t.log.Error(err)
+        }
+        history.LastAlert = time.Now()
+    }
+
+    // Save history
+    return diskspace.SaveHistory(cfg.GetDataPath(), history)
+
+}
+
@@ -0,0 +1,216 @@
+package node
+
+import (
+    "context"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/settings"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/events"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+var nodeEventsInterval, _ = time.ParseDuration("15s")
+
+
+// Node events task
+type nodeEvents struct {
+    c *cli.Context
+    log log.ColorLogger
+    w *wallet.Wallet
+    ec *ethclient.Client
+    rp *rocketpool.RocketPool
+    cache *contracts.Cache
+    bc beacon.Client
+    stream *events.Stream
+    initialized bool
+    minipoolStatuses map[common.Address]rptypes.MinipoolStatus
+    claimable map[common.Address]bool
+    synced map[string]bool
+}
+
+
+// Create node events task
+func newNodeEvents(c *cli.Context, logger log.ColorLogger, stream *events.Stream) (*nodeEvents, error) {
+
+    // Get services
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &nodeEvents{
+        c: c,
+        log: logger,
+        w: w,
+        ec: ec,
+        rp: rp,
+        cache: cache,
+        bc: bc,
+        stream: stream,
+        minipoolStatuses: make(map[common.Address]rptypes.MinipoolStatus),
+        claimable: make(map[common.Address]bool),
+        synced: make(map[string]bool),
+    }, nil
+
+}
+
+
+// Publish events for changes to client sync status and node minipools
+// The first run records the current state without publishing events
+func (t *nodeEvents) run() error {
+
+    // Check client sync status
+    eth1Synced := t.checkSyncStatus("eth1", func() (bool, error) {
+        progress, err := t.ec.SyncProgress(context.Background())
+        return (progress == nil), err
+    })
+    t.checkSyncStatus("eth2", func() (bool, error) {
+        syncStatus, err := t.bc.GetSyncStatus()
+        return !syncStatus.Syncing, err
+    })
+
+    // Minipool state can only be trusted once the eth1 client is synced
+    if !eth1Synced {
+        return nil
+    }
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Get minipool statuses
+    statuses, claimable, err := t.getMinipoolStatuses(nodeAccount.Address)
+    if err != nil {
+        return err
+    }
+
+    // Check for minipool changes
+    for address, status := range statuses {
+        previousStatus, known := t.minipoolStatuses[address]
+        if t.initialized && !known {
+            t.stream.Publish(events.DepositDetected, events.DepositData{Minipool: address, Status: status})
+        } else if t.initialized && status != previousStatus {
+            t.stream.Publish(events.MinipoolStatusChanged, events.MinipoolStatusData{Minipool: address, PreviousStatus: previousStatus, Status: status})
+        }
+        if t.initialized && claimable[address] && !t.claimable[address] {
+            t.stream.Publish(events.RewardsClaimable, events.RewardsClaimableData{Minipool: address})
+        }
+    }
+    t.minipoolStatuses = statuses
+    t.claimable = claimable
+    t.initialized = true
+
+    // Return
+    return nil
+
+}
+
+
+// Check a client's sync status and publish an event if it has changed; returns whether the client is synced
+// Unreachable clients are treated as not synced
+func (t *nodeEvents) checkSyncStatus(client string, getSynced func() (bool, error)) bool {
+    synced, err := getSynced()
+    synced = synced && (err == nil)
+    if previous, known := t.synced[client]; known && synced != previous {
+        t.stream.Publish(events.SyncStatusChanged, events.SyncStatusData{Client: client, Synced: synced})
+    }
+    t.synced[client] = synced
+    return synced
+}
+
+
+// Get node minipool statuses, and which minipools have rewards to claim
+func (t *nodeEvents) getMinipoolStatuses(nodeAddress common.Address) (map[common.Address]rptypes.MinipoolStatus, map[common.Address]bool, error) {
+
+    // Data
+    var wg1 errgroup.Group
+    var addresses []common.Address
+    var currentBlock uint64
+    var withdrawalDelay uint64
+
+    // Get node minipool addresses
+    wg1.Go(func() error {
+        var err error
+        addresses, err = minipool.GetNodeMinipoolAddresses(t.rp, nodeAddress, nil)
+        return err
+    })
+
+    // Get current block
+    wg1.Go(func() error {
+        header, err := t.ec.HeaderByNumber(context.Background(), nil)
+        if err == nil {
+            currentBlock = header.Number.Uint64()
+        }
+        return err
+    })
+
+    // Get withdrawal delay
+    wg1.Go(func() error {
+        var err error
+        withdrawalDelay, err = t.cache.GetUint64("settings.minipoolWithdrawalDelay", func() (uint64, error) { return settings.GetMinipoolWithdrawalDelay(t.rp, nil) })
+        return err
+    })
+
+    // Wait for data
+    if err := wg1.Wait(); err != nil {
+        return nil, nil, err
+    }
+
+    // Data
+    var wg2 errgroup.Group
+    details := make([]minipool.StatusDetails, len(addresses))
+
+    // Load minipool statuses
+    for mi, address := range addresses {
+        mi, address := mi, address
+        wg2.Go(func() error {
+            mp, err := minipool.NewMinipool(t.rp, address)
+            if err != nil {
+                return err
+            }
+            status, err := mp.GetStatusDetails(nil)
+            if err == nil { details[mi] = status }
+            return err
+        })
+    }
+
+    // Wait for data
+    if err := wg2.Wait(); err != nil {
+        return nil, nil, err
+    }
+
+    // Build statuses
+    statuses := make(map[common.Address]rptypes.MinipoolStatus)
+    claimable := make(map[common.Address]bool)
+    for mi, address := range addresses {
+        statuses[address] = details[mi].Status
+        claimable[address] = (details[mi].Status == rptypes.Withdrawable && (currentBlock - details[mi].StatusBlock) >= withdrawalDelay)
+    }
+
+    // Return
+    return statuses, claimable, nil
+
+}
@@ -1,10 +1,16 @@
 package node
 
 import (
+    "fmt"
+    "path/filepath"
+
     "github.com/fatih/color"
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/events"
+    "github.com/rocket-pool/smartnode/shared/services/metrics"
+    "github.com/rocket-pool/smartnode/shared/services/scheduler"
     "github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -12,6 +18,20 @@ import (
 // Config
 const (
     StakePrelaunchMinipoolsColor = color.FgBlue
+    DoppelgangerProtectionColor = color.FgMagenta
+    MinipoolNotificationsColor = color.FgGreen
+    ClaimRewardsColor = color.FgYellow
+    VerifyWithdrawalCredentialsColor = color.FgRed
+    BackupNodeColor = color.FgCyan
+    ReplaceStuckTransactionsColor = color.FgHiBlue
+    NodeEventsColor = color.FgHiGreen
+    RunJobsColor = color.FgHiYellow
+    ConfigReloadColor = color.FgHiWhite
+    RecordNodeSnapshotColor = color.FgHiMagenta
+    MonitorDiskSpaceColor = color.FgHiCyan
+    TrackValidatorPerformanceColor = color.FgHiRed
+    ProposalNotificationsColor = color.FgHiBlack
+    MaintainPortMappingsColor = color.FgWhite
 )
 
 
@@ -31,15 +51,89 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+    // Configure logging
+    if err := services.ConfigureLogging(c, "node"); err != nil { return err }
+
     // Wait until node is registered
     if err := services.WaitNodeRegistered(c, true); err != nil { return err }
 
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return err }
+
+    // Initialize event stream & metrics
+    stream := events.NewStream()
+    registry := metrics.NewRegistry(metrics.NodeNamespace)
+
     // Initialize tasks
-    stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+    stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger("stake-prelaunch-minipools", StakePrelaunchMinipoolsColor))
+    if err != nil { return err }
+    doppelgangerProtection, err := newDoppelgangerProtection(c, log.NewColorLogger("doppelganger-protection", DoppelgangerProtectionColor))
+    if err != nil { return err }
+    minipoolNotifications, err := newMinipoolNotifications(c, log.NewColorLogger("minipool-notifications", MinipoolNotificationsColor))
+    if err != nil { return err }
+    claimRewards, err := newClaimRewards(c, log.NewColorLogger("claim-rewards", ClaimRewardsColor))
+    if err != nil { return err }
+    verifyWithdrawalCredentials, err := newVerifyWithdrawalCredentials(c, log.NewColorLogger("verify-withdrawal-credentials", VerifyWithdrawalCredentialsColor))
+    if err != nil { return err }
+    backupNode, err := newBackupNode(c, log.NewColorLogger("backup-node", BackupNodeColor))
+    if err != nil { return err }
+    replaceStuckTransactions, err := newReplaceStuckTransactions(c, log.NewColorLogger("replace-stuck-transactions", ReplaceStuckTransactionsColor))
     if err != nil { return err }
+    nodeEvents, err := newNodeEvents(c, log.NewColorLogger("node-events", NodeEventsColor), stream)
+    if err != nil { return err }
+    runJobs, err := newRunJobs(c, log.NewColorLogger("run-jobs", RunJobsColor))
+    if err != nil { return err }
+    recordNodeSnapshot, err := newRecordNodeSnapshot(c, log.NewColorLogger("record-node-snapshot", RecordNodeSnapshotColor))
+    if err != nil { return err }
+    monitorDiskSpace, err := newMonitorDiskSpace(c, log.NewColorLogger("monitor-disk-space", MonitorDiskSpaceColor), registry)
+    if err != nil { return err }
+    trackValidatorPerformance, err := newTrackValidatorPerformance(c, log.NewColorLogger("track-validator-performance", TrackValidatorPerformanceColor), registry)
+    if err != nil { return err }
+    proposalNotifications, err := newProposalNotifications(c, log.NewColorLogger("proposal-notifications", ProposalNotificationsColor))
+    if err != nil { return err }
+    maintainPortMappings, err := newMaintainPortMappings(c, log.NewColorLogger("maintain-port-mappings", MaintainPortMappingsColor))
+    if err != nil { return err }
+
+    // Register & start tasks
+    sched := scheduler.New(cfg, "node")
+    if err := sched.RegisterAdaptive("stake-prelaunch-minipools", stakePrelaunchMinipoolsActiveInterval, stakePrelaunchMinipoolsIdleInterval, stakePrelaunchMinipools.run, stakePrelaunchMinipools.isActive, stakePrelaunchMinipools.log); err != nil { return err }
+    if err := sched.Register("doppelganger-protection", doppelgangerProtectionInterval, doppelgangerProtection.run, doppelgangerProtection.log); err != nil { return err }
+    if err := sched.RegisterAdaptive("minipool-notifications", minipoolNotificationsActiveInterval, minipoolNotificationsIdleInterval, minipoolNotifications.run, minipoolNotifications.isActive, minipoolNotifications.log); err != nil { return err }
+    if err := sched.RegisterAdaptive("claim-rewards", claimRewardsActiveInterval, claimRewardsIdleInterval, claimRewards.run, claimRewards.isActive, claimRewards.log); err != nil { return err }
+    if err := sched.Register("verify-withdrawal-credentials", verifyWithdrawalCredentialsInterval, verifyWithdrawalCredentials.run, verifyWithdrawalCredentials.log); err != nil { return err }
+    if err := sched.Register("backup-node", backupNodeInterval, backupNode.run, backupNode.log); err != nil { return err }
+    if err := sched.RegisterAdaptive("replace-stuck-transactions", replaceStuckTransactionsActiveInterval, replaceStuckTransactionsIdleInterval, replaceStuckTransactions.run, replaceStuckTransactions.isActive, replaceStuckTransactions.log); err != nil { return err }
+    if err := sched.Register("node-events", nodeEventsInterval, nodeEvents.run, nodeEvents.log); err != nil { return err }
+    if err := sched.RegisterAdaptive("run-jobs", runJobsActiveInterval, runJobsIdleInterval, runJobs.run, runJobs.isActive, runJobs.log); err != nil { return err }
+    if err := sched.Register("record-node-snapshot", recordNodeSnapshotInterval, recordNodeSnapshot.run, recordNodeSnapshot.log); err != nil { return err }
+    if err := sched.Register("monitor-disk-space", monitorDiskSpaceInterval, monitorDiskSpace.run, monitorDiskSpace.log); err != nil { return err }
+    if err := sched.Register("track-validator-performance", trackValidatorPerformanceInterval, trackValidatorPerformance.run, trackValidatorPerformance.log); err != nil { return err }
+    if err := sched.Register("proposal-notifications", proposalNotificationsInterval, proposalNotifications.run, proposalNotifications.log); err != nil { return err }
+    if err := sched.Register("maintain-port-mappings", maintainPortMappingsInterval, maintainPortMappings.run, maintainPortMappings.log); err != nil { return err }
+    if err := sched.Start(); err != nil { return err }
+
+    // Reload task settings when the user settings change
+    if err := services.WatchConfig(c, "node", log.NewColorLogger("config-reload", ConfigReloadColor), sched.Reload); err != nil { return err }
+
+    // Serve event stream
+    socketPath := filepath.Join(cfg.GetDataPath(), events.SocketFile)
+    go (func() {
+        nodeEvents.log.Printlnf("Streaming node events on %s...", socketPath)
+        if err := stream.Serve(socketPath); err != nil {
+            nodeEvents.log.Error(err)
+        }
+    })()
 
-    // Start tasks
-    stakePrelaunchMinipools.Start()
+    // Serve metrics; disabled if no address is set
+    if cfg.Smartnode.MetricsAddress != "" {
+        go (func() {
+            monitorDiskSpace.log.Printlnf("Serving node metrics on %s%s...", cfg.Smartnode.MetricsAddress, metrics.MetricsPath)
+            if err := registry.Serve(cfg.Smartnode.MetricsAddress); err != nil {
+                monitorDiskSpace.log.Error(fmt.Errorf("Could not serve node metrics: %w", err))
+            }
+        })()
+    }
 
     // Block thread
     select {}
@@ -0,0 +1,228 @@
+package node
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "sort"
+    "strconv"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+const ProposalNotificationsFile = "proposal-notifications.json"
+var proposalNotificationsInterval, _ = time.ParseDuration("2m")
+
+
+// Proposal notifications task
+type proposalNotifications struct {
+    c *cli.Context
+    log log.ColorLogger
+    cfg config.RocketPoolConfig
+    w *wallet.Wallet
+    rp *rocketpool.RocketPool
+    bc beacon.Client
+    alerter *alerts.Alerter
+}
+
+
+// Upcoming block proposal
+type upcomingProposal struct {
+    MinipoolAddress common.Address
+    ValidatorIndex uint64
+    Slot uint64
+}
+
+
+// Create proposal notifications task
+func newProposalNotifications(c *cli.Context, logger log.ColorLogger) (*proposalNotifications, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &proposalNotifications{
+        c: c,
+        log: logger,
+        cfg: cfg,
+        w: w,
+        rp: rp,
+        bc: bc,
+        alerter: alerter,
+    }, nil
+
+}
+
+
+// Notify the operator when node validators are scheduled to propose blocks in the current or next epoch
+// Proposer duties are only known up to the end of the next epoch, so this gives between 6 and 12 minutes' notice
+func (t *proposalNotifications) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
+    // Wait for eth clients to sync
+    if err := services.WaitEthClientSynced(t.c, false); err != nil {
+        return err
+    }
+    if err := services.WaitBeaconClientSynced(t.c, false); err != nil {
+        return err
+    }
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Get node validators
+    minipools, err := minipool.GetNodeMinipools(t.rp, nodeAccount.Address, nil)
+    if err != nil {
+        return err
+    }
+    pubkeys := []rptypes.ValidatorPubkey{}
+    minipoolAddresses := make(map[rptypes.ValidatorPubkey]common.Address)
+    for _, mp := range minipools {
+        if !mp.Exists || mp.Pubkey == (rptypes.ValidatorPubkey{}) {
+            continue
+        }
+        pubkeys = append(pubkeys, mp.Pubkey)
+        minipoolAddresses[mp.Pubkey] = mp.Address
+    }
+    if len(pubkeys) == 0 {
+        return nil
+    }
+
+    // Get beacon head & eth2 config
+    head, err := t.bc.GetBeaconHead()
+    if err != nil {
+        return err
+    }
+    eth2Config, err := t.bc.GetEth2Config()
+    if err != nil {
+        return err
+    }
+
+    // Get upcoming proposals
+    proposals := []upcomingProposal{}
+    for _, epoch := range []uint64{head.Epoch, head.Epoch + 1} {
+        duties, err := t.bc.GetValidatorDuties(pubkeys, epoch)
+        if err != nil {
+            return err
+        }
+        for _, validatorDuties := range duties {
+            minipoolAddress, ok := minipoolAddresses[validatorDuties.Pubkey]
+            if !ok {
+                continue
+            }
+            for _, slot := range validatorDuties.ProposerSlots {
+                if slot <= head.Slot {
+                    continue
+                }
+                proposals = append(proposals, upcomingProposal{
+                    MinipoolAddress: minipoolAddress,
+                    ValidatorIndex: validatorDuties.Index,
+                    Slot: slot,
+                })
+            }
+        }
+    }
+    sort.Slice(proposals, func(i, j int) bool { return proposals[i].Slot < proposals[j].Slot })
+
+    // Load notification state, removing proposals which have passed
+    state, err := t.loadState()
+    if err != nil {
+        return err
+    }
+    for slot := range state {
+        if slotNumber, err := strconv.ParseUint(slot, 10, 64); err != nil || slotNumber <= head.Slot {
+            delete(state, slot)
+        }
+    }
+
+    // Notify new proposals
+    for _, proposal := range proposals {
+        slot := strconv.FormatUint(proposal.Slot, 10)
+        if state[slot] {
+            continue
+        }
+        slotTime := time.Unix(int64(eth2Config.GenesisTime + proposal.Slot * eth2Config.SecondsPerSlot), 0)
+        t.notify("Upcoming block proposal", fmt.Sprintf(
+            "The validator for minipool %s (index %d) is scheduled to propose the block at slot %d in %s, at %s. Avoid restarting the node or its clients until the block has been proposed.",
+            proposal.MinipoolAddress.Hex(), proposal.ValidatorIndex, proposal.Slot, time.Until(slotTime).Round(time.Second), slotTime.Format(time.RFC1123)))
+        state[slot] = true
+    }
+
+    // Save notification state
+    return t.saveState(state)
+
+}
+
+
+// Send a notification
+func (t *proposalNotifications) notify(title, message string) {
+    t.log.Println(message)
+    if err := t.alerter.Send(title, message); err != nil {
+        t.log.Error(err)
+    }
+}
+
+
+// Load proposal notification state from disk
+func (t *proposalNotifications) loadState() (map[string]bool, error) {
+    state := make(map[string]bool)
+    stateBytes, err := ioutil.ReadFile(filepath.Join(t.cfg.GetDataPath(), ProposalNotificationsFile))
+    if os.IsNotExist(err) {
+        return state, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("Could not read proposal notification state: %w", err)
+    }
+    if err := json.Unmarshal(stateBytes, &state); err != nil {
+        return nil, fmt.Errorf("Could not decode proposal notification state: %w", err)
+    }
+    return state, nil
+}
+
+
+// Save proposal notification state to disk
+func (t *proposalNotifications) saveState(state map[string]bool) error {
+    stateBytes, err := json.Marshal(state)
+    if err != nil {
+        return fmt.Errorf("Could not encode proposal notification state: %w", err)
+    }
+    if err := ioutil.WriteFile(filepath.Join(t.cfg.GetDataPath(), ProposalNotificationsFile), stateBytes, 0600); err != nil {
+        return fmt.Errorf("Could not write proposal notification state: %w", err)
+    }
+    return nil
+}
+
@@ -0,0 +1,90 @@
+package node
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/multicall"
+    "github.com/rocket-pool/smartnode/shared/services/snapshots"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+var recordNodeSnapshotInterval, _ = time.ParseDuration("6h")
+
+
+// Record node snapshot task
+type recordNodeSnapshot struct {
+    c *cli.Context
+    log log.ColorLogger
+    w *wallet.Wallet
+    rp *rocketpool.RocketPool
+    mc *multicall.Client
+}
+
+
+// Create record node snapshot task
+func newRecordNodeSnapshot(c *cli.Context, logger log.ColorLogger) (*recordNodeSnapshot, error) {
+
+    // Get services
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    mc, err := services.GetMulticall(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &recordNodeSnapshot{
+        c: c,
+        log: logger,
+        w: w,
+        rp: rp,
+        mc: mc,
+    }, nil
+
+}
+
+
+// Record a snapshot of the node's balances, minipools & settings
+func (t *recordNodeSnapshot) run() error {
+
+    // Wait for eth client to sync
+    if err := services.WaitEthClientSynced(t.c, true); err != nil {
+        return err
+    }
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Collect snapshot
+    snapshot, err := snapshots.Collect(t.rp, t.mc, nodeAccount.Address, &cfg)
+    if err != nil {
+        return fmt.Errorf("Could not collect node snapshot: %w", err)
+    }
+
+    // Record snapshot
+    if err := snapshots.Record(cfg.GetDataPath(), snapshot, snapshots.DefaultRetention); err != nil {
+        return err
+    }
+
+    // Log & return
+    t.log.Printlnf("Recorded node snapshot at block %d with %d minipool(s).", snapshot.Block, len(snapshot.Minipools))
+    return nil
+
+}
@@ -0,0 +1,69 @@
+package node
+
+import (
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Settings
+var replaceStuckTransactionsActiveInterval, _ = time.ParseDuration("1m")
+var replaceStuckTransactionsIdleInterval, _ = time.ParseDuration("10m")
+
+
+// Replace stuck transactions task
+type replaceStuckTransactions struct {
+    c *cli.Context
+    log log.ColorLogger
+    txm *transactions.Manager
+}
+
+
+// Create replace stuck transactions task
+func newReplaceStuckTransactions(c *cli.Context, logger log.ColorLogger) (*replaceStuckTransactions, error) {
+
+    // Get services
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &replaceStuckTransactions{
+        c: c,
+        log: logger,
+        txm: txm,
+    }, nil
+
+}
+
+
+// Re-broadcast pending node transactions which have not been mined with a higher gas price
+func (t *replaceStuckTransactions) run() error {
+
+    // Wait for eth client to sync
+    if err := services.WaitEthClientSynced(t.c, true); err != nil {
+        return err
+    }
+
+    // Replace stuck transactions
+    replaced, err := t.txm.ReplaceStuckTransactions()
+    for _, pt := range replaced {
+        t.log.Printlnf("Replaced stuck transaction '%s' (nonce %d) with %s at a gas price of %s.", pt.Description, pt.Nonce, pt.LatestHash().Hex(), units.FormatGwei(pt.GasPrice))
+    }
+
+    // Return
+    return err
+
+}
+
+
+// Check whether the node has pending transactions; errors are treated as activity so that they are retried promptly
+func (t *replaceStuckTransactions) isActive() bool {
+    pending, err := t.txm.GetPendingTransactions()
+    return (err != nil || len(pending) > 0)
+}
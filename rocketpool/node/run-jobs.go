@@ -0,0 +1,420 @@
+package node
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/settings"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/gas"
+    "github.com/rocket-pool/smartnode/shared/services/jobs"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Settings
+var runJobsActiveInterval, _ = time.ParseDuration("1m")
+var runJobsIdleInterval, _ = time.ParseDuration("15m")
+
+
+// Error returned when a job is no longer pending as it was changed by another process
+var errJobNotPending = errors.New("The job is no longer pending")
+
+
+// Run jobs task
+type runJobs struct {
+    c *cli.Context
+    log log.ColorLogger
+    cfg config.RocketPoolConfig
+    txm *transactions.Manager
+    ec *ethclient.Client
+    rp *rocketpool.RocketPool
+    cache *contracts.Cache
+    alerter *alerts.Alerter
+    pending bool
+}
+
+
+// Create run jobs task
+func newRunJobs(c *cli.Context, logger log.ColorLogger) (*runJobs, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &runJobs{
+        c: c,
+        log: logger,
+        cfg: cfg,
+        txm: txm,
+        ec: ec,
+        rp: rp,
+        cache: cache,
+        alerter: alerter,
+    }, nil
+
+}
+
+
+// Run scheduled jobs whose conditions are met, and resume jobs interrupted by a restart
+func (t *runJobs) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
+    // Get jobs
+    allJobs, err := jobs.Load(t.cfg.GetDataPath())
+    if err != nil {
+        return err
+    }
+
+    // Get due jobs
+    dueJobs := []jobs.Job{}
+    t.pending = false
+    for _, job := range allJobs {
+        if job.Finished() {
+            continue
+        }
+        t.pending = true
+        if job.Status == jobs.StatusRunning || !time.Now().Before(job.NotBefore) {
+            dueJobs = append(dueJobs, job)
+        }
+    }
+    if len(dueJobs) == 0 {
+        return nil
+    }
+
+    // Wait for eth client to sync
+    if err := services.WaitEthClientSynced(t.c, true); err != nil {
+        return err
+    }
+
+    // Get gas price
+    gasPrice, err := services.GetGasPrice(t.c)
+    if err != nil {
+        return err
+    }
+
+    // Run jobs
+    for _, job := range dueJobs {
+        var err error
+        if job.Status == jobs.StatusRunning {
+            err = t.resumeJob(job)
+        } else {
+            err = t.runJob(job, gasPrice)
+        }
+        if err != nil {
+            t.log.Error(fmt.Errorf("Could not run job %d: %w", job.ID, err))
+        }
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Check whether the node has unfinished jobs
+func (t *runJobs) isActive() bool {
+    return t.pending
+}
+
+
+// Run a pending job if its conditions are met
+func (t *runJobs) runJob(job jobs.Job, gasPrice *big.Int) error {
+
+    // Create minipool
+    mp, err := minipool.NewMinipool(t.rp, job.Minipool)
+    if err != nil {
+        return err
+    }
+
+    // Check job state; jobs are only submitted while their action is still required on chain
+    ready, complete, err := t.checkJob(job, mp)
+    if err != nil {
+        return err
+    }
+    if complete {
+        return t.completeJob(job, job.TxHash)
+    }
+    if !ready {
+        return nil
+    }
+
+    // Check gas price
+    if err := gas.CheckMaxFee(gasPrice, t.cfg.Smartnode.MaxFee); err != nil {
+        t.log.Warnf("%s is ready, but %s The job is deferred.", job.Description(), err.Error())
+        return nil
+    }
+    if job.MaxGasPrice > 0 && gasPrice.Cmp(eth.GweiToWei(job.MaxGasPrice)) > 0 {
+        t.log.Printlnf("%s is ready, but the current gas price of %s is above its %s limit; the job is deferred.", job.Description(), units.FormatGwei(gasPrice), units.FormatGwei(eth.GweiToWei(job.MaxGasPrice)))
+        return nil
+    }
+
+    // Get transactor
+    opts, err := services.GetNodeAccountTransactor(t.c)
+    if err != nil {
+        return err
+    }
+    opts.GasPrice = gasPrice
+
+    // Get job transaction
+    transact := func(opts *bind.TransactOpts) error {
+        var err error
+        switch job.Type {
+            case jobs.TypeRefund: _, err = mp.Refund(opts)
+            case jobs.TypeWithdraw: _, err = mp.Withdraw(opts)
+            case jobs.TypeClose: _, err = mp.Close(opts)
+            default: err = fmt.Errorf("Unknown job type '%s'", job.Type)
+        }
+        return err
+    }
+
+    // Rehearse job; rehearsed jobs remain pending
+    rehearsal, err := services.IsTaskRehearsal(t.c, "run-jobs")
+    if err != nil {
+        return err
+    }
+    if rehearsal {
+        t.log.Printlnf("Rehearsing %s...", job.Description())
+        if _, err := t.txm.Send(transactions.RehearsalTransactor(opts, t.log.Printlnf), job.Description(), transact); !transactions.IsRehearsed(err) {
+            return err
+        }
+        return nil
+    }
+
+    // Mark job as running; skipped if it was cancelled in the meantime
+    job, err = jobs.Update(t.cfg.GetDataPath(), job.ID, func(j *jobs.Job) error {
+        if j.Status != jobs.StatusPending {
+            return errJobNotPending
+        }
+        j.Status = jobs.StatusRunning
+        j.Attempts++
+        return nil
+    })
+    if errors.Is(err, errJobNotPending) {
+        return nil
+    }
+    if err != nil {
+        return err
+    }
+
+    // Log
+    t.log.Printlnf("Running %s...", job.Description())
+
+    // Send transaction
+    pt, err := t.txm.Send(opts, job.Description(), transact)
+    if err != nil {
+        return t.retryJob(job, err)
+    }
+
+    // Wait for transaction
+    return t.waitForJob(job, pt)
+
+}
+
+
+// Resume a job which was running when the daemon stopped
+// The job's transaction is found in the pending transaction queue by its description; if there is none, the job is returned
+// to pending so that its on-chain state is checked again before anything is resubmitted
+func (t *runJobs) resumeJob(job jobs.Job) error {
+
+    // Get pending transactions
+    pending, err := t.txm.GetPendingTransactions()
+    if err != nil {
+        return err
+    }
+
+    // Wait for the job's transaction
+    for _, pt := range pending {
+        if pt.Description == job.Description() {
+            t.log.Printlnf("Resuming %s...", job.Description())
+            return t.waitForJob(job, pt)
+        }
+    }
+
+    // Return job to pending
+    _, err = jobs.Update(t.cfg.GetDataPath(), job.ID, func(j *jobs.Job) error {
+        j.Status = jobs.StatusPending
+        return nil
+    })
+    return err
+
+}
+
+
+// Record a job's transaction and wait for it to be mined
+func (t *runJobs) waitForJob(job jobs.Job, pt transactions.PendingTransaction) error {
+
+    // Record transaction
+    job, err := jobs.Update(t.cfg.GetDataPath(), job.ID, func(j *jobs.Job) error {
+        j.Nonce = pt.Nonce
+        j.TxHash = pt.LatestHash()
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    // Wait for transaction to be mined
+    receipt, err := t.txm.WaitForTransaction(pt.From, pt.Nonce)
+    if err != nil {
+        return t.retryJob(job, err)
+    }
+
+    // Complete job
+    return t.completeJob(job, receipt.TxHash)
+
+}
+
+
+// Mark a job as done
+func (t *runJobs) completeJob(job jobs.Job, txHash common.Hash) error {
+
+    // Update job
+    if _, err := jobs.Update(t.cfg.GetDataPath(), job.ID, func(j *jobs.Job) error {
+        j.Status = jobs.StatusDone
+        j.TxHash = txHash
+        j.Error = ""
+        return nil
+    }); err != nil {
+        return err
+    }
+
+    // Log & alert
+    message := fmt.Sprintf("%s completed successfully.", job.Description())
+    t.log.Println(message)
+    if err := t.alerter.Send("Job completed", message); err != nil {
+        t.log.Error(err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Return a job to pending after a failed attempt, or mark it as failed once it has no attempts left
+func (t *runJobs) retryJob(job jobs.Job, jobErr error) error {
+
+    // Update job
+    job, err := jobs.Update(t.cfg.GetDataPath(), job.ID, func(j *jobs.Job) error {
+        j.Error = jobErr.Error()
+        if j.Attempts >= jobs.MaxAttempts {
+            j.Status = jobs.StatusFailed
+        } else {
+            j.Status = jobs.StatusPending
+        }
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    // Alert on failure
+    if job.Status == jobs.StatusFailed {
+        message := fmt.Sprintf("%s failed after %d attempts: %s", job.Description(), job.Attempts, jobErr.Error())
+        t.log.Println(message)
+        if err := t.alerter.Send("Job failed", message); err != nil {
+            t.log.Error(err)
+        }
+        return nil
+    }
+
+    // Return
+    return jobErr
+
+}
+
+
+// Check whether a job's action can be run, or has already taken effect on chain
+func (t *runJobs) checkJob(job jobs.Job, mp *minipool.Minipool) (bool, bool, error) {
+
+    // Check minipool exists; withdrawn and closed minipools are destroyed after paying out all node balances
+    exists, err := minipool.GetMinipoolExists(t.rp, mp.Address, nil)
+    if err != nil {
+        return false, false, err
+    }
+    if !exists {
+        return false, true, nil
+    }
+
+    // Check job action
+    switch job.Type {
+
+        // Refunds are complete once the refund balance is cleared after an attempt
+        case jobs.TypeRefund:
+            refundBalance, err := mp.GetNodeRefundBalance(nil)
+            if err != nil {
+                return false, false, err
+            }
+            if refundBalance.Cmp(big.NewInt(0)) == 0 {
+                return false, (job.Attempts > 0), nil
+            }
+            return true, false, nil
+
+        // Withdrawals require a withdrawable minipool which has passed the withdrawal delay
+        case jobs.TypeWithdraw:
+            status, err := mp.GetStatusDetails(nil)
+            if err != nil {
+                return false, false, err
+            }
+            if status.Status != rptypes.Withdrawable {
+                return false, false, nil
+            }
+            header, err := t.ec.HeaderByNumber(context.Background(), nil)
+            if err != nil {
+                return false, false, err
+            }
+            withdrawalDelay, err := t.cache.GetUint64("settings.minipoolWithdrawalDelay", func() (uint64, error) { return settings.GetMinipoolWithdrawalDelay(t.rp, nil) })
+            if err != nil {
+                return false, false, err
+            }
+            return ((header.Number.Uint64() - status.StatusBlock) >= withdrawalDelay), false, nil
+
+        // Closes require a dissolved minipool
+        case jobs.TypeClose:
+            status, err := mp.GetStatus(nil)
+            if err != nil {
+                return false, false, err
+            }
+            return (status == rptypes.Dissolved), false, nil
+
+    }
+
+    // Unknown job type
+    return false, false, fmt.Errorf("Unknown job type '%s'", job.Type)
+
+}
@@ -2,22 +2,30 @@ package node
 
 import (
     "context"
-    "errors"
     "fmt"
     "time"
 
-    "github.com/docker/docker/api/types"
-    "github.com/docker/docker/client"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/ethclient"
     "github.com/rocket-pool/rocketpool-go/minipool"
     "github.com/rocket-pool/rocketpool-go/network"
     "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/settings"
     rptypes "github.com/rocket-pool/rocketpool-go/types"
     "github.com/urfave/cli"
+    eth2types "github.com/wealdtech/go-eth2-types/v2"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
     "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/containers"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/doppelganger"
+    "github.com/rocket-pool/smartnode/shared/services/multicall"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
     "github.com/rocket-pool/smartnode/shared/services/wallet"
     "github.com/rocket-pool/smartnode/shared/utils/log"
     "github.com/rocket-pool/smartnode/shared/utils/validator"
@@ -25,19 +33,46 @@ import (
 
 
 // Settings
-const ValidatorContainerName = "rocketpool_validator"
-var stakePrelaunchMinipoolsInterval, _ = time.ParseDuration("5m")
-var validatorRestartTimeout, _ = time.ParseDuration("5s")
+const (
+    LaunchTimeoutMarginBlocks = 20 // Blocks allowed for the stake transaction to be mined
+    DepositInclusionEpochs = 70 // Approximate eth1 follow distance & voting period before a deposit is processed
+    ActivationDelayEpochs = 5 // Epochs between activation eligibility & activation after dequeue
+    ValidatorQueueRefreshEpochs = 10 // Epochs between validator queue requests; the queue is projected forward by the churn limit in between
+    Eth1BlockSeconds = 13 // Approximate eth1 block time, used to estimate the launch timeout deadline
+)
+var stakePrelaunchMinipoolsActiveInterval, _ = time.ParseDuration("1m")
+var stakePrelaunchMinipoolsIdleInterval, _ = time.ParseDuration("15m")
 
 
 // Stake prelaunch minipools task
 type stakePrelaunchMinipools struct {
     c *cli.Context
     log log.ColorLogger
+    cfg config.RocketPoolConfig
     w *wallet.Wallet
+    txm *transactions.Manager
+    ec *ethclient.Client
     rp *rocketpool.RocketPool
+    cache *contracts.Cache
     bc beacon.Client
-    d *client.Client
+    containers *containers.Controller
+    alerter *alerts.Alerter
+    mc *multicall.Client
+    pending bool
+    queue beacon.ValidatorQueue
+    queueEpoch uint64
+    queueLoaded bool
+}
+
+
+// Staking window details
+type stakingWindow struct {
+    CurrentBlock uint64
+    CurrentBlockTime uint64
+    LaunchTimeout uint64
+    QueueKnown bool
+    QueueLength uint64
+    ActivationEpoch uint64
 }
 
 
@@ -45,44 +80,56 @@ type stakePrelaunchMinipools struct {
 func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakePrelaunchMinipools, error) {
 
     // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return nil, err }
     bc, err := services.GetBeaconClient(c)
     if err != nil { return nil, err }
-    d, err := services.GetDocker(c)
+    cc, err := services.GetContainerController(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+    mc, err := services.GetMulticall(c)
     if err != nil { return nil, err }
 
     // Return task
     return &stakePrelaunchMinipools{
         c: c,
         log: logger,
+        cfg: cfg,
         w: w,
+        txm: txm,
+        ec: ec,
         rp: rp,
+        cache: cache,
         bc: bc,
-        d: d,
+        containers: cc,
+        alerter: alerter,
+        mc: mc,
     }, nil
 
 }
 
 
-// Start stake prelaunch minipools task
-func (t *stakePrelaunchMinipools) Start() {
-    go (func() {
-        for {
-            if err := t.run(); err != nil {
-                t.log.Println(err)
-            }
-            time.Sleep(stakePrelaunchMinipoolsInterval)
-        }
-    })()
-}
-
-
 // Stake prelaunch minipools
 func (t *stakePrelaunchMinipools) run() error {
 
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
     // Wait for eth client to sync
     if err := services.WaitEthClientSynced(t.c, true); err != nil {
         return err
@@ -102,6 +149,7 @@ func (t *stakePrelaunchMinipools) run() error {
     if err != nil {
         return err
     }
+    t.pending = (len(minipools) > 0)
     if len(minipools) == 0 {
         return nil
     }
@@ -110,6 +158,7 @@ func (t *stakePrelaunchMinipools) run() error {
     var wg errgroup.Group
     var withdrawalCredentials common.Hash
     var eth2Config beacon.Eth2Config
+    var window stakingWindow
 
     // Get Rocket pool withdrawal credentials
     wg.Go(func() error {
@@ -125,6 +174,13 @@ func (t *stakePrelaunchMinipools) run() error {
         return err
     })
 
+    // Get staking window
+    wg.Go(func() error {
+        var err error
+        window, err = t.getStakingWindow()
+        return err
+    })
+
     // Wait for data
     if err := wg.Wait(); err != nil {
         return err
@@ -132,12 +188,30 @@ func (t *stakePrelaunchMinipools) run() error {
 
     // Log
     t.log.Printlnf("%d minipools are ready for staking...", len(minipools))
+    if window.QueueKnown {
+        t.log.Printlnf("The beacon chain activation queue has %d validators; new validators are projected to activate at epoch %d.", window.QueueLength, window.ActivationEpoch)
+    }
+
+    // Check rehearsal mode
+    rehearsal, err := services.IsTaskRehearsal(t.c, "stake-prelaunch-minipools")
+    if err != nil {
+        return err
+    }
 
     // Stake minipools
     for _, mp := range minipools {
-        if err := t.stakeMinipool(mp, withdrawalCredentials, eth2Config); err != nil {
-            t.log.Println(fmt.Errorf("Could not stake minipool %s: %w", mp.Address.Hex(), err))
+        if ok, err := t.checkStakingWindow(mp, window, eth2Config); err != nil {
+            t.log.Error(fmt.Errorf("Could not check minipool %s staking window: %w", mp.Address.Hex(), err))
+            continue
+        } else if !ok {
+            continue
         }
+        if err := t.stakeMinipool(mp, withdrawalCredentials, eth2Config, window, rehearsal); err != nil {
+            t.log.Error(fmt.Errorf("Could not stake minipool %s: %w", mp.Address.Hex(), err))
+        }
+    }
+    if rehearsal {
+        return nil
     }
 
     // Restart validator container
@@ -151,6 +225,12 @@ func (t *stakePrelaunchMinipools) run() error {
 }
 
 
+// Check whether the node has prelaunch minipools waiting to be staked
+func (t *stakePrelaunchMinipools) isActive() bool {
+    return t.pending
+}
+
+
 // Get prelaunch minipools
 func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Address) ([]*minipool.Minipool, error) {
 
@@ -170,29 +250,28 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
         minipools[mi] = mp
     }
 
-    // Data
-    var wg errgroup.Group
-    statuses := make([]rptypes.MinipoolStatus, len(minipools))
+    // Get minipool ABI
+    minipoolABI, err := t.cache.GetABI("rocketMinipool")
+    if err != nil {
+        return []*minipool.Minipool{}, err
+    }
 
-    // Load minipool statuses
+    // Load minipool statuses in a single batch
+    statuses := make([]uint8, len(minipools))
+    batch := t.mc.NewBatch()
     for mi, mp := range minipools {
-        mi, mp := mi, mp
-        wg.Go(func() error {
-            status, err := mp.GetStatus(nil)
-            if err == nil { statuses[mi] = status }
-            return err
-        })
+        if err := batch.AddCall(mp.Address, minipoolABI, &statuses[mi], "getStatus"); err != nil {
+            return []*minipool.Minipool{}, err
+        }
     }
-
-    // Wait for data
-    if err := wg.Wait(); err != nil {
-        return []*minipool.Minipool{}, err
+    if err := batch.Execute(); err != nil {
+        return []*minipool.Minipool{}, fmt.Errorf("Could not get minipool statuses: %w", err)
     }
 
     // Filter minipools by status
     prelaunchMinipools := []*minipool.Minipool{}
     for mi, mp := range minipools {
-        if statuses[mi] == rptypes.Prelaunch {
+        if rptypes.MinipoolStatus(statuses[mi]) == rptypes.Prelaunch {
             prelaunchMinipools = append(prelaunchMinipools, mp)
         }
     }
@@ -203,16 +282,159 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 }
 
 
+// Get the current staking window details
+// The beacon activation queue is optional; if it is unavailable, staking proceeds without an activation projection
+func (t *stakePrelaunchMinipools) getStakingWindow() (stakingWindow, error) {
+
+    // Data
+    var wg errgroup.Group
+    var window stakingWindow
+
+    // Get current block
+    wg.Go(func() error {
+        header, err := t.ec.HeaderByNumber(context.Background(), nil)
+        if err == nil {
+            window.CurrentBlock = header.Number.Uint64()
+            window.CurrentBlockTime = header.Time
+        }
+        return err
+    })
+
+    // Get launch timeout
+    wg.Go(func() error {
+        var err error
+        window.LaunchTimeout, err = t.cache.GetUint64("settings.minipoolLaunchTimeout", func() (uint64, error) { return settings.GetMinipoolLaunchTimeout(t.rp, nil) })
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return stakingWindow{}, err
+    }
+
+    // Get beacon head & validator queue
+    head, err := t.bc.GetBeaconHead()
+    if err != nil {
+        t.log.Error(fmt.Errorf("Could not get projected validator activation epoch: %w", err))
+        return window, nil
+    }
+    queueLength, churnLimit, err := t.getValidatorQueue(head.Epoch)
+    if err != nil {
+        t.log.Error(fmt.Errorf("Could not get projected validator activation epoch: %w", err))
+        return window, nil
+    }
+    if churnLimit == 0 {
+        return window, nil
+    }
+
+    // Project activation epoch
+    window.QueueKnown = true
+    window.QueueLength = queueLength
+    window.ActivationEpoch = head.Epoch + DepositInclusionEpochs + (queueLength / churnLimit) + ActivationDelayEpochs
+
+    // Return
+    return window, nil
+
+}
+
+
+// Get the beacon chain activation queue length & churn limit at an epoch
+// The queue is requested every ValidatorQueueRefreshEpochs, as it is derived from the full validator set by some clients,
+// and is projected forward by the churn limit in between; validators joining the queue since the last request are not counted
+func (t *stakePrelaunchMinipools) getValidatorQueue(epoch uint64) (uint64, uint64, error) {
+
+    // Refresh queue
+    if !t.queueLoaded || epoch < t.queueEpoch || epoch >= t.queueEpoch + ValidatorQueueRefreshEpochs {
+        queue, err := t.bc.GetValidatorQueue()
+        if err != nil {
+            return 0, 0, err
+        }
+        t.queue = queue
+        t.queueEpoch = epoch
+        t.queueLoaded = true
+    }
+
+    // Project queue length
+    dequeued := (epoch - t.queueEpoch) * t.queue.ChurnLimit
+    if dequeued >= t.queue.ActivationQueueLength {
+        return 0, t.queue.ChurnLimit, nil
+    }
+    return t.queue.ActivationQueueLength - dequeued, t.queue.ChurnLimit, nil
+
+}
+
+
+// Check that a minipool can be staked, and its validator activated, before its launch timeout
+func (t *stakePrelaunchMinipools) checkStakingWindow(mp *minipool.Minipool, window stakingWindow, eth2Config beacon.Eth2Config) (bool, error) {
+
+    // Get minipool status block
+    statusBlock, err := mp.GetStatusBlock(nil)
+    if err != nil {
+        return false, err
+    }
+    deadlineBlock := statusBlock + window.LaunchTimeout
+
+    // Check launch timeout
+    if window.CurrentBlock >= statusBlock && window.CurrentBlock - statusBlock + LaunchTimeoutMarginBlocks >= window.LaunchTimeout {
+        t.alertStakingWindow(fmt.Sprintf("Minipool %s has reached its launch timeout at block %d and will be dissolved, so it will not be staked.", mp.Address.Hex(), deadlineBlock))
+        return false, nil
+    }
+
+    // Check projected activation against launch timeout
+    if !window.QueueKnown || window.CurrentBlock >= deadlineBlock {
+        return true, nil
+    }
+    deadlineTime := window.CurrentBlockTime + ((deadlineBlock - window.CurrentBlock) * Eth1BlockSeconds)
+    activationTime := eth2Config.GenesisTime + ((window.ActivationEpoch - eth2Config.GenesisEpoch) * eth2Config.SecondsPerEpoch)
+    if activationTime <= deadlineTime {
+        return true, nil
+    }
+    t.alertStakingWindow(fmt.Sprintf(
+        "Minipool %s would not be activated before its launch timeout at block %d (approximately %s); the validator is projected to activate at epoch %d (approximately %s) with %d validators queued, so it will not be staked.",
+        mp.Address.Hex(),
+        deadlineBlock,
+        time.Unix(int64(deadlineTime), 0).Format(time.RFC1123),
+        window.ActivationEpoch,
+        time.Unix(int64(activationTime), 0).Format(time.RFC1123),
+        window.QueueLength))
+    return false, nil
+
+}
+
+
+// Log & alert a minipool staking window miss
+func (t *stakePrelaunchMinipools) alertStakingWindow(message string) {
+    t.log.Warn(message)
+    if err := t.alerter.Send("Minipool launch timeout", message); err != nil {
+        t.log.Error(err)
+    }
+}
+
+
 // Stake a minipool
-func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config) error {
+// Rehearsals derive the next validator key without storing it, and log the stake transaction instead of submitting it
+func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config, window stakingWindow, rehearsal bool) error {
 
     // Log
     t.log.Printlnf("Staking minipool %s...", mp.Address.Hex())
 
     // Create new validator key
-    validatorKey, err := t.w.CreateValidatorKey()
-    if err != nil {
-        return err
+    var validatorKey *eth2types.BLSPrivateKey
+    if rehearsal {
+        index, err := t.w.GetValidatorKeyCount()
+        if err != nil {
+            return err
+        }
+        validatorKey, err = t.w.GetValidatorKeyAt(index)
+        if err != nil {
+            return err
+        }
+    } else {
+        var err error
+        validatorKey, err = t.w.CreateValidatorKey()
+        if err != nil {
+            return err
+        }
     }
 
     // Get validator deposit data
@@ -222,18 +444,26 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, withdrawa
     }
 
     // Get transactor
-    opts, err := t.w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(t.c)
     if err != nil {
         return err
     }
+    if rehearsal {
+        opts = transactions.RehearsalTransactor(opts, t.log.Printlnf)
+    }
 
     // Stake minipool
-    if _, err := mp.Stake(
-        rptypes.BytesToValidatorPubkey(depositData.PublicKey),
-        rptypes.BytesToValidatorSignature(depositData.Signature),
-        depositDataRoot,
-        opts,
-    ); err != nil {
+    if _, err := t.txm.Transact(opts, fmt.Sprintf("Stake minipool %s", mp.Address.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := mp.Stake(
+            rptypes.BytesToValidatorPubkey(depositData.PublicKey),
+            rptypes.BytesToValidatorSignature(depositData.Signature),
+            depositDataRoot,
+            opts,
+        )
+        return err
+    }); transactions.IsRehearsed(err) {
+        return nil
+    } else if err != nil {
         return err
     }
 
@@ -242,8 +472,15 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, withdrawa
         return err
     }
 
-    // Log
-    t.log.Printlnf("Successfully staked minipool %s.", mp.Address.Hex())
+    // Log & alert
+    message := fmt.Sprintf("Successfully staked minipool %s.", mp.Address.Hex())
+    if window.QueueKnown {
+        message += fmt.Sprintf(" The validator is projected to activate at epoch %d (%d validators are queued ahead of it).", window.ActivationEpoch, window.QueueLength)
+    }
+    t.log.Println(message)
+    if err := t.alerter.Send("Minipool staked", message); err != nil {
+        t.log.Error(err)
+    }
 
     // Return
     return nil
@@ -254,34 +491,32 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, withdrawa
 // Restart validator container
 func (t *stakePrelaunchMinipools) restartValidator() error {
 
-    // Log
-    t.log.Println("Restarting validator container...")
-
-    // Get all containers
-    containers, err := t.d.ContainerList(context.Background(), types.ContainerListOptions{All: true})
-    if err != nil {
-        return fmt.Errorf("Could not get docker containers: %w", err)
-    }
-
-    // Get validator container ID
-    var validatorContainerId string
-    for _, container := range containers {
-        if container.Names[0] == "/" + ValidatorContainerName {
-            validatorContainerId = container.ID
-            break
+    // Defer restart to doppelganger protection task if a key change is pending
+    if t.cfg.Smartnode.DoppelgangerProtection {
+        _, pending, err := doppelganger.GetKeyChangeTime(t.cfg.GetDataPath())
+        if err != nil {
+            return err
+        }
+        if pending {
+            t.log.Warn("Doppelganger protection is active, the validator container will be restarted once the delay has elapsed.")
+            return nil
         }
     }
-    if validatorContainerId == "" {
-        return errors.New("Validator container not found")
-    }
+
+    // Log
+    t.log.Println("Restarting validator container...")
 
     // Restart validator container
-    if err := t.d.ContainerRestart(context.Background(), validatorContainerId, &validatorRestartTimeout); err != nil {
-        return fmt.Errorf("Could not restart validator container: %w", err)
+    if err := t.containers.Restart(containers.ValidatorService, "Load new validator keys"); err != nil {
+        return err
     }
 
     // Log
-    t.log.Println("Successfully restarted validator container.")
+    if t.containers.Direct() {
+        t.log.Println("Successfully restarted validator container.")
+    } else {
+        t.log.Println("Requested a validator container restart from the service agent.")
+    }
 
     // Return
     return nil
@@ -0,0 +1,263 @@
+package node
+
+import (
+    "time"
+
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/metrics"
+    "github.com/rocket-pool/smartnode/shared/services/performance"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Settings
+const (
+    PerformanceEpochsPerRun = 32
+    PerformanceMaxBackfillEpochs = 225
+)
+var trackValidatorPerformanceInterval, _ = time.ParseDuration("5m")
+
+
+// Track validator performance task
+type trackValidatorPerformance struct {
+    c *cli.Context
+    log log.ColorLogger
+    w *wallet.Wallet
+    rp *rocketpool.RocketPool
+    bc beacon.Client
+    metrics *metrics.Registry
+}
+
+
+// Create track validator performance task
+func newTrackValidatorPerformance(c *cli.Context, logger log.ColorLogger, registry *metrics.Registry) (*trackValidatorPerformance, error) {
+
+    // Get services
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Define metrics
+    registry.Counter("validator_attestations_total", "Attestations included for the minipool's validator.")
+    registry.Counter("validator_missed_attestations_total", "Attestations missed by the minipool's validator.")
+    registry.Counter("validator_proposals_total", "Blocks proposed by the minipool's validator.")
+    registry.Counter("validator_missed_proposals_total", "Block proposals missed by the minipool's validator.")
+    registry.Gauge("validator_attestation_effectiveness_percent", "Share of the minipool validator's source, target & head votes which were included and correct.")
+    registry.Gauge("validator_inclusion_distance_average", "Average attestation inclusion distance of the minipool's validator in slots, if reported by the beacon client.")
+    registry.Gauge("validator_performance_epoch", "Last epoch for which validator performance was recorded.")
+
+    // Return task
+    return &trackValidatorPerformance{
+        c: c,
+        log: logger,
+        w: w,
+        rp: rp,
+        bc: bc,
+        metrics: registry,
+    }, nil
+
+}
+
+
+// Record the attestation & proposal performance of the node's validators for each completed epoch
+// Tracking starts from the latest completed epoch; after downtime, at most a day of missed epochs is backfilled
+func (t *trackValidatorPerformance) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+
+    // Wait for eth clients to sync
+    if err := services.WaitEthClientSynced(t.c, false); err != nil {
+        return err
+    }
+    if err := services.WaitBeaconClientSynced(t.c, false); err != nil {
+        return err
+    }
+
+    // Load recorded performance
+    record, initialized, err := performance.Load(cfg.GetDataPath())
+    if err != nil {
+        return err
+    }
+    defer t.recordMetrics(&record)
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Get beacon head; votes for an epoch are final once the following epoch has completed
+    head, err := t.bc.GetBeaconHead()
+    if err != nil {
+        return err
+    }
+    if head.Epoch < 2 {
+        return nil
+    }
+    lastEpoch := head.Epoch - 2
+
+    // Get epochs to process
+    if initialized && record.LastEpoch >= lastEpoch {
+        return nil
+    }
+    startEpoch := lastEpoch
+    if initialized {
+        startEpoch = record.LastEpoch + 1
+    }
+    if lastEpoch - startEpoch >= PerformanceMaxBackfillEpochs {
+        t.log.Printlnf("Skipping validator performance for epochs %d to %d as they are too old to backfill.", startEpoch, lastEpoch - PerformanceMaxBackfillEpochs)
+        startEpoch = lastEpoch - PerformanceMaxBackfillEpochs + 1
+    }
+    endEpoch := lastEpoch
+    if endEpoch - startEpoch >= PerformanceEpochsPerRun {
+        endEpoch = startEpoch + PerformanceEpochsPerRun - 1
+    }
+
+    // Get node validators
+    minipools, err := minipool.GetNodeMinipools(t.rp, nodeAccount.Address, nil)
+    if err != nil {
+        return err
+    }
+    pubkeys := []rptypes.ValidatorPubkey{}
+    validators := make(map[rptypes.ValidatorPubkey]*performance.Validator)
+    for _, mp := range minipools {
+        if !mp.Exists || mp.Pubkey == (rptypes.ValidatorPubkey{}) {
+            continue
+        }
+        validator, ok := record.Validators[mp.Address.Hex()]
+        if !ok {
+            validator = &performance.Validator{
+                MinipoolAddress: mp.Address,
+                Pubkey: mp.Pubkey,
+            }
+        }
+        pubkeys = append(pubkeys, mp.Pubkey)
+        validators[mp.Pubkey] = validator
+    }
+    if len(pubkeys) == 0 {
+        record.LastEpoch = endEpoch
+        return performance.Save(cfg.GetDataPath(), record)
+    }
+
+    // Process epochs, saving progress if an epoch cannot be processed
+    for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+        if err := t.processEpoch(epoch, pubkeys, validators); err != nil {
+            if saveErr := performance.Save(cfg.GetDataPath(), record); saveErr != nil {
+                t.log.Error(saveErr)
+            }
+            return err
+        }
+        for _, validator := range validators {
+            record.Validators[validator.MinipoolAddress.Hex()] = validator
+        }
+        record.LastEpoch = epoch
+    }
+
+    // Save recorded performance
+    if err := performance.Save(cfg.GetDataPath(), record); err != nil {
+        return err
+    }
+
+    // Log & return
+    if startEpoch == endEpoch {
+        t.log.Printlnf("Recorded validator performance for epoch %d.", endEpoch)
+    } else {
+        t.log.Printlnf("Recorded validator performance for epochs %d to %d.", startEpoch, endEpoch)
+    }
+    return nil
+
+}
+
+
+// Record an epoch's attestation & proposal performance
+func (t *trackValidatorPerformance) processEpoch(epoch uint64, pubkeys []rptypes.ValidatorPubkey, validators map[rptypes.ValidatorPubkey]*performance.Validator) error {
+
+    // Get attestation performance & duties
+    validatorPerformance, err := t.bc.GetValidatorPerformance(pubkeys, epoch)
+    if err != nil {
+        return err
+    }
+    duties, err := t.bc.GetValidatorDuties(pubkeys, epoch)
+    if err != nil {
+        return err
+    }
+
+    // Check block proposals
+    proposals := make(map[rptypes.ValidatorPubkey]map[uint64]bool)
+    for _, validatorDuties := range duties {
+        if _, ok := validators[validatorDuties.Pubkey]; !ok {
+            continue
+        }
+        for _, slot := range validatorDuties.ProposerSlots {
+            proposerIndex, found, err := t.bc.GetBlockProposer(slot)
+            if err != nil {
+                return err
+            }
+            if proposals[validatorDuties.Pubkey] == nil {
+                proposals[validatorDuties.Pubkey] = make(map[uint64]bool)
+            }
+            proposals[validatorDuties.Pubkey][slot] = found && proposerIndex == validatorDuties.Index
+        }
+    }
+
+    // Record performance
+    for _, vp := range validatorPerformance {
+        validator, ok := validators[vp.Pubkey]
+        if !ok {
+            continue
+        }
+        validator.RecordAttestation(epoch, vp)
+        if vp.Active && !vp.Attested {
+            t.log.Printlnf("The validator for minipool %s missed its attestation in epoch %d.", validator.MinipoolAddress.Hex(), epoch)
+        }
+    }
+    for pubkey, slots := range proposals {
+        validator := validators[pubkey]
+        for slot, proposed := range slots {
+            validator.RecordProposal(slot, proposed)
+            if proposed {
+                t.log.Printlnf("The validator for minipool %s proposed the block at slot %d.", validator.MinipoolAddress.Hex(), slot)
+            } else {
+                t.log.Printlnf("The validator for minipool %s missed its block proposal at slot %d.", validator.MinipoolAddress.Hex(), slot)
+            }
+        }
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Record validator performance metrics
+func (t *trackValidatorPerformance) recordMetrics(record *performance.Record) {
+    for _, validator := range record.Validators {
+        minipoolAddress := validator.MinipoolAddress.Hex()
+        t.metrics.Set("validator_attestations_total", float64(validator.Attestations), "minipool", minipoolAddress)
+        t.metrics.Set("validator_missed_attestations_total", float64(validator.MissedAttestations), "minipool", minipoolAddress)
+        t.metrics.Set("validator_proposals_total", float64(validator.Proposals), "minipool", minipoolAddress)
+        t.metrics.Set("validator_missed_proposals_total", float64(validator.MissedProposals), "minipool", minipoolAddress)
+        t.metrics.Set("validator_attestation_effectiveness_percent", validator.Effectiveness(), "minipool", minipoolAddress)
+        if validator.InclusionDistanceCount > 0 {
+            t.metrics.Set("validator_inclusion_distance_average", validator.AverageInclusionDistance(), "minipool", minipoolAddress)
+        }
+    }
+    if record.LastEpoch > 0 {
+        t.metrics.Set("validator_performance_epoch", float64(record.LastEpoch))
+    }
+}
+
@@ -0,0 +1,114 @@
+package node
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/hex"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+
+// Settings
+var verifyWithdrawalCredentialsInterval, _ = time.ParseDuration("1h")
+
+
+// Verify withdrawal credentials task
+type verifyWithdrawalCredentials struct {
+    c *cli.Context
+    log log.ColorLogger
+    w *wallet.Wallet
+    rp *rocketpool.RocketPool
+    bc beacon.Client
+    alerter *alerts.Alerter
+    alerted map[common.Address]common.Hash
+}
+
+
+// Create verify withdrawal credentials task
+func newVerifyWithdrawalCredentials(c *cli.Context, logger log.ColorLogger) (*verifyWithdrawalCredentials, error) {
+
+    // Get services
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &verifyWithdrawalCredentials{
+        c: c,
+        log: logger,
+        w: w,
+        rp: rp,
+        bc: bc,
+        alerter: alerter,
+        alerted: make(map[common.Address]common.Hash),
+    }, nil
+
+}
+
+
+// Check that the beacon chain withdrawal credentials of node minipool validators match the network
+func (t *verifyWithdrawalCredentials) run() error {
+
+    // Wait for eth clients to sync
+    if err := services.WaitEthClientSynced(t.c, false); err != nil {
+        return err
+    }
+    if err := services.WaitBeaconClientSynced(t.c, false); err != nil {
+        return err
+    }
+
+    // Log
+    t.log.Println("Verifying minipool validator withdrawal credentials...")
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Verify credentials
+    expected, credentials, err := validator.VerifyWithdrawalCredentials(t.rp, t.bc, nodeAccount.Address)
+    if err != nil {
+        return err
+    }
+
+    // Alert on mismatches; each mismatched credential is only alerted once
+    mismatchCount := 0
+    for _, mpCredentials := range credentials {
+        if !mpCredentials.ValidatorExists || mpCredentials.Match {
+            continue
+        }
+        mismatchCount++
+        if alerted, ok := t.alerted[mpCredentials.Address]; ok && alerted == mpCredentials.WithdrawalCredentials {
+            continue
+        }
+        message := fmt.Sprintf("The validator %s for minipool %s has withdrawal credentials %s on the beacon chain, but %s was expected.", hex.AddPrefix(mpCredentials.ValidatorPubkey.Hex()), mpCredentials.Address.Hex(), mpCredentials.WithdrawalCredentials.Hex(), expected.Hex())
+        t.log.Error(message)
+        if err := t.alerter.Send("Withdrawal credentials mismatch", message); err != nil {
+            t.log.Error(err)
+        }
+        t.alerted[mpCredentials.Address] = mpCredentials.WithdrawalCredentials
+    }
+
+    // Log & return
+    if mismatchCount == 0 {
+        t.log.Printlnf("Withdrawal credentials verified for %d minipool validators.", len(credentials))
+    }
+    return nil
+
+}
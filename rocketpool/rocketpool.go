@@ -3,12 +3,16 @@ package main
 import (
     "fmt"
     "os"
+    "strings"
 
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/rocketpool/api"
+    "github.com/rocket-pool/smartnode/rocketpool/apiserver"
     "github.com/rocket-pool/smartnode/rocketpool/node"
     "github.com/rocket-pool/smartnode/rocketpool/watchtower"
+    "github.com/rocket-pool/smartnode/shared"
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
     apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
@@ -22,7 +26,7 @@ func main() {
     // Set application info
     app.Name = "rocketpool"
     app.Usage = "Rocket Pool service"
-    app.Version = "0.0.1"
+    app.Version = shared.RocketPoolVersion
     app.Authors = []cli.Author{
         cli.Author{
             Name:  "David Rugendyke",
@@ -63,18 +67,51 @@ func main() {
             Name:  "validatorKeychain, k",
             Usage: "Rocket Pool validator keychain absolute `path`",
         },
+        cli.StringFlag{
+            Name:  "data, d",
+            Usage: "Rocket Pool smart node data folder absolute `path`",
+        },
         cli.StringFlag{
             Name:  "eth1Provider, e",
             Usage: "Eth 1.0 provider `address`",
         },
+        cli.StringFlag{
+            Name:  "eth1AnalyticsProvider",
+            Usage: "Eth 1.0 provider `address` for heavy historical queries; defaults to the Eth 1.0 provider",
+        },
         cli.StringFlag{
             Name:  "eth2Provider, b",
             Usage: "Eth 2.0 provider `address`",
         },
+        cli.StringFlag{
+            Name:  "maxFee",
+            Usage: "Maximum gas price for transactions in `gwei`; transactions are refused or deferred above it",
+        },
+        cli.StringFlag{
+            Name:  "priorityFee",
+            Usage: "Priority fee to add to the base fee for transactions in `gwei`; defaults to the recent median",
+        },
+        cli.StringFlag{
+            Name:  "offline",
+            Usage: "Set to `true` to return transactions unsigned for signing on an offline machine, instead of signing and sending them; set by the CLI",
+        },
+        cli.StringFlag{
+            Name:  "dryRun",
+            Usage: "Set to `true` to simulate transactions and return their effects, instead of signing and sending them; set by the CLI",
+        },
+        cli.StringSliceFlag{
+            Name:  "set",
+            Usage: "Override a config setting with `key=value`, where key is the setting's dotted path (e.g. smartnode.maxFee=50); may be repeated",
+        },
+        cli.StringFlag{
+            Name:  "traceId",
+            Usage: "Record spans for API commands and return them in the response under the trace `ID`; set by the CLI",
+        },
     }
 
     // Register commands
            api.RegisterCommands(app, "api",        []string{"a"})
+     apiserver.RegisterCommands(app, "api-server", []string{})
           node.RegisterCommands(app, "node",       []string{"n"})
     watchtower.RegisterCommands(app, "watchtower", []string{"w"})
 
@@ -82,6 +119,9 @@ func main() {
     var commandName string
     app.Before = func(c *cli.Context) error {
         commandName = c.Args().First()
+        if traceID := c.GlobalString("traceId"); traceID != "" && commandName == "api" {
+            tracing.Start(traceID, "api", "api " + strings.Join(c.Args().Tail(), " "))
+        }
         return nil
     }
 
@@ -5,7 +5,7 @@ import (
     "fmt"
     "time"
 
-    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/ethclient"
     "github.com/rocket-pool/rocketpool-go/minipool"
     "github.com/rocket-pool/rocketpool-go/node"
@@ -16,6 +16,8 @@ import (
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
     "github.com/rocket-pool/smartnode/shared/services/wallet"
     "github.com/rocket-pool/smartnode/shared/utils/log"
 )
@@ -30,47 +32,44 @@ type dissolveTimedOutMinipools struct {
     c *cli.Context
     log log.ColorLogger
     w *wallet.Wallet
+    txm *transactions.Manager
     ec *ethclient.Client
     rp *rocketpool.RocketPool
+    cache *contracts.Cache
+    mc *minipoolCache
 }
 
 
 // Create dissolve timed out minipools task
-func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*dissolveTimedOutMinipools, error) {
+func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger, mc *minipoolCache) (*dissolveTimedOutMinipools, error) {
 
     // Get services
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
     ec, err := services.GetEthClient(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return nil, err }
 
     // Return task
     return &dissolveTimedOutMinipools{
         c: c,
         log: logger,
         w: w,
+        txm: txm,
         ec: ec,
         rp: rp,
+        cache: cache,
+        mc: mc,
     }, nil
 
 }
 
 
-// Start dissolve timed out minipools task
-func (t *dissolveTimedOutMinipools) Start() {
-    go (func() {
-        for {
-            if err := t.run(); err != nil {
-                t.log.Println(err)
-            }
-            time.Sleep(dissolveTimedOutMinipoolsInterval)
-        }
-    })()
-}
-
-
 // Dissolve timed out minipools
 func (t *dissolveTimedOutMinipools) run() error {
 
@@ -112,7 +111,7 @@ func (t *dissolveTimedOutMinipools) run() error {
     // Dissolve minipools
     for _, mp := range minipools {
         if err := t.dissolveMinipool(mp); err != nil {
-            t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.Address.Hex(), err))
+            t.log.Error(fmt.Errorf("Could not dissolve minipool %s: %w", mp.Address.Hex(), err))
         }
     }
 
@@ -127,15 +126,12 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
 
     // Data
     var wg1 errgroup.Group
-    var addresses []common.Address
     var currentBlock uint64
     var launchTimeout uint64
 
-    // Get minipool addresses
+    // Update minipool cache
     wg1.Go(func() error {
-        var err error
-        addresses, err = minipool.GetMinipoolAddresses(t.rp, nil)
-        return err
+        return t.mc.update()
     })
 
     // Get current block
@@ -150,7 +146,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
     // Get launch timeout
     wg1.Go(func() error {
         var err error
-        launchTimeout, err = settings.GetMinipoolLaunchTimeout(t.rp, nil)
+        launchTimeout, err = t.cache.GetUint64("settings.minipoolLaunchTimeout", func() (uint64, error) { return settings.GetMinipoolLaunchTimeout(t.rp, nil) })
         return err
     })
 
@@ -159,6 +155,9 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
         return []*minipool.Minipool{}, err
     }
 
+    // Get minipools which may be prelaunch
+    addresses := t.mc.getAddresses(types.Prelaunch)
+
     // Create minipool contracts
     minipools := make([]*minipool.Minipool, len(addresses))
     for mi, address := range addresses {
@@ -191,6 +190,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
     // Filter minipools by status
     timedOutMinipools := []*minipool.Minipool{}
     for mi, mp := range minipools {
+        t.mc.setStatus(mp.Address, statuses[mi].Status)
         if statuses[mi].Status == types.Prelaunch && (currentBlock - statuses[mi].StatusBlock) >= launchTimeout {
             timedOutMinipools = append(timedOutMinipools, mp)
         }
@@ -209,13 +209,22 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp *minipool.Minipool) erro
     t.log.Printlnf("Dissolving minipool %s...", mp.Address.Hex())
 
     // Get transactor
-    opts, err := t.w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(t.c)
+    if err != nil {
+        return err
+    }
+    opts, err = services.GetTaskTransactor(t.c, "dissolve-timed-out-minipools", opts, t.log)
     if err != nil {
         return err
     }
 
     // Dissolve
-    if _, err := mp.Dissolve(opts); err != nil {
+    if _, err := t.txm.Transact(opts, fmt.Sprintf("Dissolve minipool %s", mp.Address.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := mp.Dissolve(opts)
+        return err
+    }); transactions.IsRehearsed(err) {
+        return nil
+    } else if err != nil {
         return err
     }
 
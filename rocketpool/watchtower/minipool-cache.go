@@ -0,0 +1,178 @@
+package watchtower
+
+import (
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/types"
+    "golang.org/x/sync/errgroup"
+)
+
+
+// Settings
+var minipoolCacheFullScanInterval, _ = time.ParseDuration("1h")
+
+
+// Network minipool cache
+// Minipool addresses are loaded incrementally by index, and minipool statuses only move forwards,
+// so minipools which have passed the status a task is interested in can be skipped on later scans
+type minipoolCache struct {
+    rp *rocketpool.RocketPool
+    addresses []common.Address
+    minipools map[common.Address]*cachedMinipool
+    lastFullScan time.Time
+    lock sync.Mutex
+}
+
+
+// Cached minipool details
+type cachedMinipool struct {
+    Status types.MinipoolStatus
+    Pubkey types.ValidatorPubkey
+}
+
+
+// Create network minipool cache
+func newMinipoolCache(rp *rocketpool.RocketPool) *minipoolCache {
+    return &minipoolCache{
+        rp: rp,
+        addresses: []common.Address{},
+        minipools: make(map[common.Address]*cachedMinipool),
+    }
+}
+
+
+// Update the cached minipool address set
+func (mc *minipoolCache) update() error {
+
+    // Lock cache
+    mc.lock.Lock()
+    defer mc.lock.Unlock()
+
+    // Get minipool count
+    count, err := minipool.GetMinipoolCount(mc.rp, nil)
+    if err != nil {
+        return err
+    }
+
+    // Check whether a full scan is required; minipools are removed from the set by swapping in the last item
+    fullScan := count < uint64(len(mc.addresses)) || time.Since(mc.lastFullScan) >= minipoolCacheFullScanInterval
+    if !fullScan && len(mc.addresses) > 0 {
+        lastIndex := uint64(len(mc.addresses) - 1)
+        lastAddress, err := minipool.GetMinipoolAt(mc.rp, lastIndex, nil)
+        if err != nil {
+            return err
+        }
+        fullScan = (lastAddress != mc.addresses[lastIndex])
+    }
+
+    // Full scan
+    if fullScan {
+        addresses, err := minipool.GetMinipoolAddresses(mc.rp, nil)
+        if err != nil {
+            return err
+        }
+        minipools := make(map[common.Address]*cachedMinipool)
+        for _, address := range addresses {
+            if details, ok := mc.minipools[address]; ok {
+                minipools[address] = details
+            } else {
+                minipools[address] = &cachedMinipool{}
+            }
+        }
+        mc.addresses = addresses
+        mc.minipools = minipools
+        mc.lastFullScan = time.Now()
+        return nil
+    }
+
+    // Data
+    var wg errgroup.Group
+    start := uint64(len(mc.addresses))
+    newAddresses := make([]common.Address, count - start)
+
+    // Load new minipool addresses
+    for mi := start; mi < count; mi++ {
+        mi := mi
+        wg.Go(func() error {
+            address, err := minipool.GetMinipoolAt(mc.rp, mi, nil)
+            if err == nil { newAddresses[mi - start] = address }
+            return err
+        })
+    }
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return err
+    }
+
+    // Add new minipools
+    for _, address := range newAddresses {
+        mc.addresses = append(mc.addresses, address)
+        mc.minipools[address] = &cachedMinipool{}
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get the addresses of minipools which have not progressed past a status
+func (mc *minipoolCache) getAddresses(maxStatus types.MinipoolStatus) []common.Address {
+    mc.lock.Lock()
+    defer mc.lock.Unlock()
+    addresses := []common.Address{}
+    for _, address := range mc.addresses {
+        if mc.minipools[address].Status <= maxStatus {
+            addresses = append(addresses, address)
+        }
+    }
+    return addresses
+}
+
+
+// Record the latest status of a minipool
+func (mc *minipoolCache) setStatus(address common.Address, status types.MinipoolStatus) {
+    mc.lock.Lock()
+    defer mc.lock.Unlock()
+    if details, ok := mc.minipools[address]; ok && status > details.Status {
+        details.Status = status
+    }
+}
+
+
+// Get a minipool's validator pubkey, loading it if not cached
+// Pubkeys are only set when a minipool begins staking, so empty pubkeys are not cached
+func (mc *minipoolCache) getPubkey(address common.Address) (types.ValidatorPubkey, error) {
+
+    // Check cache
+    mc.lock.Lock()
+    details, ok := mc.minipools[address]
+    if ok && details.Pubkey != (types.ValidatorPubkey{}) {
+        pubkey := details.Pubkey
+        mc.lock.Unlock()
+        return pubkey, nil
+    }
+    mc.lock.Unlock()
+
+    // Load pubkey
+    pubkey, err := minipool.GetMinipoolPubkey(mc.rp, address, nil)
+    if err != nil {
+        return types.ValidatorPubkey{}, err
+    }
+
+    // Cache pubkey
+    if ok && pubkey != (types.ValidatorPubkey{}) {
+        mc.lock.Lock()
+        details.Pubkey = pubkey
+        mc.lock.Unlock()
+    }
+
+    // Return
+    return pubkey, nil
+
+}
@@ -0,0 +1,159 @@
+package watchtower
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/rocket-pool/rocketpool-go/node"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Settings
+var monitorNodeStatusInterval, _ = time.ParseDuration("5m")
+
+
+// Monitor node status task
+type monitorNodeStatus struct {
+    c *cli.Context
+    log log.ColorLogger
+    cfg config.RocketPoolConfig
+    w *wallet.Wallet
+    ec *ethclient.Client
+    rp *rocketpool.RocketPool
+    alerter *alerts.Alerter
+    monitor *watchtowerMonitor
+    wasTrusted bool
+    lowBalance bool
+}
+
+
+// Create monitor node status task
+func newMonitorNodeStatus(c *cli.Context, logger log.ColorLogger, monitor *watchtowerMonitor) (*monitorNodeStatus, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
+
+    // Return task
+    return &monitorNodeStatus{
+        c: c,
+        log: logger,
+        cfg: cfg,
+        w: w,
+        ec: ec,
+        rp: rp,
+        alerter: alerter,
+        monitor: monitor,
+    }, nil
+
+}
+
+
+// Record the node's trusted status & balance, and alert if it can no longer perform oracle duties
+func (t *monitorNodeStatus) run() error {
+
+    // Get latest config
+    cfg, err := services.GetConfig(t.c)
+    if err != nil {
+        return err
+    }
+    t.cfg = cfg
+
+    // Wait for eth client to sync
+    if err := services.WaitEthClientSynced(t.c, true); err != nil {
+        return err
+    }
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Data
+    var wg errgroup.Group
+    var trusted bool
+    var balance *big.Int
+
+    // Get node trusted status
+    wg.Go(func() error {
+        var err error
+        trusted, err = node.GetNodeTrusted(t.rp, nodeAccount.Address, nil)
+        return err
+    })
+
+    // Get node balance
+    wg.Go(func() error {
+        var err error
+        balance, err = t.ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return err
+    }
+
+    // Record metrics
+    trustedValue := 0.0
+    if trusted {
+        trustedValue = 1
+    }
+    t.monitor.metrics.Set("node_trusted", trustedValue)
+    t.monitor.metrics.Set("node_balance_eth", eth.WeiToEth(balance))
+
+    // Alert when the node loses its trusted status
+    if t.wasTrusted && !trusted {
+        t.notify("Node no longer trusted", fmt.Sprintf("Node %s is no longer a trusted node; the watchtower will stop making oracle submissions.", nodeAccount.Address.Hex()))
+    }
+    t.wasTrusted = trusted
+    if !trusted {
+        return nil
+    }
+
+    // Alert once when the node balance falls below the threshold
+    threshold := eth.EthToWei(t.cfg.GetWatchtowerLowBalanceAlert())
+    if balance.Cmp(threshold) < 0 {
+        if !t.lowBalance {
+            t.notify("Watchtower balance low", fmt.Sprintf("Node %s has a balance of %s, below the %s alert threshold; top it up so that the watchtower can continue to pay for oracle submissions.", nodeAccount.Address.Hex(), units.FormatEth(balance), units.FormatEth(threshold)))
+        }
+        t.lowBalance = true
+    } else {
+        t.lowBalance = false
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Log & send an alert
+func (t *monitorNodeStatus) notify(title, message string) {
+    t.log.Println(message)
+    if err := t.alerter.Send(title, message); err != nil {
+        t.log.Error(err)
+    }
+}
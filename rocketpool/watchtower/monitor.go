@@ -0,0 +1,98 @@
+package watchtower
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/metrics"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Config
+const MetricsNamespace = metrics.WatchtowerNamespace
+
+
+// Oracle submission types
+const (
+    SubmissionNetworkBalances = "network-balances"
+    SubmissionMinipoolWithdrawable = "minipool-withdrawable"
+)
+
+
+// Watchtower monitor
+// Records task & submission metrics, and alerts on failed submissions
+type watchtowerMonitor struct {
+    metrics *metrics.Registry
+    alerter *alerts.Alerter
+}
+
+
+// Create watchtower monitor
+func newWatchtowerMonitor(alerter *alerts.Alerter) *watchtowerMonitor {
+
+    // Define metrics
+    r := metrics.NewRegistry(MetricsNamespace)
+    r.Counter("task_runs_total", "Watchtower task runs.")
+    r.Counter("task_errors_total", "Watchtower task runs which returned an error.")
+    r.Gauge("task_duration_seconds", "Duration of the latest watchtower task run.")
+    r.Gauge("task_last_run_timestamp_seconds", "Time of the latest watchtower task run.")
+    r.Counter("submissions_total", "Oracle submissions mined.")
+    r.Counter("submission_failures_total", "Oracle submissions which could not be sent or failed.")
+    r.Counter("submissions_skipped_total", "Oracle submissions skipped as they had already reached consensus.")
+    r.Gauge("last_submission_timestamp_seconds", "Time of the latest successful oracle submission.")
+    r.Gauge("last_balances_block", "The latest block network balances were submitted for.")
+    r.Gauge("node_trusted", "Whether the node is a trusted node (1) or not (0).")
+    r.Gauge("node_balance_eth", "The node account ETH balance available to pay for submissions.")
+
+    // Return
+    return &watchtowerMonitor{
+        metrics: r,
+        alerter: alerter,
+    }
+
+}
+
+
+// Serve metrics in the background; disabled if the address is empty
+func (m *watchtowerMonitor) serve(address string, logger log.ColorLogger) {
+    if address == "" {
+        return
+    }
+    go (func() {
+        logger.Printlnf("Serving watchtower metrics on %s%s...", address, metrics.MetricsPath)
+        if err := m.metrics.Serve(address); err != nil {
+            logger.Error(fmt.Errorf("Could not serve watchtower metrics: %w", err))
+        }
+    })()
+}
+
+
+// Record a task run; used as the scheduler run hook
+func (m *watchtowerMonitor) recordTaskRun(name string, duration time.Duration, err error) {
+    m.metrics.Add("task_runs_total", 1, "task", name)
+    if err != nil {
+        m.metrics.Add("task_errors_total", 1, "task", name)
+    }
+    m.metrics.Set("task_duration_seconds", duration.Seconds(), "task", name)
+    m.metrics.Set("task_last_run_timestamp_seconds", float64(time.Now().Unix()), "task", name)
+}
+
+
+// Record an oracle submission, alerting if it failed
+func (m *watchtowerMonitor) recordSubmission(submissionType, description string, err error) error {
+    if err != nil {
+        m.metrics.Add("submission_failures_total", 1, "type", submissionType)
+        return m.alerter.Send("Watchtower submission failed", fmt.Sprintf("Could not %s: %s", description, err.Error()))
+    }
+    m.metrics.Add("submissions_total", 1, "type", submissionType)
+    m.metrics.Set("last_submission_timestamp_seconds", float64(time.Now().Unix()), "type", submissionType)
+    return nil
+}
+
+
+// Record an oracle submission skipped as it had already reached consensus
+func (m *watchtowerMonitor) recordSkipped(submissionType string) {
+    m.metrics.Add("submissions_skipped_total", 1, "type", submissionType)
+}
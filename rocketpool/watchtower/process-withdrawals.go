@@ -1,14 +1,26 @@
 package watchtower
 
 import (
+    "fmt"
+    "math/big"
     "time"
 
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/network"
+    "github.com/rocket-pool/rocketpool-go/node"
     "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/types"
     "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
     "github.com/rocket-pool/smartnode/shared/services/wallet"
     "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
@@ -21,51 +33,259 @@ type processWithdrawals struct {
     c *cli.Context
     log log.ColorLogger
     w *wallet.Wallet
+    txm *transactions.Manager
     rp *rocketpool.RocketPool
+    alerter *alerts.Alerter
+    mc *minipoolCache
+}
+
+
+// Minipool withdrawal info
+type minipoolWithdrawalDetails struct {
+    Address common.Address
+    Pubkey types.ValidatorPubkey
+    TotalBalance *big.Int
+    NodeBalance *big.Int
+    Processable bool
 }
 
 
 // Create process withdrawals task
-func newProcessWithdrawals(c *cli.Context, logger log.ColorLogger) (*processWithdrawals, error) {
+func newProcessWithdrawals(c *cli.Context, logger log.ColorLogger, mc *minipoolCache) (*processWithdrawals, error) {
 
     // Get services
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return nil, err }
 
     // Return task
     return &processWithdrawals{
         c: c,
         log: logger,
         w: w,
+        txm: txm,
         rp: rp,
+        alerter: alerter,
+        mc: mc,
     }, nil
 
 }
 
 
-// Start process withdrawals task
-func (t *processWithdrawals) Start() {
-    go (func() {
-        for {
-            if err := t.run(); err != nil {
-                t.log.Println(err)
-            }
-            time.Sleep(processWithdrawalsInterval)
+// Process withdrawals for withdrawable minipools whose validator balances have been received by the withdrawal pool
+func (t *processWithdrawals) run() error {
+
+    // Wait for eth client to sync
+    if err := services.WaitEthClientSynced(t.c, true); err != nil {
+        return err
+    }
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Check node trusted status
+    nodeTrusted, err := node.GetNodeTrusted(t.rp, nodeAccount.Address, nil)
+    if err != nil {
+        return err
+    }
+    if !nodeTrusted {
+        return nil
+    }
+
+    // Log
+    t.log.Println("Checking for minipool withdrawals to process...")
+
+    // Get minipool withdrawal details
+    minipools, err := t.getNetworkMinipoolWithdrawalDetails()
+    if err != nil {
+        return err
+    }
+    if len(minipools) == 0 {
+        return nil
+    }
+
+    // Get withdrawal pool balance
+    withdrawalBalance, err := network.GetWithdrawalBalance(t.rp, nil)
+    if err != nil {
+        return err
+    }
+
+    // Log
+    t.log.Printlnf("%d minipools have withdrawals to process...", len(minipools))
+
+    // Process withdrawals which the withdrawal pool has sufficient balance for
+    for _, details := range minipools {
+        if withdrawalBalance.Cmp(details.TotalBalance) < 0 {
+            t.log.Warnf("The withdrawal pool balance of %s is insufficient to process the minipool %s withdrawal of %s; waiting for the validator balance to be received.", units.FormatEth(withdrawalBalance), details.Address.Hex(), units.FormatEth(details.TotalBalance))
+            continue
+        }
+        if err := t.processWithdrawal(details); err != nil {
+            t.notify("Withdrawal processing failed", fmt.Sprintf("Could not process minipool %s withdrawal: %s", details.Address.Hex(), err.Error()))
+            continue
         }
-    })()
+        withdrawalBalance.Sub(withdrawalBalance, details.TotalBalance)
+    }
+
+    // Return
+    return nil
+
 }
 
 
-// Process withdrawals
-func (t *processWithdrawals) run() error {
+// Get all withdrawable minipools with unprocessed withdrawals
+func (t *processWithdrawals) getNetworkMinipoolWithdrawalDetails() ([]minipoolWithdrawalDetails, error) {
+
+    // Update minipool cache
+    if err := t.mc.update(); err != nil {
+        return []minipoolWithdrawalDetails{}, err
+    }
+
+    // Get minipools which may be withdrawable
+    addresses := t.mc.getAddresses(types.Withdrawable)
+
+    // Data
+    var wg errgroup.Group
+    minipools := make([]minipoolWithdrawalDetails, len(addresses))
+
+    // Load details
+    for mi, address := range addresses {
+        mi, address := mi, address
+        wg.Go(func() error {
+            mpDetails, err := t.getMinipoolWithdrawalDetails(address)
+            if err == nil { minipools[mi] = mpDetails }
+            return err
+        })
+    }
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return []minipoolWithdrawalDetails{}, err
+    }
 
-    // Process withdrawals
-    // TODO: implement
+    // Filter by processable status
+    processableMinipools := []minipoolWithdrawalDetails{}
+    for _, details := range minipools {
+        if details.Processable {
+            processableMinipools = append(processableMinipools, details)
+        }
+    }
+
+    // Return
+    return processableMinipools, nil
+
+}
+
+
+// Get minipool withdrawal details
+func (t *processWithdrawals) getMinipoolWithdrawalDetails(minipoolAddress common.Address) (minipoolWithdrawalDetails, error) {
+
+    // Create minipool
+    mp, err := minipool.NewMinipool(t.rp, minipoolAddress)
+    if err != nil {
+        return minipoolWithdrawalDetails{}, err
+    }
+
+    // Check minipool status
+    status, err := mp.GetStatus(nil)
+    if err != nil {
+        return minipoolWithdrawalDetails{}, err
+    }
+    t.mc.setStatus(minipoolAddress, status)
+    if status != types.Withdrawable {
+        return minipoolWithdrawalDetails{}, nil
+    }
+
+    // Check withdrawal processed status
+    withdrawalProcessed, err := minipool.GetMinipoolWithdrawalProcessed(t.rp, minipoolAddress, nil)
+    if err != nil {
+        return minipoolWithdrawalDetails{}, err
+    }
+    if withdrawalProcessed {
+        return minipoolWithdrawalDetails{}, nil
+    }
+
+    // Data
+    var wg errgroup.Group
+    details := minipoolWithdrawalDetails{
+        Address: minipoolAddress,
+        Processable: true,
+    }
+
+    // Load data
+    wg.Go(func() error {
+        var err error
+        details.Pubkey, err = t.mc.getPubkey(minipoolAddress)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        details.TotalBalance, err = minipool.GetMinipoolWithdrawalTotalBalance(t.rp, minipoolAddress, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        details.NodeBalance, err = minipool.GetMinipoolWithdrawalNodeBalance(t.rp, minipoolAddress, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return minipoolWithdrawalDetails{}, err
+    }
+
+    // Return
+    return details, nil
+
+}
+
+
+// Process a minipool withdrawal
+func (t *processWithdrawals) processWithdrawal(details minipoolWithdrawalDetails) error {
+
+    // Log
+    t.log.Printlnf("Processing minipool %s withdrawal...", details.Address.Hex())
+
+    // Get transactor
+    opts, err := services.GetNodeAccountTransactor(t.c)
+    if err != nil {
+        return err
+    }
+    opts, err = services.GetTaskTransactor(t.c, "process-withdrawals", opts, t.log)
+    if err != nil {
+        return err
+    }
+
+    // Process withdrawal
+    if _, err := t.txm.Transact(opts, fmt.Sprintf("Process minipool %s withdrawal", details.Address.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := network.ProcessWithdrawal(t.rp, details.Pubkey, opts)
+        return err
+    }); transactions.IsRehearsed(err) {
+        return nil
+    } else if err != nil {
+        return err
+    }
+
+    // Log & alert
+    t.notify("Withdrawal processed", fmt.Sprintf("Successfully processed minipool %s withdrawal of %s (%s to the node operator); the minipool can now be withdrawn from and closed by its node.", details.Address.Hex(), units.FormatEth(details.TotalBalance), units.FormatEth(details.NodeBalance)))
 
     // Return
     return nil
 
 }
 
+
+// Log & send an alert
+func (t *processWithdrawals) notify(title, message string) {
+    t.log.Println(message)
+    if err := t.alerter.Send(title, message); err != nil {
+        t.log.Error(err)
+    }
+}
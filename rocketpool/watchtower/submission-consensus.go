@@ -0,0 +1,162 @@
+package watchtower
+
+import (
+    "fmt"
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+)
+
+
+// Settings
+var submissionDutyExpiry, _ = time.ParseDuration("24h")
+var consensusThresholdBase = big.NewInt(1e18)
+
+
+// Trusted node submission consensus tracker
+// Submissions can be delayed after a duty is first seen, giving other trusted nodes the chance to reach consensus first,
+// and are skipped once consensus has been reached so that no gas is spent on redundant transactions
+type submissionConsensus struct {
+    rp *rocketpool.RocketPool
+    cache *contracts.Cache
+    delay time.Duration
+    dutiesSeen map[common.Hash]time.Time
+    lock sync.Mutex
+}
+
+
+// Consensus status of a submission
+type submissionConsensusStatus struct {
+    Submissions uint64
+    Required uint64
+    Reached bool
+}
+
+
+// Create trusted node submission consensus tracker
+func newSubmissionConsensus(rp *rocketpool.RocketPool, cache *contracts.Cache, delay time.Duration) *submissionConsensus {
+    return &submissionConsensus{
+        rp: rp,
+        cache: cache,
+        delay: delay,
+        dutiesSeen: make(map[common.Hash]time.Time),
+    }
+}
+
+
+// Set the submission delay
+func (sc *submissionConsensus) setDelay(delay time.Duration) {
+    sc.lock.Lock()
+    defer sc.lock.Unlock()
+    sc.delay = delay
+}
+
+
+// Check whether a submission duty is still within its delay, and get the remaining delay
+func (sc *submissionConsensus) isDelayed(duty common.Hash) (bool, time.Duration) {
+
+    // Lock tracker
+    sc.lock.Lock()
+    defer sc.lock.Unlock()
+
+    // Remove expired duties
+    for key, seen := range sc.dutiesSeen {
+        if time.Since(seen) > submissionDutyExpiry {
+            delete(sc.dutiesSeen, key)
+        }
+    }
+
+    // Record & check duty
+    seen, ok := sc.dutiesSeen[duty]
+    if !ok {
+        seen = time.Now()
+        sc.dutiesSeen[duty] = seen
+    }
+    remaining := sc.delay - time.Since(seen)
+    return (remaining > 0), remaining
+
+}
+
+
+// Get the consensus status of a submission from its submission count storage key
+func (sc *submissionConsensus) getStatus(countKey common.Hash) (submissionConsensusStatus, error) {
+
+    // Data
+    var wg errgroup.Group
+    var submissions *big.Int
+    var trustedNodeCount *big.Int
+    var consensusThreshold *big.Int
+
+    // Get submission count
+    wg.Go(func() error {
+        var err error
+        submissions, err = sc.rp.RocketStorage.GetUint(nil, countKey)
+        if err != nil {
+            return fmt.Errorf("Could not get submission count: %w", err)
+        }
+        return nil
+    })
+
+    // Get trusted node count
+    wg.Go(func() error {
+        rocketNodeManager, err := sc.cache.GetContract("rocketNodeManager")
+        if err != nil {
+            return err
+        }
+        count := new(*big.Int)
+        if err := rocketNodeManager.Call(nil, count, "getTrustedNodeCount"); err != nil {
+            return fmt.Errorf("Could not get trusted node count: %w", err)
+        }
+        trustedNodeCount = *count
+        return nil
+    })
+
+    // Get consensus threshold
+    wg.Go(func() error {
+        var err error
+        consensusThreshold, err = sc.cache.GetBig("settings.nodeConsensusThreshold", func() (*big.Int, error) {
+            rocketNetworkSettings, err := sc.cache.GetContract("rocketNetworkSettings")
+            if err != nil {
+                return nil, err
+            }
+            threshold := new(*big.Int)
+            if err := rocketNetworkSettings.Call(nil, threshold, "getNodeConsensusThreshold"); err != nil {
+                return nil, fmt.Errorf("Could not get node consensus threshold: %w", err)
+            }
+            return *threshold, nil
+        })
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return submissionConsensusStatus{}, err
+    }
+
+    // Get required submission count; consensus is reached once submissions / trusted nodes >= threshold
+    required := new(big.Int).Mul(trustedNodeCount, consensusThreshold)
+    required.Add(required, new(big.Int).Sub(consensusThresholdBase, big.NewInt(1)))
+    required.Div(required, consensusThresholdBase)
+
+    // Return
+    return submissionConsensusStatus{
+        Submissions: submissions.Uint64(),
+        Required: required.Uint64(),
+        Reached: (required.Sign() > 0 && submissions.Cmp(required) >= 0),
+    }, nil
+
+}
+
+
+// Encode an integer as a 32-byte storage key component
+func uint256Bytes(value *big.Int) []byte {
+    buf := make([]byte, 32)
+    value.FillBytes(buf)
+    return buf
+}
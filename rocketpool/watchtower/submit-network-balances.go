@@ -24,24 +24,35 @@ import (
 
     "github.com/rocket-pool/smartnode/shared/services"
     "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
     "github.com/rocket-pool/smartnode/shared/services/wallet"
     "github.com/rocket-pool/smartnode/shared/utils/eth2"
     "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
 )
 
 
 // Settings
-var submitNetworkBalancesInterval, _ = time.ParseDuration("5m")
+const CheckpointApproachBlocks = 80 // Blocks before a balance checkpoint from which it is polled for at the active interval
+var submitNetworkBalancesActiveInterval, _ = time.ParseDuration("1m")
+var submitNetworkBalancesIdleInterval, _ = time.ParseDuration("15m")
 
 
 // Submit network balances task
 type submitNetworkBalances struct {
     c *cli.Context
     log log.ColorLogger
+    sc *submissionConsensus
+    monitor *watchtowerMonitor
     w *wallet.Wallet
+    txm *transactions.Manager
     ec *ethclient.Client
     rp *rocketpool.RocketPool
+    cache *contracts.Cache
     bc beacon.Client
+    pending bool
 }
 
 
@@ -61,15 +72,19 @@ type minipoolBalanceDetails struct {
 
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, sc *submissionConsensus, monitor *watchtowerMonitor) (*submitNetworkBalances, error) {
 
     // Get services
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
     ec, err := services.GetEthClient(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return nil, err }
     bc, err := services.GetBeaconClient(c)
     if err != nil { return nil, err }
 
@@ -77,28 +92,19 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNe
     return &submitNetworkBalances{
         c: c,
         log: logger,
+        sc: sc,
+        monitor: monitor,
         w: w,
+        txm: txm,
         ec: ec,
         rp: rp,
+        cache: cache,
         bc: bc,
     }, nil
 
 }
 
 
-// Start submit network balances task
-func (t *submitNetworkBalances) Start() {
-    go (func() {
-        for {
-            if err := t.run(); err != nil {
-                t.log.Println(err)
-            }
-            time.Sleep(submitNetworkBalancesInterval)
-        }
-    })()
-}
-
-
 // Submit network balances
 func (t *submitNetworkBalances) run() error {
 
@@ -129,7 +135,7 @@ func (t *submitNetworkBalances) run() error {
     })
     wg.Go(func() error {
         var err error
-        submitBalancesEnabled, err = settings.GetSubmitBalancesEnabled(t.rp, nil)
+        submitBalancesEnabled, err = t.cache.GetBool("settings.submitBalancesEnabled", func() (bool, error) { return settings.GetSubmitBalancesEnabled(t.rp, nil) })
         return err
     })
 
@@ -139,6 +145,7 @@ func (t *submitNetworkBalances) run() error {
     }
 
     // Check node trusted status & settings
+    t.pending = false
     if !(nodeTrusted && submitBalancesEnabled) {
         return nil
     }
@@ -147,7 +154,7 @@ func (t *submitNetworkBalances) run() error {
     t.log.Println("Checking for network balance checkpoint...")
 
     // Get block to submit balances for
-    blockNumber, err := t.getLatestReportableBlock()
+    blockNumber, nextBlockNumber, currentBlock, err := t.getReportableBlocks()
     if err != nil {
         return err
     }
@@ -157,10 +164,18 @@ func (t *submitNetworkBalances) run() error {
     if err != nil {
         return err
     }
+    t.pending = (canSubmit || (nextBlockNumber - currentBlock) <= CheckpointApproachBlocks)
     if !canSubmit {
         return nil
     }
 
+    // Check submission delay
+    blockNumberBuf := uint256Bytes(big.NewInt(int64(blockNumber)))
+    if delayed, remaining := t.sc.isDelayed(crypto.Keccak256Hash([]byte("network.balances"), blockNumberBuf)); delayed {
+        t.log.Printlnf("Waiting %s for other trusted nodes to submit network balances for block %d...", remaining.Round(time.Second), blockNumber)
+        return nil
+    }
+
     // Log
     t.log.Printlnf("Calculating network balances for block %d...", blockNumber)
 
@@ -171,16 +186,41 @@ func (t *submitNetworkBalances) run() error {
     }
 
     // Log
-    t.log.Printlnf("Deposit pool balance: %.2f ETH", eth.WeiToEth(balances.DepositPool))
-    t.log.Printlnf("Total minipool user balance: %.2f ETH", eth.WeiToEth(balances.MinipoolsTotal))
-    t.log.Printlnf("Staking minipool user balance: %.2f ETH", eth.WeiToEth(balances.MinipoolsStaking))
-    t.log.Printlnf("rETH contract balance: %.2f ETH", eth.WeiToEth(balances.RETHContract))
-    t.log.Printlnf("rETH token supply: %.2f rETH", eth.WeiToEth(balances.RETHSupply))
+    t.log.Printlnf("Deposit pool balance: %s", units.FormatEth(balances.DepositPool))
+    t.log.Printlnf("Total minipool user balance: %s", units.FormatEth(balances.MinipoolsTotal))
+    t.log.Printlnf("Staking minipool user balance: %s", units.FormatEth(balances.MinipoolsStaking))
+    t.log.Printlnf("rETH contract balance: %s", units.FormatEth(balances.RETHContract))
+    t.log.Printlnf("rETH token supply: %s", units.FormatToken(balances.RETHSupply, units.TokenDecimals, "rETH"))
+
+    // Calculate total ETH balance
+    totalEth, err := safemath.Sum(balances.DepositPool, balances.MinipoolsTotal, balances.RETHContract)
+    if err != nil {
+        return fmt.Errorf("Could not calculate total network ETH balance: %w", err)
+    }
+
+    // Check whether the balances have already reached consensus
+    consensus, err := t.sc.getStatus(crypto.Keccak256Hash([]byte("network.balances.submitted.count"), blockNumberBuf, uint256Bytes(totalEth), uint256Bytes(balances.MinipoolsStaking), uint256Bytes(balances.RETHSupply)))
+    if err != nil {
+        return err
+    }
+    if consensus.Reached {
+        t.log.Printlnf("Network balances for block %d have already reached consensus with %d of %d required submissions; skipping redundant submission.", blockNumber, consensus.Submissions, consensus.Required)
+        t.monitor.recordSkipped(SubmissionNetworkBalances)
+        return nil
+    }
 
     // Submit balances
-    if err := t.submitBalances(balances); err != nil {
+    err = t.submitBalances(balances, totalEth)
+    if transactions.IsRehearsed(err) {
+        return nil
+    }
+    if alertErr := t.monitor.recordSubmission(SubmissionNetworkBalances, fmt.Sprintf("submit network balances for block %d", blockNumber), err); alertErr != nil {
+        t.log.Error(alertErr)
+    }
+    if err != nil {
         return fmt.Errorf("Could not submit network balances: %w", err)
     }
+    t.monitor.metrics.Set("last_balances_block", float64(blockNumber))
 
     // Return
     return nil
@@ -188,8 +228,14 @@ func (t *submitNetworkBalances) run() error {
 }
 
 
-// Get the latest block number to report balances for
-func (t *submitNetworkBalances) getLatestReportableBlock() (uint64, error) {
+// Check whether the node has balances to submit or a balance checkpoint is approaching
+func (t *submitNetworkBalances) isActive() bool {
+    return t.pending
+}
+
+
+// Get the latest block to submit balances for, the next balance checkpoint block and the current block
+func (t *submitNetworkBalances) getReportableBlocks() (uint64, uint64, uint64, error) {
 
     // Data
     var wg errgroup.Group
@@ -208,17 +254,18 @@ func (t *submitNetworkBalances) getLatestReportableBlock() (uint64, error) {
     // Get balance submission frequency
     wg.Go(func() error {
         var err error
-        submitBalancesFrequency, err = settings.GetSubmitBalancesFrequency(t.rp, nil)
+        submitBalancesFrequency, err = t.cache.GetUint64("settings.submitBalancesFrequency", func() (uint64, error) { return settings.GetSubmitBalancesFrequency(t.rp, nil) })
         return err
     })
 
     // Wait for data
     if err := wg.Wait(); err != nil {
-        return 0, err
+        return 0, 0, 0, err
     }
 
     // Calculate and return
-    return (currentBlock / submitBalancesFrequency) * submitBalancesFrequency, nil
+    latestBlock := (currentBlock / submitBalancesFrequency) * submitBalancesFrequency
+    return latestBlock, latestBlock + submitBalancesFrequency, currentBlock, nil
 
 }
 
@@ -318,10 +365,15 @@ func (t *submitNetworkBalances) getNetworkBalances(blockNumber uint64) (networkB
     }
 
     // Add minipool balances
+    var err error
     for _, mp := range minipoolBalanceDetails {
-        balances.MinipoolsTotal.Add(balances.MinipoolsTotal, mp.UserBalance)
+        if balances.MinipoolsTotal, err = safemath.Add(balances.MinipoolsTotal, mp.UserBalance); err != nil {
+            return networkBalances{}, fmt.Errorf("Could not calculate total minipool user balance: %w", err)
+        }
         if mp.IsStaking {
-            balances.MinipoolsStaking.Add(balances.MinipoolsStaking, mp.UserBalance)
+            if balances.MinipoolsStaking, err = safemath.Add(balances.MinipoolsStaking, mp.UserBalance); err != nil {
+                return networkBalances{}, fmt.Errorf("Could not calculate staking minipool user balance: %w", err)
+            }
         }
     }
 
@@ -517,8 +569,10 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(minipoolAddress common
     if err != nil {
         return minipoolBalanceDetails{}, err
     }
-    userBalance := big.NewInt(0)
-    userBalance.Sub(blockBalance, nodeBalance)
+    userBalance, err := safemath.Sub(blockBalance, nodeBalance)
+    if err != nil {
+        return minipoolBalanceDetails{}, fmt.Errorf("Could not calculate minipool %s user balance: %w", minipoolAddress.Hex(), err)
+    }
 
     // Return
     return minipoolBalanceDetails{
@@ -530,25 +584,26 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(minipoolAddress common
 
 
 // Submit network balances
-func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
+func (t *submitNetworkBalances) submitBalances(balances networkBalances, totalEth *big.Int) error {
 
     // Log
     t.log.Printlnf("Submitting network balances for block %d...", balances.Block)
 
-    // Calculate total ETH balance
-    totalEth := big.NewInt(0)
-    totalEth.Add(totalEth, balances.DepositPool)
-    totalEth.Add(totalEth, balances.MinipoolsTotal)
-    totalEth.Add(totalEth, balances.RETHContract)
-
     // Get transactor
-    opts, err := t.w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(t.c)
+    if err != nil {
+        return err
+    }
+    opts, err = services.GetTaskTransactor(t.c, "submit-network-balances", opts, t.log)
     if err != nil {
         return err
     }
 
     // Submit balances
-    if _, err := network.SubmitBalances(t.rp, balances.Block, totalEth, balances.MinipoolsStaking, balances.RETHSupply, opts); err != nil {
+    if _, err := t.txm.Transact(opts, fmt.Sprintf("Submit network balances for block %d", balances.Block), func(opts *bind.TransactOpts) error {
+        _, err := network.SubmitBalances(t.rp, balances.Block, totalEth, balances.MinipoolsStaking, balances.RETHSupply, opts)
+        return err
+    }); err != nil {
         return err
     }
 
@@ -5,6 +5,7 @@ import (
     "math/big"
     "time"
 
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/ethereum/go-ethereum/crypto"
     "github.com/rocket-pool/rocketpool-go/minipool"
@@ -18,6 +19,8 @@ import (
 
     "github.com/rocket-pool/smartnode/shared/services"
     "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
     "github.com/rocket-pool/smartnode/shared/services/wallet"
     "github.com/rocket-pool/smartnode/shared/utils/eth2"
     "github.com/rocket-pool/smartnode/shared/utils/log"
@@ -32,9 +35,14 @@ var submitWithdrawableMinipoolsInterval, _ = time.ParseDuration("5m")
 type submitWithdrawableMinipools struct {
     c *cli.Context
     log log.ColorLogger
+    sc *submissionConsensus
+    monitor *watchtowerMonitor
     w *wallet.Wallet
+    txm *transactions.Manager
     rp *rocketpool.RocketPool
+    cache *contracts.Cache
     bc beacon.Client
+    mc *minipoolCache
 }
 
 
@@ -48,13 +56,17 @@ type minipoolWithdrawableDetails struct {
 
 
 // Create submit withdrawable minipools task
-func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger) (*submitWithdrawableMinipools, error) {
+func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger, mc *minipoolCache, sc *submissionConsensus, monitor *watchtowerMonitor) (*submitWithdrawableMinipools, error) {
 
     // Get services
     w, err := services.GetWallet(c)
     if err != nil { return nil, err }
+    txm, err := services.GetTransactionManager(c)
+    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return nil, err }
     bc, err := services.GetBeaconClient(c)
     if err != nil { return nil, err }
 
@@ -62,27 +74,19 @@ func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger) (*su
     return &submitWithdrawableMinipools{
         c: c,
         log: logger,
+        sc: sc,
+        monitor: monitor,
         w: w,
+        txm: txm,
         rp: rp,
+        cache: cache,
         bc: bc,
+        mc: mc,
     }, nil
 
 }
 
 
-// Start submit withdrawable minipools task
-func (t *submitWithdrawableMinipools) Start() {
-    go (func() {
-        for {
-            if err := t.run(); err != nil {
-                t.log.Println(err)
-            }
-            time.Sleep(submitWithdrawableMinipoolsInterval)
-        }
-    })()
-}
-
-
 // Submit withdrawable minipools
 func (t *submitWithdrawableMinipools) run() error {
 
@@ -113,7 +117,7 @@ func (t *submitWithdrawableMinipools) run() error {
     })
     wg.Go(func() error {
         var err error
-        submitWithdrawableEnabled, err = settings.GetMinipoolSubmitWithdrawableEnabled(t.rp, nil)
+        submitWithdrawableEnabled, err = t.cache.GetBool("settings.minipoolSubmitWithdrawableEnabled", func() (bool, error) { return settings.GetMinipoolSubmitWithdrawableEnabled(t.rp, nil) })
         return err
     })
 
@@ -144,8 +148,21 @@ func (t *submitWithdrawableMinipools) run() error {
 
     // Submit minipools withdrawable status
     for _, details := range minipools {
-        if err := t.submitWithdrawableMinipool(details); err != nil {
-            t.log.Println(fmt.Errorf("Could not submit minipool %s withdrawable status: %w", details.Address.Hex(), err))
+        if submit, err := t.isSubmissionNeeded(details); err != nil {
+            t.log.Error(fmt.Errorf("Could not check minipool %s withdrawable status consensus: %w", details.Address.Hex(), err))
+            continue
+        } else if !submit {
+            continue
+        }
+        err := t.submitWithdrawableMinipool(details)
+        if transactions.IsRehearsed(err) {
+            continue
+        }
+        if alertErr := t.monitor.recordSubmission(SubmissionMinipoolWithdrawable, fmt.Sprintf("submit minipool %s withdrawable status", details.Address.Hex()), err); alertErr != nil {
+            t.log.Error(alertErr)
+        }
+        if err != nil {
+            t.log.Error(fmt.Errorf("Could not submit minipool %s withdrawable status: %w", details.Address.Hex(), err))
         }
     }
 
@@ -160,15 +177,12 @@ func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(node
 
     // Data
     var wg1 errgroup.Group
-    var addresses []common.Address
     var eth2Config beacon.Eth2Config
     var beaconHead beacon.BeaconHead
 
-    // Get minipool addresses
+    // Update minipool cache
     wg1.Go(func() error {
-        var err error
-        addresses, err = minipool.GetMinipoolAddresses(t.rp, nil)
-        return err
+        return t.mc.update()
     })
 
     // Get eth2 config
@@ -190,6 +204,9 @@ func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(node
         return []minipoolWithdrawableDetails{}, err
     }
 
+    // Get minipools which may be staking
+    addresses := t.mc.getAddresses(types.Staking)
+
     // Data
     var wg2 errgroup.Group
     minipools := make([]minipoolWithdrawableDetails, len(addresses))
@@ -253,7 +270,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
     })
     wg.Go(func() error {
         var err error
-        pubkey, err = minipool.GetMinipoolPubkey(t.rp, minipoolAddress, nil)
+        pubkey, err = t.mc.getPubkey(minipoolAddress)
         return err
     })
 
@@ -263,6 +280,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
     }
 
     // Check minipool status
+    t.mc.setStatus(minipoolAddress, status)
     if status != types.Staking {
         return minipoolWithdrawableDetails{}, nil
     }
@@ -298,11 +316,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
     endBalance := eth.GweiToWei(float64(validator.Balance))
 
     // Check for existing node submission
-    startBalanceBuf := make([]byte, 32)
-    startBalance.FillBytes(startBalanceBuf)
-    endBalanceBuf := make([]byte, 32)
-    endBalance.FillBytes(endBalanceBuf)
-    nodeSubmittedMinipool, err := t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("minipool.withdrawable.submitted.node"), nodeAddress.Bytes(), minipoolAddress.Bytes(), startBalanceBuf, endBalanceBuf))
+    nodeSubmittedMinipool, err := t.rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("minipool.withdrawable.submitted.node"), nodeAddress.Bytes(), minipoolAddress.Bytes(), uint256Bytes(startBalance), uint256Bytes(endBalance)))
     if err != nil {
         return minipoolWithdrawableDetails{}, err
     }
@@ -321,6 +335,35 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 }
 
 
+// Check whether a minipool's withdrawable status needs to be submitted by the node
+// Submissions are skipped while delayed, and once the minipool's withdrawable balances have reached consensus
+func (t *submitWithdrawableMinipools) isSubmissionNeeded(details minipoolWithdrawableDetails) (bool, error) {
+
+    // Check submission delay
+    startBalanceBuf := uint256Bytes(details.StartBalance)
+    endBalanceBuf := uint256Bytes(details.EndBalance)
+    if delayed, remaining := t.sc.isDelayed(crypto.Keccak256Hash([]byte("minipool.withdrawable"), details.Address.Bytes(), startBalanceBuf, endBalanceBuf)); delayed {
+        t.log.Printlnf("Waiting %s for other trusted nodes to submit minipool %s withdrawable status...", remaining.Round(time.Second), details.Address.Hex())
+        return false, nil
+    }
+
+    // Check consensus
+    consensus, err := t.sc.getStatus(crypto.Keccak256Hash([]byte("minipool.withdrawable.submitted.count"), details.Address.Bytes(), startBalanceBuf, endBalanceBuf))
+    if err != nil {
+        return false, err
+    }
+    if consensus.Reached {
+        t.log.Printlnf("Minipool %s withdrawable status has already reached consensus with %d of %d required submissions; skipping redundant submission.", details.Address.Hex(), consensus.Submissions, consensus.Required)
+        t.monitor.recordSkipped(SubmissionMinipoolWithdrawable)
+        return false, nil
+    }
+
+    // Return
+    return true, nil
+
+}
+
+
 // Submit minipool withdrawable status
 func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(details minipoolWithdrawableDetails) error {
 
@@ -328,13 +371,20 @@ func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(details minipoo
     t.log.Printlnf("Submitting minipool %s withdrawable status...", details.Address.Hex())
 
     // Get transactor
-    opts, err := t.w.GetNodeAccountTransactor()
+    opts, err := services.GetNodeAccountTransactor(t.c)
+    if err != nil {
+        return err
+    }
+    opts, err = services.GetTaskTransactor(t.c, "submit-withdrawable-minipools", opts, t.log)
     if err != nil {
         return err
     }
 
     // Dissolve
-    if _, err := minipool.SubmitMinipoolWithdrawable(t.rp, details.Address, details.StartBalance, details.EndBalance, opts); err != nil {
+    if _, err := t.txm.Transact(opts, fmt.Sprintf("Submit minipool %s withdrawable", details.Address.Hex()), func(opts *bind.TransactOpts) error {
+        _, err := minipool.SubmitMinipoolWithdrawable(t.rp, details.Address, details.StartBalance, details.EndBalance, opts)
+        return err
+    }); err != nil {
         return err
     }
 
@@ -5,6 +5,8 @@ import (
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/scheduler"
     "github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -15,6 +17,8 @@ const (
     ProcessWithdrawalsColor = color.FgCyan
     SubmitNetworkBalancesColor = color.FgYellow
     SubmitWithdrawableMinipoolsColor = color.FgBlue
+    MonitorNodeStatusColor = color.FgGreen
+    ConfigReloadColor = color.FgHiWhite
 )
 
 
@@ -34,24 +38,65 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+    // Configure logging
+    if err := services.ConfigureLogging(c, "watchtower"); err != nil { return err }
+
     // Wait until node is registered
     if err := services.WaitNodeRegistered(c, true); err != nil { return err }
 
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return err }
+    cache, err := services.GetContractCache(c)
+    if err != nil { return err }
+    alerter, err := services.GetAlerter(c)
+    if err != nil { return err }
+
+    // Initialize network minipool cache, submission consensus tracker & monitor
+    mc := newMinipoolCache(rp)
+    submissionDelay, err := cfg.GetWatchtowerSubmissionDelay()
+    if err != nil { return err }
+    sc := newSubmissionConsensus(rp, cache, submissionDelay)
+    monitor := newWatchtowerMonitor(alerter)
+    if pool, err := services.GetEthRPCPool(c); err == nil {
+        pool.SetMetrics(monitor.metrics)
+    }
+
     // Initialize tasks
-    dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+    dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger("dissolve-timed-out-minipools", DissolveTimedOutMinipoolsColor), mc)
+    if err != nil { return err }
+    processWithdrawals, err := newProcessWithdrawals(c, log.NewColorLogger("process-withdrawals", ProcessWithdrawalsColor), mc)
     if err != nil { return err }
-    processWithdrawals, err := newProcessWithdrawals(c, log.NewColorLogger(ProcessWithdrawalsColor))
+    submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger("submit-network-balances", SubmitNetworkBalancesColor), sc, monitor)
     if err != nil { return err }
-    submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor))
+    submitWithdrawableMinipools, err := newSubmitWithdrawableMinipools(c, log.NewColorLogger("submit-withdrawable-minipools", SubmitWithdrawableMinipoolsColor), mc, sc, monitor)
     if err != nil { return err }
-    submitWithdrawableMinipools, err := newSubmitWithdrawableMinipools(c, log.NewColorLogger(SubmitWithdrawableMinipoolsColor))
+    monitorNodeStatus, err := newMonitorNodeStatus(c, log.NewColorLogger("monitor-node-status", MonitorNodeStatusColor), monitor)
     if err != nil { return err }
 
-    // Start tasks
-    dissolveTimedOutMinipools.Start()
-    processWithdrawals.Start()
-    submitNetworkBalances.Start()
-    submitWithdrawableMinipools.Start()
+    // Register & start tasks
+    sched := scheduler.New(cfg, "watchtower")
+    sched.SetRunHook(monitor.recordTaskRun)
+    if err := sched.Register("dissolve-timed-out-minipools", dissolveTimedOutMinipoolsInterval, dissolveTimedOutMinipools.run, dissolveTimedOutMinipools.log); err != nil { return err }
+    if err := sched.Register("process-withdrawals", processWithdrawalsInterval, processWithdrawals.run, processWithdrawals.log); err != nil { return err }
+    if err := sched.RegisterAdaptive("submit-network-balances", submitNetworkBalancesActiveInterval, submitNetworkBalancesIdleInterval, submitNetworkBalances.run, submitNetworkBalances.isActive, submitNetworkBalances.log); err != nil { return err }
+    if err := sched.Register("submit-withdrawable-minipools", submitWithdrawableMinipoolsInterval, submitWithdrawableMinipools.run, submitWithdrawableMinipools.log); err != nil { return err }
+    if err := sched.Register("monitor-node-status", monitorNodeStatusInterval, monitorNodeStatus.run, monitorNodeStatus.log); err != nil { return err }
+    if err := sched.Start(); err != nil { return err }
+
+    // Reload task & submission settings when the user settings change
+    if err := services.WatchConfig(c, "watchtower", log.NewColorLogger("config-reload", ConfigReloadColor), func(cfg config.RocketPoolConfig) error {
+        submissionDelay, err := cfg.GetWatchtowerSubmissionDelay()
+        if err != nil { return err }
+        if err := sched.Reload(cfg); err != nil { return err }
+        sc.setDelay(submissionDelay)
+        return nil
+    }); err != nil { return err }
+
+    // Serve metrics
+    monitor.serve(cfg.Watchtower.MetricsAddress, monitorNodeStatus.log)
 
     // Block thread
     select {}
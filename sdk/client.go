@@ -0,0 +1,72 @@
+// Package sdk provides a Go client for automating a Rocket Pool smart node.
+// It wraps the smart node API with typed methods which accept a context for cancellation and timeouts:
+//     client, err := sdk.NewClient(sdk.Options{})
+//     status, err := client.GetNodeStatus(ctx)
+package sdk
+
+import (
+    "context"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Client options
+// If Host is empty, the client communicates with a smart node running on the local machine
+// MaxFee & PriorityFee (in gwei) override the smart node's transaction gas settings if set
+// If Offline is set, state-changing methods return an *api.OfflineTransactionError holding the unsigned transaction instead of sending it,
+// and if DryRun is set, they return an *api.DryRunError holding its simulation
+type Options struct {
+    Host string
+    User string
+    KeyPath string
+    MaxFee float64
+    PriorityFee float64
+    Offline bool
+    DryRun bool
+}
+
+
+// Smart node client
+type Client struct {
+    rp *rocketpool.Client
+}
+
+
+// Create new smart node client
+func NewClient(options Options) (*Client, error) {
+    rp, err := rocketpool.NewClient(options.Host, options.User, options.KeyPath)
+    if err != nil {
+        return nil, err
+    }
+    rp.SetGasSettings(options.MaxFee, options.PriorityFee)
+    rp.SetOffline(options.Offline)
+    rp.SetDryRun(options.DryRun)
+    return &Client{rp: rp}, nil
+}
+
+
+// Close client remote connection
+func (c *Client) Close() {
+    c.rp.Close()
+}
+
+
+// Call an API method in a context; the request is stopped if the context is cancelled or expires
+// Methods which send transactions may have already sent them when the request is stopped, so a cancelled or expired
+// context does not mean that a transaction was not sent; the method's result is returned if it completed regardless
+func (c *Client) call(ctx context.Context, method func(rp *rocketpool.Client) (interface{}, error)) (interface{}, error) {
+
+    // Check context
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+
+    // Call method
+    response, err := method(c.rp.WithContext(ctx))
+    if err != nil && ctx.Err() != nil {
+        return nil, ctx.Err()
+    }
+    return response, err
+
+}
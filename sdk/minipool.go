@@ -0,0 +1,106 @@
+package sdk
+
+import (
+    "context"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get minipool status
+func (c *Client) GetMinipoolStatus(ctx context.Context) (api.MinipoolStatusResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.MinipoolStatus() })
+    if err != nil { return api.MinipoolStatusResponse{}, err }
+    return response.(api.MinipoolStatusResponse), nil
+}
+
+
+// Get the recorded performance of the node's minipool validators
+func (c *Client) GetMinipoolPerformance(ctx context.Context) (api.MinipoolPerformanceResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.MinipoolPerformance() })
+    if err != nil { return api.MinipoolPerformanceResponse{}, err }
+    return response.(api.MinipoolPerformanceResponse), nil
+}
+
+
+// Check whether a minipool is eligible for a refund
+func (c *Client) CanRefundMinipool(ctx context.Context, address common.Address) (api.CanRefundMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanRefundMinipool(address) })
+    if err != nil { return api.CanRefundMinipoolResponse{}, err }
+    return response.(api.CanRefundMinipoolResponse), nil
+}
+
+
+// Refund ETH from a minipool
+func (c *Client) RefundMinipool(ctx context.Context, address common.Address) (api.RefundMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.RefundMinipool(address) })
+    if err != nil { return api.RefundMinipoolResponse{}, err }
+    return response.(api.RefundMinipoolResponse), nil
+}
+
+
+// Check whether a minipool can be dissolved
+func (c *Client) CanDissolveMinipool(ctx context.Context, address common.Address) (api.CanDissolveMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanDissolveMinipool(address) })
+    if err != nil { return api.CanDissolveMinipoolResponse{}, err }
+    return response.(api.CanDissolveMinipoolResponse), nil
+}
+
+
+// Dissolve a minipool
+func (c *Client) DissolveMinipool(ctx context.Context, address common.Address) (api.DissolveMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.DissolveMinipool(address) })
+    if err != nil { return api.DissolveMinipoolResponse{}, err }
+    return response.(api.DissolveMinipoolResponse), nil
+}
+
+
+// Check whether a minipool can be exited
+func (c *Client) CanExitMinipool(ctx context.Context, address common.Address) (api.CanExitMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanExitMinipool(address) })
+    if err != nil { return api.CanExitMinipoolResponse{}, err }
+    return response.(api.CanExitMinipoolResponse), nil
+}
+
+
+// Exit a minipool
+func (c *Client) ExitMinipool(ctx context.Context, address common.Address) (api.ExitMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ExitMinipool(address) })
+    if err != nil { return api.ExitMinipoolResponse{}, err }
+    return response.(api.ExitMinipoolResponse), nil
+}
+
+
+// Check whether a minipool can be withdrawn
+func (c *Client) CanWithdrawMinipool(ctx context.Context, address common.Address) (api.CanWithdrawMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanWithdrawMinipool(address) })
+    if err != nil { return api.CanWithdrawMinipoolResponse{}, err }
+    return response.(api.CanWithdrawMinipoolResponse), nil
+}
+
+
+// Withdraw a minipool
+func (c *Client) WithdrawMinipool(ctx context.Context, address common.Address) (api.WithdrawMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.WithdrawMinipool(address) })
+    if err != nil { return api.WithdrawMinipoolResponse{}, err }
+    return response.(api.WithdrawMinipoolResponse), nil
+}
+
+
+// Check whether a minipool can be closed
+func (c *Client) CanCloseMinipool(ctx context.Context, address common.Address) (api.CanCloseMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanCloseMinipool(address) })
+    if err != nil { return api.CanCloseMinipoolResponse{}, err }
+    return response.(api.CanCloseMinipoolResponse), nil
+}
+
+
+// Close a minipool
+func (c *Client) CloseMinipool(ctx context.Context, address common.Address) (api.CloseMinipoolResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CloseMinipool(address) })
+    if err != nil { return api.CloseMinipoolResponse{}, err }
+    return response.(api.CloseMinipoolResponse), nil
+}
@@ -0,0 +1,40 @@
+package sdk
+
+import (
+    "context"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get network node fee
+func (c *Client) GetNodeFee(ctx context.Context) (api.NodeFeeResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.NodeFee() })
+    if err != nil { return api.NodeFeeResponse{}, err }
+    return response.(api.NodeFeeResponse), nil
+}
+
+
+// Get queue status
+func (c *Client) GetQueueStatus(ctx context.Context) (api.QueueStatusResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.QueueStatus() })
+    if err != nil { return api.QueueStatusResponse{}, err }
+    return response.(api.QueueStatusResponse), nil
+}
+
+
+// Check whether the queue can be processed
+func (c *Client) CanProcessQueue(ctx context.Context) (api.CanProcessQueueResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanProcessQueue() })
+    if err != nil { return api.CanProcessQueueResponse{}, err }
+    return response.(api.CanProcessQueueResponse), nil
+}
+
+
+// Process the queue
+func (c *Client) ProcessQueue(ctx context.Context) (api.ProcessQueueResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ProcessQueue() })
+    if err != nil { return api.ProcessQueueResponse{}, err }
+    return response.(api.ProcessQueueResponse), nil
+}
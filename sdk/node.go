@@ -0,0 +1,91 @@
+package sdk
+
+import (
+    "context"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get node status
+func (c *Client) GetNodeStatus(ctx context.Context) (api.NodeStatusResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.NodeStatus() })
+    if err != nil { return api.NodeStatusResponse{}, err }
+    return response.(api.NodeStatusResponse), nil
+}
+
+
+// Check whether the node can be registered
+func (c *Client) CanRegisterNode(ctx context.Context) (api.CanRegisterNodeResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanRegisterNode() })
+    if err != nil { return api.CanRegisterNodeResponse{}, err }
+    return response.(api.CanRegisterNodeResponse), nil
+}
+
+
+// Register the node
+func (c *Client) RegisterNode(ctx context.Context, timezoneLocation string) (api.RegisterNodeResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.RegisterNode(timezoneLocation) })
+    if err != nil { return api.RegisterNodeResponse{}, err }
+    return response.(api.RegisterNodeResponse), nil
+}
+
+
+// Set the node's timezone location
+func (c *Client) SetNodeTimezone(ctx context.Context, timezoneLocation string) (api.SetNodeTimezoneResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.SetNodeTimezone(timezoneLocation) })
+    if err != nil { return api.SetNodeTimezoneResponse{}, err }
+    return response.(api.SetNodeTimezoneResponse), nil
+}
+
+
+// Check whether the node can make a deposit
+func (c *Client) CanNodeDeposit(ctx context.Context, amountWei *big.Int) (api.CanNodeDepositResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanNodeDeposit(amountWei) })
+    if err != nil { return api.CanNodeDepositResponse{}, err }
+    return response.(api.CanNodeDepositResponse), nil
+}
+
+
+// Make a node deposit
+func (c *Client) NodeDeposit(ctx context.Context, amountWei *big.Int, minNodeFee float64) (api.NodeDepositResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.NodeDeposit(amountWei, minNodeFee) })
+    if err != nil { return api.NodeDepositResponse{}, err }
+    return response.(api.NodeDepositResponse), nil
+}
+
+
+// Check whether the node can send tokens
+func (c *Client) CanNodeSend(ctx context.Context, amountWei *big.Int, token string, toAddress common.Address) (api.CanNodeSendResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanNodeSend(amountWei, token, toAddress) })
+    if err != nil { return api.CanNodeSendResponse{}, err }
+    return response.(api.CanNodeSendResponse), nil
+}
+
+
+// Send tokens from the node to an address
+func (c *Client) NodeSend(ctx context.Context, amountWei *big.Int, token string, toAddress common.Address) (api.NodeSendResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.NodeSend(amountWei, token, toAddress) })
+    if err != nil { return api.NodeSendResponse{}, err }
+    return response.(api.NodeSendResponse), nil
+}
+
+
+// Check whether the node can burn tokens
+func (c *Client) CanNodeBurn(ctx context.Context, amountWei *big.Int, token string) (api.CanNodeBurnResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.CanNodeBurn(amountWei, token) })
+    if err != nil { return api.CanNodeBurnResponse{}, err }
+    return response.(api.CanNodeBurnResponse), nil
+}
+
+
+// Burn tokens owned by the node for ETH
+func (c *Client) NodeBurn(ctx context.Context, amountWei *big.Int, token string) (api.NodeBurnResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.NodeBurn(amountWei, token) })
+    if err != nil { return api.NodeBurnResponse{}, err }
+    return response.(api.NodeBurnResponse), nil
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+    "context"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get client peers
+// If lookupLocations is set, peer addresses are sent to a third-party geolocation service to get peer countries
+func (c *Client) GetServicePeers(ctx context.Context, lookupLocations bool) (api.ServicePeersResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ServicePeers(lookupLocations) })
+    if err != nil { return api.ServicePeersResponse{}, err }
+    return response.(api.ServicePeersResponse), nil
+}
+
+
+// Get client sync progress
+func (c *Client) GetServiceSync(ctx context.Context) (api.ServiceSyncResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ServiceSync() })
+    if err != nil { return api.ServiceSyncResponse{}, err }
+    return response.(api.ServiceSyncResponse), nil
+}
+
+
+// Check the Eth 1.0 and Eth 2.0 client p2p networking
+func (c *Client) GetServiceNetDiag(ctx context.Context) (api.ServiceNetDiagResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ServiceNetDiag() })
+    if err != nil { return api.ServiceNetDiagResponse{}, err }
+    return response.(api.ServiceNetDiagResponse), nil
+}
+
+
+// Get client connection & sync status
+func (c *Client) GetClientStatus(ctx context.Context) (api.ServiceClientStatusResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ServiceClientStatus() })
+    if err != nil { return api.ServiceClientStatusResponse{}, err }
+    return response.(api.ServiceClientStatusResponse), nil
+}
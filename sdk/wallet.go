@@ -0,0 +1,80 @@
+package sdk
+
+import (
+    "context"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get wallet status
+func (c *Client) GetWalletStatus(ctx context.Context) (api.WalletStatusResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.WalletStatus() })
+    if err != nil { return api.WalletStatusResponse{}, err }
+    return response.(api.WalletStatusResponse), nil
+}
+
+
+// Set wallet password
+func (c *Client) SetPassword(ctx context.Context, password string) (api.SetPasswordResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.SetPassword(password) })
+    if err != nil { return api.SetPasswordResponse{}, err }
+    return response.(api.SetPasswordResponse), nil
+}
+
+
+// Initialize wallet
+func (c *Client) InitWallet(ctx context.Context) (api.InitWalletResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.InitWallet() })
+    if err != nil { return api.InitWalletResponse{}, err }
+    return response.(api.InitWalletResponse), nil
+}
+
+
+// Recover wallet
+func (c *Client) RecoverWallet(ctx context.Context, mnemonic string) (api.RecoverWalletResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.RecoverWallet(mnemonic) })
+    if err != nil { return api.RecoverWalletResponse{}, err }
+    return response.(api.RecoverWalletResponse), nil
+}
+
+
+// Export wallet
+func (c *Client) ExportWallet(ctx context.Context) (api.ExportWalletResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ExportWallet() })
+    if err != nil { return api.ExportWalletResponse{}, err }
+    return response.(api.ExportWalletResponse), nil
+}
+
+
+// Export validator keys as EIP-2335 keystores
+func (c *Client) ExportValidatorKeys(ctx context.Context, password string) (api.ExportValidatorKeysResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ExportValidatorKeys(password) })
+    if err != nil { return api.ExportValidatorKeysResponse{}, err }
+    return response.(api.ExportValidatorKeysResponse), nil
+}
+
+
+// Import a validator key from an EIP-2335 keystore
+func (c *Client) ImportValidatorKey(ctx context.Context, keystore []byte, password string) (api.ImportValidatorKeyResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.ImportValidatorKey(keystore, password) })
+    if err != nil { return api.ImportValidatorKeyResponse{}, err }
+    return response.(api.ImportValidatorKeyResponse), nil
+}
+
+
+// Sign a transaction prepared for offline signing with the node account
+func (c *Client) SignTransaction(ctx context.Context, transaction api.UnsignedTransaction) (api.SignTransactionResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.SignTransaction(transaction) })
+    if err != nil { return api.SignTransactionResponse{}, err }
+    return response.(api.SignTransactionResponse), nil
+}
+
+
+// Broadcast a transaction signed offline and wait for it to be mined
+func (c *Client) BroadcastTransaction(ctx context.Context, transaction api.SignedTransaction) (api.BroadcastTransactionResponse, error) {
+    response, err := c.call(ctx, func(rp *rocketpool.Client) (interface{}, error) { return rp.BroadcastTransaction(transaction) })
+    if err != nil { return api.BroadcastTransactionResponse{}, err }
+    return response.(api.BroadcastTransactionResponse), nil
+}
@@ -0,0 +1,245 @@
+package actions
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/tokens"
+    "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Settings
+var LowNodeBalance = eth.EthToWei(0.1)
+
+
+// Node state which action items are derived from
+type State struct {
+    Registered bool
+    Balances tokens.Balances
+    Minipools []MinipoolState
+    Eth1Synced bool
+    Eth2Synced bool
+    AutoWithdrawEnabled bool
+    SecurityReviewed bool
+    SecurityWarnings int
+    DiskDaysUntilFull float64
+    DiskFullDaysAlert float64
+}
+type MinipoolState struct {
+    Address common.Address
+    Status types.MinipoolStatus
+    RefundAvailable bool
+    WithdrawalAvailable bool
+    CloseAvailable bool
+    BelowMinClaimGasRatio bool
+}
+
+
+// An action rule, which derives action items from the node state
+type Rule struct {
+    Name string
+    Get func(state *State) []api.NodeActionItem
+}
+
+
+// Registered rules
+var rules = []Rule{
+    Rule{Name: "sync-clients",       Get: syncClients},
+    Rule{Name: "register-node",      Get: registerNode},
+    Rule{Name: "top-up-node",        Get: topUpNode},
+    Rule{Name: "withdraw-minipools", Get: withdrawMinipools},
+    Rule{Name: "refund-minipools",   Get: refundMinipools},
+    Rule{Name: "close-minipools",    Get: closeMinipools},
+    Rule{Name: "security-review",    Get: securityReview},
+    Rule{Name: "free-disk-space",    Get: freeDiskSpace},
+}
+var rulesLock sync.RWMutex
+
+
+// Register an action rule
+// Rules are evaluated in registration order, and their action items sorted by priority
+func RegisterRule(rule Rule) error {
+    rulesLock.Lock()
+    defer rulesLock.Unlock()
+    for _, r := range rules {
+        if r.Name == rule.Name {
+            return fmt.Errorf("Action rule %s is already registered", rule.Name)
+        }
+    }
+    rules = append(rules, rule)
+    return nil
+}
+
+
+// Get the prioritized action items for a node state
+func Get(state *State) []api.NodeActionItem {
+    rulesLock.RLock()
+    defer rulesLock.RUnlock()
+    items := []api.NodeActionItem{}
+    for _, rule := range rules {
+        for _, item := range rule.Get(state) {
+            item.Rule = rule.Name
+            items = append(items, item)
+        }
+    }
+    sort.SliceStable(items, func(i, j int) bool { return items[i].Priority < items[j].Priority })
+    return items
+}
+
+
+// Clients must be synced for the node to perform its duties
+func syncClients(state *State) []api.NodeActionItem {
+    items := []api.NodeActionItem{}
+    if !state.Eth1Synced {
+        items = append(items, api.NodeActionItem{
+            Priority: api.ActionPriorityUrgent,
+            Title: "The Eth 1.0 client is not synced",
+            Detail: "The node cannot stake minipools or send transactions until its Eth 1.0 client is synced.",
+            Command: "rocketpool doctor",
+        })
+    }
+    if !state.Eth2Synced {
+        items = append(items, api.NodeActionItem{
+            Priority: api.ActionPriorityUrgent,
+            Title: "The Eth 2.0 client is not synced",
+            Detail: "The node's validators cannot attest or propose blocks until its Eth 2.0 client is synced.",
+            Command: "rocketpool doctor",
+        })
+    }
+    return items
+}
+
+
+// The node must be registered to create minipools
+func registerNode(state *State) []api.NodeActionItem {
+    if state.Registered {
+        return nil
+    }
+    return []api.NodeActionItem{api.NodeActionItem{
+        Priority: api.ActionPriorityHigh,
+        Title: "Register the node",
+        Detail: "The node must be registered with Rocket Pool before it can make deposits and create minipools.",
+        Command: "rocketpool node register",
+    }}
+}
+
+
+// The node account needs ETH to pay for minipool transactions
+func topUpNode(state *State) []api.NodeActionItem {
+    if !state.Registered || len(state.Minipools) == 0 || state.Balances.ETH == nil || state.Balances.ETH.Cmp(LowNodeBalance) >= 0 {
+        return nil
+    }
+    return []api.NodeActionItem{api.NodeActionItem{
+        Priority: api.ActionPriorityHigh,
+        Title: "Top up the node account's ETH balance",
+        Detail: fmt.Sprintf("The node account has %s, below %s; it may not be able to pay for staking, withdrawal and refund transactions.", units.FormatEth(state.Balances.ETH), units.FormatEth(LowNodeBalance)),
+    }}
+}
+
+
+// Withdrawable minipools should be withdrawn once their rewards are worth the gas cost, unless they are withdrawn automatically
+func withdrawMinipools(state *State) []api.NodeActionItem {
+    items := []api.NodeActionItem{}
+    for _, mp := range state.Minipools {
+        if !mp.WithdrawalAvailable {
+            continue
+        }
+        switch {
+            case mp.BelowMinClaimGasRatio:
+                items = append(items, api.NodeActionItem{
+                    Priority: api.ActionPriorityLow,
+                    Title: fmt.Sprintf("Withdraw minipool %s when gas prices fall", mp.Address.Hex()),
+                    Detail: "The minipool's rewards are not yet worth the gas cost of withdrawing them at the minimum claim gas ratio.",
+                    Command: "rocketpool minipool withdraw",
+                })
+            case state.AutoWithdrawEnabled:
+                continue
+            default:
+                items = append(items, api.NodeActionItem{
+                    Priority: api.ActionPriorityHigh,
+                    Title: fmt.Sprintf("Withdraw minipool %s", mp.Address.Hex()),
+                    Detail: "The minipool is withdrawable and its rewards are worth more than the gas cost of claiming them.",
+                    Command: "rocketpool minipool withdraw",
+                })
+        }
+    }
+    return items
+}
+
+
+// Minipool refunds should be claimed
+func refundMinipools(state *State) []api.NodeActionItem {
+    items := []api.NodeActionItem{}
+    for _, mp := range state.Minipools {
+        if mp.RefundAvailable {
+            items = append(items, api.NodeActionItem{
+                Priority: api.ActionPriorityNormal,
+                Title: fmt.Sprintf("Refund minipool %s", mp.Address.Hex()),
+                Detail: "The minipool has a node deposit refund available.",
+                Command: "rocketpool minipool refund",
+            })
+        }
+    }
+    return items
+}
+
+
+// Dissolved minipools should be closed to return their node deposits
+func closeMinipools(state *State) []api.NodeActionItem {
+    items := []api.NodeActionItem{}
+    for _, mp := range state.Minipools {
+        if mp.CloseAvailable {
+            items = append(items, api.NodeActionItem{
+                Priority: api.ActionPriorityNormal,
+                Title: fmt.Sprintf("Close dissolved minipool %s", mp.Address.Hex()),
+                Detail: "Closing the minipool returns its node deposit to the node account.",
+                Command: "rocketpool minipool close",
+            })
+        }
+    }
+    return items
+}
+
+
+// The node host's security settings should be reviewed once, and any warnings resolved
+func securityReview(state *State) []api.NodeActionItem {
+    switch {
+        case !state.SecurityReviewed:
+            return []api.NodeActionItem{api.NodeActionItem{
+                Priority: api.ActionPriorityLow,
+                Title: "Complete the security review",
+                Detail: "Review the node host's SSH, firewall & automatic update settings and confirm that the wallet is backed up.",
+                Command: "rocketpool service security-review",
+            }}
+        case state.SecurityWarnings > 0:
+            return []api.NodeActionItem{api.NodeActionItem{
+                Priority: api.ActionPriorityLow,
+                Title: fmt.Sprintf("Resolve %d security review warning(s)", state.SecurityWarnings),
+                Detail: "The security review found host settings or backups which should be fixed; run the review again once they are.",
+                Command: "rocketpool service security-review",
+            }}
+    }
+    return nil
+}
+
+
+// Disk space should be freed before the disk is predicted to be full; days until full is negative if unknown
+func freeDiskSpace(state *State) []api.NodeActionItem {
+    if state.DiskDaysUntilFull < 0 || state.DiskDaysUntilFull >= state.DiskFullDaysAlert {
+        return nil
+    }
+    return []api.NodeActionItem{api.NodeActionItem{
+        Priority: api.ActionPriorityHigh,
+        Title: "Free up disk space",
+        Detail: fmt.Sprintf("The node's disk is predicted to be full in about %.0f days at its current growth rate; the clients will stop when it is.", state.DiskDaysUntilFull),
+        Command: "rocketpool service prune-eth1",
+    }}
+}
+
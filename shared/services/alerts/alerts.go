@@ -0,0 +1,196 @@
+package alerts
+
+import (
+    "bufio"
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+)
+
+
+// Config
+const (
+    AlertLogFile = "alerts.log"
+    DirMode = 0700
+    FileMode = 0600
+)
+var webhookTimeout, _ = time.ParseDuration("10s")
+
+
+// Alert
+type Alert struct {
+    Time time.Time      `json:"time"`
+    Title string        `json:"title"`
+    Message string      `json:"message"`
+}
+
+
+// Webhook payload; text & content fields are included for Slack & Discord compatibility
+type webhookPayload struct {
+    Time time.Time      `json:"time"`
+    Title string        `json:"title"`
+    Message string      `json:"message"`
+    Text string         `json:"text"`
+    Content string      `json:"content"`
+}
+
+
+// Alerter, records alerts to the alert log and posts them to a webhook if configured
+type Alerter struct {
+    dataPath string
+    webhookURL string
+    client *http.Client
+    lock sync.Mutex
+}
+
+
+// Create new alerter
+func NewAlerter(dataPath, webhookURL string) *Alerter {
+    return &Alerter{
+        dataPath: dataPath,
+        webhookURL: webhookURL,
+        client: &http.Client{Timeout: webhookTimeout},
+    }
+}
+
+
+// Set the webhook alerts are posted to
+func (a *Alerter) SetWebhookURL(webhookURL string) {
+    a.lock.Lock()
+    defer a.lock.Unlock()
+    a.webhookURL = webhookURL
+}
+
+
+// Send an alert
+func (a *Alerter) Send(title, message string) error {
+
+    // Create alert
+    alert := Alert{
+        Time: time.Now(),
+        Title: title,
+        Message: message,
+    }
+
+    // Record alert
+    if err := a.record(alert); err != nil {
+        return err
+    }
+
+    // Post alert to webhook
+    a.lock.Lock()
+    webhookURL := a.webhookURL
+    a.lock.Unlock()
+    if webhookURL != "" {
+        if err := a.post(webhookURL, alert); err != nil {
+            return err
+        }
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Append an alert to the alert log
+func (a *Alerter) record(alert Alert) error {
+
+    // Lock alert log
+    a.lock.Lock()
+    defer a.lock.Unlock()
+
+    // Encode alert
+    alertBytes, err := json.Marshal(alert)
+    if err != nil {
+        return fmt.Errorf("Could not encode alert: %w", err)
+    }
+
+    // Append alert to log
+    path := filepath.Join(a.dataPath, AlertLogFile)
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create alert log folder: %w", err)
+    }
+    file, err := os.OpenFile(path, os.O_APPEND | os.O_CREATE | os.O_WRONLY, FileMode)
+    if err != nil {
+        return fmt.Errorf("Could not open alert log: %w", err)
+    }
+    defer file.Close()
+    if _, err := file.Write(append(alertBytes, '\n')); err != nil {
+        return fmt.Errorf("Could not write alert to log: %w", err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Post an alert to the webhook
+func (a *Alerter) post(webhookURL string, alert Alert) error {
+
+    // Encode payload
+    text := fmt.Sprintf("%s: %s", alert.Title, alert.Message)
+    payloadBytes, err := json.Marshal(webhookPayload{
+        Time: alert.Time,
+        Title: alert.Title,
+        Message: alert.Message,
+        Text: text,
+        Content: text,
+    })
+    if err != nil {
+        return fmt.Errorf("Could not encode alert webhook payload: %w", err)
+    }
+
+    // Post payload
+    response, err := a.client.Post(webhookURL, "application/json", bytes.NewReader(payloadBytes))
+    if err != nil {
+        return fmt.Errorf("Could not post alert to webhook: %w", err)
+    }
+    defer response.Body.Close()
+    if response.StatusCode < 200 || response.StatusCode >= 300 {
+        return fmt.Errorf("Could not post alert to webhook: received status %s", response.Status)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get recorded alerts, oldest first
+func GetAlerts(dataPath string) ([]Alert, error) {
+
+    // Open alert log; no alerts if not found
+    file, err := os.Open(filepath.Join(dataPath, AlertLogFile))
+    if os.IsNotExist(err) {
+        return []Alert{}, nil
+    }
+    if err != nil {
+        return []Alert{}, fmt.Errorf("Could not open alert log: %w", err)
+    }
+    defer file.Close()
+
+    // Decode alerts
+    alerts := []Alert{}
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        var alert Alert
+        if err := json.Unmarshal(scanner.Bytes(), &alert); err != nil {
+            continue
+        }
+        alerts = append(alerts, alert)
+    }
+    if err := scanner.Err(); err != nil {
+        return []Alert{}, fmt.Errorf("Could not read alert log: %w", err)
+    }
+
+    // Return
+    return alerts, nil
+
+}
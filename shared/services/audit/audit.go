@@ -0,0 +1,102 @@
+package audit
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+)
+
+
+// Config
+const (
+    AuditLogFile = "audit.log"
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Audit log lock
+var lock sync.Mutex
+
+
+// Audit log entry
+type Entry struct {
+    Time time.Time      `json:"time"`
+    Actor string        `json:"actor"`
+    Action string       `json:"action"`
+    Allowed bool        `json:"allowed"`
+    Detail string       `json:"detail,omitempty"`
+}
+
+
+// Append an entry to the audit log
+func Record(dataPath string, entry Entry) error {
+
+    // Lock audit log
+    lock.Lock()
+    defer lock.Unlock()
+
+    // Encode entry
+    if entry.Time.IsZero() {
+        entry.Time = time.Now()
+    }
+    entryBytes, err := json.Marshal(entry)
+    if err != nil {
+        return fmt.Errorf("Could not encode audit log entry: %w", err)
+    }
+
+    // Append entry to log
+    path := filepath.Join(dataPath, AuditLogFile)
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create audit log folder: %w", err)
+    }
+    file, err := os.OpenFile(path, os.O_APPEND | os.O_CREATE | os.O_WRONLY, FileMode)
+    if err != nil {
+        return fmt.Errorf("Could not open audit log: %w", err)
+    }
+    defer file.Close()
+    if _, err := file.Write(append(entryBytes, '\n')); err != nil {
+        return fmt.Errorf("Could not write to audit log: %w", err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get audit log entries, oldest first
+func GetEntries(dataPath string) ([]Entry, error) {
+
+    // Open audit log; no entries if not found
+    file, err := os.Open(filepath.Join(dataPath, AuditLogFile))
+    if os.IsNotExist(err) {
+        return []Entry{}, nil
+    }
+    if err != nil {
+        return []Entry{}, fmt.Errorf("Could not open audit log: %w", err)
+    }
+    defer file.Close()
+
+    // Decode entries
+    entries := []Entry{}
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        var entry Entry
+        if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+            continue
+        }
+        entries = append(entries, entry)
+    }
+    if err := scanner.Err(); err != nil {
+        return []Entry{}, fmt.Errorf("Could not read audit log: %w", err)
+    }
+
+    // Return
+    return entries, nil
+
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+    "fmt"
+    "io/ioutil"
+    "path/filepath"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services/backup"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Get the configured backup destination
+func GetBackupDestination(c config.Context) (backup.Destination, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    return backup.NewDestination(cfg.Backup)
+}
+
+
+// Get the backup encryption passphrase; defaults to the node password if no passphrase file is configured
+func GetBackupPassphrase(c config.Context) (string, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return "", err
+    }
+    if cfg.Backup.PassphrasePath != "" {
+        passphrase, err := ioutil.ReadFile(cfg.Backup.PassphrasePath)
+        if err != nil {
+            return "", fmt.Errorf("Could not read backup passphrase: %w", err)
+        }
+        return strings.TrimSpace(string(passphrase)), nil
+    }
+    pm, err := getPasswordManager(cfg)
+    if err != nil {
+        return "", err
+    }
+    return pm.GetPassword()
+}
+
+
+// Get the folder backups are created from and restored to
+func GetBackupRootPath(c config.Context) string {
+    return filepath.Dir(c.GlobalString("settings"))
+}
@@ -0,0 +1,154 @@
+package backup
+
+import (
+    "archive/tar"
+    "bytes"
+    "compress/gzip"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+
+// Create a gzipped tar archive of a folder
+func CreateArchive(rootPath string, excludes []string) ([]byte, error) {
+
+    // Initialize writers
+    var buffer bytes.Buffer
+    gzipWriter := gzip.NewWriter(&buffer)
+    tarWriter := tar.NewWriter(gzipWriter)
+
+    // Add files
+    if err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+
+        // Get relative path
+        relPath, err := filepath.Rel(rootPath, path)
+        if err != nil {
+            return err
+        }
+        if relPath == "." {
+            return nil
+        }
+
+        // Skip excluded files & folders
+        for _, exclude := range excludes {
+            if info.Name() == exclude || relPath == exclude {
+                if info.IsDir() {
+                    return filepath.SkipDir
+                }
+                return nil
+            }
+        }
+
+        // Skip non-regular files
+        if !info.IsDir() && !info.Mode().IsRegular() {
+            return nil
+        }
+
+        // Write header
+        header, err := tar.FileInfoHeader(info, "")
+        if err != nil {
+            return err
+        }
+        header.Name = filepath.ToSlash(relPath)
+        if err := tarWriter.WriteHeader(header); err != nil {
+            return err
+        }
+
+        // Write file contents
+        if info.IsDir() {
+            return nil
+        }
+        file, err := os.Open(path)
+        if err != nil {
+            return err
+        }
+        defer file.Close()
+        _, err = io.Copy(tarWriter, file)
+        return err
+
+    }); err != nil {
+        return []byte{}, fmt.Errorf("Could not archive %s: %w", rootPath, err)
+    }
+
+    // Close writers
+    if err := tarWriter.Close(); err != nil {
+        return []byte{}, fmt.Errorf("Could not archive %s: %w", rootPath, err)
+    }
+    if err := gzipWriter.Close(); err != nil {
+        return []byte{}, fmt.Errorf("Could not archive %s: %w", rootPath, err)
+    }
+
+    // Return
+    return buffer.Bytes(), nil
+
+}
+
+
+// Extract a gzipped tar archive to a folder
+func ExtractArchive(archive []byte, rootPath string) error {
+
+    // Initialize readers
+    gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+    if err != nil {
+        return fmt.Errorf("Could not read backup archive: %w", err)
+    }
+    defer gzipReader.Close()
+    tarReader := tar.NewReader(gzipReader)
+
+    // Extract files
+    for {
+        header, err := tarReader.Next()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return fmt.Errorf("Could not read backup archive: %w", err)
+        }
+
+        // Get & check target path
+        path := filepath.Join(rootPath, filepath.FromSlash(header.Name))
+        if !strings.HasPrefix(path, filepath.Clean(rootPath) + string(os.PathSeparator)) {
+            return fmt.Errorf("Invalid path %s in backup archive", header.Name)
+        }
+
+        // Extract folder or file
+        switch header.Typeflag {
+            case tar.TypeDir:
+                if err := os.MkdirAll(path, os.FileMode(header.Mode)); err != nil {
+                    return fmt.Errorf("Could not create folder %s: %w", path, err)
+                }
+            case tar.TypeReg:
+                if err := extractFile(tarReader, path, os.FileMode(header.Mode)); err != nil {
+                    return err
+                }
+        }
+
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Extract a file from a tar archive
+func extractFile(tarReader *tar.Reader, path string, mode os.FileMode) error {
+    if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+        return fmt.Errorf("Could not create folder %s: %w", filepath.Dir(path), err)
+    }
+    file, err := os.OpenFile(path, os.O_CREATE | os.O_TRUNC | os.O_WRONLY, mode)
+    if err != nil {
+        return fmt.Errorf("Could not create file %s: %w", path, err)
+    }
+    defer file.Close()
+    if _, err := io.Copy(file, tarReader); err != nil {
+        return fmt.Errorf("Could not write file %s: %w", path, err)
+    }
+    return nil
+}
@@ -0,0 +1,150 @@
+package backup
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+)
+
+
+// Config
+const (
+    BackupNamePrefix = "rocketpool-backup-"
+    BackupNameSuffix = ".tar.gz.enc"
+    BackupTimeFormat = "20060102-150405"
+    UploadFolder = "backup-upload"
+    DefaultKnownHostsFile = "known_hosts"
+)
+
+
+// Files & folders omitted from backups; chain data can be resynced and is too large to back up,
+// and the upload folder only holds already-encrypted backups staged for upload
+var DefaultExcludes = []string{"chains", "eth1", "eth2", "chaindata", UploadFolder}
+
+
+// Backup destination
+type Destination interface {
+    Upload(name string, data []byte) error
+    Download(name string) ([]byte, error)
+    List() ([]string, error)
+    Delete(name string) error
+}
+
+
+// Create a backup of a folder and upload it to a destination
+// Files & folders with names in excludes are omitted; the oldest backups over the retention count are deleted
+func Create(destination Destination, rootPath string, excludes []string, passphrase string, retention int) (string, error) {
+
+    // Create archive
+    archive, err := CreateArchive(rootPath, excludes)
+    if err != nil {
+        return "", err
+    }
+
+    // Encrypt archive
+    data, err := Encrypt(archive, passphrase)
+    if err != nil {
+        return "", err
+    }
+
+    // Upload backup
+    name := GetBackupName(time.Now())
+    if err := Upload(destination, name, data, retention); err != nil {
+        return name, err
+    }
+
+    // Return
+    return name, nil
+
+}
+
+
+// Upload an encrypted backup to a destination
+// The oldest backups over the retention count are deleted
+func Upload(destination Destination, name string, data []byte, retention int) error {
+
+    // Upload backup
+    if err := destination.Upload(name, data); err != nil {
+        return fmt.Errorf("Could not upload backup %s: %w", name, err)
+    }
+
+    // Enforce retention
+    return EnforceRetention(destination, retention)
+
+}
+
+
+// Download a backup from a destination and restore it to a folder
+func Restore(destination Destination, name string, rootPath string, passphrase string) error {
+
+    // Download backup
+    data, err := destination.Download(name)
+    if err != nil {
+        return fmt.Errorf("Could not download backup %s: %w", name, err)
+    }
+
+    // Decrypt archive
+    archive, err := Decrypt(data, passphrase)
+    if err != nil {
+        return err
+    }
+
+    // Extract archive
+    return ExtractArchive(archive, rootPath)
+
+}
+
+
+// Get the backups at a destination, oldest first
+func List(destination Destination) ([]string, error) {
+    names, err := destination.List()
+    if err != nil {
+        return []string{}, fmt.Errorf("Could not list backups: %w", err)
+    }
+    backups := []string{}
+    for _, name := range names {
+        if _, ok := GetBackupTime(name); ok {
+            backups = append(backups, name)
+        }
+    }
+    sort.Strings(backups)
+    return backups, nil
+}
+
+
+// Delete the oldest backups at a destination until the retention count is met
+func EnforceRetention(destination Destination, retention int) error {
+    if retention <= 0 {
+        return nil
+    }
+    backups, err := List(destination)
+    if err != nil {
+        return err
+    }
+    for bi := 0; bi < len(backups) - retention; bi++ {
+        if err := destination.Delete(backups[bi]); err != nil {
+            return fmt.Errorf("Could not delete backup %s: %w", backups[bi], err)
+        }
+    }
+    return nil
+}
+
+
+// Get a backup name for a time
+func GetBackupName(t time.Time) string {
+    return BackupNamePrefix + t.UTC().Format(BackupTimeFormat) + BackupNameSuffix
+}
+
+
+// Get the time a backup was created at from its name
+func GetBackupTime(name string) (time.Time, bool) {
+    if !strings.HasPrefix(name, BackupNamePrefix) || !strings.HasSuffix(name, BackupNameSuffix) {
+        return time.Time{}, false
+    }
+    t, err := time.Parse(BackupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, BackupNamePrefix), BackupNameSuffix))
+    if err != nil {
+        return time.Time{}, false
+    }
+    return t, true
+}
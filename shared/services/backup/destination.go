@@ -0,0 +1,30 @@
+package backup
+
+import (
+    "errors"
+    "fmt"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Create a backup destination from config
+func NewDestination(cfg config.Backup) (Destination, error) {
+    switch cfg.Destination {
+        case "local":
+            if cfg.Path == "" { return nil, errors.New("A backup path is required for local backups") }
+            return NewLocalDestination(cfg.Path), nil
+        case "s3":
+            if cfg.S3.Bucket == "" { return nil, errors.New("An S3 bucket is required for S3 backups") }
+            return NewS3Destination(cfg.S3.Endpoint, cfg.S3.Region, cfg.S3.Bucket, cfg.Path, cfg.S3.AccessKey, cfg.S3.SecretKey), nil
+        case "sftp":
+            if cfg.SFTP.Host == "" { return nil, errors.New("An SFTP host is required for SFTP backups") }
+            return NewSFTPDestination(cfg.SFTP.Host, cfg.SFTP.User, cfg.SFTP.KeyPath, cfg.SFTP.KnownHostsPath, cfg.Path), nil
+        case "rsync":
+            if cfg.Rsync.Target == "" { return nil, errors.New("An rsync target is required for rsync backups") }
+            return NewRsyncDestination(cfg.Rsync.Target, cfg.Rsync.SSHKeyPath, cfg.SFTP.KnownHostsPath), nil
+        case "":
+            return nil, errors.New("No backup destination is configured")
+    }
+    return nil, fmt.Errorf("Unknown backup destination '%s'", cfg.Destination)
+}
@@ -0,0 +1,99 @@
+package backup
+
+import (
+    "crypto/aes"
+    "crypto/cipher"
+    "crypto/rand"
+    "errors"
+    "fmt"
+
+    "golang.org/x/crypto/scrypt"
+)
+
+
+// Encryption settings
+const (
+    SaltLength = 32
+    KeyLength = 32
+    ScryptN = 1 << 18
+    ScryptR = 8
+    ScryptP = 1
+)
+
+
+// Encrypt data with a passphrase
+// Output is the scrypt salt, followed by the AES-GCM nonce and ciphertext
+func Encrypt(data []byte, passphrase string) ([]byte, error) {
+
+    // Check passphrase
+    if passphrase == "" {
+        return []byte{}, errors.New("A backup passphrase is required")
+    }
+
+    // Generate salt & derive key
+    salt := make([]byte, SaltLength)
+    if _, err := rand.Read(salt); err != nil {
+        return []byte{}, fmt.Errorf("Could not generate backup encryption salt: %w", err)
+    }
+    gcm, err := getCipher(passphrase, salt)
+    if err != nil {
+        return []byte{}, err
+    }
+
+    // Generate nonce
+    nonce := make([]byte, gcm.NonceSize())
+    if _, err := rand.Read(nonce); err != nil {
+        return []byte{}, fmt.Errorf("Could not generate backup encryption nonce: %w", err)
+    }
+
+    // Encrypt & return
+    output := append(salt, nonce...)
+    return gcm.Seal(output, nonce, data, nil), nil
+
+}
+
+
+// Decrypt data encrypted with a passphrase
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+
+    // Check data length
+    if len(data) < SaltLength {
+        return []byte{}, errors.New("Invalid backup data")
+    }
+
+    // Derive key
+    gcm, err := getCipher(passphrase, data[:SaltLength])
+    if err != nil {
+        return []byte{}, err
+    }
+
+    // Decrypt & return
+    data = data[SaltLength:]
+    if len(data) < gcm.NonceSize() {
+        return []byte{}, errors.New("Invalid backup data")
+    }
+    decrypted, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+    if err != nil {
+        return []byte{}, errors.New("Could not decrypt backup; the passphrase may be incorrect")
+    }
+    return decrypted, nil
+
+}
+
+
+// Get an AES-GCM cipher with a key derived from a passphrase
+func getCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+    key, err := scrypt.Key([]byte(passphrase), salt, ScryptN, ScryptR, ScryptP, KeyLength)
+    if err != nil {
+        return nil, fmt.Errorf("Could not derive backup encryption key: %w", err)
+    }
+    block, err := aes.NewCipher(key)
+    if err != nil {
+        return nil, fmt.Errorf("Could not initialize backup encryption: %w", err)
+    }
+    gcm, err := cipher.NewGCM(block)
+    if err != nil {
+        return nil, fmt.Errorf("Could not initialize backup encryption: %w", err)
+    }
+    return gcm, nil
+}
@@ -0,0 +1,68 @@
+package backup
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+)
+
+
+// Config
+const (
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Local folder backup destination, e.g. a mounted external drive or network share
+type LocalDestination struct {
+    path string
+}
+
+
+// Create new local backup destination
+func NewLocalDestination(path string) *LocalDestination {
+    return &LocalDestination{
+        path: path,
+    }
+}
+
+
+// Write a backup to the folder
+func (d *LocalDestination) Upload(name string, data []byte) error {
+    if err := os.MkdirAll(d.path, DirMode); err != nil {
+        return err
+    }
+    return ioutil.WriteFile(filepath.Join(d.path, name), data, FileMode)
+}
+
+
+// Read a backup from the folder
+func (d *LocalDestination) Download(name string) ([]byte, error) {
+    return ioutil.ReadFile(filepath.Join(d.path, name))
+}
+
+
+// List the files in the folder
+func (d *LocalDestination) List() ([]string, error) {
+    files, err := ioutil.ReadDir(d.path)
+    if os.IsNotExist(err) {
+        return []string{}, nil
+    }
+    if err != nil {
+        return []string{}, err
+    }
+    names := []string{}
+    for _, file := range files {
+        if file.Mode().IsRegular() {
+            names = append(names, file.Name())
+        }
+    }
+    return names, nil
+}
+
+
+// Delete a backup from the folder
+func (d *LocalDestination) Delete(name string) error {
+    return os.Remove(filepath.Join(d.path, name))
+}
@@ -0,0 +1,143 @@
+package backup
+
+import (
+    "bytes"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+)
+
+
+// rsync backup destination
+// The target is an rsync destination folder such as user@host:/path/to/backups or rsync://host/module/path;
+// the rsync binary must be installed
+// Host keys for SSH targets are verified against a known_hosts file, as for SFTP destinations
+type RsyncDestination struct {
+    target string
+    sshKeyPath string
+    knownHostsPath string
+}
+
+
+// Create new rsync backup destination
+// If knownHostsPath is empty, the known_hosts file in the SSH key's folder is used
+func NewRsyncDestination(target, sshKeyPath, knownHostsPath string) *RsyncDestination {
+    if knownHostsPath == "" && sshKeyPath != "" {
+        knownHostsPath = filepath.Join(filepath.Dir(sshKeyPath), DefaultKnownHostsFile)
+    }
+    return &RsyncDestination{
+        target: strings.TrimSuffix(target, "/"),
+        sshKeyPath: sshKeyPath,
+        knownHostsPath: knownHostsPath,
+    }
+}
+
+
+// Upload a backup to the target
+func (d *RsyncDestination) Upload(name string, data []byte) error {
+
+    // Write backup to temporary folder
+    tempDir, err := ioutil.TempDir("", "rocketpool-backup")
+    if err != nil {
+        return err
+    }
+    defer os.RemoveAll(tempDir)
+    if err := ioutil.WriteFile(filepath.Join(tempDir, name), data, FileMode); err != nil {
+        return err
+    }
+
+    // Transfer backup
+    _, err = d.rsync(filepath.Join(tempDir, name), d.target + "/" + name)
+    return err
+
+}
+
+
+// Download a backup from the target
+func (d *RsyncDestination) Download(name string) ([]byte, error) {
+
+    // Transfer backup to temporary folder
+    tempDir, err := ioutil.TempDir("", "rocketpool-backup")
+    if err != nil {
+        return []byte{}, err
+    }
+    defer os.RemoveAll(tempDir)
+    if _, err := d.rsync(d.target + "/" + name, filepath.Join(tempDir, name)); err != nil {
+        return []byte{}, err
+    }
+
+    // Read backup
+    return ioutil.ReadFile(filepath.Join(tempDir, name))
+
+}
+
+
+// List the files at the target
+func (d *RsyncDestination) List() ([]string, error) {
+    output, err := d.rsync("--list-only", d.target + "/")
+    if err != nil {
+        return []string{}, err
+    }
+    names := []string{}
+    for _, line := range strings.Split(string(output), "\n") {
+        fields := strings.Fields(line)
+        if len(fields) < 5 || !strings.HasPrefix(fields[0], "-") {
+            continue
+        }
+        names = append(names, fields[len(fields) - 1])
+    }
+    return names, nil
+}
+
+
+// Delete a backup from the target
+// rsync cannot delete remote files directly, so an empty folder is synced to the target with all other files excluded
+func (d *RsyncDestination) Delete(name string) error {
+    tempDir, err := ioutil.TempDir("", "rocketpool-backup")
+    if err != nil {
+        return err
+    }
+    defer os.RemoveAll(tempDir)
+    _, err = d.rsync("-r", "--delete", "--include=" + name, "--exclude=*", tempDir + "/", d.target + "/")
+    return err
+}
+
+
+// Run rsync with arguments
+func (d *RsyncDestination) rsync(args ...string) ([]byte, error) {
+    if shell := d.remoteShell(); shell != "" {
+        args = append([]string{"-e", shell}, args...)
+    }
+    cmd := exec.Command("rsync", args...)
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    output, err := cmd.Output()
+    if err != nil {
+        return []byte{}, fmt.Errorf("rsync failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+    }
+    return output, nil
+}
+
+
+// Get the remote shell command for SSH targets, which only connects to hosts in the known_hosts file
+func (d *RsyncDestination) remoteShell() string {
+    if d.knownHostsPath == "" {
+        return ""
+    }
+    shell := "ssh"
+    if d.sshKeyPath != "" {
+        shell += " -i " + rsyncQuote(d.sshKeyPath)
+    }
+    return shell + " -o " + rsyncQuote("UserKnownHostsFile=" + d.knownHostsPath) + " -o StrictHostKeyChecking=yes"
+}
+
+
+// Quote an argument in an rsync remote shell command; rsync splits the command on spaces, and a doubled quote
+// inside a quoted argument is a literal quote
+func rsyncQuote(arg string) string {
+    return "'" + strings.ReplaceAll(arg, "'", "''") + "'"
+}
+
@@ -0,0 +1,29 @@
+package backup
+
+import (
+    "testing"
+)
+
+
+// SSH targets are only connected to if their host key is in the known_hosts file, with quoted paths
+func TestRsyncRemoteShell(t *testing.T) {
+    tests := []struct {
+        name string
+        sshKeyPath string
+        knownHostsPath string
+        shell string
+    }{
+        {"no key", "", "", ""},
+        {"default known hosts", "/.rocketpool/backup_key", "", "ssh -i '/.rocketpool/backup_key' -o 'UserKnownHostsFile=/.rocketpool/known_hosts' -o StrictHostKeyChecking=yes"},
+        {"configured known hosts", "/.rocketpool/backup_key", "/etc/ssh/known_hosts", "ssh -i '/.rocketpool/backup_key' -o 'UserKnownHostsFile=/etc/ssh/known_hosts' -o StrictHostKeyChecking=yes"},
+        {"known hosts without key", "", "/etc/ssh/known_hosts", "ssh -o 'UserKnownHostsFile=/etc/ssh/known_hosts' -o StrictHostKeyChecking=yes"},
+        {"paths with spaces & quotes", "/keys/my key", "/keys/it's known", "ssh -i '/keys/my key' -o 'UserKnownHostsFile=/keys/it''s known' -o StrictHostKeyChecking=yes"},
+    }
+    for _, test := range tests {
+        shell := NewRsyncDestination("user@host:/backups", test.sshKeyPath, test.knownHostsPath).remoteShell()
+        if shell != test.shell {
+            t.Errorf("%s: remoteShell() = %q; expected %q", test.name, shell, test.shell)
+        }
+    }
+}
+
@@ -0,0 +1,180 @@
+package backup
+
+import (
+    "bytes"
+    "encoding/xml"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "net/url"
+    "strings"
+    "time"
+
+    "github.com/aws/aws-sdk-go/aws/credentials"
+    v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+
+// Config
+const (
+    DefaultS3Endpoint = "https://s3.amazonaws.com"
+    DefaultS3Region = "us-east-1"
+)
+var s3Timeout, _ = time.ParseDuration("5m")
+
+
+// S3-compatible object storage backup destination
+// Requests use path-style bucket addressing and AWS signature version 4, which are supported by AWS and most compatible services
+type S3Destination struct {
+    endpoint string
+    region string
+    bucket string
+    prefix string
+    signer *v4.Signer
+    client *http.Client
+}
+
+
+// ListObjectsV2 response
+type s3ListResult struct {
+    Contents []struct {
+        Key string                  `xml:"Key"`
+    }                               `xml:"Contents"`
+    IsTruncated bool                `xml:"IsTruncated"`
+    NextContinuationToken string    `xml:"NextContinuationToken"`
+}
+
+
+// Create new S3 backup destination
+func NewS3Destination(endpoint, region, bucket, prefix, accessKey, secretKey string) *S3Destination {
+    if endpoint == "" {
+        endpoint = DefaultS3Endpoint
+    }
+    if region == "" {
+        region = DefaultS3Region
+    }
+    prefix = strings.Trim(prefix, "/")
+    if prefix != "" {
+        prefix += "/"
+    }
+    return &S3Destination{
+        endpoint: strings.TrimSuffix(endpoint, "/"),
+        region: region,
+        bucket: bucket,
+        prefix: prefix,
+        signer: v4.NewSigner(credentials.NewStaticCredentials(accessKey, secretKey, ""), func(signer *v4.Signer) {
+            signer.DisableURIPathEscaping = true // S3 paths are escaped once, as sent
+        }),
+        client: &http.Client{Timeout: s3Timeout},
+    }
+}
+
+
+// Upload a backup object
+func (d *S3Destination) Upload(name string, data []byte) error {
+    _, err := d.request("PUT", d.prefix + name, url.Values{}, data)
+    return err
+}
+
+
+// Download a backup object
+func (d *S3Destination) Download(name string) ([]byte, error) {
+    return d.request("GET", d.prefix + name, url.Values{}, nil)
+}
+
+
+// List the objects under the prefix
+func (d *S3Destination) List() ([]string, error) {
+    names := []string{}
+    continuationToken := ""
+    for {
+
+        // Get objects page
+        query := url.Values{}
+        query.Set("list-type", "2")
+        query.Set("prefix", d.prefix)
+        if continuationToken != "" {
+            query.Set("continuation-token", continuationToken)
+        }
+        responseBytes, err := d.request("GET", "", query, nil)
+        if err != nil {
+            return []string{}, err
+        }
+        var result s3ListResult
+        if err := xml.Unmarshal(responseBytes, &result); err != nil {
+            return []string{}, fmt.Errorf("Could not decode S3 list response: %w", err)
+        }
+
+        // Add object names
+        for _, object := range result.Contents {
+            name := strings.TrimPrefix(object.Key, d.prefix)
+            if name != "" && !strings.Contains(name, "/") {
+                names = append(names, name)
+            }
+        }
+
+        // Get next page
+        if !result.IsTruncated || result.NextContinuationToken == "" {
+            break
+        }
+        continuationToken = result.NextContinuationToken
+
+    }
+    return names, nil
+}
+
+
+// Delete a backup object
+func (d *S3Destination) Delete(name string) error {
+    _, err := d.request("DELETE", d.prefix + name, url.Values{}, nil)
+    return err
+}
+
+
+// Make a signed request to the bucket
+func (d *S3Destination) request(method, key string, query url.Values, body []byte) ([]byte, error) {
+
+    // Get request URL
+    endpoint, err := url.Parse(d.endpoint)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Invalid S3 endpoint %s: %w", d.endpoint, err)
+    }
+    requestURL := url.URL{
+        Scheme: endpoint.Scheme,
+        Host: endpoint.Host,
+        Path: "/" + d.bucket,
+        RawQuery: query.Encode(),
+    }
+    if key != "" {
+        requestURL.Path += "/" + key
+    }
+
+    // Create & sign request
+    bodyReader := bytes.NewReader(body)
+    request, err := http.NewRequest(method, requestURL.String(), bodyReader)
+    if err != nil {
+        return []byte{}, err
+    }
+    if _, err := d.signer.Sign(request, bodyReader, "s3", d.region, time.Now()); err != nil {
+        return []byte{}, fmt.Errorf("Could not sign S3 request: %w", err)
+    }
+
+    // Send request
+    response, err := d.client.Do(request)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not make S3 request: %w", err)
+    }
+    defer response.Body.Close()
+    responseBytes, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not read S3 response: %w", err)
+    }
+    if response.StatusCode < 200 || response.StatusCode >= 300 {
+        return []byte{}, fmt.Errorf("S3 request failed with status %s: %s", response.Status, strings.TrimSpace(string(responseBytes)))
+    }
+
+    // Return
+    return responseBytes, nil
+
+}
+
@@ -0,0 +1,131 @@
+package backup
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "sort"
+    "strings"
+    "sync"
+    "testing"
+)
+
+
+// In-memory S3 bucket server
+type s3TestServer struct {
+    t *testing.T
+    bucket string
+    objects map[string][]byte
+    lock sync.Mutex
+}
+
+
+// Handle an S3 request
+func (s *s3TestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+
+    // Check signature headers
+    body, _ := ioutil.ReadAll(r.Body)
+    bodyHash := sha256.Sum256(body)
+    if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(bodyHash[:]) {
+        s.t.Errorf("%s %s: content hash header does not match body", r.Method, r.URL.Path)
+    }
+    authorization := r.Header.Get("Authorization")
+    if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access/") || !strings.Contains(authorization, "/eu-west-1/s3/aws4_request") || !strings.Contains(authorization, "Signature=") {
+        s.t.Errorf("%s %s: unexpected authorization header %q", r.Method, r.URL.Path, authorization)
+    }
+
+    // Get object key
+    if !strings.HasPrefix(r.URL.Path, "/" + s.bucket) {
+        w.WriteHeader(http.StatusNotFound)
+        return
+    }
+    key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/" + s.bucket), "/")
+
+    // Handle request
+    switch {
+        case r.Method == "GET" && key == "":
+            keys := []string{}
+            for objectKey := range s.objects {
+                if strings.HasPrefix(objectKey, r.URL.Query().Get("prefix")) {
+                    keys = append(keys, objectKey)
+                }
+            }
+            sort.Strings(keys)
+            fmt.Fprint(w, "<ListBucketResult>")
+            for _, objectKey := range keys {
+                fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", objectKey)
+            }
+            fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+        case r.Method == "PUT":
+            s.objects[key] = body
+        case r.Method == "GET":
+            data, ok := s.objects[key]
+            if !ok {
+                w.WriteHeader(http.StatusNotFound)
+                fmt.Fprint(w, "NoSuchKey")
+                return
+            }
+            w.Write(data)
+        case r.Method == "DELETE":
+            delete(s.objects, key)
+            w.WriteHeader(http.StatusNoContent)
+        default:
+            w.WriteHeader(http.StatusMethodNotAllowed)
+    }
+
+}
+
+
+// Backups can be uploaded, listed, downloaded and deleted with signed requests
+func TestS3Destination(t *testing.T) {
+
+    // Start server
+    handler := &s3TestServer{t: t, bucket: "backups", objects: map[string][]byte{"other/file": []byte("x")}}
+    server := httptest.NewServer(handler)
+    defer server.Close()
+    d := NewS3Destination(server.URL + "/", "eu-west-1", "backups", "/smartnode/", "access", "secret")
+
+    // Upload
+    if err := d.Upload("backup-1.tar.gz.enc", []byte("backup data")); err != nil {
+        t.Fatal(err)
+    }
+    if _, ok := handler.objects["smartnode/backup-1.tar.gz.enc"]; !ok {
+        t.Fatalf("Object was not stored under the prefix: %v", handler.objects)
+    }
+
+    // List
+    names, err := d.List()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(names) != 1 || names[0] != "backup-1.tar.gz.enc" {
+        t.Errorf("List() = %v; expected [backup-1.tar.gz.enc]", names)
+    }
+
+    // Download
+    data, err := d.Download("backup-1.tar.gz.enc")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if string(data) != "backup data" {
+        t.Errorf("Download() = %q; expected %q", data, "backup data")
+    }
+    if _, err := d.Download("missing"); err == nil {
+        t.Error("Download() of a missing object did not fail")
+    }
+
+    // Delete
+    if err := d.Delete("backup-1.tar.gz.enc"); err != nil {
+        t.Fatal(err)
+    }
+    if names, err := d.List(); err != nil || len(names) != 0 {
+        t.Errorf("List() after delete = %v, %v; expected no backups", names, err)
+    }
+
+}
+
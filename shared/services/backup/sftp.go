@@ -0,0 +1,185 @@
+package backup
+
+import (
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path"
+    "path/filepath"
+
+    "github.com/pkg/sftp"
+    "golang.org/x/crypto/ssh"
+    "golang.org/x/crypto/ssh/knownhosts"
+
+    "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+
+// SFTP backup destination
+// The host key is verified against a known_hosts file, so hosts which only permit SFTP access are supported without a shell
+type SFTPDestination struct {
+    host string
+    user string
+    keyPath string
+    knownHostsPath string
+    path string
+}
+
+
+// SFTP connection
+type sftpConnection struct {
+    client *ssh.Client
+    sftp *sftp.Client
+}
+
+
+// Create new SFTP backup destination
+// If knownHostsPath is empty, the known_hosts file in the private key's folder is used
+func NewSFTPDestination(host, user, keyPath, knownHostsPath, path string) *SFTPDestination {
+    if knownHostsPath == "" {
+        knownHostsPath = filepath.Join(filepath.Dir(keyPath), DefaultKnownHostsFile)
+    }
+    if path == "" {
+        path = "."
+    }
+    return &SFTPDestination{
+        host: host,
+        user: user,
+        keyPath: keyPath,
+        knownHostsPath: knownHostsPath,
+        path: path,
+    }
+}
+
+
+// Upload a backup file
+func (d *SFTPDestination) Upload(name string, data []byte) error {
+    conn, err := d.connect()
+    if err != nil {
+        return err
+    }
+    defer conn.close()
+    if err := conn.sftp.MkdirAll(d.path); err != nil {
+        return fmt.Errorf("Could not create backup folder %s on %s: %w", d.path, d.host, err)
+    }
+    file, err := conn.sftp.OpenFile(path.Join(d.path, name), os.O_WRONLY | os.O_CREATE | os.O_TRUNC)
+    if err != nil {
+        return fmt.Errorf("Could not create backup file %s on %s: %w", name, d.host, err)
+    }
+    if _, err := file.Write(data); err != nil {
+        file.Close()
+        return fmt.Errorf("Could not write backup file %s on %s: %w", name, d.host, err)
+    }
+    return file.Close()
+}
+
+
+// Download a backup file
+func (d *SFTPDestination) Download(name string) ([]byte, error) {
+    conn, err := d.connect()
+    if err != nil {
+        return []byte{}, err
+    }
+    defer conn.close()
+    file, err := conn.sftp.Open(path.Join(d.path, name))
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not open backup file %s on %s: %w", name, d.host, err)
+    }
+    defer file.Close()
+    data, err := ioutil.ReadAll(file)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not read backup file %s on %s: %w", name, d.host, err)
+    }
+    return data, nil
+}
+
+
+// List the files in the backup folder
+func (d *SFTPDestination) List() ([]string, error) {
+    conn, err := d.connect()
+    if err != nil {
+        return []string{}, err
+    }
+    defer conn.close()
+    files, err := conn.sftp.ReadDir(d.path)
+    if os.IsNotExist(err) {
+        return []string{}, nil
+    }
+    if err != nil {
+        return []string{}, fmt.Errorf("Could not list backup folder %s on %s: %w", d.path, d.host, err)
+    }
+    names := []string{}
+    for _, file := range files {
+        if !file.IsDir() {
+            names = append(names, file.Name())
+        }
+    }
+    return names, nil
+}
+
+
+// Delete a backup file
+func (d *SFTPDestination) Delete(name string) error {
+    conn, err := d.connect()
+    if err != nil {
+        return err
+    }
+    defer conn.close()
+    if err := conn.sftp.Remove(path.Join(d.path, name)); err != nil {
+        return fmt.Errorf("Could not delete backup file %s on %s: %w", name, d.host, err)
+    }
+    return nil
+}
+
+
+// Connect to the host and start an SFTP session
+func (d *SFTPDestination) connect() (*sftpConnection, error) {
+
+    // Read private key
+    keyBytes, err := ioutil.ReadFile(d.keyPath)
+    if err != nil {
+        return nil, fmt.Errorf("Could not read SFTP private key at %s: %w", d.keyPath, err)
+    }
+    key, err := ssh.ParsePrivateKey(keyBytes)
+    if err != nil {
+        return nil, fmt.Errorf("Could not parse SFTP private key at %s: %w", d.keyPath, err)
+    }
+
+    // Load known hosts
+    hostKeyCallback, err := knownhosts.New(d.knownHostsPath)
+    if err != nil {
+        return nil, fmt.Errorf("Could not load SFTP known hosts at %s; add the host key with 'ssh-keyscan %s >> %s': %w", d.knownHostsPath, d.host, d.knownHostsPath, err)
+    }
+
+    // Connect
+    client, err := ssh.Dial("tcp", net.DefaultPort(d.host, "22"), &ssh.ClientConfig{
+        User: d.user,
+        Auth: []ssh.AuthMethod{ssh.PublicKeys(key)},
+        HostKeyCallback: hostKeyCallback,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("Could not connect to %s as %s: %w", d.host, d.user, err)
+    }
+
+    // Start SFTP session
+    sftpClient, err := sftp.NewClient(client)
+    if err != nil {
+        client.Close()
+        return nil, fmt.Errorf("Could not start SFTP session on %s: %w", d.host, err)
+    }
+
+    // Return
+    return &sftpConnection{
+        client: client,
+        sftp: sftpClient,
+    }, nil
+
+}
+
+
+// Close the connection
+func (c *sftpConnection) close() {
+    c.sftp.Close()
+    c.client.Close()
+}
+
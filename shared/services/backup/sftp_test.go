@@ -0,0 +1,214 @@
+package backup
+
+import (
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "crypto/x509"
+    "encoding/pem"
+    "io/ioutil"
+    "net"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/pkg/sftp"
+    "golang.org/x/crypto/ssh"
+    "golang.org/x/crypto/ssh/knownhosts"
+)
+
+
+// Generate an SSH signer, and its PEM-encoded private key
+func newTestSSHKey(t *testing.T) (ssh.Signer, []byte) {
+    key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+    keyBytes, err := x509.MarshalECPrivateKey(key)
+    if err != nil {
+        t.Fatal(err)
+    }
+    signer, err := ssh.NewSignerFromKey(key)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return signer, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+}
+
+
+// Start an SFTP server which accepts the client key, and return its listener
+func startTestSFTPServer(t *testing.T, hostKey ssh.Signer, clientKey ssh.PublicKey) net.Listener {
+
+    // Server config
+    config := &ssh.ServerConfig{
+        PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+            if string(key.Marshal()) != string(clientKey.Marshal()) {
+                return nil, ssh.ErrNoAuth
+            }
+            return nil, nil
+        },
+    }
+    config.AddHostKey(hostKey)
+
+    // Listen
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Serve connections
+    go func() {
+        for {
+            conn, err := listener.Accept()
+            if err != nil {
+                return
+            }
+            go serveTestSFTPConn(conn, config)
+        }
+    }()
+
+    // Return
+    return listener
+
+}
+
+
+// Serve SFTP subsystem requests on a connection
+func serveTestSFTPConn(conn net.Conn, config *ssh.ServerConfig) {
+    _, channels, requests, err := ssh.NewServerConn(conn, config)
+    if err != nil {
+        return
+    }
+    go ssh.DiscardRequests(requests)
+    for newChannel := range channels {
+        if newChannel.ChannelType() != "session" {
+            newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+            continue
+        }
+        channel, channelRequests, err := newChannel.Accept()
+        if err != nil {
+            continue
+        }
+        go func() {
+            for request := range channelRequests {
+                ok := request.Type == "subsystem" && strings.HasSuffix(string(request.Payload), "sftp")
+                request.Reply(ok, nil)
+                if ok {
+                    if server, err := sftp.NewServer(channel); err == nil {
+                        server.Serve()
+                    }
+                    channel.Close()
+                }
+            }
+        }()
+    }
+}
+
+
+// Backups can be uploaded, listed, downloaded and deleted on a known host
+func TestSFTPDestination(t *testing.T) {
+
+    // Start server
+    hostKey, _ := newTestSSHKey(t)
+    clientKey, clientKeyPem := newTestSSHKey(t)
+    listener := startTestSFTPServer(t, hostKey, clientKey.PublicKey())
+    defer listener.Close()
+    address := listener.Addr().String()
+
+    // Write client key & known hosts
+    dir, err := ioutil.TempDir("", "sftp-test")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer os.RemoveAll(dir)
+    keyPath := filepath.Join(dir, "backup_key")
+    if err := ioutil.WriteFile(keyPath, clientKeyPem, 0600); err != nil {
+        t.Fatal(err)
+    }
+    knownHosts := knownhosts.Line([]string{knownhosts.Normalize(address)}, hostKey.PublicKey()) + "\n"
+    if err := ioutil.WriteFile(filepath.Join(dir, DefaultKnownHostsFile), []byte(knownHosts), 0600); err != nil {
+        t.Fatal(err)
+    }
+    d := NewSFTPDestination(address, "backup", keyPath, "", filepath.Join(dir, "remote", "smartnode"))
+
+    // Upload
+    if err := d.Upload("backup-1.tar.gz.enc", []byte("backup data")); err != nil {
+        t.Fatal(err)
+    }
+
+    // List
+    names, err := d.List()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(names) != 1 || names[0] != "backup-1.tar.gz.enc" {
+        t.Errorf("List() = %v; expected [backup-1.tar.gz.enc]", names)
+    }
+
+    // Download
+    data, err := d.Download("backup-1.tar.gz.enc")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if string(data) != "backup data" {
+        t.Errorf("Download() = %q; expected %q", data, "backup data")
+    }
+
+    // Delete
+    if err := d.Delete("backup-1.tar.gz.enc"); err != nil {
+        t.Fatal(err)
+    }
+    if names, err := d.List(); err != nil || len(names) != 0 {
+        t.Errorf("List() after delete = %v, %v; expected no backups", names, err)
+    }
+
+}
+
+
+// Connections fail if the host key is missing from known hosts or does not match
+func TestSFTPDestinationHostKey(t *testing.T) {
+
+    // Start server
+    hostKey, _ := newTestSSHKey(t)
+    otherKey, _ := newTestSSHKey(t)
+    clientKey, clientKeyPem := newTestSSHKey(t)
+    listener := startTestSFTPServer(t, hostKey, clientKey.PublicKey())
+    defer listener.Close()
+    address := listener.Addr().String()
+
+    // Write client key
+    dir, err := ioutil.TempDir("", "sftp-test")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer os.RemoveAll(dir)
+    keyPath := filepath.Join(dir, "backup_key")
+    if err := ioutil.WriteFile(keyPath, clientKeyPem, 0600); err != nil {
+        t.Fatal(err)
+    }
+    knownHostsPath := filepath.Join(dir, "known_hosts")
+
+    // Missing known hosts file
+    if _, err := NewSFTPDestination(address, "backup", keyPath, knownHostsPath, dir).List(); err == nil {
+        t.Error("List() without a known hosts file did not fail")
+    }
+
+    // Unknown host
+    if err := ioutil.WriteFile(knownHostsPath, []byte(knownhosts.Line([]string{"example.com"}, hostKey.PublicKey()) + "\n"), 0600); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := NewSFTPDestination(address, "backup", keyPath, knownHostsPath, dir).List(); err == nil {
+        t.Error("List() with an unknown host did not fail")
+    }
+
+    // Mismatched host key
+    if err := ioutil.WriteFile(knownHostsPath, []byte(knownhosts.Line([]string{knownhosts.Normalize(address)}, otherKey.PublicKey()) + "\n"), 0600); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := NewSFTPDestination(address, "backup", keyPath, knownHostsPath, dir).List(); err == nil {
+        t.Error("List() with a mismatched host key did not fail")
+    }
+
+}
+
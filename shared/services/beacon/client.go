@@ -6,6 +6,18 @@ import (
 )
 
 
+// Epoch value for validator events which have not occurred
+const FarFutureEpoch = ^uint64(0)
+
+
+// Peer connection directions; the direction is unknown if not reported by the client
+const (
+    PeerDirectionUnknown PeerDirection = ""
+    PeerDirectionInbound PeerDirection = "inbound"
+    PeerDirectionOutbound PeerDirection = "outbound"
+)
+
+
 // API request options
 type ValidatorStatusOptions struct {
     Epoch uint64
@@ -20,6 +32,7 @@ type Eth2Config struct {
     GenesisForkVersion []byte
     GenesisEpoch uint64
     GenesisTime uint64
+    SecondsPerSlot uint64
     SecondsPerEpoch uint64
 }
 type BeaconHead struct {
@@ -34,6 +47,7 @@ type BeaconHead struct {
 }
 type ValidatorStatus struct {
     Pubkey types.ValidatorPubkey
+    Index uint64
     WithdrawalCredentials common.Hash
     Balance uint64
     EffectiveBalance uint64
@@ -44,6 +58,31 @@ type ValidatorStatus struct {
     WithdrawableEpoch uint64
     Exists bool
 }
+type Peer struct {
+    ID string
+    Address string
+    Direction PeerDirection
+}
+type PeerDirection string
+type ValidatorQueue struct {
+    ActivationQueueLength uint64
+    ChurnLimit uint64
+}
+type ValidatorPerformance struct {
+    Pubkey types.ValidatorPubkey
+    Index uint64
+    Active bool
+    Attested bool
+    CorrectTarget bool
+    CorrectHead bool
+    InclusionDistance uint64
+}
+type ValidatorDuties struct {
+    Pubkey types.ValidatorPubkey
+    Index uint64
+    AttesterSlot uint64
+    ProposerSlots []uint64
+}
 
 
 // Beacon client interface
@@ -52,6 +91,14 @@ type Client interface {
     GetEth2Config() (Eth2Config, error)
     GetBeaconHead() (BeaconHead, error)
     GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
+    GetPeers() ([]Peer, error)
+    GetPeerCount() (uint64, error)
+    GetValidatorQueue() (ValidatorQueue, error)
+    GetValidatorPerformance(pubkeys []types.ValidatorPubkey, epoch uint64) ([]ValidatorPerformance, error)
+    GetValidatorDuties(pubkeys []types.ValidatorPubkey, epoch uint64) ([]ValidatorDuties, error)
+    GetBlockProposer(slot uint64) (uint64, bool, error)
+    GetDomainData(domainType []byte, epoch uint64) ([]byte, error)
+    ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error
     Close()
 }
 
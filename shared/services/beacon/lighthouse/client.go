@@ -8,9 +8,11 @@ import (
     "net/http"
     "net/url"
     "strconv"
+    "strings"
 
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/types"
+    eth2types "github.com/wealdtech/go-eth2-types/v2"
     "golang.org/x/sync/errgroup"
 
     "github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -28,10 +30,23 @@ const (
     RequestEth2ConfigPath = "/spec"
     RequestBeaconHeadPath = "/beacon/head"
     RequestValidatorsPath = "/beacon/validators"
+    RequestPeersPath = "/network/peers"
+    RequestPeerCountPath = "/network/peer_count"
+    RequestAllValidatorsPath = "/beacon/validators/all"
+    RequestIndividualVotesPath = "/consensus/individual_votes"
+    RequestValidatorDutiesPath = "/validator/duties"
+    RequestBlockPath = "/beacon/block"
 
     RequestSlotsPerEpochPath = "/spec/slots_per_epoch"
     RequestGenesisTimePath = "/beacon/genesis_time"
     RequestBeaconStateRootPath = "/beacon/state_root"
+    RequestForkPath = "/beacon/fork"
+    RequestGenesisValidatorsRootPath = "/beacon/genesis_validators_root"
+    RequestVoluntaryExitPath = "/eth/v1/beacon/pool/voluntary_exits"
+
+    FarFutureEpoch = ^uint64(0)
+    MinPerEpochChurnLimit = 4
+    ChurnLimitQuotient = 65536
 )
 
 
@@ -121,6 +136,7 @@ func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
         GenesisForkVersion: config.GenesisForkVersion,
         GenesisEpoch: config.GenesisSlot / slotsPerEpoch,
         GenesisTime: genesisTime,
+        SecondsPerSlot: config.MillisecondsPerSlot / 1000,
         SecondsPerEpoch: config.MillisecondsPerSlot * slotsPerEpoch / 1000,
     }, nil
 
@@ -221,6 +237,7 @@ func (c *Client) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.V
     // Return response
     return beacon.ValidatorStatus{
         Pubkey: types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+        Index: validator.ValidatorIndex,
         WithdrawalCredentials: common.BytesToHash(validator.Validator.WithdrawalCredentials),
         Balance: validator.Balance,
         EffectiveBalance: validator.Validator.EffectiveBalance,
@@ -235,6 +252,279 @@ func (c *Client) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.V
 }
 
 
+// Get the node's connected peers
+// Peer addresses & connection directions are not available via the lighthouse API
+func (c *Client) GetPeers() ([]beacon.Peer, error) {
+
+    // Request
+    responseBody, err := c.getRequest(RequestPeersPath)
+    if err != nil {
+        return []beacon.Peer{}, fmt.Errorf("Could not get node peers: %w", err)
+    }
+
+    // Unmarshal response
+    var peerIds []string
+    if err := json.Unmarshal(responseBody, &peerIds); err != nil {
+        return []beacon.Peer{}, fmt.Errorf("Could not decode node peers: %w", err)
+    }
+
+    // Return response
+    peers := make([]beacon.Peer, len(peerIds))
+    for pi, peerId := range peerIds {
+        peers[pi] = beacon.Peer{ID: peerId}
+    }
+    return peers, nil
+
+}
+
+
+// Get the node's connected peer count
+func (c *Client) GetPeerCount() (uint64, error) {
+
+    // Request
+    responseBody, err := c.getRequest(RequestPeerCountPath)
+    if err != nil {
+        return 0, fmt.Errorf("Could not get node peer count: %w", err)
+    }
+
+    // Unmarshal response
+    var peerCount uint64
+    if err := json.Unmarshal(responseBody, &peerCount); err != nil {
+        return 0, fmt.Errorf("Could not decode node peer count: %w", err)
+    }
+
+    // Return response
+    return peerCount, nil
+
+}
+
+
+// Get the validator activation queue
+// The queue is derived from the full validator set as it is not available via the lighthouse API
+func (c *Client) GetValidatorQueue() (beacon.ValidatorQueue, error) {
+
+    // Get beacon head
+    head, err := c.GetBeaconHead()
+    if err != nil {
+        return beacon.ValidatorQueue{}, err
+    }
+
+    // Request
+    responseBody, err := c.getRequest(RequestAllValidatorsPath)
+    if err != nil {
+        return beacon.ValidatorQueue{}, fmt.Errorf("Could not get validators: %w", err)
+    }
+
+    // Unmarshal response
+    var validators []ValidatorResponse
+    if err := json.Unmarshal(responseBody, &validators); err != nil {
+        return beacon.ValidatorQueue{}, fmt.Errorf("Could not decode validators: %w", err)
+    }
+
+    // Count queued & active validators
+    var queueLength, activeCount uint64
+    for _, validator := range validators {
+        if validator.Validator.ActivationEligibilityEpoch != FarFutureEpoch && validator.Validator.ActivationEpoch == FarFutureEpoch {
+            queueLength++
+        } else if validator.Validator.ActivationEpoch <= head.Epoch && head.Epoch < validator.Validator.ExitEpoch {
+            activeCount++
+        }
+    }
+
+    // Get churn limit
+    churnLimit := activeCount / ChurnLimitQuotient
+    if churnLimit < MinPerEpochChurnLimit {
+        churnLimit = MinPerEpochChurnLimit
+    }
+
+    // Return response
+    return beacon.ValidatorQueue{
+        ActivationQueueLength: queueLength,
+        ChurnLimit: churnLimit,
+    }, nil
+
+}
+
+
+// Get validators' attestation performance for an epoch
+// Votes are reported against the state at the end of the following epoch; inclusion distances are not available via the lighthouse API
+func (c *Client) GetValidatorPerformance(pubkeys []types.ValidatorPubkey, epoch uint64) ([]beacon.ValidatorPerformance, error) {
+
+    // Request
+    responseBody, err := c.postRequest(RequestIndividualVotesPath, EpochValidatorsRequest{
+        Epoch: epoch + 1,
+        Pubkeys: getPubkeyStrings(pubkeys),
+    })
+    if err != nil {
+        return []beacon.ValidatorPerformance{}, fmt.Errorf("Could not get validator performance for epoch %d: %w", epoch, err)
+    }
+
+    // Unmarshal response
+    var votes []IndividualVoteResponse
+    if err := json.Unmarshal(responseBody, &votes); err != nil {
+        return []beacon.ValidatorPerformance{}, fmt.Errorf("Could not decode validator performance for epoch %d: %w", epoch, err)
+    }
+
+    // Return response
+    response := []beacon.ValidatorPerformance{}
+    for _, vote := range votes {
+        if vote.ValidatorIndex == nil || vote.Vote == nil {
+            continue
+        }
+        response = append(response, beacon.ValidatorPerformance{
+            Pubkey: types.BytesToValidatorPubkey(vote.Pubkey),
+            Index: *vote.ValidatorIndex,
+            Active: vote.Vote.IsActiveInPreviousEpoch,
+            Attested: vote.Vote.IsPreviousEpochAttester,
+            CorrectTarget: vote.Vote.IsPreviousEpochTargetAttester,
+            CorrectHead: vote.Vote.IsPreviousEpochHeadAttester,
+        })
+    }
+    return response, nil
+
+}
+
+
+// Get validators' attestation & proposal duties for an epoch
+func (c *Client) GetValidatorDuties(pubkeys []types.ValidatorPubkey, epoch uint64) ([]beacon.ValidatorDuties, error) {
+
+    // Request
+    responseBody, err := c.postRequest(RequestValidatorDutiesPath, EpochValidatorsRequest{
+        Epoch: epoch,
+        Pubkeys: getPubkeyStrings(pubkeys),
+    })
+    if err != nil {
+        return []beacon.ValidatorDuties{}, fmt.Errorf("Could not get validator duties for epoch %d: %w", epoch, err)
+    }
+
+    // Unmarshal response
+    var duties []ValidatorDutiesResponse
+    if err := json.Unmarshal(responseBody, &duties); err != nil {
+        return []beacon.ValidatorDuties{}, fmt.Errorf("Could not decode validator duties for epoch %d: %w", epoch, err)
+    }
+
+    // Return response
+    response := []beacon.ValidatorDuties{}
+    for _, duty := range duties {
+        if duty.ValidatorIndex == nil {
+            continue
+        }
+        validatorDuties := beacon.ValidatorDuties{
+            Pubkey: types.BytesToValidatorPubkey(duty.ValidatorPubkey),
+            Index: *duty.ValidatorIndex,
+            ProposerSlots: duty.BlockProposalSlots,
+        }
+        if duty.AttestationSlot != nil {
+            validatorDuties.AttesterSlot = *duty.AttestationSlot
+        }
+        response = append(response, validatorDuties)
+    }
+    return response, nil
+
+}
+
+
+// Get the index of the validator which proposed the block at a slot; returns false if the slot was skipped
+// Lighthouse returns the latest block at or before the slot, so skipped slots are detected by the block's slot
+func (c *Client) GetBlockProposer(slot uint64) (uint64, bool, error) {
+
+    // Get query params
+    params := url.Values{}
+    params.Set("slot", strconv.FormatUint(slot, 10))
+
+    // Request
+    responseBody, err := c.getRequest(fmt.Sprintf("%s?%s", RequestBlockPath, params.Encode()))
+    if err != nil {
+        return 0, false, fmt.Errorf("Could not get block at slot %d: %w", slot, err)
+    }
+
+    // Unmarshal response
+    var block BlockResponse
+    if err := json.Unmarshal(responseBody, &block); err != nil {
+        return 0, false, fmt.Errorf("Could not decode block at slot %d: %w", slot, err)
+    }
+
+    // Return
+    if block.BeaconBlock.Message.Slot != slot {
+        return 0, false, nil
+    }
+    return block.BeaconBlock.Message.ProposerIndex, true, nil
+
+}
+
+
+// Get the signature domain for a domain type at an epoch
+func (c *Client) GetDomainData(domainType []byte, epoch uint64) ([]byte, error) {
+
+    // Data
+    var wg errgroup.Group
+    var fork ForkResponse
+    var genesisValidatorsRoot byteArray
+
+    // Request fork
+    wg.Go(func() error {
+        responseBody, err := c.getRequest(RequestForkPath)
+        if err != nil {
+            return fmt.Errorf("Could not get fork: %w", err)
+        }
+        if err := json.Unmarshal(responseBody, &fork); err != nil {
+            return fmt.Errorf("Could not decode fork: %w", err)
+        }
+        return nil
+    })
+
+    // Request genesis validators root
+    wg.Go(func() error {
+        responseBody, err := c.getRequest(RequestGenesisValidatorsRootPath)
+        if err != nil {
+            return fmt.Errorf("Could not get genesis validators root: %w", err)
+        }
+        if err := json.Unmarshal(responseBody, &genesisValidatorsRoot); err != nil {
+            return fmt.Errorf("Could not decode genesis validators root: %w", err)
+        }
+        return nil
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return []byte{}, err
+    }
+
+    // Get fork version at epoch
+    forkVersion := fork.CurrentVersion
+    if epoch < fork.Epoch {
+        forkVersion = fork.PreviousVersion
+    }
+
+    // Return
+    var dt eth2types.DomainType
+    copy(dt[:], domainType)
+    return eth2types.Domain(dt, forkVersion, genesisValidatorsRoot), nil
+
+}
+
+
+// Submit a signed voluntary exit for a validator
+// Exits are submitted via the standard beacon node API as they are not supported by the lighthouse API
+func (c *Client) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+
+    // Build exit request
+    var request VoluntaryExitRequest
+    request.Message.Epoch = strconv.FormatUint(epoch, 10)
+    request.Message.ValidatorIndex = strconv.FormatUint(validatorIndex, 10)
+    request.Signature = hexutil.AddPrefix(signature.Hex())
+
+    // Request
+    if _, err := c.postRequest(RequestVoluntaryExitPath, request); err != nil {
+        return fmt.Errorf("Could not submit voluntary exit for validator %d: %w", validatorIndex, err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
 // Get the number of slots per epoch
 func (c *Client) getSlotsPerEpoch() (uint64, error) {
 
@@ -302,6 +592,16 @@ func (c *Client) getStateRoot(slot uint64) (string, error) {
 }
 
 
+// Get validator pubkeys as prefixed hex strings
+func getPubkeyStrings(pubkeys []types.ValidatorPubkey) []string {
+    pubkeyStrings := make([]string, len(pubkeys))
+    for pi, pubkey := range pubkeys {
+        pubkeyStrings[pi] = hexutil.AddPrefix(pubkey.Hex())
+    }
+    return pubkeyStrings
+}
+
+
 // Make a GET request to the beacon node
 func (c *Client) getRequest(requestPath string) ([]byte, error) {
 
@@ -346,6 +646,9 @@ func (c *Client) postRequest(requestPath string, requestBody interface{}) ([]byt
     if err != nil {
         return []byte{}, err
     }
+    if response.StatusCode < 200 || response.StatusCode >= 300 {
+        return []byte{}, fmt.Errorf("Received status %s: %s", response.Status, strings.TrimSpace(string(body)))
+    }
 
     // Return
     return body, nil
@@ -13,6 +13,17 @@ type ValidatorsRequest struct {
     StateRoot string                `json:"state_root,omitempty"`
     Pubkeys []string                `json:"pubkeys"`
 }
+type EpochValidatorsRequest struct {
+    Epoch uint64                    `json:"epoch"`
+    Pubkeys []string                `json:"pubkeys"`
+}
+type VoluntaryExitRequest struct {
+    Message struct {
+        Epoch string                        `json:"epoch"`
+        ValidatorIndex string               `json:"validator_index"`
+    }                               `json:"message"`
+    Signature string                `json:"signature"`
+}
 
 
 // Response types
@@ -33,6 +44,7 @@ type BeaconHeadResponse struct {
     PreviousJustifiedSlot uint64    `json:"previous_justified_slot"`
 }
 type ValidatorResponse struct {
+    ValidatorIndex uint64           `json:"validator_index"`
     Balance uint64                  `json:"balance"`
     Validator struct {
         Pubkey byteArray                    `json:"pubkey"`
@@ -45,6 +57,37 @@ type ValidatorResponse struct {
         WithdrawableEpoch uint64            `json:"withdrawable_epoch"`
     }                               `json:"validator"`
 }
+type IndividualVoteResponse struct {
+    Epoch uint64                    `json:"epoch"`
+    Pubkey byteArray                `json:"pubkey"`
+    ValidatorIndex *uint64          `json:"validator_index"`
+    Vote *struct {
+        IsActiveInPreviousEpoch bool        `json:"is_active_in_previous_epoch"`
+        IsPreviousEpochAttester bool        `json:"is_previous_epoch_attester"`
+        IsPreviousEpochTargetAttester bool  `json:"is_previous_epoch_target_attester"`
+        IsPreviousEpochHeadAttester bool    `json:"is_previous_epoch_head_attester"`
+    }                               `json:"vote"`
+}
+type ValidatorDutiesResponse struct {
+    ValidatorPubkey byteArray       `json:"validator_pubkey"`
+    ValidatorIndex *uint64          `json:"validator_index"`
+    AttestationSlot *uint64         `json:"attestation_slot"`
+    BlockProposalSlots []uint64     `json:"block_proposal_slots"`
+}
+type BlockResponse struct {
+    BeaconBlock struct {
+        Message struct {
+            Slot uint64                     `json:"slot"`
+            ProposerIndex uint64            `json:"proposer_index"`
+        }                                   `json:"message"`
+    }                               `json:"beacon_block"`
+}
+
+type ForkResponse struct {
+    PreviousVersion byteArray       `json:"previous_version"`
+    CurrentVersion byteArray        `json:"current_version"`
+    Epoch uint64                    `json:"epoch"`
+}
 
 
 // Byte array
@@ -19,6 +19,7 @@ type Client struct {
     conn *grpc.ClientConn
     bc pb.BeaconChainClient
     nc pb.NodeClient
+    vc pb.BeaconNodeValidatorClient
 }
 
 
@@ -34,12 +35,14 @@ func NewClient(providerAddress string) (*Client, error) {
     // Initialize clients
     bc := pb.NewBeaconChainClient(conn)
     nc := pb.NewNodeClient(conn)
+    vc := pb.NewBeaconNodeValidatorClient(conn)
 
     // Return client
     return &Client{
         conn: conn,
         bc: bc,
         nc: nc,
+        vc: vc,
     }, nil
 
 }
@@ -99,6 +102,7 @@ func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
         GenesisForkVersion: genesisForkVersion,
         GenesisEpoch: genesisEpoch,
         GenesisTime: uint64(genesis.GenesisTime.Seconds),
+        SecondsPerSlot: secondsPerSlot,
         SecondsPerEpoch: secondsPerSlot * slotsPerEpoch,
     }, nil
 
@@ -152,6 +156,7 @@ func (c *Client) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.V
     if len(validators.ValidatorList) == 0 {
         return beacon.ValidatorStatus{}, nil
     }
+    validatorIndex := validators.ValidatorList[0].Index
     validator := validators.ValidatorList[0].Validator
 
     // Get validator balance
@@ -167,6 +172,7 @@ func (c *Client) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.V
     // Return response
     return beacon.ValidatorStatus{
         Pubkey: types.BytesToValidatorPubkey(validator.PublicKey),
+        Index: validatorIndex,
         WithdrawalCredentials: common.BytesToHash(validator.WithdrawalCredentials),
         Balance: validatorBalance,
         EffectiveBalance: validator.EffectiveBalance,
@@ -180,3 +186,187 @@ func (c *Client) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *beacon.V
 
 }
 
+
+// Get the node's connected peers
+func (c *Client) GetPeers() ([]beacon.Peer, error) {
+
+    // Get peers
+    peers, err := c.nc.ListPeers(context.Background(), &pbtypes.Empty{})
+    if err != nil {
+        return []beacon.Peer{}, fmt.Errorf("Could not get node peers: %w", err)
+    }
+
+    // Return response
+    response := make([]beacon.Peer, len(peers.Peers))
+    for pi, peer := range peers.Peers {
+        response[pi] = beacon.Peer{
+            ID: peer.PeerId,
+            Address: getMultiaddrHost(peer.Address),
+        }
+        switch peer.Direction {
+            case pb.PeerDirection_INBOUND: response[pi].Direction = beacon.PeerDirectionInbound
+            case pb.PeerDirection_OUTBOUND: response[pi].Direction = beacon.PeerDirectionOutbound
+        }
+    }
+    return response, nil
+
+}
+
+
+// Get the node's connected peer count
+// A peer count is not available separately via the prysm API, so the peer list is counted
+func (c *Client) GetPeerCount() (uint64, error) {
+    peers, err := c.nc.ListPeers(context.Background(), &pbtypes.Empty{})
+    if err != nil {
+        return 0, fmt.Errorf("Could not get node peer count: %w", err)
+    }
+    return uint64(len(peers.Peers)), nil
+}
+
+
+// Get the validator activation queue
+func (c *Client) GetValidatorQueue() (beacon.ValidatorQueue, error) {
+
+    // Get validator queue
+    queue, err := c.bc.GetValidatorQueue(context.Background(), &pbtypes.Empty{})
+    if err != nil {
+        return beacon.ValidatorQueue{}, fmt.Errorf("Could not get validator queue: %w", err)
+    }
+
+    // Return response
+    return beacon.ValidatorQueue{
+        ActivationQueueLength: uint64(len(queue.ActivationValidatorIndices)),
+        ChurnLimit: queue.ChurnLimit,
+    }, nil
+
+}
+
+
+// Get validators' attestation performance for an epoch
+// Votes are only available once the epoch has ended; validators missing from the response are omitted
+func (c *Client) GetValidatorPerformance(pubkeys []types.ValidatorPubkey, epoch uint64) ([]beacon.ValidatorPerformance, error) {
+
+    // Get individual votes
+    votes, err := c.bc.GetIndividualVotes(context.Background(), &pb.IndividualVotesRequest{
+        Epoch: epoch,
+        PublicKeys: getPubkeyBytes(pubkeys),
+    })
+    if err != nil {
+        return []beacon.ValidatorPerformance{}, fmt.Errorf("Could not get validator performance for epoch %d: %w", epoch, err)
+    }
+
+    // Return response
+    response := []beacon.ValidatorPerformance{}
+    for _, vote := range votes.IndividualVotes {
+        if len(vote.PublicKey) == 0 {
+            continue
+        }
+        response = append(response, beacon.ValidatorPerformance{
+            Pubkey: types.BytesToValidatorPubkey(vote.PublicKey),
+            Index: vote.ValidatorIndex,
+            Active: vote.IsActiveInPreviousEpoch,
+            Attested: vote.IsPreviousEpochAttester,
+            CorrectTarget: vote.IsPreviousEpochTargetAttester,
+            CorrectHead: vote.IsPreviousEpochHeadAttester,
+            InclusionDistance: vote.InclusionDistance,
+        })
+    }
+    return response, nil
+
+}
+
+
+// Get validators' attestation & proposal duties for an epoch
+func (c *Client) GetValidatorDuties(pubkeys []types.ValidatorPubkey, epoch uint64) ([]beacon.ValidatorDuties, error) {
+
+    // Build assignments request
+    request := &pb.ListValidatorAssignmentsRequest{
+        QueryFilter: &pb.ListValidatorAssignmentsRequest_Epoch{Epoch: epoch},
+        PublicKeys: getPubkeyBytes(pubkeys),
+    }
+
+    // Get assignments
+    response := []beacon.ValidatorDuties{}
+    for {
+        assignments, err := c.bc.ListValidatorAssignments(context.Background(), request)
+        if err != nil {
+            return []beacon.ValidatorDuties{}, fmt.Errorf("Could not get validator duties for epoch %d: %w", epoch, err)
+        }
+        for _, assignment := range assignments.Assignments {
+            response = append(response, beacon.ValidatorDuties{
+                Pubkey: types.BytesToValidatorPubkey(assignment.PublicKey),
+                Index: assignment.ValidatorIndex,
+                AttesterSlot: assignment.AttesterSlot,
+                ProposerSlots: assignment.ProposerSlots,
+            })
+        }
+        if assignments.NextPageToken == "" {
+            break
+        }
+        request.PageToken = assignments.NextPageToken
+    }
+
+    // Return
+    return response, nil
+
+}
+
+
+// Get the index of the validator which proposed the block at a slot; returns false if the slot was skipped
+func (c *Client) GetBlockProposer(slot uint64) (uint64, bool, error) {
+
+    // Get blocks
+    blocks, err := c.bc.ListBlocks(context.Background(), &pb.ListBlocksRequest{
+        QueryFilter: &pb.ListBlocksRequest_Slot{Slot: slot},
+    })
+    if err != nil {
+        return 0, false, fmt.Errorf("Could not get block at slot %d: %w", slot, err)
+    }
+
+    // Return
+    for _, container := range blocks.BlockContainers {
+        if container.Block != nil && container.Block.Block != nil && container.Block.Block.Slot == slot {
+            return container.Block.Block.ProposerIndex, true, nil
+        }
+    }
+    return 0, false, nil
+
+}
+
+
+// Get the signature domain for a domain type at an epoch
+func (c *Client) GetDomainData(domainType []byte, epoch uint64) ([]byte, error) {
+
+    // Get domain data
+    domain, err := c.vc.DomainData(context.Background(), &pb.DomainRequest{
+        Epoch: epoch,
+        Domain: domainType,
+    })
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not get domain data: %w", err)
+    }
+
+    // Return
+    return domain.SignatureDomain, nil
+
+}
+
+
+// Submit a signed voluntary exit for a validator
+func (c *Client) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+
+    // Propose exit
+    if _, err := c.vc.ProposeExit(context.Background(), &pb.SignedVoluntaryExit{
+        Exit: &pb.VoluntaryExit{
+            Epoch: epoch,
+            ValidatorIndex: validatorIndex,
+        },
+        Signature: signature.Bytes(),
+    }); err != nil {
+        return fmt.Errorf("Could not submit voluntary exit for validator %d: %w", validatorIndex, err)
+    }
+
+    // Return
+    return nil
+
+}
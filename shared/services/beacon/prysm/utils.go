@@ -6,6 +6,8 @@ import (
     "regexp"
     "strconv"
     "strings"
+
+    "github.com/rocket-pool/rocketpool-go/types"
 )
 
 
@@ -47,6 +49,20 @@ func getConfigString(cfg map[string]string, key string) (string, error) {
 }
 
 
+// Get the host address from a multiaddr string (e.g. /ip4/127.0.0.1/tcp/13000)
+func getMultiaddrHost(multiaddr string) string {
+    parts := strings.Split(multiaddr, "/")
+    if len(parts) < 3 {
+        return ""
+    }
+    switch parts[1] {
+        case "ip4", "ip6", "dns4", "dns6":
+            return parts[2]
+    }
+    return ""
+}
+
+
 // Deserialize a byte slice
 func deserializeBytes(value string) ([]byte, error) {
 
@@ -71,3 +87,13 @@ func deserializeBytes(value string) ([]byte, error) {
 
 }
 
+
+// Get validator pubkeys as byte slices
+func getPubkeyBytes(pubkeys []types.ValidatorPubkey) [][]byte {
+    pubkeyBytes := make([][]byte, len(pubkeys))
+    for pi, pubkey := range pubkeys {
+        pubkeyBytes[pi] = pubkey.Bytes()
+    }
+    return pubkeyBytes
+}
+
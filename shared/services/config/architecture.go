@@ -0,0 +1,93 @@
+package config
+
+import (
+    "fmt"
+)
+
+
+// Host architectures
+const (
+    ArchitectureAmd64 = "amd64"
+    ArchitectureArm64 = "arm64"
+)
+
+
+// The built-in preset whose settings are used as client param defaults on arm64, where the client does not define its own
+const Arm64Preset = "low-power"
+
+
+// Get an architecture from the machine hardware name reported by uname -m
+func ParseArchitecture(machine string) (string, error) {
+    switch machine {
+        case "x86_64", "amd64":
+            return ArchitectureAmd64, nil
+        case "aarch64", "arm64", "aarch64_be", "armv8b", "armv8l":
+            return ArchitectureArm64, nil
+    }
+    return "", fmt.Errorf("Unsupported architecture '%s': the smart node runs on amd64 (x86_64) and arm64 (aarch64) hosts", machine)
+}
+
+
+// Get a copy of a config with its clients' images & param defaults selected for an architecture
+func (config RocketPoolConfig) ForArchitecture(arch string) RocketPoolConfig {
+    config.Chains.Eth1 = config.Chains.Eth1.forArchitecture(arch, func(preset Preset) PresetChain { return preset.Eth1 })
+    config.Chains.Eth2 = config.Chains.Eth2.forArchitecture(arch, func(preset Preset) PresetChain { return preset.Eth2 })
+    return config
+}
+func (chain Chain) forArchitecture(arch string, getPresetChain func(Preset) PresetChain) Chain {
+    if arch != ArchitectureArm64 {
+        return chain
+    }
+    var presetChain PresetChain
+    if preset, ok := GetPreset(Arm64Preset); ok {
+        presetChain = getPresetChain(preset)
+    }
+    options := make([]ClientOption, len(chain.Client.Options))
+    for oi, option := range chain.Client.Options {
+        options[oi] = option.forArm64(&presetChain)
+    }
+    chain.Client.Options = options
+    return chain
+}
+func (client ClientOption) forArm64(presetChain *PresetChain) ClientOption {
+
+    // Select images; images without an arm64 variant are assumed to be multi-arch
+    if client.Arm64Image != "" {
+        client.Image = client.Arm64Image
+    }
+    if client.Arm64BeaconImage != "" {
+        client.BeaconImage = client.Arm64BeaconImage
+    }
+    if client.Arm64ValidatorImage != "" {
+        client.ValidatorImage = client.Arm64ValidatorImage
+    }
+
+    // Select param defaults
+    params := make([]ClientParam, len(client.Params))
+    for pi, param := range client.Params {
+        if param.Arm64Default != "" {
+            param.Default = param.Arm64Default
+        } else if value, ok := presetChain.GetParam(param.Env); ok {
+            param.Default = value
+        }
+        params[pi] = param
+    }
+    client.Params = params
+    return client
+
+}
+
+
+// Check whether a client supports an architecture; clients which do not list their architectures support all of them
+func (client *ClientOption) SupportsArchitecture(arch string) bool {
+    if len(client.Architectures) == 0 {
+        return true
+    }
+    for _, supported := range client.Architectures {
+        if supported == arch {
+            return true
+        }
+    }
+    return false
+}
+
@@ -3,30 +3,94 @@ package config
 import (
     "fmt"
     "io/ioutil"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "time"
 
     "github.com/imdario/mergo"
-    "github.com/urfave/cli"
     "gopkg.in/yaml.v2"
 )
 
 
+// Settings
+const (
+    DefaultDoppelgangerDelayEpochs = 3
+    DefaultBackupInterval = "24h"
+    DefaultBackupRetention = 7
+    DefaultGasToken = "ETH"
+    DefaultMinClaimGasRatio = 1
+    DefaultContractCacheTTL = "10m"
+    DefaultWatchtowerLowBalanceAlert = 0.5
+    DefaultAlertDiskUsagePercent = 90
+    DefaultAlertDiskFullDays = 14
+    DefaultEth1P2PPort = 30303
+    DefaultEth2P2PPort = 9000
+)
+
+
+// Default p2p ports by client
+var clientP2PPorts = map[string]int{
+    "geth": 30303,
+    "lighthouse": 9000,
+    "prysm": 13000,
+}
+
+
 // Rocket Pool config
 type RocketPoolConfig struct {
+    Version int                         `yaml:"version,omitempty"`
     Rocketpool struct {
         StorageAddress string           `yaml:"storageAddress,omitempty"`
+        GasToken string                 `yaml:"gasToken,omitempty"`
+        MulticallAddress string         `yaml:"multicallAddress,omitempty"`
+        CacheTTL string                 `yaml:"cacheTtl,omitempty"`
     }                                   `yaml:"rocketpool,omitempty"`
     Smartnode struct {
         PasswordPath string             `yaml:"passwordPath,omitempty"`
+        PasswordSource PasswordSource   `yaml:"passwordSource,omitempty"`
         WalletPath string               `yaml:"walletPath,omitempty"`
         ValidatorKeychainPath string    `yaml:"validatorKeychainPath,omitempty"`
+        DataPath string                 `yaml:"dataPath,omitempty"`
+        DoppelgangerProtection bool     `yaml:"doppelgangerProtection,omitempty"`
+        DoppelgangerDelayEpochs uint64  `yaml:"doppelgangerDelayEpochs,omitempty"`
+        AlertWebhookURL string          `yaml:"alertWebhookUrl,omitempty"`
+        AutoWithdrawDisabled bool       `yaml:"autoWithdrawDisabled,omitempty"`
+        AutoWithdrawMaxGasPrice float64 `yaml:"autoWithdrawMaxGasPrice,omitempty"`
+        MinClaimGasRatio float64        `yaml:"minClaimGasRatio,omitempty"`
+        MaxFee float64                  `yaml:"maxFee,omitempty"`
+        PriorityFee float64             `yaml:"priorityFee,omitempty"`
+        DockerSocketDisabled bool       `yaml:"dockerSocketDisabled,omitempty"`
+        ContainerRuntime string         `yaml:"containerRuntime,omitempty"`
+        ServiceBackend string           `yaml:"serviceBackend,omitempty"`
+        Architecture string             `yaml:"architecture,omitempty"`
+        MetricsAddress string           `yaml:"metricsAddress,omitempty"`
+        NodeAddress string              `yaml:"nodeAddress,omitempty"`
+        Offline bool                    `yaml:"-"`
+        DryRun bool                     `yaml:"-"`
     }                                   `yaml:"smartnode,omitempty"`
     Chains struct {
         Eth1 Chain                      `yaml:"eth1,omitempty"`
         Eth2 Chain                      `yaml:"eth2,omitempty"`
     }                                   `yaml:"chains,omitempty"`
+    Backup Backup                       `yaml:"backup,omitempty"`
+    Log Log                             `yaml:"log,omitempty"`
+    Watchtower Watchtower               `yaml:"watchtower,omitempty"`
+    Alerts Alerts                       `yaml:"alerts,omitempty"`
+    RemoteSigner RemoteSigner           `yaml:"remoteSigner,omitempty"`
+    PortMapping PortMapping             `yaml:"portMapping,omitempty"`
+    Tasks map[string]Task               `yaml:"tasks,omitempty"`
 }
 type Chain struct {
     Provider string                     `yaml:"provider,omitempty"`
+    AnalyticsProvider string            `yaml:"analyticsProvider,omitempty"`
+    External bool                       `yaml:"external,omitempty"`
+    DataPath string                     `yaml:"dataPath,omitempty"`
+    P2PPort int                         `yaml:"p2pPort,omitempty"`
+    RPCConnections int                  `yaml:"rpcConnections,omitempty"`
+    RPCMaxInFlight int                  `yaml:"rpcMaxInFlight,omitempty"`
+    StaticPeers []string                `yaml:"staticPeers,omitempty"`
+    Bootnodes []string                  `yaml:"bootnodes,omitempty"`
     Client struct {
         Options []ClientOption          `yaml:"options,omitempty"`
         Selected string                 `yaml:"selected,omitempty"`
@@ -39,13 +103,86 @@ type ClientOption struct {
     Image string                        `yaml:"image,omitempty"`
     BeaconImage string                  `yaml:"beaconImage,omitempty"`
     ValidatorImage string               `yaml:"validatorImage,omitempty"`
+    Arm64Image string                   `yaml:"arm64Image,omitempty"`
+    Arm64BeaconImage string             `yaml:"arm64BeaconImage,omitempty"`
+    Arm64ValidatorImage string          `yaml:"arm64ValidatorImage,omitempty"`
+    Architectures []string              `yaml:"architectures,omitempty"`
     Params []ClientParam                `yaml:"params,omitempty"`
 }
 type ClientParam struct {
     Name string                         `yaml:"name,omitempty"`
     Env string                          `yaml:"env,omitempty"`
+    Description string                  `yaml:"description,omitempty"`
+    Type string                         `yaml:"type,omitempty"`
+    Default string                      `yaml:"default,omitempty"`
+    Arm64Default string                 `yaml:"arm64Default,omitempty"`
+    Options []string                    `yaml:"options,omitempty"`
+    Min *int64                          `yaml:"min,omitempty"`
+    Max *int64                          `yaml:"max,omitempty"`
     Required bool                       `yaml:"required,omitempty"`
     Regex string                        `yaml:"regex,omitempty"`
+    regex *regexp.Regexp
+}
+type Backup struct {
+    Destination string                  `yaml:"destination,omitempty"`
+    Path string                         `yaml:"path,omitempty"`
+    Interval string                     `yaml:"interval,omitempty"`
+    Retention int                       `yaml:"retention,omitempty"`
+    PassphrasePath string               `yaml:"passphrasePath,omitempty"`
+    S3 struct {
+        Endpoint string                 `yaml:"endpoint,omitempty"`
+        Region string                   `yaml:"region,omitempty"`
+        Bucket string                   `yaml:"bucket,omitempty"`
+        AccessKey string                `yaml:"accessKey,omitempty"`
+        SecretKey string                `yaml:"secretKey,omitempty"`
+    }                                   `yaml:"s3,omitempty"`
+    SFTP struct {
+        Host string                     `yaml:"host,omitempty"`
+        User string                     `yaml:"user,omitempty"`
+        KeyPath string                  `yaml:"keyPath,omitempty"`
+        KnownHostsPath string           `yaml:"knownHostsPath,omitempty"`
+    }                                   `yaml:"sftp,omitempty"`
+    Rsync struct {
+        Target string                   `yaml:"target,omitempty"`
+        SSHKeyPath string               `yaml:"sshKeyPath,omitempty"`
+    }                                   `yaml:"rsync,omitempty"`
+}
+type PasswordSource struct {
+    Type string                         `yaml:"type,omitempty"`
+    Path string                         `yaml:"path,omitempty"`
+    Env string                          `yaml:"env,omitempty"`
+    Command string                      `yaml:"command,omitempty"`
+    Service string                      `yaml:"service,omitempty"`
+    Account string                      `yaml:"account,omitempty"`
+}
+type Log struct {
+    Level string                        `yaml:"level,omitempty"`
+    Format string                       `yaml:"format,omitempty"`
+    Path string                         `yaml:"path,omitempty"`
+    MaxSizeMB int64                     `yaml:"maxSizeMb,omitempty"`
+    MaxBackups int                      `yaml:"maxBackups,omitempty"`
+}
+type Watchtower struct {
+    SubmissionDelay string              `yaml:"submissionDelay,omitempty"`
+    MetricsAddress string               `yaml:"metricsAddress,omitempty"`
+    LowBalanceAlert float64             `yaml:"lowBalanceAlert,omitempty"`
+}
+type Alerts struct {
+    DiskUsagePercent float64            `yaml:"diskUsagePercent,omitempty"`
+    DiskFullDays float64                `yaml:"diskFullDays,omitempty"`
+}
+type RemoteSigner struct {
+    URL string                          `yaml:"url,omitempty"`
+}
+type PortMapping struct {
+    Method string                       `yaml:"method,omitempty"`
+    Host string                         `yaml:"host,omitempty"`
+}
+type Task struct {
+    Interval string                     `yaml:"interval,omitempty"`
+    ActiveInterval string               `yaml:"activeInterval,omitempty"`
+    Disabled bool                       `yaml:"disabled,omitempty"`
+    Rehearsal bool                      `yaml:"rehearsal,omitempty"`
 }
 type UserParam struct {
     Env string                          `yaml:"env,omitempty"`
@@ -87,6 +224,211 @@ func (client *ClientOption) GetValidatorImage() string {
 }
 
 
+// Get the ports the clients listen for p2p connections on; defaults to the selected client's default port if not set
+func (config *RocketPoolConfig) GetEth1P2PPort() int {
+    if config.Chains.Eth1.P2PPort > 0 {
+        return config.Chains.Eth1.P2PPort
+    }
+    return GetDefaultP2PPort(config.Chains.Eth1.Client.Selected, DefaultEth1P2PPort)
+}
+func (config *RocketPoolConfig) GetEth2P2PPort() int {
+    if config.Chains.Eth2.P2PPort > 0 {
+        return config.Chains.Eth2.P2PPort
+    }
+    return GetDefaultP2PPort(config.Chains.Eth2.Client.Selected, DefaultEth2P2PPort)
+}
+func GetDefaultP2PPort(clientId string, fallback int) int {
+    if port, ok := clientP2PPorts[clientId]; ok {
+        return port
+    }
+    return fallback
+}
+
+
+// Get the symbol of the network's gas token; defaults to ETH if not set
+func (config *RocketPoolConfig) GetGasToken() string {
+    if config.Rocketpool.GasToken != "" {
+        return config.Rocketpool.GasToken
+    } else {
+        return DefaultGasToken
+    }
+}
+
+
+// Get the minimum ratio of a rewards claim's value to its gas cost
+func (config *RocketPoolConfig) GetMinClaimGasRatio() float64 {
+    if config.Smartnode.MinClaimGasRatio > 0 {
+        return config.Smartnode.MinClaimGasRatio
+    } else {
+        return DefaultMinClaimGasRatio
+    }
+}
+
+
+// Get the smartnode data path; defaults to the wallet folder if not set
+func (config *RocketPoolConfig) GetDataPath() string {
+    if config.Smartnode.DataPath != "" {
+        return config.Smartnode.DataPath
+    } else {
+        return filepath.Dir(config.Smartnode.WalletPath)
+    }
+}
+
+
+// Check whether service actions requested by the node daemon are queued for the host-side agent
+// The daemon cannot control the service directly if the docker socket is disabled, or if the service runs as systemd units
+func (config *RocketPoolConfig) UseServiceAgent() bool {
+    return config.Smartnode.DockerSocketDisabled || config.Smartnode.ServiceBackend == "systemd"
+}
+
+
+// Get the number of epochs to delay validator startup by after a key import or recovery
+func (config *RocketPoolConfig) GetDoppelgangerDelayEpochs() uint64 {
+    if config.Smartnode.DoppelgangerDelayEpochs > 0 {
+        return config.Smartnode.DoppelgangerDelayEpochs
+    } else {
+        return DefaultDoppelgangerDelayEpochs
+    }
+}
+
+
+// Get the interval between automatic backups
+func (config *RocketPoolConfig) GetBackupInterval() (time.Duration, error) {
+    interval := config.Backup.Interval
+    if interval == "" {
+        interval = DefaultBackupInterval
+    }
+    duration, err := time.ParseDuration(interval)
+    if err != nil {
+        return 0, fmt.Errorf("Invalid backup interval '%s': %w", interval, err)
+    }
+    return duration, nil
+}
+
+
+// Get the number of backups to retain at the backup destination
+func (config *RocketPoolConfig) GetBackupRetention() int {
+    if config.Backup.Retention > 0 {
+        return config.Backup.Retention
+    } else {
+        return DefaultBackupRetention
+    }
+}
+
+
+// Get the time the watchtower waits for other trusted nodes to reach consensus before submitting; defaults to no delay
+func (config *RocketPoolConfig) GetWatchtowerSubmissionDelay() (time.Duration, error) {
+    if config.Watchtower.SubmissionDelay == "" {
+        return 0, nil
+    }
+    delay, err := time.ParseDuration(config.Watchtower.SubmissionDelay)
+    if err != nil {
+        return 0, fmt.Errorf("Invalid watchtower submission delay '%s': %w", config.Watchtower.SubmissionDelay, err)
+    }
+    if delay < 0 {
+        return 0, fmt.Errorf("Invalid watchtower submission delay '%s': must not be negative", config.Watchtower.SubmissionDelay)
+    }
+    return delay, nil
+}
+
+
+// Get the node ETH balance below which the watchtower alerts that it may not be able to pay for submissions
+func (config *RocketPoolConfig) GetWatchtowerLowBalanceAlert() float64 {
+    if config.Watchtower.LowBalanceAlert > 0 {
+        return config.Watchtower.LowBalanceAlert
+    }
+    return DefaultWatchtowerLowBalanceAlert
+}
+
+
+// Get the percentage of a disk's space in use above which alerts are raised
+func (config *RocketPoolConfig) GetAlertDiskUsagePercent() float64 {
+    if config.Alerts.DiskUsagePercent > 0 {
+        return config.Alerts.DiskUsagePercent
+    }
+    return DefaultAlertDiskUsagePercent
+}
+
+
+// Get the number of days until the disk is predicted to be full below which alerts are raised
+func (config *RocketPoolConfig) GetAlertDiskFullDays() float64 {
+    if config.Alerts.DiskFullDays > 0 {
+        return config.Alerts.DiskFullDays
+    }
+    return DefaultAlertDiskFullDays
+}
+
+
+// Get the time contract addresses, ABIs and network settings are cached for
+func (config *RocketPoolConfig) GetContractCacheTTL() (time.Duration, error) {
+    ttl := config.Rocketpool.CacheTTL
+    if ttl == "" {
+        ttl = DefaultContractCacheTTL
+    }
+    duration, err := time.ParseDuration(ttl)
+    if err != nil {
+        return 0, fmt.Errorf("Invalid contract cache TTL '%s': %w", ttl, err)
+    }
+    if duration < 0 {
+        return 0, fmt.Errorf("Invalid contract cache TTL '%s': must not be negative", ttl)
+    }
+    return duration, nil
+}
+
+
+// Validate the settings which are parsed at runtime, so that broken configs are rejected before they are applied
+func (config *RocketPoolConfig) Validate() error {
+    if _, err := config.GetBackupInterval(); err != nil {
+        return err
+    }
+    if _, err := config.GetWatchtowerSubmissionDelay(); err != nil {
+        return err
+    }
+    if _, err := config.GetContractCacheTTL(); err != nil {
+        return err
+    }
+    if config.Watchtower.LowBalanceAlert < 0 {
+        return fmt.Errorf("Invalid watchtower low balance alert '%g': must not be negative", config.Watchtower.LowBalanceAlert)
+    }
+    if config.Alerts.DiskUsagePercent < 0 || config.Alerts.DiskUsagePercent > 100 {
+        return fmt.Errorf("Invalid disk usage alert '%g': must be a percentage from 0 to 100", config.Alerts.DiskUsagePercent)
+    }
+    if config.Alerts.DiskFullDays < 0 {
+        return fmt.Errorf("Invalid disk full alert '%g': must not be negative", config.Alerts.DiskFullDays)
+    }
+    switch config.Smartnode.ContainerRuntime {
+        case "", "docker", "podman":
+        default: return fmt.Errorf("Unknown container runtime '%s'", config.Smartnode.ContainerRuntime)
+    }
+    switch config.Smartnode.ServiceBackend {
+        case "", "compose", "systemd":
+        default: return fmt.Errorf("Unknown service backend '%s'", config.Smartnode.ServiceBackend)
+    }
+    switch config.Smartnode.Architecture {
+        case "", ArchitectureAmd64, ArchitectureArm64:
+        default: return fmt.Errorf("Unknown architecture '%s'", config.Smartnode.Architecture)
+    }
+    for _, chain := range []Chain{config.Chains.Eth1, config.Chains.Eth2} {
+        if chain.DataPath != "" && !filepath.IsAbs(chain.DataPath) {
+            return fmt.Errorf("Invalid chain data path '%s': must be an absolute path", chain.DataPath)
+        }
+    }
+    switch config.Log.Format {
+        case "", "text", "json":
+        default: return fmt.Errorf("Unknown log format '%s'", config.Log.Format)
+    }
+    for name, task := range config.Tasks {
+        for _, interval := range []string{task.Interval, task.ActiveInterval} {
+            if interval == "" { continue }
+            if duration, err := time.ParseDuration(interval); err != nil || duration <= 0 {
+                return fmt.Errorf("Invalid %s task interval '%s': must be a positive duration", name, interval)
+            }
+        }
+    }
+    return nil
+}
+
+
 // Serialize a config to yaml bytes
 func (config *RocketPoolConfig) Serialize() ([]byte, error) {
     bytes, err := yaml.Marshal(config)
@@ -97,12 +439,19 @@ func (config *RocketPoolConfig) Serialize() ([]byte, error) {
 }
 
 
-// Parse a config from yaml bytes
+// Parse a config from yaml bytes, migrating it to the current layout version
 func Parse(bytes []byte) (RocketPoolConfig, error) {
+    migration, err := Migrate(bytes)
+    if err != nil {
+        return RocketPoolConfig{}, err
+    }
     var config RocketPoolConfig
-    if err := yaml.Unmarshal(bytes, &config); err != nil {
+    if err := yaml.Unmarshal(migration.Bytes, &config); err != nil {
         return RocketPoolConfig{}, fmt.Errorf("Could not parse config: %w", err)
     }
+    if err := config.compileParamRegexes(); err != nil {
+        return RocketPoolConfig{}, err
+    }
     return config, nil
 }
 
@@ -117,28 +466,40 @@ func Merge(configs ...*RocketPoolConfig) RocketPoolConfig {
 }
 
 
-// Load merged config from files
-func Load(c *cli.Context) (RocketPoolConfig, error) {
+// Load merged config from files, with environment variable & option overrides applied
+func Load(c Context) (RocketPoolConfig, error) {
 
     // Load configs
-    globalConfig, err := loadFile(c.GlobalString("config"), true)
+    globalConfig, err := loadFile(c.GlobalString("config"), true, nil)
     if err != nil {
         return RocketPoolConfig{}, err
     }
-    userConfig, err := loadFile(c.GlobalString("settings"), false)
+    userConfig, err := loadFile(c.GlobalString("settings"), false, &globalConfig)
     if err != nil {
         return RocketPoolConfig{}, err
     }
     cliConfig := getCliConfig(c)
 
-    // Merge and return
-    return Merge(&globalConfig, &userConfig, &cliConfig), nil
+    // Merge configs
+    config := Merge(&globalConfig, &userConfig, &cliConfig)
+
+    // Apply overrides
+    overrides, err := getProcessOverrides(c)
+    if err != nil {
+        return RocketPoolConfig{}, err
+    }
+    if err := ApplyOverrides(&config, overrides); err != nil {
+        return RocketPoolConfig{}, err
+    }
+
+    // Return
+    return config, nil
 
 }
 
 
-// Load config from a file
-func loadFile(path string, required bool) (RocketPoolConfig, error) {
+// Load config from a file, validating it against the config schema
+func loadFile(path string, required bool, base *RocketPoolConfig) (RocketPoolConfig, error) {
 
     // Read file; squelch not found errors if file is optional
     bytes, err := ioutil.ReadFile(path)
@@ -150,11 +511,25 @@ func loadFile(path string, required bool) (RocketPoolConfig, error) {
         }
     }
 
+    // Migrate config; migrated files are saved by the CLI, which keeps a backup of the original
+    migration, err := Migrate(bytes)
+    if err != nil {
+        return RocketPoolConfig{}, fmt.Errorf("Could not migrate config file at %s: %w", path, err)
+    }
+
+    // Validate config
+    if err := ValidateFile(path, migration.Bytes, base); err != nil {
+        return RocketPoolConfig{}, err
+    }
+
     // Parse config
     var config RocketPoolConfig
-    if err := yaml.Unmarshal(bytes, &config); err != nil {
+    if err := yaml.Unmarshal(migration.Bytes, &config); err != nil {
         return RocketPoolConfig{}, fmt.Errorf("Could not parse config file at %s: %w", path, err)
     }
+    if err := config.compileParamRegexes(); err != nil {
+        return RocketPoolConfig{}, fmt.Errorf("Invalid config file at %s: %w", path, err)
+    }
 
     // Return
     return config, nil
@@ -163,14 +538,24 @@ func loadFile(path string, required bool) (RocketPoolConfig, error) {
 
 
 // Create config from CLI arguments
-func getCliConfig(c *cli.Context) RocketPoolConfig {
+func getCliConfig(c Context) RocketPoolConfig {
     var config RocketPoolConfig
     config.Rocketpool.StorageAddress = c.GlobalString("storageAddress")
     config.Smartnode.PasswordPath = c.GlobalString("password")
     config.Smartnode.WalletPath = c.GlobalString("wallet")
     config.Smartnode.ValidatorKeychainPath = c.GlobalString("validatorKeychain")
+    config.Smartnode.DataPath = c.GlobalString("data")
     config.Chains.Eth1.Provider = c.GlobalString("eth1Provider")
+    config.Chains.Eth1.AnalyticsProvider = c.GlobalString("eth1AnalyticsProvider")
     config.Chains.Eth2.Provider = c.GlobalString("eth2Provider")
+    if maxFee, err := strconv.ParseFloat(c.GlobalString("maxFee"), 64); err == nil {
+        config.Smartnode.MaxFee = maxFee
+    }
+    if priorityFee, err := strconv.ParseFloat(c.GlobalString("priorityFee"), 64); err == nil {
+        config.Smartnode.PriorityFee = priorityFee
+    }
+    config.Smartnode.Offline = (c.GlobalString("offline") == "true")
+    config.Smartnode.DryRun = (c.GlobalString("dryRun") == "true")
     return config
 }
 
@@ -0,0 +1,64 @@
+package config
+
+import (
+    "strconv"
+)
+
+
+// Source of global config options
+// Satisfied by *cli.Context, so services can be loaded from the CLI or directly from Go code
+type Context interface {
+    GlobalString(name string) string
+    GlobalStringSlice(name string) []string
+}
+
+
+// Global config options for loading services without a CLI context
+type Options struct {
+    ConfigPath string
+    SettingsPath string
+    StorageAddress string
+    PasswordPath string
+    WalletPath string
+    ValidatorKeychainPath string
+    DataPath string
+    Eth1Provider string
+    Eth1AnalyticsProvider string
+    Eth2Provider string
+    MaxFee float64
+    PriorityFee float64
+    Offline bool
+    DryRun bool
+    Overrides []string
+}
+
+
+// Get a global option value by its CLI flag name
+func (o Options) GlobalString(name string) string {
+    switch name {
+        case "config": return o.ConfigPath
+        case "settings": return o.SettingsPath
+        case "storageAddress": return o.StorageAddress
+        case "password": return o.PasswordPath
+        case "wallet": return o.WalletPath
+        case "validatorKeychain": return o.ValidatorKeychainPath
+        case "data": return o.DataPath
+        case "eth1Provider": return o.Eth1Provider
+        case "eth1AnalyticsProvider": return o.Eth1AnalyticsProvider
+        case "eth2Provider": return o.Eth2Provider
+        case "maxFee": if o.MaxFee > 0 { return strconv.FormatFloat(o.MaxFee, 'f', -1, 64) }
+        case "priorityFee": if o.PriorityFee > 0 { return strconv.FormatFloat(o.PriorityFee, 'f', -1, 64) }
+        case "offline": if o.Offline { return "true" }
+        case "dryRun": if o.DryRun { return "true" }
+    }
+    return ""
+}
+
+
+// Get a global option list by its CLI flag name
+func (o Options) GlobalStringSlice(name string) []string {
+    switch name {
+        case "set": return o.Overrides
+    }
+    return nil
+}
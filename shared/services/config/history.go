@@ -0,0 +1,132 @@
+package config
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+
+    "gopkg.in/yaml.v2"
+)
+
+
+// Settings
+const MaxConfigRevisions = 100
+
+
+// A recorded revision of the user config
+type Revision struct {
+    Number int                  `json:"number"`
+    Time time.Time              `json:"time"`
+    User string                 `json:"user"`
+    Description string          `json:"description"`
+    Settings string             `json:"settings"`
+    Changes []SettingChange     `json:"changes"`
+}
+
+
+// A change to a single setting between config revisions
+type SettingChange struct {
+    Setting string              `json:"setting"`
+    Old string                  `json:"old"`
+    New string                  `json:"new"`
+}
+
+
+// Get the changes between two configs, by setting path (e.g. smartnode.maxFee)
+// Values of secret settings are masked
+func Diff(oldConfig, newConfig *RocketPoolConfig) ([]SettingChange, error) {
+
+    // Flatten configs
+    oldSettings, err := flatten(oldConfig)
+    if err != nil {
+        return []SettingChange{}, err
+    }
+    newSettings, err := flatten(newConfig)
+    if err != nil {
+        return []SettingChange{}, err
+    }
+
+    // Get changed setting paths
+    paths := []string{}
+    for path, value := range oldSettings {
+        if newValue, ok := newSettings[path]; !ok || newValue != value {
+            paths = append(paths, path)
+        }
+    }
+    for path := range newSettings {
+        if _, ok := oldSettings[path]; !ok {
+            paths = append(paths, path)
+        }
+    }
+    sort.Strings(paths)
+
+    // Build changes
+    changes := make([]SettingChange, len(paths))
+    for pi, path := range paths {
+        changes[pi] = SettingChange{
+            Setting: path,
+            Old: maskSecret(path, oldSettings[path]),
+            New: maskSecret(path, newSettings[path]),
+        }
+    }
+    return changes, nil
+
+}
+
+
+// Get a config's settings by setting path, with the values of secret settings masked
+func GetSettings(config *RocketPoolConfig) (map[string]string, error) {
+    settings, err := flatten(config)
+    if err != nil {
+        return nil, err
+    }
+    for path, value := range settings {
+        settings[path] = maskSecret(path, value)
+    }
+    return settings, nil
+}
+
+
+// Flatten a config to a map of setting paths to values
+func flatten(config *RocketPoolConfig) (map[string]string, error) {
+    configBytes, err := config.Serialize()
+    if err != nil {
+        return nil, err
+    }
+    var tree interface{}
+    if err := yaml.Unmarshal(configBytes, &tree); err != nil {
+        return nil, fmt.Errorf("Could not parse config: %w", err)
+    }
+    settings := make(map[string]string)
+    flattenValue("", tree, settings)
+    return settings, nil
+}
+func flattenValue(path string, value interface{}, settings map[string]string) {
+    switch v := value.(type) {
+        case map[interface{}]interface{}:
+            for key, child := range v {
+                childPath := fmt.Sprint(key)
+                if path != "" {
+                    childPath = path + "." + childPath
+                }
+                flattenValue(childPath, child, settings)
+            }
+        case []interface{}:
+            for ci, child := range v {
+                flattenValue(fmt.Sprintf("%s[%d]", path, ci), child, settings)
+            }
+        case nil:
+        default:
+            settings[path] = fmt.Sprint(v)
+    }
+}
+
+
+// Mask the value of a secret setting
+func maskSecret(path, value string) string {
+    if value != "" && strings.Contains(strings.ToLower(path), "secret") {
+        return "********"
+    }
+    return value
+}
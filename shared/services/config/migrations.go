@@ -0,0 +1,108 @@
+package config
+
+import (
+    "fmt"
+
+    "gopkg.in/yaml.v2"
+)
+
+
+// The current config layout version
+// Increment this and add a migration whenever a change to RocketPoolConfig moves or renames existing settings
+const ConfigVersion = 1
+
+
+// A config layout migration, applied to configs with an older version
+// Migrations edit the raw yaml document, so they can read settings which no longer exist in RocketPoolConfig, and return whether they changed it
+type migration struct {
+    version int
+    description string
+    migrate func(document map[interface{}]interface{}) (bool, error)
+}
+
+
+// Config migrations, in version order
+var migrations = []migration{
+    migration{
+        version: 1,
+        description: "Record the config layout version",
+        migrate: func(document map[interface{}]interface{}) (bool, error) { return false, nil },
+    },
+}
+
+
+// The result of migrating a config
+// Bytes are the original config unless a migration changed its settings
+type MigrationResult struct {
+    Bytes []byte
+    FromVersion int
+    Applied []string
+    Changed bool
+}
+
+
+// Migrate a config to the current layout version
+// Configs which are already current, empty, or unchanged by the migrations are returned as-is; the version is recorded when they are next saved
+// Settings which the migrated layout does not recognize are rejected rather than dropped
+func Migrate(bytes []byte) (MigrationResult, error) {
+
+    // Parse document
+    document := map[interface{}]interface{}{}
+    if err := yaml.Unmarshal(bytes, &document); err != nil {
+        return MigrationResult{}, fmt.Errorf("Could not parse config: %w", err)
+    }
+    result := MigrationResult{Bytes: bytes}
+    if len(document) == 0 {
+        return result, nil
+    }
+
+    // Get version
+    if version, ok := document["version"]; ok {
+        versionNumber, ok := version.(int)
+        if !ok {
+            return MigrationResult{}, fmt.Errorf("Invalid config version '%v'", version)
+        }
+        result.FromVersion = versionNumber
+    }
+    if result.FromVersion > ConfigVersion {
+        return MigrationResult{}, fmt.Errorf("The config was written by a newer smart node version (config version %d, supported version %d); please upgrade the smart node", result.FromVersion, ConfigVersion)
+    }
+    if result.FromVersion == ConfigVersion {
+        return result, nil
+    }
+
+    // Apply migrations
+    for _, m := range migrations {
+        if m.version <= result.FromVersion {
+            continue
+        }
+        changed, err := m.migrate(document)
+        if err != nil {
+            return MigrationResult{}, fmt.Errorf("Could not migrate config to version %d (%s): %w", m.version, m.description, err)
+        }
+        document["version"] = m.version
+        result.Applied = append(result.Applied, fmt.Sprintf("%d: %s", m.version, m.description))
+        result.Changed = result.Changed || changed
+    }
+    if !result.Changed {
+        return result, nil
+    }
+
+    // Encode migrated document
+    migratedBytes, err := yaml.Marshal(document)
+    if err != nil {
+        return MigrationResult{}, fmt.Errorf("Could not encode migrated config: %w", err)
+    }
+
+    // Check that every migrated setting is recognized, so none are dropped when the config is next saved
+    var config RocketPoolConfig
+    if err := yaml.UnmarshalStrict(migratedBytes, &config); err != nil {
+        return MigrationResult{}, fmt.Errorf("The config from version %d could not be migrated without losing settings: %w", result.FromVersion, err)
+    }
+
+    // Return
+    result.Bytes = migratedBytes
+    return result, nil
+
+}
+
@@ -0,0 +1,184 @@
+package config
+
+import (
+    "fmt"
+    "os"
+    "reflect"
+    "sort"
+    "strings"
+
+    "gopkg.in/yaml.v2"
+)
+
+
+// Config
+const OverrideEnvPrefix = "RP_"
+const overrideEnvSeparator = "__"
+
+
+// A config value override
+type Override struct {
+    Source string
+    Path []string
+    Value string
+}
+
+
+// Get config overrides from RP_-prefixed environment variables and --set key=value options, in order of precedence
+// Environment variables name a setting by its path in upper snake case, with sections separated by double underscores (e.g. RP_SMARTNODE__MAX_FEE)
+// Options name a setting by its dotted path (e.g. smartnode.maxFee)
+func GetOverrides(environ []string, sets []string) ([]Override, error) {
+    overrides := []Override{}
+
+    // Environment variables
+    envOverrides := []Override{}
+    for _, variable := range environ {
+        if !strings.HasPrefix(variable, OverrideEnvPrefix) {
+            continue
+        }
+        parts := strings.SplitN(variable, "=", 2)
+        if len(parts) != 2 {
+            continue
+        }
+        segments := strings.Split(strings.TrimPrefix(parts[0], OverrideEnvPrefix), overrideEnvSeparator)
+        envOverrides = append(envOverrides, Override{Source: parts[0], Path: segments, Value: parts[1]})
+    }
+    sort.Slice(envOverrides, func(i, j int) bool { return envOverrides[i].Source < envOverrides[j].Source })
+    overrides = append(overrides, envOverrides...)
+
+    // Options
+    for _, set := range sets {
+        parts := strings.SplitN(set, "=", 2)
+        if len(parts) != 2 || parts[0] == "" {
+            return nil, fmt.Errorf("Invalid config override '%s' - must be in the format key=value", set)
+        }
+        overrides = append(overrides, Override{Source: "--set " + parts[0], Path: strings.Split(parts[0], "."), Value: parts[1]})
+    }
+
+    // Return
+    return overrides, nil
+
+}
+
+
+// Apply config overrides to a config
+// Each override is checked against the config layout, so unknown settings and invalid values are reported rather than ignored
+func ApplyOverrides(config *RocketPoolConfig, overrides []Override) error {
+    if len(overrides) == 0 {
+        return nil
+    }
+
+    // Get config document
+    configBytes, err := config.Serialize()
+    if err != nil {
+        return err
+    }
+    document := map[interface{}]interface{}{}
+    if err := yaml.Unmarshal(configBytes, &document); err != nil {
+        return fmt.Errorf("Could not parse config: %w", err)
+    }
+
+    // Apply overrides
+    var overridden RocketPoolConfig
+    for _, override := range overrides {
+
+        // Resolve setting
+        keys, settingType, err := resolveSetting(reflect.TypeOf(RocketPoolConfig{}), override.Path)
+        if err != nil {
+            return fmt.Errorf("Invalid config override %s: %w", override.Source, err)
+        }
+
+        // Get value; strings are used as-is, other settings are parsed as yaml
+        var value interface{} = override.Value
+        if settingType.Kind() != reflect.String {
+            if err := yaml.Unmarshal([]byte(override.Value), &value); err != nil {
+                return fmt.Errorf("Invalid config override %s: could not parse value '%s': %w", override.Source, override.Value, err)
+            }
+        }
+
+        // Set value
+        setDocumentValue(document, keys, value)
+
+        // Check config
+        overriddenBytes, err := yaml.Marshal(document)
+        if err != nil {
+            return fmt.Errorf("Could not encode overridden config: %w", err)
+        }
+        overridden = RocketPoolConfig{}
+        if err := yaml.UnmarshalStrict(overriddenBytes, &overridden); err != nil {
+            return fmt.Errorf("Invalid config override %s: invalid value '%s' for setting '%s'", override.Source, override.Value, strings.Join(keys, "."))
+        }
+
+    }
+
+    // Update config & return
+    *config = overridden
+    return nil
+
+}
+
+
+// Resolve a setting path to its yaml keys and type
+// Struct fields are matched by yaml key, ignoring case, underscores and dashes; map keys are lower-cased with underscores replaced by dashes
+func resolveSetting(t reflect.Type, path []string) ([]string, reflect.Type, error) {
+    keys := []string{}
+    for si, segment := range path {
+        if segment == "" {
+            return nil, nil, fmt.Errorf("Empty setting name in '%s'", strings.Join(path, "."))
+        }
+        switch t.Kind() {
+            case reflect.Struct:
+                field, key, ok := findYamlField(t, segment)
+                if !ok {
+                    return nil, nil, fmt.Errorf("Unknown setting '%s'", strings.Join(path[:si+1], "."))
+                }
+                keys = append(keys, key)
+                t = field.Type
+            case reflect.Map:
+                keys = append(keys, strings.ReplaceAll(strings.ToLower(segment), "_", "-"))
+                t = t.Elem()
+            default:
+                return nil, nil, fmt.Errorf("Setting '%s' has no setting '%s'; set the whole value instead", strings.Join(path[:si], "."), segment)
+        }
+    }
+    return keys, t, nil
+}
+
+
+// Find a struct field by its yaml key
+func findYamlField(t reflect.Type, name string) (reflect.StructField, string, bool) {
+    for fi := 0; fi < t.NumField(); fi++ {
+        field := t.Field(fi)
+        key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+        if key == "" {
+            key = strings.ToLower(field.Name)
+        }
+        if normalizeSettingName(key) == normalizeSettingName(name) {
+            return field, key, true
+        }
+    }
+    return reflect.StructField{}, "", false
+}
+func normalizeSettingName(name string) string {
+    return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+
+// Set a value in a yaml document, creating sections as required
+func setDocumentValue(document map[interface{}]interface{}, keys []string, value interface{}) {
+    for _, key := range keys[:len(keys)-1] {
+        section, ok := document[key].(map[interface{}]interface{})
+        if !ok {
+            section = map[interface{}]interface{}{}
+            document[key] = section
+        }
+        document = section
+    }
+    document[keys[len(keys)-1]] = value
+}
+
+
+// Get config overrides for the current process
+func getProcessOverrides(c Context) ([]Override, error) {
+    return GetOverrides(os.Environ(), c.GlobalStringSlice("set"))
+}
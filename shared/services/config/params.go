@@ -0,0 +1,194 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+    "net/url"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+
+// Client parameter types
+const (
+    ParamTypeString = "string"
+    ParamTypeBool = "bool"
+    ParamTypeInt = "int"
+    ParamTypeEnum = "enum"
+    ParamTypeURL = "url"
+    ParamTypePath = "path"
+)
+
+
+// Get a client parameter's type; parameters without a type are strings
+func (param *ClientParam) GetType() string {
+    if param.Type == "" {
+        return ParamTypeString
+    }
+    return param.Type
+}
+
+
+// Validate a value for a client parameter
+// Empty values are valid for optional parameters
+func (param *ClientParam) Validate(value string) error {
+
+    // Check required
+    if value == "" {
+        if param.Required {
+            return fmt.Errorf("A value for %s is required", param.Name)
+        }
+        return nil
+    }
+
+    // Check type
+    switch param.GetType() {
+        case ParamTypeString:
+        case ParamTypeBool:
+            if _, err := strconv.ParseBool(value); err != nil {
+                return fmt.Errorf("Invalid %s '%s' - must be true or false", param.Name, value)
+            }
+        case ParamTypeInt:
+            intValue, err := strconv.ParseInt(value, 10, 64)
+            if err != nil {
+                return fmt.Errorf("Invalid %s '%s' - must be a whole number", param.Name, value)
+            }
+            if param.Min != nil && intValue < *param.Min {
+                return fmt.Errorf("Invalid %s '%s' - must be at least %d", param.Name, value, *param.Min)
+            }
+            if param.Max != nil && intValue > *param.Max {
+                return fmt.Errorf("Invalid %s '%s' - must be at most %d", param.Name, value, *param.Max)
+            }
+        case ParamTypeEnum:
+            valid := false
+            for _, option := range param.Options {
+                if value == option {
+                    valid = true
+                    break
+                }
+            }
+            if !valid {
+                return fmt.Errorf("Invalid %s '%s' - valid options are %s", param.Name, value, strings.Join(param.Options, ", "))
+            }
+        case ParamTypeURL:
+            parsed, err := url.Parse(value)
+            if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+                return fmt.Errorf("Invalid %s '%s' - must be a URL including the scheme (e.g. http://)", param.Name, value)
+            }
+        case ParamTypePath:
+            if !filepath.IsAbs(value) {
+                return fmt.Errorf("Invalid %s '%s' - must be an absolute path", param.Name, value)
+            }
+        default:
+            return fmt.Errorf("Unknown type '%s' for %s", param.Type, param.Name)
+    }
+
+    // Check regex
+    if param.Regex != "" {
+        regex, err := param.getRegex()
+        if err != nil {
+            return err
+        }
+        if !regex.MatchString(value) {
+            return fmt.Errorf("Invalid %s '%s'", param.Name, value)
+        }
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get a client parameter's compiled regex, compiling it if it was not compiled on load
+func (param *ClientParam) getRegex() (*regexp.Regexp, error) {
+    if param.regex != nil && param.regex.String() == param.Regex {
+        return param.regex, nil
+    }
+    regex, err := regexp.Compile(param.Regex)
+    if err != nil {
+        return nil, fmt.Errorf("Invalid regex for %s: %w", param.Name, err)
+    }
+    param.regex = regex
+    return regex, nil
+}
+
+
+// Compile the regexes of all client parameters in a config
+func (config *RocketPoolConfig) compileParamRegexes() error {
+    for _, chain := range []*Chain{&config.Chains.Eth1, &config.Chains.Eth2} {
+        for ci := range chain.Client.Options {
+            option := &chain.Client.Options[ci]
+            for pi := range option.Params {
+                param := &option.Params[pi]
+                if param.Regex == "" { continue }
+                if _, err := param.getRegex(); err != nil {
+                    return fmt.Errorf("Invalid %s client setting: %w", option.Name, err)
+                }
+            }
+        }
+    }
+    return nil
+}
+
+
+// Get a description of the values accepted by a client parameter; empty for freeform text
+func (param *ClientParam) GetFormatDescription() string {
+    switch param.GetType() {
+        case ParamTypeBool: return "true or false"
+        case ParamTypeInt:
+            if param.Min != nil && param.Max != nil {
+                return fmt.Sprintf("a whole number from %d to %d", *param.Min, *param.Max)
+            }
+            return "a whole number"
+        case ParamTypeEnum: return fmt.Sprintf("one of %s", strings.Join(param.Options, ", "))
+        case ParamTypeURL: return "a URL"
+        case ParamTypePath: return "an absolute path"
+    }
+    return ""
+}
+
+
+// Get the validated environment variables for a chain's selected client params
+// Unset params take their default values; params not in the client schema are passed through unchanged
+func (chain *Chain) GetClientEnv() ([]string, error) {
+
+    // Get selected client
+    client := chain.GetSelectedClient()
+    if client == nil {
+        return []string{}, errors.New("No client selected")
+    }
+
+    // Get user param values
+    values := map[string]string{}
+    for _, param := range chain.Client.Params {
+        values[param.Env] = param.Value
+    }
+
+    // Validate client params
+    env := []string{}
+    for _, param := range client.Params {
+        value := values[param.Env]
+        if value == "" {
+            value = param.Default
+        }
+        if err := param.Validate(value); err != nil {
+            return []string{}, fmt.Errorf("Invalid %s client setting: %w", client.Name, err)
+        }
+        env = append(env, fmt.Sprintf("%s=%s", param.Env, value))
+        delete(values, param.Env)
+    }
+
+    // Add remaining user params
+    for _, param := range chain.Client.Params {
+        if _, ok := values[param.Env]; ok {
+            env = append(env, fmt.Sprintf("%s=%s", param.Env, param.Value))
+        }
+    }
+
+    // Return
+    return env, nil
+
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+
+    "gopkg.in/yaml.v2"
+)
+
+
+// A named config preset, which pre-populates client choices & settings in the config wizard
+type Preset struct {
+    Name string                         `yaml:"name"`
+    Description string                  `yaml:"description,omitempty"`
+    Eth1 PresetChain                    `yaml:"eth1,omitempty"`
+    Eth2 PresetChain                    `yaml:"eth2,omitempty"`
+}
+type PresetChain struct {
+    Client string                       `yaml:"client,omitempty"`
+    Params []UserParam                  `yaml:"params,omitempty"`
+}
+
+
+// Built-in presets for common setups
+var Presets = []Preset{
+    Preset{
+        Name: "low-power",
+        Description: "Low-power devices such as a Raspberry Pi: small caches and few peers",
+        Eth1: PresetChain{
+            Client: "geth",
+            Params: []UserParam{
+                UserParam{Env: "ETH1_CACHE_SIZE", Value: "256"},
+                UserParam{Env: "ETH1_MAX_PEERS", Value: "12"},
+            },
+        },
+        Eth2: PresetChain{
+            Client: "lighthouse",
+            Params: []UserParam{
+                UserParam{Env: "ETH2_MAX_PEERS", Value: "25"},
+            },
+        },
+    },
+    Preset{
+        Name: "standard",
+        Description: "Typical home staking machines with 8-16GB of RAM",
+        Eth1: PresetChain{
+            Client: "geth",
+            Params: []UserParam{
+                UserParam{Env: "ETH1_CACHE_SIZE", Value: "1024"},
+                UserParam{Env: "ETH1_MAX_PEERS", Value: "25"},
+            },
+        },
+        Eth2: PresetChain{
+            Params: []UserParam{
+                UserParam{Env: "ETH2_MAX_PEERS", Value: "50"},
+            },
+        },
+    },
+    Preset{
+        Name: "performance",
+        Description: "Dedicated servers with 32GB of RAM or more and fast connections: large caches and many peers",
+        Eth1: PresetChain{
+            Client: "geth",
+            Params: []UserParam{
+                UserParam{Env: "ETH1_CACHE_SIZE", Value: "4096"},
+                UserParam{Env: "ETH1_MAX_PEERS", Value: "50"},
+            },
+        },
+        Eth2: PresetChain{
+            Params: []UserParam{
+                UserParam{Env: "ETH2_MAX_PEERS", Value: "100"},
+            },
+        },
+    },
+    Preset{
+        Name: "archive",
+        Description: "An Eth 1.0 archive node which keeps all historical state; requires several TB of disk space",
+        Eth1: PresetChain{
+            Client: "geth",
+            Params: []UserParam{
+                UserParam{Env: "ETH1_SYNC_MODE", Value: "full"},
+                UserParam{Env: "ETH1_GC_MODE", Value: "archive"},
+                UserParam{Env: "ETH1_CACHE_SIZE", Value: "4096"},
+                UserParam{Env: "ETH1_MAX_PEERS", Value: "50"},
+            },
+        },
+        Eth2: PresetChain{
+            Params: []UserParam{
+                UserParam{Env: "ETH2_MAX_PEERS", Value: "50"},
+            },
+        },
+    },
+}
+
+
+// Get a built-in preset by name
+func GetPreset(name string) (Preset, bool) {
+    for _, preset := range Presets {
+        if preset.Name == name {
+            return preset, true
+        }
+    }
+    return Preset{}, false
+}
+
+
+// Create a preset from a user config's client choices & settings
+// Secret settings are omitted so that presets can be shared
+func NewPreset(name, description string, cfg RocketPoolConfig) Preset {
+    return Preset{
+        Name: name,
+        Description: description,
+        Eth1: PresetChain{
+            Client: cfg.Chains.Eth1.Client.Selected,
+            Params: getShareableParams(cfg.Chains.Eth1.Client.Params),
+        },
+        Eth2: PresetChain{
+            Client: cfg.Chains.Eth2.Client.Selected,
+            Params: getShareableParams(cfg.Chains.Eth2.Client.Params),
+        },
+    }
+}
+func getShareableParams(params []UserParam) []UserParam {
+    shareable := []UserParam{}
+    for _, param := range params {
+        if maskSecret(param.Env, param.Value) == param.Value {
+            shareable = append(shareable, param)
+        }
+    }
+    return shareable
+}
+
+
+// Get the value of a preset param by its environment variable
+func (chain *PresetChain) GetParam(env string) (string, bool) {
+    for _, param := range chain.Params {
+        if param.Env == env {
+            return param.Value, true
+        }
+    }
+    return "", false
+}
+
+
+// Serialize a preset to yaml bytes
+func (preset *Preset) Serialize() ([]byte, error) {
+    bytes, err := yaml.Marshal(preset)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not serialize preset: %w", err)
+    }
+    return bytes, nil
+}
+
+
+// Parse a preset from yaml bytes
+func ParsePreset(bytes []byte) (Preset, error) {
+    var preset Preset
+    if err := yaml.Unmarshal(bytes, &preset); err != nil {
+        return Preset{}, fmt.Errorf("Could not parse preset: %w", err)
+    }
+    if preset.Name == "" {
+        return Preset{}, errors.New("Could not parse preset: the preset has no name")
+    }
+    return preset, nil
+}
@@ -0,0 +1,496 @@
+package config
+
+import (
+    "fmt"
+    "net"
+    "net/url"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "gopkg.in/yaml.v2"
+
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Schema option values
+var (
+    containerRuntimes = []string{"docker", "podman"}
+    serviceBackends = []string{"compose", "systemd"}
+    architectures = []string{ArchitectureAmd64, ArchitectureArm64}
+    logFormats = []string{"text", "json"}
+    backupDestinations = []string{"local", "s3", "sftp", "rsync"}
+    passwordSourceTypes = []string{"file", "env", "exec", "keychain"}
+    portMappingMethods = []string{"upnp", "natpmp", "auto"}
+    paramTypes = []string{ParamTypeString, ParamTypeBool, ParamTypeInt, ParamTypeEnum, ParamTypeURL, ParamTypePath}
+)
+var yamlErrorLineRegex = regexp.MustCompile("^(?:yaml: )?line (\\d+): (.*)$")
+
+
+// A config schema error for a single field
+type FieldError struct {
+    Line int
+    Field string
+    Message string
+}
+func (e FieldError) Error() string {
+    var location []string
+    if e.Line > 0 { location = append(location, fmt.Sprintf("line %d", e.Line)) }
+    if e.Field != "" { location = append(location, e.Field) }
+    if len(location) == 0 {
+        return e.Message
+    }
+    return fmt.Sprintf("%s: %s", strings.Join(location, ": "), e.Message)
+}
+
+
+// The config schema errors for a file
+type SchemaError struct {
+    Path string
+    Errors []FieldError
+}
+func (e *SchemaError) Error() string {
+    lines := []string{fmt.Sprintf("Invalid config file %s:", e.Path)}
+    for _, fieldErr := range e.Errors {
+        lines = append(lines, "  " + fieldErr.Error())
+    }
+    return strings.Join(lines, "\n")
+}
+
+
+// Validate a config file against the config schema
+// Client IDs & params in a user settings file are checked against the client options in base (the global config); base is nil for the global config itself
+// Returns a *SchemaError listing each invalid field with its line number, or nil if the file is valid
+func ValidateFile(path string, bytes []byte, base *RocketPoolConfig) error {
+    s := &schemaValidator{lines: indexLines(bytes)}
+
+    // Parse config, checking for unknown fields & invalid types
+    var config RocketPoolConfig
+    if err := yaml.UnmarshalStrict(bytes, &config); err != nil {
+        if typeErr, ok := err.(*yaml.TypeError); ok {
+            for _, message := range typeErr.Errors {
+                s.addYamlError(message)
+            }
+        } else {
+            s.addYamlError(err.Error())
+            return s.result(path)
+        }
+    }
+
+    // Smart node settings
+    s.checkAddress("rocketpool.storageAddress", config.Rocketpool.StorageAddress)
+    s.checkAddress("rocketpool.multicallAddress", config.Rocketpool.MulticallAddress)
+    s.checkDuration("rocketpool.cacheTtl", config.Rocketpool.CacheTTL, false)
+    s.checkURL("smartnode.alertWebhookUrl", config.Smartnode.AlertWebhookURL, "http", "https")
+    s.checkEnum("smartnode.containerRuntime", config.Smartnode.ContainerRuntime, containerRuntimes)
+    s.checkEnum("smartnode.serviceBackend", config.Smartnode.ServiceBackend, serviceBackends)
+    s.checkEnum("smartnode.architecture", config.Smartnode.Architecture, architectures)
+    s.checkHostPort("smartnode.metricsAddress", config.Smartnode.MetricsAddress)
+    s.checkAddress("smartnode.nodeAddress", config.Smartnode.NodeAddress)
+    s.checkEnum("smartnode.passwordSource.type", config.Smartnode.PasswordSource.Type, passwordSourceTypes)
+    s.checkPath("smartnode.dataPath", config.Smartnode.DataPath)
+
+    // Chains
+    var baseChains [2]*Chain
+    if base != nil {
+        baseChains = [2]*Chain{&(base.Chains.Eth1), &(base.Chains.Eth2)}
+    }
+    for ci, chain := range []*Chain{&(config.Chains.Eth1), &(config.Chains.Eth2)} {
+        s.checkChain(fmt.Sprintf("chains.eth%d", ci + 1), chain, baseChains[ci], (ci == 1))
+    }
+
+    // Backups
+    s.checkEnum("backup.destination", config.Backup.Destination, backupDestinations)
+    s.checkDuration("backup.interval", config.Backup.Interval, true)
+    if config.Backup.Retention < 0 {
+        s.add("backup.retention", "must not be negative")
+    }
+    s.checkURL("backup.s3.endpoint", config.Backup.S3.Endpoint, "http", "https")
+
+    // Logging
+    if config.Log.Level != "" {
+        if _, err := log.ParseLevel(config.Log.Level); err != nil {
+            s.add("log.level", fmt.Sprintf("unknown log level '%s'; valid levels are debug, info, warn and error", config.Log.Level))
+        }
+    }
+    s.checkEnum("log.format", config.Log.Format, logFormats)
+    if config.Log.MaxSizeMB < 0 {
+        s.add("log.maxSizeMb", "must not be negative")
+    }
+    if config.Log.MaxBackups < 0 {
+        s.add("log.maxBackups", "must not be negative")
+    }
+
+    // Watchtower
+    s.checkDuration("watchtower.submissionDelay", config.Watchtower.SubmissionDelay, false)
+    s.checkHostPort("watchtower.metricsAddress", config.Watchtower.MetricsAddress)
+    if config.Watchtower.LowBalanceAlert < 0 {
+        s.add("watchtower.lowBalanceAlert", "must not be negative")
+    }
+
+    // Alerts
+    if config.Alerts.DiskUsagePercent < 0 || config.Alerts.DiskUsagePercent > 100 {
+        s.add("alerts.diskUsagePercent", "must be a percentage from 0 to 100")
+    }
+    if config.Alerts.DiskFullDays < 0 {
+        s.add("alerts.diskFullDays", "must not be negative")
+    }
+
+    // Remote signer
+    s.checkURL("remoteSigner.url", config.RemoteSigner.URL, "http", "https")
+
+    // Port mapping
+    s.checkEnum("portMapping.method", config.PortMapping.Method, portMappingMethods)
+    if config.PortMapping.Host != "" && net.ParseIP(config.PortMapping.Host).To4() == nil {
+        s.add("portMapping.host", fmt.Sprintf("'%s' is not an IPv4 address", config.PortMapping.Host))
+    }
+
+    // Tasks
+    for name, task := range config.Tasks {
+        s.checkDuration(fmt.Sprintf("tasks.%s.interval", name), task.Interval, true)
+        s.checkDuration(fmt.Sprintf("tasks.%s.activeInterval", name), task.ActiveInterval, true)
+    }
+
+    // Return
+    return s.result(path)
+
+}
+
+
+// Config schema validator
+type schemaValidator struct {
+    lines map[string]int
+    errors []FieldError
+}
+
+
+// Check a chain's settings
+func (s *schemaValidator) checkChain(field string, chain, baseChain *Chain, isEth2 bool) {
+
+    // Providers & data path
+    s.checkProvider(field + ".provider", chain.Provider)
+    s.checkProvider(field + ".analyticsProvider", chain.AnalyticsProvider)
+    s.checkPath(field + ".dataPath", chain.DataPath)
+    if chain.P2PPort != 0 {
+        s.checkPort(field + ".p2pPort", strconv.Itoa(chain.P2PPort))
+    }
+    if chain.RPCConnections < 0 {
+        s.add(field + ".rpcConnections", "must not be negative")
+    }
+    if chain.RPCMaxInFlight < 0 {
+        s.add(field + ".rpcMaxInFlight", "must not be negative")
+    }
+
+    // Client options are required in the global config
+    if baseChain == nil && len(chain.Client.Options) == 0 {
+        s.add(field + ".client.options", "at least one client option is required")
+    }
+    for oi, option := range chain.Client.Options {
+        s.checkClientOption(fmt.Sprintf("%s.client.options[%d]", field, oi), &option, isEth2)
+    }
+
+    // Get client options to check selections against
+    options := chain.Client.Options
+    if len(options) == 0 && baseChain != nil {
+        options = baseChain.Client.Options
+    }
+    if len(options) == 0 {
+        return
+    }
+
+    // Check selected client
+    var selected *ClientOption
+    if chain.Client.Selected != "" {
+        ids := []string{}
+        for oi, option := range options {
+            ids = append(ids, option.ID)
+            if option.ID == chain.Client.Selected {
+                selected = &(options[oi])
+            }
+        }
+        if selected == nil {
+            s.add(field + ".client.selected", fmt.Sprintf("unknown client '%s'; valid clients are %s", chain.Client.Selected, strings.Join(ids, ", ")))
+        }
+    } else if baseChain != nil {
+        selected = baseChain.GetSelectedClient()
+    }
+    if selected == nil {
+        return
+    }
+
+    // Check client param values; params not in the client schema are passed through unchecked
+    for pi, userParam := range chain.Client.Params {
+        paramField := fmt.Sprintf("%s.client.params[%d]", field, pi)
+        if userParam.Env == "" {
+            s.add(paramField + ".env", "a param env name is required")
+            continue
+        }
+        for _, param := range selected.Params {
+            if param.Env != userParam.Env || userParam.Value == "" {
+                continue
+            }
+            if _, err := regexp.Compile(param.Regex); err == nil {
+                if err := param.Validate(userParam.Value); err != nil {
+                    s.add(paramField + ".value", err.Error())
+                }
+            }
+        }
+    }
+
+}
+
+
+// Check a client option definition
+func (s *schemaValidator) checkClientOption(field string, option *ClientOption, isEth2 bool) {
+    if option.ID == "" {
+        s.add(field + ".id", "a client ID is required")
+    }
+    if option.Name == "" {
+        s.add(field + ".name", "a client name is required")
+    }
+    if option.Image == "" && (!isEth2 || option.BeaconImage == "") {
+        s.add(field + ".image", "a client image is required")
+    }
+    for pi, param := range option.Params {
+        paramField := fmt.Sprintf("%s.params[%d]", field, pi)
+        if param.Name == "" {
+            s.add(paramField + ".name", "a param name is required")
+        }
+        if param.Env == "" {
+            s.add(paramField + ".env", "a param env name is required")
+        }
+        if !s.checkEnum(paramField + ".type", param.Type, paramTypes) {
+            continue
+        }
+        if param.GetType() == ParamTypeEnum && len(param.Options) == 0 {
+            s.add(paramField + ".options", "enum params require options")
+        }
+        if param.Regex != "" {
+            if _, err := regexp.Compile(param.Regex); err != nil {
+                s.add(paramField + ".regex", fmt.Sprintf("invalid regex: %s", err.Error()))
+                continue
+            }
+        }
+        if param.Default != "" {
+            if err := param.Validate(param.Default); err != nil {
+                s.add(paramField + ".default", err.Error())
+            }
+        }
+        if param.Arm64Default != "" {
+            if err := param.Validate(param.Arm64Default); err != nil {
+                s.add(paramField + ".arm64Default", err.Error())
+            }
+        }
+    }
+    for ai, arch := range option.Architectures {
+        s.checkEnum(fmt.Sprintf("%s.architectures[%d]", field, ai), arch, architectures)
+    }
+}
+
+
+// Check an optional enum value; returns whether the value is valid
+func (s *schemaValidator) checkEnum(field, value string, options []string) bool {
+    if value == "" {
+        return true
+    }
+    for _, option := range options {
+        if value == option {
+            return true
+        }
+    }
+    s.add(field, fmt.Sprintf("unknown value '%s'; valid values are %s", value, strings.Join(options, ", ")))
+    return false
+}
+
+
+// Check an optional URL, with one of the given schemes
+func (s *schemaValidator) checkURL(field, value string, schemes ...string) {
+    if value == "" {
+        return
+    }
+    parsed, err := url.Parse(value)
+    if err != nil || parsed.Host == "" {
+        s.add(field, fmt.Sprintf("invalid URL '%s'; must include the scheme and host (e.g. %s://example.com)", value, schemes[0]))
+        return
+    }
+    if !s.checkEnum(field, parsed.Scheme, schemes) {
+        return
+    }
+    if port := parsed.Port(); port != "" {
+        s.checkPort(field, port)
+    }
+}
+
+
+// Check an optional chain provider; providers are URLs or IPC socket paths
+func (s *schemaValidator) checkProvider(field, value string) {
+    if value == "" || filepath.IsAbs(value) {
+        return
+    }
+    s.checkURL(field, value, "http", "https", "ws", "wss")
+}
+
+
+// Check an optional absolute path
+func (s *schemaValidator) checkPath(field, value string) {
+    if value != "" && !filepath.IsAbs(value) {
+        s.add(field, fmt.Sprintf("invalid path '%s'; must be an absolute path", value))
+    }
+}
+
+
+// Check an optional host:port address
+func (s *schemaValidator) checkHostPort(field, value string) {
+    if value == "" {
+        return
+    }
+    if _, port, err := net.SplitHostPort(value); err != nil {
+        s.add(field, fmt.Sprintf("invalid address '%s'; must be a host and port (e.g. 0.0.0.0:9102)", value))
+    } else {
+        s.checkPort(field, port)
+    }
+}
+
+
+// Check a port number
+func (s *schemaValidator) checkPort(field, value string) {
+    if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+        s.add(field, fmt.Sprintf("invalid port '%s'; must be from 1 to 65535", value))
+    }
+}
+
+
+// Check an optional address
+func (s *schemaValidator) checkAddress(field, value string) {
+    if value != "" && !common.IsHexAddress(value) {
+        s.add(field, fmt.Sprintf("invalid address '%s'; must be a 0x-prefixed hex address", value))
+    }
+}
+
+
+// Check an optional duration
+func (s *schemaValidator) checkDuration(field, value string, positive bool) {
+    if value == "" {
+        return
+    }
+    duration, err := time.ParseDuration(value)
+    switch {
+        case err != nil: s.add(field, fmt.Sprintf("invalid duration '%s' (e.g. 30s, 10m, 24h)", value))
+        case positive && duration <= 0: s.add(field, fmt.Sprintf("invalid duration '%s'; must be positive", value))
+        case duration < 0: s.add(field, fmt.Sprintf("invalid duration '%s'; must not be negative", value))
+    }
+}
+
+
+// Add an error for a field, locating its line in the file
+func (s *schemaValidator) add(field, message string) {
+    s.errors = append(s.errors, FieldError{
+        Line: s.getLine(field),
+        Field: field,
+        Message: message,
+    })
+}
+
+
+// Add an error reported by the yaml parser, which are prefixed with their line number
+func (s *schemaValidator) addYamlError(message string) {
+    fieldErr := FieldError{Message: message}
+    if match := yamlErrorLineRegex.FindStringSubmatch(message); match != nil {
+        fieldErr.Line, _ = strconv.Atoi(match[1])
+        fieldErr.Message = match[2]
+    }
+    s.errors = append(s.errors, fieldErr)
+}
+
+
+// Get the line a field is defined on, falling back to its closest defined parent
+func (s *schemaValidator) getLine(field string) int {
+    for field != "" {
+        if line, ok := s.lines[field]; ok {
+            return line
+        }
+        if index := strings.LastIndexAny(field, ".["); index >= 0 {
+            field = field[:index]
+        } else {
+            field = ""
+        }
+    }
+    return 0
+}
+
+
+// Get the validation result
+func (s *schemaValidator) result(path string) error {
+    if len(s.errors) == 0 {
+        return nil
+    }
+    return &SchemaError{Path: path, Errors: s.errors}
+}
+
+
+// Index the line numbers of the fields in a yaml document by their path (e.g. "chains.eth1.client.params[0].value")
+// Only block-style mappings & sequences are indexed; this covers the files written by the smart node
+func indexLines(bytes []byte) map[string]int {
+
+    // Parent fields by indentation
+    type parent struct {
+        indent int
+        field string
+        item bool
+    }
+    lines := map[string]int{}
+    counts := map[string]int{}
+    parents := []parent{parent{indent: -1}}
+
+    // Index lines
+    for li, line := range strings.Split(string(bytes), "\n") {
+        content := strings.TrimLeft(line, " ")
+        if content == "" || strings.HasPrefix(content, "#") || content == "---" {
+            continue
+        }
+        indent := len(line) - len(content)
+
+        // Sequence items may be indented at the same level as their parent field
+        if content == "-" || strings.HasPrefix(content, "- ") {
+            for len(parents) > 1 && (parents[len(parents) - 1].indent > indent || (parents[len(parents) - 1].indent == indent && parents[len(parents) - 1].item)) {
+                parents = parents[:len(parents) - 1]
+            }
+            listField := parents[len(parents) - 1].field
+            field := fmt.Sprintf("%s[%d]", listField, counts[listField])
+            counts[listField]++
+            lines[field] = li + 1
+            parents = append(parents, parent{indent: indent, field: field, item: true})
+            content = strings.TrimLeft(strings.TrimPrefix(content, "-"), " ")
+            indent = len(line) - len(content)
+            if content == "" {
+                continue
+            }
+        }
+
+        // Mapping keys
+        colon := strings.Index(content, ":")
+        if colon <= 0 {
+            continue
+        }
+        for len(parents) > 1 && parents[len(parents) - 1].indent >= indent {
+            parents = parents[:len(parents) - 1]
+        }
+        key := strings.Trim(content[:colon], "\"'")
+        field := key
+        if parentField := parents[len(parents) - 1].field; parentField != "" {
+            field = parentField + "." + key
+        }
+        lines[field] = li + 1
+        counts[field] = 0
+        parents = append(parents, parent{indent: indent, field: field})
+
+    }
+
+    // Return
+    return lines
+
+}
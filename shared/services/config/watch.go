@@ -0,0 +1,49 @@
+package config
+
+import (
+    "path/filepath"
+    "time"
+)
+
+
+// Settings
+var watchDebounceInterval, _ = time.ParseDuration("1s")
+
+
+// Watch a config file for changes, calling onChange after it is written, created or replaced
+// Editors & the CLI often replace the file rather than writing to it, so its folder is watched rather than the file itself
+// Bursts of changes are debounced into a single call
+func WatchFile(path string, onChange func()) error {
+
+    // Get absolute path
+    absPath, err := filepath.Abs(path)
+    if err != nil {
+        return err
+    }
+
+    // Start watching
+    changes, err := watchFile(absPath)
+    if err != nil {
+        return err
+    }
+
+    // Debounce changes
+    go (func() {
+        for range changes {
+            for pending := true; pending; {
+                select {
+                    case _, ok := <-changes:
+                        if !ok { return }
+                    case <-time.After(watchDebounceInterval):
+                        pending = false
+                }
+            }
+            onChange()
+        }
+    })()
+
+    // Return
+    return nil
+
+}
+
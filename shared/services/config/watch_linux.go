@@ -0,0 +1,71 @@
+package config
+
+import (
+    "fmt"
+    "path/filepath"
+    "syscall"
+    "unsafe"
+)
+
+
+// Inotify events which indicate a file has changed
+const inotifyChangeEvents = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE | syscall.IN_DELETE
+
+
+// Watch a file's folder with inotify and send on the returned channel whenever the file changes
+func watchFile(path string) (<-chan struct{}, error) {
+
+    // Initialize inotify
+    fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+    if err != nil {
+        return nil, fmt.Errorf("Could not initialize inotify: %w", err)
+    }
+    if _, err := syscall.InotifyAddWatch(fd, filepath.Dir(path), inotifyChangeEvents); err != nil {
+        syscall.Close(fd)
+        return nil, fmt.Errorf("Could not watch %s: %w", filepath.Dir(path), err)
+    }
+
+    // Read events
+    changes := make(chan struct{}, 1)
+    name := filepath.Base(path)
+    go (func() {
+        defer syscall.Close(fd)
+        buffer := make([]byte, (syscall.SizeofInotifyEvent + syscall.NAME_MAX + 1) * 16)
+        for {
+            n, err := syscall.Read(fd, buffer)
+            if err != nil {
+                if err == syscall.EINTR { continue }
+                close(changes)
+                return
+            }
+            for offset := 0; offset + syscall.SizeofInotifyEvent <= n; {
+                event := (*syscall.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+                nameBytes := buffer[offset + syscall.SizeofInotifyEvent : offset + syscall.SizeofInotifyEvent + int(event.Len)]
+                offset += syscall.SizeofInotifyEvent + int(event.Len)
+                if inotifyEventName(nameBytes) != name {
+                    continue
+                }
+                select {
+                    case changes <- struct{}{}:
+                    default:
+                }
+            }
+        }
+    })()
+
+    // Return
+    return changes, nil
+
+}
+
+
+// Get an inotify event's file name, trimming its null padding
+func inotifyEventName(nameBytes []byte) string {
+    for i, b := range nameBytes {
+        if b == 0 {
+            return string(nameBytes[:i])
+        }
+    }
+    return string(nameBytes)
+}
+
@@ -0,0 +1,42 @@
+// +build !linux
+
+package config
+
+import (
+    "os"
+    "time"
+)
+
+
+// Settings
+var watchPollInterval, _ = time.ParseDuration("5s")
+
+
+// Poll a file's modification time and send on the returned channel whenever the file changes
+// Used on platforms without inotify
+func watchFile(path string) (<-chan struct{}, error) {
+    changes := make(chan struct{}, 1)
+    go (func() {
+        var lastModified time.Time
+        if info, err := os.Stat(path); err == nil {
+            lastModified = info.ModTime()
+        }
+        for {
+            time.Sleep(watchPollInterval)
+            var modified time.Time
+            if info, err := os.Stat(path); err == nil {
+                modified = info.ModTime()
+            }
+            if modified.Equal(lastModified) {
+                continue
+            }
+            lastModified = modified
+            select {
+                case changes <- struct{}{}:
+                default:
+            }
+        }
+    })()
+    return changes, nil
+}
+
@@ -0,0 +1,274 @@
+package containers
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+
+    "github.com/docker/docker/api/types"
+    "github.com/docker/docker/client"
+)
+
+
+// Config
+const (
+    RequestsFile = "service-requests.json"
+    AgentStateFile = "service-agent.json"
+    ContainerPrefix = "rocketpool_"
+    MaxRequests = 50
+    FileMode = 0600
+)
+var stopTimeout, _ = time.ParseDuration("5s")
+
+
+// Container actions
+const (
+    ActionStop = "stop"
+    ActionRestart = "restart"
+)
+
+
+// Services
+// Only services in the allowlist may be controlled by the host-side agent, as requests are written by the containers
+const ValidatorService = "validator"
+var agentServices = []string{ValidatorService}
+
+
+// A container action requested by the node daemon, to be run by the host-side agent
+type Request struct {
+    ID uint64                   `json:"id"`
+    Action string               `json:"action"`
+    Service string              `json:"service"`
+    Reason string               `json:"reason"`
+    Created time.Time           `json:"created"`
+}
+
+
+// Host-side agent state; requests up to the last applied ID have been run
+type AgentState struct {
+    LastAppliedID uint64        `json:"lastAppliedId"`
+    Updated time.Time           `json:"updated"`
+}
+
+
+// Container controller
+// Controls Rocket Pool containers via the docker socket if available, or queues requests in the data folder for the
+// host-side agent (rocketpool service agent) if the docker socket is not mounted
+type Controller struct {
+    d *client.Client
+    dataPath string
+    lock sync.Mutex
+}
+
+
+// Create new container controller; d is nil if the docker socket is disabled
+func NewController(d *client.Client, dataPath string) *Controller {
+    return &Controller{
+        d: d,
+        dataPath: dataPath,
+    }
+}
+
+
+// Check whether containers are controlled directly via the docker socket
+func (c *Controller) Direct() bool {
+    return (c.d != nil)
+}
+
+
+// Stop a service's container if it is running
+// Queued stops are skipped if the latest request for the service was also a stop
+func (c *Controller) Stop(service, reason string) error {
+
+    // Queue request
+    if c.d == nil {
+        return c.queue(ActionStop, service, reason, func(latest Request, applied bool) bool {
+            return (latest.Action == ActionStop)
+        })
+    }
+
+    // Get container
+    containerId, running, err := c.getContainer(service)
+    if err != nil {
+        return err
+    }
+    if !running {
+        return nil
+    }
+
+    // Stop container
+    if err := c.d.ContainerStop(context.Background(), containerId, &stopTimeout); err != nil {
+        return fmt.Errorf("Could not stop %s container: %w", service, err)
+    }
+    return nil
+
+}
+
+
+// Restart a service's container, starting it if it is stopped
+// Queued restarts are skipped if a restart of the service is already pending
+func (c *Controller) Restart(service, reason string) error {
+
+    // Queue request
+    if c.d == nil {
+        return c.queue(ActionRestart, service, reason, func(latest Request, applied bool) bool {
+            return (latest.Action == ActionRestart && !applied)
+        })
+    }
+
+    // Get container
+    containerId, running, err := c.getContainer(service)
+    if err != nil {
+        return err
+    }
+
+    // Restart or start container
+    if running {
+        if err := c.d.ContainerRestart(context.Background(), containerId, &stopTimeout); err != nil {
+            return fmt.Errorf("Could not restart %s container: %w", service, err)
+        }
+    } else {
+        if err := c.d.ContainerStart(context.Background(), containerId, types.ContainerStartOptions{}); err != nil {
+            return fmt.Errorf("Could not start %s container: %w", service, err)
+        }
+    }
+    return nil
+
+}
+
+
+// Get a service's container ID and running status
+func (c *Controller) getContainer(service string) (string, bool, error) {
+
+    // Get all containers
+    containers, err := c.d.ContainerList(context.Background(), types.ContainerListOptions{All: true})
+    if err != nil {
+        return "", false, fmt.Errorf("Could not get docker containers: %w", err)
+    }
+
+    // Get service container
+    for _, container := range containers {
+        if container.Names[0] == "/" + ContainerPrefix + service {
+            return container.ID, (container.State == "running"), nil
+        }
+    }
+
+    // Return
+    return "", false, fmt.Errorf("The %s container was not found", service)
+
+}
+
+
+// Queue a request for the host-side agent, unless skip returns true for the latest request for the service
+func (c *Controller) queue(action, service, reason string, skip func(latest Request, applied bool) bool) error {
+    c.lock.Lock()
+    defer c.lock.Unlock()
+
+    // Load requests & agent state
+    requests, err := LoadRequests(c.dataPath)
+    if err != nil {
+        return err
+    }
+    state, err := LoadAgentState(c.dataPath)
+    if err != nil {
+        return err
+    }
+
+    // Check latest request for the service
+    for ri := len(requests) - 1; ri >= 0; ri-- {
+        if requests[ri].Service == service {
+            if skip(requests[ri], requests[ri].ID <= state.LastAppliedID) {
+                return nil
+            }
+            break
+        }
+    }
+
+    // Add request
+    request := Request{
+        ID: 1,
+        Action: action,
+        Service: service,
+        Reason: reason,
+        Created: time.Now(),
+    }
+    if len(requests) > 0 {
+        request.ID = requests[len(requests) - 1].ID + 1
+    }
+    requests = append(requests, request)
+    if len(requests) > MaxRequests {
+        requests = requests[len(requests) - MaxRequests:]
+    }
+
+    // Save requests
+    if err := writeFile(filepath.Join(c.dataPath, RequestsFile), requests); err != nil {
+        return fmt.Errorf("Could not save service requests: %w", err)
+    }
+    return nil
+
+}
+
+
+// Get queued requests, oldest first
+func LoadRequests(dataPath string) ([]Request, error) {
+    requests := []Request{}
+    if err := readFile(filepath.Join(dataPath, RequestsFile), &requests); err != nil {
+        return []Request{}, fmt.Errorf("Could not load service requests: %w", err)
+    }
+    return requests, nil
+}
+
+
+// Get the host-side agent state
+func LoadAgentState(dataPath string) (AgentState, error) {
+    var state AgentState
+    if err := readFile(filepath.Join(dataPath, AgentStateFile), &state); err != nil {
+        return AgentState{}, fmt.Errorf("Could not load service agent state: %w", err)
+    }
+    return state, nil
+}
+
+
+// Check that a request has a known action and a service which may be controlled via the host-side agent
+func ValidateRequest(request Request) error {
+    if request.Action != ActionStop && request.Action != ActionRestart {
+        return fmt.Errorf("Unknown service request action %q", request.Action)
+    }
+    for _, service := range agentServices {
+        if request.Service == service {
+            return nil
+        }
+    }
+    return fmt.Errorf("Service %q may not be controlled by service requests", request.Service)
+}
+
+
+// Read a JSON file; v is left unchanged if the file does not exist
+func readFile(path string, v interface{}) error {
+    fileBytes, err := ioutil.ReadFile(path)
+    if os.IsNotExist(err) {
+        return nil
+    }
+    if err != nil {
+        return err
+    }
+    return json.Unmarshal(fileBytes, v)
+}
+
+
+// Write a JSON file via a temporary file, so that readers never see a partial write
+func writeFile(path string, v interface{}) error {
+    fileBytes, err := json.Marshal(v)
+    if err != nil {
+        return err
+    }
+    if err := ioutil.WriteFile(path + ".tmp", fileBytes, FileMode); err != nil {
+        return err
+    }
+    return os.Rename(path + ".tmp", path)
+}
@@ -0,0 +1,215 @@
+package contracts
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/crypto"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+
+// Settings
+const UpgradeContractName = "rocketUpgrade"
+var upgradeCheckInterval, _ = time.ParseDuration("1m")
+
+
+// Cached value
+type cachedValue struct {
+    value interface{}
+    time time.Time
+}
+
+
+// Rocket Pool contract & storage cache
+// Contract addresses, ABIs and network settings are cached for a TTL, and the whole cache is invalidated when a protocol upgrade is detected
+// Upgrades are detected from events emitted by the upgrade contract, which are checked at most once a minute
+type Cache struct {
+    rp *rocketpool.RocketPool
+    ttl time.Duration
+    values map[string]cachedValue
+    upgradeBlock uint64
+    upgradeChecked time.Time
+    lock sync.Mutex
+}
+
+
+// Create new contract cache
+func NewCache(rp *rocketpool.RocketPool, ttl time.Duration) *Cache {
+    return &Cache{
+        rp: rp,
+        ttl: ttl,
+        values: make(map[string]cachedValue),
+    }
+}
+
+
+// Get a cached value, loading it if it is not cached or has expired
+func (c *Cache) Get(key string, load func() (interface{}, error)) (interface{}, error) {
+
+    // Check for protocol upgrades
+    if err := c.checkUpgrade(); err != nil {
+        return nil, err
+    }
+
+    // Check for cached value
+    c.lock.Lock()
+    cached, ok := c.values[key]
+    c.lock.Unlock()
+    if ok && time.Since(cached.time) <= c.ttl {
+        return cached.value, nil
+    }
+
+    // Load & cache value
+    value, err := load()
+    if err != nil {
+        return nil, err
+    }
+    c.lock.Lock()
+    c.values[key] = cachedValue{value: value, time: time.Now()}
+    c.lock.Unlock()
+    return value, nil
+
+}
+
+
+// Get a cached uint64 value
+func (c *Cache) GetUint64(key string, load func() (uint64, error)) (uint64, error) {
+    value, err := c.Get(key, func() (interface{}, error) { return load() })
+    if err != nil {
+        return 0, err
+    }
+    return value.(uint64), nil
+}
+
+
+// Get a cached bool value
+func (c *Cache) GetBool(key string, load func() (bool, error)) (bool, error) {
+    value, err := c.Get(key, func() (interface{}, error) { return load() })
+    if err != nil {
+        return false, err
+    }
+    return value.(bool), nil
+}
+
+
+// Get a cached big integer value
+func (c *Cache) GetBig(key string, load func() (*big.Int, error)) (*big.Int, error) {
+    value, err := c.Get(key, func() (interface{}, error) { return load() })
+    if err != nil {
+        return nil, err
+    }
+    return new(big.Int).Set(value.(*big.Int)), nil
+}
+
+
+// Get a Rocket Pool contract address
+func (c *Cache) GetAddress(contractName string) (common.Address, error) {
+    value, err := c.Get("contract.address." + contractName, func() (interface{}, error) {
+        address, err := c.rp.RocketStorage.GetAddress(nil, crypto.Keccak256Hash([]byte("contract.address"), []byte(contractName)))
+        if err != nil {
+            return nil, fmt.Errorf("Could not load contract %s address: %w", contractName, err)
+        }
+        return address, nil
+    })
+    if err != nil {
+        return common.Address{}, err
+    }
+    return value.(common.Address), nil
+}
+
+
+// Get a Rocket Pool contract ABI
+func (c *Cache) GetABI(contractName string) (*abi.ABI, error) {
+    value, err := c.Get("contract.abi." + contractName, func() (interface{}, error) {
+        return c.rp.GetABI(contractName)
+    })
+    if err != nil {
+        return nil, err
+    }
+    return value.(*abi.ABI), nil
+}
+
+
+// Get a Rocket Pool contract
+func (c *Cache) GetContract(contractName string) (*bind.BoundContract, error) {
+    address, err := c.GetAddress(contractName)
+    if err != nil {
+        return nil, err
+    }
+    contractABI, err := c.GetABI(contractName)
+    if err != nil {
+        return nil, err
+    }
+    return bind.NewBoundContract(address, *contractABI, c.rp.Client, c.rp.Client, c.rp.Client), nil
+}
+
+
+// Invalidate all cached values
+func (c *Cache) Invalidate() {
+    c.lock.Lock()
+    defer c.lock.Unlock()
+    c.values = make(map[string]cachedValue)
+}
+
+
+// Check for protocol upgrades since the last check, and invalidate the cache if any have occurred
+func (c *Cache) checkUpgrade() error {
+
+    // Check interval
+    c.lock.Lock()
+    if time.Since(c.upgradeChecked) < upgradeCheckInterval {
+        c.lock.Unlock()
+        return nil
+    }
+    c.upgradeChecked = time.Now()
+    fromBlock := c.upgradeBlock
+    c.lock.Unlock()
+
+    // Get current block
+    header, err := c.rp.Client.HeaderByNumber(context.Background(), nil)
+    if err != nil {
+        return fmt.Errorf("Could not get current block: %w", err)
+    }
+    currentBlock := header.Number.Uint64()
+
+    // Get upgrade events since the last check; the first check only records the current block
+    upgraded := false
+    if fromBlock > 0 && currentBlock > fromBlock {
+        upgradeAddress, err := c.rp.RocketStorage.GetAddress(nil, crypto.Keccak256Hash([]byte("contract.address"), []byte(UpgradeContractName)))
+        if err != nil {
+            return fmt.Errorf("Could not load contract %s address: %w", UpgradeContractName, err)
+        }
+        if upgradeAddress != (common.Address{}) {
+            logs, err := c.rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+                FromBlock: new(big.Int).SetUint64(fromBlock + 1),
+                ToBlock: new(big.Int).SetUint64(currentBlock),
+                Addresses: []common.Address{upgradeAddress},
+            })
+            if err != nil {
+                return fmt.Errorf("Could not check for protocol upgrades: %w", err)
+            }
+            upgraded = (len(logs) > 0)
+        }
+    }
+
+    // Update cache
+    c.lock.Lock()
+    defer c.lock.Unlock()
+    if upgraded {
+        c.values = make(map[string]cachedValue)
+    }
+    if currentBlock > c.upgradeBlock {
+        c.upgradeBlock = currentBlock
+    }
+    return nil
+
+}
+
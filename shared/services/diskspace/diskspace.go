@@ -0,0 +1,150 @@
+package diskspace
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+
+// Config
+const (
+    HistoryFile = "disk-space.json"
+    FileMode = 0644
+)
+var (
+    sampleRetention, _ = time.ParseDuration("720h")
+    growthWindow, _ = time.ParseDuration("168h")
+    minGrowthWindow, _ = time.ParseDuration("24h")
+)
+
+
+// A disk space sample
+type Sample struct {
+    Time time.Time              `json:"time"`
+    Available uint64            `json:"available"`
+    Total uint64                `json:"total"`
+}
+
+
+// Disk space history, recorded in the data folder
+type History struct {
+    Samples []Sample            `json:"samples"`
+    LastAlert time.Time         `json:"lastAlert"`
+}
+
+
+// Disk space prediction from the history
+// The growth rate is only known once samples span a day; days until full is negative if usage is not growing
+type Prediction struct {
+    Available uint64
+    Total uint64
+    GrowthKnown bool
+    GrowthPerDay float64
+    DaysUntilFull float64
+}
+
+
+// Get the disk space history; returns an empty history if none has been recorded
+func LoadHistory(dataPath string) (History, error) {
+    var history History
+    historyBytes, err := ioutil.ReadFile(filepath.Join(dataPath, HistoryFile))
+    if os.IsNotExist(err) {
+        return history, nil
+    }
+    if err != nil {
+        return History{}, fmt.Errorf("Could not read disk space history: %w", err)
+    }
+    if err := json.Unmarshal(historyBytes, &history); err != nil {
+        return History{}, fmt.Errorf("Could not decode disk space history: %w", err)
+    }
+    return history, nil
+}
+
+
+// Save the disk space history, via a temporary file so that readers never see a partial write
+func SaveHistory(dataPath string, history History) error {
+    historyBytes, err := json.Marshal(history)
+    if err != nil {
+        return fmt.Errorf("Could not encode disk space history: %w", err)
+    }
+    path := filepath.Join(dataPath, HistoryFile)
+    if err := ioutil.WriteFile(path + ".tmp", historyBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write disk space history: %w", err)
+    }
+    if err := os.Rename(path + ".tmp", path); err != nil {
+        return fmt.Errorf("Could not write disk space history: %w", err)
+    }
+    return nil
+}
+
+
+// Add a sample to the history, removing samples older than the retention period
+func (h *History) Add(sample Sample) {
+    samples := []Sample{}
+    for _, s := range h.Samples {
+        if sample.Time.Sub(s.Time) <= sampleRetention {
+            samples = append(samples, s)
+        }
+    }
+    h.Samples = append(samples, sample)
+}
+
+
+// Predict when the disk will be full from the latest sample, and the growth in used space over the last week
+// Growth is the least squares slope of used space over time, so that short-lived files do not skew it
+func (h *History) Predict() (Prediction, bool) {
+
+    // Get latest sample
+    if len(h.Samples) == 0 {
+        return Prediction{}, false
+    }
+    latest := h.Samples[len(h.Samples) - 1]
+    prediction := Prediction{
+        Available: latest.Available,
+        Total: latest.Total,
+        DaysUntilFull: -1,
+    }
+
+    // Get samples in growth window
+    samples := []Sample{}
+    for _, s := range h.Samples {
+        if latest.Time.Sub(s.Time) <= growthWindow {
+            samples = append(samples, s)
+        }
+    }
+    if len(samples) < 2 || latest.Time.Sub(samples[0].Time) < minGrowthWindow {
+        return prediction, true
+    }
+
+    // Fit used space (in bytes) against time (in days)
+    var sumX, sumY, sumXY, sumXX float64
+    for _, s := range samples {
+        x := s.Time.Sub(samples[0].Time).Hours() / 24
+        y := float64(s.Total) - float64(s.Available)
+        sumX += x
+        sumY += y
+        sumXY += x * y
+        sumXX += x * x
+    }
+    n := float64(len(samples))
+    denominator := n * sumXX - sumX * sumX
+    if denominator == 0 {
+        return prediction, true
+    }
+    prediction.GrowthKnown = true
+    prediction.GrowthPerDay = (n * sumXY - sumX * sumY) / denominator
+
+    // Get days until full
+    if prediction.GrowthPerDay > 0 {
+        prediction.DaysUntilFull = float64(latest.Available) / prediction.GrowthPerDay
+    }
+
+    // Return
+    return prediction, true
+
+}
+
@@ -0,0 +1,22 @@
+package diskspace
+
+import (
+    "fmt"
+    "syscall"
+    "time"
+)
+
+
+// Sample the available & total space of the filesystem a path is on
+func GetSample(path string) (Sample, error) {
+    var stat syscall.Statfs_t
+    if err := syscall.Statfs(path, &stat); err != nil {
+        return Sample{}, fmt.Errorf("Could not get disk space for %s: %w", path, err)
+    }
+    return Sample{
+        Time: time.Now(),
+        Available: stat.Bavail * uint64(stat.Bsize),
+        Total: stat.Blocks * uint64(stat.Bsize),
+    }, nil
+}
+
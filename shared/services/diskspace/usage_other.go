@@ -0,0 +1,15 @@
+// +build !linux
+
+package diskspace
+
+import (
+    "errors"
+)
+
+
+// Sample the available & total space of the filesystem a path is on
+// Disk space is only sampled by the node daemon, which runs on Linux
+func GetSample(path string) (Sample, error) {
+    return Sample{}, errors.New("Disk space sampling is only supported on Linux")
+}
+
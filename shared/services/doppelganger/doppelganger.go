@@ -0,0 +1,92 @@
+package doppelganger
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+
+// Config
+const (
+    KeyChangeFile = "doppelganger.json"
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Validator key change record
+type keyChange struct {
+    Time int64 `json:"time"`
+}
+
+
+// Record that validator keys have been imported or recovered
+func RecordKeyChange(dataPath string) error {
+
+    // Encode key change
+    keyChangeBytes, err := json.Marshal(keyChange{Time: time.Now().Unix()})
+    if err != nil {
+        return fmt.Errorf("Could not encode validator key change: %w", err)
+    }
+
+    // Write key change to disk
+    path := filepath.Join(dataPath, KeyChangeFile)
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create validator key change folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path, keyChangeBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write validator key change to disk: %w", err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get the time validator keys were last imported or recovered, if pending
+func GetKeyChangeTime(dataPath string) (time.Time, bool, error) {
+
+    // Read key change from disk; no pending change if not found
+    keyChangeBytes, err := ioutil.ReadFile(filepath.Join(dataPath, KeyChangeFile))
+    if os.IsNotExist(err) {
+        return time.Time{}, false, nil
+    }
+    if err != nil {
+        return time.Time{}, false, fmt.Errorf("Could not read validator key change: %w", err)
+    }
+
+    // Decode key change
+    var change keyChange
+    if err := json.Unmarshal(keyChangeBytes, &change); err != nil {
+        return time.Time{}, false, fmt.Errorf("Could not decode validator key change: %w", err)
+    }
+
+    // Return
+    return time.Unix(change.Time, 0), true, nil
+
+}
+
+
+// Clear a pending validator key change
+func ClearKeyChange(dataPath string) error {
+    if err := os.Remove(filepath.Join(dataPath, KeyChangeFile)); err != nil && !os.IsNotExist(err) {
+        return fmt.Errorf("Could not remove validator key change: %w", err)
+    }
+    return nil
+}
+
+
+// Get the time until which the validator must remain stopped after a key change
+// Returns false if there is no pending key change
+func GetDelayEnd(dataPath string, delayEpochs, secondsPerEpoch uint64) (time.Time, bool, error) {
+    changeTime, pending, err := GetKeyChangeTime(dataPath)
+    if err != nil || !pending {
+        return time.Time{}, false, err
+    }
+    return changeTime.Add(time.Duration(delayEpochs * secondsPerEpoch) * time.Second), true, nil
+}
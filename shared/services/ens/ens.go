@@ -0,0 +1,139 @@
+package ens
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/crypto"
+    "github.com/ethereum/go-ethereum/ethclient"
+)
+
+
+// ENS registry address, which is the same on mainnet and the Goerli testnet
+var RegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+
+// Contract ABIs
+const (
+    registryAbi = `[{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"resolver","outputs":[{"name":"","type":"address"}],"payable":false,"stateMutability":"view","type":"function"}]`
+    resolverAbi = `[{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"addr","outputs":[{"name":"","type":"address"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"name","outputs":[{"name":"","type":"string"}],"payable":false,"stateMutability":"view","type":"function"}]`
+)
+
+
+// Reverse resolution domain
+const reverseDomain = "addr.reverse"
+
+
+// Check whether a value is an ENS name rather than an address
+func IsName(value string) bool {
+    return strings.Contains(value, ".") && !common.IsHexAddress(value)
+}
+
+
+// Normalize an ENS name
+// Names are lowercased & trimmed; full UTS-46 normalization is not performed, so names should be ASCII
+func Normalize(name string) string {
+    return strings.ToLower(strings.TrimSpace(name))
+}
+
+
+// Get the namehash of a normalized ENS name
+func NameHash(name string) common.Hash {
+    var node common.Hash
+    if name == "" {
+        return node
+    }
+    labels := strings.Split(name, ".")
+    for li := len(labels) - 1; li >= 0; li-- {
+        node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[li])))
+    }
+    return node
+}
+
+
+// Resolve an ENS name to an address
+func ResolveName(client *ethclient.Client, name string) (common.Address, error) {
+
+    // Get name node
+    name = Normalize(name)
+    node := NameHash(name)
+
+    // Get resolver
+    resolverAddress := new(common.Address)
+    if err := call(client, RegistryAddress, registryAbi, resolverAddress, "resolver", node); err != nil {
+        return common.Address{}, fmt.Errorf("Could not get ENS resolver for %s: %w", name, err)
+    }
+    if *resolverAddress == (common.Address{}) {
+        return common.Address{}, fmt.Errorf("ENS name %s is not registered", name)
+    }
+
+    // Resolve address
+    address := new(common.Address)
+    if err := call(client, *resolverAddress, resolverAbi, address, "addr", node); err != nil {
+        return common.Address{}, fmt.Errorf("Could not resolve ENS name %s: %w", name, err)
+    }
+    if *address == (common.Address{}) {
+        return common.Address{}, fmt.Errorf("ENS name %s does not resolve to an address", name)
+    }
+
+    // Return
+    return *address, nil
+
+}
+
+
+// Look up the primary ENS name of an address; returns an empty name if the address has none
+// Names are only returned if they resolve back to the address, as anyone can set the reverse record of their own address to any name
+func LookupAddress(client *ethclient.Client, address common.Address) (string, error) {
+
+    // Get reverse record node
+    node := NameHash(fmt.Sprintf("%s.%s", strings.ToLower(address.Hex()[2:]), reverseDomain))
+
+    // Get resolver
+    resolverAddress := new(common.Address)
+    if err := call(client, RegistryAddress, registryAbi, resolverAddress, "resolver", node); err != nil {
+        return "", fmt.Errorf("Could not get ENS reverse resolver for %s: %w", address.Hex(), err)
+    }
+    if *resolverAddress == (common.Address{}) {
+        return "", nil
+    }
+
+    // Get name
+    name := new(string)
+    if err := call(client, *resolverAddress, resolverAbi, name, "name", node); err != nil {
+        return "", fmt.Errorf("Could not look up ENS name for %s: %w", address.Hex(), err)
+    }
+    if *name == "" {
+        return "", nil
+    }
+
+    // Check forward resolution
+    resolved, err := ResolveName(client, *name)
+    if err != nil || resolved != address {
+        return "", nil
+    }
+
+    // Return
+    return Normalize(*name), nil
+
+}
+
+
+// Call an ENS contract method
+func call(client *ethclient.Client, contractAddress common.Address, contractAbi string, result interface{}, method string, args ...interface{}) error {
+    parsed, err := abi.JSON(strings.NewReader(contractAbi))
+    if err != nil {
+        return err
+    }
+    contract := bind.NewBoundContract(contractAddress, parsed, client, client, client)
+    if err := contract.Call(nil, result, method, args...); errors.Is(err, bind.ErrNoCode) {
+        return errors.New("ENS is not available on the eth 1.0 network")
+    } else if err != nil {
+        return err
+    }
+    return nil
+}
@@ -0,0 +1,178 @@
+package events
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+    netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+
+// Config
+const (
+    SocketFile = "events.sock"
+    StreamPath = "/events"
+    SubscriberBufferSize = 64
+)
+var keepAliveInterval, _ = time.ParseDuration("30s")
+
+
+// Event types
+const (
+    MinipoolStatusChanged = "minipool-status"
+    DepositDetected = "deposit"
+    RewardsClaimable = "rewards-claimable"
+    SyncStatusChanged = "sync-status"
+)
+
+
+// Node event
+type Event struct {
+    ID uint64           `json:"id"`
+    Type string         `json:"type"`
+    Time time.Time      `json:"time"`
+    Data interface{}    `json:"data"`
+}
+
+
+// Event data
+type MinipoolStatusData struct {
+    Minipool common.Address                 `json:"minipool"`
+    PreviousStatus rptypes.MinipoolStatus   `json:"previousStatus"`
+    Status rptypes.MinipoolStatus           `json:"status"`
+}
+type DepositData struct {
+    Minipool common.Address                 `json:"minipool"`
+    Status rptypes.MinipoolStatus           `json:"status"`
+}
+type RewardsClaimableData struct {
+    Minipool common.Address                 `json:"minipool"`
+}
+type SyncStatusData struct {
+    Client string                           `json:"client"`
+    Synced bool                             `json:"synced"`
+}
+
+
+// Node event stream
+// Events are published to all subscribers; events are dropped for subscribers which fall behind
+type Stream struct {
+    subscribers map[chan Event]bool
+    nextID uint64
+    lock sync.Mutex
+}
+
+
+// Create new event stream
+func NewStream() *Stream {
+    return &Stream{
+        subscribers: make(map[chan Event]bool),
+        nextID: 1,
+    }
+}
+
+
+// Publish an event to all subscribers
+func (s *Stream) Publish(eventType string, data interface{}) {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    event := Event{
+        ID: s.nextID,
+        Type: eventType,
+        Time: time.Now(),
+        Data: data,
+    }
+    s.nextID++
+    for subscriber := range s.subscribers {
+        select {
+            case subscriber <- event:
+            default:
+        }
+    }
+}
+
+
+// Serve the event stream on a unix socket as server-sent events
+// Subscribers may filter events by type with a comma-separated types parameter, e.g. /events?types=deposit,rewards-claimable
+func (s *Stream) Serve(socketPath string) error {
+    listener, err := netutils.ListenUnix(socketPath)
+    if err != nil {
+        return err
+    }
+    mux := http.NewServeMux()
+    mux.HandleFunc(StreamPath, s.handleSubscriber)
+    return http.Serve(listener, mux)
+}
+
+
+// Stream events to a subscriber
+func (s *Stream) handleSubscriber(w http.ResponseWriter, r *http.Request) {
+
+    // Check method & streaming support
+    if r.Method != http.MethodGet {
+        http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+        return
+    }
+
+    // Get event type filter
+    types := make(map[string]bool)
+    if typesParam := r.URL.Query().Get("types"); typesParam != "" {
+        for _, eventType := range strings.Split(typesParam, ",") {
+            types[strings.TrimSpace(eventType)] = true
+        }
+    }
+
+    // Subscribe
+    subscriber := make(chan Event, SubscriberBufferSize)
+    s.lock.Lock()
+    s.subscribers[subscriber] = true
+    s.lock.Unlock()
+    defer (func() {
+        s.lock.Lock()
+        delete(s.subscribers, subscriber)
+        s.lock.Unlock()
+    })()
+
+    // Start stream
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.WriteHeader(http.StatusOK)
+    flusher.Flush()
+
+    // Write events
+    for {
+        select {
+            case event := <-subscriber:
+                if len(types) > 0 && !types[event.Type] {
+                    continue
+                }
+                eventBytes, err := json.Marshal(event)
+                if err != nil {
+                    continue
+                }
+                if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, eventBytes); err != nil {
+                    return
+                }
+            case <-time.After(keepAliveInterval):
+                if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+                    return
+                }
+            case <-r.Context().Done():
+                return
+        }
+        flusher.Flush()
+    }
+
+}
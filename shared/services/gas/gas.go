@@ -0,0 +1,94 @@
+package gas
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "sort"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Config
+const (
+    FeeHistoryBlocks = 10
+    FeeHistoryRewardPercentile = 50
+    BaseFeeHeadroomPercent = 125 // Allow for base fee increases before the transaction is mined
+)
+
+
+// eth_feeHistory response
+type feeHistory struct {
+    BaseFeePerGas []*hexutil.Big    `json:"baseFeePerGas"`
+    Reward [][]*hexutil.Big         `json:"reward"`
+}
+
+
+// Get a suggested gas price
+// Uses the fee history of recent blocks if the client supports it (EIP-1559), or the client's suggested gas price otherwise
+// If priorityFeeGwei is 0, the median priority fee paid in recent blocks is used
+func GetGasPrice(rc *rpc.Client, ec *ethclient.Client, priorityFeeGwei float64) (*big.Int, error) {
+
+    // Get fee history; fall back to client gas price if unsupported
+    var history feeHistory
+    if err := rc.CallContext(context.Background(), &history, "eth_feeHistory", hexutil.Uint(FeeHistoryBlocks), "latest", []float64{FeeHistoryRewardPercentile}); err != nil || len(history.BaseFeePerGas) == 0 {
+        gasPrice, err := ec.SuggestGasPrice(context.Background())
+        if err != nil {
+            return nil, fmt.Errorf("Could not get gas price: %w", err)
+        }
+        if priorityFeeGwei > 0 {
+            gasPrice.Add(gasPrice, eth.GweiToWei(priorityFeeGwei))
+        }
+        return gasPrice, nil
+    }
+
+    // Get next block base fee with headroom
+    nextBaseFee := (*big.Int)(history.BaseFeePerGas[len(history.BaseFeePerGas) - 1])
+    gasPrice, err := safemath.Percent(nextBaseFee, BaseFeeHeadroomPercent)
+    if err != nil {
+        return nil, fmt.Errorf("Could not calculate gas price: %w", err)
+    }
+
+    // Add priority fee
+    if priorityFeeGwei > 0 {
+        gasPrice.Add(gasPrice, eth.GweiToWei(priorityFeeGwei))
+    } else {
+        gasPrice.Add(gasPrice, getMedianReward(history.Reward))
+    }
+
+    // Return
+    return gasPrice, nil
+
+}
+
+
+// Get the median priority fee paid in recent blocks
+func getMedianReward(rewards [][]*hexutil.Big) *big.Int {
+    values := []*big.Int{}
+    for _, blockRewards := range rewards {
+        if len(blockRewards) > 0 && blockRewards[0] != nil {
+            values = append(values, (*big.Int)(blockRewards[0]))
+        }
+    }
+    if len(values) == 0 {
+        return big.NewInt(0)
+    }
+    sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+    return new(big.Int).Set(values[len(values) / 2])
+}
+
+
+// Check a gas price against a maximum fee in gwei; a maximum of 0 means no limit
+func CheckMaxFee(gasPrice *big.Int, maxFeeGwei float64) error {
+    if maxFeeGwei > 0 && gasPrice.Cmp(eth.GweiToWei(maxFeeGwei)) > 0 {
+        return fmt.Errorf("The current gas price of %s exceeds the maximum fee of %s.", units.FormatGwei(gasPrice), units.FormatGwei(eth.GweiToWei(maxFeeGwei)))
+    }
+    return nil
+}
@@ -0,0 +1,228 @@
+package jobs
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+)
+
+
+// Config
+const (
+    JobsFile = "jobs.json"
+    MaxAttempts = 3
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Job types
+const (
+    TypeRefund = "refund"
+    TypeWithdraw = "withdraw"
+    TypeClose = "close"
+)
+var Types = []string{TypeRefund, TypeWithdraw, TypeClose}
+
+
+// Job statuses
+const (
+    StatusPending = "pending"
+    StatusRunning = "running"
+    StatusDone = "done"
+    StatusFailed = "failed"
+    StatusCancelled = "cancelled"
+)
+
+
+// A deferred minipool action, run by the node daemon once its conditions are met
+// MaxGasPrice is in gwei; a zero value or time means the condition does not apply
+type Job struct {
+    ID uint64                   `json:"id"`
+    Type string                 `json:"type"`
+    Minipool common.Address     `json:"minipool"`
+    MaxGasPrice float64         `json:"maxGasPrice"`
+    NotBefore time.Time         `json:"notBefore"`
+    Status string               `json:"status"`
+    Attempts int                `json:"attempts"`
+    Nonce uint64                `json:"nonce"`
+    TxHash common.Hash          `json:"txHash"`
+    Error string                `json:"error,omitempty"`
+    Created time.Time           `json:"created"`
+    Updated time.Time           `json:"updated"`
+}
+
+
+// Get the job description; this is also recorded against the job's transaction in the transaction queue
+func (j Job) Description() string {
+    switch j.Type {
+        case TypeRefund: return fmt.Sprintf("Job %d: refund minipool %s", j.ID, j.Minipool.Hex())
+        case TypeWithdraw: return fmt.Sprintf("Job %d: withdraw minipool %s", j.ID, j.Minipool.Hex())
+        case TypeClose: return fmt.Sprintf("Job %d: close minipool %s", j.ID, j.Minipool.Hex())
+    }
+    return fmt.Sprintf("Job %d: %s minipool %s", j.ID, j.Type, j.Minipool.Hex())
+}
+
+
+// Check whether the job has finished
+func (j Job) Finished() bool {
+    return (j.Status == StatusDone || j.Status == StatusFailed || j.Status == StatusCancelled)
+}
+
+
+// Check whether a job type is valid
+func ValidType(jobType string) bool {
+    for _, t := range Types {
+        if t == jobType {
+            return true
+        }
+    }
+    return false
+}
+
+
+// Jobs file lock
+var lock sync.Mutex
+
+
+// Get jobs, oldest first
+func Load(dataPath string) ([]Job, error) {
+    lock.Lock()
+    defer lock.Unlock()
+    return load(dataPath)
+}
+
+
+// Add a pending job, assigning it the next ID
+func Add(dataPath string, job Job) (Job, error) {
+    lock.Lock()
+    defer lock.Unlock()
+
+    // Load jobs
+    jobs, err := load(dataPath)
+    if err != nil {
+        return Job{}, err
+    }
+
+    // Initialize & add job
+    job.ID = 1
+    if len(jobs) > 0 {
+        job.ID = jobs[len(jobs) - 1].ID + 1
+    }
+    job.Status = StatusPending
+    job.Created = time.Now()
+    job.Updated = job.Created
+    jobs = append(jobs, job)
+
+    // Save & return
+    if err := save(dataPath, jobs); err != nil {
+        return Job{}, err
+    }
+    return job, nil
+
+}
+
+
+// Cancel a pending job
+func Cancel(dataPath string, id uint64) (Job, error) {
+    return Update(dataPath, id, func(job *Job) error {
+        if job.Status != StatusPending {
+            return fmt.Errorf("Job %d is %s and cannot be cancelled", id, job.Status)
+        }
+        job.Status = StatusCancelled
+        return nil
+    })
+}
+
+
+// Update a job; the jobs file is reloaded first so that changes made by other processes are not lost
+// No changes are saved if update returns an error
+func Update(dataPath string, id uint64, update func(job *Job) error) (Job, error) {
+    lock.Lock()
+    defer lock.Unlock()
+
+    // Load jobs
+    jobs, err := load(dataPath)
+    if err != nil {
+        return Job{}, err
+    }
+
+    // Get job
+    var job *Job
+    for ji := range jobs {
+        if jobs[ji].ID == id {
+            job = &jobs[ji]
+            break
+        }
+    }
+    if job == nil {
+        return Job{}, fmt.Errorf("Job %d was not found", id)
+    }
+
+    // Update job
+    if err := update(job); err != nil {
+        return Job{}, err
+    }
+    job.Updated = time.Now()
+
+    // Save & return
+    if err := save(dataPath, jobs); err != nil {
+        return Job{}, err
+    }
+    return *job, nil
+
+}
+
+
+// Load jobs from the data folder
+func load(dataPath string) ([]Job, error) {
+
+    // Read jobs; no jobs if not found
+    jobsBytes, err := ioutil.ReadFile(filepath.Join(dataPath, JobsFile))
+    if os.IsNotExist(err) {
+        return []Job{}, nil
+    }
+    if err != nil {
+        return []Job{}, fmt.Errorf("Could not read jobs: %w", err)
+    }
+
+    // Decode jobs
+    var jobs []Job
+    if err := json.Unmarshal(jobsBytes, &jobs); err != nil {
+        return []Job{}, fmt.Errorf("Could not decode jobs: %w", err)
+    }
+    return jobs, nil
+
+}
+
+
+// Save jobs to the data folder
+// Jobs are written to a temporary file and renamed so that readers never see a partial write
+func save(dataPath string, jobs []Job) error {
+
+    // Encode jobs
+    jobsBytes, err := json.MarshalIndent(jobs, "", "    ")
+    if err != nil {
+        return fmt.Errorf("Could not encode jobs: %w", err)
+    }
+
+    // Write jobs
+    path := filepath.Join(dataPath, JobsFile)
+    if err := os.MkdirAll(dataPath, DirMode); err != nil {
+        return fmt.Errorf("Could not create jobs folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path + ".tmp", jobsBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write jobs: %w", err)
+    }
+    if err := os.Rename(path + ".tmp", path); err != nil {
+        return fmt.Errorf("Could not write jobs: %w", err)
+    }
+    return nil
+
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+    "fmt"
+    "path/filepath"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Configure daemon logging from the log settings
+// Daemons log to stdout, and to <log path>/<daemon name>.log if a log path is set
+func ConfigureLogging(c config.Context, daemonName string) error {
+
+    // Get config
+    cfg, err := getConfig(c)
+    if err != nil {
+        return err
+    }
+
+    // Get options
+    options, err := getLogOptions(cfg, daemonName)
+    if err != nil {
+        return err
+    }
+
+    // Configure logging
+    return log.Configure(options)
+
+}
+
+
+// Get daemon logging options from the log settings
+func getLogOptions(cfg config.RocketPoolConfig, daemonName string) (log.Options, error) {
+    options := log.Options{
+        Level: log.LevelInfo,
+        MaxSize: cfg.Log.MaxSizeMB * 1024 * 1024,
+        MaxBackups: cfg.Log.MaxBackups,
+    }
+    if cfg.Log.Level != "" {
+        level, err := log.ParseLevel(cfg.Log.Level)
+        if err != nil {
+            return log.Options{}, err
+        }
+        options.Level = level
+    }
+    switch cfg.Log.Format {
+        case "", "text":
+        case "json": options.JSON = true
+        default: return log.Options{}, fmt.Errorf("Unknown log format '%s'", cfg.Log.Format)
+    }
+    if cfg.Log.Path != "" {
+        options.File = filepath.Join(cfg.Log.Path, daemonName + ".log")
+    }
+    return options, nil
+}
+
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+    "fmt"
+    "io"
+    "net/http"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+
+// Config
+const (
+    MetricsPath = "/metrics"
+    ContentType = "text/plain; version=0.0.4"
+    WatchtowerNamespace = "rocketpool_watchtower"
+    NodeNamespace = "rocketpool_node"
+)
+
+
+// Metric types
+const (
+    TypeCounter = "counter"
+    TypeGauge = "gauge"
+)
+
+
+// Metrics registry
+// Metrics are exposed in the Prometheus text format; labels are given to Add and Set as name, value pairs
+type Registry struct {
+    namespace string
+    metrics map[string]*metric
+    lock sync.Mutex
+}
+type metric struct {
+    help string
+    metricType string
+    values map[string]float64
+}
+
+
+// Create new metrics registry; metric names are prefixed with the namespace
+func NewRegistry(namespace string) *Registry {
+    return &Registry{
+        namespace: namespace,
+        metrics: make(map[string]*metric),
+    }
+}
+
+
+// Define a counter
+func (r *Registry) Counter(name, help string) {
+    r.define(name, help, TypeCounter)
+}
+
+
+// Define a gauge
+func (r *Registry) Gauge(name, help string) {
+    r.define(name, help, TypeGauge)
+}
+
+
+// Add to a metric value
+func (r *Registry) Add(name string, value float64, labels ...string) {
+    r.lock.Lock()
+    defer r.lock.Unlock()
+    if m, ok := r.metrics[name]; ok {
+        m.values[formatLabels(labels)] += value
+    }
+}
+
+
+// Set a metric value
+func (r *Registry) Set(name string, value float64, labels ...string) {
+    r.lock.Lock()
+    defer r.lock.Unlock()
+    if m, ok := r.metrics[name]; ok {
+        m.values[formatLabels(labels)] = value
+    }
+}
+
+
+// Write all metrics in the Prometheus text format
+func (r *Registry) Write(w io.Writer) error {
+    r.lock.Lock()
+    defer r.lock.Unlock()
+
+    // Sort metric names
+    names := make([]string, 0, len(r.metrics))
+    for name := range r.metrics {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+
+    // Write metrics
+    for _, name := range names {
+        m := r.metrics[name]
+        fullName := r.namespace + "_" + name
+        if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", fullName, m.help, fullName, m.metricType); err != nil {
+            return err
+        }
+        labelSets := make([]string, 0, len(m.values))
+        for labels := range m.values {
+            labelSets = append(labelSets, labels)
+        }
+        sort.Strings(labelSets)
+        for _, labels := range labelSets {
+            if _, err := fmt.Fprintf(w, "%s%s %s\n", fullName, labels, strconv.FormatFloat(m.values[labels], 'g', -1, 64)); err != nil {
+                return err
+            }
+        }
+    }
+    return nil
+
+}
+
+
+// Serve metrics over HTTP at the metrics path
+func (r *Registry) Serve(address string) error {
+    mux := http.NewServeMux()
+    mux.HandleFunc(MetricsPath, func(w http.ResponseWriter, req *http.Request) {
+        w.Header().Set("Content-Type", ContentType)
+        r.Write(w)
+    })
+    return http.ListenAndServe(address, mux)
+}
+
+
+// Define a metric if it is not already defined
+func (r *Registry) define(name, help, metricType string) {
+    r.lock.Lock()
+    defer r.lock.Unlock()
+    if _, ok := r.metrics[name]; ok {
+        return
+    }
+    r.metrics[name] = &metric{
+        help: help,
+        metricType: metricType,
+        values: make(map[string]float64),
+    }
+}
+
+
+// Format label name, value pairs, e.g. {type="balances"}
+func formatLabels(labels []string) string {
+    if len(labels) < 2 {
+        return ""
+    }
+    pairs := []string{}
+    for li := 0; li + 1 < len(labels); li += 2 {
+        pairs = append(pairs, fmt.Sprintf("%s=%s", labels[li], strconv.Quote(labels[li + 1])))
+    }
+    return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,220 @@
+package multicall
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/rpc"
+)
+
+
+// Config
+const (
+    MaxBatchSize = 500
+    multicallABI = `[{"inputs":[{"internalType":"bool","name":"requireSuccess","type":"bool"},{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall2.Call[]","name":"calls","type":"tuple[]"}],"name":"tryAggregate","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall2.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"nonpayable","type":"function"}]`
+)
+
+
+// Multicall2 call & result types
+type multicallCall struct {
+    Target common.Address
+    CallData []byte
+}
+type multicallResult struct {
+    Success bool
+    ReturnData []byte
+}
+
+
+// eth_call request
+type callArgs struct {
+    To common.Address               `json:"to"`
+    Data hexutil.Bytes              `json:"data"`
+}
+
+
+// Batched contract call client
+// Calls are aggregated into a single eth_call to a Multicall2 contract if its address is configured,
+// and are otherwise sent as a single JSON-RPC batch request
+type Client struct {
+    rpcClient *rpc.Client
+    address common.Address
+    abi abi.ABI
+}
+
+
+// A batch of contract calls
+type Batch struct {
+    client *Client
+    blockNumber *big.Int
+    calls []call
+}
+type call struct {
+    target common.Address
+    abi *abi.ABI
+    method string
+    data []byte
+    output interface{}
+}
+
+
+// Create new batched contract call client; a zero multicall address disables Multicall2 aggregation
+func NewClient(rpcClient *rpc.Client, multicallAddress common.Address) (*Client, error) {
+    multicall, err := abi.JSON(strings.NewReader(multicallABI))
+    if err != nil {
+        return nil, fmt.Errorf("Could not decode multicall ABI: %w", err)
+    }
+    return &Client{
+        rpcClient: rpcClient,
+        address: multicallAddress,
+        abi: multicall,
+    }, nil
+}
+
+
+// Create a new batch of contract calls
+func (c *Client) NewBatch() *Batch {
+    return &Batch{client: c}
+}
+
+
+// Create a new batch of contract calls against the state at a block; a nil block number uses the latest state
+func (c *Client) NewBatchAt(blockNumber *big.Int) *Batch {
+    return &Batch{client: c, blockNumber: blockNumber}
+}
+
+
+// Add a contract call to the batch; its result is unpacked into output when the batch is executed
+func (b *Batch) AddCall(target common.Address, contractABI *abi.ABI, output interface{}, method string, args ...interface{}) error {
+    data, err := contractABI.Pack(method, args...)
+    if err != nil {
+        return fmt.Errorf("Could not encode %s call to %s: %w", method, target.Hex(), err)
+    }
+    b.calls = append(b.calls, call{
+        target: target,
+        abi: contractABI,
+        method: method,
+        data: data,
+        output: output,
+    })
+    return nil
+}
+
+
+// Execute the batch's calls, in chunks of up to MaxBatchSize calls
+func (b *Batch) Execute() error {
+    for start := 0; start < len(b.calls); start += MaxBatchSize {
+        end := start + MaxBatchSize
+        if end > len(b.calls) {
+            end = len(b.calls)
+        }
+        var err error
+        if b.client.address != (common.Address{}) {
+            err = b.client.aggregate(b.calls[start:end], b.blockNumber)
+        } else {
+            err = b.client.batchCall(b.calls[start:end], b.blockNumber)
+        }
+        if err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+
+// Execute calls in a single eth_call to the Multicall2 contract
+func (c *Client) aggregate(calls []call, blockNumber *big.Int) error {
+
+    // Encode calls
+    multicallCalls := make([]multicallCall, len(calls))
+    for ci, cl := range calls {
+        multicallCalls[ci] = multicallCall{Target: cl.target, CallData: cl.data}
+    }
+    data, err := c.abi.Pack("tryAggregate", false, multicallCalls)
+    if err != nil {
+        return fmt.Errorf("Could not encode multicall: %w", err)
+    }
+
+    // Call multicall contract
+    var response hexutil.Bytes
+    if err := c.rpcClient.CallContext(context.Background(), &response, "eth_call", callArgs{To: c.address, Data: data}, toBlockNumArg(blockNumber)); err != nil {
+        return fmt.Errorf("Could not execute multicall: %w", err)
+    }
+
+    // Decode results
+    var results []multicallResult
+    if err := c.abi.Unpack(&results, "tryAggregate", response); err != nil {
+        return fmt.Errorf("Could not decode multicall results: %w", err)
+    }
+    if len(results) != len(calls) {
+        return fmt.Errorf("Multicall returned %d results for %d calls", len(results), len(calls))
+    }
+    for ri, result := range results {
+        if !result.Success {
+            return fmt.Errorf("Could not execute %s call to %s: the call reverted", calls[ri].method, calls[ri].target.Hex())
+        }
+        if err := unpackResult(calls[ri], result.ReturnData); err != nil {
+            return err
+        }
+    }
+    return nil
+
+}
+
+
+// Execute calls as a single JSON-RPC batch request
+func (c *Client) batchCall(calls []call, blockNumber *big.Int) error {
+
+    // Send batch
+    responses := make([]hexutil.Bytes, len(calls))
+    batch := make([]rpc.BatchElem, len(calls))
+    for ci, cl := range calls {
+        batch[ci] = rpc.BatchElem{
+            Method: "eth_call",
+            Args: []interface{}{callArgs{To: cl.target, Data: cl.data}, toBlockNumArg(blockNumber)},
+            Result: &responses[ci],
+        }
+    }
+    if err := c.rpcClient.BatchCallContext(context.Background(), batch); err != nil {
+        return fmt.Errorf("Could not execute batched calls: %w", err)
+    }
+
+    // Decode results
+    for ci, cl := range calls {
+        if batch[ci].Error != nil {
+            return fmt.Errorf("Could not execute %s call to %s: %w", cl.method, cl.target.Hex(), batch[ci].Error)
+        }
+        if err := unpackResult(cl, responses[ci]); err != nil {
+            return err
+        }
+    }
+    return nil
+
+}
+
+
+// Unpack a call result into its output
+func unpackResult(cl call, data []byte) error {
+    if len(data) == 0 {
+        return fmt.Errorf("Could not execute %s call to %s: no data was returned", cl.method, cl.target.Hex())
+    }
+    if err := cl.abi.Unpack(cl.output, cl.method, data); err != nil {
+        return fmt.Errorf("Could not decode %s result from %s: %w", cl.method, cl.target.Hex(), err)
+    }
+    return nil
+}
+
+
+// Get the eth_call block parameter for a block number
+func toBlockNumArg(blockNumber *big.Int) string {
+    if blockNumber == nil {
+        return "latest"
+    }
+    return hexutil.EncodeBig(blockNumber)
+}
+
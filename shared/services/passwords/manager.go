@@ -4,6 +4,7 @@ import (
     "errors"
     "fmt"
     "io/ioutil"
+    "sync"
 )
 
 
@@ -15,8 +16,12 @@ const (
 
 
 // Password manager
+// The password is stored on disk, unless it is provided by an external source
 type PasswordManager struct {
     passwordPath string
+    source Source
+    sourcePassword string
+    lock sync.Mutex
 }
 
 
@@ -28,8 +33,20 @@ func NewPasswordManager(passwordPath string) *PasswordManager {
 }
 
 
+// Create new password manager which gets the password from an external source
+func NewSourcePasswordManager(source Source) *PasswordManager {
+    return &PasswordManager{
+        source: source,
+    }
+}
+
+
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
+    if pm.source != nil {
+        _, err := pm.GetPassword()
+        return (err == nil)
+    }
     _, err := ioutil.ReadFile(pm.passwordPath)
     return (err == nil)
 }
@@ -38,6 +55,20 @@ func (pm *PasswordManager) IsPasswordSet() bool {
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
 
+    // Get from external source; the password is cached once retrieved
+    if pm.source != nil {
+        pm.lock.Lock()
+        defer pm.lock.Unlock()
+        if pm.sourcePassword == "" {
+            password, err := pm.source.GetPassword()
+            if err != nil {
+                return "", err
+            }
+            pm.sourcePassword = password
+        }
+        return pm.sourcePassword, nil
+    }
+
     // Read from disk
     password, err := ioutil.ReadFile(pm.passwordPath)
     if err != nil {
@@ -53,6 +84,11 @@ func (pm *PasswordManager) GetPassword() (string, error) {
 // Set the password
 func (pm *PasswordManager) SetPassword(password string) error {
 
+    // Check password is not provided by an external source
+    if pm.source != nil {
+        return errors.New("The password is provided by an external password source and cannot be set")
+    }
+
     // Check password is not set
     if pm.IsPasswordSet() {
         return errors.New("Password is already set")
@@ -0,0 +1,148 @@
+package passwords
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "os/exec"
+    "runtime"
+    "strings"
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Password source types
+const (
+    FileSourceType = "file"
+    EnvSourceType = "env"
+    ExecSourceType = "exec"
+    KeychainSourceType = "keychain"
+)
+
+
+// Settings
+const (
+    DefaultKeychainService = "rocketpool"
+    DefaultKeychainAccount = "node-password"
+)
+var execSourceTimeout, _ = time.ParseDuration("30s")
+
+
+// External password source
+type Source interface {
+    GetPassword() (string, error)
+}
+
+
+// Create a password source from its settings
+func NewSource(cfg config.PasswordSource) (Source, error) {
+    switch cfg.Type {
+        case FileSourceType:
+            if cfg.Path == "" {
+                return nil, errors.New("The file password source requires a path")
+            }
+            return &fileSource{path: cfg.Path}, nil
+        case EnvSourceType:
+            if cfg.Env == "" {
+                return nil, errors.New("The env password source requires an environment variable name")
+            }
+            return &envSource{name: cfg.Env}, nil
+        case ExecSourceType:
+            if cfg.Command == "" {
+                return nil, errors.New("The exec password source requires a command")
+            }
+            return &execSource{command: cfg.Command}, nil
+        case KeychainSourceType:
+            service := cfg.Service
+            if service == "" { service = DefaultKeychainService }
+            account := cfg.Account
+            if account == "" { account = DefaultKeychainAccount }
+            return &keychainSource{service: service, account: account}, nil
+        default:
+            return nil, fmt.Errorf("Unknown password source type '%s'; valid types are '%s', '%s', '%s' and '%s'", cfg.Type, FileSourceType, EnvSourceType, ExecSourceType, KeychainSourceType)
+    }
+}
+
+
+// File password source
+// The file must only be accessible to its owner, so that the password is not exposed to other users
+type fileSource struct {
+    path string
+}
+func (s *fileSource) GetPassword() (string, error) {
+    info, err := os.Stat(s.path)
+    if err != nil {
+        return "", fmt.Errorf("Could not read password file: %w", err)
+    }
+    if info.Mode().Perm() & 0077 != 0 {
+        return "", fmt.Errorf("Password file %s must only be accessible to its owner (mode 0600), but has mode %04o", s.path, info.Mode().Perm())
+    }
+    password, err := ioutil.ReadFile(s.path)
+    if err != nil {
+        return "", fmt.Errorf("Could not read password file: %w", err)
+    }
+    return strings.TrimRight(string(password), "\r\n"), nil
+}
+
+
+// Environment variable password source
+type envSource struct {
+    name string
+}
+func (s *envSource) GetPassword() (string, error) {
+    password, ok := os.LookupEnv(s.name)
+    if !ok {
+        return "", fmt.Errorf("Password environment variable %s is not set", s.name)
+    }
+    return password, nil
+}
+
+
+// External command password source; the password is read from the command's output
+type execSource struct {
+    command string
+}
+func (s *execSource) GetPassword() (string, error) {
+    return runPasswordCommand("sh", "-c", s.command)
+}
+
+
+// OS keychain password source, using secret-tool on Linux and security on macOS
+type keychainSource struct {
+    service string
+    account string
+}
+func (s *keychainSource) GetPassword() (string, error) {
+    switch runtime.GOOS {
+        case "linux":
+            return runPasswordCommand("secret-tool", "lookup", "service", s.service, "account", s.account)
+        case "darwin":
+            return runPasswordCommand("security", "find-generic-password", "-s", s.service, "-a", s.account, "-w")
+        default:
+            return "", fmt.Errorf("The keychain password source is not supported on %s", runtime.GOOS)
+    }
+}
+
+
+// Run a command which prints a password
+func runPasswordCommand(name string, args ...string) (string, error) {
+    ctx, cancel := context.WithTimeout(context.Background(), execSourceTimeout)
+    defer cancel()
+    var stderr bytes.Buffer
+    cmd := exec.CommandContext(ctx, name, args...)
+    cmd.Stderr = &stderr
+    output, err := cmd.Output()
+    if err != nil {
+        return "", fmt.Errorf("Could not get password from %s: %w %s", name, err, strings.TrimSpace(stderr.String()))
+    }
+    password := strings.TrimRight(string(output), "\r\n")
+    if password == "" {
+        return "", fmt.Errorf("Could not get password from %s: no password was returned", name)
+    }
+    return password, nil
+}
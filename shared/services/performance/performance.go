@@ -0,0 +1,139 @@
+package performance
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/types"
+
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+
+// Config
+const (
+    PerformanceFile = "validator-performance.json"
+    FileMode = 0600
+)
+
+
+// Recorded validator performance
+// Validators are keyed by minipool address; totals are counted from the first epoch tracked for each validator
+type Record struct {
+    LastEpoch uint64                        `json:"lastEpoch"`
+    Validators map[string]*Validator        `json:"validators"`
+}
+type Validator struct {
+    MinipoolAddress common.Address          `json:"minipoolAddress"`
+    Pubkey types.ValidatorPubkey            `json:"pubkey"`
+    Index uint64                            `json:"index"`
+    FirstEpoch uint64                       `json:"firstEpoch"`
+    ActiveEpochs uint64                     `json:"activeEpochs"`
+    Attestations uint64                     `json:"attestations"`
+    MissedAttestations uint64               `json:"missedAttestations"`
+    CorrectTargets uint64                   `json:"correctTargets"`
+    CorrectHeads uint64                     `json:"correctHeads"`
+    InclusionDistanceTotal uint64           `json:"inclusionDistanceTotal"`
+    InclusionDistanceCount uint64           `json:"inclusionDistanceCount"`
+    Proposals uint64                        `json:"proposals"`
+    MissedProposals uint64                  `json:"missedProposals"`
+    LastMissedAttestationEpoch uint64       `json:"lastMissedAttestationEpoch"`
+    LastMissedProposalSlot uint64           `json:"lastMissedProposalSlot"`
+}
+
+
+// Record an epoch's attestation performance
+func (v *Validator) RecordAttestation(epoch uint64, performance beacon.ValidatorPerformance) {
+    v.Index = performance.Index
+    if !performance.Active {
+        return
+    }
+    if v.ActiveEpochs == 0 {
+        v.FirstEpoch = epoch
+    }
+    v.ActiveEpochs++
+    if !performance.Attested {
+        v.MissedAttestations++
+        v.LastMissedAttestationEpoch = epoch
+        return
+    }
+    v.Attestations++
+    if performance.CorrectTarget { v.CorrectTargets++ }
+    if performance.CorrectHead { v.CorrectHeads++ }
+    if performance.InclusionDistance > 0 {
+        v.InclusionDistanceTotal += performance.InclusionDistance
+        v.InclusionDistanceCount++
+    }
+}
+
+
+// Record a block proposal duty
+func (v *Validator) RecordProposal(slot uint64, proposed bool) {
+    if proposed {
+        v.Proposals++
+    } else {
+        v.MissedProposals++
+        v.LastMissedProposalSlot = slot
+    }
+}
+
+
+// Get the validator's attestation effectiveness as a percentage
+// Each active epoch has three votes (source, target & head); effectiveness is the share of votes which were included and correct
+func (v *Validator) Effectiveness() float64 {
+    if v.ActiveEpochs == 0 {
+        return 0
+    }
+    return float64(v.Attestations + v.CorrectTargets + v.CorrectHeads) / float64(3 * v.ActiveEpochs) * 100
+}
+
+
+// Get the validator's average attestation inclusion distance in slots; zero if not reported by the beacon client
+func (v *Validator) AverageInclusionDistance() float64 {
+    if v.InclusionDistanceCount == 0 {
+        return 0
+    }
+    return float64(v.InclusionDistanceTotal) / float64(v.InclusionDistanceCount)
+}
+
+
+// Load recorded validator performance; returns false if no performance has been recorded
+func Load(dataPath string) (Record, bool, error) {
+    record := Record{Validators: make(map[string]*Validator)}
+    recordBytes, err := ioutil.ReadFile(filepath.Join(dataPath, PerformanceFile))
+    if os.IsNotExist(err) {
+        return record, false, nil
+    }
+    if err != nil {
+        return Record{}, false, fmt.Errorf("Could not read validator performance: %w", err)
+    }
+    if err := json.Unmarshal(recordBytes, &record); err != nil {
+        return Record{}, false, fmt.Errorf("Could not decode validator performance: %w", err)
+    }
+    if record.Validators == nil {
+        record.Validators = make(map[string]*Validator)
+    }
+    return record, true, nil
+}
+
+
+// Save recorded validator performance
+func Save(dataPath string, record Record) error {
+    recordBytes, err := json.Marshal(record)
+    if err != nil {
+        return fmt.Errorf("Could not encode validator performance: %w", err)
+    }
+    path := filepath.Join(dataPath, PerformanceFile)
+    if err := ioutil.WriteFile(path + ".tmp", recordBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write validator performance: %w", err)
+    }
+    if err := os.Rename(path + ".tmp", path); err != nil {
+        return fmt.Errorf("Could not write validator performance: %w", err)
+    }
+    return nil
+}
+
@@ -0,0 +1,46 @@
+package portmap
+
+import (
+    "bufio"
+    "encoding/binary"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "net"
+    "os"
+    "strings"
+)
+
+
+// Get the IPv4 default gateway address from the kernel routing table
+func getDefaultGateway() (net.IP, error) {
+
+    // Read routing table
+    file, err := os.Open("/proc/net/route")
+    if err != nil {
+        return nil, fmt.Errorf("Could not read routing table: %w", err)
+    }
+    defer file.Close()
+
+    // Find default route; addresses are little-endian hex
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        fields := strings.Fields(scanner.Text())
+        if len(fields) < 3 || fields[1] != "00000000" {
+            continue
+        }
+        gatewayBytes, err := hex.DecodeString(fields[2])
+        if err != nil || len(gatewayBytes) != 4 {
+            continue
+        }
+        gateway := make(net.IP, 4)
+        binary.BigEndian.PutUint32(gateway, binary.LittleEndian.Uint32(gatewayBytes))
+        return gateway, nil
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, fmt.Errorf("Could not read routing table: %w", err)
+    }
+    return nil, errors.New("No default gateway found")
+
+}
+
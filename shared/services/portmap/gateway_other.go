@@ -0,0 +1,16 @@
+// +build !linux
+
+package portmap
+
+import (
+    "errors"
+    "net"
+)
+
+
+// Get the IPv4 default gateway address
+// Ports are only mapped by the node daemon, which runs on Linux
+func getDefaultGateway() (net.IP, error) {
+    return nil, errors.New("Finding the default gateway is only supported on Linux")
+}
+
@@ -0,0 +1,143 @@
+package portmap
+
+import (
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "net"
+    "time"
+)
+
+
+// NAT-PMP config
+const (
+    NATPMPPort = 5351
+    natpmpAttempts = 4
+)
+var natpmpInitialTimeout, _ = time.ParseDuration("250ms")
+
+
+// NAT-PMP result codes
+var natpmpResults = map[uint16]string{
+    1: "unsupported version",
+    2: "not authorized",
+    3: "network failure",
+    4: "out of resources",
+    5: "unsupported opcode",
+}
+
+
+// A NAT-PMP gateway (RFC 6886)
+// Mappings forward to the address requests are sent from, so the daemon must share the host's network
+type natpmpGateway struct {
+    addr *net.UDPAddr
+}
+
+
+// Find the NAT-PMP gateway at the default route
+func discoverNATPMP() (Gateway, error) {
+    gatewayIP, err := getDefaultGateway()
+    if err != nil {
+        return nil, err
+    }
+    gateway := &natpmpGateway{addr: &net.UDPAddr{IP: gatewayIP, Port: NATPMPPort}}
+    if _, err := gateway.GetExternalIP(); err != nil {
+        return nil, fmt.Errorf("No NAT-PMP gateway found at %s: %w", gatewayIP.String(), err)
+    }
+    return gateway, nil
+}
+
+
+// Get the port mapping method
+func (g *natpmpGateway) Method() string {
+    return MethodNATPMP
+}
+
+
+// Get the gateway's external IP address
+func (g *natpmpGateway) GetExternalIP() (string, error) {
+    response, err := g.request([]byte{0, 0}, 12)
+    if err != nil {
+        return "", err
+    }
+    return net.IP(response[8:12]).String(), nil
+}
+
+
+// Map a port for a lease period; returns the lease period granted by the gateway
+func (g *natpmpGateway) AddMapping(protocol string, port int, description string, lease time.Duration) (time.Duration, error) {
+    response, err := g.request(g.mappingRequest(protocol, port, port, lease), 16)
+    if err != nil {
+        return 0, err
+    }
+    if externalPort := int(binary.BigEndian.Uint16(response[10:12])); externalPort != port {
+        g.request(g.mappingRequest(protocol, port, 0, 0), 16)
+        return 0, fmt.Errorf("The gateway could only map external port %d", externalPort)
+    }
+    return time.Duration(binary.BigEndian.Uint32(response[12:16])) * time.Second, nil
+}
+
+
+// Delete a port mapping
+func (g *natpmpGateway) DeleteMapping(protocol string, port int) error {
+    _, err := g.request(g.mappingRequest(protocol, port, 0, 0), 16)
+    return err
+}
+
+
+// Build a mapping request; a zero lease deletes the mapping
+func (g *natpmpGateway) mappingRequest(protocol string, internalPort, externalPort int, lease time.Duration) []byte {
+    request := make([]byte, 12)
+    request[1] = 1
+    if protocol == ProtocolTCP {
+        request[1] = 2
+    }
+    binary.BigEndian.PutUint16(request[4:6], uint16(internalPort))
+    binary.BigEndian.PutUint16(request[6:8], uint16(externalPort))
+    binary.BigEndian.PutUint32(request[8:12], uint32(lease / time.Second))
+    return request
+}
+
+
+// Send a request to the gateway, retrying with doubling timeouts, and check the response
+func (g *natpmpGateway) request(request []byte, responseLength int) ([]byte, error) {
+
+    // Connect
+    conn, err := net.DialUDP("udp4", nil, g.addr)
+    if err != nil {
+        return nil, fmt.Errorf("Could not connect to NAT-PMP gateway: %w", err)
+    }
+    defer conn.Close()
+
+    // Send request & wait for response
+    response := make([]byte, 16)
+    timeout := natpmpInitialTimeout
+    for attempt := 0; attempt < natpmpAttempts; attempt++ {
+        if _, err := conn.Write(request); err != nil {
+            return nil, fmt.Errorf("Could not send NAT-PMP request: %w", err)
+        }
+        conn.SetReadDeadline(time.Now().Add(timeout))
+        n, err := conn.Read(response)
+        if err != nil {
+            if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+                timeout *= 2
+                continue
+            }
+            return nil, fmt.Errorf("Could not read NAT-PMP response: %w", err)
+        }
+        if n < responseLength || response[0] != 0 || response[1] != request[1] + 128 {
+            continue
+        }
+        if result := binary.BigEndian.Uint16(response[2:4]); result != 0 {
+            message, ok := natpmpResults[result]
+            if !ok {
+                message = fmt.Sprintf("result code %d", result)
+            }
+            return nil, fmt.Errorf("The NAT-PMP gateway refused the request: %s", message)
+        }
+        return response[:n], nil
+    }
+    return nil, errors.New("The NAT-PMP gateway did not respond")
+
+}
+
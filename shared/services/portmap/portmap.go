@@ -0,0 +1,159 @@
+package portmap
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+
+// Config
+const (
+    StatusFile = "port-mappings.json"
+    FileMode = 0644
+    MethodUPnP = "upnp"
+    MethodNATPMP = "natpmp"
+    MethodAuto = "auto"
+    ProtocolTCP = "TCP"
+    ProtocolUDP = "UDP"
+)
+var discoveryTimeout, _ = time.ParseDuration("3s")
+
+
+// A router which forwards ports on request
+// Mappings forward the same external & internal port, as the clients advertise their local port to peers
+type Gateway interface {
+    Method() string
+    GetExternalIP() (string, error)
+    AddMapping(protocol string, port int, description string, lease time.Duration) (time.Duration, error)
+    DeleteMapping(protocol string, port int) error
+}
+
+
+// Port mapping status, recorded in the data folder by the node daemon
+type Status struct {
+    Method string                   `json:"method"`
+    ExternalIP string               `json:"externalIp"`
+    Updated time.Time               `json:"updated"`
+    Error string                    `json:"error"`
+    Mappings []Mapping              `json:"mappings"`
+    Alerted bool                    `json:"alerted"`
+}
+type Mapping struct {
+    Chain string                    `json:"chain"`
+    Protocol string                 `json:"protocol"`
+    Port int                        `json:"port"`
+    Expires time.Time               `json:"expires"`
+    Error string                    `json:"error"`
+}
+
+
+// Find a gateway on the local network supporting a port mapping method
+// The auto method tries UPnP, then NAT-PMP; the internal client is only used by UPnP
+func Discover(method, internalClient string) (Gateway, error) {
+    switch method {
+        case MethodUPnP:
+            return discoverUPnP(internalClient)
+        case MethodNATPMP:
+            return discoverNATPMP()
+        case MethodAuto:
+            gateway, upnpErr := discoverUPnP(internalClient)
+            if upnpErr == nil {
+                return gateway, nil
+            }
+            gateway, natpmpErr := discoverNATPMP()
+            if natpmpErr == nil {
+                return gateway, nil
+            }
+            return nil, fmt.Errorf("No port mapping gateway found (%s; %s)", upnpErr.Error(), natpmpErr.Error())
+    }
+    return nil, fmt.Errorf("Unknown port mapping method '%s'", method)
+}
+
+
+// Check whether both protocols of a port are currently mapped; returns the first mapping error otherwise
+func (s *Status) IsMapped(port int) (bool, string) {
+    found := 0
+    for _, mapping := range s.Mappings {
+        if mapping.Port != port {
+            continue
+        }
+        if mapping.Error != "" {
+            return false, mapping.Error
+        }
+        if time.Now().After(mapping.Expires) {
+            return false, fmt.Sprintf("The %s mapping expired at %s", mapping.Protocol, mapping.Expires.Format(time.RFC1123))
+        }
+        found++
+    }
+    if found == 0 {
+        if s.Error != "" {
+            return false, s.Error
+        }
+        return false, "The port is not mapped"
+    }
+    return true, ""
+}
+
+
+// Get the errors of a status update, or nil if all ports were mapped
+func (s *Status) Err() error {
+    if s.Error != "" {
+        return errors.New(s.Error)
+    }
+    messages := []string{}
+    for _, mapping := range s.Mappings {
+        if mapping.Error != "" {
+            messages = append(messages, fmt.Sprintf("%s port %d (%s): %s", mapping.Chain, mapping.Port, mapping.Protocol, mapping.Error))
+        }
+    }
+    if len(messages) > 0 {
+        return errors.New(strings.Join(messages, "; "))
+    }
+    return nil
+}
+
+
+// Get the port mapping status; returns false if no status has been recorded
+func LoadStatus(dataPath string) (Status, bool, error) {
+    var status Status
+    statusBytes, err := ioutil.ReadFile(filepath.Join(dataPath, StatusFile))
+    if os.IsNotExist(err) {
+        return status, false, nil
+    }
+    if err != nil {
+        return Status{}, false, fmt.Errorf("Could not read port mapping status: %w", err)
+    }
+    if err := json.Unmarshal(statusBytes, &status); err != nil {
+        return Status{}, false, fmt.Errorf("Could not decode port mapping status: %w", err)
+    }
+    return status, true, nil
+}
+
+
+// Save the port mapping status
+func SaveStatus(dataPath string, status Status) error {
+    statusBytes, err := json.Marshal(status)
+    if err != nil {
+        return fmt.Errorf("Could not encode port mapping status: %w", err)
+    }
+    if err := ioutil.WriteFile(filepath.Join(dataPath, StatusFile), statusBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write port mapping status: %w", err)
+    }
+    return nil
+}
+
+
+// Delete the port mapping status
+func DeleteStatus(dataPath string) error {
+    if err := os.Remove(filepath.Join(dataPath, StatusFile)); err != nil && !os.IsNotExist(err) {
+        return fmt.Errorf("Could not delete port mapping status: %w", err)
+    }
+    return nil
+}
+
@@ -0,0 +1,323 @@
+package portmap
+
+import (
+    "bufio"
+    "bytes"
+    "encoding/xml"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "net"
+    "net/http"
+    "net/url"
+    "strconv"
+    "strings"
+    "time"
+)
+
+
+// UPnP config
+const (
+    SSDPAddress = "239.255.255.250:1900"
+    IGDDeviceType = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+)
+var upnpServiceTypes = []string{
+    "urn:schemas-upnp-org:service:WANIPConnection:2",
+    "urn:schemas-upnp-org:service:WANIPConnection:1",
+    "urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+var upnpRequestTimeout, _ = time.ParseDuration("5s")
+
+
+// UPnP device description
+type upnpDescription struct {
+    URLBase string                      `xml:"URLBase"`
+    Device upnpDevice                   `xml:"device"`
+}
+type upnpDevice struct {
+    Services []upnpService              `xml:"serviceList>service"`
+    Devices []upnpDevice                `xml:"deviceList>device"`
+}
+type upnpService struct {
+    ServiceType string                  `xml:"serviceType"`
+    ControlURL string                   `xml:"controlURL"`
+}
+
+
+// UPnP SOAP response & fault
+type upnpEnvelope struct {
+    Body struct {
+        Inner []byte                    `xml:",innerxml"`
+        Fault *struct {
+            Detail struct {
+                Error struct {
+                    Code int             `xml:"errorCode"`
+                    Description string   `xml:"errorDescription"`
+                }                       `xml:"UPnPError"`
+            }                           `xml:"detail"`
+        }                               `xml:"Fault"`
+    }                                   `xml:"Body"`
+}
+
+
+// A UPnP internet gateway device
+type upnpGateway struct {
+    controlURL string
+    serviceType string
+    internalClient string
+}
+
+
+// Find a UPnP internet gateway device on the local network via SSDP
+// Ports are mapped to the internal client address if set, or the address the gateway is reached from
+func discoverUPnP(internalClient string) (Gateway, error) {
+
+    // Send search request
+    conn, err := net.ListenPacket("udp4", ":0")
+    if err != nil {
+        return nil, fmt.Errorf("Could not open SSDP socket: %w", err)
+    }
+    defer conn.Close()
+    ssdpAddr, err := net.ResolveUDPAddr("udp4", SSDPAddress)
+    if err != nil {
+        return nil, err
+    }
+    search := strings.Join([]string{
+        "M-SEARCH * HTTP/1.1",
+        "HOST: " + SSDPAddress,
+        "ST: " + IGDDeviceType,
+        "MAN: \"ssdp:discover\"",
+        "MX: 2",
+        "", "",
+    }, "\r\n")
+    if _, err := conn.WriteTo([]byte(search), ssdpAddr); err != nil {
+        return nil, fmt.Errorf("Could not send SSDP search: %w", err)
+    }
+
+    // Check responding devices
+    conn.SetReadDeadline(time.Now().Add(discoveryTimeout))
+    buffer := make([]byte, 2048)
+    checked := make(map[string]bool)
+    var lastErr error
+    for {
+        n, _, err := conn.ReadFrom(buffer)
+        if err != nil {
+            break
+        }
+        response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buffer[:n])), nil)
+        if err != nil {
+            continue
+        }
+        location := response.Header.Get("Location")
+        if location == "" || checked[location] {
+            continue
+        }
+        checked[location] = true
+        gateway, err := getUPnPGateway(location, internalClient)
+        if err != nil {
+            lastErr = err
+            continue
+        }
+        return gateway, nil
+    }
+    if lastErr != nil {
+        return nil, lastErr
+    }
+    return nil, errors.New("No UPnP gateway found")
+
+}
+
+
+// Get a gateway's WAN connection service from its device description
+func getUPnPGateway(location, internalClient string) (*upnpGateway, error) {
+
+    // Get device description
+    client := http.Client{Timeout: upnpRequestTimeout}
+    response, err := client.Get(location)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get UPnP device description: %w", err)
+    }
+    defer response.Body.Close()
+    var description upnpDescription
+    if err := xml.NewDecoder(response.Body).Decode(&description); err != nil {
+        return nil, fmt.Errorf("Could not decode UPnP device description: %w", err)
+    }
+
+    // Find WAN connection service
+    for _, serviceType := range upnpServiceTypes {
+        service, ok := findUPnPService(description.Device, serviceType)
+        if !ok {
+            continue
+        }
+
+        // Get control URL
+        base, err := url.Parse(location)
+        if err != nil {
+            return nil, fmt.Errorf("Invalid UPnP device location '%s': %w", location, err)
+        }
+        if description.URLBase != "" {
+            if urlBase, err := url.Parse(description.URLBase); err == nil {
+                base = urlBase
+            }
+        }
+        controlURL, err := base.Parse(service.ControlURL)
+        if err != nil {
+            return nil, fmt.Errorf("Invalid UPnP control URL '%s': %w", service.ControlURL, err)
+        }
+
+        // Get internal client address
+        if internalClient == "" {
+            conn, err := net.Dial("udp4", controlURL.Host)
+            if err != nil {
+                if conn, err = net.Dial("udp4", net.JoinHostPort(controlURL.Hostname(), "80")); err != nil {
+                    return nil, fmt.Errorf("Could not get local address: %w", err)
+                }
+            }
+            internalClient = conn.LocalAddr().(*net.UDPAddr).IP.String()
+            conn.Close()
+        }
+
+        // Return
+        return &upnpGateway{
+            controlURL: controlURL.String(),
+            serviceType: service.ServiceType,
+            internalClient: internalClient,
+        }, nil
+
+    }
+    return nil, fmt.Errorf("The UPnP device at %s does not support port mapping", location)
+
+}
+
+
+// Find a service in a device tree
+func findUPnPService(device upnpDevice, serviceType string) (upnpService, bool) {
+    for _, service := range device.Services {
+        if service.ServiceType == serviceType {
+            return service, true
+        }
+    }
+    for _, child := range device.Devices {
+        if service, ok := findUPnPService(child, serviceType); ok {
+            return service, true
+        }
+    }
+    return upnpService{}, false
+}
+
+
+// Get the port mapping method
+func (g *upnpGateway) Method() string {
+    return MethodUPnP
+}
+
+
+// Get the gateway's external IP address
+func (g *upnpGateway) GetExternalIP() (string, error) {
+    response, err := g.call("GetExternalIPAddress", nil)
+    if err != nil {
+        return "", err
+    }
+    var result struct {
+        Address string `xml:"NewExternalIPAddress"`
+    }
+    if err := xml.Unmarshal(response, &result); err != nil {
+        return "", fmt.Errorf("Could not decode UPnP external IP address: %w", err)
+    }
+    return strings.TrimSpace(result.Address), nil
+}
+
+
+// Map a port for a lease period; returns the lease period granted by the gateway
+// Gateways which only support permanent mappings are asked for one, and it is renewed the same way
+func (g *upnpGateway) AddMapping(protocol string, port int, description string, lease time.Duration) (time.Duration, error) {
+    args := [][2]string{
+        {"NewRemoteHost", ""},
+        {"NewExternalPort", strconv.Itoa(port)},
+        {"NewProtocol", protocol},
+        {"NewInternalPort", strconv.Itoa(port)},
+        {"NewInternalClient", g.internalClient},
+        {"NewEnabled", "1"},
+        {"NewPortMappingDescription", description},
+        {"NewLeaseDuration", strconv.Itoa(int(lease / time.Second))},
+    }
+    _, err := g.call("AddPortMapping", args)
+    if fault, ok := err.(upnpFault); ok && fault.code == 725 {
+        args[7][1] = "0"
+        _, err = g.call("AddPortMapping", args)
+    }
+    if err != nil {
+        return 0, err
+    }
+    return lease, nil
+}
+
+
+// Delete a port mapping
+func (g *upnpGateway) DeleteMapping(protocol string, port int) error {
+    _, err := g.call("DeletePortMapping", [][2]string{
+        {"NewRemoteHost", ""},
+        {"NewExternalPort", strconv.Itoa(port)},
+        {"NewProtocol", protocol},
+    })
+    return err
+}
+
+
+// A UPnP error returned by the gateway
+type upnpFault struct {
+    code int
+    description string
+}
+func (f upnpFault) Error() string {
+    return fmt.Sprintf("The UPnP gateway refused the request: %s (%d)", f.description, f.code)
+}
+
+
+// Call a SOAP action on the WAN connection service and return the response body
+func (g *upnpGateway) call(action string, args [][2]string) ([]byte, error) {
+
+    // Build request
+    var body bytes.Buffer
+    body.WriteString(`<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+    fmt.Fprintf(&body, `<u:%s xmlns:u="%s">`, action, g.serviceType)
+    for _, arg := range args {
+        fmt.Fprintf(&body, "<%s>", arg[0])
+        xml.EscapeText(&body, []byte(arg[1]))
+        fmt.Fprintf(&body, "</%s>", arg[0])
+    }
+    fmt.Fprintf(&body, `</u:%s></s:Body></s:Envelope>`, action)
+    request, err := http.NewRequest("POST", g.controlURL, &body)
+    if err != nil {
+        return nil, err
+    }
+    request.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+    request.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, g.serviceType, action))
+
+    // Send request
+    client := http.Client{Timeout: upnpRequestTimeout}
+    response, err := client.Do(request)
+    if err != nil {
+        return nil, fmt.Errorf("Could not call UPnP action %s: %w", action, err)
+    }
+    defer response.Body.Close()
+    responseBytes, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+        return nil, fmt.Errorf("Could not read UPnP %s response: %w", action, err)
+    }
+
+    // Decode response
+    var envelope upnpEnvelope
+    if err := xml.Unmarshal(responseBytes, &envelope); err != nil {
+        return nil, fmt.Errorf("Could not decode UPnP %s response: %w", action, err)
+    }
+    if envelope.Body.Fault != nil {
+        return nil, upnpFault{code: envelope.Body.Fault.Detail.Error.Code, description: envelope.Body.Fault.Detail.Error.Description}
+    }
+    if response.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("Could not call UPnP action %s: HTTP status %d", action, response.StatusCode)
+    }
+    return envelope.Body.Inner, nil
+
+}
+
@@ -0,0 +1,68 @@
+package services
+
+import (
+    "fmt"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Config reload handler, which validates & applies a daemon's own settings from a new config
+// Handlers must not apply any settings if they return an error
+type ConfigReloadHandler func(cfg config.RocketPoolConfig) error
+
+
+// Watch the user settings file and reload the daemon config when it changes
+// Broken configs are rejected, and the last good config remains active
+func WatchConfig(c config.Context, daemonName string, logger log.ColorLogger, onReload ConfigReloadHandler) error {
+    path := c.GlobalString("settings")
+    if err := config.WatchFile(path, func() {
+        if err := reloadConfig(c, daemonName, onReload); err != nil {
+            logger.Errorf("The updated settings at %s were rejected and the last good config remains active: %s", path, err)
+            return
+        }
+        logger.Printlnf("Reloaded settings from %s.", path)
+    }); err != nil {
+        return fmt.Errorf("Could not watch settings file: %w", err)
+    }
+    logger.Printlnf("Watching %s for changes...", path)
+    return nil
+}
+
+
+// Load, validate & apply the daemon config
+func reloadConfig(c config.Context, daemonName string, onReload ConfigReloadHandler) error {
+
+    // Load & validate config
+    newCfg, err := config.Load(c)
+    if err != nil {
+        return err
+    }
+    if err := newCfg.Validate(); err != nil {
+        return err
+    }
+    logOptions, err := getLogOptions(newCfg, daemonName)
+    if err != nil {
+        return err
+    }
+
+    // Apply daemon settings
+    if onReload != nil {
+        if err := onReload(newCfg); err != nil {
+            return err
+        }
+    }
+
+    // Apply shared settings
+    setConfig(newCfg)
+    if err := log.Configure(logOptions); err != nil {
+        return err
+    }
+    if alerter != nil {
+        alerter.SetWebhookURL(newCfg.Smartnode.AlertWebhookURL)
+    }
+    return nil
+
+}
+
@@ -9,7 +9,9 @@ import (
 
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/node"
-    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
 
@@ -29,19 +31,26 @@ var beaconClientSyncPollInterval, _ = time.ParseDuration("5s")
 //
 
 
-func RequireNodePassword(c *cli.Context) error {
+func RequireNodePassword(c config.Context) error {
     nodePasswordSet, err := getNodePasswordSet(c)
     if err != nil {
         return err
     }
     if !nodePasswordSet {
-        return errors.New("The node password has not been set. Please run 'rocketpool wallet init' and try again.")
+        return apiutils.NotReadyError(errors.New("The node password has not been set. Please run 'rocketpool wallet init' and try again."))
     }
     return nil
 }
 
 
-func RequireNodeWallet(c *cli.Context) error {
+func RequireNodeWallet(c config.Context) error {
+    nodeWalletOffline, err := getNodeWalletOffline(c)
+    if err != nil {
+        return err
+    }
+    if nodeWalletOffline {
+        return nil
+    }
     if err := RequireNodePassword(c); err != nil {
         return err
     }
@@ -50,37 +59,37 @@ func RequireNodeWallet(c *cli.Context) error {
         return err
     }
     if !nodeWalletInitialized {
-        return errors.New("The node wallet has not been initialized. Please run 'rocketpool wallet init' and try again.")
+        return apiutils.NotReadyError(errors.New("The node wallet has not been initialized. Please run 'rocketpool wallet init' and try again."))
     }
     return nil
 }
 
 
-func RequireEthClientSynced(c *cli.Context) error {
+func RequireEthClientSynced(c config.Context) error {
     ethClientSynced, err := waitEthClientSynced(c, false, EthClientSyncTimeout)
     if err != nil {
         return err
     }
     if !ethClientSynced {
-        return errors.New("The Eth 1.0 node is currently syncing. Please try again later.")
+        return apiutils.NotReadyError(errors.New("The Eth 1.0 node is currently syncing. Please try again later."))
     }
     return nil
 }
 
 
-func RequireBeaconClientSynced(c *cli.Context) error {
+func RequireBeaconClientSynced(c config.Context) error {
     beaconClientSynced, err := waitBeaconClientSynced(c, false, BeaconClientSyncTimeout)
     if err != nil {
         return err
     }
     if !beaconClientSynced {
-        return errors.New("The Eth 2.0 node is currently syncing. Please try again later.")
+        return apiutils.NotReadyError(errors.New("The Eth 2.0 node is currently syncing. Please try again later."))
     }
     return nil
 }
 
 
-func RequireRocketStorage(c *cli.Context) error {
+func RequireRocketStorage(c config.Context) error {
     if err := RequireEthClientSynced(c); err != nil {
         return err
     }
@@ -89,13 +98,13 @@ func RequireRocketStorage(c *cli.Context) error {
         return err
     }
     if !rocketStorageLoaded {
-        return errors.New("The Rocket Pool storage contract was not found; the configured address may be incorrect, or the Eth 1.0 node may not be synced. Please try again later.")
+        return apiutils.NotReadyError(errors.New("The Rocket Pool storage contract was not found; the configured address may be incorrect, or the Eth 1.0 node may not be synced. Please try again later."))
     }
     return nil
 }
 
 
-func RequireNodeRegistered(c *cli.Context) error {
+func RequireNodeRegistered(c config.Context) error {
     if err := RequireNodeWallet(c); err != nil {
         return err
     }
@@ -107,7 +116,7 @@ func RequireNodeRegistered(c *cli.Context) error {
         return err
     }
     if !nodeRegistered {
-        return errors.New("The node is not registered with Rocket Pool. Please run 'rocketpool node register' and try again.")
+        return apiutils.NotReadyError(errors.New("The node is not registered with Rocket Pool. Please run 'rocketpool node register' and try again."))
     }
     return nil
 }
@@ -118,7 +127,7 @@ func RequireNodeRegistered(c *cli.Context) error {
 //
 
 
-func WaitNodePassword(c *cli.Context, verbose bool) error {
+func WaitNodePassword(c config.Context, verbose bool) error {
     for {
         nodePasswordSet, err := getNodePasswordSet(c)
         if err != nil {
@@ -135,7 +144,7 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 }
 
 
-func WaitNodeWallet(c *cli.Context, verbose bool) error {
+func WaitNodeWallet(c config.Context, verbose bool) error {
     if err := WaitNodePassword(c, verbose); err != nil {
         return err
     }
@@ -155,19 +164,19 @@ func WaitNodeWallet(c *cli.Context, verbose bool) error {
 }
 
 
-func WaitEthClientSynced(c *cli.Context, verbose bool) error {
+func WaitEthClientSynced(c config.Context, verbose bool) error {
     _, err := waitEthClientSynced(c, verbose, 0)
     return err
 }
 
 
-func WaitBeaconClientSynced(c *cli.Context, verbose bool) error {
+func WaitBeaconClientSynced(c config.Context, verbose bool) error {
     _, err := waitBeaconClientSynced(c, verbose, 0)
     return err
 }
 
 
-func WaitRocketStorage(c *cli.Context, verbose bool) error {
+func WaitRocketStorage(c config.Context, verbose bool) error {
     if err := WaitEthClientSynced(c, verbose); err != nil {
         return err
     }
@@ -187,7 +196,7 @@ func WaitRocketStorage(c *cli.Context, verbose bool) error {
 }
 
 
-func WaitNodeRegistered(c *cli.Context, verbose bool) error {
+func WaitNodeRegistered(c config.Context, verbose bool) error {
     if err := WaitNodeWallet(c, verbose); err != nil {
         return err
     }
@@ -216,7 +225,7 @@ func WaitNodeRegistered(c *cli.Context, verbose bool) error {
 
 
 // Check if the node password is set
-func getNodePasswordSet(c *cli.Context) (bool, error) {
+func getNodePasswordSet(c config.Context) (bool, error) {
     pm, err := GetPasswordManager(c)
     if err != nil {
         return false, err
@@ -226,7 +235,7 @@ func getNodePasswordSet(c *cli.Context) (bool, error) {
 
 
 // Check if the node wallet is initialized
-func getNodeWalletInitialized(c *cli.Context) (bool, error) {
+func getNodeWalletInitialized(c config.Context) (bool, error) {
     w, err := GetWallet(c)
     if err != nil {
         return false, err
@@ -235,8 +244,18 @@ func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 }
 
 
+// Check if the node wallet is kept offline
+func getNodeWalletOffline(c config.Context) (bool, error) {
+    w, err := GetWallet(c)
+    if err != nil {
+        return false, err
+    }
+    return w.IsOffline(), nil
+}
+
+
 // Check if the RocketStorage contract is loaded
-func getRocketStorageLoaded(c *cli.Context) (bool, error) {
+func getRocketStorageLoaded(c config.Context) (bool, error) {
     cfg, err := GetConfig(c)
     if err != nil {
         return false, err
@@ -254,7 +273,7 @@ func getRocketStorageLoaded(c *cli.Context) (bool, error) {
 
 
 // Check if the node is registered
-func getNodeRegistered(c *cli.Context) (bool, error) {
+func getNodeRegistered(c config.Context) (bool, error) {
     w, err := GetWallet(c)
     if err != nil {
         return false, err
@@ -274,7 +293,7 @@ func getNodeRegistered(c *cli.Context) (bool, error) {
 // Wait for the eth client to sync
 // timeout of 0 indicates no timeout
 var ethClientSyncLock sync.Mutex
-func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, error) {
+func waitEthClientSynced(c config.Context, verbose bool, timeout int64) (bool, error) {
 
     // Prevent multiple waiting goroutines from requesting sync progress
     ethClientSyncLock.Lock()
@@ -323,7 +342,7 @@ func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, err
 // Wait for the beacon client to sync
 // timeout of 0 indicates no timeout
 var beaconClientSyncLock sync.Mutex
-func waitBeaconClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, error) {
+func waitBeaconClientSynced(c config.Context, verbose bool, timeout int64) (bool, error) {
 
     // Prevent multiple waiting goroutines from requesting sync progress
     beaconClientSyncLock.Lock()
@@ -0,0 +1,94 @@
+package rewards
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "math/big"
+    "os"
+    "path/filepath"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+)
+
+
+// Config
+const (
+    ClaimLogFile = "rewards.log"
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Rewards claim record; amount is the nETH claimed from the minipool
+type Claim struct {
+    Time time.Time                  `json:"time"`
+    MinipoolAddress common.Address  `json:"minipoolAddress"`
+    Amount *big.Int                 `json:"amount"`
+    TxHash common.Hash              `json:"txHash"`
+    GasPrice *big.Int               `json:"gasPrice"`
+    Automatic bool                  `json:"automatic"`
+}
+
+
+// Append a rewards claim to the claim log
+func RecordClaim(dataPath string, claim Claim) error {
+
+    // Encode claim
+    claimBytes, err := json.Marshal(claim)
+    if err != nil {
+        return fmt.Errorf("Could not encode rewards claim: %w", err)
+    }
+
+    // Append claim to log
+    path := filepath.Join(dataPath, ClaimLogFile)
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create rewards claim log folder: %w", err)
+    }
+    file, err := os.OpenFile(path, os.O_APPEND | os.O_CREATE | os.O_WRONLY, FileMode)
+    if err != nil {
+        return fmt.Errorf("Could not open rewards claim log: %w", err)
+    }
+    defer file.Close()
+    if _, err := file.Write(append(claimBytes, '\n')); err != nil {
+        return fmt.Errorf("Could not write rewards claim to log: %w", err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get recorded rewards claims, oldest first
+func GetClaims(dataPath string) ([]Claim, error) {
+
+    // Open claim log; no claims if not found
+    file, err := os.Open(filepath.Join(dataPath, ClaimLogFile))
+    if os.IsNotExist(err) {
+        return []Claim{}, nil
+    }
+    if err != nil {
+        return []Claim{}, fmt.Errorf("Could not open rewards claim log: %w", err)
+    }
+    defer file.Close()
+
+    // Decode claims
+    claims := []Claim{}
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        var claim Claim
+        if err := json.Unmarshal(scanner.Bytes(), &claim); err != nil {
+            continue
+        }
+        claims = append(claims, claim)
+    }
+    if err := scanner.Err(); err != nil {
+        return []Claim{}, fmt.Errorf("Could not read rewards claim log: %w", err)
+    }
+
+    // Return
+    return claims, nil
+
+}
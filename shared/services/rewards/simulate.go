@@ -0,0 +1,109 @@
+package rewards
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/tokens"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
+)
+
+
+// Simulated rewards claim from a withdrawable minipool
+// The node receives the minipool's nETH balance, and any ETH balance remaining in the minipool when it is destroyed
+type ClaimSimulation struct {
+    NETHAmount *big.Int
+    ETHAmount *big.Int
+    GasInfo api.GasInfo
+    GasCost *big.Int
+}
+
+
+// Simulate a minipool rewards claim by the node at a gas price
+// Gas estimation executes the withdrawal against the latest block, so claims which would revert fail here
+func SimulateClaim(rp *rocketpool.RocketPool, minipoolAddress, nodeAddress common.Address, gasPrice *big.Int) (ClaimSimulation, error) {
+
+    // Data
+    var wg errgroup.Group
+    simulation := ClaimSimulation{}
+
+    // Get minipool nETH balance
+    wg.Go(func() error {
+        var err error
+        simulation.NETHAmount, err = tokens.GetNETHBalance(rp, minipoolAddress, nil)
+        return err
+    })
+
+    // Get minipool ETH balance
+    wg.Go(func() error {
+        var err error
+        simulation.ETHAmount, err = rp.Client.BalanceAt(context.Background(), minipoolAddress, nil)
+        return err
+    })
+
+    // Estimate gas
+    wg.Go(func() error {
+        minipoolAbi, err := rp.GetABI("rocketMinipool")
+        if err != nil {
+            return err
+        }
+        simulation.GasInfo, err = eth1.EstimateCallGas(rp, gasPrice, minipoolAddress, minipoolAbi, "rocketMinipool", nodeAddress, nil, "withdraw")
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return ClaimSimulation{}, fmt.Errorf("Could not simulate minipool %s rewards claim: %w", minipoolAddress.Hex(), err)
+    }
+
+    // Get gas cost
+    gasCost, err := safemath.Mul(gasPrice, new(big.Int).SetUint64(simulation.GasInfo.EstGasLimit))
+    if err != nil {
+        return ClaimSimulation{}, fmt.Errorf("Could not calculate minipool %s rewards claim gas cost: %w", minipoolAddress.Hex(), err)
+    }
+    simulation.GasCost = gasCost
+
+    // Return
+    return simulation, nil
+
+}
+
+
+// Get the total value of the claim in ETH; nETH is backed 1:1 by ETH
+func (s ClaimSimulation) Value() (*big.Int, error) {
+    return safemath.Add(s.NETHAmount, s.ETHAmount)
+}
+
+
+// Get the ratio of the claim value to its gas cost
+func (s ClaimSimulation) ValueToGasRatio() (float64, error) {
+    value, err := s.Value()
+    if err != nil {
+        return 0, err
+    }
+    percent, err := safemath.PercentOf(value, s.GasCost)
+    if err != nil {
+        return 0, err
+    }
+    return percent / 100, nil
+}
+
+
+// Check whether the claim value is at least a minimum multiple of its gas cost
+func (s ClaimSimulation) MeetsMinRatio(minRatio float64) (bool, error) {
+    if s.GasCost.Sign() == 0 {
+        return true, nil
+    }
+    ratio, err := s.ValueToGasRatio()
+    if err != nil {
+        return false, err
+    }
+    return (ratio >= minRatio), nil
+}
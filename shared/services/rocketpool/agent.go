@@ -0,0 +1,72 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services/containers"
+)
+
+
+// Config
+const DataFolder = "data"
+
+
+// Get the container requests queued by the node daemon, and the agent state
+func (c *Client) LoadServiceRequests() ([]containers.Request, containers.AgentState, error) {
+
+    // Load requests
+    requestsBytes, err := c.readOutput(fmt.Sprintf("cat %s/%s/%s 2>/dev/null || true", RocketPoolPath, DataFolder, containers.RequestsFile))
+    if err != nil {
+        return []containers.Request{}, containers.AgentState{}, fmt.Errorf("Could not read service requests: %w", err)
+    }
+    requests := []containers.Request{}
+    if len(strings.TrimSpace(string(requestsBytes))) > 0 {
+        if err := json.Unmarshal(requestsBytes, &requests); err != nil {
+            return []containers.Request{}, containers.AgentState{}, fmt.Errorf("Could not decode service requests: %w", err)
+        }
+    }
+
+    // Load agent state
+    stateBytes, err := c.readOutput(fmt.Sprintf("cat %s/%s/%s 2>/dev/null || true", RocketPoolPath, DataFolder, containers.AgentStateFile))
+    if err != nil {
+        return []containers.Request{}, containers.AgentState{}, fmt.Errorf("Could not read service agent state: %w", err)
+    }
+    var state containers.AgentState
+    if len(strings.TrimSpace(string(stateBytes))) > 0 {
+        if err := json.Unmarshal(stateBytes, &state); err != nil {
+            return []containers.Request{}, containers.AgentState{}, fmt.Errorf("Could not decode service agent state: %w", err)
+        }
+    }
+
+    // Return
+    return requests, state, nil
+
+}
+
+
+// Run a container request via the service backend
+func (c *Client) ApplyServiceRequest(request containers.Request) error {
+    if err := containers.ValidateRequest(request); err != nil {
+        return err
+    }
+    backend, err := c.GetServiceBackend()
+    if err != nil {
+        return err
+    }
+    return backend.ApplyAction(request.Action, request.Service)
+}
+
+
+// Save the agent state
+func (c *Client) SaveServiceAgentState(state containers.AgentState) error {
+    stateBytes, err := json.Marshal(state)
+    if err != nil {
+        return fmt.Errorf("Could not encode service agent state: %w", err)
+    }
+    if _, err := c.readOutput(fmt.Sprintf("cat > %s/%s/%s <<'EOF'\n%s\nEOF", RocketPoolPath, DataFolder, containers.AgentStateFile, string(stateBytes))); err != nil {
+        return fmt.Errorf("Could not write service agent state: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,172 @@
+package rocketpool
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "net"
+    "net/http"
+    "os"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Config
+const (
+    APISocketFile = "api.sock"
+    APIServerURL = "http://rocketpool/api"
+)
+
+
+// Returned when the API server cannot be reached, so that the service backend should be used instead
+var errAPIServerUnavailable = errors.New("The API server is unavailable")
+
+
+// Call the Rocket Pool API server over its unix socket
+func (c *Client) callAPIServer(ctx context.Context, globalArgs []string, args string) ([]byte, error) {
+
+    // Get API socket path
+    socketPath, err := c.getAPISocketPath()
+    if err != nil {
+        return []byte{}, errAPIServerUnavailable
+    }
+
+    // Split command args
+    splitArgs, err := splitArgs(args)
+    if err != nil {
+        return []byte{}, err
+    }
+
+    // Encode request
+    requestBytes, err := json.Marshal(api.ServerRequest{
+        GlobalArgs: globalArgs,
+        Args: splitArgs,
+    })
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not encode API request: %w", err)
+    }
+
+    // Connect to API server; the API server is treated as unavailable if a connection cannot be made
+    conn, err := c.dialAPISocket(socketPath)
+    if err != nil {
+        return []byte{}, errAPIServerUnavailable
+    }
+    defer conn.Close()
+
+    // Send request
+    httpClient := &http.Client{
+        Transport: &http.Transport{
+            DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+                return conn, nil
+            },
+            DisableKeepAlives: true,
+        },
+    }
+    request, err := http.NewRequestWithContext(ctx, "POST", APIServerURL, bytes.NewReader(requestBytes))
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not create API request: %w", err)
+    }
+    request.Header.Set("Content-Type", "application/json")
+    response, err := httpClient.Do(request)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not call API server: %w", err)
+    }
+    defer response.Body.Close()
+
+    // Read response
+    responseBytes, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not read API server response: %w", err)
+    }
+    if response.StatusCode != http.StatusOK {
+        return []byte{}, fmt.Errorf("API server returned %s: %s", response.Status, strings.TrimSpace(string(responseBytes)))
+    }
+    return responseBytes, nil
+
+}
+
+
+// Get the API socket path on the smart node host
+func (c *Client) getAPISocketPath() (string, error) {
+    if c.apiSocketPath != "" {
+        return c.apiSocketPath, nil
+    }
+    var socketPath string
+    if c.client == nil {
+        homeDir, err := os.UserHomeDir()
+        if err != nil {
+            return "", err
+        }
+        socketPath = strings.Replace(fmt.Sprintf("%s/%s", RocketPoolPath, APISocketFile), "~", homeDir, 1)
+    } else {
+        path, err := c.readOutput(fmt.Sprintf("echo %s/%s", RocketPoolPath, APISocketFile))
+        if err != nil {
+            return "", err
+        }
+        socketPath = strings.TrimSpace(string(path))
+    }
+    c.apiSocketPath = socketPath
+    return socketPath, nil
+}
+
+
+// Connect to the API socket, locally or through the SSH connection
+func (c *Client) dialAPISocket(socketPath string) (net.Conn, error) {
+    if c.client == nil {
+        if _, err := os.Stat(socketPath); err != nil {
+            return nil, err
+        }
+        return net.Dial("unix", socketPath)
+    }
+    return c.client.Dial("unix", socketPath)
+}
+
+
+// Split a command argument string into arguments, as a POSIX shell would for quoted & escaped text
+func splitArgs(args string) ([]string, error) {
+    splitArgs := []string{}
+    var current strings.Builder
+    inArg := false
+    var quote rune
+    escaped := false
+    for _, r := range args {
+        switch {
+            case escaped:
+                current.WriteRune(r)
+                escaped = false
+            case r == '\\' && quote != '\'':
+                escaped = true
+                inArg = true
+            case quote != 0:
+                if r == quote {
+                    quote = 0
+                } else {
+                    current.WriteRune(r)
+                }
+            case r == '"' || r == '\'':
+                quote = r
+                inArg = true
+            case r == ' ' || r == '\t' || r == '\n':
+                if inArg {
+                    splitArgs = append(splitArgs, current.String())
+                    current.Reset()
+                    inArg = false
+                }
+            default:
+                current.WriteRune(r)
+                inArg = true
+        }
+    }
+    if quote != 0 || escaped {
+        return []string{}, fmt.Errorf("Invalid API command arguments: %s", args)
+    }
+    if inArg {
+        splitArgs = append(splitArgs, current.String())
+    }
+    return splitArgs, nil
+}
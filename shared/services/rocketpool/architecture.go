@@ -0,0 +1,42 @@
+package rocketpool
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Get the architecture of the smart node host
+// The architecture is selected in the config, or detected from the host's machine hardware name
+func (c *Client) GetArchitecture() (string, error) {
+
+    // Return cached architecture
+    if c.architecture != "" {
+        return c.architecture, nil
+    }
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return "", err
+    }
+
+    // Detect architecture if not selected
+    arch := rpConfig.Smartnode.Architecture
+    if arch == "" {
+        output, err := c.readOutput("uname -m")
+        if err != nil {
+            return "", fmt.Errorf("Could not detect the host architecture: %w", err)
+        }
+        if arch, err = config.ParseArchitecture(strings.TrimSpace(string(output))); err != nil {
+            return "", err
+        }
+    }
+
+    // Cache & return
+    c.architecture = arch
+    return arch, nil
+
+}
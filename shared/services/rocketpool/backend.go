@@ -0,0 +1,71 @@
+package rocketpool
+
+import (
+    "fmt"
+)
+
+
+// Service backends
+const (
+    BackendCompose = "compose"
+    BackendSystemd = "systemd"
+)
+
+
+// A service backend, which runs the Rocket Pool service processes (eth1, eth2, validator, node & watchtower)
+type ServiceBackend interface {
+    Name() string
+    Start() error
+    Pause() error
+    Stop() error
+    PrintStatus() error
+    PrintLogs(options LogOptions, serviceNames ...string) error
+    PrintStats() error
+    ApplyAction(action, serviceName string) error
+    APICommand(globalArgs, args string) (string, error)
+}
+
+
+// Get the service backend used to run the Rocket Pool service
+// The backend is selected in the config, defaulting to docker-compose (or podman-compose)
+func (c *Client) GetServiceBackend() (ServiceBackend, error) {
+
+    // Return cached backend
+    if c.backend != nil {
+        return c.backend, nil
+    }
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return nil, err
+    }
+
+    // Get backend
+    switch rpConfig.Smartnode.ServiceBackend {
+        case "", BackendCompose:
+            c.backend = &composeBackend{c: c}
+        case BackendSystemd:
+            c.backend = &systemdBackend{c: c}
+        default:
+            return nil, fmt.Errorf("Unknown service backend '%s'", rpConfig.Smartnode.ServiceBackend)
+    }
+
+    // Return
+    return c.backend, nil
+
+}
+
+
+// Check that the compose service backend is in use, for commands which manage containers directly
+func (c *Client) checkComposeBackend() error {
+    backend, err := c.GetServiceBackend()
+    if err != nil {
+        return err
+    }
+    if backend.Name() != BackendCompose {
+        return fmt.Errorf("This command manages the Rocket Pool containers, and is not available with the %s service backend.", backend.Name())
+    }
+    return nil
+}
+
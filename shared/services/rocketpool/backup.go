@@ -0,0 +1,82 @@
+package rocketpool
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services/backup"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Create a gzipped tar archive of the Rocket Pool folder on the node host
+// Files & folders with names in excludes are omitted, along with the API & event sockets
+func (c *Client) ArchiveNodeFolder(excludes []string) ([]byte, error) {
+    excludeArgs := []string{"--exclude='*.sock'"}
+    for _, exclude := range excludes {
+        excludeArgs = append(excludeArgs, fmt.Sprintf("--exclude='%s'", exclude))
+    }
+    archive, err := c.readLongOutput(fmt.Sprintf("tar czf - -C %s %s .", RocketPoolPath, strings.Join(excludeArgs, " ")))
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not archive the Rocket Pool folder: %w", err)
+    }
+    return archive, nil
+}
+
+
+// Extract a gzipped tar archive to the Rocket Pool folder on the node host, creating it if required
+func (c *Client) ExtractNodeFolder(archive []byte) error {
+
+    // Initialize command
+    ctx, cancel := c.commandContext(0)
+    defer cancel()
+    cmd, err := c.newCommand(ctx, fmt.Sprintf("mkdir -p %s && tar xzf - -C %s", RocketPoolPath, RocketPoolPath))
+    if err != nil { return err }
+    defer cmd.Close()
+    cmd.SetStdin(bytes.NewReader(archive))
+
+    // Run command
+    if err := contextError(ctx, cmd.Run(), 0); err != nil {
+        return fmt.Errorf("Could not extract the archive to the Rocket Pool folder: %w", err)
+    }
+    return nil
+
+}
+
+
+// Upload an encrypted backup to the backup destination
+// The backup is staged in the backup upload folder on the node host, so no passphrase is passed to the API
+func (c *Client) UploadBackup(name string, data []byte) (api.ServiceCreateBackupResponse, error) {
+
+    // Initialize command
+    uploadPath := fmt.Sprintf("%s/%s", RocketPoolPath, backup.UploadFolder)
+    ctx, cancel := c.commandContext(0)
+    defer cancel()
+    cmd, err := c.newCommand(ctx, fmt.Sprintf("mkdir -p %s && chmod 700 %s && cat > %s/%s", uploadPath, uploadPath, uploadPath, name))
+    if err != nil { return api.ServiceCreateBackupResponse{}, err }
+    defer cmd.Close()
+    cmd.SetStdin(bytes.NewReader(data))
+
+    // Stage backup
+    if err := contextError(ctx, cmd.Run(), 0); err != nil {
+        return api.ServiceCreateBackupResponse{}, fmt.Errorf("Could not stage backup %s for upload: %w", name, err)
+    }
+
+    // Upload backup
+    responseBytes, err := c.callAPI(fmt.Sprintf("service upload-backup %s", name))
+    if err != nil {
+        return api.ServiceCreateBackupResponse{}, fmt.Errorf("Could not upload backup: %w", err)
+    }
+    var response api.ServiceCreateBackupResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceCreateBackupResponse{}, fmt.Errorf("Could not decode upload backup response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceCreateBackupResponse{}, fmt.Errorf("Could not upload backup: %s", response.Error)
+    }
+    return response, nil
+
+}
+
@@ -0,0 +1,172 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+)
+
+
+// Config
+const (
+    BenchmarkFile = "benchmark.tmp"
+    BenchmarkHistoryFile = "benchmarks.log"
+    BenchmarkBlockSize = 4096
+    BenchmarkBlockCount = 2000
+    BenchmarkDownloadURL = "https://speed.cloudflare.com/__down?bytes=25000000"
+    BenchmarkDownloadTimeout = 30
+)
+
+
+// Benchmark result
+type BenchmarkResult struct {
+    Time time.Time              `json:"time"`
+    WriteIOPS float64           `json:"writeIops"`
+    WriteLatency float64        `json:"writeLatencyMs"`
+    ReadIOPS float64            `json:"readIops"`
+    ReadLatency float64         `json:"readLatencyMs"`
+    DownloadSpeed float64       `json:"downloadMbps"`
+    NetworkTested bool          `json:"networkTested"`
+}
+
+
+// Run disk & network benchmarks on the host
+func (c *Client) RunBenchmark(skipNetwork bool) (BenchmarkResult, error) {
+
+    // Result
+    result := BenchmarkResult{Time: time.Now()}
+
+    // Benchmark file path
+    benchmarkPath := fmt.Sprintf("%s/%s", RocketPoolPath, BenchmarkFile)
+    defer c.readOutput(fmt.Sprintf("rm -f %s", benchmarkPath))
+
+    // Run synchronous write test
+    writeSeconds, err := c.runDiskBenchmark(fmt.Sprintf("dd if=/dev/zero of=%s bs=%d count=%d oflag=dsync", benchmarkPath, BenchmarkBlockSize, BenchmarkBlockCount))
+    if err != nil {
+        return BenchmarkResult{}, fmt.Errorf("Could not run disk write benchmark: %w", err)
+    }
+    result.WriteIOPS = float64(BenchmarkBlockCount) / writeSeconds
+    result.WriteLatency = (writeSeconds * 1000) / float64(BenchmarkBlockCount)
+
+    // Run direct read test
+    readSeconds, err := c.runDiskBenchmark(fmt.Sprintf("dd if=%s of=/dev/null bs=%d count=%d iflag=direct", benchmarkPath, BenchmarkBlockSize, BenchmarkBlockCount))
+    if err != nil {
+        return BenchmarkResult{}, fmt.Errorf("Could not run disk read benchmark: %w", err)
+    }
+    result.ReadIOPS = float64(BenchmarkBlockCount) / readSeconds
+    result.ReadLatency = (readSeconds * 1000) / float64(BenchmarkBlockCount)
+
+    // Run network download test
+    if !skipNetwork {
+        downloadSpeed, err := c.runNetworkBenchmark()
+        if err != nil {
+            return BenchmarkResult{}, fmt.Errorf("Could not run network benchmark: %w", err)
+        }
+        result.DownloadSpeed = downloadSpeed
+        result.NetworkTested = true
+    }
+
+    // Return
+    return result, nil
+
+}
+
+
+// Append a benchmark result to the history file on the host
+func (c *Client) SaveBenchmarkResult(result BenchmarkResult) error {
+    resultBytes, err := json.Marshal(result)
+    if err != nil {
+        return fmt.Errorf("Could not encode benchmark result: %w", err)
+    }
+    path := fmt.Sprintf("%s/%s", RocketPoolPath, BenchmarkHistoryFile)
+    if _, err := c.readOutput(fmt.Sprintf("cat >> %s <<'EOF'\n%s\nEOF", path, string(resultBytes))); err != nil {
+        return fmt.Errorf("Could not write benchmark history to %s: %w", path, err)
+    }
+    return nil
+}
+
+
+// Get the benchmark result history from the host, oldest first
+func (c *Client) GetBenchmarkHistory() ([]BenchmarkResult, error) {
+
+    // Read history file
+    path := fmt.Sprintf("%s/%s", RocketPoolPath, BenchmarkHistoryFile)
+    historyBytes, err := c.readOutput(fmt.Sprintf("cat %s 2>/dev/null || true", path))
+    if err != nil {
+        return []BenchmarkResult{}, fmt.Errorf("Could not read benchmark history at %s: %w", path, err)
+    }
+
+    // Decode results
+    results := []BenchmarkResult{}
+    for _, line := range strings.Split(string(historyBytes), "\n") {
+        if strings.TrimSpace(line) == "" { continue }
+        var result BenchmarkResult
+        if err := json.Unmarshal([]byte(line), &result); err != nil {
+            continue
+        }
+        results = append(results, result)
+    }
+
+    // Return
+    return results, nil
+
+}
+
+
+// Run a dd disk benchmark and return the elapsed time in seconds
+func (c *Client) runDiskBenchmark(ddCmd string) (float64, error) {
+
+    // Run command
+    output, err := c.readLongOutput(fmt.Sprintf("LC_ALL=C %s 2>&1", ddCmd))
+    if err != nil {
+        return 0, fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+    }
+
+    // Parse elapsed time from dd summary
+    matches := regexp.MustCompile(`copied, ([0-9.e+-]+) s`).FindStringSubmatch(string(output))
+    if len(matches) < 2 {
+        return 0, fmt.Errorf("Could not parse dd output '%s'", strings.TrimSpace(string(output)))
+    }
+    seconds, err := strconv.ParseFloat(matches[1], 64)
+    if err != nil {
+        return 0, fmt.Errorf("Could not parse elapsed time '%s': %w", matches[1], err)
+    }
+    if seconds <= 0 {
+        return 0, errors.New("Benchmark completed too quickly to measure")
+    }
+
+    // Return
+    return seconds, nil
+
+}
+
+
+// Run a download benchmark and return the throughput in Mbps
+func (c *Client) runNetworkBenchmark() (float64, error) {
+
+    // Check for cURL
+    hasCurl, err := c.readOutput("command -v curl")
+    if err != nil || len(hasCurl) == 0 {
+        return 0, errors.New("cURL is required to run the network benchmark.")
+    }
+
+    // Run download
+    output, err := c.readOutput(fmt.Sprintf("curl -sL -o /dev/null --max-time %d -w '%%{speed_download}' '%s'", BenchmarkDownloadTimeout, BenchmarkDownloadURL))
+    if err != nil && len(output) == 0 {
+        return 0, err
+    }
+
+    // Parse download speed (bytes per second)
+    bytesPerSecond, err := strconv.ParseFloat(strings.TrimSpace(strings.Replace(string(output), ",", ".", 1)), 64)
+    if err != nil {
+        return 0, fmt.Errorf("Could not parse download speed '%s': %w", strings.TrimSpace(string(output)), err)
+    }
+
+    // Return
+    return (bytesPerSecond * 8) / 1000000, nil
+
+}
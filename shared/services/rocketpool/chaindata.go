@@ -0,0 +1,294 @@
+package rocketpool
+
+import (
+    "errors"
+    "fmt"
+    "path"
+    "strconv"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Config
+const (
+    Eth1ComposeVolume = "eth1clientdata"
+    Eth2ComposeVolume = "eth2clientdata"
+    Eth1VolumeName = ComposeProjectName + "_" + Eth1ComposeVolume
+    Eth2VolumeName = ComposeProjectName + "_" + Eth2ComposeVolume
+    ChainDataFile = "chaindata.snapshot"
+    ChainDataImportImage = "alpine:latest"
+)
+
+
+// Chain data placement within the eth1 volume by client
+type chainDataPlacement struct {
+    Folder string
+    Owner string
+}
+var chainDataPlacements = map[string]chainDataPlacement{
+    "geth": chainDataPlacement{
+        Folder: "geth",
+        Owner: "root:root",
+    },
+}
+
+
+// Offline pruning procedures by client; commands are run in the client image with its chain data mounted at /ethclient
+type pruneProcedure struct {
+    Entrypoint string
+    Args string
+    MinFreeSpace uint64
+}
+var pruneProcedures = map[string]pruneProcedure{
+    "geth": pruneProcedure{
+        Entrypoint: "geth",
+        Args: "snapshot prune-state --datadir /ethclient",
+        MinFreeSpace: 40 * 1024 * 1024 * 1024,
+    },
+}
+
+
+// Import an Eth 1.0 chain data snapshot from a URL or host file path into the eth1 volume
+func (c *Client) ImportChainData(source, checksum string) error {
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+
+    // Check selected client
+    if rpConfig.Chains.Eth1.External {
+        return errors.New("The Eth 1.0 client is externally managed; chain data must be imported into it directly.")
+    }
+    eth1Client := rpConfig.GetSelectedEth1Client()
+    if eth1Client == nil {
+        return errors.New("No Eth 1.0 client selected. Please run 'rocketpool service config' and try again.")
+    }
+    placement, ok := chainDataPlacements[eth1Client.ID]
+    if !ok {
+        return fmt.Errorf("Chain data import is not supported for the %s client.", eth1Client.Name)
+    }
+
+    // Get snapshot file, downloading if required
+    snapshotPath := source
+    if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+        snapshotPath = fmt.Sprintf("%s/%s", RocketPoolPath, ChainDataFile)
+        defer c.readOutput(fmt.Sprintf("rm -f %s", snapshotPath))
+        downloader, err := c.getDownloader()
+        if err != nil { return err }
+        fmt.Printf("Downloading chain data snapshot from %s...\n", source)
+        if _, err := c.readLongOutput(fmt.Sprintf("%s '%s' > %s", downloader, source, snapshotPath)); err != nil {
+            return fmt.Errorf("Could not download chain data snapshot: %w", err)
+        }
+    } else if _, err := c.readOutput(fmt.Sprintf("test -f '%s'", snapshotPath)); err != nil {
+        return fmt.Errorf("Chain data snapshot file %s does not exist on the host.", snapshotPath)
+    }
+
+    // Verify snapshot checksum
+    if checksum != "" {
+        fmt.Println("Verifying chain data snapshot checksum...")
+        hash, err := c.readLongOutput(fmt.Sprintf("sha256sum '%s' | cut -d ' ' -f 1", snapshotPath))
+        if err != nil {
+            return fmt.Errorf("Could not get chain data snapshot checksum: %w", err)
+        }
+        if !strings.EqualFold(strings.TrimSpace(string(hash)), strings.TrimSpace(checksum)) {
+            return fmt.Errorf("Chain data snapshot checksum %s does not match expected checksum %s.", strings.TrimSpace(string(hash)), checksum)
+        }
+    }
+
+    // Stop eth1 container
+    stopCmd, err := c.compose(fmt.Sprintf("stop %s", Eth1ServiceName))
+    if err != nil { return err }
+    if err := c.printOutput(stopCmd); err != nil {
+        return fmt.Errorf("Could not stop the Eth 1.0 client: %w", err)
+    }
+
+    // Get archive extraction flags
+    var tarFlags string
+    switch {
+        case strings.HasSuffix(source, ".tar.gz") || strings.HasSuffix(source, ".tgz"): tarFlags = "-xzf"
+        case strings.HasSuffix(source, ".tar.bz2"): tarFlags = "-xjf"
+        default: tarFlags = "-xf"
+    }
+
+    // Extract snapshot into volume, replacing existing chain data, and fix ownership
+    fmt.Println("Importing chain data snapshot, this may take some time...")
+    dataPath := fmt.Sprintf("/ethclient/%s", placement.Folder)
+    importScript := fmt.Sprintf("rm -rf %s && mkdir -p %s && tar %s /snapshot -C %s && chown -R %s %s", dataPath, dataPath, tarFlags, dataPath, placement.Owner, dataPath)
+    importCmd, err := c.runtimeCommand(fmt.Sprintf("run --rm -v %s:/ethclient -v \"$(realpath '%s')\":/snapshot:ro %s sh -c '%s'", getChainDataMount(rpConfig.Chains.Eth1, Eth1VolumeName), snapshotPath, ChainDataImportImage, importScript))
+    if err != nil { return err }
+    if err := c.printOutput(importCmd); err != nil {
+        return fmt.Errorf("Could not import chain data snapshot: %w", err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Move a managed client's chain data from its docker volume or current host path to a new host path
+// The existing chain data is copied rather than moved, and left in place until removed by the user
+func (c *Client) MigrateChainData(chainID, targetPath string) (string, error) {
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return "", err
+    }
+    userConfig, err := c.LoadUserConfig()
+    if err != nil {
+        return "", err
+    }
+
+    // Get chain
+    var chain config.Chain
+    var chainName, serviceName, volumeName string
+    var userDataPath *string
+    switch chainID {
+        case "eth1":
+            chain, chainName, serviceName, volumeName, userDataPath = rpConfig.Chains.Eth1, "Eth 1.0", Eth1ServiceName, Eth1VolumeName, &(userConfig.Chains.Eth1.DataPath)
+        case "eth2":
+            chain, chainName, serviceName, volumeName, userDataPath = rpConfig.Chains.Eth2, "Eth 2.0", Eth2ServiceName, Eth2VolumeName, &(userConfig.Chains.Eth2.DataPath)
+        default:
+            return "", fmt.Errorf("Unknown chain '%s'; expected 'eth1' or 'eth2'.", chainID)
+    }
+
+    // Check chain & target path
+    if chain.External {
+        return "", fmt.Errorf("The %s client is externally managed; its chain data must be moved directly.", chainName)
+    }
+    if !path.IsAbs(targetPath) {
+        return "", fmt.Errorf("The chain data path %s must be an absolute path on the host.", targetPath)
+    }
+    targetPath = path.Clean(targetPath)
+    sourceMount := getChainDataMount(chain, volumeName)
+    if sourceMount == targetPath {
+        return "", fmt.Errorf("The %s chain data is already stored at %s.", chainName, targetPath)
+    }
+    if _, err := c.readOutput(fmt.Sprintf("test -z \"$(ls -A '%s' 2>/dev/null)\"", targetPath)); err != nil {
+        return "", fmt.Errorf("The chain data path %s is not empty.", targetPath)
+    }
+
+    // Stop client container
+    stopCmd, err := c.compose(fmt.Sprintf("stop %s", serviceName))
+    if err != nil { return "", err }
+    if err := c.printOutput(stopCmd); err != nil {
+        return "", fmt.Errorf("Could not stop the %s client: %w", chainName, err)
+    }
+
+    // Copy chain data, preserving ownership & permissions
+    fmt.Printf("Copying %s chain data to %s, this may take some time...\n", chainName, targetPath)
+    if _, err := c.readOutput(fmt.Sprintf("mkdir -p '%s'", targetPath)); err != nil {
+        return "", fmt.Errorf("Could not create chain data path %s: %w", targetPath, err)
+    }
+    copyCmd, err := c.runtimeCommand(fmt.Sprintf("run --rm -v %s:/source:ro -v '%s':/target %s sh -c 'cp -a /source/. /target/'", sourceMount, targetPath, ChainDataImportImage))
+    if err != nil { return "", err }
+    if err := c.printOutput(copyCmd); err != nil {
+        return "", fmt.Errorf("Could not copy chain data: %w", err)
+    }
+
+    // Verify copy by comparing file counts & total file sizes
+    fmt.Println("Verifying copied chain data...")
+    verifyScript := `for d in /source /target; do echo \$(find \$d | wc -l) \$(find \$d -type f -exec stat -c %s {} + | awk '{s+=\$1} END {print s}'); done`
+    verifyCmd, err := c.runtimeCommand(fmt.Sprintf("run --rm -v %s:/source:ro -v '%s':/target:ro %s sh -c \"%s\"", sourceMount, targetPath, ChainDataImportImage, verifyScript))
+    if err != nil { return "", err }
+    summary, err := c.readLongOutput(verifyCmd)
+    if err != nil {
+        return "", fmt.Errorf("Could not verify copied chain data: %w", err)
+    }
+    lines := strings.Split(strings.TrimSpace(string(summary)), "\n")
+    if len(lines) != 2 || lines[0] != lines[1] {
+        return "", fmt.Errorf("The copied chain data at %s does not match the original; the config was not changed and the original chain data is intact.", targetPath)
+    }
+
+    // Update config
+    *userDataPath = targetPath
+    if err := c.SaveUserConfig(userConfig, fmt.Sprintf("Moved %s chain data to %s", chainName, targetPath)); err != nil {
+        return "", err
+    }
+
+    // Return previous location
+    return sourceMount, nil
+
+}
+
+
+// Prune the Eth 1.0 client's chain data offline
+// The Rocket Pool service is stopped while the client's pruning command runs in a one-off container, and restarted after
+// it succeeds; if pruning fails, the service is left stopped so that the chain data can be checked
+func (c *Client) PruneEth1() error {
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+    arch, err := c.GetArchitecture()
+    if err != nil {
+        return err
+    }
+    rpConfig = rpConfig.ForArchitecture(arch)
+
+    // Check selected client
+    if rpConfig.Chains.Eth1.External {
+        return errors.New("The Eth 1.0 client is externally managed; it must be pruned directly.")
+    }
+    eth1Client := rpConfig.GetSelectedEth1Client()
+    if eth1Client == nil {
+        return errors.New("No Eth 1.0 client selected. Please run 'rocketpool service config' and try again.")
+    }
+    procedure, ok := pruneProcedures[eth1Client.ID]
+    if !ok {
+        return fmt.Errorf("Offline pruning is not supported for the %s client.", eth1Client.Name)
+    }
+    mount := getChainDataMount(rpConfig.Chains.Eth1, Eth1VolumeName)
+
+    // Check free disk space on the chain data volume
+    dfCmd, err := c.runtimeCommand(fmt.Sprintf("run --rm -v %s:/ethclient:ro %s sh -c \"df -Pk /ethclient | tail -n 1 | awk '{print \\$4}'\"", mount, ChainDataImportImage))
+    if err != nil { return err }
+    dfOutput, err := c.readOutput(dfCmd)
+    if err != nil {
+        return fmt.Errorf("Could not get free disk space for the Eth 1.0 chain data: %w", err)
+    }
+    freeKB, err := strconv.ParseUint(strings.TrimSpace(string(dfOutput)), 10, 64)
+    if err != nil {
+        return fmt.Errorf("Could not parse free disk space '%s': %w", strings.TrimSpace(string(dfOutput)), err)
+    }
+    if freeKB * 1024 < procedure.MinFreeSpace {
+        return fmt.Errorf("Pruning the %s chain data needs at least %d GB of free disk space, but only %d GB is available.", eth1Client.Name, procedure.MinFreeSpace / (1024 * 1024 * 1024), freeKB / (1024 * 1024))
+    }
+
+    // Stop service
+    fmt.Println("Stopping the Rocket Pool service...")
+    stopCmd, err := c.compose("stop")
+    if err != nil { return err }
+    if err := c.printOutput(stopCmd); err != nil {
+        return fmt.Errorf("Could not stop the Rocket Pool service: %w", err)
+    }
+
+    // Prune chain data
+    fmt.Printf("Pruning the %s chain data, this may take several hours...\n", eth1Client.Name)
+    pruneCmd, err := c.runtimeCommand(fmt.Sprintf("run --rm -v %s:/ethclient --entrypoint %s %s %s", mount, procedure.Entrypoint, eth1Client.Image, procedure.Args))
+    if err != nil { return err }
+    if err := c.printOutput(pruneCmd); err != nil {
+        return fmt.Errorf("Could not prune the Eth 1.0 chain data; the Rocket Pool service has been left stopped: %w", err)
+    }
+
+    // Restart service
+    fmt.Println("Restarting the Rocket Pool service...")
+    return c.StartService()
+
+}
+
+
+// Get the docker volume or host path a client's chain data is mounted from
+func getChainDataMount(chain config.Chain, volumeName string) string {
+    if chain.DataPath != "" {
+        return chain.DataPath
+    }
+    return volumeName
+}
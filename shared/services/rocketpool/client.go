@@ -2,19 +2,26 @@ package rocketpool
 
 import (
     "bufio"
+    "context"
+    "encoding/json"
     "errors"
     "fmt"
     "io"
     "io/ioutil"
     "os"
+    "os/signal"
     "strings"
+    "time"
 
     "github.com/fatih/color"
     "github.com/urfave/cli"
     "golang.org/x/crypto/ssh"
 
     "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
+    "github.com/rocket-pool/smartnode/shared/types/api"
     "github.com/rocket-pool/smartnode/shared/utils/net"
+    "github.com/rocket-pool/smartnode/shared/utils/retry"
 )
 
 
@@ -26,23 +33,59 @@ const (
     GlobalConfigFile = "config.yml"
     UserConfigFile = "settings.yml"
     ComposeFile = "docker-compose.yml"
+    ComposeOverrideFile = "docker-compose.override.yml"
+    ComposeProjectName = "rocketpool"
+
+    Eth1ServiceName = "eth1"
+    Eth2ServiceName = "eth2"
 
     APIContainerName = "rocketpool_api"
+    DockerSocketPath = "/var/run/docker.sock"
+    PodmanSocketPath = "/run/podman/podman.sock"
+    RootlessPodmanSocketPath = "podman/podman.sock"
+    DisabledDockerSocketPath = "/dev/null"
     APIBinPath = "/go/bin/rocketpool"
 
     DebugColor = color.FgYellow
+
+    DefaultAPITimeout = 15 * time.Minute
+    DefaultCommandTimeout = 2 * time.Minute
+    SSHDialTimeout = 30 * time.Second
 )
 
 
 // Rocket Pool client
 type Client struct {
     client *ssh.Client
+    ctx context.Context
+    apiTimeout time.Duration
+    commandTimeout time.Duration
+    interruptible bool
+    maxFee float64
+    priorityFee float64
+    offline bool
+    dryRun bool
+    accessible bool
+    apiSocketPath string
+    runtime *ContainerRuntime
+    backend ServiceBackend
+    architecture string
 }
 
 
 // Create new Rocket Pool client from CLI context
 func NewClientFromCtx(c *cli.Context) (*Client, error) {
-    return NewClient(c.GlobalString("host"), c.GlobalString("user"), c.GlobalString("key"))
+    client, err := NewClient(c.GlobalString("host"), c.GlobalString("user"), c.GlobalString("key"))
+    if err != nil {
+        return nil, err
+    }
+    client.SetGasSettings(c.GlobalFloat64("max-fee"), c.GlobalFloat64("priority-fee"))
+    client.SetOffline(c.GlobalBool("offline"))
+    client.SetDryRun(c.GlobalBool("dry-run"))
+    client.SetAccessible(c.GlobalBool("accessible"))
+    client.SetTimeouts(c.GlobalDuration("api-timeout"), c.GlobalDuration("command-timeout"))
+    client.SetInterruptible(true)
+    return client, nil
 }
 
 
@@ -74,11 +117,18 @@ func NewClient(hostAddress, user, keyPath string) (*Client, error) {
         }
 
         // Initialise client
-        sshClient, err = ssh.Dial("tcp", net.DefaultPort(hostAddress, "22"), &ssh.ClientConfig{
-            User: user,
-            Auth: []ssh.AuthMethod{ssh.PublicKeys(key)},
-            HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+        span := tracing.StartSpan("ssh connect", "host", hostAddress)
+        err = retry.Do(context.Background(), retry.DefaultPolicy, func() error {
+            var dialErr error
+            sshClient, dialErr = ssh.Dial("tcp", net.DefaultPort(hostAddress, "22"), &ssh.ClientConfig{
+                User: user,
+                Auth: []ssh.AuthMethod{ssh.PublicKeys(key)},
+                HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+                Timeout: SSHDialTimeout,
+            })
+            return dialErr
         })
+        span.End(err)
         if err != nil {
             return nil, fmt.Errorf("Could not connect to %s as %s: %w", hostAddress, user, err)
         }
@@ -88,6 +138,9 @@ func NewClient(hostAddress, user, keyPath string) (*Client, error) {
     // Return client
     return &Client{
         client: sshClient,
+        ctx: context.Background(),
+        apiTimeout: DefaultAPITimeout,
+        commandTimeout: DefaultCommandTimeout,
     }, nil
 
 }
@@ -101,20 +154,137 @@ func (c *Client) Close() {
 }
 
 
+// Set the maximum fee & priority fee in gwei for transactions sent via the API; 0 uses the smart node settings
+func (c *Client) SetGasSettings(maxFee, priorityFee float64) {
+    c.maxFee = maxFee
+    c.priorityFee = priorityFee
+}
+
+
+// Set whether API commands prepare their transactions for offline signing instead of signing and sending them
+func (c *Client) SetOffline(offline bool) {
+    c.offline = offline
+}
+
+
+// Set whether API commands simulate their transactions instead of signing and sending them
+func (c *Client) SetDryRun(dryRun bool) {
+    c.dryRun = dryRun
+}
+
+
+// Set whether service command output should avoid colors, animations and table formatting
+func (c *Client) SetAccessible(accessible bool) {
+    c.accessible = accessible
+}
+
+
+// Set the timeouts for API calls and for commands run on the smart node host; 0 disables a timeout
+// Long-running commands (e.g. service logs, downloads and installation) are not subject to the command timeout
+func (c *Client) SetTimeouts(apiTimeout, commandTimeout time.Duration) {
+    c.apiTimeout = apiTimeout
+    c.commandTimeout = commandTimeout
+}
+
+
+// Set the context which API calls and commands are run in; they are cancelled if it is cancelled
+func (c *Client) SetContext(ctx context.Context) {
+    c.ctx = ctx
+}
+
+
+// Get a copy of the client which runs API calls and commands in a context; they are cancelled if it is cancelled
+// The copy shares the client's remote connection, so only the original client should be closed
+func (c *Client) WithContext(ctx context.Context) *Client {
+    client := *c
+    client.ctx = ctx
+    return &client
+}
+
+
+// Set whether running API calls and commands are cancelled on interrupt (SIGINT)
+// Interrupts are only caught while a call or command is running, so prompts can still be interrupted as usual
+func (c *Client) SetInterruptible(interruptible bool) {
+    c.interruptible = interruptible
+}
+
+
 // Load the global config
 func (c *Client) LoadGlobalConfig() (config.RocketPoolConfig, error) {
-    return c.loadConfig(fmt.Sprintf("%s/%s", RocketPoolPath, GlobalConfigFile))
+    return c.loadConfig(fmt.Sprintf("%s/%s", RocketPoolPath, GlobalConfigFile), nil)
 }
 
 
-// Save the user config
-func (c *Client) SaveUserConfig(cfg config.RocketPoolConfig) error {
-    return c.saveConfig(cfg, fmt.Sprintf("%s/%s", RocketPoolPath, UserConfigFile))
+// Load the merged global & user config
+func (c *Client) LoadMergedConfig() (config.RocketPoolConfig, error) {
+    globalConfig, err := c.loadConfig(fmt.Sprintf("%s/%s", RocketPoolPath, GlobalConfigFile), nil)
+    if err != nil {
+        return config.RocketPoolConfig{}, err
+    }
+    userConfig, err := c.loadConfig(fmt.Sprintf("%s/%s", RocketPoolPath, UserConfigFile), &globalConfig)
+    if err != nil {
+        return config.RocketPoolConfig{}, err
+    }
+    return config.Merge(&globalConfig, &userConfig), nil
+}
+
+
+// Load the user config; returns an empty config if the user config has not been created
+func (c *Client) LoadUserConfig() (config.RocketPoolConfig, error) {
+    configBytes, err := c.readOutputRetry(fmt.Sprintf("cat %s/%s 2>/dev/null || true", RocketPoolPath, UserConfigFile))
+    if err != nil {
+        return config.RocketPoolConfig{}, fmt.Errorf("Could not read Rocket Pool user config: %w", err)
+    }
+    if configBytes, err = c.migrateUserConfig(configBytes); err != nil {
+        return config.RocketPoolConfig{}, err
+    }
+    return config.Parse(configBytes)
+}
+
+
+// Migrate the user config to the current layout version
+// Migrated settings are saved, with the original file kept as settings.yml.v<version>.bak
+func (c *Client) migrateUserConfig(configBytes []byte) ([]byte, error) {
+    path := fmt.Sprintf("%s/%s", RocketPoolPath, UserConfigFile)
+    migration, err := config.Migrate(configBytes)
+    if err != nil {
+        return nil, fmt.Errorf("Could not migrate Rocket Pool user config at %s: %w", path, err)
+    }
+    if !migration.Changed {
+        return configBytes, nil
+    }
+    backupPath := fmt.Sprintf("%s.v%d.bak", path, migration.FromVersion)
+    if _, err := c.readOutput(fmt.Sprintf("cp -p %s %s", path, backupPath)); err != nil {
+        return nil, fmt.Errorf("Could not back up Rocket Pool user config to %s: %w", backupPath, err)
+    }
+    if _, err := c.readOutput(fmt.Sprintf("cat > %s <<'EOF'\n%sEOF", path, string(migration.Bytes))); err != nil {
+        return nil, fmt.Errorf("Could not write migrated Rocket Pool user config to %s: %w", path, err)
+    }
+    fmt.Fprintf(os.Stderr, "Migrated the Rocket Pool user config from version %d to version %d; the original was saved to %s.\n", migration.FromVersion, config.ConfigVersion, backupPath)
+    return migration.Bytes, nil
+}
+
+
+// Save the user config, recording the change in the config history
+func (c *Client) SaveUserConfig(cfg config.RocketPoolConfig, description string) error {
+    previousConfig, err := c.LoadUserConfig()
+    if err != nil {
+        return err
+    }
+    if err := c.saveConfig(cfg, fmt.Sprintf("%s/%s", RocketPoolPath, UserConfigFile)); err != nil {
+        return err
+    }
+    if err := c.recordConfigRevision(previousConfig, cfg, description); err != nil {
+        return fmt.Errorf("The config was saved, but could not be recorded in the config history: %w", err)
+    }
+    return nil
 }
 
 
 // Install the Rocket Pool service
-func (c *Client) InstallService(verbose, noDeps bool, network, version string) error {
+// The systemd backend installs the clients & smart node natively as systemd units, rather than pulling container images
+// The installer detects the host architecture if none is given
+func (c *Client) InstallService(verbose, noDeps bool, network, version, backend, architecture string) error {
 
     // Get installation script downloader type
     downloader, err := c.getDownloader()
@@ -128,9 +298,17 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version string) e
     if noDeps {
         flags = append(flags, "-d")
     }
+    if backend == BackendSystemd {
+        flags = append(flags, "-b", backend)
+    }
+    if architecture != "" {
+        flags = append(flags, "-a", architecture)
+    }
 
     // Initialize installation command
-    cmd, err := c.newCommand(fmt.Sprintf("%s %s | sh -s -- %s", downloader, InstallerURL, strings.Join(flags, " ")))
+    ctx, cancel := c.commandContext(0)
+    defer cancel()
+    cmd, err := c.newCommand(ctx, fmt.Sprintf("%s %s | sh -s -- %s", downloader, InstallerURL, strings.Join(flags, " ")))
     if err != nil { return err }
     defer cmd.Close()
 
@@ -173,72 +351,74 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version string) e
 
 // Start the Rocket Pool service
 func (c *Client) StartService() error {
-    cmd, err := c.compose("up -d")
+    backend, err := c.GetServiceBackend()
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return backend.Start()
 }
 
 
 // Pause the Rocket Pool service
 func (c *Client) PauseService() error {
-    cmd, err := c.compose("stop")
+    backend, err := c.GetServiceBackend()
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return backend.Pause()
 }
 
 
 // Stop the Rocket Pool service
 func (c *Client) StopService() error {
-    cmd, err := c.compose("down -v")
+    backend, err := c.GetServiceBackend()
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return backend.Stop()
 }
 
 
 // Print the Rocket Pool service status
 func (c *Client) PrintServiceStatus() error {
-    cmd, err := c.compose("ps")
+    backend, err := c.GetServiceBackend()
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return backend.PrintStatus()
 }
 
 
 // Print the Rocket Pool service logs
-func (c *Client) PrintServiceLogs(tail string, serviceNames ...string) error {
-    cmd, err := c.compose(fmt.Sprintf("logs -f --tail %s %s", tail, strings.Join(serviceNames, " ")))
+func (c *Client) PrintServiceLogs(options LogOptions, serviceNames ...string) error {
+    backend, err := c.GetServiceBackend()
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return backend.PrintLogs(options, serviceNames...)
 }
 
 
 // Print the Rocket Pool service stats
 func (c *Client) PrintServiceStats() error {
-
-    // Get service container IDs
-    cmd, err := c.compose("ps -q")
+    backend, err := c.GetServiceBackend()
     if err != nil { return err }
-    containers, err := c.readOutput(cmd)
-    if err != nil { return err }
-    containerIds := strings.Split(strings.TrimSpace(string(containers)), "\n")
-
-    // Print stats
-    return c.printOutput(fmt.Sprintf("docker stats %s", strings.Join(containerIds, " ")))
-
+    return backend.PrintStats()
 }
 
 
 // Load a config file
-func (c *Client) loadConfig(path string) (config.RocketPoolConfig, error) {
-    configBytes, err := c.readOutput(fmt.Sprintf("cat %s", path))
+// The file is validated against the config schema; base is the global config when loading the user config, which is also migrated
+func (c *Client) loadConfig(path string, base *config.RocketPoolConfig) (config.RocketPoolConfig, error) {
+    configBytes, err := c.readOutputRetry(fmt.Sprintf("cat %s", path))
     if err != nil {
         return config.RocketPoolConfig{}, fmt.Errorf("Could not read Rocket Pool config at %s: %w", path, err)
     }
+    if base != nil {
+        if configBytes, err = c.migrateUserConfig(configBytes); err != nil {
+            return config.RocketPoolConfig{}, err
+        }
+    }
+    if err := config.ValidateFile(path, configBytes, base); err != nil {
+        return config.RocketPoolConfig{}, err
+    }
     return config.Parse(configBytes)
 }
 
 
 // Save a config file
 func (c *Client) saveConfig(cfg config.RocketPoolConfig, path string) error {
+    cfg.Version = config.ConfigVersion
     configBytes, err := cfg.Serialize()
     if err != nil {
         return err
@@ -250,31 +430,46 @@ func (c *Client) saveConfig(cfg config.RocketPoolConfig, path string) error {
 }
 
 
-// Build a docker-compose command
-func (c *Client) compose(args string) (string, error) {
+// Get the environment variables passed to docker-compose for a config
+// Client images & param defaults are selected for the host architecture
+func (c *Client) GetComposeEnv(rpConfig config.RocketPoolConfig) ([]string, error) {
 
-    // Load config
-    globalConfig, err := c.loadConfig(fmt.Sprintf("%s/%s", RocketPoolPath, GlobalConfigFile))
-    if err != nil {
-        return "", err
-    }
-    userConfig, err := c.loadConfig(fmt.Sprintf("%s/%s", RocketPoolPath, UserConfigFile))
+    // Get host architecture
+    arch, err := c.GetArchitecture()
     if err != nil {
-        return "", err
+        return []string{}, err
     }
-    rpConfig := config.Merge(&globalConfig, &userConfig)
+    rpConfig = rpConfig.ForArchitecture(arch)
 
     // Check config
     if rpConfig.GetSelectedEth1Client() == nil {
-        return "", errors.New("No Eth 1.0 client selected. Please run 'rocketpool service config' and try again.")
+        return []string{}, errors.New("No Eth 1.0 client selected. Please run 'rocketpool service config' and try again.")
     }
     if rpConfig.GetSelectedEth2Client() == nil {
-        return "", errors.New("No Eth 2.0 client selected. Please run 'rocketpool service config' and try again.")
+        return []string{}, errors.New("No Eth 2.0 client selected. Please run 'rocketpool service config' and try again.")
+    }
+    for _, client := range []*config.ClientOption{rpConfig.GetSelectedEth1Client(), rpConfig.GetSelectedEth2Client()} {
+        if !client.SupportsArchitecture(arch) {
+            return []string{}, fmt.Errorf("The %s client does not support %s hosts. Please run 'rocketpool service config' to select another client and try again.", client.Name, arch)
+        }
+    }
+
+    // Get container runtime
+    runtime, err := c.GetContainerRuntime()
+    if err != nil {
+        return []string{}, err
+    }
+
+    // Get docker socket to mount in the node & api containers
+    dockerSocket := runtime.SocketPath
+    if rpConfig.Smartnode.DockerSocketDisabled {
+        dockerSocket = DisabledDockerSocketPath
     }
 
     // Set environment variables from config
     env := []string{
-        "COMPOSE_PROJECT_NAME=rocketpool",
+        fmt.Sprintf("COMPOSE_PROJECT_NAME=%s", ComposeProjectName),
+        fmt.Sprintf("ARCHITECTURE=%s",     arch),
         fmt.Sprintf("ETH1_CLIENT=%s",      rpConfig.GetSelectedEth1Client().ID),
         fmt.Sprintf("ETH1_IMAGE=%s",       rpConfig.GetSelectedEth1Client().Image),
         fmt.Sprintf("ETH2_CLIENT=%s",      rpConfig.GetSelectedEth2Client().ID),
@@ -283,23 +478,222 @@ func (c *Client) compose(args string) (string, error) {
         fmt.Sprintf("VALIDATOR_IMAGE=%s",  rpConfig.GetSelectedEth2Client().GetValidatorImage()),
         fmt.Sprintf("ETH1_PROVIDER=%s",    rpConfig.Chains.Eth1.Provider),
         fmt.Sprintf("ETH2_PROVIDER=%s",    rpConfig.Chains.Eth2.Provider),
+        fmt.Sprintf("DOPPELGANGER_DETECTION=%t", rpConfig.Smartnode.DoppelgangerProtection),
+        fmt.Sprintf("REMOTE_SIGNER_URL=%s",  rpConfig.RemoteSigner.URL),
+        fmt.Sprintf("DOCKER_SOCKET=%s",      dockerSocket),
+        fmt.Sprintf("ETH1_DATA_VOLUME=%s",   getChainDataMount(rpConfig.Chains.Eth1, Eth1ComposeVolume)),
+        fmt.Sprintf("ETH2_DATA_VOLUME=%s",   getChainDataMount(rpConfig.Chains.Eth2, Eth2ComposeVolume)),
+        fmt.Sprintf("ETH1_STATIC_PEERS='%s'", strings.Join(rpConfig.Chains.Eth1.StaticPeers, ",")),
+        fmt.Sprintf("ETH1_BOOTNODES='%s'",    strings.Join(rpConfig.Chains.Eth1.Bootnodes, ",")),
+        fmt.Sprintf("ETH2_STATIC_PEERS='%s'", strings.Join(rpConfig.Chains.Eth2.StaticPeers, ",")),
+        fmt.Sprintf("ETH2_BOOTNODES='%s'",    strings.Join(rpConfig.Chains.Eth2.Bootnodes, ",")),
+        fmt.Sprintf("ETH1_P2P_PORT=%d",       rpConfig.GetEth1P2PPort()),
+        fmt.Sprintf("ETH2_P2P_PORT=%d",       rpConfig.GetEth2P2PPort()),
+    }
+    eth1Env, err := rpConfig.Chains.Eth1.GetClientEnv()
+    if err != nil {
+        return []string{}, err
+    }
+    eth2Env, err := rpConfig.Chains.Eth2.GetClientEnv()
+    if err != nil {
+        return []string{}, err
+    }
+    env = append(env, eth1Env...)
+    env = append(env, eth2Env...)
+
+    // Return
+    return env, nil
+
+}
+
+
+// Build a docker-compose or podman-compose command
+func (c *Client) compose(args string) (string, error) {
+
+    // Check service backend
+    if err := c.checkComposeBackend(); err != nil {
+        return "", err
+    }
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return "", err
+    }
+
+    // Get container runtime & environment variables
+    runtime, err := c.GetContainerRuntime()
+    if err != nil {
+        return "", err
     }
-    for _, param := range rpConfig.Chains.Eth1.Client.Params {
-        env = append(env, fmt.Sprintf("%s=%s", param.Env, param.Value))
+    env, err := c.GetComposeEnv(rpConfig)
+    if err != nil {
+        return "", err
     }
-    for _, param := range rpConfig.Chains.Eth2.Client.Params {
-        env = append(env, fmt.Sprintf("%s=%s", param.Env, param.Value))
+
+    // Get compose files
+    composeFiles := c.getComposeFiles()
+
+    // Return podman-compose command; paths are relative to the compose file folder, and output is not colored
+    if runtime.Name == RuntimePodman {
+        return fmt.Sprintf("%s %s %s %s", strings.Join(env, " "), runtime.ComposeCmd, composeFiles, args), nil
+    }
+
+    // Disable colors & progress animations in accessible mode
+    if c.accessible {
+        args = "--no-ansi " + args
     }
 
     // Return command
-    return fmt.Sprintf("%s docker-compose --project-directory %s -f %s %s", strings.Join(env, " "), RocketPoolPath, fmt.Sprintf("%s/%s", RocketPoolPath, ComposeFile), args), nil
+    return fmt.Sprintf("%s %s --project-directory %s %s %s", strings.Join(env, " "), runtime.ComposeCmd, RocketPoolPath, composeFiles, args), nil
+
+}
+
+
+// Get the compose file arguments for a compose command
+// The user's override file is appended after the Rocket Pool compose file if it exists, so its settings take precedence and survive upgrades
+func (c *Client) getComposeFiles() string {
+    files := fmt.Sprintf("-f %s/%s", RocketPoolPath, ComposeFile)
+    if _, err := c.readOutput(fmt.Sprintf("test -f %s/%s", RocketPoolPath, ComposeOverrideFile)); err == nil {
+        files += fmt.Sprintf(" -f %s/%s", RocketPoolPath, ComposeOverrideFile)
+    }
+    return files
+}
+
+
+// Get the names of the docker-compose services managed by Rocket Pool, and those replaced by external clients
+func (c *Client) getServices() ([]string, []string, error) {
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return []string{}, []string{}, err
+    }
+
+    // Get external services
+    externalServices := []string{}
+    if rpConfig.Chains.Eth1.External {
+        externalServices = append(externalServices, Eth1ServiceName)
+    }
+    if rpConfig.Chains.Eth2.External {
+        externalServices = append(externalServices, Eth2ServiceName)
+    }
+    if len(externalServices) == 0 {
+        return []string{}, externalServices, nil
+    }
+
+    // Get all services
+    cmd, err := c.compose("config --services")
+    if err != nil {
+        return []string{}, []string{}, err
+    }
+    serviceNames, err := c.readOutput(cmd)
+    if err != nil {
+        return []string{}, []string{}, fmt.Errorf("Could not get Rocket Pool service names: %w", err)
+    }
+
+    // Filter managed services
+    managedServices := []string{}
+    for _, serviceName := range strings.Fields(string(serviceNames)) {
+        external := false
+        for _, externalService := range externalServices {
+            if serviceName == externalService {
+                external = true
+                break
+            }
+        }
+        if !external {
+            managedServices = append(managedServices, serviceName)
+        }
+    }
+
+    // Return
+    return managedServices, externalServices, nil
 
 }
 
 
 // Call the Rocket Pool API
+// The API server socket is used if available, falling back to running the API command via the service backend
+// Transient failures are only retried before the request is delivered, as API commands may submit transactions
 func (c *Client) callAPI(args string) ([]byte, error) {
-    return c.readOutput(fmt.Sprintf("docker exec %s %s api %s", APIContainerName, APIBinPath, args))
+    globalArgs := []string{}
+    if c.maxFee > 0 {
+        globalArgs = append(globalArgs, "--maxFee", fmt.Sprintf("%f", c.maxFee))
+    }
+    if c.priorityFee > 0 {
+        globalArgs = append(globalArgs, "--priorityFee", fmt.Sprintf("%f", c.priorityFee))
+    }
+    if c.offline {
+        globalArgs = append(globalArgs, "--offline", "true")
+    }
+    if c.dryRun {
+        globalArgs = append(globalArgs, "--dryRun", "true")
+    }
+    if traceID := tracing.TraceID(); traceID != "" {
+        globalArgs = append(globalArgs, "--traceId", traceID)
+    }
+    span := tracing.StartSpan("call api " + args, "transport", "api server")
+    if c.client != nil {
+        span.SetAttribute("ssh", c.client.RemoteAddr().String())
+    }
+    ctx, cancel := c.commandContext(c.apiTimeout)
+    defer cancel()
+    var response []byte
+    err := retry.Do(ctx, retry.DefaultPolicy, func() error {
+        var err error
+        response, err = c.callAPIServer(ctx, globalArgs, args)
+        if !errors.Is(err, errAPIServerUnavailable) {
+            return retry.Permanent(err)
+        }
+        backend, err := c.GetServiceBackend()
+        if err != nil {
+            return err
+        }
+        span.SetAttribute("transport", backend.Name() + " exec")
+        execCmd, err := backend.APICommand(strings.Join(globalArgs, " "), args)
+        if err != nil {
+            return err
+        }
+        cmd, err := c.newCommand(ctx, execCmd)
+        if err != nil {
+            return err
+        }
+        defer cmd.Close()
+        response, err = cmd.Output()
+        return retry.Permanent(err)
+    })
+    err = contextError(ctx, err, c.apiTimeout)
+    span.End(err)
+    if err != nil {
+        return []byte{}, err
+    }
+    return decodeAPIResponse(response)
+}
+
+
+// Decode an API response envelope, returning the response data or an *api.Error if the command failed
+// Returns an *api.OfflineTransactionError if the command prepared its transaction for offline signing, or an
+// *api.DryRunError if it simulated its transaction in dry-run mode
+// Responses from API versions before the envelope was introduced are returned as is
+func decodeAPIResponse(responseBytes []byte) ([]byte, error) {
+    var envelope api.ResponseEnvelope
+    if err := json.Unmarshal(responseBytes, &envelope); err != nil {
+        return []byte{}, fmt.Errorf("Could not decode API response: %w", err)
+    }
+    if envelope.Version == 0 {
+        return responseBytes, nil
+    }
+    tracing.Add(envelope.Trace)
+    switch {
+        case envelope.Status == api.StatusSuccess:
+            return envelope.Data, nil
+        case envelope.Status == api.StatusOffline && envelope.Transaction != nil:
+            return []byte{}, &api.OfflineTransactionError{Transaction: *envelope.Transaction}
+        case envelope.Status == api.StatusDryRun && envelope.Simulation != nil:
+            return []byte{}, &api.DryRunError{Simulation: *envelope.Simulation}
+    }
+    return []byte{}, &api.Error{Code: envelope.Code, Message: envelope.Error}
 }
 
 
@@ -325,10 +719,13 @@ func (c *Client) getDownloader() (string, error) {
 
 
 // Run a command and print its output
+// The command is not subject to the command timeout, as it may be long-running (e.g. pulling images or following logs)
 func (c *Client) printOutput(cmdText string) error {
 
     // Initialize command
-    cmd, err := c.newCommand(cmdText)
+    ctx, cancel := c.commandContext(0)
+    defer cancel()
+    cmd, err := c.newCommand(ctx, cmdText)
     if err != nil { return err }
     defer cmd.Close()
 
@@ -341,16 +738,76 @@ func (c *Client) printOutput(cmdText string) error {
     go io.Copy(os.Stderr, cmdErr)
 
     // Run command
-    return cmd.Run()
+    return contextError(ctx, cmd.Run(), 0)
 
 }
 
 
-// Run a command and return its output
+// Run a command and pass each line of its output to a handler; stderr is merged into the output
+// The command is not subject to the command timeout, as it may be long-running
+func (c *Client) scanOutput(cmdText string, handle func(line string)) error {
+
+    // Initialize command
+    ctx, cancel := c.commandContext(0)
+    defer cancel()
+    cmd, err := c.newCommand(ctx, cmdText + " 2>&1")
+    if err != nil { return err }
+    defer cmd.Close()
+
+    // Start command
+    cmdOut, err := cmd.StdoutPipe()
+    if err != nil { return err }
+    if err := cmd.Start(); err != nil { return err }
+
+    // Handle output lines
+    scanner := bufio.NewScanner(cmdOut)
+    scanner.Buffer(make([]byte, 64 * 1024), MaxLogLineSize)
+    for scanner.Scan() {
+        handle(scanner.Text())
+    }
+
+    // Wait for command to complete
+    return contextError(ctx, cmd.Wait(), 0)
+
+}
+
+
+// Run a command and return its output; the command is cancelled after the command timeout
 func (c *Client) readOutput(cmdText string) ([]byte, error) {
+    ctx, cancel := c.commandContext(c.commandTimeout)
+    defer cancel()
+    output, err := c.readOutputContext(ctx, cmdText)
+    return output, contextError(ctx, err, c.commandTimeout)
+}
+
+
+// Run an idempotent command (e.g. reading a file) and return its output, retrying transient failures such as a lost SSH connection
+func (c *Client) readOutputRetry(cmdText string) ([]byte, error) {
+    var output []byte
+    err := retry.Do(c.ctx, retry.DefaultPolicy, func() error {
+        var err error
+        output, err = c.readOutput(cmdText)
+        return err
+    })
+    return output, err
+}
+
+
+// Run a long-running command (e.g. a download or checksum of a large file) and return its output
+// The command is not subject to the command timeout
+func (c *Client) readLongOutput(cmdText string) ([]byte, error) {
+    ctx, cancel := c.commandContext(0)
+    defer cancel()
+    output, err := c.readOutputContext(ctx, cmdText)
+    return output, contextError(ctx, err, 0)
+}
+
+
+// Run a command in a context and return its output
+func (c *Client) readOutputContext(ctx context.Context, cmdText string) ([]byte, error) {
 
     // Initialize command
-    cmd, err := c.newCommand(cmdText)
+    cmd, err := c.newCommand(ctx, cmdText)
     if err != nil {
         return []byte{}, err
     }
@@ -361,3 +818,52 @@ func (c *Client) readOutput(cmdText string) ([]byte, error) {
 
 }
 
+
+// Get a context for an API call or command, which is cancelled after a timeout (if non-zero) and on interrupt if the client is interruptible
+// The returned function releases the context, and must be called once the call or command completes
+func (c *Client) commandContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+
+    // Create context
+    var ctx context.Context
+    var cancel context.CancelFunc
+    if timeout > 0 {
+        ctx, cancel = context.WithTimeout(c.ctx, timeout)
+    } else {
+        ctx, cancel = context.WithCancel(c.ctx)
+    }
+    if !c.interruptible {
+        return ctx, cancel
+    }
+
+    // Cancel on interrupt
+    interrupt := make(chan os.Signal, 1)
+    signal.Notify(interrupt, os.Interrupt)
+    go (func() {
+        select {
+            case <-interrupt:
+                cancel()
+            case <-ctx.Done():
+        }
+    })()
+    return ctx, func() {
+        signal.Stop(interrupt)
+        cancel()
+    }
+
+}
+
+
+// Replace the error of a cancelled call or command with the reason it was cancelled
+func contextError(ctx context.Context, err error, timeout time.Duration) error {
+    if err == nil {
+        return nil
+    }
+    switch ctx.Err() {
+        case context.DeadlineExceeded:
+            return fmt.Errorf("The command timed out after %s; the timeouts can be changed with the --api-timeout and --command-timeout options", timeout)
+        case context.Canceled:
+            return errors.New("The command was interrupted")
+    }
+    return err
+}
+
@@ -1,6 +1,7 @@
 package rocketpool
 
 import (
+    "context"
     "io"
     "os/exec"
 
@@ -13,14 +14,16 @@ type command struct {
     cmd *exec.Cmd
     session *ssh.Session
     cmdText string
+    done chan struct{}
 }
 
 
 // Create a command to be run by the Rocket Pool client
-func (c *Client) newCommand(cmdText string) (*command, error) {
+// The command is killed (or sent SIGINT over SSH) if the context is cancelled before it completes
+func (c *Client) newCommand(ctx context.Context, cmdText string) (*command, error) {
     if c.client == nil {
         return &command{
-            cmd: exec.Command("sh", "-c", cmdText),
+            cmd: exec.CommandContext(ctx, "sh", "-c", cmdText),
             cmdText: cmdText,
         }, nil
     } else {
@@ -28,10 +31,20 @@ func (c *Client) newCommand(cmdText string) (*command, error) {
         if err != nil {
             return nil, err
         }
-        return &command{
+        cmd := &command{
             session: session,
             cmdText: cmdText,
-        }, nil
+            done: make(chan struct{}),
+        }
+        go (func() {
+            select {
+                case <-ctx.Done():
+                    session.Signal(ssh.SIGINT)
+                    session.Close()
+                case <-cmd.done:
+            }
+        })()
+        return cmd, nil
     }
 }
 
@@ -39,6 +52,7 @@ func (c *Client) newCommand(cmdText string) (*command, error) {
 // Close the command session
 func (c *command) Close() error {
     if c.session != nil {
+        close(c.done)
         return c.session.Close()
     }
     return nil
@@ -55,6 +69,26 @@ func (c *command) Run() error {
 }
 
 
+// Start the command without waiting for it to complete
+func (c *command) Start() error {
+    if c.cmd != nil {
+        return c.cmd.Start()
+    } else {
+        return c.session.Start(c.cmdText)
+    }
+}
+
+
+// Wait for a started command to complete
+func (c *command) Wait() error {
+    if c.cmd != nil {
+        return c.cmd.Wait()
+    } else {
+        return c.session.Wait()
+    }
+}
+
+
 // Run the command and return its output
 func (c *command) Output() ([]byte, error) {
     if c.cmd != nil {
@@ -84,3 +118,13 @@ func (c *command) StderrPipe() (io.Reader, error) {
     }
 }
 
+
+
+// Set the command's stdin
+func (c *command) SetStdin(stdin io.Reader) {
+    if c.cmd != nil {
+        c.cmd.Stdin = stdin
+    } else {
+        c.session.Stdin = stdin
+    }
+}
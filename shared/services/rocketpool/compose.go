@@ -0,0 +1,176 @@
+package rocketpool
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+    "sync"
+
+    "golang.org/x/sync/errgroup"
+)
+
+
+// docker-compose (or podman-compose) service backend
+// Each service runs in a container defined in the Rocket Pool compose file
+type composeBackend struct {
+    c *Client
+}
+
+
+// Get the backend name
+func (b *composeBackend) Name() string {
+    return BackendCompose
+}
+
+
+// Start the Rocket Pool service
+// Containers for externally managed clients are omitted and removed if running
+func (b *composeBackend) Start() error {
+
+    // Get managed & external services
+    managedServices, externalServices, err := b.c.getServices()
+    if err != nil { return err }
+
+    // Start all services if none are external
+    if len(externalServices) == 0 {
+        cmd, err := b.c.compose("up -d")
+        if err != nil { return err }
+        return b.c.printOutput(cmd)
+    }
+
+    // Remove external service containers
+    rmCmd, err := b.c.compose(fmt.Sprintf("rm -s -f %s", strings.Join(externalServices, " ")))
+    if err != nil { return err }
+    if err := b.c.printOutput(rmCmd); err != nil { return err }
+
+    // Start managed services
+    upCmd, err := b.c.compose(fmt.Sprintf("up -d --no-deps %s", strings.Join(managedServices, " ")))
+    if err != nil { return err }
+    return b.c.printOutput(upCmd)
+
+}
+
+
+// Pause the Rocket Pool service
+func (b *composeBackend) Pause() error {
+    cmd, err := b.c.compose("stop")
+    if err != nil { return err }
+    return b.c.printOutput(cmd)
+}
+
+
+// Stop the Rocket Pool service
+func (b *composeBackend) Stop() error {
+    cmd, err := b.c.compose("down -v")
+    if err != nil { return err }
+    return b.c.printOutput(cmd)
+}
+
+
+// Print the Rocket Pool service status
+func (b *composeBackend) PrintStatus() error {
+    if b.c.accessible {
+        runtime, err := b.c.GetContainerRuntime()
+        if err != nil { return err }
+        return b.c.printOutput(fmt.Sprintf("%s ps -a --filter label=%s=%s --format \"{{.Names}}: {{.Status}}\"", runtime.Name, runtime.ProjectLabel, ComposeProjectName))
+    }
+    cmd, err := b.c.compose("ps")
+    if err != nil { return err }
+    return b.c.printOutput(cmd)
+}
+
+
+// Print the Rocket Pool service logs
+// Each service's container logs are read separately and merged; lines are sorted by time unless following
+func (b *composeBackend) PrintLogs(options LogOptions, serviceNames ...string) error {
+
+    // Get services
+    if len(serviceNames) == 0 {
+        var err error
+        if serviceNames, err = b.c.getContainerServiceNames(); err != nil { return err }
+        if len(serviceNames) == 0 {
+            return errors.New("No Rocket Pool service containers were found. Please run 'rocketpool service start' and try again.")
+        }
+    }
+
+    // Get log command arguments
+    args := []string{"logs", "--timestamps"}
+    if options.Tail != "" {
+        args = append(args, "--tail", options.Tail)
+    }
+    if !options.Since.IsZero() {
+        args = append(args, "--since", strconv.FormatInt(options.Since.Unix(), 10))
+    }
+    if !options.Until.IsZero() {
+        args = append(args, "--until", strconv.FormatInt(options.Until.Unix(), 10))
+    }
+    if options.Follow {
+        args = append(args, "--follow")
+    }
+
+    // Read service logs
+    printer := newLogPrinter(options, serviceNames, b.c.accessible)
+    var lock sync.Mutex
+    lines := []logLine{}
+    var wg errgroup.Group
+    for _, serviceName := range serviceNames {
+        serviceName := serviceName
+        wg.Go(func() error {
+            cmd, err := b.c.runtimeCommand(fmt.Sprintf("%s %s_%s", strings.Join(args, " "), ComposeProjectName, serviceName))
+            if err != nil { return err }
+            return b.c.scanOutput(cmd, func(text string) {
+                line := parseTimestampedLogLine(serviceName, text)
+                if options.Follow {
+                    printer.print(line)
+                    return
+                }
+                lock.Lock()
+                lines = append(lines, line)
+                lock.Unlock()
+            })
+        })
+    }
+    err := wg.Wait()
+
+    // Print logs
+    printer.printSorted(lines)
+    return err
+
+}
+
+
+// Print the Rocket Pool service stats
+func (b *composeBackend) PrintStats() error {
+
+    // Get service container IDs
+    containerIds, err := b.c.getContainerIDs()
+    if err != nil { return err }
+    if len(containerIds) == 0 {
+        return errors.New("No Rocket Pool service containers are running. Please run 'rocketpool service start' and try again.")
+    }
+
+    // Print stats; print a single snapshot in accessible mode rather than refreshing
+    statsArgs := "stats"
+    if b.c.accessible {
+        statsArgs = "stats --no-stream"
+    }
+    statsCmd, err := b.c.runtimeCommand(fmt.Sprintf("%s %s", statsArgs, strings.Join(containerIds, " ")))
+    if err != nil { return err }
+    return b.c.printOutput(statsCmd)
+
+}
+
+
+// Apply a service action requested by the node daemon (stop or restart) to a service's container
+func (b *composeBackend) ApplyAction(action, serviceName string) error {
+    cmd, err := b.c.compose(fmt.Sprintf("%s %s", action, serviceName))
+    if err != nil { return err }
+    return b.c.printOutput(cmd)
+}
+
+
+// Get the command to run an API command in the API container
+func (b *composeBackend) APICommand(globalArgs, args string) (string, error) {
+    return b.c.runtimeCommand(fmt.Sprintf("exec %s %s %s api %s", APIContainerName, APIBinPath, globalArgs, args))
+}
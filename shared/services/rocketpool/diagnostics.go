@@ -0,0 +1,179 @@
+package rocketpool
+
+import (
+    "errors"
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+)
+
+
+// Config
+const (
+    ClockReferenceURL = "https://www.cloudflare.com"
+)
+
+
+// Host disk space
+type DiskSpace struct {
+    Path string
+    Total uint64
+    Available uint64
+}
+
+
+// Get the container runtime version; fails if the Docker daemon or podman is not running or accessible
+func (c *Client) GetDockerVersion() (string, error) {
+    runtime, err := c.GetContainerRuntime()
+    if err != nil {
+        return "", err
+    }
+    output, err := c.readOutput(fmt.Sprintf("%s version --format '%s' 2>&1", runtime.Name, runtime.VersionFormat))
+    if err != nil {
+        return "", fmt.Errorf("Could not connect to %s: %s", runtime.Name, strings.TrimSpace(string(output)))
+    }
+    return strings.TrimSpace(string(output)), nil
+}
+
+
+// Get the states of the Rocket Pool service containers by name
+func (c *Client) GetContainerStates() (map[string]string, error) {
+    runtime, err := c.GetContainerRuntime()
+    if err != nil {
+        return nil, err
+    }
+    output, err := c.readOutput(fmt.Sprintf("%s ps -a --filter label=%s=%s --format '{{.Names}} {{.State}}'", runtime.Name, runtime.ProjectLabel, ComposeProjectName))
+    if err != nil {
+        return nil, fmt.Errorf("Could not get service container states: %w", err)
+    }
+    states := make(map[string]string)
+    for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+        fields := strings.Fields(line)
+        if len(fields) == 2 {
+            states[fields[0]] = fields[1]
+        }
+    }
+    return states, nil
+}
+
+
+// Get the disk space available to the Rocket Pool data folder and Docker volumes
+func (c *Client) GetDiskSpace() ([]DiskSpace, error) {
+
+    // Get paths; container volumes are only used by the compose service backend
+    paths := []string{RocketPoolPath}
+    backend, err := c.GetServiceBackend()
+    if err != nil {
+        return nil, err
+    }
+    if backend.Name() == BackendCompose {
+        runtime, err := c.GetContainerRuntime()
+        if err != nil {
+            return nil, err
+        }
+        if dockerRoot, err := c.readOutput(fmt.Sprintf("%s info --format '%s'", runtime.Name, runtime.RootDirFormat)); err == nil && strings.TrimSpace(string(dockerRoot)) != "" {
+            paths = append(paths, strings.TrimSpace(string(dockerRoot)))
+        }
+    }
+
+    // Get disk space
+    spaces := []DiskSpace{}
+    for _, path := range paths {
+        output, err := c.readOutput(fmt.Sprintf("df -Pk %s | tail -n 1", path))
+        if err != nil {
+            return nil, fmt.Errorf("Could not get disk space for %s: %w", path, err)
+        }
+        fields := strings.Fields(string(output))
+        if len(fields) < 4 {
+            return nil, fmt.Errorf("Could not parse disk space for %s", path)
+        }
+        total, err := strconv.ParseUint(fields[1], 10, 64)
+        if err != nil {
+            return nil, fmt.Errorf("Could not parse disk space for %s: %w", path, err)
+        }
+        available, err := strconv.ParseUint(fields[3], 10, 64)
+        if err != nil {
+            return nil, fmt.Errorf("Could not parse disk space for %s: %w", path, err)
+        }
+        spaces = append(spaces, DiskSpace{
+            Path: path,
+            Total: total * 1024,
+            Available: available * 1024,
+        })
+    }
+
+    // Return
+    return spaces, nil
+
+}
+
+
+// Get the TCP & UDP ports which are being listened on by the host
+func (c *Client) GetListeningPorts() (map[uint64]bool, error) {
+    output, err := c.readOutput("ss -Htuln 2>/dev/null || netstat -tuln 2>/dev/null")
+    if err != nil || len(output) == 0 {
+        return nil, errors.New("Could not list listening ports; neither ss nor netstat is available.")
+    }
+    addressRegex := regexp.MustCompile(`[:.](\d+)$`)
+    ports := make(map[uint64]bool)
+    for _, line := range strings.Split(string(output), "\n") {
+        for _, field := range strings.Fields(line) {
+            match := addressRegex.FindStringSubmatch(field)
+            if match == nil {
+                continue
+            }
+            if port, err := strconv.ParseUint(match[1], 10, 16); err == nil {
+                ports[port] = true
+            }
+            break
+        }
+    }
+    return ports, nil
+}
+
+
+// Get the offset of the host clock from a reference server's clock, with a precision of 1 second
+func (c *Client) GetClockOffset() (time.Duration, error) {
+
+    // Get host time & reference server date header
+    output, err := c.readOutput(fmt.Sprintf("date -u +%%s && curl -sI %s | grep -i '^date:'", ClockReferenceURL))
+    if err != nil {
+        return 0, fmt.Errorf("Could not get reference time from %s; cURL is required.", ClockReferenceURL)
+    }
+    lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+    if len(lines) < 2 {
+        return 0, errors.New("Could not get host and reference times.")
+    }
+
+    // Parse times
+    hostUnix, err := strconv.ParseInt(strings.TrimSpace(lines[0]), 10, 64)
+    if err != nil {
+        return 0, fmt.Errorf("Could not parse host time: %w", err)
+    }
+    referenceTime, err := time.Parse(time.RFC1123, strings.TrimSpace(strings.SplitN(lines[1], ":", 2)[1]))
+    if err != nil {
+        return 0, fmt.Errorf("Could not parse reference time: %w", err)
+    }
+
+    // Return
+    return time.Unix(hostUnix, 0).Sub(referenceTime), nil
+
+}
+
+
+// Restart a Rocket Pool service container (e.g. eth1)
+func (c *Client) RestartServiceContainer(service string) error {
+    cmd, err := c.runtimeCommand(fmt.Sprintf("restart %s_%s", ComposeProjectName, service))
+    if err != nil { return err }
+    return c.printOutput(cmd)
+}
+
+
+// Remove Docker images which are not used by any container
+func (c *Client) PruneDockerImages() error {
+    cmd, err := c.runtimeCommand("image prune -a -f")
+    if err != nil { return err }
+    return c.printOutput(cmd)
+}
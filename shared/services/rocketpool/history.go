@@ -0,0 +1,129 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Config
+const ConfigHistoryFile = "settings-history.json"
+
+
+// Load the user config history, oldest revision first
+func (c *Client) LoadConfigHistory() ([]config.Revision, error) {
+    historyBytes, err := c.readOutput(fmt.Sprintf("cat %s/%s 2>/dev/null || true", RocketPoolPath, ConfigHistoryFile))
+    if err != nil {
+        return []config.Revision{}, fmt.Errorf("Could not read config history: %w", err)
+    }
+    if len(strings.TrimSpace(string(historyBytes))) == 0 {
+        return []config.Revision{}, nil
+    }
+    var history []config.Revision
+    if err := json.Unmarshal(historyBytes, &history); err != nil {
+        return []config.Revision{}, fmt.Errorf("Could not decode config history: %w", err)
+    }
+    return history, nil
+}
+
+
+// Revert the user config to a previous revision, recording the revert as a new revision
+func (c *Client) RevertUserConfig(number int) error {
+
+    // Get revision
+    history, err := c.LoadConfigHistory()
+    if err != nil {
+        return err
+    }
+    var revision *config.Revision
+    for ri := range history {
+        if history[ri].Number == number {
+            revision = &history[ri]
+            break
+        }
+    }
+    if revision == nil {
+        return fmt.Errorf("Config revision %d was not found", number)
+    }
+
+    // Parse & save revision settings
+    cfg, err := config.Parse([]byte(revision.Settings))
+    if err != nil {
+        return err
+    }
+    return c.SaveUserConfig(cfg, fmt.Sprintf("Reverted to revision %d", number))
+
+}
+
+
+// Record a change to the user config in the config history
+func (c *Client) recordConfigRevision(previousConfig, cfg config.RocketPoolConfig, description string) error {
+
+    // Load history
+    history, err := c.LoadConfigHistory()
+    if err != nil {
+        return err
+    }
+
+    // Get user
+    user, err := c.readOutput("whoami")
+    if err != nil {
+        return fmt.Errorf("Could not get user: %w", err)
+    }
+
+    // Record the settings in place before history was kept, so that they can be reverted to
+    previousSettings, err := previousConfig.Serialize()
+    if err != nil {
+        return err
+    }
+    if len(history) == 0 && strings.TrimSpace(string(previousSettings)) != "{}" {
+        history = append(history, config.Revision{
+            Number: 1,
+            Time: time.Now(),
+            User: strings.TrimSpace(string(user)),
+            Description: "Settings before the config history was recorded",
+            Settings: string(previousSettings),
+            Changes: []config.SettingChange{},
+        })
+    }
+
+    // Add revision
+    settings, err := cfg.Serialize()
+    if err != nil {
+        return err
+    }
+    changes, err := config.Diff(&previousConfig, &cfg)
+    if err != nil {
+        return err
+    }
+    number := 1
+    if len(history) > 0 {
+        number = history[len(history) - 1].Number + 1
+    }
+    history = append(history, config.Revision{
+        Number: number,
+        Time: time.Now(),
+        User: strings.TrimSpace(string(user)),
+        Description: description,
+        Settings: string(settings),
+        Changes: changes,
+    })
+    if len(history) > config.MaxConfigRevisions {
+        history = history[len(history) - config.MaxConfigRevisions:]
+    }
+
+    // Save history
+    historyBytes, err := json.Marshal(history)
+    if err != nil {
+        return fmt.Errorf("Could not encode config history: %w", err)
+    }
+    if _, err := c.readOutput(fmt.Sprintf("cat > %s/%s <<'EOF'\n%s\nEOF", RocketPoolPath, ConfigHistoryFile, string(historyBytes))); err != nil {
+        return fmt.Errorf("Could not write config history: %w", err)
+    }
+    return nil
+
+}
@@ -0,0 +1,154 @@
+package rocketpool
+
+import (
+    "fmt"
+    "path"
+    "regexp"
+    "strings"
+)
+
+
+// Config
+const (
+    InstallReleasesURL = "https://github.com/rocket-pool/smartnode-install/releases"
+    InstallManifestFile = "rp-smartnode-install-%s.sha256"
+    InstallPackageFile = "rp-smartnode-install-%s.tar.xz"
+)
+var sha256Pattern = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+
+// Installed file statuses
+const (
+    InstallFileOK = "ok"
+    InstallFileModified = "modified"
+    InstallFileMissing = "missing"
+)
+
+
+// An installed file checked against the release manifest
+type InstallFile struct {
+    Path string
+    ExpectedChecksum string
+    Checksum string
+    Status string
+}
+
+
+// Compare the files installed under the Rocket Pool path against the checksums published with a smartnode-install release
+// The release manifest lists the installed files (compose files, chain configs & scripts) in sha256sum format, relative to
+// the Rocket Pool path; user settings are not included
+func (c *Client) VerifyInstall(network, version string) ([]InstallFile, error) {
+
+    // Get downloader
+    downloader, err := c.getDownloader()
+    if err != nil { return []InstallFile{}, err }
+
+    // Download release manifest
+    manifestURL := getInstallReleaseURL(version, fmt.Sprintf(InstallManifestFile, network))
+    manifestOutput, err := c.readOutput(fmt.Sprintf("%s '%s'", downloader, manifestURL))
+    if err != nil {
+        return []InstallFile{}, fmt.Errorf("Could not download install manifest: %w", err)
+    }
+    files := []InstallFile{}
+    for _, line := range strings.Split(string(manifestOutput), "\n") {
+        fields := strings.Fields(line)
+        if len(fields) != 2 || !sha256Pattern.MatchString(fields[0]) {
+            continue
+        }
+        filePath := path.Clean(strings.TrimPrefix(fields[1], "*"))
+        if path.IsAbs(filePath) || strings.HasPrefix(filePath, "..") {
+            return []InstallFile{}, fmt.Errorf("The install manifest contains an invalid path '%s'.", fields[1])
+        }
+        files = append(files, InstallFile{
+            Path: filePath,
+            ExpectedChecksum: strings.ToLower(fields[0]),
+        })
+    }
+    if len(files) == 0 {
+        return []InstallFile{}, fmt.Errorf("No install manifest was found for version %s on the %s network at %s.", version, network, manifestURL)
+    }
+
+    // Get installed file checksums
+    paths := make([]string, len(files))
+    for fi, file := range files {
+        paths[fi] = fmt.Sprintf("'%s'", file.Path)
+    }
+    checksumOutput, err := c.readOutput(fmt.Sprintf("cd %s && sha256sum %s 2>/dev/null || true", RocketPoolPath, strings.Join(paths, " ")))
+    if err != nil {
+        return []InstallFile{}, fmt.Errorf("Could not get installed file checksums: %w", err)
+    }
+    checksums := make(map[string]string)
+    for _, line := range strings.Split(string(checksumOutput), "\n") {
+        if fields := strings.Fields(line); len(fields) == 2 {
+            checksums[path.Clean(fields[1])] = strings.ToLower(fields[0])
+        }
+    }
+
+    // Compare checksums
+    for fi := range files {
+        file := &files[fi]
+        checksum, ok := checksums[file.Path]
+        switch {
+            case !ok:
+                file.Status = InstallFileMissing
+            case checksum != file.ExpectedChecksum:
+                file.Checksum = checksum
+                file.Status = InstallFileModified
+            default:
+                file.Checksum = checksum
+                file.Status = InstallFileOK
+        }
+    }
+
+    // Return
+    return files, nil
+
+}
+
+
+// Restore installed files from a smartnode-install release package
+// Modified files are kept with a .bak suffix before being replaced
+func (c *Client) RestoreInstallFiles(network, version string, files []InstallFile) error {
+
+    // Get downloader
+    downloader, err := c.getDownloader()
+    if err != nil { return err }
+
+    // Download release package
+    packagePath := fmt.Sprintf("%s/%s", RocketPoolPath, fmt.Sprintf(InstallPackageFile, network))
+    defer c.readOutput(fmt.Sprintf("rm -f %s", packagePath))
+    fmt.Printf("Downloading the %s install package...\n", version)
+    if _, err := c.readLongOutput(fmt.Sprintf("%s '%s' > %s", downloader, getInstallReleaseURL(version, fmt.Sprintf(InstallPackageFile, network)), packagePath)); err != nil {
+        return fmt.Errorf("Could not download install package: %w", err)
+    }
+
+    // Back up modified files
+    paths := []string{}
+    for _, file := range files {
+        if file.Status == InstallFileModified {
+            if _, err := c.readOutput(fmt.Sprintf("cp -p %s/'%s' %s/'%s.bak'", RocketPoolPath, file.Path, RocketPoolPath, file.Path)); err != nil {
+                return fmt.Errorf("Could not back up %s: %w", file.Path, err)
+            }
+        }
+        paths = append(paths, fmt.Sprintf("'%s'", file.Path))
+    }
+
+    // Extract files
+    if _, err := c.readOutput(fmt.Sprintf("tar -xJf %s -C %s %s", packagePath, RocketPoolPath, strings.Join(paths, " "))); err != nil {
+        return fmt.Errorf("Could not extract files from the install package: %w", err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get the download URL for a smartnode-install release file
+func getInstallReleaseURL(version, file string) string {
+    if version == "latest" {
+        return fmt.Sprintf("%s/latest/download/%s", InstallReleasesURL, file)
+    }
+    return fmt.Sprintf("%s/download/%s/%s", InstallReleasesURL, version, file)
+}
+
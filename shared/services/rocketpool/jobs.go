@@ -0,0 +1,66 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get the node's scheduled jobs
+func (c *Client) Jobs() (api.JobsResponse, error) {
+    responseBytes, err := c.callAPI("jobs list")
+    if err != nil {
+        return api.JobsResponse{}, fmt.Errorf("Could not get jobs: %w", err)
+    }
+    var response api.JobsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.JobsResponse{}, fmt.Errorf("Could not decode jobs response: %w", err)
+    }
+    if response.Error != "" {
+        return api.JobsResponse{}, fmt.Errorf("Could not get jobs: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Schedule a minipool action; a zero max gas price or not before time means the condition does not apply
+func (c *Client) AddJob(jobType string, minipoolAddress common.Address, maxGasPrice float64, notBefore time.Time) (api.AddJobResponse, error) {
+    var notBeforeUnix int64
+    if !notBefore.IsZero() {
+        notBeforeUnix = notBefore.Unix()
+    }
+    responseBytes, err := c.callAPI(fmt.Sprintf("jobs add %s %s %f %d", jobType, minipoolAddress.Hex(), maxGasPrice, notBeforeUnix))
+    if err != nil {
+        return api.AddJobResponse{}, fmt.Errorf("Could not add job: %w", err)
+    }
+    var response api.AddJobResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.AddJobResponse{}, fmt.Errorf("Could not decode add job response: %w", err)
+    }
+    if response.Error != "" {
+        return api.AddJobResponse{}, fmt.Errorf("Could not add job: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Cancel a pending job
+func (c *Client) CancelJob(id uint64) (api.CancelJobResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("jobs cancel %d", id))
+    if err != nil {
+        return api.CancelJobResponse{}, fmt.Errorf("Could not cancel job: %w", err)
+    }
+    var response api.CancelJobResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.CancelJobResponse{}, fmt.Errorf("Could not decode cancel job response: %w", err)
+    }
+    if response.Error != "" {
+        return api.CancelJobResponse{}, fmt.Errorf("Could not cancel job: %s", response.Error)
+    }
+    return response, nil
+}
@@ -0,0 +1,184 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "regexp"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/fatih/color"
+)
+
+
+// Config
+const (
+    MaxLogLineSize = 1024 * 1024
+    LogTimeFormat = "2006-01-02 15:04:05"
+)
+var logServiceColors = []color.Attribute{color.FgCyan, color.FgYellow, color.FgGreen, color.FgMagenta, color.FgBlue, color.FgRed, color.FgHiCyan, color.FgHiYellow, color.FgHiGreen, color.FgHiMagenta}
+
+
+// JSON log entry fields recognized by client log formats
+var (
+    logLevelFields = []string{"level", "lvl", "severity"}
+    logMessageFields = []string{"msg", "message"}
+    logTimeFields = []string{"time", "t", "ts", "timestamp"}
+)
+
+
+// Service log options
+// Logs are followed unless Follow is false; zero times are not applied, and a nil pattern matches all lines
+type LogOptions struct {
+    Tail string
+    Since time.Time
+    Until time.Time
+    Grep *regexp.Regexp
+    Follow bool
+}
+
+
+// A service log line
+type logLine struct {
+    Service string
+    Time time.Time
+    Message string
+}
+
+
+// Service log printer
+// Prints lines from multiple services with a colored service prefix, formatting JSON log entries and filtering by the grep pattern
+type logPrinter struct {
+    options LogOptions
+    colors map[string]*color.Color
+    width int
+    lock sync.Mutex
+}
+
+
+// Create a log printer for a set of services
+func newLogPrinter(options LogOptions, serviceNames []string, noColor bool) *logPrinter {
+    p := &logPrinter{
+        options: options,
+        colors: make(map[string]*color.Color),
+    }
+    sorted := append([]string{}, serviceNames...)
+    sort.Strings(sorted)
+    for si, serviceName := range sorted {
+        c := color.New(logServiceColors[si % len(logServiceColors)])
+        if noColor {
+            c.DisableColor()
+        }
+        p.colors[serviceName] = c
+        if len(serviceName) > p.width {
+            p.width = len(serviceName)
+        }
+    }
+    return p
+}
+
+
+// Print a log line, if it matches the grep pattern
+func (p *logPrinter) print(line logLine) {
+    message := formatLogMessage(line.Message)
+    if p.options.Grep != nil && !p.options.Grep.MatchString(message) {
+        return
+    }
+    c, ok := p.colors[line.Service]
+    if !ok {
+        c = color.New(color.Reset)
+    }
+    timestamp := ""
+    if !line.Time.IsZero() {
+        timestamp = line.Time.Local().Format(LogTimeFormat) + " "
+    }
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    c.Printf("%-*s |", p.width, line.Service)
+    fmt.Printf(" %s%s\n", timestamp, message)
+}
+
+
+// Print log lines in time order
+func (p *logPrinter) printSorted(lines []logLine) {
+    sort.SliceStable(lines, func(i, j int) bool { return lines[i].Time.Before(lines[j].Time) })
+    for _, line := range lines {
+        p.print(line)
+    }
+}
+
+
+// Format a JSON log entry as its level, message and remaining fields; other messages are returned unchanged
+// The entry's own timestamp is dropped, as the line's timestamp is printed
+func formatLogMessage(message string) string {
+
+    // Decode entry
+    trimmed := strings.TrimSpace(message)
+    if !strings.HasPrefix(trimmed, "{") {
+        return message
+    }
+    var fields map[string]interface{}
+    if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+        return message
+    }
+    level := takeLogField(fields, logLevelFields)
+    text := takeLogField(fields, logMessageFields)
+    if level == "" && text == "" {
+        return message
+    }
+    takeLogField(fields, logTimeFields)
+
+    // Format entry
+    parts := []string{}
+    if level != "" {
+        parts = append(parts, fmt.Sprintf("[%s]", strings.ToUpper(level)))
+    }
+    if text != "" {
+        parts = append(parts, text)
+    }
+    keys := make([]string, 0, len(fields))
+    for key := range fields {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    for _, key := range keys {
+        value := fields[key]
+        switch value.(type) {
+            case map[string]interface{}, []interface{}:
+                valueBytes, _ := json.Marshal(value)
+                parts = append(parts, fmt.Sprintf("%s=%s", key, string(valueBytes)))
+            default:
+                parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+        }
+    }
+    return strings.Join(parts, " ")
+
+}
+
+
+// Remove the first present field of a set from a JSON log entry and return it as a string
+func takeLogField(fields map[string]interface{}, names []string) string {
+    for _, name := range names {
+        if value, ok := fields[name]; ok {
+            delete(fields, name)
+            return fmt.Sprintf("%v", value)
+        }
+    }
+    return ""
+}
+
+
+// Parse a log line prefixed with an RFC 3339 timestamp, as output by the container runtime
+func parseTimestampedLogLine(serviceName, text string) logLine {
+    line := logLine{Service: serviceName, Message: text}
+    if parts := strings.SplitN(text, " ", 2); len(parts) == 2 {
+        if t, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+            line.Time = t
+            line.Message = parts[1]
+        }
+    }
+    return line
+}
+
@@ -27,6 +27,23 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 }
 
 
+// Get the recorded performance of the node's minipool validators
+func (c *Client) MinipoolPerformance() (api.MinipoolPerformanceResponse, error) {
+    responseBytes, err := c.callAPI("minipool performance")
+    if err != nil {
+        return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %w", err)
+    }
+    var response api.MinipoolPerformanceResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not decode minipool performance response: %w", err)
+    }
+    if response.Error != "" {
+        return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %s", response.Error)
+    }
+    return response, nil
+}
+
+
 // Check whether a minipool is eligible for a refund
 func (c *Client) CanRefundMinipool(address common.Address) (api.CanRefundMinipoolResponse, error) {
     responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-refund %s", address.Hex()))
@@ -180,6 +197,23 @@ func (c *Client) CanCloseMinipool(address common.Address) (api.CanCloseMinipoolR
 }
 
 
+// Simulate closing a minipool
+func (c *Client) SimulateCloseMinipool(address common.Address) (api.SimulateCloseMinipoolResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("minipool simulate-close %s", address.Hex()))
+    if err != nil {
+        return api.SimulateCloseMinipoolResponse{}, fmt.Errorf("Could not simulate closing minipool: %w", err)
+    }
+    var response api.SimulateCloseMinipoolResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.SimulateCloseMinipoolResponse{}, fmt.Errorf("Could not decode simulate close minipool response: %w", err)
+    }
+    if response.Error != "" {
+        return api.SimulateCloseMinipoolResponse{}, fmt.Errorf("Could not simulate closing minipool: %s", response.Error)
+    }
+    return response, nil
+}
+
+
 // Close a minipool
 func (c *Client) CloseMinipool(address common.Address) (api.CloseMinipoolResponse, error) {
     responseBytes, err := c.callAPI(fmt.Sprintf("minipool close %s", address.Hex()))
@@ -196,3 +230,37 @@ func (c *Client) CloseMinipool(address common.Address) (api.CloseMinipoolRespons
     return response, nil
 }
 
+
+
+// Verify the withdrawal credentials of the node's minipool validators
+func (c *Client) VerifyMinipoolCredentials() (api.VerifyMinipoolCredentialsResponse, error) {
+    responseBytes, err := c.callAPI("minipool verify-credentials")
+    if err != nil {
+        return api.VerifyMinipoolCredentialsResponse{}, fmt.Errorf("Could not verify minipool withdrawal credentials: %w", err)
+    }
+    var response api.VerifyMinipoolCredentialsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.VerifyMinipoolCredentialsResponse{}, fmt.Errorf("Could not decode verify minipool credentials response: %w", err)
+    }
+    if response.Error != "" {
+        return api.VerifyMinipoolCredentialsResponse{}, fmt.Errorf("Could not verify minipool withdrawal credentials: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Project the node's returns for each deposit type at a validator APR
+func (c *Client) MinipoolCalculator(apr float64, years uint64) (api.MinipoolCalculatorResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("minipool calculator %f %d", apr, years))
+    if err != nil {
+        return api.MinipoolCalculatorResponse{}, fmt.Errorf("Could not get minipool calculator projections: %w", err)
+    }
+    var response api.MinipoolCalculatorResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.MinipoolCalculatorResponse{}, fmt.Errorf("Could not decode minipool calculator response: %w", err)
+    }
+    if response.Error != "" {
+        return api.MinipoolCalculatorResponse{}, fmt.Errorf("Could not get minipool calculator projections: %s", response.Error)
+    }
+    return response, nil
+}
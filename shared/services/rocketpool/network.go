@@ -3,6 +3,9 @@ package rocketpool
 import (
     "encoding/json"
     "fmt"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
 
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -24,3 +27,135 @@ func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
     return response, nil
 }
 
+
+// Get network-wide statistics
+func (c *Client) NetworkStats() (api.NetworkStatsResponse, error) {
+    responseBytes, err := c.callAPI("network stats")
+    if err != nil {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats: %w", err)
+    }
+    var response api.NetworkStatsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not decode network stats response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get network-wide statistics at a past block; requires an archive node
+func (c *Client) NetworkStatsAt(blockNumber uint64) (api.NetworkStatsResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("network stats %d", blockNumber))
+    if err != nil {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats at block %d: %w", blockNumber, err)
+    }
+    var response api.NetworkStatsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not decode network stats response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats at block %d: %s", blockNumber, response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the network's on-chain protocol settings
+func (c *Client) NetworkSettings() (api.NetworkSettingsResponse, error) {
+    responseBytes, err := c.callAPI("network settings")
+    if err != nil {
+        return api.NetworkSettingsResponse{}, fmt.Errorf("Could not get network settings: %w", err)
+    }
+    var response api.NetworkSettingsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkSettingsResponse{}, fmt.Errorf("Could not decode network settings response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkSettingsResponse{}, fmt.Errorf("Could not get network settings: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the latest block mined at or before a time
+func (c *Client) GetBlockAtTime(t time.Time) (api.NetworkBlockAtTimeResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("network block-at-time %d", t.Unix()))
+    if err != nil {
+        return api.NetworkBlockAtTimeResponse{}, fmt.Errorf("Could not get block at time: %w", err)
+    }
+    var response api.NetworkBlockAtTimeResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkBlockAtTimeResponse{}, fmt.Errorf("Could not decode block at time response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkBlockAtTimeResponse{}, fmt.Errorf("Could not get block at time: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Resolve an ENS name to an address
+func (c *Client) ResolveName(name string) (api.NetworkResolveNameResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("network resolve-name %s", name))
+    if err != nil {
+        return api.NetworkResolveNameResponse{}, fmt.Errorf("Could not resolve ENS name: %w", err)
+    }
+    var response api.NetworkResolveNameResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkResolveNameResponse{}, fmt.Errorf("Could not decode resolve ENS name response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkResolveNameResponse{}, fmt.Errorf("Could not resolve ENS name: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Look up the primary ENS name of an address; the name is empty if the address has none
+func (c *Client) LookupAddress(address common.Address) (api.NetworkLookupAddressResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("network lookup-address %s", address.Hex()))
+    if err != nil {
+        return api.NetworkLookupAddressResponse{}, fmt.Errorf("Could not look up ENS name: %w", err)
+    }
+    var response api.NetworkLookupAddressResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkLookupAddressResponse{}, fmt.Errorf("Could not decode look up ENS name response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkLookupAddressResponse{}, fmt.Errorf("Could not look up ENS name: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the address for an address or ENS name argument, and its description for display
+func (c *Client) ResolveAddress(value string) (common.Address, string, error) {
+    if common.IsHexAddress(value) {
+        address := common.HexToAddress(value)
+        return address, address.Hex(), nil
+    }
+    response, err := c.ResolveName(value)
+    if err != nil {
+        return common.Address{}, "", err
+    }
+    return response.Address, fmt.Sprintf("%s (%s)", response.Name, response.Address.Hex()), nil
+}
+
+
+// Get the deposit pool and minipool queue status
+func (c *Client) NetworkQueue() (api.NetworkQueueResponse, error) {
+    responseBytes, err := c.callAPI("network queue")
+    if err != nil {
+        return api.NetworkQueueResponse{}, fmt.Errorf("Could not get network queue status: %w", err)
+    }
+    var response api.NetworkQueueResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkQueueResponse{}, fmt.Errorf("Could not decode network queue status response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkQueueResponse{}, fmt.Errorf("Could not get network queue status: %s", response.Error)
+    }
+    return response, nil
+}
@@ -28,6 +28,23 @@ func (c *Client) NodeStatus() (api.NodeStatusResponse, error) {
 }
 
 
+// Get node status at a past block; requires an archive node
+func (c *Client) NodeStatusAt(blockNumber uint64) (api.NodeStatusResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node status %d", blockNumber))
+    if err != nil {
+        return api.NodeStatusResponse{}, fmt.Errorf("Could not get node status at block %d: %w", blockNumber, err)
+    }
+    var response api.NodeStatusResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeStatusResponse{}, fmt.Errorf("Could not decode node status response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeStatusResponse{}, fmt.Errorf("Could not get node status at block %d: %s", blockNumber, response.Error)
+    }
+    return response, nil
+}
+
+
 // Check whether the node can be registered
 func (c *Client) CanRegisterNode() (api.CanRegisterNodeResponse, error) {
     responseBytes, err := c.callAPI("node can-register")
@@ -114,8 +131,8 @@ func (c *Client) NodeDeposit(amountWei *big.Int, minFee float64) (api.NodeDeposi
 
 
 // Check whether the node can send tokens
-func (c *Client) CanNodeSend(amountWei *big.Int, token string) (api.CanNodeSendResponse, error) {
-    responseBytes, err := c.callAPI(fmt.Sprintf("node can-send %s %s", amountWei.String(), token))
+func (c *Client) CanNodeSend(amountWei *big.Int, token string, toAddress common.Address) (api.CanNodeSendResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node can-send %s %s %s", amountWei.String(), token, toAddress.Hex()))
     if err != nil {
         return api.CanNodeSendResponse{}, fmt.Errorf("Could not get can node send status: %w", err)
     }
@@ -180,3 +197,88 @@ func (c *Client) NodeBurn(amountWei *big.Int, token string) (api.NodeBurnRespons
     return response, nil
 }
 
+
+
+// Get node rewards claim history
+func (c *Client) NodeRewards() (api.NodeRewardsResponse, error) {
+    responseBytes, err := c.callAPI("node rewards")
+    if err != nil {
+        return api.NodeRewardsResponse{}, fmt.Errorf("Could not get node rewards: %w", err)
+    }
+    var response api.NodeRewardsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeRewardsResponse{}, fmt.Errorf("Could not decode node rewards response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeRewardsResponse{}, fmt.Errorf("Could not get node rewards: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the node's pending transactions
+func (c *Client) NodeTxQueue() (api.NodeTxQueueResponse, error) {
+    responseBytes, err := c.callAPI("node tx-queue")
+    if err != nil {
+        return api.NodeTxQueueResponse{}, fmt.Errorf("Could not get node transaction queue: %w", err)
+    }
+    var response api.NodeTxQueueResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeTxQueueResponse{}, fmt.Errorf("Could not decode node transaction queue response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeTxQueueResponse{}, fmt.Errorf("Could not get node transaction queue: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Cancel a pending node transaction
+func (c *Client) CancelNodeTransaction(nonce uint64) (api.CancelNodeTransactionResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-tx %d", nonce))
+    if err != nil {
+        return api.CancelNodeTransactionResponse{}, fmt.Errorf("Could not cancel node transaction: %w", err)
+    }
+    var response api.CancelNodeTransactionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.CancelNodeTransactionResponse{}, fmt.Errorf("Could not decode cancel node transaction response: %w", err)
+    }
+    if response.Error != "" {
+        return api.CancelNodeTransactionResponse{}, fmt.Errorf("Could not cancel node transaction: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Sign an attestation that the node controls its account, for a challenge
+func (c *Client) ProveNodeOwnership(challenge string) (api.ProveNodeOwnershipResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node prove-ownership \"%s\"", challenge))
+    if err != nil {
+        return api.ProveNodeOwnershipResponse{}, fmt.Errorf("Could not prove node ownership: %w", err)
+    }
+    var response api.ProveNodeOwnershipResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ProveNodeOwnershipResponse{}, fmt.Errorf("Could not decode prove node ownership response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ProveNodeOwnershipResponse{}, fmt.Errorf("Could not prove node ownership: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the changes to the node's state since a recorded snapshot
+func (c *Client) NodeDiff(since string) (api.NodeDiffResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node diff %s", since))
+    if err != nil {
+        return api.NodeDiffResponse{}, fmt.Errorf("Could not get node diff: %w", err)
+    }
+    var response api.NodeDiffResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeDiffResponse{}, fmt.Errorf("Could not decode node diff response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeDiffResponse{}, fmt.Errorf("Could not get node diff: %s", response.Error)
+    }
+    return response, nil
+}
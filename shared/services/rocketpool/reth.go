@@ -0,0 +1,78 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "math/big"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Check whether the node can deposit ETH for rETH
+func (c *Client) CanRETHDeposit(amountWei *big.Int) (api.CanRETHDepositResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("reth can-deposit %s", amountWei.String()))
+    if err != nil {
+        return api.CanRETHDepositResponse{}, fmt.Errorf("Could not get can deposit rETH status: %w", err)
+    }
+    var response api.CanRETHDepositResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.CanRETHDepositResponse{}, fmt.Errorf("Could not decode can deposit rETH response: %w", err)
+    }
+    if response.Error != "" {
+        return api.CanRETHDepositResponse{}, fmt.Errorf("Could not get can deposit rETH status: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Deposit ETH into the deposit pool for rETH
+func (c *Client) RETHDeposit(amountWei *big.Int) (api.RETHDepositResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("reth deposit %s", amountWei.String()))
+    if err != nil {
+        return api.RETHDepositResponse{}, fmt.Errorf("Could not deposit ETH for rETH: %w", err)
+    }
+    var response api.RETHDepositResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.RETHDepositResponse{}, fmt.Errorf("Could not decode deposit rETH response: %w", err)
+    }
+    if response.Error != "" {
+        return api.RETHDepositResponse{}, fmt.Errorf("Could not deposit ETH for rETH: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Check whether the node can burn rETH for ETH
+func (c *Client) CanRETHBurn(amountWei *big.Int) (api.CanRETHBurnResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("reth can-burn %s", amountWei.String()))
+    if err != nil {
+        return api.CanRETHBurnResponse{}, fmt.Errorf("Could not get can burn rETH status: %w", err)
+    }
+    var response api.CanRETHBurnResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.CanRETHBurnResponse{}, fmt.Errorf("Could not decode can burn rETH response: %w", err)
+    }
+    if response.Error != "" {
+        return api.CanRETHBurnResponse{}, fmt.Errorf("Could not get can burn rETH status: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Burn rETH for ETH
+func (c *Client) RETHBurn(amountWei *big.Int) (api.RETHBurnResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("reth burn %s", amountWei.String()))
+    if err != nil {
+        return api.RETHBurnResponse{}, fmt.Errorf("Could not burn rETH: %w", err)
+    }
+    var response api.RETHBurnResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.RETHBurnResponse{}, fmt.Errorf("Could not decode burn rETH response: %w", err)
+    }
+    if response.Error != "" {
+        return api.RETHBurnResponse{}, fmt.Errorf("Could not burn rETH: %s", response.Error)
+    }
+    return response, nil
+}
+
@@ -0,0 +1,160 @@
+package rocketpool
+
+import (
+    "fmt"
+    "strings"
+)
+
+
+// Container runtimes
+const (
+    RuntimeDocker = "docker"
+    RuntimePodman = "podman"
+)
+
+
+// Container runtime commands & formats
+// Podman's CLI is docker-compatible, so runtimes differ only in the compose tool, labels and inspection formats
+type ContainerRuntime struct {
+    Name string
+    ComposeCmd string
+    ProjectLabel string
+    VersionFormat string
+    RootDirFormat string
+    SocketPath string
+    Rootless bool
+}
+
+
+// Get the container runtime used to run the Rocket Pool service
+// The runtime is selected in the config, or detected from the commands available on the host, preferring docker
+func (c *Client) GetContainerRuntime() (*ContainerRuntime, error) {
+
+    // Return cached runtime
+    if c.runtime != nil {
+        return c.runtime, nil
+    }
+
+    // Load config
+    rpConfig, err := c.LoadMergedConfig()
+    if err != nil {
+        return nil, err
+    }
+
+    // Detect runtime if not selected
+    name := rpConfig.Smartnode.ContainerRuntime
+    if name == "" {
+        output, err := c.readOutput(fmt.Sprintf("if command -v %s >/dev/null 2>&1; then echo %s; elif command -v %s >/dev/null 2>&1; then echo %s; fi", RuntimeDocker, RuntimeDocker, RuntimePodman, RuntimePodman))
+        if err != nil {
+            return nil, fmt.Errorf("Could not detect the container runtime: %w", err)
+        }
+        name = strings.TrimSpace(string(output))
+        if name == "" {
+            name = RuntimeDocker
+        }
+    }
+
+    // Get runtime
+    var runtime ContainerRuntime
+    switch name {
+        case RuntimeDocker:
+            runtime = ContainerRuntime{
+                Name: RuntimeDocker,
+                ComposeCmd: "docker-compose",
+                ProjectLabel: "com.docker.compose.project",
+                VersionFormat: "{{.Server.Version}}",
+                RootDirFormat: "{{.DockerRootDir}}",
+                SocketPath: DockerSocketPath,
+            }
+        case RuntimePodman:
+            runtime = ContainerRuntime{
+                Name: RuntimePodman,
+                ComposeCmd: "podman-compose",
+                ProjectLabel: "io.podman.compose.project",
+                VersionFormat: "{{.Client.Version}}",
+                RootDirFormat: "{{.Store.GraphRoot}}",
+                SocketPath: PodmanSocketPath,
+            }
+
+            // Check whether podman runs rootless
+            if output, err := c.readOutput("podman info --format '{{.Host.Security.Rootless}}' 2>/dev/null"); err == nil {
+                runtime.Rootless = (strings.TrimSpace(string(output)) == "true")
+            }
+
+            // Get the docker-compatible API socket, which differs for rootless podman
+            // The rootless socket is in the user's runtime folder, and is only reported by podman info once the socket service has been enabled
+            if output, err := c.readOutput("podman info --format '{{.Host.RemoteSocket.Path}}' 2>/dev/null"); err == nil {
+                if socketPath := strings.TrimPrefix(strings.TrimSpace(string(output)), "unix://"); socketPath != "" {
+                    runtime.SocketPath = socketPath
+                } else if runtime.Rootless {
+                    if output, err := c.readOutput(fmt.Sprintf("echo ${XDG_RUNTIME_DIR:-/run/user/$(id -u)}/%s", RootlessPodmanSocketPath)); err == nil {
+                        runtime.SocketPath = strings.TrimSpace(string(output))
+                    }
+                }
+            }
+
+        default:
+            return nil, fmt.Errorf("Unknown container runtime '%s'", name)
+    }
+
+    // Cache & return
+    c.runtime = &runtime
+    return c.runtime, nil
+
+}
+
+
+// Get the IDs of the Rocket Pool service containers
+// Containers are listed by their compose project label, as podman-compose does not support listing container IDs
+func (c *Client) getContainerIDs() ([]string, error) {
+    runtime, err := c.GetContainerRuntime()
+    if err != nil {
+        return []string{}, err
+    }
+    cmd, err := c.runtimeCommand(fmt.Sprintf("ps -q --filter label=%s=%s", runtime.ProjectLabel, ComposeProjectName))
+    if err != nil {
+        return []string{}, err
+    }
+    output, err := c.readOutput(cmd)
+    if err != nil {
+        return []string{}, fmt.Errorf("Could not get Rocket Pool service containers: %w", err)
+    }
+    return strings.Fields(string(output)), nil
+}
+
+
+// Get the service names of the Rocket Pool service containers, from their container names (rocketpool_<service>)
+func (c *Client) getContainerServiceNames() ([]string, error) {
+    runtime, err := c.GetContainerRuntime()
+    if err != nil {
+        return []string{}, err
+    }
+    cmd, err := c.runtimeCommand(fmt.Sprintf("ps -a --filter label=%s=%s --format \"{{.Names}}\"", runtime.ProjectLabel, ComposeProjectName))
+    if err != nil {
+        return []string{}, err
+    }
+    output, err := c.readOutput(cmd)
+    if err != nil {
+        return []string{}, fmt.Errorf("Could not get Rocket Pool service containers: %w", err)
+    }
+    serviceNames := []string{}
+    for _, containerName := range strings.Fields(string(output)) {
+        if strings.HasPrefix(containerName, ComposeProjectName + "_") {
+            serviceNames = append(serviceNames, strings.TrimPrefix(containerName, ComposeProjectName + "_"))
+        }
+    }
+    return serviceNames, nil
+}
+
+
+// Get the command to run a container runtime CLI command, e.g. "docker ps"
+func (c *Client) runtimeCommand(args string) (string, error) {
+    if err := c.checkComposeBackend(); err != nil {
+        return "", err
+    }
+    runtime, err := c.GetContainerRuntime()
+    if err != nil {
+        return "", err
+    }
+    return fmt.Sprintf("%s %s", runtime.Name, args), nil
+}
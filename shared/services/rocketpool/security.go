@@ -0,0 +1,98 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/rocket-pool/smartnode/shared/services/security"
+)
+
+
+// Host security settings checked by the security review
+type HostSecurity struct {
+    SSHConfigFound bool
+    SSHPasswordAuthentication string
+    SSHPermitRootLogin string
+    Firewall string
+    AutoUpdates string
+}
+
+
+// Get the host's SSH, firewall & automatic update settings
+// SSH settings are read from the sshd config files, where the first value set for an option applies; settings in Match blocks
+// are ignored, and empty values are unset
+func (c *Client) GetHostSecurity() (HostSecurity, error) {
+    var host HostSecurity
+
+    // Get SSH settings
+    sshOutput, err := c.readOutput("cat /etc/ssh/sshd_config.d/*.conf /etc/ssh/sshd_config 2>/dev/null || true")
+    if err != nil {
+        return HostSecurity{}, fmt.Errorf("Could not read SSH server config: %w", err)
+    }
+    host.SSHConfigFound = (len(strings.TrimSpace(string(sshOutput))) > 0)
+    for _, line := range strings.Split(string(sshOutput), "\n") {
+        fields := strings.Fields(line)
+        if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+            continue
+        }
+        if strings.EqualFold(fields[0], "Match") {
+            break
+        }
+        switch strings.ToLower(fields[0]) {
+            case "passwordauthentication":
+                if host.SSHPasswordAuthentication == "" { host.SSHPasswordAuthentication = strings.ToLower(fields[1]) }
+            case "permitrootlogin":
+                if host.SSHPermitRootLogin == "" { host.SSHPermitRootLogin = strings.ToLower(fields[1]) }
+        }
+    }
+
+    // Get firewall
+    firewallOutput, err := c.readOutput("if grep -qs '^ENABLED=yes' /etc/ufw/ufw.conf; then echo ufw; elif systemctl is-active -q firewalld 2>/dev/null; then echo firewalld; fi")
+    if err != nil {
+        return HostSecurity{}, fmt.Errorf("Could not get firewall status: %w", err)
+    }
+    host.Firewall = strings.TrimSpace(string(firewallOutput))
+
+    // Get automatic updates
+    updatesOutput, err := c.readOutput("if grep -qs 'Unattended-Upgrade \"1\"' /etc/apt/apt.conf.d/20auto-upgrades; then echo unattended-upgrades; elif systemctl is-enabled -q dnf-automatic-install.timer 2>/dev/null || systemctl is-enabled -q dnf-automatic.timer 2>/dev/null; then echo dnf-automatic; fi")
+    if err != nil {
+        return HostSecurity{}, fmt.Errorf("Could not get automatic update status: %w", err)
+    }
+    host.AutoUpdates = strings.TrimSpace(string(updatesOutput))
+
+    // Return
+    return host, nil
+
+}
+
+
+// Get the recorded security review; returns nil if the review has not been completed
+func (c *Client) LoadSecurityReview() (*security.Review, error) {
+    reviewBytes, err := c.readOutput(fmt.Sprintf("cat %s/%s/%s 2>/dev/null || true", RocketPoolPath, DataFolder, security.ReviewFile))
+    if err != nil {
+        return nil, fmt.Errorf("Could not read security review: %w", err)
+    }
+    if len(strings.TrimSpace(string(reviewBytes))) == 0 {
+        return nil, nil
+    }
+    var review security.Review
+    if err := json.Unmarshal(reviewBytes, &review); err != nil {
+        return nil, fmt.Errorf("Could not decode security review: %w", err)
+    }
+    return &review, nil
+}
+
+
+// Record a completed security review
+func (c *Client) SaveSecurityReview(review security.Review) error {
+    reviewBytes, err := json.Marshal(review)
+    if err != nil {
+        return fmt.Errorf("Could not encode security review: %w", err)
+    }
+    if _, err := c.readOutput(fmt.Sprintf("mkdir -p %s/%s && cat > %s/%s/%s <<'EOF'\n%s\nEOF", RocketPoolPath, DataFolder, RocketPoolPath, DataFolder, security.ReviewFile, string(reviewBytes))); err != nil {
+        return fmt.Errorf("Could not write security review: %w", err)
+    }
+    return nil
+}
+
@@ -0,0 +1,212 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get client peers, optionally looking up their locations
+func (c *Client) ServicePeers(lookupLocations bool) (api.ServicePeersResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("service peers %t", lookupLocations))
+    if err != nil {
+        return api.ServicePeersResponse{}, fmt.Errorf("Could not get client peers: %w", err)
+    }
+    var response api.ServicePeersResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServicePeersResponse{}, fmt.Errorf("Could not decode client peers response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServicePeersResponse{}, fmt.Errorf("Could not get client peers: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the Eth 1.0 and Eth 2.0 client sync progress
+func (c *Client) ServiceSync() (api.ServiceSyncResponse, error) {
+    responseBytes, err := c.callAPI("service sync")
+    if err != nil {
+        return api.ServiceSyncResponse{}, fmt.Errorf("Could not get client sync progress: %w", err)
+    }
+    var response api.ServiceSyncResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceSyncResponse{}, fmt.Errorf("Could not decode client sync progress response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceSyncResponse{}, fmt.Errorf("Could not get client sync progress: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Check the Eth 1.0 and Eth 2.0 client p2p networking
+func (c *Client) ServiceNetDiag() (api.ServiceNetDiagResponse, error) {
+    responseBytes, err := c.callAPI("service net-diag")
+    if err != nil {
+        return api.ServiceNetDiagResponse{}, fmt.Errorf("Could not get client network diagnostics: %w", err)
+    }
+    var response api.ServiceNetDiagResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceNetDiagResponse{}, fmt.Errorf("Could not decode client network diagnostics response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceNetDiagResponse{}, fmt.Errorf("Could not get client network diagnostics: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get client connection & sync status
+func (c *Client) ServiceClientStatus() (api.ServiceClientStatusResponse, error) {
+    responseBytes, err := c.callAPI("service client-status")
+    if err != nil {
+        return api.ServiceClientStatusResponse{}, fmt.Errorf("Could not get client status: %w", err)
+    }
+    var response api.ServiceClientStatusResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceClientStatusResponse{}, fmt.Errorf("Could not decode client status response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceClientStatusResponse{}, fmt.Errorf("Could not get client status: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the backups available at the backup destination
+func (c *Client) ServiceBackups() (api.ServiceBackupsResponse, error) {
+    responseBytes, err := c.callAPI("service backups")
+    if err != nil {
+        return api.ServiceBackupsResponse{}, fmt.Errorf("Could not get backups: %w", err)
+    }
+    var response api.ServiceBackupsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceBackupsResponse{}, fmt.Errorf("Could not decode backups response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceBackupsResponse{}, fmt.Errorf("Could not get backups: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Create a backup at the backup destination
+func (c *Client) CreateBackup(passphrase string) (api.ServiceCreateBackupResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("service create-backup \"%s\"", passphrase))
+    if err != nil {
+        return api.ServiceCreateBackupResponse{}, fmt.Errorf("Could not create backup: %w", err)
+    }
+    var response api.ServiceCreateBackupResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceCreateBackupResponse{}, fmt.Errorf("Could not decode create backup response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceCreateBackupResponse{}, fmt.Errorf("Could not create backup: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Restore a backup from the backup destination
+func (c *Client) RestoreBackup(name, passphrase string) (api.ServiceRestoreBackupResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("service restore-backup %s \"%s\"", name, passphrase))
+    if err != nil {
+        return api.ServiceRestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %w", err)
+    }
+    var response api.ServiceRestoreBackupResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceRestoreBackupResponse{}, fmt.Errorf("Could not decode restore backup response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceRestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Verify the most recent backup at the backup destination
+func (c *Client) VerifyBackup(passphrase string) (api.ServiceVerifyBackupResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("service verify-backup \"%s\"", passphrase))
+    if err != nil {
+        return api.ServiceVerifyBackupResponse{}, fmt.Errorf("Could not verify backup: %w", err)
+    }
+    var response api.ServiceVerifyBackupResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceVerifyBackupResponse{}, fmt.Errorf("Could not decode verify backup response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceVerifyBackupResponse{}, fmt.Errorf("Could not verify backup: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the status of the daemon tasks
+func (c *Client) ServiceTasks() (api.ServiceTasksResponse, error) {
+    responseBytes, err := c.callAPI("service tasks")
+    if err != nil {
+        return api.ServiceTasksResponse{}, fmt.Errorf("Could not get daemon tasks: %w", err)
+    }
+    var response api.ServiceTasksResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceTasksResponse{}, fmt.Errorf("Could not decode daemon tasks response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceTasksResponse{}, fmt.Errorf("Could not get daemon tasks: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Trigger a daemon task to run immediately
+func (c *Client) TriggerTask(name string) (api.ServiceTriggerTaskResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("service trigger-task %s", name))
+    if err != nil {
+        return api.ServiceTriggerTaskResponse{}, fmt.Errorf("Could not trigger task: %w", err)
+    }
+    var response api.ServiceTriggerTaskResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceTriggerTaskResponse{}, fmt.Errorf("Could not decode trigger task response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceTriggerTaskResponse{}, fmt.Errorf("Could not trigger task: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the remote signer status
+func (c *Client) SignerStatus() (api.ServiceSignerStatusResponse, error) {
+    responseBytes, err := c.callAPI("service signer-status")
+    if err != nil {
+        return api.ServiceSignerStatusResponse{}, fmt.Errorf("Could not get remote signer status: %w", err)
+    }
+    var response api.ServiceSignerStatusResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceSignerStatusResponse{}, fmt.Errorf("Could not decode remote signer status response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceSignerStatusResponse{}, fmt.Errorf("Could not get remote signer status: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Import the validator keys missing from the remote signer
+func (c *Client) SyncSigner() (api.ServiceSyncSignerResponse, error) {
+    responseBytes, err := c.callAPI("service sync-signer")
+    if err != nil {
+        return api.ServiceSyncSignerResponse{}, fmt.Errorf("Could not sync remote signer: %w", err)
+    }
+    var response api.ServiceSyncSignerResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ServiceSyncSignerResponse{}, fmt.Errorf("Could not decode sync remote signer response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ServiceSyncSignerResponse{}, fmt.Errorf("Could not sync remote signer: %s", response.Error)
+    }
+    return response, nil
+}
@@ -0,0 +1,77 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "regexp"
+    "strings"
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Config
+const SupportSocketFile = "support.sock"
+var supportHelperPattern = regexp.MustCompile("^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+$")
+
+
+// Create a time-limited, read-only support access token
+func (c *Client) SupportGrant(duration time.Duration) (api.SupportGrantResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("support grant %s", duration.String()))
+    if err != nil {
+        return api.SupportGrantResponse{}, fmt.Errorf("Could not grant support access: %w", err)
+    }
+    var response api.SupportGrantResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.SupportGrantResponse{}, fmt.Errorf("Could not decode support grant response: %w", err)
+    }
+    if response.Error != "" {
+        return api.SupportGrantResponse{}, fmt.Errorf("Could not grant support access: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Revoke all support access tokens
+func (c *Client) SupportRevoke() (api.SupportRevokeResponse, error) {
+    responseBytes, err := c.callAPI("support revoke")
+    if err != nil {
+        return api.SupportRevokeResponse{}, fmt.Errorf("Could not revoke support access: %w", err)
+    }
+    var response api.SupportRevokeResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.SupportRevokeResponse{}, fmt.Errorf("Could not decode support revoke response: %w", err)
+    }
+    if response.Error != "" {
+        return api.SupportRevokeResponse{}, fmt.Errorf("Could not revoke support access: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the support access audit log
+func (c *Client) SupportAuditLog() (api.SupportAuditLogResponse, error) {
+    responseBytes, err := c.callAPI("support audit-log")
+    if err != nil {
+        return api.SupportAuditLogResponse{}, fmt.Errorf("Could not get audit log: %w", err)
+    }
+    var response api.SupportAuditLogResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.SupportAuditLogResponse{}, fmt.Errorf("Could not decode audit log response: %w", err)
+    }
+    if response.Error != "" {
+        return api.SupportAuditLogResponse{}, fmt.Errorf("Could not get audit log: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Open a reverse SSH tunnel from a port on the helper's host to the support socket, until the duration elapses or it is interrupted
+func (c *Client) OpenSupportTunnel(helper string, remotePort uint, duration time.Duration) error {
+    if !supportHelperPattern.MatchString(helper) {
+        return fmt.Errorf("Invalid support helper '%s' - must be in the form user@host", helper)
+    }
+    socketPath := strings.Replace(fmt.Sprintf("%s/%s", RocketPoolPath, SupportSocketFile), "~", "$HOME", 1)
+    return c.printOutput(fmt.Sprintf("timeout %d ssh -N -o ExitOnForwardFailure=yes -o ServerAliveInterval=30 -R %d:%s %s", int64(duration.Seconds()), remotePort, socketPath, helper))
+}
@@ -0,0 +1,255 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+
+// Config
+const (
+    SystemdUnitPrefix = "rocketpool-"
+    SystemdUnitSuffix = ".service"
+    SystemdSlice = "rocketpool.slice"
+    NativeDaemonPath = "/usr/local/bin/rocketpoold"
+)
+
+
+// systemd service backend
+// Each service runs natively as a rocketpool-<service> systemd unit in the rocketpool slice, e.g. rocketpool-eth1.service;
+// units are installed by the installer, and controlled with sudo
+type systemdBackend struct {
+    c *Client
+}
+
+
+// Get the backend name
+func (b *systemdBackend) Name() string {
+    return BackendSystemd
+}
+
+
+// Start the Rocket Pool service
+// Units for externally managed clients are omitted and stopped if running; started units are enabled to start on boot
+func (b *systemdBackend) Start() error {
+
+    // Get managed & external units
+    managedUnits, externalUnits, err := b.getUnits()
+    if err != nil { return err }
+    if len(managedUnits) == 0 {
+        return fmt.Errorf("No Rocket Pool systemd units are installed. Please run 'rocketpool service install --backend %s' and try again.", BackendSystemd)
+    }
+
+    // Stop external service units
+    if len(externalUnits) > 0 {
+        if err := b.c.printOutput(fmt.Sprintf("sudo systemctl disable --now %s", strings.Join(externalUnits, " "))); err != nil { return err }
+    }
+
+    // Start managed service units
+    return b.c.printOutput(fmt.Sprintf("sudo systemctl enable --now %s", strings.Join(managedUnits, " ")))
+
+}
+
+
+// Pause the Rocket Pool service
+func (b *systemdBackend) Pause() error {
+    units, err := b.getInstalledUnits()
+    if err != nil { return err }
+    return b.c.printOutput(fmt.Sprintf("sudo systemctl stop %s", strings.Join(units, " ")))
+}
+
+
+// Stop the Rocket Pool service
+// Units are stopped and disabled; unlike the compose backend, chain data is kept
+func (b *systemdBackend) Stop() error {
+    units, err := b.getInstalledUnits()
+    if err != nil { return err }
+    return b.c.printOutput(fmt.Sprintf("sudo systemctl disable --now %s", strings.Join(units, " ")))
+}
+
+
+// Print the Rocket Pool service status
+func (b *systemdBackend) PrintStatus() error {
+    plain := ""
+    if b.c.accessible {
+        plain = " --plain"
+    }
+    return b.c.printOutput(fmt.Sprintf("systemctl list-units --all --no-pager%s '%s*%s'", plain, SystemdUnitPrefix, SystemdUnitSuffix))
+}
+
+
+// Print the Rocket Pool service logs
+// The journal is read as JSON, which merges the units' entries in time order
+func (b *systemdBackend) PrintLogs(options LogOptions, serviceNames ...string) error {
+
+    // Get units
+    units := []string{}
+    for _, serviceName := range serviceNames {
+        units = append(units, "-u " + getSystemdUnit(serviceName))
+    }
+    if len(units) == 0 {
+        units = append(units, fmt.Sprintf("-u '%s*%s'", SystemdUnitPrefix, SystemdUnitSuffix))
+        installedUnits, err := b.getInstalledUnits()
+        if err != nil { return err }
+        for _, unit := range installedUnits {
+            serviceNames = append(serviceNames, getSystemdServiceName(unit))
+        }
+    }
+
+    // Get journal arguments
+    args := []string{"journalctl", "--no-pager", "-o", "json"}
+    if options.Tail != "" {
+        args = append(args, "-n", options.Tail)
+    }
+    if !options.Since.IsZero() {
+        args = append(args, "--since", fmt.Sprintf("@%d", options.Since.Unix()))
+    }
+    if !options.Until.IsZero() {
+        args = append(args, "--until", fmt.Sprintf("@%d", options.Until.Unix()))
+    }
+    if options.Follow {
+        args = append(args, "-f")
+    }
+
+    // Print journal entries
+    printer := newLogPrinter(options, serviceNames, b.c.accessible)
+    return b.c.scanOutput(fmt.Sprintf("%s %s", strings.Join(args, " "), strings.Join(units, " ")), func(text string) {
+        var entry struct {
+            Unit string             `json:"_SYSTEMD_UNIT"`
+            Timestamp string        `json:"__REALTIME_TIMESTAMP"`
+            Message json.RawMessage `json:"MESSAGE"`
+        }
+        if err := json.Unmarshal([]byte(text), &entry); err != nil {
+            printer.print(logLine{Message: text})
+            return
+        }
+        line := logLine{Service: getSystemdServiceName(entry.Unit), Message: decodeJournalMessage(entry.Message)}
+        if micros, err := strconv.ParseInt(entry.Timestamp, 10, 64); err == nil {
+            line.Time = time.Unix(0, micros * int64(time.Microsecond))
+        }
+        printer.print(line)
+    })
+
+}
+
+
+// Print the Rocket Pool service stats
+// Print a single snapshot in accessible mode rather than refreshing
+func (b *systemdBackend) PrintStats() error {
+    statsArgs := ""
+    if b.c.accessible {
+        statsArgs = "-b -n 1 "
+    }
+    return b.c.printOutput(fmt.Sprintf("systemd-cgtop %s%s", statsArgs, SystemdSlice))
+}
+
+
+// Apply a service action requested by the node daemon (stop or restart) to a service's unit
+func (b *systemdBackend) ApplyAction(action, serviceName string) error {
+    return b.c.printOutput(fmt.Sprintf("sudo systemctl %s %s", action, getSystemdUnit(serviceName)))
+}
+
+
+// Get the command to run an API command with the native daemon binary
+func (b *systemdBackend) APICommand(globalArgs, args string) (string, error) {
+    return fmt.Sprintf("%s --config %s/%s --settings %s/%s %s api %s", NativeDaemonPath, RocketPoolPath, GlobalConfigFile, RocketPoolPath, UserConfigFile, globalArgs, args), nil
+}
+
+
+// Get the active states of the installed Rocket Pool units by name
+func (c *Client) GetUnitStates() (map[string]string, error) {
+    output, err := c.readOutput(fmt.Sprintf("systemctl list-units --all --no-legend --no-pager --plain '%s*%s'", SystemdUnitPrefix, SystemdUnitSuffix))
+    if err != nil {
+        return nil, fmt.Errorf("Could not get Rocket Pool systemd unit states: %w", err)
+    }
+    states := make(map[string]string)
+    for _, line := range strings.Split(string(output), "\n") {
+        if fields := strings.Fields(line); len(fields) >= 4 {
+            states[fields[0]] = fields[3]
+        }
+    }
+    return states, nil
+}
+
+
+// Get the names of the installed Rocket Pool units
+func (b *systemdBackend) getInstalledUnits() ([]string, error) {
+    output, err := b.c.readOutput(fmt.Sprintf("systemctl list-unit-files --no-legend --no-pager '%s*%s'", SystemdUnitPrefix, SystemdUnitSuffix))
+    if err != nil {
+        return []string{}, fmt.Errorf("Could not get Rocket Pool systemd units: %w", err)
+    }
+    units := []string{}
+    for _, line := range strings.Split(string(output), "\n") {
+        if fields := strings.Fields(line); len(fields) > 0 {
+            units = append(units, fields[0])
+        }
+    }
+    return units, nil
+}
+
+
+// Get the names of the installed units managed by Rocket Pool, and those replaced by external clients
+func (b *systemdBackend) getUnits() ([]string, []string, error) {
+
+    // Load config
+    rpConfig, err := b.c.LoadMergedConfig()
+    if err != nil {
+        return []string{}, []string{}, err
+    }
+
+    // Get installed units
+    units, err := b.getInstalledUnits()
+    if err != nil {
+        return []string{}, []string{}, err
+    }
+
+    // Filter managed units
+    managedUnits := []string{}
+    externalUnits := []string{}
+    for _, unit := range units {
+        if (unit == getSystemdUnit(Eth1ServiceName) && rpConfig.Chains.Eth1.External) || (unit == getSystemdUnit(Eth2ServiceName) && rpConfig.Chains.Eth2.External) {
+            externalUnits = append(externalUnits, unit)
+        } else {
+            managedUnits = append(managedUnits, unit)
+        }
+    }
+
+    // Return
+    return managedUnits, externalUnits, nil
+
+}
+
+
+// Get the systemd unit name for a service
+func getSystemdUnit(serviceName string) string {
+    return SystemdUnitPrefix + serviceName + SystemdUnitSuffix
+}
+
+
+
+// Get the service name for a systemd unit
+func getSystemdServiceName(unit string) string {
+    return strings.TrimSuffix(strings.TrimPrefix(unit, SystemdUnitPrefix), SystemdUnitSuffix)
+}
+
+
+// Decode a journal entry message, which is an array of bytes if it is not valid UTF-8
+func decodeJournalMessage(message json.RawMessage) string {
+    var text string
+    if err := json.Unmarshal(message, &text); err == nil {
+        return text
+    }
+    var textBytes []byte
+    var byteValues []int
+    if err := json.Unmarshal(message, &byteValues); err == nil {
+        for _, value := range byteValues {
+            textBytes = append(textBytes, byte(value))
+        }
+        return string(textBytes)
+    }
+    return string(message)
+}
+
@@ -0,0 +1,31 @@
+package rocketpool
+
+import (
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Broadcast a transaction signed offline and wait for it to be mined
+func (c *Client) BroadcastTransaction(transaction api.SignedTransaction) (api.BroadcastTransactionResponse, error) {
+    transactionBytes, err := json.Marshal(transaction)
+    if err != nil {
+        return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not encode signed transaction: %w", err)
+    }
+    responseBytes, err := c.callAPI(fmt.Sprintf("tx broadcast %s", hex.EncodeToString(transactionBytes)))
+    if err != nil {
+        return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %w", err)
+    }
+    var response api.BroadcastTransactionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not decode broadcast transaction response: %w", err)
+    }
+    if response.Error != "" {
+        return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %s", response.Error)
+    }
+    return response, nil
+}
+
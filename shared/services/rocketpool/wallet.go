@@ -1,6 +1,7 @@
 package rocketpool
 
 import (
+    "encoding/hex"
     "encoding/json"
     "fmt"
 
@@ -92,3 +93,59 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
     return response, nil
 }
 
+
+
+// Export validator keys as EIP-2335 keystores
+func (c *Client) ExportValidatorKeys(password string) (api.ExportValidatorKeysResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("wallet export-keys \"%s\"", password))
+    if err != nil {
+        return api.ExportValidatorKeysResponse{}, fmt.Errorf("Could not export validator keys: %w", err)
+    }
+    var response api.ExportValidatorKeysResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ExportValidatorKeysResponse{}, fmt.Errorf("Could not decode export validator keys response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ExportValidatorKeysResponse{}, fmt.Errorf("Could not export validator keys: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Import a validator key from an EIP-2335 keystore
+func (c *Client) ImportValidatorKey(keystore []byte, password string) (api.ImportValidatorKeyResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("wallet import-key %s \"%s\"", hex.EncodeToString(keystore), password))
+    if err != nil {
+        return api.ImportValidatorKeyResponse{}, fmt.Errorf("Could not import validator key: %w", err)
+    }
+    var response api.ImportValidatorKeyResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.ImportValidatorKeyResponse{}, fmt.Errorf("Could not decode import validator key response: %w", err)
+    }
+    if response.Error != "" {
+        return api.ImportValidatorKeyResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Sign a transaction prepared for offline signing with the node account
+func (c *Client) SignTransaction(transaction api.UnsignedTransaction) (api.SignTransactionResponse, error) {
+    transactionBytes, err := json.Marshal(transaction)
+    if err != nil {
+        return api.SignTransactionResponse{}, fmt.Errorf("Could not encode unsigned transaction: %w", err)
+    }
+    responseBytes, err := c.callAPI(fmt.Sprintf("wallet sign-tx %s", hex.EncodeToString(transactionBytes)))
+    if err != nil {
+        return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %w", err)
+    }
+    var response api.SignTransactionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.SignTransactionResponse{}, fmt.Errorf("Could not decode sign transaction response: %w", err)
+    }
+    if response.Error != "" {
+        return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %s", response.Error)
+    }
+    return response, nil
+}
+
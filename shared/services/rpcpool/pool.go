@@ -0,0 +1,295 @@
+package rpcpool
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/rpc"
+
+    "github.com/rocket-pool/smartnode/shared/services/metrics"
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
+    "github.com/rocket-pool/smartnode/shared/utils/retry"
+)
+
+
+// Settings
+const (
+    DefaultConnections = 2
+    DefaultMaxInFlight = 16
+    UnhealthyFailures = 3
+)
+var unhealthyCooldown, _ = time.ParseDuration("30s")
+
+
+// A pool of RPC connections to an Eth 1.0 provider
+// Requests are limited to a maximum number in flight across the pool, and sent on the healthiest, least busy connection
+type Pool struct {
+    provider string
+    conns []*conn
+    slots chan struct{}
+    metrics *metrics.Registry
+    lock sync.Mutex
+}
+
+
+// A pooled connection and its health
+type conn struct {
+    index int
+    client *rpc.Client
+    inFlight int
+    requests uint64
+    errors uint64
+    failures int
+    lastError string
+    unhealthyUntil time.Time
+}
+
+
+// Connection status
+type ConnStatus struct {
+    Index int
+    Healthy bool
+    InFlight int
+    Requests uint64
+    Errors uint64
+    LastError string
+}
+
+
+// Create a new pool of connections to a provider
+func New(provider string, connections, maxInFlight int) (*Pool, error) {
+
+    // Get settings
+    if connections <= 0 {
+        connections = DefaultConnections
+    }
+    if maxInFlight <= 0 {
+        maxInFlight = DefaultMaxInFlight
+    }
+
+    // Dial connections
+    p := &Pool{
+        provider: provider,
+        conns: make([]*conn, connections),
+        slots: make(chan struct{}, maxInFlight),
+    }
+    for ci := range p.conns {
+        client, err := dial(provider)
+        if err != nil {
+            p.Close()
+            return nil, fmt.Errorf("Could not connect to Eth 1.0 provider %s: %w", provider, err)
+        }
+        p.conns[ci] = &conn{index: ci, client: client}
+    }
+
+    // Return
+    return p, nil
+
+}
+
+
+// Get an RPC client which sends its requests through the pool
+// The client can be shared by ethclient, rocketpool-go & multicall clients; subscriptions are not supported
+func (p *Pool) Client() (*rpc.Client, error) {
+    return rpc.DialHTTPWithClient("http://rpcpool", &http.Client{Transport: &poolTransport{pool: p}})
+}
+
+
+// Record pool metrics in a registry
+func (p *Pool) SetMetrics(r *metrics.Registry) {
+    r.Counter("rpc_requests_total", "Eth 1.0 RPC requests sent, by pooled connection.")
+    r.Counter("rpc_errors_total", "Eth 1.0 RPC requests which failed to reach the provider, by pooled connection.")
+    r.Gauge("rpc_in_flight", "Eth 1.0 RPC requests in flight, by pooled connection.")
+    r.Gauge("rpc_connection_healthy", "Whether the pooled Eth 1.0 RPC connection is healthy (1) or not (0).")
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    p.metrics = r
+    for _, c := range p.conns {
+        p.recordMetrics(c)
+    }
+}
+
+
+// Get the status of each pooled connection
+func (p *Pool) Status() []ConnStatus {
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    now := time.Now()
+    statuses := make([]ConnStatus, len(p.conns))
+    for ci, c := range p.conns {
+        statuses[ci] = ConnStatus{
+            Index: c.index,
+            Healthy: c.isHealthy(now),
+            InFlight: c.inFlight,
+            Requests: c.requests,
+            Errors: c.errors,
+            LastError: c.lastError,
+        }
+    }
+    return statuses
+}
+
+
+// Close all pooled connections
+func (p *Pool) Close() {
+    for _, c := range p.conns {
+        if c != nil {
+            c.client.Close()
+        }
+    }
+}
+
+
+// Make a call on a pooled connection
+// Transient connection failures are retried, on the healthiest connection at the time; transaction submissions are never retried
+func (p *Pool) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+    return p.do(ctx, isRetryableMethod(method), func(c *conn) error {
+        return c.client.CallContext(ctx, result, method, args...)
+    })
+}
+
+
+// Make a batch call on a pooled connection
+// Transient connection failures are retried unless the batch submits a transaction
+func (p *Pool) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+    retryable := true
+    for _, elem := range batch {
+        if !isRetryableMethod(elem.Method) {
+            retryable = false
+        }
+    }
+    return p.do(ctx, retryable, func(c *conn) error {
+        return c.client.BatchCallContext(ctx, batch)
+    })
+}
+
+
+// Run a request on a pooled connection, retrying transient failures if retryable
+func (p *Pool) do(ctx context.Context, retryable bool, request func(c *conn) error) error {
+    policy := retry.DefaultPolicy
+    if !retryable {
+        policy.Attempts = 1
+    }
+    return retry.Do(ctx, policy, func() error {
+        c, err := p.acquire(ctx)
+        if err != nil {
+            return err
+        }
+        err = request(c)
+        p.release(c, err)
+        return err
+    })
+}
+
+
+// Check whether requests for a method may be retried
+// A failed transaction submission may still have reached the provider, so is not resent
+func isRetryableMethod(method string) bool {
+    return method != "eth_sendRawTransaction" && method != "eth_sendTransaction"
+}
+
+
+// Wait for a request slot and select a connection
+// Healthy connections are preferred; if none are healthy, the least recently failed connection is tried
+func (p *Pool) acquire(ctx context.Context) (*conn, error) {
+
+    // Wait for slot
+    select {
+        case p.slots <- struct{}{}:
+        case <-ctx.Done():
+            return nil, ctx.Err()
+    }
+
+    // Select connection
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    now := time.Now()
+    var selected *conn
+    for _, c := range p.conns {
+        if selected == nil || c.isPreferredTo(selected, now) {
+            selected = c
+        }
+    }
+    selected.inFlight++
+    selected.requests++
+    p.recordMetrics(selected)
+    return selected, nil
+
+}
+
+
+// Release a connection after a request, updating its health
+// JSON-RPC errors returned by the provider (e.g. reverted calls) do not count against its health
+func (p *Pool) release(c *conn, err error) {
+    p.lock.Lock()
+    var rpcErr rpc.Error
+    switch {
+        case err == nil || errors.As(err, &rpcErr):
+            c.failures = 0
+            c.unhealthyUntil = time.Time{}
+        case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+        default:
+            c.errors++
+            c.failures++
+            c.lastError = err.Error()
+            if c.failures >= UnhealthyFailures {
+                c.unhealthyUntil = time.Now().Add(unhealthyCooldown)
+            }
+    }
+    c.inFlight--
+    p.recordMetrics(c)
+    p.lock.Unlock()
+    <-p.slots
+}
+
+
+// Record a connection's metrics; requires the pool lock
+func (p *Pool) recordMetrics(c *conn) {
+    if p.metrics == nil {
+        return
+    }
+    label := strconv.Itoa(c.index)
+    healthy := 0.0
+    if c.isHealthy(time.Now()) {
+        healthy = 1
+    }
+    p.metrics.Set("rpc_requests_total", float64(c.requests), "connection", label)
+    p.metrics.Set("rpc_errors_total", float64(c.errors), "connection", label)
+    p.metrics.Set("rpc_in_flight", float64(c.inFlight), "connection", label)
+    p.metrics.Set("rpc_connection_healthy", healthy, "connection", label)
+}
+
+
+// Check whether a connection is healthy
+func (c *conn) isHealthy(now time.Time) bool {
+    return !now.Before(c.unhealthyUntil)
+}
+
+
+// Check whether a connection should be used in preference to another
+func (c *conn) isPreferredTo(other *conn, now time.Time) bool {
+    healthy, otherHealthy := c.isHealthy(now), other.isHealthy(now)
+    switch {
+        case healthy != otherHealthy:
+            return healthy
+        case !healthy:
+            return c.unhealthyUntil.Before(other.unhealthyUntil)
+        default:
+            return c.inFlight < other.inFlight
+    }
+}
+
+
+// Dial a connection to a provider, tracing HTTP requests if enabled
+func dial(provider string) (*rpc.Client, error) {
+    if tracing.Enabled() && (strings.HasPrefix(provider, "http://") || strings.HasPrefix(provider, "https://")) {
+        return rpc.DialHTTPWithClient(provider, &http.Client{Transport: tracing.NewTransport(http.DefaultTransport)})
+    }
+    return rpc.Dial(provider)
+}
@@ -0,0 +1,143 @@
+package rpcpool
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "io/ioutil"
+    "net/http"
+
+    "github.com/ethereum/go-ethereum/rpc"
+)
+
+
+// Settings
+const defaultErrorCode = -32000
+
+
+// JSON-RPC messages
+type jsonRequest struct {
+    Version string              `json:"jsonrpc"`
+    ID json.RawMessage          `json:"id"`
+    Method string               `json:"method"`
+    Params []json.RawMessage    `json:"params"`
+}
+type jsonResponse struct {
+    Version string              `json:"jsonrpc"`
+    ID json.RawMessage          `json:"id"`
+    Result json.RawMessage      `json:"result,omitempty"`
+    Error *jsonError            `json:"error,omitempty"`
+}
+type jsonError struct {
+    Code int                    `json:"code"`
+    Message string              `json:"message"`
+    Data interface{}            `json:"data,omitempty"`
+}
+
+
+// HTTP transport which serves JSON-RPC requests from a pool
+// Requests which fail to reach the provider are returned as HTTP errors, so they are reported to the caller as transport errors
+type poolTransport struct {
+    pool *Pool
+}
+
+
+// Serve a JSON-RPC request or batch
+func (t *poolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+    // Read request
+    body, err := ioutil.ReadAll(req.Body)
+    req.Body.Close()
+    if err != nil {
+        return nil, err
+    }
+    ctx := req.Context()
+
+    // Serve batch
+    if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+        var requests []jsonRequest
+        if err := json.Unmarshal(body, &requests); err != nil {
+            return nil, err
+        }
+        batch := make([]rpc.BatchElem, len(requests))
+        results := make([]json.RawMessage, len(requests))
+        for ri, request := range requests {
+            batch[ri] = rpc.BatchElem{Method: request.Method, Args: getArgs(request.Params), Result: &results[ri]}
+        }
+        if err := t.pool.BatchCallContext(ctx, batch); err != nil {
+            return nil, err
+        }
+        responses := make([]jsonResponse, len(requests))
+        for ri, request := range requests {
+            responses[ri] = getResponse(request.ID, results[ri], batch[ri].Error)
+        }
+        return newResponse(req, responses)
+    }
+
+    // Serve request
+    var request jsonRequest
+    if err := json.Unmarshal(body, &request); err != nil {
+        return nil, err
+    }
+    var result json.RawMessage
+    err = t.pool.CallContext(ctx, &result, request.Method, getArgs(request.Params)...)
+    var rpcErr rpc.Error
+    if err != nil && !errors.As(err, &rpcErr) {
+        return nil, err
+    }
+    return newResponse(req, getResponse(request.ID, result, err))
+
+}
+
+
+// Get call arguments from request params, which are passed through unchanged
+func getArgs(params []json.RawMessage) []interface{} {
+    args := make([]interface{}, len(params))
+    for pi, param := range params {
+        args[pi] = param
+    }
+    return args
+}
+
+
+// Get a JSON-RPC response for a call result
+func getResponse(id json.RawMessage, result json.RawMessage, err error) jsonResponse {
+    response := jsonResponse{Version: "2.0", ID: id}
+    if err == nil {
+        if len(result) == 0 {
+            result = json.RawMessage("null")
+        }
+        response.Result = result
+        return response
+    }
+    response.Error = &jsonError{Code: defaultErrorCode, Message: err.Error()}
+    var rpcErr rpc.Error
+    if errors.As(err, &rpcErr) {
+        response.Error.Code = rpcErr.ErrorCode()
+    }
+    var dataErr rpc.DataError
+    if errors.As(err, &dataErr) {
+        response.Error.Data = dataErr.ErrorData()
+    }
+    return response
+}
+
+
+// Build an HTTP response with a JSON body
+func newResponse(req *http.Request, body interface{}) (*http.Response, error) {
+    bodyBytes, err := json.Marshal(body)
+    if err != nil {
+        return nil, err
+    }
+    return &http.Response{
+        Status: "200 OK",
+        StatusCode: http.StatusOK,
+        Proto: "HTTP/1.1",
+        ProtoMajor: 1,
+        ProtoMinor: 1,
+        Header: http.Header{"Content-Type": []string{"application/json"}},
+        Body: ioutil.NopCloser(bytes.NewReader(bodyBytes)),
+        ContentLength: int64(len(bodyBytes)),
+        Request: req,
+    }, nil
+}
@@ -0,0 +1,456 @@
+package scheduler
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Config
+const (
+    TasksFolder = "tasks"
+    StatusFileSuffix = ".status.json"
+    TriggerFileSuffix = ".trigger"
+    DirMode = 0700
+    FileMode = 0600
+)
+var triggerPollInterval, _ = time.ParseDuration("5s")
+
+
+// Task handler
+type Handler func() error
+
+
+// Task activity check, called after each run to determine whether the task has work in progress
+type ActivityCheck func() bool
+
+
+// Task run hook, called after each run with its duration and result
+type RunHook func(name string, duration time.Duration, err error)
+
+
+// Task status, shared with the API through the data folder
+type TaskStatus struct {
+    Name string                     `json:"name"`
+    Daemon string                   `json:"daemon"`
+    Interval time.Duration          `json:"interval"`
+    ActiveInterval time.Duration    `json:"activeInterval"`
+    IdleInterval time.Duration      `json:"idleInterval"`
+    Enabled bool                    `json:"enabled"`
+    Rehearsal bool                  `json:"rehearsal"`
+    LastRun time.Time               `json:"lastRun"`
+    LastError string                `json:"lastError"`
+}
+
+
+// Scheduled task
+type task struct {
+    handler Handler
+    isActive ActivityCheck
+    log log.ColorLogger
+    trigger chan struct{}
+    reload chan struct{}
+    defaultActiveInterval time.Duration
+    defaultIdleInterval time.Duration
+    status TaskStatus
+}
+
+
+// Daemon task scheduler
+// Tasks run at their configured interval if enabled, and whenever they are triggered on demand
+// Adaptive tasks run at their active interval while they have work in progress, and back off to their idle interval when they do not
+type Scheduler struct {
+    cfg config.RocketPoolConfig
+    daemon string
+    tasks []*task
+    runHook RunHook
+    lock sync.Mutex
+}
+
+
+// Create new task scheduler for a daemon
+func New(cfg config.RocketPoolConfig, daemon string) *Scheduler {
+    return &Scheduler{
+        cfg: cfg,
+        daemon: daemon,
+        tasks: []*task{},
+    }
+}
+
+
+// Register a task with its default interval
+func (s *Scheduler) Register(name string, defaultInterval time.Duration, handler Handler, logger log.ColorLogger) error {
+    return s.RegisterAdaptive(name, defaultInterval, defaultInterval, handler, nil, logger)
+}
+
+
+// Register an adaptive task with its default active & idle intervals
+func (s *Scheduler) RegisterAdaptive(name string, defaultActiveInterval, defaultIdleInterval time.Duration, handler Handler, isActive ActivityCheck, logger log.ColorLogger) error {
+
+    // Get task settings
+    taskConfig := s.cfg.Tasks[name]
+    activeInterval, idleInterval, err := getIntervals(name, taskConfig, defaultActiveInterval, defaultIdleInterval)
+    if err != nil {
+        return err
+    }
+
+    // Add task
+    s.tasks = append(s.tasks, &task{
+        handler: handler,
+        isActive: isActive,
+        log: logger,
+        trigger: make(chan struct{}, 1),
+        reload: make(chan struct{}, 1),
+        defaultActiveInterval: defaultActiveInterval,
+        defaultIdleInterval: defaultIdleInterval,
+        status: TaskStatus{
+            Name: name,
+            Daemon: s.daemon,
+            Interval: activeInterval,
+            ActiveInterval: activeInterval,
+            IdleInterval: idleInterval,
+            Enabled: !taskConfig.Disabled,
+            Rehearsal: taskConfig.Rehearsal,
+        },
+    })
+    return nil
+
+}
+
+
+// Reload task settings from a new config
+// All settings are validated before any are applied; tasks with changed settings are rescheduled at their new interval
+func (s *Scheduler) Reload(cfg config.RocketPoolConfig) error {
+
+    // Get task settings
+    activeIntervals := make([]time.Duration, len(s.tasks))
+    idleIntervals := make([]time.Duration, len(s.tasks))
+    for ti, t := range s.tasks {
+        activeInterval, idleInterval, err := getIntervals(t.status.Name, cfg.Tasks[t.status.Name], t.defaultActiveInterval, t.defaultIdleInterval)
+        if err != nil {
+            return err
+        }
+        activeIntervals[ti] = activeInterval
+        idleIntervals[ti] = idleInterval
+    }
+
+    // Apply task settings
+    s.lock.Lock()
+    for ti, t := range s.tasks {
+        enabled := !cfg.Tasks[t.status.Name].Disabled
+        rehearsal := cfg.Tasks[t.status.Name].Rehearsal
+        if rehearsal != t.status.Rehearsal {
+            if rehearsal {
+                t.log.Warn("Task is now in rehearsal mode and will log transactions instead of submitting them.")
+            } else {
+                t.log.Println("Task left rehearsal mode and will submit transactions.")
+            }
+            t.status.Rehearsal = rehearsal
+        }
+        if t.status.ActiveInterval == activeIntervals[ti] && t.status.IdleInterval == idleIntervals[ti] && t.status.Enabled == enabled {
+            continue
+        }
+        if enabled != t.status.Enabled {
+            if enabled {
+                t.log.Println("Task was enabled.")
+            } else {
+                t.log.Warn("Task was disabled and will only run when triggered.")
+            }
+        }
+        t.status.ActiveInterval = activeIntervals[ti]
+        t.status.IdleInterval = idleIntervals[ti]
+        t.status.Interval = activeIntervals[ti]
+        t.status.Enabled = enabled
+        select {
+            case t.reload <- struct{}{}:
+            default:
+        }
+    }
+    s.lock.Unlock()
+
+    // Save status
+    return s.saveStatus()
+
+}
+
+
+// Set a hook to call after each task run; must be called before the scheduler is started
+func (s *Scheduler) SetRunHook(hook RunHook) {
+    s.runHook = hook
+}
+
+
+// Start running registered tasks
+func (s *Scheduler) Start() error {
+
+    // Save initial status & remove stale triggers
+    if err := s.saveStatus(); err != nil {
+        return err
+    }
+    for _, t := range s.tasks {
+        os.Remove(triggerPath(s.cfg.GetDataPath(), t.status.Name))
+    }
+
+    // Start tasks
+    for _, t := range s.tasks {
+        t := t
+        if !t.status.Enabled {
+            t.log.Warn("Task is disabled and will only run when triggered.")
+        }
+        if t.status.Rehearsal {
+            t.log.Warn("Task is in rehearsal mode and will log transactions instead of submitting them.")
+        }
+        go (func() {
+            if s.isEnabled(t) {
+                s.runTask(t)
+            }
+            for {
+                select {
+                    case <-time.After(s.getInterval(t)):
+                        if !s.isEnabled(t) { continue }
+                    case <-t.reload:
+                        continue
+                    case <-t.trigger:
+                        t.log.Println("Task triggered on demand.")
+                }
+                s.runTask(t)
+            }
+        })()
+    }
+
+    // Poll for triggers
+    go (func() {
+        for {
+            time.Sleep(triggerPollInterval)
+            s.checkTriggers()
+        }
+    })()
+
+    // Return
+    return nil
+
+}
+
+
+// Run a task and record its status
+func (s *Scheduler) runTask(t *task) {
+
+    // Run task
+    start := time.Now()
+    err := t.handler()
+    if err != nil {
+        t.log.Error(err)
+    }
+    if s.runHook != nil {
+        s.runHook(t.status.Name, time.Since(start), err)
+    }
+
+    // Check activity
+    active := t.isActive != nil && t.isActive()
+
+    // Get next interval; intervals double after each idle run until they reach the idle interval
+    s.lock.Lock()
+    interval := t.status.IdleInterval
+    if t.isActive != nil {
+        if active {
+            interval = t.status.ActiveInterval
+        } else if t.status.Interval * 2 < t.status.IdleInterval {
+            interval = t.status.Interval * 2
+        }
+    }
+    if interval != t.status.Interval {
+        t.log.Debugf("Next run in %s.", interval)
+    }
+
+    // Update status
+    t.status.LastRun = time.Now()
+    t.status.Interval = interval
+    if err != nil {
+        t.status.LastError = err.Error()
+    } else {
+        t.status.LastError = ""
+    }
+    s.lock.Unlock()
+    if err := s.saveStatus(); err != nil {
+        t.log.Error(err)
+    }
+
+}
+
+
+// Get a task's current interval
+func (s *Scheduler) getInterval(t *task) time.Duration {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    return t.status.Interval
+}
+
+
+// Check whether a task is enabled
+func (s *Scheduler) isEnabled(t *task) bool {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    return t.status.Enabled
+}
+
+
+// Check for and consume task triggers
+func (s *Scheduler) checkTriggers() {
+    for _, t := range s.tasks {
+        path := triggerPath(s.cfg.GetDataPath(), t.status.Name)
+        if _, err := os.Stat(path); err != nil {
+            continue
+        }
+        os.Remove(path)
+        select {
+            case t.trigger <- struct{}{}:
+            default:
+        }
+    }
+}
+
+
+// Save the daemon's task statuses to the data folder
+func (s *Scheduler) saveStatus() error {
+
+    // Get statuses
+    s.lock.Lock()
+    statuses := make([]TaskStatus, len(s.tasks))
+    for ti, t := range s.tasks {
+        statuses[ti] = t.status
+    }
+    s.lock.Unlock()
+
+    // Encode & write statuses
+    statusBytes, err := json.Marshal(statuses)
+    if err != nil {
+        return fmt.Errorf("Could not encode %s task status: %w", s.daemon, err)
+    }
+    path := filepath.Join(s.cfg.GetDataPath(), TasksFolder, s.daemon + StatusFileSuffix)
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create task folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path, statusBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write %s task status: %w", s.daemon, err)
+    }
+    return nil
+
+}
+
+
+// Get the statuses of all tasks registered by running daemons
+func GetTaskStatuses(dataPath string) ([]TaskStatus, error) {
+
+    // Get status files
+    files, err := ioutil.ReadDir(filepath.Join(dataPath, TasksFolder))
+    if os.IsNotExist(err) {
+        return []TaskStatus{}, nil
+    }
+    if err != nil {
+        return []TaskStatus{}, fmt.Errorf("Could not read task folder: %w", err)
+    }
+
+    // Load statuses
+    statuses := []TaskStatus{}
+    for _, file := range files {
+        if !strings.HasSuffix(file.Name(), StatusFileSuffix) {
+            continue
+        }
+        statusBytes, err := ioutil.ReadFile(filepath.Join(dataPath, TasksFolder, file.Name()))
+        if err != nil {
+            return []TaskStatus{}, fmt.Errorf("Could not read task status: %w", err)
+        }
+        var daemonStatuses []TaskStatus
+        if err := json.Unmarshal(statusBytes, &daemonStatuses); err != nil {
+            return []TaskStatus{}, fmt.Errorf("Could not decode task status: %w", err)
+        }
+        statuses = append(statuses, daemonStatuses...)
+    }
+
+    // Sort & return
+    sort.Slice(statuses, func(i, j int) bool {
+        if statuses[i].Daemon != statuses[j].Daemon {
+            return statuses[i].Daemon < statuses[j].Daemon
+        }
+        return statuses[i].Name < statuses[j].Name
+    })
+    return statuses, nil
+
+}
+
+
+// Trigger a task to run on demand; the task runs within a few seconds if its daemon is running
+func Trigger(dataPath, name string) error {
+
+    // Check task exists
+    statuses, err := GetTaskStatuses(dataPath)
+    if err != nil {
+        return err
+    }
+    found := false
+    for _, status := range statuses {
+        if status.Name == name {
+            found = true
+            break
+        }
+    }
+    if !found {
+        return fmt.Errorf("Task %s was not found", name)
+    }
+
+    // Write trigger
+    if err := ioutil.WriteFile(triggerPath(dataPath, name), []byte{}, FileMode); err != nil {
+        return fmt.Errorf("Could not trigger task %s: %w", name, err)
+    }
+    return nil
+
+}
+
+
+// Get a task's active & idle intervals from its settings
+func getIntervals(name string, taskConfig config.Task, defaultActiveInterval, defaultIdleInterval time.Duration) (time.Duration, time.Duration, error) {
+    idleInterval, err := parseInterval(name, taskConfig.Interval, defaultIdleInterval)
+    if err != nil {
+        return 0, 0, err
+    }
+    activeInterval, err := parseInterval(name, taskConfig.ActiveInterval, defaultActiveInterval)
+    if err != nil {
+        return 0, 0, err
+    }
+    if activeInterval > idleInterval {
+        activeInterval = idleInterval
+    }
+    return activeInterval, idleInterval, nil
+}
+
+
+// Parse a task interval setting
+func parseInterval(name, value string, defaultInterval time.Duration) (time.Duration, error) {
+    if value == "" {
+        return defaultInterval, nil
+    }
+    interval, err := time.ParseDuration(value)
+    if err != nil {
+        return 0, fmt.Errorf("Invalid %s task interval '%s': %w", name, value, err)
+    }
+    if interval <= 0 {
+        return 0, fmt.Errorf("Invalid %s task interval '%s': must be positive", name, value)
+    }
+    return interval, nil
+}
+
+
+// Get the path of a task's trigger file
+func triggerPath(dataPath, name string) string {
+    return filepath.Join(dataPath, TasksFolder, name + TriggerFileSuffix)
+}
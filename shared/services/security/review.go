@@ -0,0 +1,67 @@
+package security
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+
+// Config
+const ReviewFile = "security-review.json"
+
+
+// Check statuses
+const (
+    CheckPassed = "passed"
+    CheckWarning = "warning"
+    CheckSkipped = "skipped"
+)
+
+
+// A completed first-run security review, recorded in the data folder
+type Review struct {
+    Completed time.Time         `json:"completed"`
+    Checks []Check              `json:"checks"`
+}
+
+
+// A security review check result
+type Check struct {
+    Name string                 `json:"name"`
+    Status string               `json:"status"`
+    Detail string               `json:"detail,omitempty"`
+}
+
+
+// Get the recorded security review; returns nil if the review has not been completed
+func LoadReview(dataPath string) (*Review, error) {
+    reviewBytes, err := ioutil.ReadFile(filepath.Join(dataPath, ReviewFile))
+    if os.IsNotExist(err) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("Could not read security review: %w", err)
+    }
+    var review Review
+    if err := json.Unmarshal(reviewBytes, &review); err != nil {
+        return nil, fmt.Errorf("Could not decode security review: %w", err)
+    }
+    return &review, nil
+}
+
+
+// Get the number of checks with warnings
+func (r *Review) Warnings() int {
+    warnings := 0
+    for _, check := range r.Checks {
+        if check.Status == CheckWarning {
+            warnings++
+        }
+    }
+    return warnings
+}
+
@@ -1,47 +1,102 @@
 package services
 
 import (
+    "context"
     "fmt"
+    "log"
+    "net/http"
+    "strings"
     "sync"
+    "time"
 
     "github.com/docker/docker/client"
     "github.com/ethereum/go-ethereum/common"
     "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/ethereum/go-ethereum/rpc"
     "github.com/rocket-pool/rocketpool-go/rocketpool"
-    "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/shared/services/alerts"
     "github.com/rocket-pool/smartnode/shared/services/beacon"
     "github.com/rocket-pool/smartnode/shared/services/beacon/lighthouse"
     "github.com/rocket-pool/smartnode/shared/services/beacon/prysm"
     "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/containers"
+    "github.com/rocket-pool/smartnode/shared/services/contracts"
+    "github.com/rocket-pool/smartnode/shared/services/multicall"
     "github.com/rocket-pool/smartnode/shared/services/passwords"
+    "github.com/rocket-pool/smartnode/shared/services/rpcpool"
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
     "github.com/rocket-pool/smartnode/shared/services/wallet"
     lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
     prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
+    w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+    "github.com/rocket-pool/smartnode/shared/services/web3signer"
 )
 
 
 // Config
 const DockerAPIVersion = "1.40"
+var (
+    analyticsClientCheckTimeout, _ = time.ParseDuration("5s")
+    analyticsClientRetryInterval, _ = time.ParseDuration("1m")
+)
 
 
 // Service instances & initializers
+// Services are process-wide singletons: each is initialized once from the config loaded by the first caller,
+// and its initialization error (if any) is stored and returned to all later callers
+// The analytics provider is the exception: if it is unavailable, the primary client is used and it is retried later
 var (
     cfg config.RocketPoolConfig
     passwordManager *passwords.PasswordManager
     nodeWallet *wallet.Wallet
+    ethRPCPool *rpcpool.Pool
+    ethRPCClient *rpc.Client
     ethClient *ethclient.Client
     rocketPool *rocketpool.RocketPool
+    ethAnalyticsClient *ethclient.Client
+    analyticsRocketPool *rocketpool.RocketPool
+    analyticsRocketPoolClient *ethclient.Client
+    analyticsClientChecked time.Time
     beaconClient beacon.Client
     docker *client.Client
+    alerter *alerts.Alerter
+    txManager *transactions.Manager
+    remoteSigner *web3signer.Client
+    multicallClient *multicall.Client
+    contractCache *contracts.Cache
+    containerController *containers.Controller
 
     initCfg sync.Once
     initPasswordManager sync.Once
     initNodeWallet sync.Once
+    initEthRPCPool sync.Once
+    initEthRPCClient sync.Once
     initEthClient sync.Once
     initRocketPool sync.Once
     initBeaconClient sync.Once
     initDocker sync.Once
+    initAlerter sync.Once
+    initTxManager sync.Once
+    initRemoteSigner sync.Once
+    initMulticallClient sync.Once
+    initContractCache sync.Once
+    initContainerController sync.Once
+
+    cfgErr error
+    passwordManagerErr error
+    nodeWalletErr error
+    ethRPCPoolErr error
+    ethRPCClientErr error
+    rocketPoolErr error
+    beaconClientErr error
+    dockerErr error
+    multicallClientErr error
+    contractCacheErr error
+
+    cfgLock sync.RWMutex
+    analyticsLock sync.Mutex
 )
 
 
@@ -50,31 +105,53 @@ var (
 //
 
 
-func GetConfig(c *cli.Context) (config.RocketPoolConfig, error) {
+func GetConfig(c config.Context) (config.RocketPoolConfig, error) {
     return getConfig(c)
 }
 
 
-func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
+func GetPasswordManager(c config.Context) (*passwords.PasswordManager, error) {
     cfg, err := getConfig(c)
     if err != nil {
         return nil, err
     }
-    return getPasswordManager(cfg), nil
+    return getPasswordManager(cfg)
 }
 
 
-func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
+func GetWallet(c config.Context) (*wallet.Wallet, error) {
     cfg, err := getConfig(c)
     if err != nil {
         return nil, err
     }
-    pm := getPasswordManager(cfg)
+    pm, err := getPasswordManager(cfg)
+    if err != nil {
+        return nil, err
+    }
     return getWallet(cfg, pm)
 }
 
 
-func GetEthClient(c *cli.Context) (*ethclient.Client, error) {
+// Get the pool of Eth 1.0 RPC connections which the Eth 1.0 clients send their requests through
+func GetEthRPCPool(c config.Context) (*rpcpool.Pool, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    return getEthRPCPool(cfg)
+}
+
+
+func GetEthRPCClient(c config.Context) (*rpc.Client, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    return getEthRPCClient(cfg)
+}
+
+
+func GetEthClient(c config.Context) (*ethclient.Client, error) {
     cfg, err := getConfig(c)
     if err != nil {
         return nil, err
@@ -83,7 +160,7 @@ func GetEthClient(c *cli.Context) (*ethclient.Client, error) {
 }
 
 
-func GetRocketPool(c *cli.Context) (*rocketpool.RocketPool, error) {
+func GetRocketPool(c config.Context) (*rocketpool.RocketPool, error) {
     cfg, err := getConfig(c)
     if err != nil {
         return nil, err
@@ -96,7 +173,36 @@ func GetRocketPool(c *cli.Context) (*rocketpool.RocketPool, error) {
 }
 
 
-func GetBeaconClient(c *cli.Context) (beacon.Client, error) {
+func GetEthAnalyticsClient(c config.Context) (*ethclient.Client, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    return getEthAnalyticsClient(cfg)
+}
+
+
+func GetAnalyticsRocketPool(c config.Context) (*rocketpool.RocketPool, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    ec, err := getEthClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    aec, err := getEthAnalyticsClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    if aec == ec {
+        return getRocketPool(cfg, ec)
+    }
+    return getAnalyticsRocketPool(cfg, aec)
+}
+
+
+func GetBeaconClient(c config.Context) (beacon.Client, error) {
     cfg, err := getConfig(c)
     if err != nil {
         return nil, err
@@ -105,8 +211,104 @@ func GetBeaconClient(c *cli.Context) (beacon.Client, error) {
 }
 
 
-func GetDocker(c *cli.Context) (*client.Client, error) {
-    return getDocker()
+// Returns nil if the docker socket is disabled
+func GetDocker(c config.Context) (*client.Client, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    return getDocker(cfg)
+}
+
+
+func GetAlerter(c config.Context) (*alerts.Alerter, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    return getAlerter(cfg), nil
+}
+
+
+func GetTransactionManager(c config.Context) (*transactions.Manager, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    pm, err := getPasswordManager(cfg)
+    if err != nil {
+        return nil, err
+    }
+    w, err := getWallet(cfg, pm)
+    if err != nil {
+        return nil, err
+    }
+    rc, err := getEthRPCClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    ec, err := getEthClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    rp, err := getRocketPool(cfg, ec)
+    if err != nil {
+        return nil, err
+    }
+    return getTxManager(cfg, rc, ec, rp, w), nil
+}
+
+
+// Get the remote signer client; returns nil if no remote signer is configured
+func GetRemoteSigner(c config.Context) (*web3signer.Client, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    return getRemoteSigner(cfg), nil
+}
+
+
+func GetMulticall(c config.Context) (*multicall.Client, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    rpcClient, err := getEthRPCClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    return getMulticall(cfg, rpcClient)
+}
+
+
+func GetContractCache(c config.Context) (*contracts.Cache, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    ec, err := getEthClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    rp, err := getRocketPool(cfg, ec)
+    if err != nil {
+        return nil, err
+    }
+    return getContractCache(cfg, rp)
+}
+
+
+func GetContainerController(c config.Context) (*containers.Controller, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    d, err := getDocker(cfg)
+    if err != nil {
+        return nil, err
+    }
+    return getContainerController(cfg, d), nil
 }
 
 
@@ -115,77 +317,244 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 //
 
 
-func getConfig(c *cli.Context) (config.RocketPoolConfig, error) {
-    var err error
+func getConfig(c config.Context) (config.RocketPoolConfig, error) {
     initCfg.Do(func() {
-        cfg, err = config.Load(c)
+        cfg, cfgErr = config.Load(c)
     })
-    return cfg, err
+    cfgLock.RLock()
+    defer cfgLock.RUnlock()
+    return cfg, cfgErr
+}
+
+
+func setConfig(newCfg config.RocketPoolConfig) {
+    cfgLock.Lock()
+    defer cfgLock.Unlock()
+    cfg = newCfg
 }
 
 
-func getPasswordManager(cfg config.RocketPoolConfig) *passwords.PasswordManager {
+func getPasswordManager(cfg config.RocketPoolConfig) (*passwords.PasswordManager, error) {
     initPasswordManager.Do(func() {
-        passwordManager = passwords.NewPasswordManager(cfg.Smartnode.PasswordPath)
+        if cfg.Smartnode.PasswordSource.Type == "" {
+            passwordManager = passwords.NewPasswordManager(cfg.Smartnode.PasswordPath)
+            return
+        }
+        var source passwords.Source
+        source, passwordManagerErr = passwords.NewSource(cfg.Smartnode.PasswordSource)
+        if passwordManagerErr == nil {
+            passwordManager = passwords.NewSourcePasswordManager(source)
+        }
     })
-    return passwordManager
+    return passwordManager, passwordManagerErr
 }
 
 
 func getWallet(cfg config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
-    var err error
     initNodeWallet.Do(func() {
-        nodeWallet, err = wallet.NewWallet(cfg.Smartnode.WalletPath, pm)
-        if err == nil {
+        nodeWallet, nodeWalletErr = wallet.NewWallet(cfg.Smartnode.WalletPath, pm)
+        if nodeWalletErr == nil {
+            if cfg.Smartnode.NodeAddress != "" {
+                nodeWallet.SetOfflineNodeAddress(common.HexToAddress(cfg.Smartnode.NodeAddress))
+            }
+            if signer := getRemoteSigner(cfg); signer != nil {
+                nodeWallet.AddKeystore("web3signer", w3skeystore.NewKeystore(signer, pm))
+                return
+            }
             lighthouseKeystore := lhkeystore.NewKeystore(cfg.Smartnode.ValidatorKeychainPath, pm)
             prysmKeystore := prkeystore.NewKeystore(cfg.Smartnode.ValidatorKeychainPath)
             nodeWallet.AddKeystore("lighthouse", lighthouseKeystore)
             nodeWallet.AddKeystore("prysm", prysmKeystore)
         }
     })
-    return nodeWallet, err
+    return nodeWallet, nodeWalletErr
+}
+
+
+func getEthRPCPool(cfg config.RocketPoolConfig) (*rpcpool.Pool, error) {
+    initEthRPCPool.Do(func() {
+        ethRPCPool, ethRPCPoolErr = rpcpool.New(cfg.Chains.Eth1.Provider, cfg.Chains.Eth1.RPCConnections, cfg.Chains.Eth1.RPCMaxInFlight)
+    })
+    return ethRPCPool, ethRPCPoolErr
+}
+
+
+func getEthRPCClient(cfg config.RocketPoolConfig) (*rpc.Client, error) {
+    pool, err := getEthRPCPool(cfg)
+    if err != nil {
+        return nil, err
+    }
+    initEthRPCClient.Do(func() {
+        ethRPCClient, ethRPCClientErr = pool.Client()
+    })
+    return ethRPCClient, ethRPCClientErr
 }
 
 
 func getEthClient(cfg config.RocketPoolConfig) (*ethclient.Client, error) {
-    var err error
+    rpcClient, err := getEthRPCClient(cfg)
+    if err != nil {
+        return nil, err
+    }
     initEthClient.Do(func() {
-        ethClient, err = ethclient.Dial(cfg.Chains.Eth1.Provider)
+        ethClient = ethclient.NewClient(rpcClient)
     })
-    return ethClient, err
+    return ethClient, nil
 }
 
 
 func getRocketPool(cfg config.RocketPoolConfig, client *ethclient.Client) (*rocketpool.RocketPool, error) {
-    var err error
     initRocketPool.Do(func() {
-        rocketPool, err = rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Rocketpool.StorageAddress))
+        rocketPool, rocketPoolErr = rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Rocketpool.StorageAddress))
     })
-    return rocketPool, err
+    return rocketPool, rocketPoolErr
 }
 
 
-func getBeaconClient(cfg config.RocketPoolConfig) (beacon.Client, error) {
+func getEthAnalyticsClient(cfg config.RocketPoolConfig) (*ethclient.Client, error) {
+
+    // Use the primary client if no separate analytics provider is set
+    provider := cfg.Chains.Eth1.AnalyticsProvider
+    if provider == "" || provider == cfg.Chains.Eth1.Provider {
+        return getEthClient(cfg)
+    }
+
+    // Connect to analytics provider; if it is unavailable, the primary client is used until it is retried
+    analyticsLock.Lock()
+    defer analyticsLock.Unlock()
+    if ethAnalyticsClient == nil && time.Since(analyticsClientChecked) >= analyticsClientRetryInterval {
+        analyticsClientChecked = time.Now()
+        if ec, err := dialEthAnalyticsClient(provider); err != nil {
+            log.Printf("The Eth 1.0 analytics provider is unavailable, using the primary Eth 1.0 client and retrying in %s: %s\n", analyticsClientRetryInterval.String(), err.Error())
+        } else {
+            ethAnalyticsClient = ec
+        }
+    }
+    if ethAnalyticsClient == nil {
+        return getEthClient(cfg)
+    }
+    return ethAnalyticsClient, nil
+
+}
+
+
+// Dial the analytics provider and check that it is reachable
+func dialEthAnalyticsClient(provider string) (*ethclient.Client, error) {
+    var rpcClient *rpc.Client
     var err error
+    if tracing.Enabled() && (strings.HasPrefix(provider, "http://") || strings.HasPrefix(provider, "https://")) {
+        rpcClient, err = rpc.DialHTTPWithClient(provider, &http.Client{Transport: tracing.NewTransport(http.DefaultTransport)})
+    } else {
+        rpcClient, err = rpc.Dial(provider)
+    }
+    if err != nil {
+        return nil, err
+    }
+    ec := ethclient.NewClient(rpcClient)
+    ctx, cancel := context.WithTimeout(context.Background(), analyticsClientCheckTimeout)
+    defer cancel()
+    if _, err := ec.HeaderByNumber(ctx, nil); err != nil {
+        ec.Close()
+        return nil, err
+    }
+    return ec, nil
+}
+
+
+// The analytics contract bindings are recreated if the analytics client changes, e.g. once the analytics provider is available
+func getAnalyticsRocketPool(cfg config.RocketPoolConfig, client *ethclient.Client) (*rocketpool.RocketPool, error) {
+    analyticsLock.Lock()
+    defer analyticsLock.Unlock()
+    if analyticsRocketPool == nil || analyticsRocketPoolClient != client {
+        rp, err := rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Rocketpool.StorageAddress))
+        if err != nil {
+            return nil, err
+        }
+        analyticsRocketPool = rp
+        analyticsRocketPoolClient = client
+    }
+    return analyticsRocketPool, nil
+}
+
+
+func getBeaconClient(cfg config.RocketPoolConfig) (beacon.Client, error) {
     initBeaconClient.Do(func() {
         switch cfg.Chains.Eth2.Client.Selected {
             case "lighthouse":
                 beaconClient = lighthouse.NewClient(cfg.Chains.Eth2.Provider)
             case "prysm":
-                beaconClient, err = prysm.NewClient(cfg.Chains.Eth2.Provider)
+                beaconClient, beaconClientErr = prysm.NewClient(cfg.Chains.Eth2.Provider)
             default:
-                err = fmt.Errorf("Unknown Eth 2.0 client '%s' selected", cfg.Chains.Eth2.Client.Selected)
+                beaconClientErr = fmt.Errorf("Unknown Eth 2.0 client '%s' selected", cfg.Chains.Eth2.Client.Selected)
         }
     })
-    return beaconClient, err
+    return beaconClient, beaconClientErr
 }
 
 
-func getDocker() (*client.Client, error) {
-    var err error
+func getDocker(cfg config.RocketPoolConfig) (*client.Client, error) {
     initDocker.Do(func() {
-        docker, err = client.NewClientWithOpts(client.WithVersion(DockerAPIVersion))
+        if !cfg.UseServiceAgent() {
+            docker, dockerErr = client.NewClientWithOpts(client.WithVersion(DockerAPIVersion))
+        }
+    })
+    return docker, dockerErr
+}
+
+
+func getAlerter(cfg config.RocketPoolConfig) *alerts.Alerter {
+    initAlerter.Do(func() {
+        alerter = alerts.NewAlerter(cfg.GetDataPath(), cfg.Smartnode.AlertWebhookURL)
+    })
+    return alerter
+}
+
+
+func getTxManager(cfg config.RocketPoolConfig, rc *rpc.Client, ec *ethclient.Client, rp *rocketpool.RocketPool, w *wallet.Wallet) *transactions.Manager {
+    initTxManager.Do(func() {
+        txManager = transactions.NewManager(ec, w, cfg.GetDataPath(), cfg.Smartnode.MaxFee)
+        txManager.SetOffline(cfg.Smartnode.Offline)
+        if cfg.Smartnode.DryRun {
+            txManager.SetDryRun(rc, rp, common.HexToAddress(cfg.Rocketpool.StorageAddress), cfg.GetGasToken())
+        }
+    })
+    return txManager
+}
+
+
+func getRemoteSigner(cfg config.RocketPoolConfig) *web3signer.Client {
+    initRemoteSigner.Do(func() {
+        if cfg.RemoteSigner.URL != "" {
+            remoteSigner = web3signer.NewClient(cfg.RemoteSigner.URL)
+        }
     })
-    return docker, err
+    return remoteSigner
 }
 
+
+func getMulticall(cfg config.RocketPoolConfig, rpcClient *rpc.Client) (*multicall.Client, error) {
+    initMulticallClient.Do(func() {
+        multicallClient, multicallClientErr = multicall.NewClient(rpcClient, common.HexToAddress(cfg.Rocketpool.MulticallAddress))
+    })
+    return multicallClient, multicallClientErr
+}
+
+
+func getContractCache(cfg config.RocketPoolConfig, rp *rocketpool.RocketPool) (*contracts.Cache, error) {
+    initContractCache.Do(func() {
+        var ttl time.Duration
+        ttl, contractCacheErr = cfg.GetContractCacheTTL()
+        if contractCacheErr == nil {
+            contractCache = contracts.NewCache(rp, ttl)
+        }
+    })
+    return contractCache, contractCacheErr
+}
+
+
+func getContainerController(cfg config.RocketPoolConfig, d *client.Client) *containers.Controller {
+    initContainerController.Do(func() {
+        containerController = containers.NewController(d, cfg.GetDataPath())
+    })
+    return containerController
+}
@@ -0,0 +1,123 @@
+package snapshots
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/tokens"
+    "github.com/rocket-pool/rocketpool-go/types"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/multicall"
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
+)
+
+
+// Collect a snapshot of the node's current state
+// Minipool details are loaded in a single batch of calls at the snapshot block
+func Collect(rp *rocketpool.RocketPool, mc *multicall.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig) (Snapshot, error) {
+
+    // Data
+    var wg errgroup.Group
+    snapshot := Snapshot{
+        Time: time.Now().UTC(),
+        NodeAddress: nodeAddress,
+        Minipools: []Minipool{},
+    }
+    var addresses []common.Address
+    var minipoolABI *abi.ABI
+
+    // Get settings
+    settings, err := GetSnapshotSettings(cfg)
+    if err != nil {
+        return Snapshot{}, err
+    }
+    snapshot.Settings = settings
+
+    // Get current block
+    header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+    if err != nil {
+        return Snapshot{}, fmt.Errorf("Could not get current block: %w", err)
+    }
+    snapshot.Block = header.Number.Uint64()
+
+    // Get node balances
+    wg.Go(func() error {
+        var err error
+        snapshot.Balances, err = tokens.GetBalances(rp, nodeAddress, nil)
+        return err
+    })
+
+    // Get minipool addresses
+    wg.Go(func() error {
+        var err error
+        addresses, err = minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+        return err
+    })
+
+    // Get minipool ABI
+    wg.Go(func() error {
+        var err error
+        minipoolABI, err = rp.GetABI("rocketMinipool")
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return Snapshot{}, err
+    }
+
+    // Load minipool details
+    statuses := make([]uint8, len(addresses))
+    nodeDepositBalances := make([]*big.Int, len(addresses))
+    nodeRefundBalances := make([]*big.Int, len(addresses))
+    userDepositBalances := make([]*big.Int, len(addresses))
+    batch := mc.NewBatch()
+    for mi, address := range addresses {
+        if err := batch.AddCall(address, minipoolABI, &statuses[mi], "getStatus"); err != nil {
+            return Snapshot{}, err
+        }
+        if err := batch.AddCall(address, minipoolABI, &nodeDepositBalances[mi], "getNodeDepositBalance"); err != nil {
+            return Snapshot{}, err
+        }
+        if err := batch.AddCall(address, minipoolABI, &nodeRefundBalances[mi], "getNodeRefundBalance"); err != nil {
+            return Snapshot{}, err
+        }
+        if err := batch.AddCall(address, minipoolABI, &userDepositBalances[mi], "getUserDepositBalance"); err != nil {
+            return Snapshot{}, err
+        }
+    }
+    if err := batch.Execute(); err != nil {
+        return Snapshot{}, fmt.Errorf("Could not get minipool details: %w", err)
+    }
+
+    // Build minipool snapshots
+    snapshot.Collateral = big.NewInt(0)
+    for mi, address := range addresses {
+        snapshot.Minipools = append(snapshot.Minipools, Minipool{
+            Address: address,
+            Status: types.MinipoolStatus(statuses[mi]).String(),
+            NodeDepositBalance: nodeDepositBalances[mi],
+            NodeRefundBalance: nodeRefundBalances[mi],
+            UserDepositBalance: userDepositBalances[mi],
+        })
+        if nodeDepositBalances[mi] != nil {
+            collateral, err := safemath.Add(snapshot.Collateral, nodeDepositBalances[mi])
+            if err != nil {
+                return Snapshot{}, fmt.Errorf("Could not calculate node collateral: %w", err)
+            }
+            snapshot.Collateral = collateral
+        }
+    }
+
+    // Return
+    return snapshot, nil
+
+}
@@ -0,0 +1,257 @@
+package snapshots
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "math/big"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/tokens"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Config
+const (
+    SnapshotLogFile = "snapshots.log"
+    DefaultRetention = 90 * 24 * time.Hour
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// A snapshot of the node's state
+// Collateral is the total node deposit balance across the node's minipools
+type Snapshot struct {
+    Time time.Time                  `json:"time"`
+    Block uint64                    `json:"block"`
+    NodeAddress common.Address      `json:"nodeAddress"`
+    Balances tokens.Balances        `json:"balances"`
+    Collateral *big.Int             `json:"collateral"`
+    Minipools []Minipool            `json:"minipools"`
+    Settings map[string]string      `json:"settings"`
+}
+type Minipool struct {
+    Address common.Address          `json:"address"`
+    Status string                   `json:"status"`
+    NodeDepositBalance *big.Int     `json:"nodeDepositBalance"`
+    NodeRefundBalance *big.Int      `json:"nodeRefundBalance"`
+    UserDepositBalance *big.Int     `json:"userDepositBalance"`
+}
+
+
+// A change between snapshots
+type Change struct {
+    Category string                 `json:"category"`
+    Item string                     `json:"item"`
+    Old string                      `json:"old"`
+    New string                      `json:"new"`
+}
+
+
+// Get the settings to record in a snapshot from a config
+// Client option definitions are replaced with the selected clients' images, so upgrades show as image changes
+func GetSnapshotSettings(cfg *config.RocketPoolConfig) (map[string]string, error) {
+    settings, err := config.GetSettings(cfg)
+    if err != nil {
+        return nil, err
+    }
+    for path := range settings {
+        if strings.HasPrefix(path, "chains.eth1.client.options") || strings.HasPrefix(path, "chains.eth2.client.options") {
+            delete(settings, path)
+        }
+    }
+    if client := cfg.GetSelectedEth1Client(); client != nil {
+        settings["chains.eth1.client.image"] = client.Image
+    }
+    if client := cfg.GetSelectedEth2Client(); client != nil {
+        settings["chains.eth2.client.beaconImage"] = client.GetBeaconImage()
+        settings["chains.eth2.client.validatorImage"] = client.GetValidatorImage()
+    }
+    return settings, nil
+}
+
+
+// Append a snapshot to the snapshot log, removing snapshots older than the retention period
+func Record(dataPath string, snapshot Snapshot, retention time.Duration) error {
+
+    // Get retained snapshots
+    snapshots, err := Get(dataPath)
+    if err != nil {
+        return err
+    }
+    retained := []Snapshot{}
+    for _, s := range snapshots {
+        if snapshot.Time.Sub(s.Time) <= retention {
+            retained = append(retained, s)
+        }
+    }
+    retained = append(retained, snapshot)
+
+    // Encode snapshots
+    var logBytes []byte
+    for _, s := range retained {
+        snapshotBytes, err := json.Marshal(s)
+        if err != nil {
+            return fmt.Errorf("Could not encode node snapshot: %w", err)
+        }
+        logBytes = append(logBytes, snapshotBytes...)
+        logBytes = append(logBytes, '\n')
+    }
+
+    // Write snapshot log
+    path := filepath.Join(dataPath, SnapshotLogFile)
+    if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+        return fmt.Errorf("Could not create node snapshot log folder: %w", err)
+    }
+    if err := ioutil.WriteFile(path + ".tmp", logBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write node snapshot log: %w", err)
+    }
+    if err := os.Rename(path + ".tmp", path); err != nil {
+        return fmt.Errorf("Could not write node snapshot log: %w", err)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get recorded snapshots, oldest first
+func Get(dataPath string) ([]Snapshot, error) {
+
+    // Open snapshot log; no snapshots if not found
+    file, err := os.Open(filepath.Join(dataPath, SnapshotLogFile))
+    if os.IsNotExist(err) {
+        return []Snapshot{}, nil
+    }
+    if err != nil {
+        return []Snapshot{}, fmt.Errorf("Could not open node snapshot log: %w", err)
+    }
+    defer file.Close()
+
+    // Decode snapshots
+    snapshots := []Snapshot{}
+    scanner := bufio.NewScanner(file)
+    scanner.Buffer(make([]byte, 64 * 1024), 16 * 1024 * 1024)
+    for scanner.Scan() {
+        var snapshot Snapshot
+        if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+            continue
+        }
+        snapshots = append(snapshots, snapshot)
+    }
+    if err := scanner.Err(); err != nil {
+        return []Snapshot{}, fmt.Errorf("Could not read node snapshot log: %w", err)
+    }
+
+    // Return
+    return snapshots, nil
+
+}
+
+
+// Get the latest snapshot recorded at or before a time, or the oldest snapshot if none were
+func GetAt(snapshots []Snapshot, t time.Time) (Snapshot, bool) {
+    if len(snapshots) == 0 {
+        return Snapshot{}, false
+    }
+    found := snapshots[0]
+    for _, snapshot := range snapshots {
+        if snapshot.Time.After(t) {
+            break
+        }
+        found = snapshot
+    }
+    return found, true
+}
+
+
+// Get the changes between two snapshots
+func Diff(oldSnapshot, newSnapshot *Snapshot) []Change {
+    changes := []Change{}
+
+    // Node
+    if oldSnapshot.NodeAddress != newSnapshot.NodeAddress {
+        changes = append(changes, Change{Category: "node", Item: "address", Old: oldSnapshot.NodeAddress.Hex(), New: newSnapshot.NodeAddress.Hex()})
+    }
+
+    // Balances
+    changes = appendAmountChange(changes, "balances", "ETH balance", oldSnapshot.Balances.ETH, newSnapshot.Balances.ETH, "ETH")
+    changes = appendAmountChange(changes, "balances", "nETH balance", oldSnapshot.Balances.NETH, newSnapshot.Balances.NETH, "nETH")
+    changes = appendAmountChange(changes, "balances", "collateral", oldSnapshot.Collateral, newSnapshot.Collateral, "ETH")
+
+    // Minipools
+    oldMinipools := make(map[common.Address]Minipool)
+    for _, mp := range oldSnapshot.Minipools {
+        oldMinipools[mp.Address] = mp
+    }
+    newMinipools := make(map[common.Address]Minipool)
+    for _, mp := range newSnapshot.Minipools {
+        newMinipools[mp.Address] = mp
+    }
+    for _, mp := range newSnapshot.Minipools {
+        oldMp, ok := oldMinipools[mp.Address]
+        if !ok {
+            changes = append(changes, Change{Category: "minipools", Item: mp.Address.Hex(), Old: "", New: fmt.Sprintf("created (%s)", mp.Status)})
+            continue
+        }
+        if oldMp.Status != mp.Status {
+            changes = append(changes, Change{Category: "minipools", Item: mp.Address.Hex() + " status", Old: oldMp.Status, New: mp.Status})
+        }
+        changes = appendAmountChange(changes, "minipools", mp.Address.Hex() + " node deposit", oldMp.NodeDepositBalance, mp.NodeDepositBalance, "ETH")
+        changes = appendAmountChange(changes, "minipools", mp.Address.Hex() + " node refund", oldMp.NodeRefundBalance, mp.NodeRefundBalance, "ETH")
+        changes = appendAmountChange(changes, "minipools", mp.Address.Hex() + " user deposit", oldMp.UserDepositBalance, mp.UserDepositBalance, "ETH")
+    }
+    for _, mp := range oldSnapshot.Minipools {
+        if _, ok := newMinipools[mp.Address]; !ok {
+            changes = append(changes, Change{Category: "minipools", Item: mp.Address.Hex(), Old: mp.Status, New: "removed"})
+        }
+    }
+
+    // Settings
+    paths := []string{}
+    for path, value := range oldSnapshot.Settings {
+        if newValue, ok := newSnapshot.Settings[path]; !ok || newValue != value {
+            paths = append(paths, path)
+        }
+    }
+    for path := range newSnapshot.Settings {
+        if _, ok := oldSnapshot.Settings[path]; !ok {
+            paths = append(paths, path)
+        }
+    }
+    sort.Strings(paths)
+    for _, path := range paths {
+        changes = append(changes, Change{Category: "config", Item: path, Old: oldSnapshot.Settings[path], New: newSnapshot.Settings[path]})
+    }
+
+    // Return
+    return changes
+
+}
+
+
+// Append a change to an amount if it changed
+func appendAmountChange(changes []Change, category, item string, oldAmount, newAmount *big.Int, symbol string) []Change {
+    if oldAmount == nil { oldAmount = big.NewInt(0) }
+    if newAmount == nil { newAmount = big.NewInt(0) }
+    if oldAmount.Cmp(newAmount) == 0 {
+        return changes
+    }
+    return append(changes, Change{
+        Category: category,
+        Item: item,
+        Old: units.FormatToken(oldAmount, units.TokenDecimals, symbol),
+        New: units.FormatToken(newAmount, units.TokenDecimals, symbol),
+    })
+}
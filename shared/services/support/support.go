@@ -0,0 +1,178 @@
+package support
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/subtle"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+)
+
+
+// Config
+const (
+    GrantsFile = "support-grants.json"
+    TokenBytes = 32
+    MaxDuration = 7 * 24 * time.Hour
+    FileMode = 0600
+)
+
+
+// Grants file lock
+var lock sync.Mutex
+
+
+// Errors
+var ErrInvalidToken = errors.New("Invalid or expired support token")
+
+
+// Read-only support access grant; only a hash of the token is stored
+type Grant struct {
+    ID string               `json:"id"`
+    TokenHash string        `json:"tokenHash"`
+    Created time.Time       `json:"created"`
+    Expires time.Time       `json:"expires"`
+}
+
+
+// Create a support access grant, returning the grant and its token
+func CreateGrant(dataPath string, duration time.Duration) (Grant, string, error) {
+
+    // Check duration
+    if duration <= 0 || duration > MaxDuration {
+        return Grant{}, "", fmt.Errorf("Support access duration must be positive and at most %s", MaxDuration)
+    }
+
+    // Generate token
+    tokenBytes := make([]byte, TokenBytes)
+    if _, err := rand.Read(tokenBytes); err != nil {
+        return Grant{}, "", fmt.Errorf("Could not generate support token: %w", err)
+    }
+    token := hex.EncodeToString(tokenBytes)
+    tokenHash := hashToken(token)
+
+    // Create grant
+    now := time.Now()
+    grant := Grant{
+        ID: tokenHash[:8],
+        TokenHash: tokenHash,
+        Created: now,
+        Expires: now.Add(duration),
+    }
+
+    // Save grant, removing expired grants
+    lock.Lock()
+    defer lock.Unlock()
+    grants, err := loadGrants(dataPath)
+    if err != nil {
+        return Grant{}, "", err
+    }
+    grants = append(activeGrants(grants), grant)
+    if err := saveGrants(dataPath, grants); err != nil {
+        return Grant{}, "", err
+    }
+
+    // Return
+    return grant, token, nil
+
+}
+
+
+// Revoke all support access grants, returning the number of active grants revoked
+func RevokeGrants(dataPath string) (int, error) {
+    lock.Lock()
+    defer lock.Unlock()
+    grants, err := loadGrants(dataPath)
+    if err != nil {
+        return 0, err
+    }
+    if err := saveGrants(dataPath, []Grant{}); err != nil {
+        return 0, err
+    }
+    return len(activeGrants(grants)), nil
+}
+
+
+// Get the active support access grants
+func GetGrants(dataPath string) ([]Grant, error) {
+    lock.Lock()
+    defer lock.Unlock()
+    grants, err := loadGrants(dataPath)
+    if err != nil {
+        return []Grant{}, err
+    }
+    return activeGrants(grants), nil
+}
+
+
+// Get the active grant for a support token
+func Authorize(dataPath, token string) (Grant, error) {
+    grants, err := GetGrants(dataPath)
+    if err != nil {
+        return Grant{}, err
+    }
+    tokenHash := hashToken(token)
+    for _, grant := range grants {
+        if subtle.ConstantTimeCompare([]byte(grant.TokenHash), []byte(tokenHash)) == 1 {
+            return grant, nil
+        }
+    }
+    return Grant{}, ErrInvalidToken
+}
+
+
+// Filter expired grants
+func activeGrants(grants []Grant) []Grant {
+    active := []Grant{}
+    now := time.Now()
+    for _, grant := range grants {
+        if now.Before(grant.Expires) {
+            active = append(active, grant)
+        }
+    }
+    return active
+}
+
+
+// Hash a support token
+func hashToken(token string) string {
+    hash := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(hash[:])
+}
+
+
+// Load grants from disk
+func loadGrants(dataPath string) ([]Grant, error) {
+    grantBytes, err := ioutil.ReadFile(filepath.Join(dataPath, GrantsFile))
+    if os.IsNotExist(err) {
+        return []Grant{}, nil
+    }
+    if err != nil {
+        return []Grant{}, fmt.Errorf("Could not read support grants: %w", err)
+    }
+    var grants []Grant
+    if err := json.Unmarshal(grantBytes, &grants); err != nil {
+        return []Grant{}, fmt.Errorf("Could not decode support grants: %w", err)
+    }
+    return grants, nil
+}
+
+
+// Save grants to disk
+func saveGrants(dataPath string, grants []Grant) error {
+    grantBytes, err := json.Marshal(grants)
+    if err != nil {
+        return fmt.Errorf("Could not encode support grants: %w", err)
+    }
+    if err := ioutil.WriteFile(filepath.Join(dataPath, GrantsFile), grantBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write support grants: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,121 @@
+package tracing
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "sort"
+    "time"
+)
+
+
+// Config
+const FileMode = 0600
+
+
+// Trace event, in the Chrome trace event format read by chrome://tracing and https://ui.perfetto.dev
+type traceEvent struct {
+    Name string                         `json:"name"`
+    Phase string                        `json:"ph"`
+    Timestamp int64                     `json:"ts,omitempty"`
+    Duration int64                      `json:"dur,omitempty"`
+    ProcessID int                       `json:"pid"`
+    ThreadID int                        `json:"tid"`
+    Args map[string]string              `json:"args,omitempty"`
+}
+type traceFile struct {
+    TraceEvents []traceEvent            `json:"traceEvents"`
+    DisplayTimeUnit string              `json:"displayTimeUnit"`
+}
+
+
+// Export spans to a trace file which can be opened in a local trace viewer
+func Export(path string, spans []Span) error {
+
+    // Sort spans by start time, with enclosing spans first
+    sorted := append([]Span{}, spans...)
+    sort.SliceStable(sorted, func(i, j int) bool {
+        if !sorted[i].Start.Equal(sorted[j].Start) {
+            return sorted[i].Start.Before(sorted[j].Start)
+        }
+        return sorted[i].Duration > sorted[j].Duration
+    })
+
+    // Create events; spans are grouped by process, and concurrent spans are placed in separate lanes
+    events := []traceEvent{}
+    processIDs := make(map[string]int)
+    lanes := make(map[string][][]Span)
+    for _, span := range sorted {
+        pid, ok := processIDs[span.Process]
+        if !ok {
+            pid = len(processIDs) + 1
+            processIDs[span.Process] = pid
+            events = append(events, traceEvent{
+                Name: "process_name",
+                Phase: "M",
+                ProcessID: pid,
+                Args: map[string]string{"name": span.Process},
+            })
+        }
+        args := make(map[string]string)
+        for key, value := range span.Attributes {
+            args[key] = value
+        }
+        if span.Error != "" {
+            args["error"] = span.Error
+        }
+        events = append(events, traceEvent{
+            Name: span.Name,
+            Phase: "X",
+            Timestamp: span.Start.UnixNano() / int64(time.Microsecond),
+            Duration: int64(span.Duration / time.Microsecond),
+            ProcessID: pid,
+            ThreadID: getLane(lanes, span),
+            Args: args,
+        })
+    }
+
+    // Encode & write trace file
+    traceBytes, err := json.Marshal(traceFile{
+        TraceEvents: events,
+        DisplayTimeUnit: "ms",
+    })
+    if err != nil {
+        return fmt.Errorf("Could not encode trace: %w", err)
+    }
+    if err := ioutil.WriteFile(path, traceBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write trace file: %w", err)
+    }
+    return nil
+
+}
+
+
+// Get the first lane in a span's process which it can be placed in; spans in a lane must be nested or sequential
+func getLane(lanes map[string][][]Span, span Span) int {
+    processLanes := lanes[span.Process]
+    for li, lane := range processLanes {
+
+        // Remove spans which ended before this span started
+        for len(lane) > 0 && !getEnd(lane[len(lane) - 1]).After(span.Start) {
+            lane = lane[:len(lane) - 1]
+        }
+
+        // Place span in lane if it is empty or the span is nested within the innermost open span
+        if len(lane) == 0 || !getEnd(span).After(getEnd(lane[len(lane) - 1])) {
+            processLanes[li] = append(lane, span)
+            return li + 1
+        }
+        processLanes[li] = lane
+
+    }
+    lanes[span.Process] = append(processLanes, []Span{span})
+    return len(processLanes) + 1
+}
+
+
+// Get a span's end time
+func getEnd(span Span) time.Time {
+    return span.Start.Add(span.Duration)
+}
+
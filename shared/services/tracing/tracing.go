@@ -0,0 +1,166 @@
+package tracing
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "sync"
+    "time"
+)
+
+
+// A completed span, recording the time taken by one step of a traced command
+type Span struct {
+    Name string                         `json:"name"`
+    Process string                      `json:"process"`
+    Start time.Time                     `json:"start"`
+    Duration time.Duration              `json:"duration"`
+    Attributes map[string]string        `json:"attributes,omitempty"`
+    Error string                        `json:"error,omitempty"`
+}
+
+
+// An in-progress span
+type ActiveSpan struct {
+    name string
+    start time.Time
+    attributes map[string]string
+}
+
+
+// Trace state for the current process; tracing is disabled unless a trace is started
+var (
+    traceID string
+    process string
+    root *ActiveSpan
+    spans []Span
+    lock sync.Mutex
+)
+
+
+// Generate a new random trace ID
+func NewTraceID() string {
+    bytes := make([]byte, 16)
+    rand.Read(bytes)
+    return hex.EncodeToString(bytes)
+}
+
+
+// Start recording a trace in this process, with a root span covering the whole command
+func Start(id, processName, name string, attributes ...string) {
+    lock.Lock()
+    defer lock.Unlock()
+    traceID = id
+    process = processName
+    spans = []Span{}
+    root = newSpan(name, attributes)
+}
+
+
+// Check whether a trace is being recorded
+func Enabled() bool {
+    lock.Lock()
+    defer lock.Unlock()
+    return traceID != ""
+}
+
+
+// Get the ID of the trace being recorded, to pass on to other processes
+func TraceID() string {
+    lock.Lock()
+    defer lock.Unlock()
+    return traceID
+}
+
+
+// Start a span; attributes are given as key/value pairs
+// Returns nil if no trace is being recorded, which is safe to end
+func StartSpan(name string, attributes ...string) *ActiveSpan {
+    if !Enabled() {
+        return nil
+    }
+    return newSpan(name, attributes)
+}
+
+
+// Set a span attribute
+func (s *ActiveSpan) SetAttribute(key, value string) {
+    if s == nil {
+        return
+    }
+    s.attributes[key] = value
+}
+
+
+// End a span and record it, with the error the step failed with if any
+func (s *ActiveSpan) End(err error) {
+    if s == nil {
+        return
+    }
+    lock.Lock()
+    defer lock.Unlock()
+    if traceID == "" {
+        return
+    }
+    spans = append(spans, s.complete(err))
+}
+
+
+// Add spans recorded by another process
+func Add(remoteSpans []Span) {
+    lock.Lock()
+    defer lock.Unlock()
+    if traceID == "" {
+        return
+    }
+    spans = append(spans, remoteSpans...)
+}
+
+
+// End the root span and stop recording, returning all recorded spans
+// Returns nil if no trace is being recorded
+func Finish(err error) []Span {
+    lock.Lock()
+    defer lock.Unlock()
+    if traceID == "" {
+        return nil
+    }
+    recorded := append(spans, root.complete(err))
+    traceID = ""
+    root = nil
+    spans = nil
+    return recorded
+}
+
+
+// Create a span from key/value attribute pairs
+func newSpan(name string, attributes []string) *ActiveSpan {
+    span := &ActiveSpan{
+        name: name,
+        start: time.Now(),
+        attributes: make(map[string]string),
+    }
+    for ai := 0; ai + 1 < len(attributes); ai += 2 {
+        span.attributes[attributes[ai]] = attributes[ai + 1]
+    }
+    return span
+}
+
+
+// Complete a span
+func (s *ActiveSpan) complete(err error) Span {
+    span := Span{
+        Name: s.name,
+        Process: process,
+        Start: s.start,
+        Duration: time.Since(s.start),
+        Attributes: s.attributes,
+    }
+    if len(span.Attributes) == 0 {
+        span.Attributes = nil
+    }
+    if err != nil {
+        span.Error = err.Error()
+    }
+    return span
+}
+
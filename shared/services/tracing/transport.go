@@ -0,0 +1,66 @@
+package tracing
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+)
+
+
+// JSON-RPC request, decoded to name RPC spans
+type rpcRequest struct {
+    Method string                       `json:"method"`
+}
+
+
+// HTTP transport which records a span for each JSON-RPC request
+type Transport struct {
+    Base http.RoundTripper
+}
+
+
+// Create a new JSON-RPC tracing transport
+func NewTransport(base http.RoundTripper) *Transport {
+    return &Transport{Base: base}
+}
+
+
+// Send a request, recording a span named after its RPC method
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+    // Check if tracing is enabled
+    if !Enabled() || request.Body == nil {
+        return t.Base.RoundTrip(request)
+    }
+
+    // Read request body
+    body, err := ioutil.ReadAll(request.Body)
+    request.Body.Close()
+    if err != nil {
+        return nil, err
+    }
+    request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+    // Get span name
+    name := "rpc"
+    var single rpcRequest
+    var batch []rpcRequest
+    if err := json.Unmarshal(body, &single); err == nil && single.Method != "" {
+        name = "rpc " + single.Method
+    } else if err := json.Unmarshal(body, &batch); err == nil {
+        name = fmt.Sprintf("rpc batch (%d)", len(batch))
+    }
+
+    // Send request
+    span := StartSpan(name, "host", request.URL.Host)
+    response, err := t.Base.RoundTrip(request)
+    if err == nil && (response.StatusCode < 200 || response.StatusCode >= 300) {
+        span.SetAttribute("status", response.Status)
+    }
+    span.End(err)
+    return response, err
+
+}
+
@@ -0,0 +1,34 @@
+package transactions
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "syscall"
+)
+
+
+// Take an exclusive lock on the transaction queue lock file, blocking until it is available
+// The lock is held by the open file, so it is released if the process exits without unlocking
+func lockQueueFile(dataPath string) (func(), error) {
+    if err := os.MkdirAll(dataPath, DirMode); err != nil {
+        return nil, fmt.Errorf("Could not create transaction queue folder: %w", err)
+    }
+    file, err := os.OpenFile(filepath.Join(dataPath, QueueLockFile), os.O_CREATE | os.O_RDWR, FileMode)
+    if err != nil {
+        return nil, fmt.Errorf("Could not open transaction queue lock: %w", err)
+    }
+    for {
+        err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+        if err != syscall.EINTR { break }
+    }
+    if err != nil {
+        file.Close()
+        return nil, fmt.Errorf("Could not lock transaction queue: %w", err)
+    }
+    return func() {
+        syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+        file.Close()
+    }, nil
+}
+
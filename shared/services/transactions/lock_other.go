@@ -0,0 +1,11 @@
+// +build !linux
+
+package transactions
+
+
+// Take an exclusive lock on the transaction queue lock file
+// File locks are only supported on linux, where the smart node runs; elsewhere the queue is only locked within the process
+func lockQueueFile(dataPath string) (func(), error) {
+    return func() {}, nil
+}
+
@@ -0,0 +1,387 @@
+package transactions
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/utils/safemath"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Config
+const (
+    GasPriceBumpPercent = 125 // Replacements must increase the gas price by at least 10% to be accepted
+    CancelGasLimit = 21000
+)
+var (
+    receiptPollInterval, _ = time.ParseDuration("5s")
+    stuckTransactionTimeout, _ = time.ParseDuration("10m")
+)
+
+
+// Error returned from the signer to capture a signed transaction before a contract binding sends it
+var errTransactionCaptured = errors.New("Transaction captured")
+
+
+// Transaction manager
+// Serializes node account transactions, assigns nonces, persists pending transactions to the data folder,
+// and replaces stuck transactions with higher gas prices
+type Manager struct {
+    ec *ethclient.Client
+    w *wallet.Wallet
+    dataPath string
+    maxFeeGwei float64
+    offline bool
+    dryRun *simulator
+    queueLock sync.Mutex
+}
+
+
+// Create new transaction manager
+func NewManager(ec *ethclient.Client, w *wallet.Wallet, dataPath string, maxFeeGwei float64) *Manager {
+    return &Manager{
+        ec: ec,
+        w: w,
+        dataPath: dataPath,
+        maxFeeGwei: maxFeeGwei,
+    }
+}
+
+
+// Make a transaction and wait for it to be mined
+// send is called with the transactor to submit the transaction via a contract binding, e.g.:
+//     m.Transact(opts, "Stake minipool", func(opts *bind.TransactOpts) error { _, err := mp.Stake(..., opts); return err })
+func (m *Manager) Transact(opts *bind.TransactOpts, description string, send func(opts *bind.TransactOpts) error) (*types.Receipt, error) {
+
+    // Send transaction
+    pt, err := m.Send(opts, description, send)
+    if err != nil {
+        return nil, err
+    }
+
+    // Wait for transaction to be mined
+    return m.WaitForTransaction(pt.From, pt.Nonce)
+
+}
+
+
+// Sign & broadcast a transaction with the next nonce, recording it in the pending transaction queue
+// The queue is locked from nonce assignment until the transaction is broadcast, so processes sharing the data folder
+// do not assign the same nonce; the transaction is recorded before it is broadcast
+// Use WaitForTransaction to wait for the transaction to be mined
+// In offline mode, the transaction is returned unsigned in an *api.OfflineTransactionError instead, and in dry-run mode
+// it is simulated and returned in an *api.DryRunError
+func (m *Manager) Send(opts *bind.TransactOpts, description string, send func(opts *bind.TransactOpts) error) (PendingTransaction, error) {
+
+    // Lock queue
+    unlock, err := m.lockQueue()
+    if err != nil {
+        return PendingTransaction{}, err
+    }
+    defer unlock()
+
+    // Get nonce
+    queue, err := m.loadQueue()
+    if err != nil {
+        return PendingTransaction{}, err
+    }
+    nonce, err := m.getNextNonce(opts.From, queue)
+    if err != nil {
+        return PendingTransaction{}, err
+    }
+
+    // Simulate transaction
+    if m.dryRun != nil {
+        return PendingTransaction{}, m.simulate(opts, nonce, description, send)
+    }
+
+    // Prepare transaction for offline signing
+    if m.offline {
+        return PendingTransaction{}, m.prepareOffline(opts, nonce, description, send)
+    }
+
+    // Capture signed transaction
+    var signedTx *types.Transaction
+    signer := opts.Signer
+    captureOpts := *opts
+    captureOpts.Nonce = new(big.Int).SetUint64(nonce)
+    captureOpts.Signer = func(s types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+        var err error
+        signedTx, err = signer(s, address, tx)
+        if err != nil {
+            return nil, err
+        }
+        return nil, errTransactionCaptured
+    }
+    if err := send(&captureOpts); signedTx == nil {
+        if err == nil {
+            err = errors.New("The transaction was not signed")
+        }
+        return PendingTransaction{}, err
+    }
+
+    // Record & broadcast transaction
+    pt := newPendingTransaction(description, opts.From, signedTx)
+    if err := m.saveQueue(withPending(queue, pt)); err != nil {
+        return PendingTransaction{}, err
+    }
+    if err := m.ec.SendTransaction(context.Background(), signedTx); err != nil {
+        m.saveQueue(queue)
+        return PendingTransaction{}, err
+    }
+
+    // Return
+    return pt, nil
+
+}
+
+
+// Wait for a pending transaction or any of its replacements to be mined
+func (m *Manager) WaitForTransaction(from common.Address, nonce uint64) (*types.Receipt, error) {
+    span := tracing.StartSpan("wait for transaction", "from", from.Hex(), "nonce", fmt.Sprint(nonce))
+    receipt, err := m.waitForTransaction(from, nonce)
+    if receipt != nil {
+        span.SetAttribute("hash", receipt.TxHash.Hex())
+        span.SetAttribute("block", receipt.BlockNumber.String())
+    }
+    span.End(err)
+    return receipt, err
+}
+func (m *Manager) waitForTransaction(from common.Address, nonce uint64) (*types.Receipt, error) {
+    for {
+
+        // Get pending transaction; reloaded on each poll as it may be replaced by another process
+        pt, ok, err := m.getPending(from, nonce)
+        if err != nil {
+            return nil, err
+        }
+        if !ok {
+            return nil, fmt.Errorf("Transaction with nonce %d is no longer pending", nonce)
+        }
+
+        // Check for a receipt
+        receipt, err := m.getReceipt(pt)
+        if err != nil {
+            return nil, err
+        }
+        if receipt != nil {
+            m.removePending(from, nonce)
+            if pt.Cancelled {
+                return receipt, errors.New("The transaction was cancelled")
+            }
+            if receipt.Status == 0 {
+                return receipt, errors.New("Transaction failed with status 0")
+            }
+            return receipt, nil
+        }
+
+        // Check whether the nonce has been used by a transaction outside the queue
+        accountNonce, err := m.ec.NonceAt(context.Background(), from, nil)
+        if err != nil {
+            return nil, err
+        }
+        if accountNonce > nonce {
+            receipt, err := m.getReceipt(pt)
+            if err != nil {
+                return nil, err
+            }
+            if receipt != nil {
+                continue
+            }
+            m.removePending(from, nonce)
+            return nil, fmt.Errorf("Transaction with nonce %d was replaced by another transaction", nonce)
+        }
+
+        // Wait
+        time.Sleep(receiptPollInterval)
+
+    }
+}
+
+
+// Replace pending transactions which have not been mined within the timeout with higher gas prices
+// Transactions are not replaced if the gas price would exceed the maximum fee
+func (m *Manager) ReplaceStuckTransactions() ([]PendingTransaction, error) {
+
+    // Get pending transactions
+    queue, err := m.getQueue()
+    if err != nil {
+        return []PendingTransaction{}, err
+    }
+
+    // Replace stuck transactions
+    replaced := []PendingTransaction{}
+    for _, pt := range queue {
+        if time.Since(pt.LastBroadcast) < stuckTransactionTimeout {
+            continue
+        }
+        if receipt, err := m.getReceipt(pt); err != nil || receipt != nil {
+            continue
+        }
+        updated, err := m.replace(pt)
+        if err != nil {
+            return replaced, err
+        }
+        if updated != nil {
+            replaced = append(replaced, *updated)
+        }
+    }
+
+    // Return
+    return replaced, nil
+
+}
+
+
+// Cancel a pending transaction by replacing it with an empty transfer to the sender at a higher gas price
+func (m *Manager) CancelTransaction(from common.Address, nonce uint64) (common.Hash, error) {
+    pt, ok, err := m.getPending(from, nonce)
+    if err != nil {
+        return common.Hash{}, err
+    }
+    if !ok {
+        return common.Hash{}, fmt.Errorf("No pending transaction with nonce %d was found", nonce)
+    }
+    pt.Cancelled = true
+    pt.To = &pt.From
+    pt.Value = big.NewInt(0)
+    pt.GasLimit = CancelGasLimit
+    pt.Data = []byte{}
+    updated, err := m.replace(pt)
+    if err != nil {
+        return common.Hash{}, err
+    }
+    if updated == nil {
+        return common.Hash{}, fmt.Errorf("Could not cancel the transaction without exceeding the maximum fee of %s", units.FormatGwei(eth.GweiToWei(m.maxFeeGwei)))
+    }
+    return updated.LatestHash(), nil
+}
+
+
+// Get pending transactions, removing any which have been mined
+func (m *Manager) GetPendingTransactions() ([]PendingTransaction, error) {
+
+    // Get pending transactions
+    queue, err := m.getQueue()
+    if err != nil {
+        return []PendingTransaction{}, err
+    }
+
+    // Filter mined transactions
+    pending := []PendingTransaction{}
+    for _, pt := range queue {
+        accountNonce, err := m.ec.NonceAt(context.Background(), pt.From, nil)
+        if err != nil {
+            return []PendingTransaction{}, err
+        }
+        if accountNonce > pt.Nonce {
+            m.removePending(pt.From, pt.Nonce)
+            continue
+        }
+        pending = append(pending, pt)
+    }
+
+    // Return
+    return pending, nil
+
+}
+
+
+// Re-sign & broadcast a pending transaction with a bumped gas price
+// Returns nil if the bumped gas price would exceed the maximum fee
+func (m *Manager) replace(pt PendingTransaction) (*PendingTransaction, error) {
+
+    // Get bumped gas price
+    gasPrice, err := safemath.Percent(pt.GasPrice, GasPriceBumpPercent)
+    if err != nil {
+        return nil, fmt.Errorf("Could not calculate replacement gas price: %w", err)
+    }
+    if m.maxFeeGwei > 0 && gasPrice.Cmp(eth.GweiToWei(m.maxFeeGwei)) > 0 {
+        return nil, nil
+    }
+
+    // Sign replacement transaction
+    opts, err := m.w.GetNodeAccountTransactor()
+    if err != nil {
+        return nil, err
+    }
+    var tx *types.Transaction
+    if pt.To == nil {
+        tx = types.NewContractCreation(pt.Nonce, pt.Value, pt.GasLimit, gasPrice, pt.Data)
+    } else {
+        tx = types.NewTransaction(pt.Nonce, *pt.To, pt.Value, pt.GasLimit, gasPrice, pt.Data)
+    }
+    signedTx, err := opts.Signer(types.HomesteadSigner{}, pt.From, tx)
+    if err != nil {
+        return nil, err
+    }
+
+    // Broadcast & record replacement
+    unlock, err := m.lockQueue()
+    if err != nil {
+        return nil, err
+    }
+    defer unlock()
+    queue, err := m.loadQueue()
+    if err != nil {
+        return nil, err
+    }
+    if err := m.ec.SendTransaction(context.Background(), signedTx); err != nil {
+        return nil, fmt.Errorf("Could not replace transaction with nonce %d: %w", pt.Nonce, err)
+    }
+    pt.GasPrice = gasPrice
+    pt.Hashes = append(pt.Hashes, signedTx.Hash())
+    pt.LastBroadcast = time.Now()
+    if err := m.saveQueue(withPending(queue, pt)); err != nil {
+        return nil, err
+    }
+
+    // Return
+    return &pt, nil
+
+}
+
+
+// Get the receipt for any broadcast of a pending transaction
+func (m *Manager) getReceipt(pt PendingTransaction) (*types.Receipt, error) {
+    for _, hash := range pt.Hashes {
+        receipt, err := m.ec.TransactionReceipt(context.Background(), hash)
+        if err == ethereum.NotFound {
+            continue
+        }
+        if err != nil {
+            return nil, err
+        }
+        return receipt, nil
+    }
+    return nil, nil
+}
+
+
+// Get the next nonce for an account, accounting for queued transactions not yet seen by the client
+func (m *Manager) getNextNonce(from common.Address, queue []PendingTransaction) (uint64, error) {
+    nonce, err := m.ec.PendingNonceAt(context.Background(), from)
+    if err != nil {
+        return 0, err
+    }
+    for _, pt := range queue {
+        if pt.From == from && pt.Nonce >= nonce {
+            nonce = pt.Nonce + 1
+        }
+    }
+    return nonce, nil
+}
@@ -0,0 +1,294 @@
+package transactions
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "math/big"
+    "os"
+    "path/filepath"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/rlp"
+
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Config
+const OfflineReservationFile = "tx-offline.json"
+var (
+    offlineReservationTimeout, _ = time.ParseDuration("1h")
+    broadcastTimeout, _ = time.ParseDuration("10m")
+)
+
+
+// The nonce of the last transaction prepared for offline signing
+// Prepared transactions are not recorded in the pending transaction queue until they are broadcast, so the nonce is
+// reserved to prevent another transaction being prepared with it in the meantime
+type offlineReservation struct {
+    Description string          `json:"description"`
+    From common.Address         `json:"from"`
+    Nonce uint64                `json:"nonce"`
+    Created time.Time           `json:"created"`
+}
+
+
+// Set whether transactions are prepared for offline signing instead of being signed & sent
+// In offline mode, Send and Transact return an *api.OfflineTransactionError holding the unsigned transaction
+func (m *Manager) SetOffline(offline bool) {
+    m.offline = offline
+}
+
+
+// Prepare a transaction for signing on an offline machine
+// The transaction is built by the contract binding, which estimates its gas, but is not signed or sent
+// Only one transaction may be prepared at a time; another cannot be prepared until it is broadcast, or its reservation expires
+// The queue must be locked
+func (m *Manager) prepareOffline(opts *bind.TransactOpts, nonce uint64, description string, send func(opts *bind.TransactOpts) error) error {
+
+    // Check for an earlier transaction which has not been broadcast
+    reservation, err := m.loadOfflineReservation()
+    if err != nil {
+        return err
+    }
+    if reservation != nil && reservation.From == opts.From && reservation.Nonce >= nonce && time.Since(reservation.Created) < offlineReservationTimeout {
+        return fmt.Errorf("The transaction '%s' with nonce %d was prepared for offline signing and has not been broadcast; broadcast it with 'rocketpool tx broadcast' before preparing another transaction, or wait until %s to prepare a replacement", reservation.Description, reservation.Nonce, reservation.Created.Add(offlineReservationTimeout).Format(time.RFC3339))
+    }
+
+    // Get chain ID
+    chainID, err := m.ec.ChainID(context.Background())
+    if err != nil {
+        return fmt.Errorf("Could not get chain ID: %w", err)
+    }
+
+    // Build transaction
+    unsignedTx, err := captureUnsignedTransaction(opts, nonce, send)
+    if err != nil {
+        return err
+    }
+
+    // Reserve nonce
+    if err := m.saveOfflineReservation(offlineReservation{
+        Description: description,
+        From: opts.From,
+        Nonce: unsignedTx.Nonce(),
+        Created: time.Now(),
+    }); err != nil {
+        return err
+    }
+
+    // Return unsigned transaction
+    return &api.OfflineTransactionError{Transaction: api.UnsignedTransaction{
+        Description: description,
+        ChainID: chainID,
+        From: opts.From,
+        To: unsignedTx.To(),
+        Nonce: unsignedTx.Nonce(),
+        Value: unsignedTx.Value(),
+        GasLimit: unsignedTx.Gas(),
+        GasPrice: unsignedTx.GasPrice(),
+        Data: unsignedTx.Data(),
+    }}
+
+}
+
+
+// Load the offline transaction reservation; returns nil if there is none
+// The queue must be locked
+func (m *Manager) loadOfflineReservation() (*offlineReservation, error) {
+    reservationBytes, err := ioutil.ReadFile(filepath.Join(m.dataPath, OfflineReservationFile))
+    if os.IsNotExist(err) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("Could not read offline transaction reservation: %w", err)
+    }
+    var reservation offlineReservation
+    if err := json.Unmarshal(reservationBytes, &reservation); err != nil {
+        return nil, fmt.Errorf("Could not decode offline transaction reservation: %w", err)
+    }
+    return &reservation, nil
+}
+
+
+// Save the offline transaction reservation
+// The queue must be locked
+func (m *Manager) saveOfflineReservation(reservation offlineReservation) error {
+    reservationBytes, err := json.Marshal(reservation)
+    if err != nil {
+        return fmt.Errorf("Could not encode offline transaction reservation: %w", err)
+    }
+    path := filepath.Join(m.dataPath, OfflineReservationFile)
+    if err := ioutil.WriteFile(path + ".tmp", reservationBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write offline transaction reservation: %w", err)
+    }
+    if err := os.Rename(path + ".tmp", path); err != nil {
+        return fmt.Errorf("Could not write offline transaction reservation: %w", err)
+    }
+    return nil
+}
+
+
+// Build a transaction with a contract binding without signing it
+// The binding estimates the transaction's gas, so transactions which would revert fail here
+func captureUnsignedTransaction(opts *bind.TransactOpts, nonce uint64, send func(opts *bind.TransactOpts) error) (*types.Transaction, error) {
+    var unsignedTx *types.Transaction
+    captureOpts := *opts
+    captureOpts.Nonce = new(big.Int).SetUint64(nonce)
+    captureOpts.Signer = func(s types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+        unsignedTx = tx
+        return nil, errTransactionCaptured
+    }
+    if err := send(&captureOpts); unsignedTx == nil {
+        if err == nil {
+            err = errors.New("The transaction was not built")
+        }
+        return nil, err
+    }
+    return unsignedTx, nil
+}
+
+
+// Sign a transaction prepared for offline signing with the node account
+// The transaction is signed with EIP-155 replay protection for the chain it was prepared on
+func SignOfflineTransaction(w *wallet.Wallet, utx api.UnsignedTransaction) (api.SignedTransaction, error) {
+
+    // Check transaction
+    if utx.ChainID == nil || utx.ChainID.Sign() <= 0 {
+        return api.SignedTransaction{}, errors.New("The transaction does not have a valid chain ID")
+    }
+    if utx.Value == nil || utx.GasPrice == nil {
+        return api.SignedTransaction{}, errors.New("The transaction does not have a value and gas price")
+    }
+
+    // Get node account transactor
+    opts, err := w.GetNodeAccountTransactor()
+    if err != nil {
+        return api.SignedTransaction{}, err
+    }
+    if opts.From != utx.From {
+        return api.SignedTransaction{}, fmt.Errorf("The transaction is from %s, not the node account %s", utx.From.Hex(), opts.From.Hex())
+    }
+
+    // Sign transaction
+    var tx *types.Transaction
+    if utx.To == nil {
+        tx = types.NewContractCreation(utx.Nonce, utx.Value, utx.GasLimit, utx.GasPrice, utx.Data)
+    } else {
+        tx = types.NewTransaction(utx.Nonce, *utx.To, utx.Value, utx.GasLimit, utx.GasPrice, utx.Data)
+    }
+    signedTx, err := opts.Signer(types.NewEIP155Signer(utx.ChainID), utx.From, tx)
+    if err != nil {
+        return api.SignedTransaction{}, fmt.Errorf("Could not sign transaction: %w", err)
+    }
+    rawTx, err := rlp.EncodeToBytes(signedTx)
+    if err != nil {
+        return api.SignedTransaction{}, fmt.Errorf("Could not encode signed transaction: %w", err)
+    }
+
+    // Return
+    return api.SignedTransaction{
+        Description: utx.Description,
+        ChainID: utx.ChainID,
+        From: utx.From,
+        Nonce: utx.Nonce,
+        Hash: signedTx.Hash(),
+        RawTransaction: rawTx,
+    }, nil
+
+}
+
+
+// Broadcast a transaction signed offline and wait for it to be mined
+// The transaction must be signed by its sender for the connected chain; it is not recorded in the pending transaction
+// queue, as it cannot be re-signed to replace it if it gets stuck
+// Waiting fails if the transaction's nonce is used by another transaction, or it is not mined within the timeout
+func (m *Manager) BroadcastSignedTransaction(stx api.SignedTransaction) (*types.Receipt, error) {
+
+    // Decode transaction
+    signedTx := new(types.Transaction)
+    if err := rlp.DecodeBytes(stx.RawTransaction, signedTx); err != nil {
+        return nil, fmt.Errorf("Could not decode signed transaction: %w", err)
+    }
+    if signedTx.Hash() != stx.Hash {
+        return nil, fmt.Errorf("The signed transaction hash %s does not match its recorded hash %s", signedTx.Hash().Hex(), stx.Hash.Hex())
+    }
+
+    // Check chain & sender
+    chainID, err := m.ec.ChainID(context.Background())
+    if err != nil {
+        return nil, fmt.Errorf("Could not get chain ID: %w", err)
+    }
+    if signedTx.ChainId().Cmp(chainID) != 0 {
+        return nil, fmt.Errorf("The transaction was signed for chain ID %s, but the Eth 1.0 client is on chain ID %s", signedTx.ChainId().String(), chainID.String())
+    }
+    sender, err := types.Sender(types.NewEIP155Signer(chainID), signedTx)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get transaction sender: %w", err)
+    }
+    if sender != stx.From {
+        return nil, fmt.Errorf("The transaction was signed by %s, not its sender %s", sender.Hex(), stx.From.Hex())
+    }
+
+    // Check nonce
+    accountNonce, err := m.ec.NonceAt(context.Background(), sender, nil)
+    if err != nil {
+        return nil, err
+    }
+    if accountNonce > signedTx.Nonce() {
+        return nil, fmt.Errorf("The transaction nonce %d has already been used; prepare and sign the transaction again", signedTx.Nonce())
+    }
+
+    // Broadcast transaction
+    if err := m.ec.SendTransaction(context.Background(), signedTx); err != nil {
+        return nil, err
+    }
+
+    // Wait for transaction to be mined
+    deadline := time.Now().Add(broadcastTimeout)
+    for {
+
+        // Check for a receipt
+        receipt, err := m.ec.TransactionReceipt(context.Background(), signedTx.Hash())
+        if err == nil {
+            if receipt.Status == 0 {
+                return receipt, errors.New("Transaction failed with status 0")
+            }
+            return receipt, nil
+        }
+        if err != ethereum.NotFound {
+            return nil, err
+        }
+
+        // Check whether the nonce has been used by another transaction
+        accountNonce, err := m.ec.NonceAt(context.Background(), sender, nil)
+        if err != nil {
+            return nil, err
+        }
+        if accountNonce > signedTx.Nonce() {
+            if _, err := m.ec.TransactionReceipt(context.Background(), signedTx.Hash()); err == nil {
+                continue
+            } else if err != ethereum.NotFound {
+                return nil, err
+            }
+            return nil, fmt.Errorf("The transaction with nonce %d was replaced by another transaction", signedTx.Nonce())
+        }
+
+        // Check timeout & wait
+        if time.Now().After(deadline) {
+            return nil, fmt.Errorf("The transaction %s was broadcast but has not been mined after %s; it may still be mined later", signedTx.Hash().Hex(), broadcastTimeout)
+        }
+        time.Sleep(receiptPollInterval)
+
+    }
+
+}
+
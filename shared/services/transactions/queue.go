@@ -0,0 +1,199 @@
+package transactions
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "math/big"
+    "os"
+    "path/filepath"
+    "sort"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/core/types"
+)
+
+
+// Config
+const (
+    QueueFile = "tx-queue.json"
+    QueueLockFile = "tx-queue.lock"
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// A transaction which has been broadcast but not yet mined
+type PendingTransaction struct {
+    Description string          `json:"description"`
+    From common.Address         `json:"from"`
+    Nonce uint64                `json:"nonce"`
+    To *common.Address          `json:"to"`
+    Value *big.Int              `json:"value"`
+    GasLimit uint64             `json:"gasLimit"`
+    GasPrice *big.Int           `json:"gasPrice"`
+    Data hexutil.Bytes          `json:"data"`
+    Hashes []common.Hash        `json:"hashes"`
+    Created time.Time           `json:"created"`
+    LastBroadcast time.Time     `json:"lastBroadcast"`
+    Cancelled bool              `json:"cancelled"`
+}
+
+
+// Create a pending transaction from a signed transaction
+func newPendingTransaction(description string, from common.Address, tx *types.Transaction) PendingTransaction {
+    return PendingTransaction{
+        Description: description,
+        From: from,
+        Nonce: tx.Nonce(),
+        To: tx.To(),
+        Value: tx.Value(),
+        GasLimit: tx.Gas(),
+        GasPrice: tx.GasPrice(),
+        Data: tx.Data(),
+        Hashes: []common.Hash{tx.Hash()},
+        Created: time.Now(),
+        LastBroadcast: time.Now(),
+    }
+}
+
+
+// Get the hash of the latest broadcast of a pending transaction
+func (pt PendingTransaction) LatestHash() common.Hash {
+    return pt.Hashes[len(pt.Hashes) - 1]
+}
+
+
+// Lock the pending transaction queue, within the process and across processes sharing the data folder
+// The returned function releases the lock
+func (m *Manager) lockQueue() (func(), error) {
+    m.queueLock.Lock()
+    unlockFile, err := lockQueueFile(m.dataPath)
+    if err != nil {
+        m.queueLock.Unlock()
+        return nil, err
+    }
+    return func() {
+        unlockFile()
+        m.queueLock.Unlock()
+    }, nil
+}
+
+
+// Load pending transactions, ordered by nonce
+// The queue must be locked
+func (m *Manager) loadQueue() ([]PendingTransaction, error) {
+    queue := []PendingTransaction{}
+    queueBytes, err := ioutil.ReadFile(filepath.Join(m.dataPath, QueueFile))
+    if os.IsNotExist(err) {
+        return queue, nil
+    }
+    if err != nil {
+        return []PendingTransaction{}, fmt.Errorf("Could not read transaction queue: %w", err)
+    }
+    if err := json.Unmarshal(queueBytes, &queue); err != nil {
+        return []PendingTransaction{}, fmt.Errorf("Could not decode transaction queue: %w", err)
+    }
+    sort.Slice(queue, func(i, j int) bool { return queue[i].Nonce < queue[j].Nonce })
+    return queue, nil
+}
+
+
+// Save pending transactions
+// The queue must be locked; it is written to a temporary file first so it is never left partially written
+func (m *Manager) saveQueue(queue []PendingTransaction) error {
+    queueBytes, err := json.Marshal(queue)
+    if err != nil {
+        return fmt.Errorf("Could not encode transaction queue: %w", err)
+    }
+    path := filepath.Join(m.dataPath, QueueFile)
+    if err := ioutil.WriteFile(path + ".tmp", queueBytes, FileMode); err != nil {
+        return fmt.Errorf("Could not write transaction queue: %w", err)
+    }
+    if err := os.Rename(path + ".tmp", path); err != nil {
+        return fmt.Errorf("Could not write transaction queue: %w", err)
+    }
+    return nil
+}
+
+
+// Get pending transactions, ordered by nonce
+func (m *Manager) getQueue() ([]PendingTransaction, error) {
+    unlock, err := m.lockQueue()
+    if err != nil {
+        return []PendingTransaction{}, err
+    }
+    defer unlock()
+    return m.loadQueue()
+}
+
+
+// Update the queue with a function
+func (m *Manager) updateQueue(update func([]PendingTransaction) []PendingTransaction) error {
+    unlock, err := m.lockQueue()
+    if err != nil {
+        return err
+    }
+    defer unlock()
+    queue, err := m.loadQueue()
+    if err != nil {
+        return err
+    }
+    return m.saveQueue(update(queue))
+}
+
+
+// Get a pending transaction by nonce
+func (m *Manager) getPending(from common.Address, nonce uint64) (PendingTransaction, bool, error) {
+    unlock, err := m.lockQueue()
+    if err != nil {
+        return PendingTransaction{}, false, err
+    }
+    defer unlock()
+    queue, err := m.loadQueue()
+    if err != nil {
+        return PendingTransaction{}, false, err
+    }
+    for _, pt := range queue {
+        if pt.From == from && pt.Nonce == nonce {
+            return pt, true, nil
+        }
+    }
+    return PendingTransaction{}, false, nil
+}
+
+
+// Add or replace a pending transaction
+func (m *Manager) putPending(pt PendingTransaction) error {
+    return m.updateQueue(func(queue []PendingTransaction) []PendingTransaction {
+        return withPending(queue, pt)
+    })
+}
+
+
+// Remove a pending transaction
+func (m *Manager) removePending(from common.Address, nonce uint64) error {
+    return m.updateQueue(func(queue []PendingTransaction) []PendingTransaction {
+        return withoutPending(queue, from, nonce)
+    })
+}
+
+
+// Get a queue with a pending transaction added or replaced
+func withPending(queue []PendingTransaction, pt PendingTransaction) []PendingTransaction {
+    return append([]PendingTransaction{pt}, withoutPending(queue, pt.From, pt.Nonce)...)
+}
+
+
+// Get a queue with a pending transaction removed
+func withoutPending(queue []PendingTransaction, from common.Address, nonce uint64) []PendingTransaction {
+    updated := []PendingTransaction{}
+    for _, qpt := range queue {
+        if qpt.From != from || qpt.Nonce != nonce {
+            updated = append(updated, qpt)
+        }
+    }
+    return updated
+}
@@ -0,0 +1,50 @@
+package transactions
+
+import (
+    "errors"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Error returned in place of a transaction submission by a rehearsal transactor
+var ErrRehearsed = errors.New("The transaction was not submitted because the task is in rehearsal mode")
+
+
+// Get a rehearsal transactor from a node account transactor
+// Contract bindings still estimate gas, simulating the transaction against the current chain state, but the transaction
+// is logged instead of being signed & submitted; callers should treat ErrRehearsed as a successful run without side effects
+func RehearsalTransactor(opts *bind.TransactOpts, logf func(format string, v ...interface{})) *bind.TransactOpts {
+    rehearsalOpts := *opts
+    rehearsalOpts.Signer = func(s types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+        to := "contract creation"
+        if tx.To() != nil {
+            to = tx.To().Hex()
+        }
+        maxCost := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.Gas()))
+        logf("Rehearsal: would submit transaction to %s with value %s, gas limit %d, gas price %s (max cost %s), nonce %d and %d bytes of call data%s.",
+            to, units.FormatEth(tx.Value()), tx.Gas(), units.FormatGwei(tx.GasPrice()), units.FormatEth(maxCost), tx.Nonce(), len(tx.Data()), getMethodID(tx.Data()))
+        return nil, ErrRehearsed
+    }
+    return &rehearsalOpts
+}
+
+
+// Check whether an error was returned in place of a transaction submission by a rehearsal transactor
+func IsRehearsed(err error) bool {
+    return errors.Is(err, ErrRehearsed)
+}
+
+
+// Get a transaction's method ID for display
+func getMethodID(data []byte) string {
+    if len(data) < 4 {
+        return ""
+    }
+    return " (method 0x" + common.Bytes2Hex(data[:4]) + ")"
+}
@@ -0,0 +1,452 @@
+package transactions
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "math/big"
+    "sort"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Rocket Pool contracts whose events are decoded and which are named in simulated state changes
+// Minipool contracts are not registered by name, so their events are decoded but their addresses are not named
+var simulationContractNames = []string{
+    "rocketDepositPool",
+    "rocketETHToken",
+    "rocketMinipool",
+    "rocketMinipoolManager",
+    "rocketMinipoolQueue",
+    "rocketMinipoolStatus",
+    "rocketNetworkBalances",
+    "rocketNetworkFees",
+    "rocketNetworkWithdrawal",
+    "rocketNodeDeposit",
+    "rocketNodeETHToken",
+    "rocketNodeManager",
+}
+
+
+// Error(string) revert reason selector
+var revertReasonSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+
+// Transaction simulation settings
+type simulator struct {
+    rc *rpc.Client
+    rp *rocketpool.RocketPool
+    storageAddress common.Address
+    gasToken string
+}
+
+
+// Call frame from the callTracer, with its logs
+type traceCallFrame struct {
+    Error string                `json:"error"`
+    Logs []struct {
+        Address common.Address  `json:"address"`
+        Topics []common.Hash    `json:"topics"`
+        Data hexutil.Bytes      `json:"data"`
+    }                           `json:"logs"`
+    Calls []traceCallFrame      `json:"calls"`
+}
+
+
+// Account states from the prestateTracer in diff mode
+type traceAccountState struct {
+    Balance *hexutil.Big                    `json:"balance"`
+    Code hexutil.Bytes                      `json:"code"`
+    Storage map[common.Hash]common.Hash     `json:"storage"`
+}
+type tracePrestateDiff struct {
+    Pre map[common.Address]traceAccountState    `json:"pre"`
+    Post map[common.Address]traceAccountState   `json:"post"`
+}
+
+
+// Account state diff from trace_call
+// Each field is "=" if unchanged, or an object describing the change: {"*": {"from": x, "to": y}}, {"+": y} or {"-": x}
+type traceStateDiff struct {
+    Balance json.RawMessage                         `json:"balance"`
+    Code json.RawMessage                            `json:"code"`
+    Storage map[common.Hash]json.RawMessage         `json:"storage"`
+}
+
+
+// Set the manager to simulate transactions instead of signing & sending them
+// In dry-run mode, Send and Transact return an *api.DryRunError holding the simulation; the RPC client is used to trace the
+// simulated call, and the Rocket Pool contract manager to decode its events
+func (m *Manager) SetDryRun(rc *rpc.Client, rp *rocketpool.RocketPool, storageAddress common.Address, gasToken string) {
+    m.dryRun = &simulator{
+        rc: rc,
+        rp: rp,
+        storageAddress: storageAddress,
+        gasToken: gasToken,
+    }
+}
+
+
+// Simulate a transaction against the latest block
+// The transaction is executed with eth_call, and traced to get its events & state changes if the client supports it
+func (m *Manager) simulate(opts *bind.TransactOpts, nonce uint64, description string, send func(opts *bind.TransactOpts) error) error {
+
+    // Build transaction
+    tx, err := captureUnsignedTransaction(opts, nonce, send)
+    if err != nil {
+        return fmt.Errorf("Could not simulate transaction '%s': %w", description, err)
+    }
+    simulation := api.TransactionSimulation{
+        Description: description,
+        From: opts.From,
+        To: tx.To(),
+        Value: tx.Value(),
+        Nonce: tx.Nonce(),
+        GasLimit: tx.Gas(),
+        GasPrice: tx.GasPrice(),
+        GasToken: m.dryRun.gasToken,
+    }
+
+    // Execute transaction
+    returnData, err := m.ec.CallContract(context.Background(), ethereum.CallMsg{
+        From: opts.From,
+        To: tx.To(),
+        Gas: tx.Gas(),
+        GasPrice: tx.GasPrice(),
+        Value: tx.Value(),
+        Data: tx.Data(),
+    }, nil)
+    if err != nil {
+        simulation.Reverted = true
+        simulation.RevertReason = err.Error()
+    } else if reason, ok := getRevertReason(returnData); ok {
+        simulation.Reverted = true
+        simulation.RevertReason = reason
+    } else {
+        simulation.ReturnData = returnData
+    }
+
+    // Trace transaction
+    if !simulation.Reverted {
+        m.dryRun.trace(&simulation, tx)
+    }
+
+    // Return simulation
+    return &api.DryRunError{Simulation: simulation}
+
+}
+
+
+// Trace a simulated transaction to get its events & state changes
+// debug_traceCall is used if supported (e.g. by Geth), falling back to trace_call (e.g. by OpenEthereum and Nethermind),
+// which reports state changes but not events
+func (s *simulator) trace(simulation *api.TransactionSimulation, tx *types.Transaction) {
+
+    // Get call arguments
+    callArgs := map[string]interface{}{
+        "from": simulation.From,
+        "gas": hexutil.Uint64(tx.Gas()),
+        "gasPrice": (*hexutil.Big)(tx.GasPrice()),
+        "value": (*hexutil.Big)(tx.Value()),
+        "data": hexutil.Bytes(tx.Data()),
+    }
+    if tx.To() != nil {
+        callArgs["to"] = tx.To()
+    }
+
+    // Get contract names & ABIs
+    names, abis := s.getContracts()
+    names[simulation.From] = "node account"
+
+    // Trace with debug_traceCall
+    var prestateDiff tracePrestateDiff
+    err := s.rc.CallContext(context.Background(), &prestateDiff, "debug_traceCall", callArgs, "latest", map[string]interface{}{
+        "tracer": "prestateTracer",
+        "tracerConfig": map[string]interface{}{"diffMode": true},
+    })
+    if err == nil {
+        simulation.StateChanges = getPrestateChanges(prestateDiff, names)
+        simulation.StateChangesAvailable = true
+        var callFrame traceCallFrame
+        err = s.rc.CallContext(context.Background(), &callFrame, "debug_traceCall", callArgs, "latest", map[string]interface{}{
+            "tracer": "callTracer",
+            "tracerConfig": map[string]interface{}{"withLog": true},
+        })
+        if err != nil {
+            simulation.TraceError = fmt.Sprintf("Could not trace events: %s", err.Error())
+            return
+        }
+        simulation.Events = getCallEvents(callFrame, names, abis)
+        simulation.EventsAvailable = true
+        return
+    }
+
+    // Trace with trace_call
+    var traceResult struct {
+        StateDiff map[common.Address]traceStateDiff `json:"stateDiff"`
+    }
+    if parityErr := s.rc.CallContext(context.Background(), &traceResult, "trace_call", callArgs, []string{"stateDiff"}, "latest"); parityErr != nil {
+        simulation.TraceError = fmt.Sprintf("The Eth 1.0 client does not support call tracing (debug_traceCall: %s; trace_call: %s)", err.Error(), parityErr.Error())
+        return
+    }
+    simulation.StateChanges = getStateDiffChanges(traceResult.StateDiff, names)
+    simulation.StateChangesAvailable = true
+    simulation.TraceError = "The Eth 1.0 client's trace_call does not report events"
+
+}
+
+
+// Get the names & ABIs of Rocket Pool contracts; contracts which cannot be loaded are skipped
+func (s *simulator) getContracts() (map[common.Address]string, []*abi.ABI) {
+    names := map[common.Address]string{s.storageAddress: "rocketStorage"}
+    abis := []*abi.ABI{}
+    for _, name := range simulationContractNames {
+        if contractAbi, err := s.rp.GetABI(name); err == nil {
+            abis = append(abis, contractAbi)
+        }
+        if name == "rocketMinipool" {
+            continue
+        }
+        if address, err := s.rp.GetAddress(name); err == nil {
+            names[*address] = name
+        }
+    }
+    return names, abis
+}
+
+
+// Get the events emitted by a traced call and its subcalls, in call order
+// Events from reverted calls are discarded, as they are not emitted
+func getCallEvents(frame traceCallFrame, names map[common.Address]string, abis []*abi.ABI) []api.SimulatedEvent {
+    events := []api.SimulatedEvent{}
+    if frame.Error != "" {
+        return events
+    }
+    for _, log := range frame.Logs {
+        events = append(events, decodeEvent(log.Address, log.Topics, log.Data, names, abis))
+    }
+    for _, call := range frame.Calls {
+        events = append(events, getCallEvents(call, names, abis)...)
+    }
+    return events
+}
+
+
+// Decode an event log with the Rocket Pool contract ABIs
+// Events which cannot be decoded are returned with their topic & data length
+func decodeEvent(address common.Address, topics []common.Hash, data []byte, names map[common.Address]string, abis []*abi.ABI) api.SimulatedEvent {
+
+    // Initialize event
+    event := api.SimulatedEvent{
+        Address: address,
+        Contract: names[address],
+        Args: []api.SimulatedEventArg{},
+    }
+    if len(topics) == 0 {
+        event.Name = "(anonymous)"
+        return event
+    }
+
+    // Find & decode event
+    for _, contractAbi := range abis {
+        for _, abiEvent := range contractAbi.Events {
+            if abiEvent.ID != topics[0] {
+                continue
+            }
+            indexed := abi.Arguments{}
+            for _, input := range abiEvent.Inputs {
+                if input.Indexed {
+                    indexed = append(indexed, input)
+                }
+            }
+            values := map[string]interface{}{}
+            if err := abi.ParseTopicsIntoMap(values, indexed, topics[1:]); err != nil {
+                continue
+            }
+            if err := abiEvent.Inputs.NonIndexed().UnpackIntoMap(values, data); err != nil {
+                continue
+            }
+            event.Name = abiEvent.RawName
+            for _, input := range abiEvent.Inputs {
+                event.Args = append(event.Args, api.SimulatedEventArg{Name: input.Name, Value: formatEventValue(values[input.Name])})
+            }
+            return event
+        }
+    }
+
+    // Return undecoded event
+    event.Name = fmt.Sprintf("unknown event %s (%d bytes of data)", topics[0].Hex(), len(data))
+    return event
+
+}
+
+
+// Format a decoded event argument for display
+func formatEventValue(value interface{}) string {
+    switch v := value.(type) {
+        case common.Address: return v.Hex()
+        case common.Hash: return v.Hex()
+        case [32]byte: return common.Hash(v).Hex()
+        case []byte: return hexutil.Encode(v)
+        case *big.Int: return v.String()
+    }
+    return fmt.Sprint(value)
+}
+
+
+// Get state changes from a prestateTracer diff
+func getPrestateChanges(diff tracePrestateDiff, names map[common.Address]string) []api.SimulatedStateChange {
+    changes := []api.SimulatedStateChange{}
+    for _, address := range getDiffAddresses(diff) {
+        pre, existed := diff.Pre[address]
+        post := diff.Post[address]
+        change := api.SimulatedStateChange{
+            Address: address,
+            Contract: names[address],
+            Created: (!existed && len(post.Code) > 0),
+        }
+        if post.Balance != nil {
+            change.BalanceBefore = big.NewInt(0)
+            if pre.Balance != nil {
+                change.BalanceBefore = pre.Balance.ToInt()
+            }
+            change.BalanceAfter = post.Balance.ToInt()
+        }
+        slots := map[common.Hash]bool{}
+        for slot := range pre.Storage {
+            slots[slot] = true
+        }
+        for slot := range post.Storage {
+            slots[slot] = true
+        }
+        change.StorageSlots = len(slots)
+        if change.Created || change.BalanceAfter != nil || change.StorageSlots > 0 {
+            changes = append(changes, change)
+        }
+    }
+    return changes
+}
+func getDiffAddresses(diff tracePrestateDiff) []common.Address {
+    addresses := []common.Address{}
+    for address := range diff.Pre {
+        addresses = append(addresses, address)
+    }
+    for address := range diff.Post {
+        if _, ok := diff.Pre[address]; !ok {
+            addresses = append(addresses, address)
+        }
+    }
+    sortAddresses(addresses)
+    return addresses
+}
+
+
+// Get state changes from a trace_call state diff
+func getStateDiffChanges(stateDiff map[common.Address]traceStateDiff, names map[common.Address]string) []api.SimulatedStateChange {
+    addresses := []common.Address{}
+    for address := range stateDiff {
+        addresses = append(addresses, address)
+    }
+    sortAddresses(addresses)
+    changes := []api.SimulatedStateChange{}
+    for _, address := range addresses {
+        diff := stateDiff[address]
+        change := api.SimulatedStateChange{
+            Address: address,
+            Contract: names[address],
+        }
+        if from, to, changed := parseStateDiffValue(diff.Balance); changed {
+            change.BalanceBefore = hexToBig(from)
+            change.BalanceAfter = hexToBig(to)
+        }
+        if from, to, changed := parseStateDiffValue(diff.Code); changed {
+            change.Created = ((from == "" || from == "0x") && to != "" && to != "0x")
+        }
+        for _, slot := range diff.Storage {
+            if _, _, changed := parseStateDiffValue(slot); changed {
+                change.StorageSlots++
+            }
+        }
+        if change.Created || change.BalanceAfter != nil || change.StorageSlots > 0 {
+            changes = append(changes, change)
+        }
+    }
+    return changes
+}
+
+
+// Parse a trace_call state diff value into its before & after values
+func parseStateDiffValue(raw json.RawMessage) (string, string, bool) {
+    var diff map[string]json.RawMessage
+    if err := json.Unmarshal(raw, &diff); err != nil {
+        return "", "", false
+    }
+    var from, to string
+    if change, ok := diff["*"]; ok {
+        var fromTo struct {
+            From string `json:"from"`
+            To string   `json:"to"`
+        }
+        if err := json.Unmarshal(change, &fromTo); err != nil {
+            return "", "", false
+        }
+        return fromTo.From, fromTo.To, true
+    }
+    if added, ok := diff["+"]; ok {
+        json.Unmarshal(added, &to)
+        return "", to, true
+    }
+    if removed, ok := diff["-"]; ok {
+        json.Unmarshal(removed, &from)
+        return from, "", true
+    }
+    return "", "", false
+}
+
+
+// Decode a hex quantity, treating invalid or empty values as zero
+func hexToBig(value string) *big.Int {
+    if decoded, err := hexutil.DecodeBig(value); err == nil {
+        return decoded
+    }
+    return big.NewInt(0)
+}
+
+
+// Sort addresses for display
+func sortAddresses(addresses []common.Address) {
+    sort.Slice(addresses, func(i, j int) bool {
+        return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+    })
+}
+
+
+// Get the revert reason from a call's return data, if it reverted with an Error(string) reason
+// Clients which do not return an error for reverted calls return the encoded reason as the call's output
+func getRevertReason(returnData []byte) (string, bool) {
+    if len(returnData) < 4 || !bytes.Equal(returnData[:4], revertReasonSelector) {
+        return "", false
+    }
+    stringType, err := abi.NewType("string", "", nil)
+    if err != nil {
+        return "", false
+    }
+    values, err := (abi.Arguments{{Type: stringType}}).UnpackValues(returnData[4:])
+    if err != nil || len(values) == 0 {
+        return "", false
+    }
+    reason, ok := values[0].(string)
+    return reason, ok
+}
+
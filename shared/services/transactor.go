@@ -0,0 +1,86 @@
+package services
+
+import (
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/gas"
+    "github.com/rocket-pool/smartnode/shared/services/transactions"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Get the current gas price from the gas oracle, including the configured priority fee
+func GetGasPrice(c config.Context) (*big.Int, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    rc, err := getEthRPCClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    ec, err := getEthClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    return gas.GetGasPrice(rc, ec, cfg.Smartnode.PriorityFee)
+}
+
+
+// Get a node account transactor with its gas price set by the gas oracle
+// Returns an error if the gas price exceeds the configured maximum fee
+func GetNodeAccountTransactor(c config.Context) (*bind.TransactOpts, error) {
+
+    // Get services
+    cfg, err := getConfig(c)
+    if err != nil { return nil, err }
+    w, err := GetWallet(c)
+    if err != nil { return nil, err }
+
+    // Get & check gas price
+    gasPrice, err := GetGasPrice(c)
+    if err != nil {
+        return nil, err
+    }
+    if err := gas.CheckMaxFee(gasPrice, cfg.Smartnode.MaxFee); err != nil {
+        return nil, err
+    }
+
+    // Get transactor
+    opts, err := w.GetNodeAccountTransactor()
+    if err != nil {
+        return nil, err
+    }
+    opts.GasPrice = gasPrice
+
+    // Return
+    return opts, nil
+
+}
+
+
+// Check whether a daemon task is configured to run in rehearsal mode
+func IsTaskRehearsal(c config.Context, task string) (bool, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return false, err
+    }
+    return cfg.Tasks[task].Rehearsal, nil
+}
+
+
+// Get a daemon task's transactor from a node account transactor
+// Returns a rehearsal transactor which logs transactions instead of submitting them if the task is in rehearsal mode
+func GetTaskTransactor(c config.Context, task string, opts *bind.TransactOpts, logger log.ColorLogger) (*bind.TransactOpts, error) {
+    rehearsal, err := IsTaskRehearsal(c, task)
+    if err != nil {
+        return nil, err
+    }
+    if !rehearsal {
+        return opts, nil
+    }
+    return transactions.RehearsalTransactor(opts, logger.Printlnf), nil
+}
@@ -0,0 +1,110 @@
+package wallet
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+
+    "github.com/google/uuid"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    eth2types "github.com/wealdtech/go-eth2-types/v2"
+    eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+
+// EIP-2335 validator keystore
+type ValidatorKeystore struct {
+    Crypto map[string]interface{}   `json:"crypto"`
+    Description string              `json:"description"`
+    Pubkey rptypes.ValidatorPubkey  `json:"pubkey"`
+    Path string                     `json:"path"`
+    UUID uuid.UUID                  `json:"uuid"`
+    Version uint                    `json:"version"`
+}
+
+
+// Export a validator key by index as an EIP-2335 keystore encrypted with a password
+func (w *Wallet) ExportValidatorKey(index uint, password string) ([]byte, error) {
+
+    // Check wallet is initialized
+    if !w.IsInitialized() {
+        return []byte{}, errors.New("Wallet is not initialized")
+    }
+
+    // Get validator key
+    key, derivationPath, err := w.getValidatorPrivateKey(index)
+    if err != nil {
+        return []byte{}, err
+    }
+
+    // Encrypt key
+    encryptor := eth2ks.New(eth2ks.WithCipher("scrypt"))
+    encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not encrypt validator key: %w", err)
+    }
+
+    // Encode keystore
+    keystoreBytes, err := json.Marshal(ValidatorKeystore{
+        Crypto: encryptedKey,
+        Description: "Rocket Pool smart node validator key",
+        Pubkey: rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+        Path: derivationPath,
+        UUID: uuid.New(),
+        Version: encryptor.Version(),
+    })
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not encode validator keystore: %w", err)
+    }
+
+    // Return
+    return keystoreBytes, nil
+
+}
+
+
+// Import a validator key from an EIP-2335 keystore and store it in the validator keystores
+// Imported keys are not derived from the wallet seed and cannot be recovered from the mnemonic
+func (w *Wallet) ImportValidatorKey(keystoreBytes []byte, password string) (rptypes.ValidatorPubkey, error) {
+
+    // Decode keystore
+    var keystore ValidatorKeystore
+    if err := json.Unmarshal(keystoreBytes, &keystore); err != nil {
+        return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decode validator keystore: %w", err)
+    }
+    if keystore.Crypto == nil {
+        return rptypes.ValidatorPubkey{}, errors.New("Validator keystore does not contain an encrypted key")
+    }
+
+    // Decrypt key
+    keyBytes, err := eth2ks.New().Decrypt(keystore.Crypto, password)
+    if err != nil {
+        return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decrypt validator keystore: %w", err)
+    }
+
+    // Initialize BLS support
+    initializeBLS()
+
+    // Get private key
+    key, err := eth2types.BLSPrivateKeyFromBytes(keyBytes)
+    if err != nil {
+        return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not get validator private key: %w", err)
+    }
+    pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+    // Check pubkey
+    if keystore.Pubkey != (rptypes.ValidatorPubkey{}) && keystore.Pubkey != pubkey {
+        return rptypes.ValidatorPubkey{}, fmt.Errorf("Validator keystore pubkey %s does not match decrypted key pubkey %s", keystore.Pubkey.Hex(), pubkey.Hex())
+    }
+
+    // Update keystores
+    for name, ks := range w.keystores {
+        if err := ks.StoreValidatorKey(key, keystore.Path); err != nil {
+            return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not store %s validator key: %w", name, err)
+        }
+    }
+
+    // Return
+    return pubkey, nil
+
+}
@@ -0,0 +1,84 @@
+package web3signer
+
+import (
+    "encoding/json"
+    "fmt"
+
+    "github.com/google/uuid"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    eth2types "github.com/wealdtech/go-eth2-types/v2"
+    eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+    "github.com/rocket-pool/smartnode/shared/services/passwords"
+    "github.com/rocket-pool/smartnode/shared/services/web3signer"
+)
+
+
+// Web3Signer keystore
+// Validator keys are imported into the remote signer instead of being stored on disk
+type Keystore struct {
+    signer *web3signer.Client
+    pm *passwords.PasswordManager
+    encryptor *eth2ks.Encryptor
+}
+
+
+// Encrypted validator key store
+type validatorKey struct {
+    Crypto map[string]interface{}   `json:"crypto"`
+    Description string              `json:"description"`
+    Version uint                    `json:"version"`
+    UUID uuid.UUID                  `json:"uuid"`
+    Path string                     `json:"path"`
+    Pubkey rptypes.ValidatorPubkey  `json:"pubkey"`
+}
+
+
+// Create new web3signer keystore
+func NewKeystore(signer *web3signer.Client, passwordManager *passwords.PasswordManager) *Keystore {
+    return &Keystore{
+        signer: signer,
+        pm: passwordManager,
+        encryptor: eth2ks.New(eth2ks.WithCipher("scrypt")),
+    }
+}
+
+
+// Store a wallet
+func (ks *Keystore) StoreWallet(walletData []byte) error {
+    return nil
+}
+
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+    // Get wallet password
+    password, err := ks.pm.GetPassword()
+    if err != nil {
+        return fmt.Errorf("Could not get wallet password: %w", err)
+    }
+
+    // Encrypt key
+    encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+    if err != nil {
+        return fmt.Errorf("Could not encrypt validator key: %w", err)
+    }
+
+    // Encode key store
+    keyStoreBytes, err := json.Marshal(validatorKey{
+        Crypto: encryptedKey,
+        Description: "Rocket Pool smart node validator key",
+        Version: ks.encryptor.Version(),
+        UUID: uuid.New(),
+        Path: derivationPath,
+        Pubkey: rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+    })
+    if err != nil {
+        return fmt.Errorf("Could not encode validator key: %w", err)
+    }
+
+    // Import key store into signer
+    return ks.signer.ImportKeystore(keyStoreBytes, password)
+
+}
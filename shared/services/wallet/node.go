@@ -8,6 +8,8 @@ import (
     "github.com/btcsuite/btcutil/hdkeychain"
     "github.com/ethereum/go-ethereum/accounts"
     "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
     "github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -16,9 +18,27 @@ import (
 const NodeKeyPath = "m/44'/60'/0'/0/%d"
 
 
+// Set the node account address for a node whose wallet is kept on an offline machine
+// The address is used in place of the wallet's node account while the wallet is not initialized
+func (w *Wallet) SetOfflineNodeAddress(address common.Address) {
+    w.offlineNodeAddress = &address
+}
+
+
+// Check whether the wallet is kept offline, i.e. it is not initialized but the node account address is set
+func (w *Wallet) IsOffline() bool {
+    return (!w.IsInitialized() && w.offlineNodeAddress != nil)
+}
+
+
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+    // Get offline node account
+    if w.IsOffline() {
+        return accounts.Account{Address: *w.offlineNodeAddress}, nil
+    }
+
     // Check wallet is initialized
     if !w.IsInitialized() {
         return accounts.Account{}, errors.New("Wallet is not initialized")
@@ -50,8 +70,19 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
 
 // Get a transactor for the node account
+// The transactor of an offline wallet cannot sign; its transactions must be prepared for offline signing
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
+    // Get offline transactor
+    if w.IsOffline() {
+        return &bind.TransactOpts{
+            From: *w.offlineNodeAddress,
+            Signer: func(signer types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+                return nil, errors.New("The node wallet is kept offline; run the command with --offline to prepare the transaction for signing with 'rocketpool wallet sign-tx'")
+            },
+        }, nil
+    }
+
     // Check wallet is initialized
     if !w.IsInitialized() {
         return nil, errors.New("Wallet is not initialized")
@@ -69,6 +100,34 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 }
 
 
+// Sign a message with the node account as an EIP-191 personal message
+// The signature's recovery ID is 27 or 28, as expected by personal_sign verifiers
+func (w *Wallet) SignNodeMessage(message []byte) ([]byte, error) {
+
+    // Check wallet is initialized
+    if !w.IsInitialized() {
+        return nil, errors.New("Wallet is not initialized")
+    }
+
+    // Get private key
+    privateKey, _, err := w.getNodePrivateKey()
+    if err != nil {
+        return nil, err
+    }
+
+    // Sign message
+    signature, err := crypto.Sign(accounts.TextHash(message), privateKey)
+    if err != nil {
+        return nil, fmt.Errorf("Could not sign message: %w", err)
+    }
+    signature[crypto.RecoveryIDOffset] += 27
+
+    // Return
+    return signature, nil
+
+}
+
+
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
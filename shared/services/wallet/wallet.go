@@ -9,6 +9,7 @@ import (
 
     "github.com/btcsuite/btcd/chaincfg"
     "github.com/btcsuite/btcutil/hdkeychain"
+    "github.com/ethereum/go-ethereum/common"
     "github.com/google/uuid"
     "github.com/tyler-smith/go-bip39"
     eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -45,6 +46,9 @@ type Wallet struct {
     nodeKey *ecdsa.PrivateKey
     nodeKeyPath string
 
+    // Node account address if the wallet is kept offline
+    offlineNodeAddress *common.Address
+
     // Validator key cache
     validatorKeys map[uint]*eth2types.BLSPrivateKey
     validatorKeyIndices map[string]uint
@@ -0,0 +1,170 @@
+package web3signer
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "strings"
+    "time"
+
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+    hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+
+// Config
+const (
+    UpcheckPath = "/upcheck"
+    PublicKeysPath = "/api/v1/eth2/publicKeys"
+    KeystoresPath = "/eth/v1/keystores"
+    RequestContentType = "application/json"
+)
+var requestTimeout, _ = time.ParseDuration("30s")
+
+
+// Keystore import statuses
+const (
+    ImportStatusImported = "imported"
+    ImportStatusDuplicate = "duplicate"
+    ImportStatusError = "error"
+)
+
+
+// Keystore import request & response
+type importKeystoresRequest struct {
+    Keystores []string      `json:"keystores"`
+    Passwords []string      `json:"passwords"`
+}
+type importKeystoresResponse struct {
+    Data []struct {
+        Status string       `json:"status"`
+        Message string      `json:"message"`
+    }                       `json:"data"`
+}
+
+
+// Web3Signer remote signer client
+type Client struct {
+    url string
+    client *http.Client
+}
+
+
+// Create new Web3Signer client
+func NewClient(url string) *Client {
+    return &Client{
+        url: strings.TrimSuffix(url, "/"),
+        client: &http.Client{Timeout: requestTimeout},
+    }
+}
+
+
+// Check that the signer is up
+func (c *Client) Upcheck() error {
+    if _, err := c.request(http.MethodGet, UpcheckPath, nil); err != nil {
+        return fmt.Errorf("Could not reach the remote signer at %s: %w", c.url, err)
+    }
+    return nil
+}
+
+
+// Get the validator pubkeys the signer holds keys for
+func (c *Client) GetPublicKeys() ([]rptypes.ValidatorPubkey, error) {
+
+    // Get pubkeys
+    responseBody, err := c.request(http.MethodGet, PublicKeysPath, nil)
+    if err != nil {
+        return []rptypes.ValidatorPubkey{}, fmt.Errorf("Could not get remote signer public keys: %w", err)
+    }
+    var pubkeyHexes []string
+    if err := json.Unmarshal(responseBody, &pubkeyHexes); err != nil {
+        return []rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decode remote signer public keys: %w", err)
+    }
+
+    // Decode pubkeys
+    pubkeys := make([]rptypes.ValidatorPubkey, len(pubkeyHexes))
+    for pi, pubkeyHex := range pubkeyHexes {
+        pubkey, err := rptypes.HexToValidatorPubkey(hexutil.RemovePrefix(pubkeyHex))
+        if err != nil {
+            return []rptypes.ValidatorPubkey{}, fmt.Errorf("Invalid remote signer public key '%s': %w", pubkeyHex, err)
+        }
+        pubkeys[pi] = pubkey
+    }
+    return pubkeys, nil
+
+}
+
+
+// Import an EIP-2335 keystore into the signer; keystores the signer already holds are not an error
+func (c *Client) ImportKeystore(keystore []byte, password string) error {
+
+    // Import keystore
+    responseBody, err := c.request(http.MethodPost, KeystoresPath, importKeystoresRequest{
+        Keystores: []string{string(keystore)},
+        Passwords: []string{password},
+    })
+    if err != nil {
+        return fmt.Errorf("Could not import keystore into the remote signer: %w", err)
+    }
+
+    // Check import status
+    var response importKeystoresResponse
+    if err := json.Unmarshal(responseBody, &response); err != nil {
+        return fmt.Errorf("Could not decode remote signer import response: %w", err)
+    }
+    if len(response.Data) != 1 {
+        return fmt.Errorf("Unexpected remote signer import response: %s", strings.TrimSpace(string(responseBody)))
+    }
+    if response.Data[0].Status == ImportStatusError {
+        return fmt.Errorf("The remote signer could not import the keystore: %s", response.Data[0].Message)
+    }
+    return nil
+
+}
+
+
+// Make a request to the signer
+func (c *Client) request(method, requestPath string, requestBody interface{}) ([]byte, error) {
+
+    // Get request body
+    var requestBodyReader *bytes.Reader
+    if requestBody != nil {
+        requestBodyBytes, err := json.Marshal(requestBody)
+        if err != nil {
+            return []byte{}, err
+        }
+        requestBodyReader = bytes.NewReader(requestBodyBytes)
+    } else {
+        requestBodyReader = bytes.NewReader([]byte{})
+    }
+
+    // Send request
+    request, err := http.NewRequest(method, c.url + requestPath, requestBodyReader)
+    if err != nil {
+        return []byte{}, err
+    }
+    if requestBody != nil {
+        request.Header.Set("Content-Type", RequestContentType)
+    }
+    response, err := c.client.Do(request)
+    if err != nil {
+        return []byte{}, err
+    }
+    defer response.Body.Close()
+
+    // Get response
+    body, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+        return []byte{}, err
+    }
+    if response.StatusCode < 200 || response.StatusCode >= 300 {
+        return []byte{}, fmt.Errorf("Received status %s: %s", response.Status, strings.TrimSpace(string(body)))
+    }
+
+    // Return
+    return body, nil
+
+}
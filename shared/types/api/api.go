@@ -1,8 +1,77 @@
 package api
 
+import (
+    "encoding/json"
+    "math/big"
+
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
+)
+
+
+// API response envelope version
+const ResponseVersion = 1
+
+
+// API response statuses
+const (
+    StatusSuccess = "success"                   // The command succeeded
+    StatusError = "error"                       // The command failed
+    StatusOffline = "offline"                   // The command's transaction was prepared for offline signing instead of being sent
+    StatusDryRun = "dry-run"                    // The command's transaction was simulated instead of being sent
+)
+
+
+// API error codes
+const (
+    ErrorCodeInvalidInput = "invalid_input"     // Invalid command arguments
+    ErrorCodeNotReady = "not_ready"             // The node is not set up or its clients are not synced
+    ErrorCodeChain = "chain"                    // An Eth 1.0 or Eth 2.0 client request failed
+    ErrorCodeInternal = "internal"              // Any other error
+)
+
+
+// Versioned envelope wrapping all API responses
+// Data holds the command's response object, and is null if the command failed
+// Transaction holds the unsigned transaction if the command prepared it for offline signing (status offline), and
+// Simulation the simulated transaction if the command was run in dry-run mode (status dry-run)
+type ResponseEnvelope struct {
+    Version int                         `json:"version"`
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    Code string                         `json:"code"`
+    Data json.RawMessage                `json:"data"`
+    Trace []tracing.Span                `json:"trace,omitempty"`
+    Transaction *UnsignedTransaction    `json:"transaction,omitempty"`
+    Simulation *TransactionSimulation   `json:"simulation,omitempty"`
+}
+
+
+// API error, decoded from a failed response envelope
+type Error struct {
+    Code string
+    Message string
+}
+func (e *Error) Error() string {
+    return e.Message
+}
+
 
 type APIResponse struct {
     Status string   `json:"status"`
     Error string    `json:"error"`
 }
 
+
+type ServerRequest struct {
+    GlobalArgs []string `json:"globalArgs"`
+    Args []string       `json:"args"`
+}
+
+
+
+type GasInfo struct {
+    EstGasLimit uint64              `json:"estGasLimit"`
+    GasPrice *big.Int               `json:"gasPrice"`
+    MaxGasPrice *big.Int            `json:"maxGasPrice"`
+    GasToken string                 `json:"gasToken"`
+}
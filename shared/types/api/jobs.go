@@ -0,0 +1,26 @@
+package api
+
+import (
+    "github.com/rocket-pool/smartnode/shared/services/jobs"
+)
+
+
+type JobsResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Jobs []jobs.Job                 `json:"jobs"`
+}
+
+
+type AddJobResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Job jobs.Job                    `json:"job"`
+}
+
+
+type CancelJobResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Job jobs.Job                    `json:"job"`
+}
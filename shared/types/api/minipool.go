@@ -33,16 +33,47 @@ type MinipoolDetails struct {
 type ValidatorDetails struct {
     Exists bool                     `json:"exists"`
     Active bool                     `json:"active"`
+    Exiting bool                    `json:"exiting"`
+    ExitEpoch uint64                `json:"exitEpoch"`
+    WithdrawableEpoch uint64        `json:"withdrawableEpoch"`
     Balance *big.Int                `json:"balance"`
     NodeBalance *big.Int            `json:"nodeBalance"`
 }
 
 
+type MinipoolPerformanceResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Tracked bool                    `json:"tracked"`
+    LastEpoch uint64                `json:"lastEpoch"`
+    Minipools []MinipoolPerformance `json:"minipools"`
+}
+type MinipoolPerformance struct {
+    Address common.Address                  `json:"address"`
+    ValidatorPubkey types.ValidatorPubkey   `json:"validatorPubkey"`
+    ValidatorIndex uint64                   `json:"validatorIndex"`
+    FirstEpoch uint64                       `json:"firstEpoch"`
+    ActiveEpochs uint64                     `json:"activeEpochs"`
+    Attestations uint64                     `json:"attestations"`
+    MissedAttestations uint64               `json:"missedAttestations"`
+    CorrectTargets uint64                   `json:"correctTargets"`
+    CorrectHeads uint64                     `json:"correctHeads"`
+    Effectiveness float64                   `json:"effectiveness"`
+    AverageInclusionDistance float64        `json:"averageInclusionDistance"`
+    Proposals uint64                        `json:"proposals"`
+    MissedProposals uint64                  `json:"missedProposals"`
+    LastMissedAttestationEpoch uint64       `json:"lastMissedAttestationEpoch"`
+    LastMissedProposalSlot uint64           `json:"lastMissedProposalSlot"`
+}
+
+
 type CanRefundMinipoolResponse struct {
     Status string                   `json:"status"`
     Error string                    `json:"error"`
     CanRefund bool                  `json:"canRefund"`
     InsufficientRefundBalance bool  `json:"insufficientRefundBalance"`
+    RefundBalance *big.Int          `json:"refundBalance"`
+    GasInfo GasInfo                 `json:"gasInfo"`
 }
 type RefundMinipoolResponse struct {
     Status string                   `json:"status"`
@@ -56,6 +87,9 @@ type CanDissolveMinipoolResponse struct {
     Error string                    `json:"error"`
     CanDissolve bool                `json:"canDissolve"`
     InvalidStatus bool              `json:"invalidStatus"`
+    LaunchTimeoutActive bool        `json:"launchTimeoutActive"`
+    NodeDepositBalance *big.Int     `json:"nodeDepositBalance"`
+    GasInfo GasInfo                 `json:"gasInfo"`
 }
 type DissolveMinipoolResponse struct {
     Status string                   `json:"status"`
@@ -64,15 +98,47 @@ type DissolveMinipoolResponse struct {
 }
 
 
+type SimulateCloseMinipoolResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    MinipoolStatus types.MinipoolStatus `json:"minipoolStatus"`
+    Method string                   `json:"method"`
+    CanClose bool                   `json:"canClose"`
+    InvalidStatus bool              `json:"invalidStatus"`
+    WithdrawalDelayActive bool      `json:"withdrawalDelayActive"`
+    SimulationError string          `json:"simulationError"`
+    NodeETHAmount *big.Int          `json:"nodeEthAmount"`
+    NodeNETHAmount *big.Int         `json:"nodeNethAmount"`
+    UserAmount *big.Int             `json:"userAmount"`
+    ValidatorExists bool            `json:"validatorExists"`
+    ValidatorIndex uint64           `json:"validatorIndex"`
+    ValidatorBalance *big.Int       `json:"validatorBalance"`
+    ValidatorExited bool            `json:"validatorExited"`
+    ValidatorWithdrawn bool         `json:"validatorWithdrawn"`
+    CurrentEpoch uint64             `json:"currentEpoch"`
+    ExitEpoch uint64                `json:"exitEpoch"`
+    WithdrawableEpoch uint64        `json:"withdrawableEpoch"`
+    ForfeitAmount *big.Int          `json:"forfeitAmount"`
+    GasInfo GasInfo                 `json:"gasInfo"`
+}
+
+
 type CanExitMinipoolResponse struct {
     Status string                   `json:"status"`
     Error string                    `json:"error"`
     CanExit bool                    `json:"canExit"`
     InvalidStatus bool              `json:"invalidStatus"`
+    ValidatorNotActive bool         `json:"validatorNotActive"`
+    ValidatorExiting bool           `json:"validatorExiting"`
+    ActivationTooRecent bool        `json:"activationTooRecent"`
+    CurrentEpoch uint64             `json:"currentEpoch"`
+    EarliestExitEpoch uint64        `json:"earliestExitEpoch"`
 }
 type ExitMinipoolResponse struct {
     Status string                   `json:"status"`
     Error string                    `json:"error"`
+    ValidatorIndex uint64           `json:"validatorIndex"`
+    Epoch uint64                    `json:"epoch"`
 }
 
 
@@ -82,6 +148,11 @@ type CanWithdrawMinipoolResponse struct {
     CanWithdraw bool                `json:"canWithdraw"`
     InvalidStatus bool              `json:"invalidStatus"`
     WithdrawalDelayActive bool      `json:"withdrawalDelayActive"`
+    NETHAmount *big.Int             `json:"nethAmount"`
+    ETHAmount *big.Int              `json:"ethAmount"`
+    GasInfo GasInfo                 `json:"gasInfo"`
+    BelowMinClaimGasRatio bool      `json:"belowMinClaimGasRatio"`
+    MinClaimGasRatio float64        `json:"minClaimGasRatio"`
 }
 type WithdrawMinipoolResponse struct {
     Status string                   `json:"status"`
@@ -95,6 +166,8 @@ type CanCloseMinipoolResponse struct {
     Error string                    `json:"error"`
     CanClose bool                   `json:"canClose"`
     InvalidStatus bool              `json:"invalidStatus"`
+    NodeDepositBalance *big.Int     `json:"nodeDepositBalance"`
+    GasInfo GasInfo                 `json:"gasInfo"`
 }
 type CloseMinipoolResponse struct {
     Status string                   `json:"status"`
@@ -102,3 +175,42 @@ type CloseMinipoolResponse struct {
     TxHash common.Hash              `json:"txHash"`
 }
 
+
+
+type VerifyMinipoolCredentialsResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    ExpectedCredentials common.Hash         `json:"expectedCredentials"`
+    Minipools []MinipoolCredentials         `json:"minipools"`
+    MismatchCount int                       `json:"mismatchCount"`
+}
+type MinipoolCredentials struct {
+    Address common.Address                  `json:"address"`
+    ValidatorPubkey types.ValidatorPubkey   `json:"validatorPubkey"`
+    ValidatorExists bool                    `json:"validatorExists"`
+    WithdrawalCredentials common.Hash       `json:"withdrawalCredentials"`
+    Match bool                              `json:"match"`
+}
+
+
+
+type MinipoolCalculatorResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    NodeFee float64                         `json:"nodeFee"`
+    APR float64                             `json:"apr"`
+    Years uint64                            `json:"years"`
+    DepositTypes []DepositTypeProjection    `json:"depositTypes"`
+}
+type DepositTypeProjection struct {
+    DepositType string                      `json:"depositType"`
+    NodeAmount *big.Int                     `json:"nodeAmount"`
+    UserAmount *big.Int                     `json:"userAmount"`
+    Available bool                          `json:"available"`
+    GasEstimated bool                       `json:"gasEstimated"`
+    GasInfo GasInfo                         `json:"gasInfo"`
+    GasCost *big.Int                        `json:"gasCost"`
+    Rewards *big.Int                        `json:"rewards"`
+    NetRewards *big.Int                     `json:"netRewards"`
+    AnnualReturn float64                    `json:"annualReturn"`
+}
@@ -1,5 +1,13 @@
 package api
 
+import (
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/types"
+)
+
 
 type NodeFeeResponse struct {
     Status string           `json:"status"`
@@ -10,3 +18,96 @@ type NodeFeeResponse struct {
     MaxNodeFee float64      `json:"maxNodeFee"`
 }
 
+
+
+type NetworkStatsResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Block uint64                    `json:"block"`
+    BlockTime time.Time             `json:"blockTime"`
+    TotalETHBalance *big.Int        `json:"totalEthBalance"`
+    StakingETHBalance *big.Int      `json:"stakingEthBalance"`
+    RETHSupply *big.Int             `json:"rethSupply"`
+    RETHExchangeRate float64        `json:"rethExchangeRate"`
+    BalancesBlock uint64            `json:"balancesBlock"`
+    NodeCount uint64                `json:"nodeCount"`
+    MinipoolCount uint64            `json:"minipoolCount"`
+    DepositPoolBalance *big.Int     `json:"depositPoolBalance"`
+    MinipoolQueueLength uint64      `json:"minipoolQueueLength"`
+    MinipoolQueueCapacity *big.Int  `json:"minipoolQueueCapacity"`
+    NodeFee float64                 `json:"nodeFee"`
+}
+
+
+type NetworkBlockAtTimeResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Block uint64                    `json:"block"`
+    BlockTime time.Time             `json:"blockTime"`
+}
+
+
+type NetworkSettingsResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Deposit struct {
+        AssignDepositsEnabled bool      `json:"assignDepositsEnabled"`
+        MaximumDepositAssignments uint64 `json:"maximumDepositAssignments"`
+    }                               `json:"deposit"`
+    Minipool struct {
+        FullDepositNodeAmount *big.Int  `json:"fullDepositNodeAmount"`
+        HalfDepositNodeAmount *big.Int  `json:"halfDepositNodeAmount"`
+        EmptyDepositNodeAmount *big.Int `json:"emptyDepositNodeAmount"`
+        SubmitWithdrawableEnabled bool  `json:"submitWithdrawableEnabled"`
+        LaunchTimeout uint64            `json:"launchTimeout"`
+        WithdrawalDelay uint64          `json:"withdrawalDelay"`
+    }                               `json:"minipool"`
+    Network struct {
+        SubmitBalancesEnabled bool      `json:"submitBalancesEnabled"`
+        SubmitBalancesFrequency uint64  `json:"submitBalancesFrequency"`
+        ProcessWithdrawalsEnabled bool  `json:"processWithdrawalsEnabled"`
+        MinimumNodeFee float64          `json:"minimumNodeFee"`
+        TargetNodeFee float64           `json:"targetNodeFee"`
+        MaximumNodeFee float64          `json:"maximumNodeFee"`
+    }                               `json:"network"`
+    Node struct {
+        RegistrationEnabled bool        `json:"registrationEnabled"`
+        DepositEnabled bool             `json:"depositEnabled"`
+    }                               `json:"node"`
+}
+
+
+type NetworkResolveNameResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Name string                     `json:"name"`
+    Address common.Address          `json:"address"`
+}
+
+
+type NetworkLookupAddressResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Address common.Address          `json:"address"`
+    Name string                     `json:"name"`
+}
+
+
+type NetworkQueueResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    DepositPoolBalance *big.Int     `json:"depositPoolBalance"`
+    MinipoolQueueLength uint64      `json:"minipoolQueueLength"`
+    MinipoolQueueCapacity *big.Int  `json:"minipoolQueueCapacity"`
+    HalfQueueLength uint64          `json:"halfQueueLength"`
+    FullQueueLength uint64          `json:"fullQueueLength"`
+    EmptyQueueLength uint64         `json:"emptyQueueLength"`
+    NodeMinipools []QueuedMinipool  `json:"nodeMinipools"`
+    RecentAssignments uint64        `json:"recentAssignments"`
+    AssignmentRate float64          `json:"assignmentRate"`
+}
+type QueuedMinipool struct {
+    Address common.Address              `json:"address"`
+    DepositType types.MinipoolDeposit   `json:"depositType"`
+    Position uint64                     `json:"position"`
+}
@@ -1,16 +1,25 @@
 package api
 
 import (
+    "math/big"
+    "time"
+
     "github.com/ethereum/go-ethereum/common"
 
     "github.com/rocket-pool/rocketpool-go/tokens"
+
+    "github.com/rocket-pool/smartnode/shared/services/snapshots"
+    "github.com/rocket-pool/smartnode/shared/utils/attestation"
 )
 
 
 type NodeStatusResponse struct {
     Status string                   `json:"status"`
     Error string                    `json:"error"`
+    Block uint64                    `json:"block"`
+    BlockTime time.Time             `json:"blockTime"`
     AccountAddress common.Address   `json:"accountAddress"`
+    AccountAddressName string       `json:"accountAddressName"`
     Registered bool                 `json:"registered"`
     Trusted bool                    `json:"trusted"`
     TimezoneLocation string         `json:"timezoneLocation"`
@@ -26,6 +35,44 @@ type NodeStatusResponse struct {
         WithdrawalAvailable int         `json:"withdrawalAvailable"`
         CloseAvailable int              `json:"closeAvailable"`
     }                               `json:"minipoolCounts"`
+    Validators struct {
+        Active int                      `json:"active"`
+        Balance *big.Int                `json:"balance"`
+        Error string                    `json:"error"`
+    }                               `json:"validators"`
+    DiskSpace struct {
+        Available uint64                `json:"available"`
+        Total uint64                    `json:"total"`
+        GrowthKnown bool                `json:"growthKnown"`
+        GrowthPerDay float64            `json:"growthPerDay"`
+        DaysUntilFull float64           `json:"daysUntilFull"`
+    }                               `json:"diskSpace"`
+    Sync struct {
+        Eth1Synced bool                 `json:"eth1Synced"`
+        Eth1Error string                `json:"eth1Error"`
+        Eth2Synced bool                 `json:"eth2Synced"`
+        Eth2Error string                `json:"eth2Error"`
+    }                               `json:"sync"`
+    Actions []NodeActionItem        `json:"actions"`
+}
+
+
+// Node action item priorities, most urgent first
+const (
+    ActionPriorityUrgent = 1
+    ActionPriorityHigh = 2
+    ActionPriorityNormal = 3
+    ActionPriorityLow = 4
+)
+
+
+// An action the node operator should take, derived from the node's on-chain & beacon chain state
+type NodeActionItem struct {
+    Priority int                    `json:"priority"`
+    Rule string                     `json:"rule"`
+    Title string                    `json:"title"`
+    Detail string                   `json:"detail"`
+    Command string                  `json:"command"`
 }
 
 
@@ -57,6 +104,7 @@ type CanNodeDepositResponse struct {
     InsufficientBalance bool        `json:"insufficientBalance"`
     InvalidAmount bool              `json:"invalidAmount"`
     DepositDisabled bool            `json:"depositDisabled"`
+    GasInfo GasInfo                 `json:"gasInfo"`
 }
 type NodeDepositResponse struct {
     Status string                   `json:"status"`
@@ -71,6 +119,7 @@ type CanNodeSendResponse struct {
     Error string                    `json:"error"`
     CanSend bool                    `json:"canSend"`
     InsufficientBalance bool        `json:"insufficientBalance"`
+    GasInfo GasInfo                 `json:"gasInfo"`
 }
 type NodeSendResponse struct {
     Status string                   `json:"status"`
@@ -92,3 +141,62 @@ type NodeBurnResponse struct {
     TxHash common.Hash              `json:"txHash"`
 }
 
+
+type NodeRewardsResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Claims []RewardsClaim           `json:"claims"`
+    TotalAmount *big.Int            `json:"totalAmount"`
+    AutoWithdrawEnabled bool        `json:"autoWithdrawEnabled"`
+    AutoWithdrawMaxGasPrice float64 `json:"autoWithdrawMaxGasPrice"`
+}
+type RewardsClaim struct {
+    Time time.Time                  `json:"time"`
+    MinipoolAddress common.Address  `json:"minipoolAddress"`
+    Amount *big.Int                 `json:"amount"`
+    TxHash common.Hash              `json:"txHash"`
+    Automatic bool                  `json:"automatic"`
+}
+
+
+type NodeTxQueueResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    Transactions []PendingTransaction   `json:"transactions"`
+}
+type PendingTransaction struct {
+    Description string                  `json:"description"`
+    Nonce uint64                        `json:"nonce"`
+    TxHash common.Hash                  `json:"txHash"`
+    To *common.Address                  `json:"to"`
+    Value *big.Int                      `json:"value"`
+    GasPrice *big.Int                   `json:"gasPrice"`
+    Created time.Time                   `json:"created"`
+    LastBroadcast time.Time             `json:"lastBroadcast"`
+    Replacements int                    `json:"replacements"`
+    Cancelled bool                      `json:"cancelled"`
+}
+type CancelNodeTransactionResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    TxHash common.Hash                  `json:"txHash"`
+}
+
+
+type ProveNodeOwnershipResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    Attestation attestation.Attestation `json:"attestation"`
+}
+
+
+type NodeDiffResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    HasSnapshot bool                    `json:"hasSnapshot"`
+    SnapshotTime time.Time              `json:"snapshotTime"`
+    SnapshotBlock uint64                `json:"snapshotBlock"`
+    CurrentTime time.Time               `json:"currentTime"`
+    CurrentBlock uint64                 `json:"currentBlock"`
+    Changes []snapshots.Change          `json:"changes"`
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+)
+
+
+type CanRETHDepositResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    CanDeposit bool                 `json:"canDeposit"`
+    InsufficientBalance bool        `json:"insufficientBalance"`
+    BelowMinimum bool               `json:"belowMinimum"`
+    DepositDisabled bool            `json:"depositDisabled"`
+    MinimumDeposit *big.Int         `json:"minimumDeposit"`
+    DepositPoolBalance *big.Int     `json:"depositPoolBalance"`
+    ExchangeRate float64            `json:"exchangeRate"`
+    ExpectedRETH *big.Int           `json:"expectedReth"`
+    GasInfo GasInfo                 `json:"gasInfo"`
+}
+type RETHDepositResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    TxHash common.Hash              `json:"txHash"`
+}
+
+
+type CanRETHBurnResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    CanBurn bool                    `json:"canBurn"`
+    InsufficientBalance bool        `json:"insufficientBalance"`
+    InsufficientCollateral bool     `json:"insufficientCollateral"`
+    Collateral *big.Int             `json:"collateral"`
+    ExchangeRate float64            `json:"exchangeRate"`
+    ExpectedETH *big.Int            `json:"expectedEth"`
+    GasInfo GasInfo                 `json:"gasInfo"`
+}
+type RETHBurnResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    TxHash common.Hash              `json:"txHash"`
+}
+
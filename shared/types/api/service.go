@@ -0,0 +1,164 @@
+package api
+
+import (
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/types"
+)
+
+
+type ServicePeersResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Eth1 ClientPeers            `json:"eth1"`
+    Eth2 ClientPeers            `json:"eth2"`
+    SnapshotTime int64          `json:"snapshotTime"`
+}
+type ClientPeers struct {
+    PeerCount int               `json:"peerCount"`
+    AdminAPIAvailable bool      `json:"adminApiAvailable"`
+    Countries map[string]int    `json:"countries"`
+    NewPeers int                `json:"newPeers"`
+    LostPeers int               `json:"lostPeers"`
+}
+
+
+type ServiceClientStatusResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Eth1 ClientStatus           `json:"eth1"`
+    Eth2 ClientStatus           `json:"eth2"`
+}
+type ClientStatus struct {
+    External bool               `json:"external"`
+    Provider string             `json:"provider"`
+    Reachable bool              `json:"reachable"`
+    Synced bool                 `json:"synced"`
+    SyncProgress float64        `json:"syncProgress"`
+    LatestBlockTime uint64      `json:"latestBlockTime"`
+    Error string                `json:"error"`
+}
+
+
+type ServiceSyncResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Eth1 ClientSync             `json:"eth1"`
+    Eth2 ClientSync             `json:"eth2"`
+    SnapshotTime int64          `json:"snapshotTime"`
+}
+type ClientSync struct {
+    Reachable bool              `json:"reachable"`
+    Synced bool                 `json:"synced"`
+    CurrentHeight uint64        `json:"currentHeight"`
+    NetworkHeight uint64        `json:"networkHeight"`
+    PeerCount int               `json:"peerCount"`
+    HeightsPerSecond float64    `json:"heightsPerSecond"`
+    SecondsRemaining int64      `json:"secondsRemaining"`
+    Error string                `json:"error"`
+}
+
+
+type ServiceNetDiagResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    PublicIP string             `json:"publicIp"`
+    PublicIPError string        `json:"publicIpError"`
+    PortMapping PortMappingDiag `json:"portMapping"`
+    Eth1 ClientNetDiag          `json:"eth1"`
+    Eth2 ClientNetDiag          `json:"eth2"`
+}
+type ClientNetDiag struct {
+    External bool               `json:"external"`
+    P2PPort int                 `json:"p2pPort"`
+    PeerCount int               `json:"peerCount"`
+    DirectionsKnown bool        `json:"directionsKnown"`
+    InboundPeers int            `json:"inboundPeers"`
+    PortChecked bool            `json:"portChecked"`
+    PortOpen bool               `json:"portOpen"`
+    PortMapped bool             `json:"portMapped"`
+    PortMappingError string     `json:"portMappingError"`
+    Error string                `json:"error"`
+}
+type PortMappingDiag struct {
+    Enabled bool                `json:"enabled"`
+    Method string               `json:"method"`
+    ExternalIP string           `json:"externalIp"`
+    Updated int64               `json:"updated"`
+}
+
+
+type ServiceBackupsResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Destination string          `json:"destination"`
+    Backups []string            `json:"backups"`
+}
+
+
+type ServiceCreateBackupResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Destination string          `json:"destination"`
+    Name string                 `json:"name"`
+}
+
+
+type ServiceRestoreBackupResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+}
+
+
+type ServiceVerifyBackupResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Name string                 `json:"name"`
+    ConfigFiles []string        `json:"configFiles"`
+    NodeAddress common.Address  `json:"nodeAddress"`
+    KeystoreCount int           `json:"keystoreCount"`
+}
+
+
+type ServiceTasksResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Tasks []DaemonTask          `json:"tasks"`
+}
+type DaemonTask struct {
+    Name string                     `json:"name"`
+    Daemon string                   `json:"daemon"`
+    Interval time.Duration          `json:"interval"`
+    ActiveInterval time.Duration    `json:"activeInterval"`
+    IdleInterval time.Duration      `json:"idleInterval"`
+    Enabled bool                    `json:"enabled"`
+    Rehearsal bool                  `json:"rehearsal"`
+    LastRun time.Time               `json:"lastRun"`
+    LastError string                `json:"lastError"`
+}
+
+
+type ServiceTriggerTaskResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+}
+
+
+type ServiceSignerStatusResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    Configured bool                         `json:"configured"`
+    URL string                              `json:"url"`
+    Reachable bool                          `json:"reachable"`
+    SignerError string                      `json:"signerError"`
+    SignerKeyCount int                      `json:"signerKeyCount"`
+    MissingKeys []types.ValidatorPubkey     `json:"missingKeys"`
+}
+
+
+type ServiceSyncSignerResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    ImportedKeys []types.ValidatorPubkey    `json:"importedKeys"`
+}
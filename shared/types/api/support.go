@@ -0,0 +1,35 @@
+package api
+
+import (
+    "time"
+)
+
+
+type SupportGrantResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    ID string                   `json:"id"`
+    Token string                `json:"token"`
+    Expires time.Time           `json:"expires"`
+}
+
+
+type SupportRevokeResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    RevokedCount int            `json:"revokedCount"`
+}
+
+
+type SupportAuditLogResponse struct {
+    Status string               `json:"status"`
+    Error string                `json:"error"`
+    Entries []AuditLogEntry     `json:"entries"`
+}
+type AuditLogEntry struct {
+    Time time.Time              `json:"time"`
+    Actor string                `json:"actor"`
+    Action string               `json:"action"`
+    Allowed bool                `json:"allowed"`
+    Detail string               `json:"detail"`
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+
+// Unsigned transaction, prepared on the node for signing on an offline machine
+type UnsignedTransaction struct {
+    Description string              `json:"description"`
+    ChainID *big.Int                `json:"chainId"`
+    From common.Address             `json:"from"`
+    To *common.Address              `json:"to"`
+    Nonce uint64                    `json:"nonce"`
+    Value *big.Int                  `json:"value"`
+    GasLimit uint64                 `json:"gasLimit"`
+    GasPrice *big.Int               `json:"gasPrice"`
+    Data hexutil.Bytes              `json:"data"`
+}
+
+
+// Signed transaction, ready to broadcast from the node
+type SignedTransaction struct {
+    Description string              `json:"description"`
+    ChainID *big.Int                `json:"chainId"`
+    From common.Address             `json:"from"`
+    Nonce uint64                    `json:"nonce"`
+    Hash common.Hash                `json:"hash"`
+    RawTransaction hexutil.Bytes    `json:"rawTransaction"`
+}
+
+
+// Error returned by a command in offline mode, in place of sending its transaction
+type OfflineTransactionError struct {
+    Transaction UnsignedTransaction
+}
+func (e *OfflineTransactionError) Error() string {
+    return fmt.Sprintf("The transaction '%s' was prepared for offline signing and has not been sent.", e.Transaction.Description)
+}
+
+
+// Simulation of a transaction against the latest block
+// Events and state changes are only available if the Eth 1.0 client supports call tracing; TraceError explains why if not
+type TransactionSimulation struct {
+    Description string                      `json:"description"`
+    From common.Address                     `json:"from"`
+    To *common.Address                      `json:"to"`
+    Value *big.Int                          `json:"value"`
+    Nonce uint64                            `json:"nonce"`
+    GasLimit uint64                         `json:"gasLimit"`
+    GasPrice *big.Int                       `json:"gasPrice"`
+    GasToken string                         `json:"gasToken"`
+    Reverted bool                           `json:"reverted"`
+    RevertReason string                     `json:"revertReason"`
+    ReturnData hexutil.Bytes                `json:"returnData"`
+    EventsAvailable bool                    `json:"eventsAvailable"`
+    Events []SimulatedEvent                 `json:"events"`
+    StateChangesAvailable bool              `json:"stateChangesAvailable"`
+    StateChanges []SimulatedStateChange     `json:"stateChanges"`
+    TraceError string                       `json:"traceError"`
+}
+type SimulatedEvent struct {
+    Address common.Address                  `json:"address"`
+    Contract string                         `json:"contract"`
+    Name string                             `json:"name"`
+    Args []SimulatedEventArg                `json:"args"`
+}
+type SimulatedEventArg struct {
+    Name string                             `json:"name"`
+    Value string                            `json:"value"`
+}
+type SimulatedStateChange struct {
+    Address common.Address                  `json:"address"`
+    Contract string                         `json:"contract"`
+    Created bool                            `json:"created"`
+    BalanceBefore *big.Int                  `json:"balanceBefore"`
+    BalanceAfter *big.Int                   `json:"balanceAfter"`
+    StorageSlots int                        `json:"storageSlots"`
+}
+
+
+// Error returned by a command in dry-run mode, in place of sending its transaction
+type DryRunError struct {
+    Simulation TransactionSimulation
+}
+func (e *DryRunError) Error() string {
+    return fmt.Sprintf("The transaction '%s' was simulated and has not been sent.", e.Simulation.Description)
+}
+
+
+type SignTransactionResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Transaction SignedTransaction   `json:"transaction"`
+}
+
+
+type BroadcastTransactionResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    TxHash common.Hash              `json:"txHash"`
+}
+
@@ -11,6 +11,7 @@ type WalletStatusResponse struct {
     Error string                            `json:"error"`
     PasswordSet bool                        `json:"passwordSet"`
     WalletInitialized bool                  `json:"walletInitialized"`
+    WalletOffline bool                      `json:"walletOffline"`
     AccountAddress common.Address           `json:"accountAddress"`
     ValidatorKeys []types.ValidatorPubkey   `json:"validatorKeys"`
 }
@@ -46,3 +47,17 @@ type ExportWalletResponse struct {
     AccountPrivateKey string                `json:"accountPrivateKey"`
 }
 
+
+
+type ExportValidatorKeysResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    Keystores []string                      `json:"keystores"`
+}
+
+
+type ImportValidatorKeyResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    ValidatorKey types.ValidatorPubkey      `json:"validatorKey"`
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+    "errors"
+    "net"
+    "net/url"
+
+    "github.com/ethereum/go-ethereum/rpc"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Error with an API error code
+type codedError struct {
+    code string
+    err error
+}
+func (e *codedError) Error() string {
+    return e.err.Error()
+}
+func (e *codedError) Unwrap() error {
+    return e.err
+}
+
+
+// Mark an error as caused by invalid command arguments
+func InputError(err error) error {
+    return &codedError{code: api.ErrorCodeInvalidInput, err: err}
+}
+
+
+// Mark an error as caused by the node not being set up or synced
+func NotReadyError(err error) error {
+    return &codedError{code: api.ErrorCodeNotReady, err: err}
+}
+
+
+// Mark an error as caused by a failed Eth 1.0 or Eth 2.0 client request
+func ChainError(err error) error {
+    return &codedError{code: api.ErrorCodeChain, err: err}
+}
+
+
+// Get the API error code for an error
+// Unmarked RPC and network errors are treated as chain errors
+func GetErrorCode(err error) string {
+    var ce *codedError
+    if errors.As(err, &ce) {
+        return ce.code
+    }
+    var rpcErr rpc.Error
+    var netErr net.Error
+    var urlErr *url.Error
+    if errors.As(err, &rpcErr) || errors.As(err, &netErr) || errors.As(err, &urlErr) {
+        return api.ErrorCodeChain
+    }
+    return api.ErrorCodeInternal
+}
@@ -6,11 +6,13 @@ import (
     "fmt"
     "reflect"
 
+    "github.com/rocket-pool/smartnode/shared/services/tracing"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
-// Print an API response
+// Print an API response, wrapped in a versioned envelope with an error code if the command failed
+// Transactions prepared for offline signing or simulated in dry-run mode are returned with their own status rather than as errors
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
 
@@ -35,20 +37,59 @@ func PrintResponse(response interface{}, responseError error) {
         return
     }
 
+    // Get offline transaction or simulation
+    var transaction *api.UnsignedTransaction
+    var simulation *api.TransactionSimulation
+    var offlineErr *api.OfflineTransactionError
+    var dryRunErr *api.DryRunError
+    if errors.As(responseError, &offlineErr) {
+        transaction = &offlineErr.Transaction
+        responseError = nil
+    } else if errors.As(responseError, &dryRunErr) {
+        simulation = &dryRunErr.Simulation
+        responseError = nil
+    }
+
     // Populate error
+    code := ""
     if responseError != nil {
         ef.SetString(responseError.Error())
+        code = GetErrorCode(responseError)
+    } else if ef.String() != "" {
+        code = api.ErrorCodeInternal
     }
 
     // Set status
-    if ef.String() == "" {
-        sf.SetString("success")
-    } else {
-        sf.SetString("error")
+    switch {
+        case ef.String() != "": sf.SetString(api.StatusError)
+        case transaction != nil: sf.SetString(api.StatusOffline)
+        case simulation != nil: sf.SetString(api.StatusDryRun)
+        default: sf.SetString(api.StatusSuccess)
     }
 
-    // Encode
-    responseBytes, err := json.Marshal(response)
+    // Encode response data
+    var data []byte
+    if sf.String() == api.StatusSuccess {
+        var err error
+        data, err = json.Marshal(response)
+        if err != nil {
+            PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+            return
+        }
+    }
+
+    // Encode envelope
+    envelope := api.ResponseEnvelope{
+        Version: api.ResponseVersion,
+        Status: sf.String(),
+        Error: ef.String(),
+        Code: code,
+        Data: data,
+        Trace: tracing.Finish(responseError),
+        Transaction: transaction,
+        Simulation: simulation,
+    }
+    responseBytes, err := json.Marshal(envelope)
     if err != nil {
         PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
         return
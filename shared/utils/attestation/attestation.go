@@ -0,0 +1,110 @@
+package attestation
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "regexp"
+    "time"
+
+    "github.com/ethereum/go-ethereum/accounts"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/crypto"
+)
+
+
+// Config
+const messageTitle = "Rocket Pool node ownership attestation"
+var challengeRegex = regexp.MustCompile("^[A-Za-z0-9 !#%&'()*+,./:;<=>?@\\[\\]^_{|}~-]{1,256}$")
+
+
+// A signed statement that the holder of a node account's key responded to a challenge
+// The message is signed with the node account as an EIP-191 personal message, so it can also be verified with standard Ethereum tooling
+type Attestation struct {
+    Address common.Address      `json:"address"`
+    Challenge string            `json:"challenge"`
+    Timestamp time.Time         `json:"timestamp"`
+    SmartnodeVersion string     `json:"smartnodeVersion"`
+    Message string              `json:"message"`
+    Signature hexutil.Bytes     `json:"signature"`
+}
+
+
+// Check that a challenge can be attested to
+// Challenges are a single line of printable characters, excluding quotes, backslashes, $ and backticks
+func ValidateChallenge(challenge string) error {
+    if !challengeRegex.MatchString(challenge) {
+        return fmt.Errorf("Invalid challenge '%s' - must be 1 to 256 printable characters, excluding quotes, backslashes, $ and backticks", challenge)
+    }
+    return nil
+}
+
+
+// Create an unsigned attestation
+func New(address common.Address, challenge, smartnodeVersion string, timestamp time.Time) (Attestation, error) {
+    if err := ValidateChallenge(challenge); err != nil {
+        return Attestation{}, err
+    }
+    a := Attestation{
+        Address: address,
+        Challenge: challenge,
+        Timestamp: timestamp.UTC().Truncate(time.Second),
+        SmartnodeVersion: smartnodeVersion,
+    }
+    a.Message = a.getMessage()
+    return a, nil
+}
+
+
+// Get the hash of the attestation message which is signed
+func (a *Attestation) Hash() []byte {
+    return accounts.TextHash([]byte(a.Message))
+}
+
+
+// Verify the attestation's message and signature
+// Returns an error if the message does not match the attested details or was not signed by the attested address
+func (a *Attestation) Verify() error {
+
+    // Check message
+    if err := ValidateChallenge(a.Challenge); err != nil {
+        return err
+    }
+    if a.Message != a.getMessage() {
+        return errors.New("The attestation message does not match its address, challenge, timestamp and version")
+    }
+
+    // Recover signer
+    if len(a.Signature) != crypto.SignatureLength {
+        return fmt.Errorf("Invalid signature length %d", len(a.Signature))
+    }
+    signature := make([]byte, crypto.SignatureLength)
+    copy(signature, a.Signature)
+    if signature[crypto.RecoveryIDOffset] >= 27 {
+        signature[crypto.RecoveryIDOffset] -= 27
+    }
+    publicKey, err := crypto.SigToPub(a.Hash(), signature)
+    if err != nil {
+        return fmt.Errorf("Could not recover the attestation signer: %w", err)
+    }
+
+    // Check signer
+    signer := crypto.PubkeyToAddress(*publicKey)
+    if !bytes.Equal(signer.Bytes(), a.Address.Bytes()) {
+        return fmt.Errorf("The attestation was signed by %s, not %s", signer.Hex(), a.Address.Hex())
+    }
+    return nil
+
+}
+
+
+// Get the attestation message for its details
+func (a *Attestation) getMessage() string {
+    return fmt.Sprintf("%s\nNode: %s\nChallenge: %s\nTimestamp: %s\nSmartnode version: %s",
+        messageTitle,
+        a.Address.Hex(),
+        a.Challenge,
+        a.Timestamp.UTC().Format(time.RFC3339),
+        a.SmartnodeVersion)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Print estimated transaction gas usage & cost
+func PrintGasInfo(gasInfo api.GasInfo) {
+    if gasInfo.GasPrice == nil {
+        return
+    }
+    totalCost := new(big.Int).Mul(gasInfo.GasPrice, new(big.Int).SetUint64(gasInfo.EstGasLimit))
+    fmt.Printf("This transaction is estimated to use %d gas at %s, costing approximately %s.\n", gasInfo.EstGasLimit, units.FormatGwei(gasInfo.GasPrice), units.FormatGasCost(totalCost, gasInfo.GasToken))
+    if gasInfo.MaxGasPrice != nil && gasInfo.GasPrice.Cmp(gasInfo.MaxGasPrice) > 0 {
+        PrintStatus(StatusWarn, fmt.Sprintf("The current gas price exceeds the maximum fee of %s, so this transaction will not be sent until gas prices fall or the maximum fee is raised.", units.FormatGwei(gasInfo.MaxGasPrice)))
+    }
+}
+
+
+// Print estimated total gas usage & cost for a set of transactions
+func PrintTotalGasInfo(gasInfos []api.GasInfo) {
+    if len(gasInfos) == 1 {
+        PrintGasInfo(gasInfos[0])
+        return
+    }
+    totalGas := uint64(0)
+    totalCost := big.NewInt(0)
+    var gasInfo api.GasInfo
+    for _, info := range gasInfos {
+        if info.GasPrice == nil {
+            continue
+        }
+        totalGas += info.EstGasLimit
+        totalCost.Add(totalCost, new(big.Int).Mul(info.GasPrice, new(big.Int).SetUint64(info.EstGasLimit)))
+        gasInfo = info
+    }
+    if gasInfo.GasPrice == nil {
+        return
+    }
+    fmt.Printf("These %d transactions are estimated to use %d gas in total at %s, costing approximately %s.\n", len(gasInfos), totalGas, units.FormatGwei(gasInfo.GasPrice), units.FormatGasCost(totalCost, gasInfo.GasToken))
+    if gasInfo.MaxGasPrice != nil && gasInfo.GasPrice.Cmp(gasInfo.MaxGasPrice) > 0 {
+        PrintStatus(StatusWarn, fmt.Sprintf("The current gas price exceeds the maximum fee of %s, so these transactions will not be sent until gas prices fall or the maximum fee is raised.", units.FormatGwei(gasInfo.MaxGasPrice)))
+    }
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+    "fmt"
+
+    "github.com/fatih/color"
+)
+
+
+// Status labels; status is always printed as a word so that it is never signalled by color alone
+const (
+    StatusOK = "OK"
+    StatusWarn = "WARN"
+    StatusFail = "FAIL"
+)
+
+
+// Output settings
+var accessible bool
+
+
+// Enable or disable accessible output mode
+// Accessible output avoids colors, animations and decorative formatting for screen readers and simple terminals
+func SetAccessible(enabled bool) {
+    accessible = enabled
+    if enabled {
+        color.NoColor = true
+    }
+}
+
+
+// Check whether accessible output mode is enabled
+func IsAccessible() bool {
+    return accessible
+}
+
+
+// Print a message with a status label
+func PrintStatus(status, message string) {
+    var c *color.Color
+    switch status {
+        case StatusOK:   c = color.New(color.FgGreen)
+        case StatusWarn: c = color.New(color.FgYellow)
+        default:         c = color.New(color.FgRed)
+    }
+    fmt.Printf("%s: %s\n", c.Sprint(status), message)
+}
+
+
+// Print a decorative separator line; omitted in accessible mode
+func PrintSeparator(separator string) {
+    if !accessible {
+        fmt.Println(separator)
+    }
+}
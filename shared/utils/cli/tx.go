@@ -0,0 +1,32 @@
+package cli
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/units"
+)
+
+
+// Print the details of a transaction prepared for offline signing
+func PrintUnsignedTransaction(tx api.UnsignedTransaction) {
+    to := "contract creation"
+    if tx.To != nil {
+        to = tx.To.Hex()
+    }
+    fmt.Printf("Transaction:  %s\n", tx.Description)
+    fmt.Printf("From:         %s\n", tx.From.Hex())
+    fmt.Printf("To:           %s\n", to)
+    if tx.Value != nil {
+        fmt.Printf("Value:        %s\n", units.FormatEth(tx.Value))
+    }
+    fmt.Printf("Nonce:        %d\n", tx.Nonce)
+    fmt.Printf("Chain ID:     %s\n", tx.ChainID.String())
+    if tx.GasPrice != nil {
+        maxCost := new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.GasLimit))
+        fmt.Printf("Gas:          %d at %s (max cost %s)\n", tx.GasLimit, units.FormatGwei(tx.GasPrice), units.FormatEth(maxCost))
+    }
+    fmt.Printf("Call data:    %d bytes\n", len(tx.Data))
+}
+
@@ -1,17 +1,23 @@
 package cli
 
 import (
+    "encoding/hex"
     "fmt"
     "math/big"
     "regexp"
     "strconv"
     "strings"
+    "time"
 
     "github.com/ethereum/go-ethereum/common"
     "github.com/tyler-smith/go-bip39"
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/shared/services/backup"
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/services/ens"
     "github.com/rocket-pool/smartnode/shared/services/passwords"
+    apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
 
@@ -23,7 +29,25 @@ import (
 // Validate command argument count
 func ValidateArgCount(c *cli.Context, count int) error {
     if len(c.Args()) != count {
-        return fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText)
+        return apiutils.InputError(fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText))
+    }
+    return nil
+}
+
+
+// Validate minimum command argument count
+func ValidateMinArgCount(c *cli.Context, count int) error {
+    if len(c.Args()) < count {
+        return apiutils.InputError(fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText))
+    }
+    return nil
+}
+
+
+// Validate maximum command argument count
+func ValidateMaxArgCount(c *cli.Context, count int) error {
+    if len(c.Args()) > count {
+        return apiutils.InputError(fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText))
     }
     return nil
 }
@@ -32,17 +56,68 @@ func ValidateArgCount(c *cli.Context, count int) error {
 // Validate an address
 func ValidateAddress(name, value string) (common.Address, error) {
     if !common.IsHexAddress(value) {
-        return common.Address{}, fmt.Errorf("Invalid %s '%s'", name, value)
+        return common.Address{}, apiutils.InputError(fmt.Errorf("Invalid %s '%s'", name, value))
     }
     return common.HexToAddress(value), nil
 }
 
 
+// Validate an ENS name
+func ValidateENSName(name, value string) (string, error) {
+    if !ens.IsName(value) {
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be an ENS name such as 'name.eth'", name, value))
+    }
+    return ens.Normalize(value), nil
+}
+
+
+// Validate an address or ENS name
+func ValidateAddressOrENSName(name, value string) (string, error) {
+    if common.IsHexAddress(value) {
+        return value, nil
+    }
+    if !ens.IsName(value) {
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be an address or an ENS name", name, value))
+    }
+    return ens.Normalize(value), nil
+}
+
+
+// Validate hex-encoded data
+func ValidateHexData(name, value string) ([]byte, error) {
+    val, err := hex.DecodeString(value)
+    if err != nil {
+        return []byte{}, apiutils.InputError(fmt.Errorf("Invalid %s '%s'", name, value))
+    }
+    return val, nil
+}
+
+
 // Validate a wei amount
 func ValidateWeiAmount(name, value string) (*big.Int, error) {
     val := new(big.Int)
     if _, ok := val.SetString(value, 10); !ok {
-        return nil, fmt.Errorf("Invalid %s '%s'", name, value)
+        return nil, apiutils.InputError(fmt.Errorf("Invalid %s '%s'", name, value))
+    }
+    return val, nil
+}
+
+
+// Validate an unsigned integer value
+func ValidateUint(name, value string) (uint64, error) {
+    val, err := strconv.ParseUint(value, 10, 64)
+    if err != nil {
+        return 0, apiutils.InputError(fmt.Errorf("Invalid %s '%s'", name, value))
+    }
+    return val, nil
+}
+
+
+// Validate a boolean value
+func ValidateBool(name, value string) (bool, error) {
+    val, err := strconv.ParseBool(value)
+    if err != nil {
+        return false, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be true or false", name, value))
     }
     return val, nil
 }
@@ -52,17 +127,55 @@ func ValidateWeiAmount(name, value string) (*big.Int, error) {
 func ValidateEthAmount(name, value string) (float64, error) {
     val, err := strconv.ParseFloat(value, 64)
     if err != nil {
-        return 0, fmt.Errorf("Invalid %s '%s'", name, value)
+        return 0, apiutils.InputError(fmt.Errorf("Invalid %s '%s'", name, value))
     }
     return val, nil
 }
 
 
+// Validate a positive duration (e.g. 24h)
+func ValidateDuration(name, value string) (time.Duration, error) {
+    val, err := time.ParseDuration(value)
+    if err != nil || val <= 0 {
+        return 0, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be a positive duration such as 30m or 24h", name, value))
+    }
+    return val, nil
+}
+
+
+// Validate a date, as YYYY-MM-DD, YYYY-MM-DD HH:MM in local time, or RFC 3339
+func ValidateDate(name, value string) (time.Time, error) {
+    for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", time.RFC3339} {
+        if val, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+            return val, nil
+        }
+    }
+    return time.Time{}, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be a date such as 2020-11-01, 2020-11-01 12:00 or 2020-11-01T12:00:00Z", name, value))
+}
+
+
+// Validate a positive age, as a duration or a number of days or weeks (e.g. 12h, 7d or 2w)
+func ValidateAge(name, value string) (time.Duration, error) {
+    units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+    if len(value) > 1 {
+        if unit, ok := units[value[len(value)-1:]]; ok {
+            if count, err := strconv.ParseUint(value[:len(value)-1], 10, 64); err == nil && count > 0 {
+                return time.Duration(count) * unit, nil
+            }
+        }
+    }
+    if val, err := time.ParseDuration(value); err == nil && val > 0 {
+        return val, nil
+    }
+    return 0, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be a positive duration such as 12h, 7d or 2w", name, value))
+}
+
+
 // Validate a fraction
 func ValidateFraction(name, value string) (float64, error) {
     val, err := strconv.ParseFloat(value, 64)
     if err != nil || val < 0 || val > 1 {
-        return 0, fmt.Errorf("Invalid %s '%s' - must be a number between 0 and 1", name, value)
+        return 0, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be a number between 0 and 1", name, value))
     }
     return val, nil
 }
@@ -71,8 +184,37 @@ func ValidateFraction(name, value string) (float64, error) {
 // Validate a token type
 func ValidateTokenType(name, value string) (string, error) {
     val := strings.ToLower(value)
-    if !(val == "eth" || val == "neth") {
-        return "", fmt.Errorf("Invalid %s '%s' - valid types are 'ETH' and 'nETH'", name, value)
+    if !(val == "eth" || val == "neth" || val == "reth") {
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - valid types are 'ETH', 'nETH' and 'rETH'", name, value))
+    }
+    return val, nil
+}
+
+
+// Validate a backup name
+func ValidateBackupName(name, value string) (string, error) {
+    if _, ok := backup.GetBackupTime(value); !ok {
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s'", name, value))
+    }
+    return value, nil
+}
+
+
+// Validate a service backend
+func ValidateServiceBackend(name, value string) (string, error) {
+    val := strings.ToLower(value)
+    if !(val == "compose" || val == "systemd") {
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - valid backends are 'compose' and 'systemd'", name, value))
+    }
+    return val, nil
+}
+
+
+// Validate a host architecture
+func ValidateArchitecture(name, value string) (string, error) {
+    val, err := config.ParseArchitecture(strings.ToLower(value))
+    if err != nil {
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - valid architectures are 'amd64' and 'arm64'", name, value))
     }
     return val, nil
 }
@@ -90,7 +232,7 @@ func ValidatePositiveWeiAmount(name, value string) (*big.Int, error) {
         return nil, err
     }
     if val.Cmp(big.NewInt(0)) < 1 {
-        return nil, fmt.Errorf("Invalid %s '%s' - must be greater than 0", name, value)
+        return nil, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be greater than 0", name, value))
     }
     return val, nil
 }
@@ -103,7 +245,7 @@ func ValidateDepositWeiAmount(name, value string) (*big.Int, error) {
         return nil, err
     }
     if ether := strings.Repeat("0", 18); !(val.String() == "0" || val.String() == "16"+ether || val.String() == "32"+ether) {
-        return nil, fmt.Errorf("Invalid %s '%s' - valid values are 0, 16 and 32 ether", name, value)
+        return nil, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - valid values are 0, 16 and 32 ether", name, value))
     }
     return val, nil
 }
@@ -116,7 +258,7 @@ func ValidatePositiveEthAmount(name, value string) (float64, error) {
         return 0, err
     }
     if val <= 0 {
-        return 0, fmt.Errorf("Invalid %s '%s' - must be greater than 0", name, value)
+        return 0, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be greater than 0", name, value))
     }
     return val, nil
 }
@@ -129,7 +271,7 @@ func ValidateDepositEthAmount(name, value string) (float64, error) {
         return 0, err
     }
     if !(val == 0 || val == 16 || val == 32) {
-        return 0, fmt.Errorf("Invalid %s '%s' - valid values are 0, 16 and 32 ether", name, value)
+        return 0, apiutils.InputError(fmt.Errorf("Invalid %s '%s' - valid values are 0, 16 and 32 ether", name, value))
     }
     return val, nil
 }
@@ -139,7 +281,7 @@ func ValidateDepositEthAmount(name, value string) (float64, error) {
 func ValidateBurnableTokenType(name, value string) (string, error) {
     val := strings.ToLower(value)
     if !(val == "neth") {
-        return "", fmt.Errorf("Invalid %s '%s' - valid types are 'nETH'", name, value)
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - valid types are 'nETH'", name, value))
     }
     return val, nil
 }
@@ -149,7 +291,7 @@ func ValidateBurnableTokenType(name, value string) (string, error) {
 func ValidateWithdrawableTokenType(name, value string) (string, error) {
     val := strings.ToLower(value)
     if !(val == "eth") {
-        return "", fmt.Errorf("Invalid %s '%s' - valid types are 'ETH'", name, value)
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - valid types are 'ETH'", name, value))
     }
     return val, nil
 }
@@ -158,7 +300,7 @@ func ValidateWithdrawableTokenType(name, value string) (string, error) {
 // Validate a node password
 func ValidateNodePassword(name, value string) (string, error) {
     if len(value) < passwords.MinPasswordLength {
-        return "", fmt.Errorf("Invalid %s '%s' - must be at least %d characters long", name, value, passwords.MinPasswordLength)
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be at least %d characters long", name, value, passwords.MinPasswordLength))
     }
     return value, nil
 }
@@ -167,7 +309,7 @@ func ValidateNodePassword(name, value string) (string, error) {
 // Validate a wallet mnemonic phrase
 func ValidateWalletMnemonic(name, value string) (string, error) {
     if !bip39.IsMnemonicValid(value) {
-        return "", fmt.Errorf("Invalid %s '%s'", name, value)
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s'", name, value))
     }
     return value, nil
 }
@@ -176,7 +318,7 @@ func ValidateWalletMnemonic(name, value string) (string, error) {
 // Validate a timezone location
 func ValidateTimezoneLocation(name, value string) (string, error) {
     if !regexp.MustCompile("^\\w{2,}\\/\\w{2,}$").MatchString(value) {
-        return "", fmt.Errorf("Invalid %s '%s' - must be in the format 'Country/City'", name, value)
+        return "", apiutils.InputError(fmt.Errorf("Invalid %s '%s' - must be in the format 'Country/City'", name, value))
     }
     return value, nil
 }
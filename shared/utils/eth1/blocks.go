@@ -0,0 +1,84 @@
+package eth1
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/ethclient"
+)
+
+
+// Get the number of the latest block mined at or before a time
+// Blocks are binary searched by timestamp, so this makes O(log n) header requests
+func GetBlockAtTime(client *ethclient.Client, t time.Time) (uint64, error) {
+
+    // Get latest block
+    latest, err := client.HeaderByNumber(context.Background(), nil)
+    if err != nil {
+        return 0, fmt.Errorf("Could not get latest block: %w", err)
+    }
+    if latest.Time <= uint64(t.Unix()) {
+        return latest.Number.Uint64(), nil
+    }
+
+    // Search blocks
+    low := uint64(0)
+    high := latest.Number.Uint64()
+    for low < high {
+        mid := (low + high + 1) / 2
+        header, err := client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(mid))
+        if err != nil {
+            return 0, fmt.Errorf("Could not get block %d: %w", mid, err)
+        }
+        if header.Time <= uint64(t.Unix()) {
+            low = mid
+        } else {
+            high = mid - 1
+        }
+    }
+
+    // Check the genesis block is not after the time
+    if low == 0 {
+        genesis, err := client.HeaderByNumber(context.Background(), big.NewInt(0))
+        if err != nil {
+            return 0, fmt.Errorf("Could not get genesis block: %w", err)
+        }
+        if genesis.Time > uint64(t.Unix()) {
+            return 0, fmt.Errorf("%s is before the genesis block", t.Format(time.RFC1123))
+        }
+    }
+
+    // Return
+    return low, nil
+
+}
+
+
+// Get call options for contract reads at a block; a zero block number reads the latest state
+// Reads at past blocks need an archive node, so the client is checked for state at the block first
+func GetHistoricalCallOpts(client *ethclient.Client, blockNumber uint64) (*bind.CallOpts, error) {
+
+    // Latest state
+    if blockNumber == 0 {
+        return nil, nil
+    }
+
+    // Check block state is available
+    block := new(big.Int).SetUint64(blockNumber)
+    if _, err := client.BalanceAt(context.Background(), common.Address{}, block); err != nil {
+        if strings.Contains(err.Error(), "missing trie node") || strings.Contains(err.Error(), "header not found") {
+            return nil, fmt.Errorf("The Eth 1.0 client does not have the chain state at block %d; historical queries need an archive node.", blockNumber)
+        }
+        return nil, fmt.Errorf("Could not get chain state at block %d: %w", blockNumber, err)
+    }
+
+    // Return
+    return &bind.CallOpts{BlockNumber: block}, nil
+
+}
+
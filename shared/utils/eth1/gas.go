@@ -0,0 +1,62 @@
+package eth1
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Estimate the gas limit for a Rocket Pool contract transaction at a gas price
+func EstimateContractGas(rp *rocketpool.RocketPool, gasPrice *big.Int, contractName string, from common.Address, value *big.Int, method string, params ...interface{}) (api.GasInfo, error) {
+
+    // Get contract details
+    contractAddress, err := rp.GetAddress(contractName)
+    if err != nil {
+        return api.GasInfo{}, err
+    }
+    contractAbi, err := rp.GetABI(contractName)
+    if err != nil {
+        return api.GasInfo{}, err
+    }
+
+    // Estimate gas
+    return EstimateCallGas(rp, gasPrice, *contractAddress, contractAbi, contractName, from, value, method, params...)
+
+}
+
+
+// Estimate the gas limit for a transaction to a contract at a known address (e.g. a minipool) at a gas price
+func EstimateCallGas(rp *rocketpool.RocketPool, gasPrice *big.Int, contractAddress common.Address, contractAbi *abi.ABI, contractName string, from common.Address, value *big.Int, method string, params ...interface{}) (api.GasInfo, error) {
+
+    // Encode call data
+    data, err := contractAbi.Pack(method, params...)
+    if err != nil {
+        return api.GasInfo{}, fmt.Errorf("Could not encode %s.%s call data: %w", contractName, method, err)
+    }
+
+    // Estimate gas limit
+    gasLimit, err := rp.Client.EstimateGas(context.Background(), ethereum.CallMsg{
+        From: from,
+        To: &contractAddress,
+        Value: value,
+        Data: data,
+    })
+    if err != nil {
+        return api.GasInfo{}, fmt.Errorf("Could not estimate gas for %s.%s: %w", contractName, method, err)
+    }
+
+    // Return
+    return api.GasInfo{
+        EstGasLimit: gasLimit,
+        GasPrice: gasPrice,
+    }, nil
+
+}
@@ -1,50 +1,222 @@
 package log
 
 import (
-    "log"
+    "encoding/json"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "sync"
+    "time"
 
     "github.com/fatih/color"
 )
 
 
-// Logger with ANSI color output
+// Log levels
+type Level int
+const (
+    LevelDebug Level = iota
+    LevelInfo
+    LevelWarn
+    LevelError
+)
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+
+// Config
+const (
+    TimeFormat = "2006/01/02 15:04:05"
+)
+
+
+// Logging options
+type Options struct {
+    Level Level
+    JSON bool
+    File string
+    MaxSize int64
+    MaxBackups int
+}
+
+
+// JSON log entry
+type entry struct {
+    Time time.Time      `json:"time"`
+    Level string        `json:"level"`
+    Task string         `json:"task,omitempty"`
+    Message string      `json:"message"`
+}
+
+
+// Shared log output
+var (
+    options = Options{Level: LevelInfo}
+    stdout io.Writer = os.Stdout
+    file io.WriteCloser
+    outputLock sync.Mutex
+)
+
+
+// Logger with ANSI color output and a task prefix
 type ColorLogger struct {
     Color color.Attribute
+    Task string
     sprintFunc func(a ...interface{}) string
-    sprintfFunc func(format string, a ...interface{}) string
 }
 
 
 // Create new color logger
-func NewColorLogger(colorAttr color.Attribute) ColorLogger {
+func NewColorLogger(task string, colorAttr color.Attribute) ColorLogger {
     return ColorLogger{
         Color: colorAttr,
+        Task: task,
         sprintFunc: color.New(colorAttr).SprintFunc(),
-        sprintfFunc: color.New(colorAttr).SprintfFunc(),
     }
 }
 
 
+// Configure shared log output
+func Configure(opts Options) error {
+    outputLock.Lock()
+    defer outputLock.Unlock()
+    if file != nil {
+        file.Close()
+        file = nil
+    }
+    if opts.File != "" {
+        rw, err := newRotatingWriter(opts.File, opts.MaxSize, opts.MaxBackups)
+        if err != nil {
+            return err
+        }
+        file = rw
+    }
+    options = opts
+    return nil
+}
+
+
+// Parse a log level name
+func ParseLevel(name string) (Level, error) {
+    for level, levelName := range levelNames {
+        if strings.EqualFold(name, levelName) {
+            return Level(level), nil
+        }
+    }
+    return LevelInfo, fmt.Errorf("Unknown log level '%s'", name)
+}
+
+
+// Get a log level's name
+func (level Level) String() string {
+    if level < LevelDebug || level > LevelError {
+        return "unknown"
+    }
+    return levelNames[level]
+}
+
+
 // Print values
 func (l *ColorLogger) Print(v ...interface{}) {
-    log.Print(l.sprintFunc(v...))
+    l.write(LevelInfo, fmt.Sprint(v...))
 }
 
 
 // Print values with a newline
 func (l *ColorLogger) Println(v ...interface{}) {
-    log.Println(l.sprintFunc(v...))
+    l.write(LevelInfo, fmt.Sprint(v...))
 }
 
 
 // Print a formatted string
 func (l *ColorLogger) Printf(format string, v ...interface{}) {
-    log.Print(l.sprintfFunc(format, v...))
+    l.write(LevelInfo, fmt.Sprintf(format, v...))
 }
 
 
 // Print a formatted string with a newline
 func (l *ColorLogger) Printlnf(format string, v ...interface{}) {
-    log.Println(l.sprintfFunc(format, v...))
+    l.write(LevelInfo, fmt.Sprintf(format, v...))
 }
 
+
+// Print debug values
+func (l *ColorLogger) Debug(v ...interface{}) {
+    l.write(LevelDebug, fmt.Sprint(v...))
+}
+
+
+// Print a formatted debug string
+func (l *ColorLogger) Debugf(format string, v ...interface{}) {
+    l.write(LevelDebug, fmt.Sprintf(format, v...))
+}
+
+
+// Print warning values
+func (l *ColorLogger) Warn(v ...interface{}) {
+    l.write(LevelWarn, fmt.Sprint(v...))
+}
+
+
+// Print a formatted warning string
+func (l *ColorLogger) Warnf(format string, v ...interface{}) {
+    l.write(LevelWarn, fmt.Sprintf(format, v...))
+}
+
+
+// Print error values
+func (l *ColorLogger) Error(v ...interface{}) {
+    l.write(LevelError, fmt.Sprint(v...))
+}
+
+
+// Print a formatted error string
+func (l *ColorLogger) Errorf(format string, v ...interface{}) {
+    l.write(LevelError, fmt.Sprintf(format, v...))
+}
+
+
+// Write a log message at a level to stdout and the log file
+func (l *ColorLogger) write(level Level, message string) {
+    outputLock.Lock()
+    defer outputLock.Unlock()
+
+    // Check level
+    if level < options.Level {
+        return
+    }
+
+    // Format message
+    now := time.Now()
+    var plain, colored string
+    if options.JSON {
+        entryBytes, err := json.Marshal(entry{
+            Time: now,
+            Level: level.String(),
+            Task: l.Task,
+            Message: message,
+        })
+        if err != nil {
+            return
+        }
+        plain = string(entryBytes)
+        colored = plain
+    } else {
+        prefix := now.Format(TimeFormat) + " "
+        if level != LevelInfo {
+            prefix += strings.ToUpper(level.String()) + " "
+        }
+        if l.Task != "" {
+            prefix += "[" + l.Task + "] "
+        }
+        plain = prefix + message
+        colored = prefix + l.sprintFunc(message)
+    }
+
+    // Write message
+    fmt.Fprintln(stdout, colored)
+    if file != nil {
+        fmt.Fprintln(file, plain)
+    }
+
+}
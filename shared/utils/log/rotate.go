@@ -0,0 +1,111 @@
+package log
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+)
+
+
+// Config
+const (
+    DefaultMaxSize = 10 * 1024 * 1024
+    DefaultMaxBackups = 5
+    DirMode = 0700
+    FileMode = 0600
+)
+
+
+// Log file writer with size-based rotation
+// When a write would exceed the maximum size, the file is renamed to <path>.1, existing backups are shifted, and a new file is started
+type rotatingWriter struct {
+    path string
+    maxSize int64
+    maxBackups int
+    file *os.File
+    size int64
+}
+
+
+// Create a rotating log file writer
+func newRotatingWriter(path string, maxSize int64, maxBackups int) (*rotatingWriter, error) {
+    if maxSize <= 0 {
+        maxSize = DefaultMaxSize
+    }
+    if maxBackups <= 0 {
+        maxBackups = DefaultMaxBackups
+    }
+    w := &rotatingWriter{
+        path: path,
+        maxSize: maxSize,
+        maxBackups: maxBackups,
+    }
+    if err := w.open(); err != nil {
+        return nil, err
+    }
+    return w, nil
+}
+
+
+// Write to the log file, rotating it if required
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+    if w.file == nil {
+        if err := w.open(); err != nil {
+            return 0, err
+        }
+    }
+    if w.size > 0 && w.size + int64(len(p)) > w.maxSize {
+        if err := w.rotate(); err != nil {
+            return 0, err
+        }
+    }
+    n, err := w.file.Write(p)
+    w.size += int64(n)
+    return n, err
+}
+
+
+// Close the log file
+func (w *rotatingWriter) Close() error {
+    if w.file == nil {
+        return nil
+    }
+    return w.file.Close()
+}
+
+
+// Open the log file for appending
+func (w *rotatingWriter) open() error {
+    if err := os.MkdirAll(filepath.Dir(w.path), DirMode); err != nil {
+        return fmt.Errorf("Could not create log folder: %w", err)
+    }
+    file, err := os.OpenFile(w.path, os.O_APPEND | os.O_CREATE | os.O_WRONLY, FileMode)
+    if err != nil {
+        return fmt.Errorf("Could not open log file: %w", err)
+    }
+    info, err := file.Stat()
+    if err != nil {
+        file.Close()
+        return fmt.Errorf("Could not get log file size: %w", err)
+    }
+    w.file = file
+    w.size = info.Size()
+    return nil
+}
+
+
+// Rotate the log file
+func (w *rotatingWriter) rotate() error {
+    if err := w.file.Close(); err != nil {
+        return fmt.Errorf("Could not close log file: %w", err)
+    }
+    w.file = nil
+    os.Remove(fmt.Sprintf("%s.%d", w.path, w.maxBackups))
+    for i := w.maxBackups - 1; i >= 1; i-- {
+        os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i + 1))
+    }
+    if err := os.Rename(w.path, w.path + ".1"); err != nil {
+        return fmt.Errorf("Could not rotate log file: %w", err)
+    }
+    return w.open()
+}
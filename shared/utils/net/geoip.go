@@ -0,0 +1,55 @@
+package net
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "time"
+)
+
+
+// FreeGeoIP config
+const (
+    FreeGeoIPURL = "https://freegeoip.app/json/"
+    FreeGeoIPTimeout = 5 * time.Second
+)
+
+
+// FreeGeoIP response
+type freeGeoIPResponse struct {
+    CountryCode string `json:"country_code"`
+}
+
+
+// Get the country code for a host address
+// The address is sent to FreeGeoIP, so lookups should only be made with the user's consent
+func GetCountryCode(host string) (string, error) {
+
+    // Request
+    client := http.Client{Timeout: FreeGeoIPTimeout}
+    response, err := client.Get(FreeGeoIPURL + host)
+    if err != nil {
+        return "", fmt.Errorf("Could not look up location of %s: %w", host, err)
+    }
+    defer response.Body.Close()
+    if response.StatusCode != http.StatusOK {
+        return "", fmt.Errorf("Could not look up location of %s: %s", host, response.Status)
+    }
+
+    // Get response
+    body, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+        return "", fmt.Errorf("Could not read location of %s: %w", host, err)
+    }
+
+    // Unmarshal response
+    var location freeGeoIPResponse
+    if err := json.Unmarshal(body, &location); err != nil {
+        return "", fmt.Errorf("Could not decode location of %s: %w", host, err)
+    }
+
+    // Return
+    return location.CountryCode, nil
+
+}
@@ -0,0 +1,58 @@
+package net
+
+import (
+    "fmt"
+    "io/ioutil"
+    "net"
+    "net/http"
+    "strings"
+    "time"
+)
+
+
+// Public IP lookup config
+const (
+    PublicIPURL = "https://api.ipify.org"
+    PublicIPTimeout = 5 * time.Second
+)
+
+
+// Get the host's public IP address
+func GetPublicIP() (string, error) {
+
+    // Request
+    client := http.Client{Timeout: PublicIPTimeout}
+    response, err := client.Get(PublicIPURL)
+    if err != nil {
+        return "", fmt.Errorf("Could not look up public IP address: %w", err)
+    }
+    defer response.Body.Close()
+
+    // Get response
+    body, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+        return "", fmt.Errorf("Could not read public IP address: %w", err)
+    }
+
+    // Check address
+    address := strings.TrimSpace(string(body))
+    if net.ParseIP(address) == nil {
+        return "", fmt.Errorf("Invalid public IP address '%s'", address)
+    }
+
+    // Return
+    return address, nil
+
+}
+
+
+// Check whether a TCP port accepts connections at an address
+func CheckTCPPort(host string, port int, timeout time.Duration) bool {
+    conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, fmt.Sprintf("%d", port)), timeout)
+    if err != nil {
+        return false
+    }
+    conn.Close()
+    return true
+}
+
@@ -0,0 +1,41 @@
+package net
+
+import (
+    "fmt"
+    "net"
+    "os"
+    "path/filepath"
+    "syscall"
+)
+
+
+// Listen on a unix socket, accessible only to the owner of its folder (the user the smart node is installed for)
+func ListenUnix(socketPath string) (net.Listener, error) {
+
+    // Remove stale socket & listen
+    if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+        return nil, fmt.Errorf("Could not remove stale socket %s: %w", socketPath, err)
+    }
+    listener, err := net.Listen("unix", socketPath)
+    if err != nil {
+        return nil, fmt.Errorf("Could not listen on socket %s: %w", socketPath, err)
+    }
+
+    // Restrict socket access
+    if err := os.Chmod(socketPath, 0600); err != nil {
+        listener.Close()
+        return nil, fmt.Errorf("Could not set socket %s permissions: %w", socketPath, err)
+    }
+    if info, err := os.Stat(filepath.Dir(socketPath)); err == nil {
+        if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+            if err := os.Chown(socketPath, int(stat.Uid), int(stat.Gid)); err != nil {
+                listener.Close()
+                return nil, fmt.Errorf("Could not set socket %s owner: %w", socketPath, err)
+            }
+        }
+    }
+
+    // Return
+    return listener, nil
+
+}
@@ -0,0 +1,147 @@
+package retry
+
+import (
+    "context"
+    "errors"
+    "io"
+    "math/rand"
+    "net"
+    "os/exec"
+    "strings"
+    "syscall"
+    "time"
+
+    "golang.org/x/crypto/ssh"
+)
+
+
+// A retry policy
+// Delays grow from the initial delay by the multiplier up to the maximum delay, and are varied randomly by the jitter fraction
+type Policy struct {
+    Attempts int
+    InitialDelay time.Duration
+    MaxDelay time.Duration
+    Multiplier float64
+    Jitter float64
+}
+
+
+// Default policy: 4 attempts over about 3.5 seconds
+var DefaultPolicy = Policy{
+    Attempts: 4,
+    InitialDelay: 500 * time.Millisecond,
+    MaxDelay: 10 * time.Second,
+    Multiplier: 2,
+    Jitter: 0.2,
+}
+
+
+// Messages of transient network errors which have lost their type, e.g. when formatted into another error
+var retryableMessages = []string{
+    "connection refused",
+    "connection reset",
+    "broken pipe",
+    "i/o timeout",
+    "no route to host",
+    "network is unreachable",
+    "unexpected eof",
+    "tls handshake timeout",
+    "too many requests",
+    "502 bad gateway",
+    "503 service unavailable",
+    "504 gateway timeout",
+}
+
+
+// An error which should not be retried
+type permanentError struct {
+    err error
+}
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+
+// Mark an error as fatal, so that it is not retried regardless of its type
+// Used for errors after which an operation may have taken effect, e.g. when a connection is lost after a request is sent
+func Permanent(err error) error {
+    if err == nil {
+        return nil
+    }
+    return &permanentError{err: err}
+}
+
+
+// Run an operation, retrying it with jittered exponential backoff while it fails with retryable errors
+// Returns the last error, or the context's error if it is cancelled while waiting to retry
+func Do(ctx context.Context, policy Policy, operation func() error) error {
+    delay := policy.InitialDelay
+    for attempt := 1; ; attempt++ {
+
+        // Run operation
+        err := operation()
+        if err == nil {
+            return nil
+        }
+        var permanentErr *permanentError
+        if errors.As(err, &permanentErr) {
+            return permanentErr.err
+        }
+        if attempt >= policy.Attempts || !IsRetryable(err) {
+            return err
+        }
+
+        // Wait to retry
+        wait := delay
+        if policy.Jitter > 0 {
+            wait = time.Duration(float64(wait) * (1 + policy.Jitter * (2 * rand.Float64() - 1)))
+        }
+        select {
+            case <-time.After(wait):
+            case <-ctx.Done():
+                return err
+        }
+        delay = time.Duration(float64(delay) * policy.Multiplier)
+        if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+            delay = policy.MaxDelay
+        }
+
+    }
+}
+
+
+// Check whether an error is a transient network failure which may succeed if retried
+// Cancellations, command exit statuses, JSON-RPC errors and other errors (e.g. validation failures) are fatal
+func IsRetryable(err error) bool {
+
+    // Check fatal errors
+    if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+        return false
+    }
+    var permanentErr *permanentError
+    var exitErr *exec.ExitError
+    var sshExitErr *ssh.ExitError
+    var rpcErr interface{ ErrorCode() int }
+    if errors.As(err, &permanentErr) || errors.As(err, &exitErr) || errors.As(err, &sshExitErr) || errors.As(err, &rpcErr) {
+        return false
+    }
+
+    // Check network errors
+    var netErr net.Error
+    var opErr *net.OpError
+    var sshExitMissingErr *ssh.ExitMissingError
+    if errors.As(err, &netErr) || errors.As(err, &opErr) || errors.As(err, &sshExitMissingErr) {
+        return true
+    }
+    if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+        return true
+    }
+    message := strings.ToLower(err.Error())
+    for _, retryableMessage := range retryableMessages {
+        if strings.Contains(message, retryableMessage) {
+            return true
+        }
+    }
+    return false
+
+}
+
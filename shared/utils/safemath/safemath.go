@@ -0,0 +1,146 @@
+package safemath
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+)
+
+
+// Errors
+var (
+    ErrNilOperand = errors.New("Nil big.Int operand")
+    ErrDivisionByZero = errors.New("Division by zero")
+    ErrNegativeResult = errors.New("Arithmetic result is negative")
+    ErrOverflow = errors.New("Arithmetic result exceeds 256 bits")
+)
+
+
+// The maximum value of an unsigned 256-bit integer, as used by contract balances
+var MaxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+
+// Check that a value is a valid unsigned 256-bit integer
+func check(value *big.Int) (*big.Int, error) {
+    if value.Sign() < 0 {
+        return nil, ErrNegativeResult
+    }
+    if value.Cmp(MaxUint256) > 0 {
+        return nil, ErrOverflow
+    }
+    return value, nil
+}
+
+
+// Check that operands are not nil
+func checkOperands(operands ...*big.Int) error {
+    for _, operand := range operands {
+        if operand == nil {
+            return ErrNilOperand
+        }
+    }
+    return nil
+}
+
+
+// Return a + b
+func Add(a, b *big.Int) (*big.Int, error) {
+    if err := checkOperands(a, b); err != nil {
+        return nil, err
+    }
+    return check(new(big.Int).Add(a, b))
+}
+
+
+// Return the sum of a set of values
+func Sum(values ...*big.Int) (*big.Int, error) {
+    sum := big.NewInt(0)
+    for _, value := range values {
+        var err error
+        if sum, err = Add(sum, value); err != nil {
+            return nil, err
+        }
+    }
+    return sum, nil
+}
+
+
+// Return a - b; fails if b is greater than a
+func Sub(a, b *big.Int) (*big.Int, error) {
+    if err := checkOperands(a, b); err != nil {
+        return nil, err
+    }
+    return check(new(big.Int).Sub(a, b))
+}
+
+
+// Return a * b
+func Mul(a, b *big.Int) (*big.Int, error) {
+    if err := checkOperands(a, b); err != nil {
+        return nil, err
+    }
+    return check(new(big.Int).Mul(a, b))
+}
+
+
+// Return a / b, rounded down
+func Div(a, b *big.Int) (*big.Int, error) {
+    if err := checkOperands(a, b); err != nil {
+        return nil, err
+    }
+    if b.Sign() == 0 {
+        return nil, ErrDivisionByZero
+    }
+    return check(new(big.Int).Quo(a, b))
+}
+
+
+// Return a * b / c, rounded down
+// The intermediate product may exceed 256 bits as long as the result does not
+func MulDiv(a, b, c *big.Int) (*big.Int, error) {
+    if err := checkOperands(a, b, c); err != nil {
+        return nil, err
+    }
+    if c.Sign() == 0 {
+        return nil, ErrDivisionByZero
+    }
+    return check(new(big.Int).Quo(new(big.Int).Mul(a, b), c))
+}
+
+
+// Return a percentage of an amount, rounded down
+func Percent(amount *big.Int, percent uint64) (*big.Int, error) {
+    return MulDiv(amount, new(big.Int).SetUint64(percent), big.NewInt(100))
+}
+
+
+// Return the percentage one amount is of another
+func PercentOf(part, total *big.Int) (float64, error) {
+    if err := checkOperands(part, total); err != nil {
+        return 0, err
+    }
+    if total.Sign() == 0 {
+        return 0, ErrDivisionByZero
+    }
+    percent, _ := new(big.Float).Quo(new(big.Float).SetInt(new(big.Int).Mul(part, big.NewInt(100))), new(big.Float).SetInt(total)).Float64()
+    return percent, nil
+}
+
+
+// Linearly interpolate between a start and end value, at a position within a length
+// Returns start + (end - start) * position / length, which may decrease if end is less than start
+func Interpolate(start, end *big.Int, position, length uint64) (*big.Int, error) {
+    if err := checkOperands(start, end); err != nil {
+        return nil, err
+    }
+    if length == 0 {
+        return nil, ErrDivisionByZero
+    }
+    if position > length {
+        return nil, fmt.Errorf("Interpolation position %d is outside of length %d", position, length)
+    }
+    delta := new(big.Int).Sub(end, start)
+    delta.Mul(delta, new(big.Int).SetUint64(position))
+    delta.Quo(delta, new(big.Int).SetUint64(length))
+    return check(delta.Add(delta, start))
+}
@@ -0,0 +1,164 @@
+package safemath
+
+import (
+    "errors"
+    "math/big"
+    "math/rand"
+    "reflect"
+    "testing"
+    "testing/quick"
+)
+
+
+// A random unsigned 256-bit integer
+type uint256 struct {
+    *big.Int
+}
+func (uint256) Generate(r *rand.Rand, size int) reflect.Value {
+    bytes := make([]byte, 1 + r.Intn(32))
+    r.Read(bytes)
+    return reflect.ValueOf(uint256{new(big.Int).SetBytes(bytes)})
+}
+
+
+// Property check config
+var quickConfig = &quick.Config{MaxCount: 1000}
+
+
+// Adding then subtracting a value returns the original value, unless the sum overflows
+func TestAddSubRoundTrip(t *testing.T) {
+    property := func(a, b uint256) bool {
+        sum, err := Add(a.Int, b.Int)
+        if new(big.Int).Add(a.Int, b.Int).Cmp(MaxUint256) > 0 {
+            return errors.Is(err, ErrOverflow)
+        }
+        if err != nil {
+            return false
+        }
+        diff, err := Sub(sum, b.Int)
+        return err == nil && diff.Cmp(a.Int) == 0
+    }
+    if err := quick.Check(property, quickConfig); err != nil {
+        t.Error(err)
+    }
+}
+
+
+// Subtracting a larger value fails with a negative result error
+func TestSubNegative(t *testing.T) {
+    property := func(a, b uint256) bool {
+        diff, err := Sub(a.Int, b.Int)
+        if a.Cmp(b.Int) < 0 {
+            return diff == nil && errors.Is(err, ErrNegativeResult)
+        }
+        return err == nil && diff.Cmp(new(big.Int).Sub(a.Int, b.Int)) == 0
+    }
+    if err := quick.Check(property, quickConfig); err != nil {
+        t.Error(err)
+    }
+}
+
+
+// MulDiv matches big.Int arithmetic when the result is in range, and fails with an overflow error otherwise
+func TestMulDiv(t *testing.T) {
+    property := func(a, b, c uint256) bool {
+        result, err := MulDiv(a.Int, b.Int, c.Int)
+        if c.Sign() == 0 {
+            return errors.Is(err, ErrDivisionByZero)
+        }
+        expected := new(big.Int).Quo(new(big.Int).Mul(a.Int, b.Int), c.Int)
+        if expected.Cmp(MaxUint256) > 0 {
+            return result == nil && errors.Is(err, ErrOverflow)
+        }
+        return err == nil && result.Cmp(expected) == 0
+    }
+    if err := quick.Check(property, quickConfig); err != nil {
+        t.Error(err)
+    }
+}
+
+
+// MulDiv fails with an overflow error for results just above the 256-bit range, and succeeds at the maximum
+func TestMulDivBounds(t *testing.T) {
+    one := big.NewInt(1)
+    two := big.NewInt(2)
+    if result, err := MulDiv(MaxUint256, one, one); err != nil || result.Cmp(MaxUint256) != 0 {
+        t.Errorf("MulDiv(max, 1, 1) = %v, %v; expected max", result, err)
+    }
+    if result, err := MulDiv(MaxUint256, two, two); err != nil || result.Cmp(MaxUint256) != 0 {
+        t.Errorf("MulDiv(max, 2, 2) = %v, %v; expected max", result, err)
+    }
+    if _, err := MulDiv(MaxUint256, two, one); !errors.Is(err, ErrOverflow) {
+        t.Errorf("MulDiv(max, 2, 1) error = %v; expected overflow", err)
+    }
+    if _, err := Add(MaxUint256, one); !errors.Is(err, ErrOverflow) {
+        t.Errorf("Add(max, 1) error = %v; expected overflow", err)
+    }
+}
+
+
+// Division by zero is reported
+func TestDivisionByZero(t *testing.T) {
+    property := func(a, b uint256) bool {
+        if _, err := Div(a.Int, big.NewInt(0)); !errors.Is(err, ErrDivisionByZero) {
+            return false
+        }
+        if _, err := Interpolate(a.Int, b.Int, 0, 0); !errors.Is(err, ErrDivisionByZero) {
+            return false
+        }
+        if _, err := PercentOf(a.Int, big.NewInt(0)); !errors.Is(err, ErrDivisionByZero) {
+            return false
+        }
+        return true
+    }
+    if err := quick.Check(property, quickConfig); err != nil {
+        t.Error(err)
+    }
+}
+
+
+// Interpolated values are between the start and end values, and equal them at the ends of the length
+func TestInterpolateBounds(t *testing.T) {
+    property := func(start, end uint256, position, length uint64) bool {
+        if position > length {
+            position, length = length, position
+        }
+        if length == 0 {
+            length = 1
+        }
+        result, err := Interpolate(start.Int, end.Int, position, length)
+        if err != nil {
+            return false
+        }
+        low, high := start.Int, end.Int
+        if low.Cmp(high) > 0 {
+            low, high = high, low
+        }
+        if result.Cmp(low) < 0 || result.Cmp(high) > 0 {
+            return false
+        }
+        if position == 0 && result.Cmp(start.Int) != 0 {
+            return false
+        }
+        if position == length && result.Cmp(end.Int) != 0 {
+            return false
+        }
+        return true
+    }
+    if err := quick.Check(property, quickConfig); err != nil {
+        t.Error(err)
+    }
+}
+
+
+// Nil operands are reported
+func TestNilOperands(t *testing.T) {
+    one := big.NewInt(1)
+    if _, err := Add(nil, one); !errors.Is(err, ErrNilOperand) {
+        t.Errorf("Add(nil, 1) error = %v; expected nil operand", err)
+    }
+    if _, err := MulDiv(one, nil, one); !errors.Is(err, ErrNilOperand) {
+        t.Errorf("MulDiv(1, nil, 1) error = %v; expected nil operand", err)
+    }
+}
+
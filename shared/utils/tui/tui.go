@@ -0,0 +1,291 @@
+package tui
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "strings"
+
+    "golang.org/x/crypto/ssh/terminal"
+)
+
+
+// Terminal control sequences
+const (
+    enterAltScreen = "\x1b[?1049h"
+    exitAltScreen = "\x1b[?1049l"
+    hideCursor = "\x1b[?25l"
+    showCursor = "\x1b[?25h"
+    clearScreen = "\x1b[2J\x1b[H"
+    highlight = "\x1b[7m"
+    dim = "\x1b[2m"
+    red = "\x1b[31m"
+    reset = "\x1b[0m"
+)
+
+
+// Keys
+type key int
+const (
+    keyNone key = iota
+    keyUp
+    keyDown
+    keyEnter
+    keyBack
+    keyBackspace
+    keyQuit
+    keyChar
+)
+
+
+// A full-screen terminal UI, drawn on the terminal's alternate screen in raw mode
+type Screen struct {
+    fd int
+    state *terminal.State
+}
+
+
+// A menu item; the description of the highlighted item is shown below the menu
+type Item struct {
+    Label string
+    Value string
+    Description string
+}
+
+
+// Check whether a full-screen UI can be opened on stdin/stdout
+func IsAvailable() bool {
+    return terminal.IsTerminal(int(os.Stdin.Fd())) && terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+
+// Open a full-screen UI on stdin/stdout; fails if stdin is not a terminal
+func Open() (*Screen, error) {
+    fd := int(os.Stdin.Fd())
+    if !terminal.IsTerminal(fd) {
+        return nil, errors.New("Standard input is not a terminal")
+    }
+    state, err := terminal.MakeRaw(fd)
+    if err != nil {
+        return nil, fmt.Errorf("Could not set terminal to raw mode: %w", err)
+    }
+    fmt.Print(enterAltScreen + hideCursor)
+    return &Screen{
+        fd: fd,
+        state: state,
+    }, nil
+}
+
+
+// Close the UI and restore the terminal
+func (s *Screen) Close() {
+    fmt.Print(showCursor + exitAltScreen)
+    terminal.Restore(s.fd, s.state)
+}
+
+
+// Show a menu and wait for an item to be selected
+// Returns the selected item index, or false if the menu was exited with escape or q
+func (s *Screen) Menu(title string, items []Item, selected int) (int, bool) {
+    if selected < 0 || selected >= len(items) {
+        selected = 0
+    }
+    for {
+
+        // Draw menu
+        lines := []string{}
+        labelWidth := 0
+        for _, item := range items {
+            if len(item.Label) > labelWidth {
+                labelWidth = len(item.Label)
+            }
+        }
+        for ii, item := range items {
+            line := fmt.Sprintf("  %-*s  %s", labelWidth, item.Label, item.Value)
+            if ii == selected {
+                line = highlight + fmt.Sprintf("> %-*s  %s", labelWidth, item.Label, item.Value) + reset
+            }
+            lines = append(lines, line)
+        }
+        if len(items) > 0 && items[selected].Description != "" {
+            lines = append(lines, "")
+            for _, line := range s.wrap(items[selected].Description) {
+                lines = append(lines, dim + line + reset)
+            }
+        }
+        s.draw(title, lines, "up/down: move   enter: select   esc/q: back")
+
+        // Handle key
+        k, char := s.readKey()
+        switch {
+            case k == keyChar && char == "k": k = keyUp
+            case k == keyChar && char == "j": k = keyDown
+            case k == keyChar && char == "q": k = keyQuit
+        }
+        switch k {
+            case keyUp: if selected > 0 { selected-- }
+            case keyDown: if selected < len(items) - 1 { selected++ }
+            case keyEnter: if len(items) > 0 { return selected, true }
+            case keyBack, keyQuit: return selected, false
+        }
+
+    }
+}
+
+
+// Show a text field and wait for a valid value to be entered
+// Returns the entered value, or false if editing was cancelled with escape
+func (s *Screen) Edit(title, description, value string, validate func(string) error) (string, bool) {
+    var validationErr error
+    for {
+
+        // Draw field
+        lines := []string{}
+        for _, line := range s.wrap(description) {
+            lines = append(lines, dim + line + reset)
+        }
+        lines = append(lines, "", "> " + value + highlight + " " + reset)
+        if validationErr != nil {
+            lines = append(lines, "", red + validationErr.Error() + reset)
+        }
+        s.draw(title, lines, "enter: accept   esc: cancel")
+
+        // Handle key
+        k, char := s.readKey()
+        switch k {
+            case keyChar: value += char
+            case keyBackspace: if len(value) > 0 { value = value[:len(value) - 1] }
+            case keyEnter:
+                if validate != nil {
+                    if validationErr = validate(value); validationErr != nil {
+                        continue
+                    }
+                }
+                return value, true
+            case keyBack, keyQuit: return value, false
+        }
+
+    }
+}
+
+
+// Show scrollable text until it is closed
+func (s *Screen) View(title string, text []string) {
+    offset := 0
+    for {
+
+        // Draw visible text
+        _, height := s.size()
+        visible := height - 4
+        if visible < 1 {
+            visible = 1
+        }
+        end := offset + visible
+        if end > len(text) {
+            end = len(text)
+        }
+        s.draw(title, text[offset:end], "up/down: scroll   enter/esc/q: back")
+
+        // Handle key
+        k, char := s.readKey()
+        if k == keyChar && char == "q" {
+            return
+        }
+        switch k {
+            case keyUp: if offset > 0 { offset-- }
+            case keyDown: if end < len(text) { offset++ }
+            case keyEnter, keyBack, keyQuit: return
+        }
+
+    }
+}
+
+
+// Draw a screen with a title, body lines and a footer
+func (s *Screen) draw(title string, lines []string, footer string) {
+    var b strings.Builder
+    b.WriteString(clearScreen)
+    b.WriteString(highlight + " " + title + " " + reset + "\r\n\r\n")
+    for _, line := range lines {
+        b.WriteString(line + "\r\n")
+    }
+    b.WriteString("\r\n" + dim + footer + reset)
+    fmt.Print(b.String())
+}
+
+
+// Wrap text to the terminal width
+func (s *Screen) wrap(text string) []string {
+    width, _ := s.size()
+    lines := []string{}
+    for _, paragraph := range strings.Split(text, "\n") {
+        line := ""
+        for _, word := range strings.Fields(paragraph) {
+            if line != "" && len(line) + 1 + len(word) > width {
+                lines = append(lines, line)
+                line = ""
+            }
+            if line != "" {
+                line += " "
+            }
+            line += word
+        }
+        lines = append(lines, line)
+    }
+    return lines
+}
+
+
+// Get the terminal size, with a fallback if it is unavailable
+func (s *Screen) size() (int, int) {
+    width, height, err := terminal.GetSize(s.fd)
+    if err != nil || width <= 0 || height <= 0 {
+        return 80, 24
+    }
+    return width - 2, height
+}
+
+
+// Read a key press
+func (s *Screen) readKey() (key, string) {
+    buf := make([]byte, 256)
+    n, err := os.Stdin.Read(buf)
+    if err != nil || n == 0 {
+        return keyQuit, ""
+    }
+    return parseKey(buf[:n])
+}
+
+
+// Parse the input read for a key press
+// Escape sequences other than the up & down cursor keys are ignored rather than treated as text
+func parseKey(input []byte) (key, string) {
+
+    // Get control keys
+    switch {
+        case len(input) == 0: return keyNone, ""
+        case len(input) == 1 && input[0] == 0x1b: return keyBack, ""
+        case len(input) >= 3 && input[0] == 0x1b && (input[1] == '[' || input[1] == 'O'):
+            if len(input) == 3 && input[2] == 'A' { return keyUp, "" }
+            if len(input) == 3 && input[2] == 'B' { return keyDown, "" }
+            return keyNone, ""
+        case input[0] == 0x1b: return keyNone, ""
+        case input[0] == '\r' || input[0] == '\n': return keyEnter, ""
+        case input[0] == 0x7f || input[0] == 0x08: return keyBackspace, ""
+        case input[0] == 0x03: return keyQuit, ""
+    }
+
+    // Get printable characters; pasted text may be read in a single chunk
+    chars := ""
+    for _, char := range input {
+        if char >= 0x20 && char < 0x7f {
+            chars += string(char)
+        }
+    }
+    if chars != "" {
+        return keyChar, chars
+    }
+    return keyNone, ""
+
+}
+
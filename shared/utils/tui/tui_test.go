@@ -0,0 +1,45 @@
+package tui
+
+import (
+    "testing"
+)
+
+
+// Key presses are parsed from terminal input
+func TestParseKey(t *testing.T) {
+    tests := []struct {
+        name string
+        input string
+        key key
+        chars string
+    }{
+        {"empty", "", keyNone, ""},
+        {"up", "\x1b[A", keyUp, ""},
+        {"down", "\x1b[B", keyDown, ""},
+        {"up in application mode", "\x1bOA", keyUp, ""},
+        {"down in application mode", "\x1bOB", keyDown, ""},
+        {"right", "\x1b[C", keyNone, ""},
+        {"left", "\x1b[D", keyNone, ""},
+        {"delete", "\x1b[3~", keyNone, ""},
+        {"modified up", "\x1b[1;5A", keyNone, ""},
+        {"alt key", "\x1bx", keyNone, ""},
+        {"escape", "\x1b", keyBack, ""},
+        {"enter", "\r", keyEnter, ""},
+        {"newline", "\n", keyEnter, ""},
+        {"backspace", "\x7f", keyBackspace, ""},
+        {"ctrl-h", "\x08", keyBackspace, ""},
+        {"ctrl-c", "\x03", keyQuit, ""},
+        {"character", "q", keyChar, "q"},
+        {"pasted text", "http://localhost:8545", keyChar, "http://localhost:8545"},
+        {"pasted text with control characters", "abc\tdef\r", keyChar, "abcdef"},
+        {"non-ascii text", "\xc3\xa9", keyNone, ""},
+        {"other control key", "\x01", keyNone, ""},
+    }
+    for _, test := range tests {
+        k, chars := parseKey([]byte(test.input))
+        if k != test.key || chars != test.chars {
+            t.Errorf("%s: parseKey(%q) = %v, %q; expected %v, %q", test.name, test.input, k, chars, test.key, test.chars)
+        }
+    }
+}
+
@@ -0,0 +1,96 @@
+package units
+
+import (
+    "os"
+    "strings"
+    "sync"
+)
+
+
+// Number formatting locale
+type Locale struct {
+    DecimalSeparator string
+    GroupSeparator string
+}
+
+
+// Known locales by language
+var (
+    DefaultLocale = Locale{DecimalSeparator: ".", GroupSeparator: ","}
+    periodGroupLocale = Locale{DecimalSeparator: ",", GroupSeparator: "."}
+    spaceGroupLocale = Locale{DecimalSeparator: ",", GroupSeparator: " "}
+    swissLocale = Locale{DecimalSeparator: ".", GroupSeparator: "'"}
+)
+var languageLocales = map[string]Locale{
+    "da": periodGroupLocale,
+    "de": periodGroupLocale,
+    "el": periodGroupLocale,
+    "es": periodGroupLocale,
+    "id": periodGroupLocale,
+    "it": periodGroupLocale,
+    "nl": periodGroupLocale,
+    "pt": periodGroupLocale,
+    "tr": periodGroupLocale,
+    "cs": spaceGroupLocale,
+    "fi": spaceGroupLocale,
+    "fr": spaceGroupLocale,
+    "nb": spaceGroupLocale,
+    "pl": spaceGroupLocale,
+    "ru": spaceGroupLocale,
+    "sv": spaceGroupLocale,
+    "uk": spaceGroupLocale,
+}
+
+
+// Current locale
+var locale *Locale
+var localeLock sync.Mutex
+
+
+// Get the current number formatting locale, detected from the environment if not set
+func GetLocale() Locale {
+    localeLock.Lock()
+    defer localeLock.Unlock()
+    if locale == nil {
+        detected := detectLocale()
+        locale = &detected
+    }
+    return *locale
+}
+
+
+// Set the number formatting locale
+func SetLocale(l Locale) {
+    localeLock.Lock()
+    defer localeLock.Unlock()
+    locale = &l
+}
+
+
+// Detect the number formatting locale from the LC_ALL, LC_NUMERIC & LANG environment variables
+func detectLocale() Locale {
+    for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+        value := os.Getenv(name)
+        if value == "" {
+            continue
+        }
+        return parseLocale(value)
+    }
+    return DefaultLocale
+}
+
+
+// Parse a POSIX locale name (e.g. de_DE.UTF-8)
+func parseLocale(name string) Locale {
+    name = strings.SplitN(name, ".", 2)[0]
+    name = strings.SplitN(name, "@", 2)[0]
+    parts := strings.SplitN(name, "_", 2)
+    language := strings.ToLower(parts[0])
+    if len(parts) == 2 && language == "de" && strings.ToUpper(parts[1]) == "CH" {
+        return swissLocale
+    }
+    if l, ok := languageLocales[language]; ok {
+        return l
+    }
+    return DefaultLocale
+}
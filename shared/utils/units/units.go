@@ -0,0 +1,135 @@
+package units
+
+import (
+    "math/big"
+    "strings"
+)
+
+
+// Unit decimals
+const (
+    EthDecimals = 18
+    GweiDecimals = 9
+    TokenDecimals = 18
+)
+
+
+// Display precision
+const (
+    EthPrecision = 6
+    GweiPrecision = 2
+    MinPrecision = 2
+)
+
+
+// Default gas token symbol
+const DefaultGasToken = "ETH"
+
+
+// Format an ETH amount in wei for display
+func FormatEth(wei *big.Int) string {
+    return FormatToken(wei, TokenDecimals, "ETH")
+}
+
+
+// Format a gas price in wei for display in gwei
+func FormatGwei(wei *big.Int) string {
+    return FormatAmount(wei, GweiDecimals, GweiPrecision) + " gwei"
+}
+
+
+// Format a gas cost in wei for display in the network's gas token
+func FormatGasCost(wei *big.Int, gasToken string) string {
+    if gasToken == "" {
+        gasToken = DefaultGasToken
+    }
+    return FormatToken(wei, EthDecimals, gasToken)
+}
+
+
+// Format a token amount in its base units for display with its symbol
+func FormatToken(amount *big.Int, decimals uint, symbol string) string {
+    return FormatAmount(amount, decimals, EthPrecision) + " " + symbol
+}
+
+
+// Format an amount in base units as a decimal value in the current locale
+// Values are rounded half away from zero to the given precision, and trailing zeros are trimmed to MinPrecision
+func FormatAmount(amount *big.Int, decimals uint, precision uint) string {
+    if amount == nil {
+        amount = big.NewInt(0)
+    }
+    if precision > decimals {
+        precision = decimals
+    }
+
+    // Round to precision
+    negative := (amount.Sign() < 0)
+    value := new(big.Int).Abs(amount)
+    scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals - precision)), nil)
+    remainder := new(big.Int)
+    value.QuoRem(value, scale, remainder)
+    if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(scale) >= 0 {
+        value.Add(value, big.NewInt(1))
+    }
+
+    // Split into integer & fractional digits
+    digits := value.String()
+    if uint(len(digits)) <= precision {
+        digits = strings.Repeat("0", int(precision) - len(digits) + 1) + digits
+    }
+    integer := digits[:uint(len(digits)) - precision]
+    fraction := digits[uint(len(digits)) - precision:]
+
+    // Trim trailing zeros
+    minPrecision := MinPrecision
+    if int(precision) < minPrecision {
+        minPrecision = int(precision)
+    }
+    for len(fraction) > minPrecision && fraction[len(fraction) - 1] == '0' {
+        fraction = fraction[:len(fraction) - 1]
+    }
+
+    // Format
+    locale := GetLocale()
+    formatted := groupDigits(integer, locale.GroupSeparator)
+    if fraction != "" {
+        formatted += locale.DecimalSeparator + fraction
+    }
+    if negative && strings.Trim(integer + fraction, "0") != "" {
+        formatted = "-" + formatted
+    }
+    return formatted
+
+}
+
+
+// Insert group separators into an integer digit string
+func groupDigits(digits string, separator string) string {
+    if separator == "" || len(digits) <= 3 {
+        return digits
+    }
+    var builder strings.Builder
+    head := len(digits) % 3
+    if head > 0 {
+        builder.WriteString(digits[:head])
+    }
+    for i := head; i < len(digits); i += 3 {
+        if builder.Len() > 0 {
+            builder.WriteString(separator)
+        }
+        builder.WriteString(digits[i:i + 3])
+    }
+    return builder.String()
+}
+
+
+// Get the display symbol for a token name as accepted by the CLI (e.g. neth => nETH)
+func TokenSymbol(token string) string {
+    switch strings.ToLower(token) {
+        case "eth": return "ETH"
+        case "neth": return "nETH"
+        case "reth": return "rETH"
+    }
+    return strings.ToUpper(token)
+}
@@ -0,0 +1,51 @@
+package validator
+
+import (
+    "github.com/prysmaticlabs/go-ssz"
+    "github.com/rocket-pool/rocketpool-go/types"
+    eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+
+// Exit settings
+const ShardCommitteePeriod = 256 // Epochs a validator must be active for before it can exit
+
+
+// Voluntary exit message
+type VoluntaryExit struct {
+    Epoch uint64
+    ValidatorIndex uint64
+}
+
+
+// Get the earliest epoch a validator activated at an epoch can exit at
+func GetEarliestExitEpoch(activationEpoch uint64) uint64 {
+    return activationEpoch + ShardCommitteePeriod
+}
+
+
+// Get a voluntary exit message signature for a given validator key and index
+func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex uint64, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, error) {
+
+    // Get signing root
+    sr, err := ssz.HashTreeRoot(VoluntaryExit{
+        Epoch: epoch,
+        ValidatorIndex: validatorIndex,
+    })
+    if err != nil {
+        return types.ValidatorSignature{}, err
+    }
+
+    // Get signing root with domain
+    srWithDomain, err := ssz.HashTreeRoot(signingRoot{
+        ObjectRoot: sr[:],
+        Domain: signatureDomain,
+    })
+    if err != nil {
+        return types.ValidatorSignature{}, err
+    }
+
+    // Sign & return
+    return types.BytesToValidatorSignature(validatorKey.Sign(srWithDomain[:]).Marshal()), nil
+
+}
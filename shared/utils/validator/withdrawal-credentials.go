@@ -0,0 +1,90 @@
+package validator
+
+import (
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/network"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/types"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services/beacon"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Verify the beacon chain withdrawal credentials of a node's minipool validators
+// Expected credentials are loaded from the network contracts; minipools without validators are omitted
+func VerifyWithdrawalCredentials(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address) (common.Hash, []api.MinipoolCredentials, error) {
+
+    // Data
+    var wg1 errgroup.Group
+    var addresses []common.Address
+    var expected common.Hash
+
+    // Get node minipool addresses
+    wg1.Go(func() error {
+        var err error
+        addresses, err = minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+        return err
+    })
+
+    // Get expected withdrawal credentials
+    wg1.Go(func() error {
+        var err error
+        expected, err = network.GetWithdrawalCredentials(rp, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg1.Wait(); err != nil {
+        return common.Hash{}, []api.MinipoolCredentials{}, err
+    }
+
+    // Data
+    var wg2 errgroup.Group
+    credentials := make([]api.MinipoolCredentials, len(addresses))
+
+    // Load validator credentials
+    for mi, address := range addresses {
+        mi, address := mi, address
+        wg2.Go(func() error {
+            pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+            if err != nil {
+                return err
+            }
+            credentials[mi] = api.MinipoolCredentials{
+                Address: address,
+                ValidatorPubkey: pubkey,
+            }
+            if pubkey == (types.ValidatorPubkey{}) {
+                return nil
+            }
+            validator, err := bc.GetValidatorStatus(pubkey, nil)
+            if err != nil {
+                return err
+            }
+            credentials[mi].ValidatorExists = validator.Exists
+            credentials[mi].WithdrawalCredentials = validator.WithdrawalCredentials
+            credentials[mi].Match = validator.Exists && validator.WithdrawalCredentials == expected
+            return nil
+        })
+    }
+
+    // Wait for data
+    if err := wg2.Wait(); err != nil {
+        return common.Hash{}, []api.MinipoolCredentials{}, err
+    }
+
+    // Filter minipools without validators
+    validatorCredentials := []api.MinipoolCredentials{}
+    for _, mpCredentials := range credentials {
+        if mpCredentials.ValidatorPubkey != (types.ValidatorPubkey{}) {
+            validatorCredentials = append(validatorCredentials, mpCredentials)
+        }
+    }
+
+    // Return
+    return expected, validatorCredentials, nil
+
+}
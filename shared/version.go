@@ -0,0 +1,5 @@
+package shared
+
+
+// Smart node version
+const RocketPoolVersion = "0.0.1"